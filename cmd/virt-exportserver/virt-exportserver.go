@@ -20,19 +20,27 @@
 package main
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
+	exportv1 "kubevirt.io/api/export/v1alpha1"
 	"kubevirt.io/client-go/log"
 
 	"kubevirt.io/kubevirt/pkg/service"
+	"kubevirt.io/kubevirt/pkg/storage/export/s3"
 
 	exportServer "kubevirt.io/kubevirt/pkg/storage/export/virt-exportserver"
 )
 
 const (
 	listenAddr = ":8443"
+
+	// Keys of the destination credentials, the same as used by CDI for S3 sources
+	accessKeyIDKey = "accessKeyId"
+	secretKeyKey   = "secretKey"
 )
 
 func main() {
@@ -47,6 +55,11 @@ func main() {
 		ListenAddr: getListenAddr(),
 		TokenFile:  getTokenFile(),
 		Volumes:    getVolumeInfo(),
+
+		StatusListenAddr: os.Getenv("STATUS_LISTEN_ADDR"),
+		StatusTokenFile:  os.Getenv("STATUS_TOKEN_FILE"),
+		ChecksumsURI:     os.Getenv("CHECKSUMS_URI"),
+		Push:             getPushConfig(),
 	}
 	server := exportServer.NewExportServer(config)
 	service.Setup(server)
@@ -74,6 +87,51 @@ func getVolumeInfo() []exportServer.VolumeInfo {
 	return result
 }
 
+func getPushConfig() *exportServer.PushConfig {
+	endpoint := os.Getenv("DESTINATION_S3_ENDPOINT")
+	if endpoint == "" {
+		return nil
+	}
+
+	credentialsDir := os.Getenv("DESTINATION_CREDENTIALS_DIR")
+	accessKeyID, err := readCredential(credentialsDir, accessKeyIDKey)
+	if err != nil {
+		panic(err)
+	}
+	secretKey, err := readCredential(credentialsDir, secretKeyKey)
+	if err != nil {
+		panic(err)
+	}
+
+	client, err := s3.NewClient(s3.Config{
+		Endpoint:              endpoint,
+		Bucket:                os.Getenv("DESTINATION_S3_BUCKET"),
+		Region:                os.Getenv("DESTINATION_S3_REGION"),
+		AccessKeyID:           accessKeyID,
+		SecretKey:             secretKey,
+		InsecureSkipTLSVerify: os.Getenv("DESTINATION_S3_INSECURE_SKIP_TLS_VERIFY") == "true",
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return &exportServer.PushConfig{
+		Uploader:              client,
+		Prefix:                os.Getenv("DESTINATION_S3_PREFIX"),
+		Format:                exportv1.ExportVolumeFormat(os.Getenv("DESTINATION_FORMAT")),
+		CredentialsSecretName: os.Getenv("DESTINATION_CREDENTIALS_SECRET"),
+		StatusURI:             os.Getenv("PUSH_STATUS_URI"),
+	}
+}
+
+func readCredential(dir, key string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(dir, key))
+	if err != nil {
+		return "", fmt.Errorf("failed to read destination credentials: %v", err)
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
 func getTokenFile() string {
 	tokenFile := os.Getenv("TOKEN_FILE")
 	if tokenFile == "" {
@@ -173,6 +173,15 @@ func createIsoConfigImage(output string, volID string, files []string, size int6
 	return nil
 }
 
+// CreateIsoImage creates an iso image at isoPath containing all files found in sourceDir
+func CreateIsoImage(isoPath string, sourceDir string, volID string) error {
+	filesPath, err := getFilesLayout(sourceDir)
+	if err != nil {
+		return err
+	}
+	return createISOImage(isoPath, volID, filesPath)
+}
+
 func findIsoSize(vmi *v1.VirtualMachineInstance, volume *v1.Volume, emptyIso bool) (int64, error) {
 	if emptyIso {
 		for _, vs := range vmi.Status.VolumeStatus {
@@ -195,7 +204,8 @@ type volumeInfo interface {
 func createIsoDisksForConfigVolumes(vmi *v1.VirtualMachineInstance, emptyIso bool, info volumeInfo) error {
 	volumes := make(map[string]v1.Volume)
 	for _, volume := range vmi.Spec.Volumes {
-		if info.isValidType(&volume) {
+		// Hotplugged config volumes are attached as iso images built by virt-handler
+		if info.isValidType(&volume) && !util.IsHotplugVolume(&volume) {
 			volumes[volume.Name] = volume
 		}
 	}
@@ -58,6 +58,12 @@ const KernelBootVolumeName = KernelBootName + "-volume"
 
 const ephemeralStorageOverheadSize = "50M"
 
+// HotplugDiskName is the name under which a hotplugged containerDisk is served by its attachment pod
+const HotplugDiskName = "disk"
+
+// HotplugVolumeMountDir is the directory in which the attachment pod serves a hotplugged containerDisk
+const HotplugVolumeMountDir = "/var/run/kubevirt/hotplug-container-disk"
+
 var digestRegex = regexp.MustCompile(`sha256:([a-zA-Z0-9]+)`)
 
 func GetLegacyVolumeMountDirOnHost(vmi *v1.VirtualMachineInstance) string {
@@ -250,8 +256,7 @@ func generateKernelBootContainerHelper(vmi *v1.VirtualMachineInstance, config *v
 		},
 	}
 
-	const fakeVolumeIdx = 0 // volume index makes no difference for kernel-boot container
-	return generateContainerFromVolume(vmi, config, imageIDs, podVolumeName, binVolumeName, isInit, true, &kernelBootVolume, fakeVolumeIdx)
+	return generateContainerFromVolume(vmi, config, imageIDs, podVolumeName, binVolumeName, GetVolumeMountDirOnGuest(vmi), KernelBootName, isInit, &kernelBootVolume)
 }
 
 // The controller uses this function to generate the container
@@ -264,19 +269,29 @@ func generateContainersHelper(vmi *v1.VirtualMachineInstance, config *virtconfig
 		if volume.Name == KernelBootVolumeName {
 			continue
 		}
-		if container := generateContainerFromVolume(vmi, config, imageIDs, podVolumeName, binVolumeName, isInit, false, &volume, index); container != nil {
+		// Hotplugged containerDisks are served by the attachment pod
+		if volume.ContainerDisk != nil && volume.ContainerDisk.Hotpluggable {
+			continue
+		}
+		mountedDiskName := "disk_" + strconv.Itoa(index)
+		if container := generateContainerFromVolume(vmi, config, imageIDs, podVolumeName, binVolumeName, GetVolumeMountDirOnGuest(vmi), mountedDiskName, isInit, &volume); container != nil {
 			containers = append(containers, *container)
 		}
 	}
 	return containers
 }
 
-func generateContainerFromVolume(vmi *v1.VirtualMachineInstance, config *virtconfig.ClusterConfig, imageIDs map[string]string, podVolumeName, binVolumeName string, isInit, isKernelBoot bool, volume *v1.Volume, volumeIdx int) *kubev1.Container {
+// GenerateHotplugContainer generates the container spec serving a hotplugged containerDisk from its attachment pod.
+// The container exposes the disk through a socket named after HotplugDiskName on the pod volume podVolumeName.
+func GenerateHotplugContainer(vmi *v1.VirtualMachineInstance, config *virtconfig.ClusterConfig, podVolumeName string, binVolumeName string, volume *v1.Volume) *kubev1.Container {
+	return generateContainerFromVolume(vmi, config, nil, podVolumeName, binVolumeName, HotplugVolumeMountDir, HotplugDiskName, false, volume)
+}
+
+func generateContainerFromVolume(vmi *v1.VirtualMachineInstance, config *virtconfig.ClusterConfig, imageIDs map[string]string, podVolumeName, binVolumeName, volumeMountDir, mountedDiskName string, isInit bool, volume *v1.Volume) *kubev1.Container {
 	if volume.ContainerDisk == nil {
 		return nil
 	}
 
-	volumeMountDir := GetVolumeMountDirOnGuest(vmi)
 	diskContainerName := toContainerName(volume.Name)
 	diskContainerImage := volume.ContainerDisk.Image
 	if img, exists := imageIDs[volume.Name]; exists {
@@ -306,13 +321,6 @@ func generateContainerFromVolume(vmi *v1.VirtualMachineInstance, config *virtcon
 		resources.Limits[kubev1.ResourceMemory] = *memLimit
 	}
 
-	var mountedDiskName string
-	if isKernelBoot {
-		mountedDiskName = KernelBootName
-	} else {
-		mountedDiskName = "disk_" + strconv.Itoa(volumeIdx)
-	}
-
 	if vmi.IsCPUDedicated() || vmi.WantsToHaveQOSGuaranteed() {
 		resources.Requests[kubev1.ResourceCPU] = resources.Limits[kubev1.ResourceCPU]
 		resources.Requests[kubev1.ResourceMemory] = resources.Limits[kubev1.ResourceMemory]
@@ -374,7 +382,7 @@ func CreateEphemeralImages(
 	// for each disk that requires it.
 
 	for i, volume := range vmi.Spec.Volumes {
-		if volume.VolumeSource.ContainerDisk != nil {
+		if volume.VolumeSource.ContainerDisk != nil && !volume.VolumeSource.ContainerDisk.Hotpluggable {
 			info, _ := disksInfo[volume.Name]
 			if info == nil {
 				return fmt.Errorf("no disk info provided for volume %s", volume.Name)
@@ -400,7 +408,7 @@ func getContainerDiskSocketBasePath(baseDir, podUID string) string {
 func ExtractImageIDsFromSourcePod(vmi *v1.VirtualMachineInstance, sourcePod *kubev1.Pod) (imageIDs map[string]string, err error) {
 	imageIDs = map[string]string{}
 	for _, volume := range vmi.Spec.Volumes {
-		if volume.ContainerDisk == nil {
+		if volume.ContainerDisk == nil || volume.ContainerDisk.Hotpluggable {
 			continue
 		}
 		imageIDs[volume.Name] = volume.ContainerDisk.Image
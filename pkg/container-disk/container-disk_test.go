@@ -302,6 +302,37 @@ var _ = Describe("ContainerDisk", func() {
 				Expect(containers[1].ImagePullPolicy).To(Equal(k8sv1.PullAlways))
 			})
 
+			It("by verifying that hotpluggable containerDisks are skipped", func() {
+				clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+					SupportContainerResources: []v1.SupportContainerResources{},
+				})
+				vmi := api.NewMinimalVMI("fake-vmi")
+				appendContainerDisk(vmi, "r0")
+				appendContainerDisk(vmi, "hotplug")
+				vmi.Spec.Volumes[1].ContainerDisk.Hotpluggable = true
+				containers := GenerateContainers(vmi, clusterConfig, nil, "libvirt-runtime", "bin-volume")
+
+				Expect(containers).To(HaveLen(1))
+				Expect(containers[0].Name).To(Equal("volumer0"))
+			})
+
+			It("by verifying hotplug container generation", func() {
+				clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+					SupportContainerResources: []v1.SupportContainerResources{},
+				})
+				vmi := api.NewMinimalVMI("fake-vmi")
+				appendContainerDisk(vmi, "hotplug")
+				vmi.Spec.Volumes[0].ContainerDisk.Hotpluggable = true
+				container := GenerateHotplugContainer(vmi, clusterConfig, "hotplug", "bin-volume", &vmi.Spec.Volumes[0])
+
+				Expect(container).ToNot(BeNil())
+				Expect(container.Args).To(Equal([]string{"--copy-path", HotplugVolumeMountDir + "/" + HotplugDiskName}))
+				Expect(container.VolumeMounts).To(ConsistOf(
+					k8sv1.VolumeMount{Name: "hotplug", MountPath: HotplugVolumeMountDir},
+					k8sv1.VolumeMount{Name: "bin-volume", MountPath: "/usr/bin"},
+				))
+			})
+
 			Context("which checks socket paths", func() {
 
 				var vmi *v1.VirtualMachineInstance
@@ -0,0 +1,81 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package signature
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type dockerAuth struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Auth     string `json:"auth,omitempty"`
+}
+
+type dockerConfig struct {
+	Auths map[string]dockerAuth `json:"auths"`
+}
+
+// credentialsFor looks up the credentials of the registry domain in the content of a
+// .dockerconfigjson or a legacy .dockercfg pull secret
+func credentialsFor(config []byte, domain string) (username, password string, err error) {
+	if len(config) == 0 {
+		return "", "", nil
+	}
+	auths := map[string]dockerAuth{}
+	cfg := &dockerConfig{}
+	if err := json.Unmarshal(config, cfg); err == nil && cfg.Auths != nil {
+		auths = cfg.Auths
+	} else if err := json.Unmarshal(config, &auths); err != nil {
+		return "", "", fmt.Errorf("failed to parse the docker config: %v", err)
+	}
+
+	for key, auth := range auths {
+		if !matchesDomain(key, domain) {
+			continue
+		}
+		if auth.Username != "" || auth.Password != "" {
+			return auth.Username, auth.Password, nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to decode the auth of %s: %v", key, err)
+		}
+		username, password, found := strings.Cut(string(decoded), ":")
+		if !found {
+			return "", "", fmt.Errorf("invalid auth of %s", key)
+		}
+		return username, password, nil
+	}
+	return "", "", nil
+}
+
+// matchesDomain matches docker config keys like "https://index.docker.io/v1/" or "quay.io"
+func matchesDomain(key, domain string) bool {
+	key = strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+	key, _, _ = strings.Cut(key, "/")
+	if domain == dockerHubDomain {
+		return key == dockerHubDomain || key == dockerHubIndex || key == dockerHubRegistry
+	}
+	return key == domain
+}
@@ -0,0 +1,100 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package signature
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	dockerHubDomain    = "docker.io"
+	dockerHubRegistry  = "registry-1.docker.io"
+	dockerHubIndex     = "index.docker.io"
+	dockerOfficialRepo = "library/"
+	defaultTag         = "latest"
+)
+
+// reference is a parsed container image reference
+type reference struct {
+	// name is the image as given, without tag and digest
+	name string
+	// domain is the registry domain, used to look up credentials
+	domain string
+	// repository is the repository path in the registry
+	repository string
+	tag        string
+	digest     string
+}
+
+func parseReference(image string) (*reference, error) {
+	if image == "" {
+		return nil, fmt.Errorf("empty image reference")
+	}
+	ref := &reference{}
+
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.digest = name[i+1:]
+		name = name[:i]
+		if !strings.HasPrefix(ref.digest, "sha256:") || len(ref.digest) != len("sha256:")+64 {
+			return nil, fmt.Errorf("unsupported digest %q in image %s", ref.digest, image)
+		}
+	}
+	if i := strings.LastIndex(name, ":"); i >= 0 && !strings.Contains(name[i:], "/") {
+		ref.tag = name[i+1:]
+		name = name[:i]
+	}
+	if ref.tag == "" && ref.digest == "" {
+		ref.tag = defaultTag
+	}
+	ref.name = name
+
+	ref.domain, ref.repository = dockerHubDomain, name
+	if i := strings.Index(name, "/"); i >= 0 {
+		domain := name[:i]
+		if strings.ContainsAny(domain, ".:") || domain == "localhost" {
+			ref.domain, ref.repository = domain, name[i+1:]
+		}
+	}
+	if ref.domain == dockerHubIndex {
+		ref.domain = dockerHubDomain
+	}
+	if ref.domain == dockerHubDomain && !strings.Contains(ref.repository, "/") {
+		ref.repository = dockerOfficialRepo + ref.repository
+	}
+	if ref.repository == "" || ref.repository != strings.ToLower(ref.repository) {
+		return nil, fmt.Errorf("invalid repository in image %s", image)
+	}
+	return ref, nil
+}
+
+// registry returns the host serving the registry API
+func (r *reference) registry() string {
+	if r.domain == dockerHubDomain {
+		return dockerHubRegistry
+	}
+	return r.domain
+}
+
+// withDigest returns the image pinned to the given digest
+func (r *reference) withDigest(digest string) string {
+	return r.name + "@" + digest
+}
@@ -0,0 +1,68 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package signature
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+var _ = Describe("Image reference", func() {
+	DescribeTable("should be parsed", func(image, domain, repository, tag, digest string) {
+		ref, err := parseReference(image)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ref.domain).To(Equal(domain))
+		Expect(ref.repository).To(Equal(repository))
+		Expect(ref.tag).To(Equal(tag))
+		Expect(ref.digest).To(Equal(digest))
+	},
+		Entry("of an official docker hub image", "fedora", "docker.io", "library/fedora", "latest", ""),
+		Entry("of a docker hub image with a tag", "kubevirt/fedora:39", "docker.io", "kubevirt/fedora", "39", ""),
+		Entry("of a docker hub image with its index domain", "index.docker.io/kubevirt/fedora", "docker.io", "kubevirt/fedora", "latest", ""),
+		Entry("of an image with a digest", "quay.io/kubevirt/fedora@"+testDigest, "quay.io", "kubevirt/fedora", "", testDigest),
+		Entry("of an image with a tag and a digest", "quay.io/kubevirt/fedora:39@"+testDigest, "quay.io", "kubevirt/fedora", "39", testDigest),
+		Entry("of an image in a registry with a port", "registry:5000/disks/fedora:39", "registry:5000", "disks/fedora", "39", ""),
+		Entry("of an image in localhost", "localhost/fedora", "localhost", "fedora", "latest", ""),
+	)
+
+	DescribeTable("should be rejected", func(image string) {
+		_, err := parseReference(image)
+		Expect(err).To(HaveOccurred())
+	},
+		Entry("when empty", ""),
+		Entry("with an unsupported digest", "quay.io/kubevirt/fedora@sha512:1234"),
+		Entry("with an uppercase repository", "quay.io/KubeVirt/fedora"),
+	)
+
+	It("should be pinned to a digest", func() {
+		ref, err := parseReference("quay.io/kubevirt/fedora:39")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ref.withDigest(testDigest)).To(Equal("quay.io/kubevirt/fedora@" + testDigest))
+		Expect(ref.registry()).To(Equal("quay.io"))
+	})
+
+	It("should use the docker hub registry for docker hub images", func() {
+		ref, err := parseReference("fedora")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ref.registry()).To(Equal("registry-1.docker.io"))
+	})
+})
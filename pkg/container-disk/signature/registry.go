@@ -0,0 +1,240 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package signature
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	maxManifestSize = 4 * 1024 * 1024
+	maxPayloadSize  = 1024 * 1024
+)
+
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+var errNotFound = fmt.Errorf("not found")
+
+// registryClient is a minimal client of the registry v2 API, supporting anonymous,
+// basic and bearer token authentication
+type registryClient struct {
+	httpClient *http.Client
+	ref        *reference
+	username   string
+	password   string
+	token      string
+}
+
+func (r *registryClient) url(kind, name string) string {
+	return fmt.Sprintf("https://%s/v2/%s/%s/%s", r.ref.registry(), r.ref.repository, kind, name)
+}
+
+func (r *registryClient) do(ctx context.Context, method, url string, accept []string) (*http.Response, error) {
+	resp, err := r.send(ctx, method, url, accept)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
+	}
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+	if err := r.authenticate(ctx, challenge); err != nil {
+		return nil, err
+	}
+	return r.send(ctx, method, url, accept)
+}
+
+func (r *registryClient) send(ctx context.Context, method, url string, accept []string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(accept) > 0 {
+		req.Header.Set("Accept", strings.Join(accept, ", "))
+	}
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	} else if r.username != "" {
+		req.SetBasicAuth(r.username, r.password)
+	}
+	return r.httpClient.Do(req)
+}
+
+func (r *registryClient) authenticate(ctx context.Context, challenge string) error {
+	scheme, params := parseChallenge(challenge)
+	switch scheme {
+	case "basic":
+		// Known credentials are sent with every request, a basic challenge can't be answered
+		if r.username == "" {
+			return fmt.Errorf("registry %s requires credentials", r.ref.registry())
+		}
+		return fmt.Errorf("registry %s rejected the credentials", r.ref.registry())
+	case "bearer":
+	default:
+		return fmt.Errorf("unsupported authentication challenge %q from registry %s", challenge, r.ref.registry())
+	}
+	if r.token != "" {
+		return fmt.Errorf("registry %s rejected the token", r.ref.registry())
+	}
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Host == "" {
+		return fmt.Errorf("invalid token realm %q from registry %s", params["realm"], r.ref.registry())
+	}
+	query := realm.Query()
+	if service, exists := params["service"]; exists {
+		query.Set("service", service)
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull", r.ref.repository))
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	if r.username != "" {
+		req.SetBasicAuth(r.username, r.password)
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get a token for %s: %s", r.ref.repository, resp.Status)
+	}
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxPayloadSize)).Decode(&token); err != nil {
+		return fmt.Errorf("failed to decode the token for %s: %v", r.ref.repository, err)
+	}
+	r.token = token.Token
+	if r.token == "" {
+		r.token = token.AccessToken
+	}
+	if r.token == "" {
+		return fmt.Errorf("registry %s returned an empty token", r.ref.registry())
+	}
+	return nil
+}
+
+// parseChallenge parses a WWW-Authenticate header like `Bearer realm="...",service="..."`
+func parseChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+	for rest != "" {
+		var param string
+		rest = strings.TrimLeft(rest, " ,")
+		key, value, found := strings.Cut(rest, "=")
+		if !found {
+			break
+		}
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				break
+			}
+			param, rest = value[1:end+1], value[end+2:]
+		} else {
+			param, rest, _ = strings.Cut(value, ",")
+		}
+		params[strings.ToLower(strings.TrimSpace(key))] = param
+	}
+	return strings.ToLower(scheme), params
+}
+
+// resolveDigest returns the digest of the manifest the tag points to
+func (r *registryClient) resolveDigest(ctx context.Context, tag string) (string, error) {
+	resp, err := r.do(ctx, http.MethodHead, r.url("manifests", tag), manifestMediaTypes)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		if digest := resp.Header.Get("Docker-Content-Digest"); strings.HasPrefix(digest, "sha256:") {
+			return digest, nil
+		}
+	}
+	manifest, err := r.manifest(ctx, tag)
+	if err != nil {
+		return "", err
+	}
+	return sha256Digest(manifest), nil
+}
+
+func (r *registryClient) manifest(ctx context.Context, reference string) ([]byte, error) {
+	return r.get(ctx, r.url("manifests", reference), manifestMediaTypes, maxManifestSize)
+}
+
+// blob returns the content of a blob, after checking it against its digest
+func (r *registryClient) blob(ctx context.Context, digest string, maxSize int64) ([]byte, error) {
+	content, err := r.get(ctx, r.url("blobs", digest), nil, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	if sha256Digest(content) != digest {
+		return nil, fmt.Errorf("content of blob %s does not match its digest", digest)
+	}
+	return content, nil
+}
+
+func (r *registryClient) get(ctx context.Context, url string, accept []string, maxSize int64) ([]byte, error) {
+	resp, err := r.do(ctx, http.MethodGet, url, accept)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, errNotFound
+	default:
+		return nil, fmt.Errorf("unexpected response from registry %s: %s", r.ref.registry(), resp.Status)
+	}
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > maxSize {
+		return nil, fmt.Errorf("response from registry %s exceeds %d bytes", r.ref.registry(), maxSize)
+	}
+	return content, nil
+}
+
+func sha256Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
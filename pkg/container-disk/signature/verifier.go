@@ -0,0 +1,245 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+// Package signature verifies cosign signatures of container images against local public keys,
+// without depending on a transparency log or any other online service than the image registry.
+package signature
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+const (
+	// SimpleSigningMediaType is the media type of the layers of a cosign signature manifest
+	SimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	// SignatureAnnotation holds the base64 encoded signature of a layer payload
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// CosignSignatureType is the type of the signed payloads
+	CosignSignatureType = "cosign container image signature"
+
+	signatureTagSuffix = ".sig"
+
+	// a digest can't change its content, only the public keys decide whether its signature is valid
+	verifiedCacheSize = 1024
+	verifiedCacheTTL  = 10 * time.Minute
+)
+
+// ErrNoSignature is returned for images without any cosign signature
+var ErrNoSignature = errors.New("image has no signature")
+
+// VerifyOptions hold what is needed to verify the signature of an image
+type VerifyOptions struct {
+	// PublicKeys of which one must have signed the image
+	PublicKeys []crypto.PublicKey
+	// DockerConfig is the content of the pull secret of the image, if any
+	DockerConfig []byte
+}
+
+// Verifier verifies the signature of images
+type Verifier interface {
+	// Verify checks that the image carries a signature of one of the public keys
+	// and returns the image pinned to the verified digest
+	Verify(ctx context.Context, image string, opts VerifyOptions) (string, error)
+}
+
+type verifier struct {
+	httpClient *http.Client
+	// verified holds the digests whose signature was verified, keyed by verifiedKey
+	verified *cache.LRUExpireCache
+}
+
+func NewVerifier(httpClient *http.Client) Verifier {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &verifier{httpClient: httpClient, verified: cache.NewLRUExpireCache(verifiedCacheSize)}
+}
+
+type signatureManifest struct {
+	Layers []struct {
+		MediaType   string            `json:"mediaType"`
+		Digest      string            `json:"digest"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"layers"`
+}
+
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+func (v *verifier) Verify(ctx context.Context, image string, opts VerifyOptions) (string, error) {
+	if len(opts.PublicKeys) == 0 {
+		return "", fmt.Errorf("no public keys to verify the signature with")
+	}
+	ref, err := parseReference(image)
+	if err != nil {
+		return "", err
+	}
+	username, password, err := credentialsFor(opts.DockerConfig, ref.domain)
+	if err != nil {
+		return "", err
+	}
+	client := &registryClient{httpClient: v.httpClient, ref: ref, username: username, password: password}
+
+	digest := ref.digest
+	if digest == "" {
+		if digest, err = client.resolveDigest(ctx, ref.tag); err != nil {
+			return "", fmt.Errorf("failed to resolve the digest of %s: %v", image, err)
+		}
+	}
+
+	cacheKey, cacheable := verifiedKey(ref, digest, opts.PublicKeys)
+	if cacheable {
+		if _, exists := v.verified.Get(cacheKey); exists {
+			return ref.withDigest(digest), nil
+		}
+	}
+
+	// cosign stores the signatures of a manifest in the manifest tagged sha256-<hex>.sig
+	manifestBytes, err := client.manifest(ctx, strings.Replace(digest, ":", "-", 1)+signatureTagSuffix)
+	if errors.Is(err, errNotFound) {
+		return "", ErrNoSignature
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get the signatures of %s: %v", image, err)
+	}
+	manifest := &signatureManifest{}
+	if err := json.Unmarshal(manifestBytes, manifest); err != nil {
+		return "", fmt.Errorf("failed to parse the signatures of %s: %v", image, err)
+	}
+
+	for _, layer := range manifest.Layers {
+		encoded, exists := layer.Annotations[SignatureAnnotation]
+		if layer.MediaType != SimpleSigningMediaType || !exists {
+			continue
+		}
+		sig, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+		payload, err := client.blob(ctx, layer.Digest, maxPayloadSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to get the signed payload of %s: %v", image, err)
+		}
+		if !verifyAny(opts.PublicKeys, payload, sig) {
+			continue
+		}
+		if err := checkPayload(payload, digest); err != nil {
+			return "", fmt.Errorf("invalid signature of %s: %v", image, err)
+		}
+		if cacheable {
+			v.verified.Add(cacheKey, struct{}{}, verifiedCacheTTL)
+		}
+		return ref.withDigest(digest), nil
+	}
+	return "", fmt.Errorf("no signature of %s matches the configured public keys", image)
+}
+
+// verifiedKey identifies a verification of the digest against the public keys
+func verifiedKey(ref *reference, digest string, keys []crypto.PublicKey) (string, bool) {
+	fingerprints := make([]string, 0, len(keys))
+	for _, key := range keys {
+		der, err := x509.MarshalPKIXPublicKey(key)
+		if err != nil {
+			return "", false
+		}
+		fingerprints = append(fingerprints, sha256Digest(der))
+	}
+	sort.Strings(fingerprints)
+	return ref.domain + "/" + ref.repository + "@" + digest + "|" + strings.Join(fingerprints, ","), true
+}
+
+func checkPayload(payload []byte, digest string) error {
+	p := &simpleSigningPayload{}
+	if err := json.Unmarshal(payload, p); err != nil {
+		return fmt.Errorf("failed to parse the signed payload: %v", err)
+	}
+	if p.Critical.Type != CosignSignatureType {
+		return fmt.Errorf("unexpected signature type %q", p.Critical.Type)
+	}
+	if p.Critical.Image.DockerManifestDigest != digest {
+		return fmt.Errorf("signature is for digest %s, not %s", p.Critical.Image.DockerManifestDigest, digest)
+	}
+	return nil
+}
+
+func verifyAny(keys []crypto.PublicKey, payload, sig []byte) bool {
+	for _, key := range keys {
+		if verifySignature(key, payload, sig) {
+			return true
+		}
+	}
+	return false
+}
+
+func verifySignature(key crypto.PublicKey, payload, sig []byte) bool {
+	hash := sha256.Sum256(payload)
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, hash[:], sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], sig) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, payload, sig)
+	}
+	return false
+}
+
+// ParsePublicKeys parses PEM encoded ECDSA, RSA or Ed25519 public keys
+func ParsePublicKeys(pemKeys []string) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	for i, pemKey := range pemKeys {
+		block, _ := pem.Decode([]byte(pemKey))
+		if block == nil {
+			return nil, fmt.Errorf("public key %d is not PEM encoded", i)
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key %d: %v", i, err)
+		}
+		switch key.(type) {
+		case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		default:
+			return nil, fmt.Errorf("public key %d has unsupported type %T", i, key)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
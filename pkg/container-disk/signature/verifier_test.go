@@ -0,0 +1,353 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package signature
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeRegistry struct {
+	server    *httptest.Server
+	manifests map[string][]byte
+	blobs     map[string][]byte
+	username  string
+	password  string
+}
+
+func newFakeRegistry() *fakeRegistry {
+	r := &fakeRegistry{manifests: map[string][]byte{}, blobs: map[string][]byte{}}
+	r.server = httptest.NewTLSServer(http.HandlerFunc(r.serve))
+	return r
+}
+
+func (r *fakeRegistry) serve(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		if username, password, _ := req.BasicAuth(); username != r.username || password != r.password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if req.URL.Query().Get("scope") != "repository:disks/fedora:pull" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		fmt.Fprint(w, `{"token": "secret-token"}`)
+		return
+	}
+	if r.username != "" && req.Header.Get("Authorization") != "Bearer secret-token" {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry"`, r.server.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var content []byte
+	var exists bool
+	if name, found := strings.CutPrefix(req.URL.Path, "/v2/disks/fedora/manifests/"); found {
+		content, exists = r.manifests[name]
+		if exists {
+			w.Header().Set("Docker-Content-Digest", sha256Digest(content))
+		}
+	} else if name, found := strings.CutPrefix(req.URL.Path, "/v2/disks/fedora/blobs/"); found {
+		content, exists = r.blobs[name]
+	}
+	if !exists {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if req.Method == http.MethodGet {
+		w.Write(content)
+	}
+}
+
+func (r *fakeRegistry) image(tag string) string {
+	return strings.TrimPrefix(r.server.URL, "https://") + "/disks/fedora:" + tag
+}
+
+// push adds an image manifest under the tag and returns its digest
+func (r *fakeRegistry) push(tag string) string {
+	manifest := []byte(fmt.Sprintf(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","tag":%q}`, tag))
+	r.manifests[tag] = manifest
+	digest := sha256Digest(manifest)
+	r.manifests[digest] = manifest
+	return digest
+}
+
+// sign attaches a cosign signature of the payload to the manifest with the given digest
+func (r *fakeRegistry) sign(digest string, payload []byte, sign func([]byte) []byte) {
+	payloadDigest := sha256Digest(payload)
+	r.blobs[payloadDigest] = payload
+
+	manifest := &signatureManifest{}
+	sigTag := strings.Replace(digest, ":", "-", 1) + signatureTagSuffix
+	if existing, exists := r.manifests[sigTag]; exists {
+		Expect(json.Unmarshal(existing, manifest)).To(Succeed())
+	}
+	manifest.Layers = append(manifest.Layers, struct {
+		MediaType   string            `json:"mediaType"`
+		Digest      string            `json:"digest"`
+		Annotations map[string]string `json:"annotations"`
+	}{
+		MediaType:   SimpleSigningMediaType,
+		Digest:      payloadDigest,
+		Annotations: map[string]string{SignatureAnnotation: base64.StdEncoding.EncodeToString(sign(payload))},
+	})
+	content, err := json.Marshal(manifest)
+	Expect(err).ToNot(HaveOccurred())
+	r.manifests[sigTag] = content
+}
+
+func cosignPayload(digest string) []byte {
+	return []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"disks/fedora"},"image":{"docker-manifest-digest":%q},"type":%q},"optional":null}`, digest, CosignSignatureType))
+}
+
+func ecdsaSigner(key *ecdsa.PrivateKey) func([]byte) []byte {
+	return func(payload []byte) []byte {
+		hash := sha256.Sum256(payload)
+		sig, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+		Expect(err).ToNot(HaveOccurred())
+		return sig
+	}
+}
+
+func publicKeyPEM(key crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	Expect(err).ToNot(HaveOccurred())
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+var _ = Describe("Verifier", func() {
+	var registry *fakeRegistry
+	var verifier Verifier
+	var signingKey *ecdsa.PrivateKey
+	var opts VerifyOptions
+
+	BeforeEach(func() {
+		registry = newFakeRegistry()
+		DeferCleanup(registry.server.Close)
+		verifier = NewVerifier(registry.server.Client())
+
+		var err error
+		signingKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		keys, err := ParsePublicKeys([]string{publicKeyPEM(&signingKey.PublicKey)})
+		Expect(err).ToNot(HaveOccurred())
+		opts = VerifyOptions{PublicKeys: keys}
+	})
+
+	It("should accept a signed image and pin it to its digest", func() {
+		digest := registry.push("39")
+		registry.sign(digest, cosignPayload(digest), ecdsaSigner(signingKey))
+
+		pinned, err := verifier.Verify(context.Background(), registry.image("39"), opts)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pinned).To(Equal(strings.TrimSuffix(registry.image("39"), ":39") + "@" + digest))
+	})
+
+	It("should accept a signed image referenced by digest", func() {
+		digest := registry.push("39")
+		registry.sign(digest, cosignPayload(digest), ecdsaSigner(signingKey))
+		image := strings.TrimSuffix(registry.image("39"), ":39") + "@" + digest
+
+		pinned, err := verifier.Verify(context.Background(), image, opts)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pinned).To(Equal(image))
+	})
+
+	It("should not fetch the signatures of a verified digest again", func() {
+		digest := registry.push("39")
+		registry.sign(digest, cosignPayload(digest), ecdsaSigner(signingKey))
+		_, err := verifier.Verify(context.Background(), registry.image("39"), opts)
+		Expect(err).ToNot(HaveOccurred())
+
+		delete(registry.manifests, strings.Replace(digest, ":", "-", 1)+signatureTagSuffix)
+		pinned, err := verifier.Verify(context.Background(), registry.image("39"), opts)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pinned).To(HaveSuffix("@" + digest))
+
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		otherKeys, err := ParsePublicKeys([]string{publicKeyPEM(&otherKey.PublicKey)})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = verifier.Verify(context.Background(), registry.image("39"), VerifyOptions{PublicKeys: otherKeys})
+		Expect(err).To(MatchError(ErrNoSignature))
+	})
+
+	It("should accept an image signed by any of the keys", func() {
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		digest := registry.push("39")
+		registry.sign(digest, cosignPayload(digest), ecdsaSigner(otherKey))
+		registry.sign(digest, cosignPayload(digest), ecdsaSigner(signingKey))
+
+		_, err = verifier.Verify(context.Background(), registry.image("39"), opts)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should reject an unsigned image", func() {
+		registry.push("39")
+
+		_, err := verifier.Verify(context.Background(), registry.image("39"), opts)
+		Expect(err).To(MatchError(ErrNoSignature))
+	})
+
+	It("should reject an image signed by another key", func() {
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		digest := registry.push("39")
+		registry.sign(digest, cosignPayload(digest), ecdsaSigner(otherKey))
+
+		_, err = verifier.Verify(context.Background(), registry.image("39"), opts)
+		Expect(err).To(MatchError(ContainSubstring("no signature")))
+		Expect(err).To(MatchError(ContainSubstring("matches the configured public keys")))
+	})
+
+	It("should reject a signature of another image", func() {
+		digest := registry.push("39")
+		otherDigest := registry.push("40")
+		registry.sign(digest, cosignPayload(otherDigest), ecdsaSigner(signingKey))
+
+		_, err := verifier.Verify(context.Background(), registry.image("39"), opts)
+		Expect(err).To(MatchError(ContainSubstring("signature is for digest " + otherDigest)))
+	})
+
+	It("should reject a tampered payload", func() {
+		digest := registry.push("39")
+		payload := cosignPayload(digest)
+		registry.sign(digest, payload, ecdsaSigner(signingKey))
+		registry.blobs[sha256Digest(payload)] = append(payload, ' ')
+
+		_, err := verifier.Verify(context.Background(), registry.image("39"), opts)
+		Expect(err).To(MatchError(ContainSubstring("does not match its digest")))
+	})
+
+	It("should reject a missing image", func() {
+		_, err := verifier.Verify(context.Background(), registry.image("39"), opts)
+		Expect(err).To(MatchError(ContainSubstring("failed to resolve the digest")))
+	})
+
+	It("should fail without public keys", func() {
+		_, err := verifier.Verify(context.Background(), registry.image("39"), VerifyOptions{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("with a registry requiring authentication", func() {
+		BeforeEach(func() {
+			registry.username, registry.password = "user", "password"
+		})
+
+		It("should get a token with the credentials of the pull secret", func() {
+			digest := registry.push("39")
+			registry.sign(digest, cosignPayload(digest), ecdsaSigner(signingKey))
+			host := strings.TrimPrefix(registry.server.URL, "https://")
+			auth := base64.StdEncoding.EncodeToString([]byte("user:password"))
+			opts.DockerConfig = []byte(fmt.Sprintf(`{"auths":{"https://%s/v1/":{"auth":%q}}}`, host, auth))
+
+			_, err := verifier.Verify(context.Background(), registry.image("39"), opts)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should fail without credentials", func() {
+			registry.push("39")
+
+			_, err := verifier.Verify(context.Background(), registry.image("39"), opts)
+			Expect(err).To(MatchError(ContainSubstring("failed to get a token")))
+		})
+	})
+
+	DescribeTable("should verify signatures", func(generate func() (crypto.PublicKey, func([]byte) []byte)) {
+		publicKey, sign := generate()
+		keys, err := ParsePublicKeys([]string{publicKeyPEM(publicKey)})
+		Expect(err).ToNot(HaveOccurred())
+		digest := registry.push("39")
+		registry.sign(digest, cosignPayload(digest), sign)
+
+		_, err = verifier.Verify(context.Background(), registry.image("39"), VerifyOptions{PublicKeys: keys})
+		Expect(err).ToNot(HaveOccurred())
+	},
+		Entry("of RSA keys", func() (crypto.PublicKey, func([]byte) []byte) {
+			key, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).ToNot(HaveOccurred())
+			return &key.PublicKey, func(payload []byte) []byte {
+				hash := sha256.Sum256(payload)
+				sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+				Expect(err).ToNot(HaveOccurred())
+				return sig
+			}
+		}),
+		Entry("of Ed25519 keys", func() (crypto.PublicKey, func([]byte) []byte) {
+			publicKey, key, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+			return publicKey, func(payload []byte) []byte {
+				return ed25519.Sign(key, payload)
+			}
+		}),
+	)
+})
+
+var _ = Describe("Public keys", func() {
+	It("should reject keys which are not PEM encoded", func() {
+		_, err := ParsePublicKeys([]string{"not a key"})
+		Expect(err).To(MatchError(ContainSubstring("not PEM encoded")))
+	})
+
+	It("should reject invalid keys", func() {
+		_, err := ParsePublicKeys([]string{string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("invalid")}))})
+		Expect(err).To(MatchError(ContainSubstring("failed to parse public key 0")))
+	})
+})
+
+var _ = Describe("Docker config credentials", func() {
+	auth := base64.StdEncoding.EncodeToString([]byte("user:password"))
+
+	DescribeTable("should be found", func(config, domain string) {
+		username, password, err := credentialsFor([]byte(config), domain)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(username).To(Equal("user"))
+		Expect(password).To(Equal("password"))
+	},
+		Entry("in a dockerconfigjson", `{"auths":{"quay.io":{"auth":"`+auth+`"}}}`, "quay.io"),
+		Entry("in a legacy dockercfg", `{"quay.io":{"auth":"`+auth+`"}}`, "quay.io"),
+		Entry("with username and password", `{"auths":{"quay.io":{"username":"user","password":"password"}}}`, "quay.io"),
+		Entry("for docker hub", `{"auths":{"https://index.docker.io/v1/":{"auth":"`+auth+`"}}}`, "docker.io"),
+	)
+
+	It("should be empty for other registries", func() {
+		username, _, err := credentialsFor([]byte(`{"auths":{"quay.io":{"auth":"`+auth+`"}}}`), "docker.io")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(username).To(BeEmpty())
+	})
+})
@@ -40,6 +40,7 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 
 	"kubevirt.io/kubevirt/pkg/network/vmispec"
+	"kubevirt.io/kubevirt/pkg/util"
 )
 
 const (
@@ -201,17 +202,8 @@ func ApplyVolumeRequestOnVMISpec(vmiSpec *v1.VirtualMachineInstanceSpec, request
 
 		if !alreadyAdded {
 			newVolume := v1.Volume{
-				Name: request.AddVolumeOptions.Name,
-			}
-
-			if request.AddVolumeOptions.VolumeSource.PersistentVolumeClaim != nil {
-				pvcSource := request.AddVolumeOptions.VolumeSource.PersistentVolumeClaim.DeepCopy()
-				pvcSource.Hotpluggable = true
-				newVolume.VolumeSource.PersistentVolumeClaim = pvcSource
-			} else if request.AddVolumeOptions.VolumeSource.DataVolume != nil {
-				dvSource := request.AddVolumeOptions.VolumeSource.DataVolume.DeepCopy()
-				dvSource.Hotpluggable = true
-				newVolume.VolumeSource.DataVolume = dvSource
+				Name:         request.AddVolumeOptions.Name,
+				VolumeSource: util.HotplugVolumeSourceToVolumeSource(request.AddVolumeOptions.VolumeSource),
 			}
 
 			vmiSpec.Volumes = append(vmiSpec.Volumes, newVolume)
@@ -243,6 +235,71 @@ func ApplyVolumeRequestOnVMISpec(vmiSpec *v1.VirtualMachineInstanceSpec, request
 
 		vmiSpec.Volumes = newVolumesList
 		vmiSpec.Domain.Devices.Disks = newDisksList
+	} else if request.InsertMediaOptions != nil {
+		alreadyInserted := false
+		for _, volume := range vmiSpec.Volumes {
+			if volume.Name == request.InsertMediaOptions.Name {
+				alreadyInserted = true
+				break
+			}
+		}
+
+		if !alreadyInserted {
+			newVolume := v1.Volume{
+				Name:         request.InsertMediaOptions.Name,
+				VolumeSource: util.HotplugVolumeSourceToVolumeSource(request.InsertMediaOptions.VolumeSource),
+			}
+
+			vmiSpec.Volumes = append(vmiSpec.Volumes, newVolume)
+		}
+	} else if request.EjectMediaOptions != nil {
+		// The cdrom disk is kept, only the volume backing its media is removed
+		newVolumesList := []v1.Volume{}
+
+		for _, volume := range vmiSpec.Volumes {
+			if volume.Name != request.EjectMediaOptions.Name {
+				newVolumesList = append(newVolumesList, volume)
+			}
+		}
+
+		vmiSpec.Volumes = newVolumesList
+	} else if request.AddFilesystemOptions != nil {
+		alreadyAdded := false
+		for _, volume := range vmiSpec.Volumes {
+			if volume.Name == request.AddFilesystemOptions.Name {
+				alreadyAdded = true
+				break
+			}
+		}
+
+		if !alreadyAdded {
+			vmiSpec.Volumes = append(vmiSpec.Volumes, v1.Volume{
+				Name:         request.AddFilesystemOptions.Name,
+				VolumeSource: util.HotplugVolumeSourceToVolumeSource(request.AddFilesystemOptions.VolumeSource),
+			})
+			vmiSpec.Domain.Devices.Filesystems = append(vmiSpec.Domain.Devices.Filesystems, v1.Filesystem{
+				Name:     request.AddFilesystemOptions.Name,
+				Virtiofs: &v1.FilesystemVirtiofs{},
+			})
+		}
+	} else if request.RemoveFilesystemOptions != nil {
+		newVolumesList := []v1.Volume{}
+		newFilesystemsList := []v1.Filesystem{}
+
+		for _, volume := range vmiSpec.Volumes {
+			if volume.Name != request.RemoveFilesystemOptions.Name {
+				newVolumesList = append(newVolumesList, volume)
+			}
+		}
+
+		for _, fs := range vmiSpec.Domain.Devices.Filesystems {
+			if fs.Name != request.RemoveFilesystemOptions.Name {
+				newFilesystemsList = append(newFilesystemsList, fs)
+			}
+		}
+
+		vmiSpec.Volumes = newVolumesList
+		vmiSpec.Domain.Devices.Filesystems = newFilesystemsList
 	}
 
 	return vmiSpec
@@ -355,10 +412,7 @@ func VMIHasHotplugVolumes(vmi *v1.VirtualMachineInstance) bool {
 		}
 	}
 	for _, volume := range vmi.Spec.Volumes {
-		if volume.DataVolume != nil && volume.DataVolume.Hotpluggable {
-			return true
-		}
-		if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.Hotpluggable {
+		if util.IsHotplugVolume(&volume) {
 			return true
 		}
 	}
@@ -413,6 +467,29 @@ func ApplyNetworkInterfaceRemoveRequest(vmiSpec *v1.VirtualMachineInstanceSpec,
 	return vmiSpec
 }
 
+// ApplyNetworkAttachmentChanges points the existing secondary bridge interfaces of the VMI spec to the
+// NetworkAttachmentDefinition referenced by the given (VM template) networks.
+// Interfaces that are absent or not bound via bridge are left untouched.
+func ApplyNetworkAttachmentChanges(vmiSpec *v1.VirtualMachineInstanceSpec, networks []v1.Network) *v1.VirtualMachineInstanceSpec {
+	networksByName := vmispec.IndexNetworkSpecByName(networks)
+	for i := range vmiSpec.Networks {
+		vmiNetwork := &vmiSpec.Networks[i]
+		if !vmispec.IsSecondaryMultusNetwork(*vmiNetwork) {
+			continue
+		}
+		network, exists := networksByName[vmiNetwork.Name]
+		if !exists || !vmispec.IsSecondaryMultusNetwork(network) {
+			continue
+		}
+		iface := vmispec.LookupInterfaceByName(vmiSpec.Domain.Devices.Interfaces, vmiNetwork.Name)
+		if iface == nil || iface.Bridge == nil || iface.State == v1.InterfaceStateAbsent {
+			continue
+		}
+		vmiNetwork.Multus.NetworkName = network.Multus.NetworkName
+	}
+	return vmiSpec
+}
+
 func newNetworkInterface(name, netAttachDefName string) (v1.Network, v1.Interface) {
 	network := v1.Network{
 		Name: name,
@@ -135,6 +135,15 @@ type KubeInformerFactory interface {
 	// Watches VirtualMachineRestore objects
 	VirtualMachineRestore() cache.SharedIndexInformer
 
+	// Watches VirtualMachineSnapshotGroup objects
+	VirtualMachineSnapshotGroup() cache.SharedIndexInformer
+
+	// Watches VirtualMachineRestoreGroup objects
+	VirtualMachineRestoreGroup() cache.SharedIndexInformer
+
+	// Watches VirtualMachineSnapshotMount objects
+	VirtualMachineSnapshotMount() cache.SharedIndexInformer
+
 	// Watches MigrationPolicy objects
 	MigrationPolicy() cache.SharedIndexInformer
 
@@ -294,6 +303,12 @@ type KubeInformerFactory interface {
 	// PVC StorageClasses
 	StorageClass() cache.SharedIndexInformer
 
+	// PersistentVolumes bound to PVCs
+	PersistentVolume() cache.SharedIndexInformer
+
+	// CSIDrivers describing the CSI volumes
+	CSIDriver() cache.SharedIndexInformer
+
 	// Pod returns an informer for ALL Pods in the system
 	Pod() cache.SharedIndexInformer
 
@@ -691,6 +706,48 @@ func (f *kubeInformerFactory) VirtualMachineRestore() cache.SharedIndexInformer
 	})
 }
 
+func (f *kubeInformerFactory) VirtualMachineSnapshotGroup() cache.SharedIndexInformer {
+	return f.getInformer("vmSnapshotGroupInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().SnapshotV1alpha1().RESTClient(), "virtualmachinesnapshotgroups", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &snapshotv1.VirtualMachineSnapshotGroup{}, f.defaultResync, cache.Indexers{})
+	})
+}
+
+func (f *kubeInformerFactory) VirtualMachineRestoreGroup() cache.SharedIndexInformer {
+	return f.getInformer("vmRestoreGroupInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().SnapshotV1alpha1().RESTClient(), "virtualmachinerestoregroups", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &snapshotv1.VirtualMachineRestoreGroup{}, f.defaultResync, cache.Indexers{})
+	})
+}
+
+func GetVirtualMachineSnapshotMountInformerIndexers() cache.Indexers {
+	return cache.Indexers{
+		"vm": func(obj interface{}) ([]string, error) {
+			mount, ok := obj.(*snapshotv1.VirtualMachineSnapshotMount)
+			if !ok {
+				return nil, unexpectedObjectError
+			}
+
+			target := mount.Spec.Target
+			if target != nil &&
+				target.APIGroup != nil &&
+				*target.APIGroup == core.GroupName &&
+				target.Kind == "VirtualMachine" {
+				return []string{fmt.Sprintf("%s/%s", mount.Namespace, target.Name)}, nil
+			}
+
+			return nil, nil
+		},
+	}
+}
+
+func (f *kubeInformerFactory) VirtualMachineSnapshotMount() cache.SharedIndexInformer {
+	return f.getInformer("vmSnapshotMountInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().SnapshotV1alpha1().RESTClient(), "virtualmachinesnapshotmounts", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &snapshotv1.VirtualMachineSnapshotMount{}, f.defaultResync, GetVirtualMachineSnapshotMountInformerIndexers())
+	})
+}
+
 func (f *kubeInformerFactory) MigrationPolicy() cache.SharedIndexInformer {
 	return f.getInformer("migrationPolicyInformer", func() cache.SharedIndexInformer {
 		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().MigrationsV1alpha1().RESTClient(), migrations.ResourceMigrationPolicies, k8sv1.NamespaceAll, fields.Everything())
@@ -1266,6 +1323,22 @@ func (f *kubeInformerFactory) StorageClass() cache.SharedIndexInformer {
 	})
 }
 
+func (f *kubeInformerFactory) PersistentVolume() cache.SharedIndexInformer {
+	return f.getInformer("persistentVolumeInformer", func() cache.SharedIndexInformer {
+		restClient := f.clientSet.CoreV1().RESTClient()
+		lw := cache.NewListWatchFromClient(restClient, "persistentvolumes", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &k8sv1.PersistentVolume{}, f.defaultResync, cache.Indexers{})
+	})
+}
+
+func (f *kubeInformerFactory) CSIDriver() cache.SharedIndexInformer {
+	return f.getInformer("csiDriverInformer", func() cache.SharedIndexInformer {
+		restClient := f.clientSet.StorageV1().RESTClient()
+		lw := cache.NewListWatchFromClient(restClient, "csidrivers", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &storagev1.CSIDriver{}, f.defaultResync, cache.Indexers{})
+	})
+}
+
 func (f *kubeInformerFactory) Pod() cache.SharedIndexInformer {
 	return f.getInformer("podInformer", func() cache.SharedIndexInformer {
 		lw := cache.NewListWatchFromClient(f.clientSet.CoreV1().RESTClient(), "pods", k8sv1.NamespaceAll, fields.Everything())
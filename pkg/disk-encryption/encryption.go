@@ -0,0 +1,155 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package diskencryption
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/config"
+	"kubevirt.io/kubevirt/pkg/util"
+)
+
+const (
+	// PassphraseKey is the key of the Secret holding the LUKS passphrase
+	PassphraseKey = "passphrase"
+
+	luksMagic = "LUKS\xba\xbe"
+	// a volume is considered blank if its first MiB only contains zeros
+	blankProbeSize = 1024 * 1024
+	// space left for the LUKS header when a blank volume is formatted,
+	// the header qemu writes is well below this size
+	luksHeaderReserve = 16 * 1024 * 1024
+)
+
+// SecretVolumeName returns the name of the pod volume carrying the passphrase of the disk
+func SecretVolumeName(diskName string) string {
+	return diskName + "-encryption"
+}
+
+// PassphraseDir returns the directory the passphrase Secret of the disk is mounted to
+func PassphraseDir(diskName string) string {
+	return filepath.Join(config.SecretSourceDir, SecretVolumeName(diskName))
+}
+
+// SecretUUID returns the UUID of the libvirt secret holding the passphrase of the disk.
+// It is stable for a VMI, so that migration targets define the same secret.
+func SecretUUID(vmiUID types.UID, diskName string) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(string(vmiUID)+"/"+diskName)).String()
+}
+
+// HasEncryptedDisks returns true if any disk of the VMI is LUKS encrypted
+func HasEncryptedDisks(vmi *v1.VirtualMachineInstance) bool {
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.Encryption != nil {
+			return true
+		}
+	}
+	return false
+}
+
+type VolumePreparer struct {
+	passphraseDir func(diskName string) string
+	formatFunc    func(path, passphrasePath string, size int64) error
+}
+
+func NewVolumePreparer() *VolumePreparer {
+	return &VolumePreparer{
+		passphraseDir: PassphraseDir,
+		formatFunc:    formatLUKS,
+	}
+}
+
+// ReadPassphrase returns the passphrase of the disk as it is stored in the Secret
+func (p *VolumePreparer) ReadPassphrase(diskName string) ([]byte, error) {
+	passphrase, err := os.ReadFile(p.passphrasePath(diskName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the passphrase of disk %s: %v", diskName, err)
+	}
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("the passphrase of disk %s is empty", diskName)
+	}
+	return passphrase, nil
+}
+
+// PrepareVolume makes sure the image at path is a LUKS volume.
+// Blank volumes are only formatted if format is set, any other content is refused to not destroy it.
+func (p *VolumePreparer) PrepareVolume(diskName, path string, format bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer util.CloseIOAndCheckErr(f, nil)
+
+	header := make([]byte, blankProbeSize)
+	n, err := io.ReadFull(f, header)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("failed to read the header of disk %s: %v", diskName, err)
+	}
+	header = header[:n]
+
+	if bytes.HasPrefix(header, []byte(luksMagic)) {
+		return nil
+	}
+	if !format {
+		return fmt.Errorf("disk %s is not a LUKS volume and formatting it is not allowed", diskName)
+	}
+	if len(bytes.Trim(header, "\x00")) != 0 {
+		return fmt.Errorf("disk %s is neither a LUKS volume nor blank, refusing to format it", diskName)
+	}
+
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return fmt.Errorf("failed to determine the size of disk %s: %v", diskName, err)
+	}
+	size = util.AlignImageSizeTo1MiB(size-luksHeaderReserve, nil)
+	if size <= 0 {
+		return fmt.Errorf("disk %s is too small to be formatted as LUKS volume", diskName)
+	}
+	return p.formatFunc(path, p.passphrasePath(diskName), size)
+}
+
+func (p *VolumePreparer) passphrasePath(diskName string) string {
+	return filepath.Join(p.passphraseDir(diskName), PassphraseKey)
+}
+
+func formatLUKS(path, passphrasePath string, size int64) error {
+	// the passphrase is handed over as file, to keep it out of the process arguments
+	// #nosec No risk for attacker injection, the paths are defined by KubeVirt
+	cmd := exec.Command("/usr/bin/qemu-img", "create", "-f", "luks",
+		"--object", "secret,id=sec0,file="+passphrasePath,
+		"-o", "key-secret=sec0",
+		path, strconv.FormatInt(size, 10))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("formatting %s as LUKS volume failed with output '%s': %v", path, string(out), err)
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package diskencryption
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestDiskEncryption(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
@@ -0,0 +1,114 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package diskencryption
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Disk encryption", func() {
+	var (
+		tmpDir    string
+		preparer  *VolumePreparer
+		formatted map[string]int64
+	)
+
+	BeforeEach(func() {
+		tmpDir = GinkgoT().TempDir()
+		formatted = map[string]int64{}
+		preparer = &VolumePreparer{
+			passphraseDir: func(diskName string) string {
+				return filepath.Join(tmpDir, SecretVolumeName(diskName))
+			},
+			formatFunc: func(path, passphrasePath string, size int64) error {
+				Expect(passphrasePath).To(Equal(filepath.Join(tmpDir, "disk0-encryption", PassphraseKey)))
+				formatted[path] = size
+				return nil
+			},
+		}
+	})
+
+	createImage := func(header []byte, size int64) string {
+		path := filepath.Join(tmpDir, "disk.img")
+		Expect(os.WriteFile(path, header, 0600)).To(Succeed())
+		Expect(os.Truncate(path, size)).To(Succeed())
+		return path
+	}
+
+	It("should use a stable secret UUID per VMI and disk", func() {
+		Expect(SecretUUID("1234", "disk0")).To(Equal(SecretUUID("1234", "disk0")))
+		Expect(SecretUUID("1234", "disk0")).ToNot(Equal(SecretUUID("1234", "disk1")))
+		Expect(SecretUUID("1234", "disk0")).ToNot(Equal(SecretUUID("5678", "disk0")))
+	})
+
+	It("should read the passphrase as stored in the Secret", func() {
+		Expect(os.MkdirAll(filepath.Join(tmpDir, "disk0-encryption"), 0700)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(tmpDir, "disk0-encryption", PassphraseKey), []byte("secret\n"), 0600)).To(Succeed())
+
+		Expect(preparer.ReadPassphrase("disk0")).To(Equal([]byte("secret\n")))
+	})
+
+	It("should fail on an empty passphrase", func() {
+		Expect(os.MkdirAll(filepath.Join(tmpDir, "disk0-encryption"), 0700)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(tmpDir, "disk0-encryption", PassphraseKey), nil, 0600)).To(Succeed())
+
+		_, err := preparer.ReadPassphrase("disk0")
+		Expect(err).To(MatchError(ContainSubstring("empty")))
+	})
+
+	It("should leave LUKS volumes untouched", func() {
+		path := createImage([]byte(luksMagic), 64*1024*1024)
+
+		Expect(preparer.PrepareVolume("disk0", path, false)).To(Succeed())
+		Expect(formatted).To(BeEmpty())
+	})
+
+	It("should format blank volumes while leaving room for the header", func() {
+		path := createImage(nil, 64*1024*1024+512)
+
+		Expect(preparer.PrepareVolume("disk0", path, true)).To(Succeed())
+		Expect(formatted).To(HaveKeyWithValue(path, int64(48*1024*1024)))
+	})
+
+	It("should refuse to format volumes with content", func() {
+		path := createImage([]byte("some filesystem"), 64*1024*1024)
+
+		Expect(preparer.PrepareVolume("disk0", path, true)).To(MatchError(ContainSubstring("neither a LUKS volume nor blank")))
+		Expect(formatted).To(BeEmpty())
+	})
+
+	It("should refuse blank volumes if formatting is not allowed", func() {
+		path := createImage(nil, 64*1024*1024)
+
+		Expect(preparer.PrepareVolume("disk0", path, false)).To(MatchError(ContainSubstring("not a LUKS volume")))
+		Expect(formatted).To(BeEmpty())
+	})
+
+	It("should refuse to format volumes too small for the header", func() {
+		path := createImage(nil, 8*1024*1024)
+
+		Expect(preparer.PrepareVolume("disk0", path, true)).To(MatchError(ContainSubstring("too small")))
+		Expect(formatted).To(BeEmpty())
+	})
+})
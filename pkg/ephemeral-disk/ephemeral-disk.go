@@ -37,8 +37,20 @@ const (
 	ephemeralDiskPVCBaseDir         = "/var/run/kubevirt-private/vmi-disks"
 	ephemeralDiskBlockDeviceBaseDir = "/dev"
 	ephemeralDiskFormat             = "raw"
+	overlayFileName                 = "disk.qcow2"
+	overlayBaseVolumeSuffix         = "-base"
 )
 
+// OverlayBaseVolumeName returns the name of the pod volume the base image of an overlay volume is attached as
+func OverlayBaseVolumeName(volumeName string) string {
+	return volumeName + overlayBaseVolumeSuffix
+}
+
+// GetOverlayFilePath returns the path of the persistent qcow2 overlay on the PVC of an overlay volume
+func GetOverlayFilePath(volumeName string) string {
+	return filepath.Join(ephemeralDiskPVCBaseDir, volumeName, overlayFileName)
+}
+
 type EphemeralDiskCreatorInterface interface {
 	CreateBackedImageForVolume(volume v1.Volume, backingFile string, backingFormat string) error
 	CreateEphemeralImages(vmi *v1.VirtualMachineInstance, domain *api.Domain) error
@@ -93,14 +105,20 @@ func (c *ephemeralDiskCreator) GetFilePath(volumeName string) string {
 	return filepath.Join(volumeMountDir, "disk.qcow2")
 }
 
+func (c *ephemeralDiskCreator) getOverlayFilePath(volumeName string) string {
+	return filepath.Join(c.pvcBaseDir, volumeName, overlayFileName)
+}
+
 func (c *ephemeralDiskCreator) CreateBackedImageForVolume(volume v1.Volume, backingFile string, backingFormat string) error {
 	err := c.createVolumeDirectory(volume.Name)
 	if err != nil {
 		return err
 	}
 
-	imagePath := c.GetFilePath(volume.Name)
+	return c.createBackedImage(c.GetFilePath(volume.Name), backingFile, backingFormat)
+}
 
+func (c *ephemeralDiskCreator) createBackedImage(imagePath string, backingFile string, backingFormat string) error {
 	if _, err := os.Stat(imagePath); err == nil {
 		return nil
 	} else if !errors.Is(err, os.ErrNotExist) {
@@ -109,8 +127,9 @@ func (c *ephemeralDiskCreator) CreateBackedImageForVolume(volume v1.Volume, back
 
 	output, err := c.discCreateFunc(backingFile, backingFormat, imagePath)
 
-	// Cleanup of previous images isn't really necessary as they're all on EmptyDir.
 	if err != nil {
+		// Persistent overlays live on a PVC, don't leave a broken image behind for the next boot
+		_ = os.Remove(imagePath)
 		return fmt.Errorf("qemu-img failed with output '%s': %v", string(output), err)
 	}
 
@@ -135,6 +154,14 @@ func (c *ephemeralDiskCreator) CreateEphemeralImages(vmi *v1.VirtualMachineInsta
 				return err
 			}
 		}
+		// Persistent overlays are only created on first boot, afterwards they keep the writes of the VMI
+		if volume.VolumeSource.Overlay != nil {
+			baseVolumeName := OverlayBaseVolumeName(volume.Name)
+			backingFile := c.getBackingFilePath(baseVolumeName, isBlockVolumes[baseVolumeName])
+			if err := c.createBackedImage(c.getOverlayFilePath(volume.Name), backingFile, ephemeralDiskFormat); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -182,6 +182,91 @@ var _ = Describe("ContainerDisk", func() {
 			})
 		})
 	})
+
+	Describe("persistent overlay", func() {
+		appendOverlay := func(vmi *v1.VirtualMachineInstance, volumeName string) {
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: volumeName,
+				VolumeSource: v1.VolumeSource{
+					Overlay: &v1.OverlayVolumeSource{
+						ClaimName:     volumeName + "-overlay",
+						BaseClaimName: "golden",
+					},
+				},
+			})
+			// the overlay PVC is mounted by the pod
+			Expect(os.Mkdir(filepath.Join(pvcBaseTempDirPath, volumeName), 0755)).To(Succeed())
+		}
+
+		It("Should create the overlay on the PVC backed by a filesystem base image", func() {
+			vmi := api2.NewMinimalVMI("fake-vmi")
+			appendOverlay(vmi, "vdi")
+			Expect(createBackingImageForPVC(OverlayBaseVolumeName("vdi"), false)).To(Succeed())
+
+			Expect(creator.CreateEphemeralImages(vmi, &api.Domain{})).To(Succeed())
+
+			_, err := os.Stat(filepath.Join(pvcBaseTempDirPath, "vdi", "disk.qcow2"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should create the overlay backed by a block base image", func() {
+			vmi := api2.NewMinimalVMI("fake-vmi")
+			appendOverlay(vmi, "vdi")
+			baseVolumeName := OverlayBaseVolumeName("vdi")
+			f, err := os.Create(filepath.Join(blockDevBaseDir, baseVolumeName))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.Close()).To(Succeed())
+
+			Expect(creator.CreateEphemeralImages(vmi, &api.Domain{
+				Spec: api.DomainSpec{
+					Devices: api.Devices{
+						Disks: []api.Disk{{
+							BackingStore: &api.BackingStore{
+								Type: "block",
+								Source: &api.DiskSource{
+									Dev:  filepath.Join(blockDevBaseDir, baseVolumeName),
+									Name: baseVolumeName,
+								},
+							},
+						}},
+					},
+				},
+			})).To(Succeed())
+
+			_, err = os.Stat(filepath.Join(pvcBaseTempDirPath, "vdi", "disk.qcow2"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should keep an existing overlay", func() {
+			vmi := api2.NewMinimalVMI("fake-vmi")
+			appendOverlay(vmi, "vdi")
+			overlayPath := filepath.Join(pvcBaseTempDirPath, "vdi", "disk.qcow2")
+			Expect(os.WriteFile(overlayPath, []byte("guest writes"), 0640)).To(Succeed())
+			creator.discCreateFunc = func(_, _, _ string) ([]byte, error) {
+				return nil, fmt.Errorf("must not be called")
+			}
+
+			Expect(creator.CreateEphemeralImages(vmi, &api.Domain{})).To(Succeed())
+
+			content, err := os.ReadFile(overlayPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("guest writes"))
+		})
+
+		It("Should not leave a broken overlay behind", func() {
+			vmi := api2.NewMinimalVMI("fake-vmi")
+			appendOverlay(vmi, "vdi")
+			creator.discCreateFunc = func(_, _, imagePath string) ([]byte, error) {
+				Expect(os.WriteFile(imagePath, []byte("partial"), 0640)).To(Succeed())
+				return []byte("no space left on device"), fmt.Errorf("exit status 1")
+			}
+
+			Expect(creator.CreateEphemeralImages(vmi, &api.Domain{})).To(MatchError(ContainSubstring("no space left on device")))
+
+			_, err := os.Stat(filepath.Join(pvcBaseTempDirPath, "vdi", "disk.qcow2"))
+			Expect(errors.Is(err, os.ErrNotExist)).To(BeTrue())
+		})
+	})
 })
 
 func fakeCreateBackingDisk(backingFile string, backingFormat string, imagePath string) ([]byte, error) {
@@ -40,6 +40,7 @@ It has these top-level messages:
 	SEVInfoResponse
 	LaunchMeasurementResponse
 	InjectLaunchSecretRequest
+	GuestFSTrimResponse
 */
 package v1
 
@@ -883,6 +884,30 @@ func (m *InjectLaunchSecretRequest) GetOptions() []byte {
 	return nil
 }
 
+type GuestFSTrimResponse struct {
+	Response            *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	GuestFSTrimResponse string    `protobuf:"bytes,2,opt,name=guestFSTrimResponse" json:"guestFSTrimResponse,omitempty"`
+}
+
+func (m *GuestFSTrimResponse) Reset()                    { *m = GuestFSTrimResponse{} }
+func (m *GuestFSTrimResponse) String() string            { return proto.CompactTextString(m) }
+func (*GuestFSTrimResponse) ProtoMessage()               {}
+func (*GuestFSTrimResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *GuestFSTrimResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GuestFSTrimResponse) GetGuestFSTrimResponse() string {
+	if m != nil {
+		return m.GuestFSTrimResponse
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*QemuVersionResponse)(nil), "kubevirt.cmd.v1.QemuVersionResponse")
 	proto.RegisterType((*VMI)(nil), "kubevirt.cmd.v1.VMI")
@@ -914,6 +939,7 @@ func init() {
 	proto.RegisterType((*SEVInfoResponse)(nil), "kubevirt.cmd.v1.SEVInfoResponse")
 	proto.RegisterType((*LaunchMeasurementResponse)(nil), "kubevirt.cmd.v1.LaunchMeasurementResponse")
 	proto.RegisterType((*InjectLaunchSecretRequest)(nil), "kubevirt.cmd.v1.InjectLaunchSecretRequest")
+	proto.RegisterType((*GuestFSTrimResponse)(nil), "kubevirt.cmd.v1.GuestFSTrimResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -956,6 +982,8 @@ type CmdClient interface {
 	GetSEVInfo(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*SEVInfoResponse, error)
 	GetLaunchMeasurement(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*LaunchMeasurementResponse, error)
 	InjectLaunchSecret(ctx context.Context, in *InjectLaunchSecretRequest, opts ...grpc.CallOption) (*Response, error)
+	SyncVirtualMachineIOTune(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	GuestFSTrim(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*GuestFSTrimResponse, error)
 }
 
 type cmdClient struct {
@@ -1227,6 +1255,24 @@ func (c *cmdClient) InjectLaunchSecret(ctx context.Context, in *InjectLaunchSecr
 	return out, nil
 }
 
+func (c *cmdClient) SyncVirtualMachineIOTune(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/SyncVirtualMachineIOTune", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) GuestFSTrim(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*GuestFSTrimResponse, error) {
+	out := new(GuestFSTrimResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GuestFSTrim", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Cmd service
 
 type CmdServer interface {
@@ -1259,6 +1305,8 @@ type CmdServer interface {
 	GetSEVInfo(context.Context, *EmptyRequest) (*SEVInfoResponse, error)
 	GetLaunchMeasurement(context.Context, *VMIRequest) (*LaunchMeasurementResponse, error)
 	InjectLaunchSecret(context.Context, *InjectLaunchSecretRequest) (*Response, error)
+	SyncVirtualMachineIOTune(context.Context, *VMIRequest) (*Response, error)
+	GuestFSTrim(context.Context, *VMIRequest) (*GuestFSTrimResponse, error)
 }
 
 func RegisterCmdServer(s *grpc.Server, srv CmdServer) {
@@ -1787,6 +1835,42 @@ func _Cmd_InjectLaunchSecret_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cmd_SyncVirtualMachineIOTune_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).SyncVirtualMachineIOTune(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/SyncVirtualMachineIOTune",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).SyncVirtualMachineIOTune(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_GuestFSTrim_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).GuestFSTrim(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/GuestFSTrim",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).GuestFSTrim(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Cmd_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "kubevirt.cmd.v1.Cmd",
 	HandlerType: (*CmdServer)(nil),
@@ -1907,6 +1991,14 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 			MethodName: "InjectLaunchSecret",
 			Handler:    _Cmd_InjectLaunchSecret_Handler,
 		},
+		{
+			MethodName: "SyncVirtualMachineIOTune",
+			Handler:    _Cmd_SyncVirtualMachineIOTune_Handler,
+		},
+		{
+			MethodName: "GuestFSTrim",
+			Handler:    _Cmd_GuestFSTrim_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/handler-launcher-com/cmd/v1/cmd.proto",
@@ -1915,108 +2007,110 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/handler-launcher-com/cmd/v1/cmd.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1633 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x6d, 0x6f, 0x1b, 0xc7,
-	0x11, 0x16, 0x45, 0x4a, 0x26, 0x47, 0x2f, 0xb1, 0x57, 0x2f, 0x3d, 0xab, 0xb5, 0xad, 0x2e, 0x0a,
-	0x43, 0x29, 0x12, 0xa9, 0x76, 0x9d, 0xa0, 0x08, 0x8a, 0x22, 0x35, 0x45, 0x2b, 0x4e, 0x42, 0x9b,
-	0x39, 0x4a, 0x32, 0x9a, 0x36, 0x08, 0x56, 0x77, 0xab, 0xd3, 0x56, 0x77, 0xbb, 0xd7, 0xdb, 0x3d,
-	0xd6, 0x0c, 0x50, 0xa0, 0x40, 0x8b, 0x7e, 0x28, 0xd0, 0x3f, 0xd1, 0x2f, 0xfd, 0x49, 0xfd, 0x3b,
-	0xc1, 0xee, 0xed, 0x51, 0x47, 0xde, 0xd1, 0xb4, 0x40, 0x7e, 0xe2, 0xcd, 0xee, 0xcc, 0x33, 0x73,
-	0xb3, 0x33, 0xb3, 0x0f, 0x0f, 0x3e, 0x8c, 0xaf, 0x83, 0xa3, 0x2b, 0xc2, 0xfd, 0x90, 0x26, 0x1f,
-	0x87, 0x24, 0xe5, 0xde, 0x15, 0x4d, 0x3e, 0xf6, 0x44, 0x74, 0xe4, 0x45, 0xfe, 0xd1, 0xe0, 0x89,
-	0xfe, 0x39, 0x8c, 0x13, 0xa1, 0x04, 0xfa, 0xe0, 0x3a, 0xbd, 0xa0, 0x03, 0x96, 0xa8, 0x43, 0xbd,
-	0x36, 0x78, 0x82, 0x2f, 0x61, 0xeb, 0x1b, 0x1a, 0xa5, 0xe7, 0x34, 0x91, 0x4c, 0x70, 0x97, 0xca,
-	0x58, 0x70, 0x49, 0xd1, 0x27, 0xd0, 0x4c, 0xec, 0xb3, 0x53, 0xdb, 0xaf, 0x1d, 0xac, 0x3d, 0xbd,
-	0x7f, 0x38, 0x61, 0x7a, 0x98, 0x2b, 0xbb, 0x23, 0x55, 0xe4, 0xc0, 0x9d, 0x41, 0x86, 0xe4, 0x2c,
-	0xef, 0xd7, 0x0e, 0x5a, 0x6e, 0x2e, 0xe2, 0x47, 0x50, 0x3f, 0xef, 0xbe, 0x34, 0x0a, 0x11, 0xfb,
-	0x52, 0x0a, 0x6e, 0x60, 0xd7, 0xdd, 0x5c, 0xc4, 0x4f, 0xa0, 0xde, 0xee, 0x9d, 0xa1, 0x4d, 0x58,
-	0x66, 0xbe, 0xd9, 0xdb, 0x70, 0x97, 0x99, 0x8f, 0xf6, 0xa0, 0x29, 0xd9, 0x45, 0xc8, 0x78, 0x20,
-	0x9d, 0xe5, 0xfd, 0xfa, 0xc1, 0x86, 0x3b, 0x92, 0xf1, 0x11, 0xdc, 0xe9, 0x67, 0xcf, 0x25, 0xb3,
-	0x6d, 0x58, 0x19, 0x90, 0x30, 0xa5, 0x26, 0x8c, 0x86, 0x9b, 0x09, 0xb8, 0x03, 0x2b, 0x3d, 0x12,
-	0x50, 0xa9, 0xb7, 0x3d, 0x91, 0x72, 0x65, 0x2c, 0x1a, 0x6e, 0x26, 0x20, 0x04, 0x8d, 0x94, 0x33,
-	0x65, 0x43, 0x37, 0xcf, 0x7a, 0x4d, 0xb2, 0x1f, 0xa8, 0x53, 0x37, 0xd0, 0xe6, 0x19, 0x3f, 0x83,
-	0xd5, 0x2e, 0x8d, 0x44, 0x32, 0x44, 0xbb, 0xb0, 0x4a, 0xa2, 0x02, 0x90, 0x95, 0xaa, 0x90, 0xf0,
-	0xff, 0x6b, 0xd0, 0x68, 0xd3, 0x30, 0x2c, 0xc5, 0x7a, 0x04, 0xab, 0x91, 0x81, 0x33, 0xea, 0x6b,
-	0x4f, 0x7f, 0x52, 0xca, 0x74, 0xe6, 0xcd, 0xb5, 0x6a, 0xe8, 0x23, 0x58, 0x89, 0xf5, 0x6b, 0x38,
-	0xf5, 0xfd, 0xfa, 0xc1, 0xda, 0xd3, 0xdd, 0x92, 0xbe, 0x79, 0x49, 0x37, 0x53, 0x42, 0x9f, 0x42,
-	0xcb, 0x67, 0x52, 0x11, 0xee, 0x51, 0xe9, 0x34, 0x8c, 0x85, 0x53, 0xb2, 0xb0, 0x79, 0x74, 0x6f,
-	0x54, 0xd1, 0x01, 0x34, 0xbc, 0x38, 0x95, 0xce, 0x8a, 0x31, 0xd9, 0x2e, 0x99, 0xb4, 0x7b, 0x67,
-	0xae, 0xd1, 0xc0, 0x9f, 0x43, 0xf3, 0x54, 0xc4, 0x22, 0x14, 0xc1, 0x10, 0x3d, 0x03, 0xe0, 0x69,
-	0x44, 0xbe, 0xf7, 0x68, 0x18, 0x4a, 0xa7, 0x66, 0x6c, 0x77, 0xca, 0xb6, 0x34, 0x0c, 0xdd, 0x96,
-	0x56, 0xd4, 0x4f, 0x12, 0xff, 0xbb, 0x06, 0xab, 0xfd, 0xee, 0x73, 0x26, 0x24, 0xc2, 0xb0, 0x1e,
-	0x11, 0x9e, 0x5e, 0x12, 0x4f, 0xa5, 0x09, 0x4d, 0x4c, 0x9e, 0x5a, 0xee, 0xd8, 0x9a, 0xae, 0xa2,
-	0x38, 0x11, 0x7e, 0xea, 0xe5, 0x19, 0xce, 0xc5, 0x62, 0x01, 0xd6, 0xc7, 0x0a, 0x10, 0xdd, 0x85,
-	0xba, 0xbc, 0x4e, 0x9d, 0x86, 0x59, 0xd5, 0x8f, 0xfa, 0xf0, 0x2e, 0x49, 0xc4, 0xc2, 0xa1, 0xb3,
-	0x62, 0x16, 0xad, 0x84, 0xff, 0x55, 0x83, 0xe6, 0x31, 0x93, 0xd7, 0x2f, 0xf9, 0xa5, 0x30, 0x4a,
-	0x22, 0x89, 0x88, 0xb2, 0x81, 0x58, 0x09, 0xed, 0xc3, 0xda, 0x05, 0xf1, 0xae, 0x19, 0x0f, 0x5e,
-	0xb0, 0x90, 0xda, 0x30, 0x8a, 0x4b, 0xe8, 0x21, 0x80, 0x8e, 0x97, 0x84, 0xfd, 0xbc, 0x7e, 0x1a,
-	0x6e, 0x61, 0x45, 0x23, 0xe8, 0x94, 0xe4, 0x0a, 0x0d, 0xa3, 0x50, 0x5c, 0xc2, 0x7f, 0x83, 0x8d,
-	0x76, 0x98, 0x4a, 0x45, 0x93, 0xb6, 0xe0, 0x97, 0x2c, 0x40, 0x87, 0x80, 0x3a, 0x6f, 0x63, 0xc2,
-	0x7d, 0x1d, 0x9e, 0xec, 0x70, 0x72, 0x11, 0xd2, 0xac, 0x92, 0x9a, 0x6e, 0xc5, 0x0e, 0xfa, 0x2d,
-	0xdc, 0x7f, 0x91, 0x50, 0xaa, 0xcb, 0xc1, 0xa5, 0xb1, 0x48, 0x14, 0xe3, 0xc1, 0x31, 0x93, 0x99,
-	0xd9, 0xb2, 0x31, 0x9b, 0xae, 0x80, 0xff, 0xd7, 0x80, 0x9d, 0xf3, 0x2c, 0x9c, 0x2e, 0xf1, 0xae,
-	0x18, 0xa7, 0xaf, 0x63, 0xc5, 0x04, 0x97, 0xe8, 0x2b, 0xd8, 0x1e, 0xdf, 0xc8, 0xce, 0xce, 0x4e,
-	0x8a, 0x72, 0xfd, 0x66, 0xdb, 0x6e, 0xa5, 0x11, 0x7a, 0x06, 0x3b, 0x5d, 0x1a, 0x3d, 0x27, 0x61,
-	0x28, 0x04, 0xef, 0x2b, 0xa2, 0x64, 0x8f, 0x26, 0x4c, 0x64, 0x01, 0x6e, 0xb8, 0xd5, 0x9b, 0xe8,
-	0x57, 0xb0, 0xd5, 0x4b, 0xa8, 0x5e, 0xf7, 0x88, 0xa2, 0xfe, 0xb9, 0x08, 0xd3, 0xc8, 0x76, 0x44,
-	0xcb, 0xad, 0xda, 0xd2, 0x23, 0x4d, 0xd9, 0x2a, 0x35, 0xc9, 0xae, 0x1a, 0x69, 0x79, 0x19, 0xbb,
-	0x23, 0x55, 0xd4, 0x87, 0x96, 0xc9, 0xa9, 0xae, 0x06, 0xdb, 0x0b, 0x9f, 0x94, 0xec, 0x2a, 0xd3,
-	0x74, 0x38, 0xb2, 0xeb, 0x70, 0x95, 0x0c, 0xdd, 0x1b, 0x9c, 0x29, 0x07, 0xb9, 0x3a, 0xf5, 0x20,
-	0x8f, 0x61, 0xc3, 0x2b, 0x56, 0x82, 0x73, 0xc7, 0xbc, 0xc0, 0xc3, 0x72, 0x63, 0x15, 0xb5, 0xdc,
-	0x71, 0xa3, 0xbd, 0x37, 0xb0, 0x39, 0x1e, 0x92, 0x6e, 0x8a, 0x6b, 0x3a, 0xb4, 0xa5, 0xad, 0x1f,
-	0xd1, 0x51, 0x71, 0x70, 0x56, 0xa5, 0x28, 0xef, 0x0c, 0x3b, 0x53, 0x3f, 0x5b, 0xfe, 0x4d, 0x0d,
-	0x0f, 0x00, 0xce, 0xbb, 0x2f, 0x5d, 0xfa, 0x97, 0x94, 0x4a, 0x85, 0x1e, 0x43, 0x7d, 0x10, 0x31,
-	0x5b, 0x0c, 0xe5, 0xb9, 0xa1, 0x35, 0xb5, 0x02, 0xfa, 0x1c, 0xee, 0x88, 0x2c, 0x53, 0xd6, 0xd9,
-	0xe3, 0xf7, 0xcb, 0xab, 0x9b, 0x9b, 0xe1, 0x53, 0xb8, 0xdb, 0x65, 0x41, 0x42, 0x94, 0xb9, 0xba,
-	0x6e, 0xe7, 0xdd, 0x19, 0xf7, 0xbe, 0x7e, 0x83, 0xfa, 0x8f, 0x1a, 0xac, 0x75, 0xde, 0x52, 0x2f,
-	0x47, 0x7c, 0x08, 0xe0, 0x8b, 0x88, 0x30, 0xfe, 0x8a, 0x44, 0xd4, 0xe6, 0xaa, 0xb0, 0xa2, 0x91,
-	0xda, 0x22, 0x8a, 0x08, 0xf7, 0xf3, 0x69, 0x64, 0x45, 0x7d, 0x0d, 0xfc, 0x3e, 0x09, 0xf2, 0xaa,
-	0x34, 0xcf, 0xe8, 0x31, 0x6c, 0x2a, 0x16, 0x51, 0x91, 0xaa, 0x3e, 0xf5, 0x04, 0xf7, 0xa5, 0x29,
-	0xc6, 0x15, 0x77, 0x62, 0x15, 0x6f, 0xc2, 0x7a, 0x27, 0x8a, 0xd5, 0xd0, 0x46, 0x81, 0x7f, 0x07,
-	0x4d, 0xb7, 0x70, 0xcd, 0xca, 0xd4, 0xf3, 0xa8, 0x94, 0xb6, 0xf9, 0x73, 0x51, 0xef, 0x44, 0x54,
-	0x4a, 0x12, 0xe4, 0x23, 0x29, 0x17, 0xf1, 0xf7, 0xb0, 0x79, 0x6c, 0x62, 0x9e, 0xf7, 0x8e, 0xdf,
-	0x85, 0xd5, 0xec, 0xe5, 0xad, 0x07, 0x2b, 0x61, 0x0e, 0x5b, 0x99, 0x03, 0xd3, 0xa6, 0xf3, 0x7a,
-	0xd9, 0x87, 0x35, 0xff, 0x06, 0x2d, 0x9f, 0xaf, 0x85, 0x25, 0xfc, 0x16, 0xee, 0x9d, 0xe8, 0xcc,
-	0x98, 0x62, 0x9c, 0xd3, 0xdb, 0x47, 0x70, 0x2f, 0x98, 0xc4, 0xb2, 0x3e, 0xcb, 0x1b, 0xf8, 0x9f,
-	0x35, 0xd8, 0x31, 0xae, 0xcf, 0x24, 0x4d, 0xbe, 0x66, 0x52, 0xcd, 0xeb, 0xfe, 0x19, 0xec, 0x04,
-	0x55, 0x78, 0x36, 0x84, 0xea, 0x4d, 0xfc, 0x9f, 0x1a, 0x38, 0x26, 0x0c, 0x7d, 0xdd, 0xc8, 0xa1,
-	0x54, 0x34, 0x9a, 0x3b, 0xed, 0x9f, 0x81, 0x13, 0x4c, 0x81, 0xb4, 0xc1, 0x4c, 0xdd, 0xc7, 0x43,
-	0x58, 0xcf, 0xda, 0x66, 0xbe, 0x10, 0xf6, 0xa0, 0x49, 0xdf, 0x32, 0xd5, 0x16, 0x7e, 0xe6, 0x72,
-	0xc5, 0x1d, 0xc9, 0xba, 0xf6, 0xa4, 0xf2, 0x5f, 0xa7, 0xca, 0xde, 0xee, 0x56, 0xc2, 0xdf, 0xc2,
-	0x5d, 0x93, 0x89, 0x9e, 0xe6, 0x30, 0xef, 0xd9, 0xb6, 0xe5, 0x46, 0x5c, 0xae, 0x6c, 0xc4, 0x2f,
-	0x6d, 0x9d, 0x65, 0xd8, 0x73, 0xbd, 0x1b, 0x16, 0xb0, 0xa1, 0xef, 0xdb, 0x1f, 0xe8, 0x6d, 0xa7,
-	0xd5, 0xa7, 0xb0, 0x9b, 0xf2, 0x4b, 0x63, 0x7a, 0x5a, 0x15, 0xf4, 0x94, 0x5d, 0xfc, 0x06, 0xee,
-	0x65, 0xe4, 0xf1, 0x38, 0x8d, 0xe2, 0xdb, 0x3a, 0xdd, 0x83, 0xa6, 0x9f, 0x46, 0x71, 0x8f, 0xa8,
-	0x2b, 0x7b, 0xf8, 0x23, 0x19, 0x5f, 0xc0, 0x07, 0xfd, 0xce, 0xf9, 0x22, 0x7a, 0x4f, 0x0f, 0x33,
-	0x3a, 0x30, 0xd7, 0xab, 0x1d, 0xc4, 0x56, 0xc4, 0x7f, 0xaf, 0xc1, 0xfd, 0xaf, 0xcd, 0xdf, 0x99,
-	0x2e, 0x25, 0x32, 0x4d, 0x68, 0x44, 0xb9, 0x5a, 0x40, 0xab, 0x87, 0x93, 0x98, 0xd6, 0x71, 0x79,
-	0x03, 0x7f, 0x07, 0xf7, 0x5f, 0xf2, 0x3f, 0x53, 0x4f, 0x65, 0x71, 0xf4, 0xa9, 0x97, 0x50, 0xb5,
-	0xb0, 0xab, 0xe6, 0xe9, 0x7f, 0xb7, 0xa0, 0xde, 0x8e, 0x7c, 0xf4, 0x0a, 0x50, 0x7f, 0xc8, 0xbd,
-	0xf1, 0xeb, 0x0e, 0xfd, 0xb4, 0x12, 0x32, 0x73, 0xbe, 0x37, 0xfd, 0x65, 0xf1, 0x12, 0x7a, 0x0d,
-	0x5b, 0x3d, 0x92, 0x4a, 0xba, 0x30, 0xc0, 0x6f, 0x60, 0xe7, 0x8c, 0xc7, 0x0b, 0x85, 0xec, 0xc3,
-	0x76, 0xd6, 0x0b, 0x13, 0x88, 0x65, 0x52, 0x33, 0xd6, 0x32, 0xef, 0x06, 0x75, 0x61, 0xf7, 0xcc,
-	0x76, 0xc2, 0xc2, 0x02, 0x3d, 0x05, 0xa7, 0x2f, 0x2e, 0x95, 0x4b, 0x2f, 0x84, 0x50, 0x0b, 0x43,
-	0x75, 0x61, 0xb7, 0x7f, 0x95, 0x2a, 0x5f, 0xfc, 0x95, 0x2f, 0x0c, 0xf3, 0x15, 0xa0, 0xaf, 0x58,
-	0x18, 0x2e, 0x0c, 0xaf, 0x07, 0xdb, 0xc7, 0x34, 0xa4, 0x6a, 0x71, 0xb9, 0x7c, 0x03, 0x3b, 0x19,
-	0x63, 0x9b, 0x84, 0xfc, 0x79, 0xf9, 0x4f, 0xef, 0x04, 0xb3, 0x9b, 0x59, 0xf1, 0xba, 0x83, 0x46,
-	0x46, 0xa7, 0x24, 0x09, 0xa8, 0x9a, 0x23, 0xd2, 0x3f, 0xc0, 0x83, 0xb6, 0xfe, 0x23, 0x3c, 0x91,
-	0xcd, 0x91, 0x83, 0x39, 0x8f, 0x9e, 0x05, 0x9c, 0x84, 0x59, 0x90, 0x3d, 0xe1, 0xb7, 0x43, 0x4a,
-	0x78, 0x1a, 0xcf, 0x81, 0xf9, 0x47, 0x78, 0xf4, 0x82, 0x71, 0x12, 0xb2, 0xc9, 0xc2, 0x5f, 0x44,
-	0xc0, 0xaf, 0x00, 0x7d, 0x21, 0x54, 0x1c, 0xa6, 0xc1, 0x17, 0x42, 0xaa, 0x63, 0x3a, 0x60, 0x1e,
-	0x95, 0x73, 0xe0, 0x75, 0xa1, 0x75, 0x42, 0x55, 0xc6, 0x16, 0xd1, 0x83, 0x92, 0x66, 0x91, 0xf7,
-	0xee, 0x3d, 0x2a, 0xff, 0x03, 0x19, 0xa3, 0xb1, 0xa6, 0xa8, 0x36, 0x47, 0x70, 0x86, 0x1b, 0xce,
-	0xc2, 0xfc, 0xc5, 0x14, 0xcc, 0x31, 0xe6, 0x6a, 0x46, 0xd4, 0xfa, 0x09, 0x55, 0x23, 0x96, 0x39,
-	0x0b, 0x16, 0x97, 0xb6, 0x4b, 0x04, 0xd5, 0x80, 0x36, 0x4f, 0xa8, 0x61, 0x73, 0x33, 0xe3, 0x7c,
-	0x5c, 0x0d, 0x58, 0x62, 0x82, 0x4b, 0xe8, 0x4f, 0x26, 0x05, 0x05, 0x56, 0x36, 0x0b, 0xfa, 0xc3,
-	0x6a, 0xe8, 0x2a, 0x5e, 0xb7, 0x84, 0x9e, 0x43, 0x43, 0xb3, 0x9f, 0x59, 0x98, 0xef, 0x3c, 0xf3,
-	0x0e, 0x34, 0x34, 0x3b, 0x44, 0x3f, 0x2b, 0x63, 0xdc, 0xfc, 0xd7, 0xda, 0x7b, 0x30, 0x65, 0xb7,
-	0x30, 0x8c, 0x5b, 0x23, 0x36, 0x56, 0x31, 0x34, 0x26, 0x59, 0xe0, 0xb4, 0x33, 0x29, 0x92, 0x39,
-	0xd3, 0x3d, 0xce, 0x44, 0xd7, 0x8c, 0x48, 0x13, 0xc2, 0x53, 0x3e, 0xc7, 0x15, 0x18, 0xd5, 0xac,
-	0x99, 0xa7, 0xcf, 0xa6, 0xf0, 0x95, 0xf5, 0xf6, 0xe5, 0x59, 0xf1, 0x89, 0xd6, 0xce, 0x91, 0x12,
-	0x6b, 0x68, 0xf7, 0xce, 0xe4, 0x5c, 0xcc, 0x01, 0x4e, 0xa8, 0xb2, 0xd4, 0x6e, 0x56, 0xa0, 0xfb,
-	0xe5, 0x2f, 0x3d, 0xe3, 0x9c, 0x10, 0x2f, 0x21, 0x02, 0xdb, 0x27, 0x54, 0x95, 0x68, 0xdc, 0xbb,
-	0x43, 0xfc, 0x65, 0x69, 0x73, 0x2a, 0x0f, 0xc4, 0x4b, 0xe8, 0x3b, 0x40, 0x65, 0x92, 0x86, 0xca,
-	0x18, 0x53, 0x99, 0xdc, 0x3b, 0x53, 0xf2, 0xbc, 0xf1, 0xed, 0xf2, 0xe0, 0xc9, 0xc5, 0xaa, 0xf9,
-	0x80, 0xfe, 0xeb, 0x1f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x62, 0x62, 0x8a, 0x89, 0x6d, 0x17, 0x00,
-	0x00,
+	// 1673 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0x6d, 0x6f, 0x1b, 0xc7,
+	0x11, 0x16, 0x45, 0x4a, 0xa6, 0x46, 0x2f, 0xb1, 0x57, 0x2f, 0x3d, 0xab, 0xb5, 0xad, 0x2e, 0x02,
+	0x43, 0x29, 0x12, 0x29, 0x76, 0x9d, 0xa0, 0x08, 0x8a, 0x22, 0x35, 0x25, 0x2b, 0x4a, 0x42, 0x9b,
+	0x39, 0x4a, 0x32, 0x9a, 0x36, 0x08, 0x56, 0x77, 0xcb, 0xd3, 0x56, 0x77, 0xbb, 0xd7, 0xdb, 0x3d,
+	0xd6, 0x34, 0xd0, 0xa2, 0x40, 0x8b, 0x7e, 0x28, 0xd0, 0xdf, 0xd1, 0x3f, 0xd3, 0xef, 0xfd, 0x3b,
+	0xc5, 0xee, 0xed, 0x51, 0x47, 0xde, 0x51, 0x8c, 0x43, 0x7e, 0xd2, 0xcd, 0xce, 0xcc, 0xb3, 0x73,
+	0xb3, 0x33, 0xb3, 0xcf, 0x51, 0xf0, 0x41, 0x7c, 0x1d, 0x1c, 0x5e, 0x11, 0xee, 0x87, 0x34, 0xf9,
+	0x28, 0x24, 0x29, 0xf7, 0xae, 0x68, 0xf2, 0x91, 0x27, 0xa2, 0x43, 0x2f, 0xf2, 0x0f, 0xfb, 0x4f,
+	0xf4, 0x9f, 0x83, 0x38, 0x11, 0x4a, 0xa0, 0xf7, 0xae, 0xd3, 0x4b, 0xda, 0x67, 0x89, 0x3a, 0xd0,
+	0x6b, 0xfd, 0x27, 0xb8, 0x07, 0x9b, 0xdf, 0xd0, 0x28, 0xbd, 0xa0, 0x89, 0x64, 0x82, 0xbb, 0x54,
+	0xc6, 0x82, 0x4b, 0x8a, 0x3e, 0x81, 0x66, 0x62, 0x9f, 0x9d, 0xda, 0x5e, 0x6d, 0x7f, 0xf5, 0xe9,
+	0xfd, 0x83, 0x31, 0xd7, 0x83, 0xdc, 0xd8, 0x1d, 0x9a, 0x22, 0x07, 0xee, 0xf4, 0x33, 0x24, 0x67,
+	0x71, 0xaf, 0xb6, 0xbf, 0xe2, 0xe6, 0x22, 0x7e, 0x04, 0xf5, 0x8b, 0xf6, 0xa9, 0x31, 0x88, 0xd8,
+	0x97, 0x52, 0x70, 0x03, 0xbb, 0xe6, 0xe6, 0x22, 0x7e, 0x02, 0xf5, 0x56, 0xe7, 0x1c, 0x6d, 0xc0,
+	0x22, 0xf3, 0x8d, 0x6e, 0xdd, 0x5d, 0x64, 0x3e, 0xda, 0x85, 0xa6, 0x64, 0x97, 0x21, 0xe3, 0x81,
+	0x74, 0x16, 0xf7, 0xea, 0xfb, 0xeb, 0xee, 0x50, 0xc6, 0x87, 0x70, 0xa7, 0x9b, 0x3d, 0x97, 0xdc,
+	0xb6, 0x60, 0xa9, 0x4f, 0xc2, 0x94, 0x9a, 0x30, 0x1a, 0x6e, 0x26, 0xe0, 0x63, 0x58, 0xea, 0x90,
+	0x80, 0x4a, 0xad, 0xf6, 0x44, 0xca, 0x95, 0xf1, 0x68, 0xb8, 0x99, 0x80, 0x10, 0x34, 0x52, 0xce,
+	0x94, 0x0d, 0xdd, 0x3c, 0xeb, 0x35, 0xc9, 0xde, 0x52, 0xa7, 0x6e, 0xa0, 0xcd, 0x33, 0x7e, 0x06,
+	0xcb, 0x6d, 0x1a, 0x89, 0x64, 0x80, 0x76, 0x60, 0x99, 0x44, 0x05, 0x20, 0x2b, 0x55, 0x21, 0xe1,
+	0xff, 0xd5, 0xa0, 0xd1, 0xa2, 0x61, 0x58, 0x8a, 0xf5, 0x10, 0x96, 0x23, 0x03, 0x67, 0xcc, 0x57,
+	0x9f, 0xfe, 0xa4, 0x94, 0xe9, 0x6c, 0x37, 0xd7, 0x9a, 0xa1, 0x0f, 0x61, 0x29, 0xd6, 0xaf, 0xe1,
+	0xd4, 0xf7, 0xea, 0xfb, 0xab, 0x4f, 0x77, 0x4a, 0xf6, 0xe6, 0x25, 0xdd, 0xcc, 0x08, 0x7d, 0x0a,
+	0x2b, 0x3e, 0x93, 0x8a, 0x70, 0x8f, 0x4a, 0xa7, 0x61, 0x3c, 0x9c, 0x92, 0x87, 0xcd, 0xa3, 0x7b,
+	0x63, 0x8a, 0xf6, 0xa1, 0xe1, 0xc5, 0xa9, 0x74, 0x96, 0x8c, 0xcb, 0x56, 0xc9, 0xa5, 0xd5, 0x39,
+	0x77, 0x8d, 0x05, 0xfe, 0x1c, 0x9a, 0x67, 0x22, 0x16, 0xa1, 0x08, 0x06, 0xe8, 0x19, 0x00, 0x4f,
+	0x23, 0xf2, 0xbd, 0x47, 0xc3, 0x50, 0x3a, 0x35, 0xe3, 0xbb, 0x5d, 0xf6, 0xa5, 0x61, 0xe8, 0xae,
+	0x68, 0x43, 0xfd, 0x24, 0xf1, 0xbf, 0x6a, 0xb0, 0xdc, 0x6d, 0x3f, 0x67, 0x42, 0x22, 0x0c, 0x6b,
+	0x11, 0xe1, 0x69, 0x8f, 0x78, 0x2a, 0x4d, 0x68, 0x62, 0xf2, 0xb4, 0xe2, 0x8e, 0xac, 0xe9, 0x2a,
+	0x8a, 0x13, 0xe1, 0xa7, 0x5e, 0x9e, 0xe1, 0x5c, 0x2c, 0x16, 0x60, 0x7d, 0xa4, 0x00, 0xd1, 0x5d,
+	0xa8, 0xcb, 0xeb, 0xd4, 0x69, 0x98, 0x55, 0xfd, 0xa8, 0x0f, 0xaf, 0x47, 0x22, 0x16, 0x0e, 0x9c,
+	0x25, 0xb3, 0x68, 0x25, 0xfc, 0xcf, 0x1a, 0x34, 0x8f, 0x98, 0xbc, 0x3e, 0xe5, 0x3d, 0x61, 0x8c,
+	0x44, 0x12, 0x11, 0x65, 0x03, 0xb1, 0x12, 0xda, 0x83, 0xd5, 0x4b, 0xe2, 0x5d, 0x33, 0x1e, 0xbc,
+	0x60, 0x21, 0xb5, 0x61, 0x14, 0x97, 0xd0, 0x43, 0x00, 0x1d, 0x2f, 0x09, 0xbb, 0x79, 0xfd, 0x34,
+	0xdc, 0xc2, 0x8a, 0x46, 0xd0, 0x29, 0xc9, 0x0d, 0x1a, 0xc6, 0xa0, 0xb8, 0x84, 0xff, 0x02, 0xeb,
+	0xad, 0x30, 0x95, 0x8a, 0x26, 0x2d, 0xc1, 0x7b, 0x2c, 0x40, 0x07, 0x80, 0x8e, 0xdf, 0xc4, 0x84,
+	0xfb, 0x3a, 0x3c, 0x79, 0xcc, 0xc9, 0x65, 0x48, 0xb3, 0x4a, 0x6a, 0xba, 0x15, 0x1a, 0xf4, 0x6b,
+	0xb8, 0xff, 0x22, 0xa1, 0x54, 0x97, 0x83, 0x4b, 0x63, 0x91, 0x28, 0xc6, 0x83, 0x23, 0x26, 0x33,
+	0xb7, 0x45, 0xe3, 0x36, 0xd9, 0x00, 0xff, 0xa7, 0x01, 0xdb, 0x17, 0x59, 0x38, 0x6d, 0xe2, 0x5d,
+	0x31, 0x4e, 0x5f, 0xc5, 0x8a, 0x09, 0x2e, 0xd1, 0x57, 0xb0, 0x35, 0xaa, 0xc8, 0xce, 0xce, 0xa9,
+	0x4d, 0xa8, 0xdf, 0x4c, 0xed, 0x56, 0x3a, 0xa1, 0x67, 0xb0, 0xdd, 0xa6, 0xd1, 0x73, 0x12, 0x86,
+	0x42, 0xf0, 0xae, 0x22, 0x4a, 0x76, 0x68, 0xc2, 0x44, 0x16, 0xe0, 0xba, 0x5b, 0xad, 0x44, 0x1f,
+	0xc3, 0x66, 0x27, 0xa1, 0x7a, 0xdd, 0x23, 0x8a, 0xfa, 0x17, 0x22, 0x4c, 0x23, 0xdb, 0x11, 0x2b,
+	0x6e, 0x95, 0x4a, 0x8f, 0x34, 0x65, 0xab, 0xd4, 0x69, 0x4c, 0x18, 0x69, 0x79, 0x19, 0xbb, 0x43,
+	0x53, 0xd4, 0x85, 0x15, 0x93, 0x53, 0x5d, 0x0d, 0xb6, 0x17, 0x3e, 0x29, 0xf9, 0x55, 0xa6, 0xe9,
+	0x60, 0xe8, 0x77, 0xcc, 0x55, 0x32, 0x70, 0x6f, 0x70, 0x26, 0x1c, 0xe4, 0xf2, 0xc4, 0x83, 0x3c,
+	0x82, 0x75, 0xaf, 0x58, 0x09, 0xce, 0x1d, 0xf3, 0x02, 0x0f, 0xcb, 0x8d, 0x55, 0xb4, 0x72, 0x47,
+	0x9d, 0x76, 0x5f, 0xc3, 0xc6, 0x68, 0x48, 0xba, 0x29, 0xae, 0xe9, 0xc0, 0x96, 0xb6, 0x7e, 0x44,
+	0x87, 0xc5, 0xc1, 0x59, 0x95, 0xa2, 0xbc, 0x33, 0xec, 0x4c, 0xfd, 0x6c, 0xf1, 0x57, 0x35, 0xdc,
+	0x07, 0xb8, 0x68, 0x9f, 0xba, 0xf4, 0x4f, 0x29, 0x95, 0x0a, 0x3d, 0x86, 0x7a, 0x3f, 0x62, 0xb6,
+	0x18, 0xca, 0x73, 0x43, 0x5b, 0x6a, 0x03, 0xf4, 0x39, 0xdc, 0x11, 0x59, 0xa6, 0xec, 0x66, 0x8f,
+	0x7f, 0x58, 0x5e, 0xdd, 0xdc, 0x0d, 0x9f, 0xc1, 0xdd, 0x36, 0x0b, 0x12, 0xa2, 0xcc, 0xd5, 0xf5,
+	0x6e, 0xbb, 0x3b, 0xa3, 0xbb, 0xaf, 0xdd, 0xa0, 0xfe, 0xbd, 0x06, 0xab, 0xc7, 0x6f, 0xa8, 0x97,
+	0x23, 0x3e, 0x04, 0xf0, 0x45, 0x44, 0x18, 0x7f, 0x49, 0x22, 0x6a, 0x73, 0x55, 0x58, 0xd1, 0x48,
+	0x2d, 0x11, 0x45, 0x84, 0xfb, 0xf9, 0x34, 0xb2, 0xa2, 0xbe, 0x06, 0x7e, 0x9b, 0x04, 0x79, 0x55,
+	0x9a, 0x67, 0xf4, 0x18, 0x36, 0x14, 0x8b, 0xa8, 0x48, 0x55, 0x97, 0x7a, 0x82, 0xfb, 0xd2, 0x14,
+	0xe3, 0x92, 0x3b, 0xb6, 0x8a, 0x37, 0x60, 0xed, 0x38, 0x8a, 0xd5, 0xc0, 0x46, 0x81, 0x7f, 0x03,
+	0x4d, 0xb7, 0x70, 0xcd, 0xca, 0xd4, 0xf3, 0xa8, 0x94, 0xb6, 0xf9, 0x73, 0x51, 0x6b, 0x22, 0x2a,
+	0x25, 0x09, 0xf2, 0x91, 0x94, 0x8b, 0xf8, 0x7b, 0xd8, 0x38, 0x32, 0x31, 0xcf, 0x7a, 0xc7, 0xef,
+	0xc0, 0x72, 0xf6, 0xf2, 0x76, 0x07, 0x2b, 0x61, 0x0e, 0x9b, 0xd9, 0x06, 0xa6, 0x4d, 0x67, 0xdd,
+	0x65, 0x0f, 0x56, 0xfd, 0x1b, 0xb4, 0x7c, 0xbe, 0x16, 0x96, 0xf0, 0x1b, 0xb8, 0x77, 0xa2, 0x33,
+	0x63, 0x8a, 0x71, 0xc6, 0xdd, 0x3e, 0x84, 0x7b, 0xc1, 0x38, 0x96, 0xdd, 0xb3, 0xac, 0xc0, 0xff,
+	0xa8, 0xc1, 0xb6, 0xd9, 0xfa, 0x5c, 0xd2, 0xe4, 0x6b, 0x26, 0xd5, 0xac, 0xdb, 0x3f, 0x83, 0xed,
+	0xa0, 0x0a, 0xcf, 0x86, 0x50, 0xad, 0xc4, 0xff, 0xae, 0x81, 0x63, 0xc2, 0xd0, 0xd7, 0x8d, 0x1c,
+	0x48, 0x45, 0xa3, 0x99, 0xd3, 0xfe, 0x19, 0x38, 0xc1, 0x04, 0x48, 0x1b, 0xcc, 0x44, 0x3d, 0x1e,
+	0xc0, 0x5a, 0xd6, 0x36, 0xb3, 0x85, 0xb0, 0x0b, 0x4d, 0xfa, 0x86, 0xa9, 0x96, 0xf0, 0xb3, 0x2d,
+	0x97, 0xdc, 0xa1, 0xac, 0x6b, 0x4f, 0x2a, 0xff, 0x55, 0xaa, 0xec, 0xed, 0x6e, 0x25, 0xfc, 0x2d,
+	0xdc, 0x35, 0x99, 0xe8, 0x68, 0x0e, 0xf3, 0x03, 0xdb, 0xb6, 0xdc, 0x88, 0x8b, 0x95, 0x8d, 0xf8,
+	0x25, 0xdc, 0x2b, 0x60, 0xcf, 0xf4, 0x6e, 0x58, 0xc0, 0xba, 0xbe, 0x6f, 0xdf, 0xd2, 0x77, 0x9d,
+	0x56, 0x9f, 0xc2, 0x4e, 0xca, 0x7b, 0xc6, 0xf5, 0xac, 0x2a, 0xe8, 0x09, 0x5a, 0xfc, 0x1a, 0xee,
+	0x65, 0xe4, 0xf1, 0x28, 0x8d, 0xe2, 0x77, 0xdd, 0x74, 0x17, 0x9a, 0x7e, 0x1a, 0xc5, 0x1d, 0xa2,
+	0xae, 0xec, 0xe1, 0x0f, 0x65, 0x7c, 0x09, 0xef, 0x75, 0x8f, 0x2f, 0xe6, 0xd1, 0x7b, 0x7a, 0x98,
+	0xd1, 0xbe, 0xb9, 0x5e, 0xed, 0x20, 0xb6, 0x22, 0xfe, 0x5b, 0x0d, 0xee, 0x7f, 0x6d, 0x3e, 0x67,
+	0xda, 0x94, 0xc8, 0x34, 0xa1, 0x11, 0xe5, 0x6a, 0x0e, 0xad, 0x1e, 0x8e, 0x63, 0xda, 0x8d, 0xcb,
+	0x0a, 0xfc, 0x1d, 0xdc, 0x3f, 0xe5, 0x7f, 0xa4, 0x9e, 0xca, 0xe2, 0xe8, 0x52, 0x2f, 0xa1, 0x6a,
+	0x7e, 0x57, 0xcd, 0x5f, 0x61, 0x33, 0xeb, 0xe0, 0xee, 0x59, 0xc2, 0xa2, 0x59, 0x5f, 0xed, 0x63,
+	0xd8, 0x0c, 0xca, 0x68, 0xf6, 0xe8, 0xaa, 0x54, 0x4f, 0xff, 0xbb, 0x05, 0xf5, 0x56, 0xe4, 0xa3,
+	0x97, 0x80, 0xba, 0x03, 0xee, 0x8d, 0x5e, 0xb7, 0xe8, 0xa7, 0x95, 0xaf, 0x94, 0xbd, 0xfc, 0xee,
+	0xe4, 0x88, 0xf0, 0x02, 0x7a, 0x05, 0x9b, 0x1d, 0x92, 0x4a, 0x3a, 0x37, 0xc0, 0x6f, 0x60, 0xfb,
+	0x9c, 0xc7, 0x73, 0x85, 0xec, 0xc2, 0x56, 0xd6, 0x8b, 0x63, 0x88, 0x65, 0x52, 0x35, 0xd2, 0xb2,
+	0xb7, 0x83, 0xba, 0xb0, 0x73, 0xce, 0x7b, 0x55, 0xb0, 0x3f, 0x3e, 0xd0, 0x33, 0x70, 0xba, 0xa2,
+	0xa7, 0x5c, 0x7a, 0x29, 0x84, 0x9a, 0x1b, 0xaa, 0x0b, 0x3b, 0xdd, 0xab, 0x54, 0xf9, 0xe2, 0xcf,
+	0x7c, 0x6e, 0x98, 0x2f, 0x01, 0x7d, 0xc5, 0xc2, 0x70, 0x6e, 0x78, 0x1d, 0xd8, 0x3a, 0xa2, 0x21,
+	0x55, 0xf3, 0xcb, 0xe5, 0x6b, 0xd8, 0xce, 0x18, 0xe3, 0x38, 0xe4, 0xcf, 0xcb, 0x1f, 0xdd, 0x63,
+	0xcc, 0x72, 0x6a, 0xc5, 0xeb, 0x0e, 0x1a, 0x3a, 0x9d, 0x91, 0x24, 0xa0, 0x6a, 0x86, 0x48, 0x7f,
+	0x07, 0x0f, 0x5a, 0xfa, 0x43, 0x7c, 0x2c, 0x9b, 0xc3, 0x0d, 0x66, 0x3c, 0x7a, 0x16, 0x70, 0x12,
+	0x66, 0x41, 0x76, 0x84, 0xdf, 0x0a, 0x29, 0xe1, 0x69, 0x3c, 0x03, 0xe6, 0xef, 0xe1, 0xd1, 0x0b,
+	0xc6, 0x49, 0xc8, 0xde, 0xd2, 0xf9, 0x07, 0xfc, 0x12, 0xd0, 0x17, 0x42, 0xc5, 0x61, 0x1a, 0x7c,
+	0x21, 0xa4, 0x3a, 0xa2, 0x7d, 0xe6, 0x51, 0x39, 0x03, 0x5e, 0x1b, 0x56, 0x4e, 0xa8, 0xca, 0xd8,
+	0x2a, 0x7a, 0x50, 0xb2, 0x2c, 0xf2, 0xee, 0xdd, 0x47, 0xe5, 0x2f, 0xa0, 0x11, 0x1a, 0x6d, 0x8a,
+	0x6a, 0x63, 0x08, 0x67, 0xb8, 0xe9, 0x34, 0xcc, 0xf7, 0x27, 0x60, 0x8e, 0x30, 0x67, 0x33, 0xa2,
+	0xd6, 0x4e, 0xa8, 0x1a, 0xb2, 0xdc, 0x69, 0xb0, 0xb8, 0xa4, 0x2e, 0x11, 0x64, 0x03, 0xda, 0x3c,
+	0xa1, 0x86, 0x4d, 0x4e, 0x8d, 0xf3, 0x71, 0x35, 0x60, 0x89, 0x89, 0x2e, 0xa0, 0x3f, 0x98, 0x14,
+	0x14, 0x58, 0xe1, 0x34, 0xe8, 0x0f, 0xaa, 0xa1, 0xab, 0x78, 0xe5, 0x02, 0x7a, 0x0e, 0x0d, 0xcd,
+	0xbe, 0xa6, 0x61, 0xde, 0x7a, 0xe6, 0xc7, 0xd0, 0xd0, 0xec, 0x14, 0xfd, 0xac, 0x8c, 0x71, 0xf3,
+	0xad, 0xb7, 0xfb, 0x60, 0x82, 0xb6, 0x30, 0x8c, 0x57, 0x86, 0x6c, 0xb0, 0x62, 0x68, 0x8c, 0xb3,
+	0xd0, 0x5d, 0x7c, 0x9b, 0x49, 0xa1, 0x7b, 0x9c, 0xb1, 0xae, 0x19, 0x92, 0x36, 0x84, 0x27, 0xfc,
+	0x1c, 0x58, 0x60, 0x74, 0xd3, 0x66, 0x9e, 0x3e, 0x9b, 0xc2, 0xaf, 0xbc, 0xef, 0x5e, 0x9e, 0x15,
+	0x3f, 0x11, 0xdb, 0x39, 0x52, 0x62, 0x0d, 0xad, 0xce, 0xb9, 0x9c, 0x89, 0x39, 0xc0, 0x09, 0x55,
+	0x96, 0x5a, 0x4e, 0x0b, 0x74, 0xaf, 0xa4, 0x1e, 0xe3, 0xa4, 0x78, 0x01, 0x11, 0xd8, 0x3a, 0xa1,
+	0xaa, 0x44, 0x23, 0x6f, 0x0f, 0xf1, 0x17, 0x25, 0xe5, 0x44, 0x1e, 0x8a, 0x17, 0xd0, 0x77, 0x80,
+	0xca, 0x24, 0x11, 0x95, 0x31, 0x26, 0x32, 0xc9, 0xe9, 0xf7, 0x7f, 0x29, 0xcd, 0xa7, 0xaf, 0xce,
+	0xd2, 0x19, 0xef, 0xff, 0xd5, 0x02, 0xf5, 0xbc, 0x1d, 0xe8, 0xfd, 0x09, 0xcd, 0x3a, 0x42, 0x26,
+	0xf1, 0xc2, 0xf3, 0xc6, 0xb7, 0x8b, 0xfd, 0x27, 0x97, 0xcb, 0xe6, 0x5f, 0x0d, 0xbf, 0xfc, 0xff,
+	0x00, 0x43, 0xe7, 0x3b, 0xbf, 0x97, 0x18, 0x00, 0x00,
 }
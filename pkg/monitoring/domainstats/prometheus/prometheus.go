@@ -552,6 +552,36 @@ func (metrics *vmiMetrics) updateFilesystem(vmFSStats k6tv1.VirtualMachineInstan
 	}
 }
 
+func (metrics *vmiMetrics) updateVolumeGuestUsage(volumeStatuses []k6tv1.VolumeStatus) {
+	// The same label as the storage metrics, so they can be joined on the volume
+	usageLabels := []string{"drive"}
+
+	for _, volumeStatus := range volumeStatuses {
+		if volumeStatus.GuestUsage == nil {
+			continue
+		}
+		usageLabelValues := []string{volumeStatus.Name}
+
+		metrics.pushCustomMetric(
+			"kubevirt_vmi_volume_guest_capacity_bytes",
+			"Total capacity of the guest filesystems located on the volume in bytes, as reported by the guest agent.",
+			prometheus.GaugeValue,
+			float64(volumeStatus.GuestUsage.TotalBytes),
+			usageLabels,
+			usageLabelValues,
+		)
+
+		metrics.pushCustomMetric(
+			"kubevirt_vmi_volume_guest_used_bytes",
+			"Used capacity of the guest filesystems located on the volume in bytes, as reported by the guest agent.",
+			prometheus.GaugeValue,
+			float64(volumeStatus.GuestUsage.UsedBytes),
+			usageLabels,
+			usageLabelValues,
+		)
+	}
+}
+
 func updateVersion(ch chan<- prometheus.Metric) {
 	verinfo := version.Get()
 	ch <- prometheus.MustNewConstMetric(
@@ -714,6 +744,7 @@ func (metrics *vmiMetrics) updateMetrics(vmi *k6tv1.VirtualMachineInstance, vmSt
 	}
 	metrics.updateMigrateInfo(vmStats.DomainStats.MigrateDomainJobInfo)
 	metrics.updateFilesystem(vmStats.FsStats)
+	metrics.updateVolumeGuestUsage(vmi.Status.VolumeStatus)
 }
 
 func (metrics *vmiMetrics) newPrometheusDesc(name string, help string, customLabels []string) *prometheus.Desc {
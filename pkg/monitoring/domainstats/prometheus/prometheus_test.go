@@ -1213,6 +1213,56 @@ var _ = Describe("Prometheus", func() {
 			Expect(ch).To(BeEmpty())
 		})
 
+		It("should expose the guest usage of volumes", func() {
+			ch := make(chan prometheus.Metric, 2)
+			defer close(ch)
+
+			ps := prometheusScraper{ch: ch}
+
+			domainStats := &stats.DomainStats{
+				Cpu:                  &stats.DomainStatsCPU{},
+				Memory:               &stats.DomainStatsMemory{},
+				Net:                  []stats.DomainStatsNet{},
+				MigrateDomainJobInfo: &stats.DomainJobInfo{},
+			}
+
+			vmi := k6tv1.VirtualMachineInstance{
+				Status: k6tv1.VirtualMachineInstanceStatus{
+					VolumeStatus: []k6tv1.VolumeStatus{
+						{
+							Name: "rootdisk",
+							GuestUsage: &k6tv1.VolumeGuestUsage{
+								UsedBytes:  10,
+								TotalBytes: 1000,
+							},
+						},
+						{
+							Name: "cloudinitdisk",
+						},
+					},
+				},
+			}
+			ps.Report("test", &vmi, newVmStats(domainStats, nil))
+
+			for _, expected := range []struct {
+				name  string
+				value float64
+			}{
+				{"kubevirt_vmi_volume_guest_capacity_bytes", 1000},
+				{"kubevirt_vmi_volume_guest_used_bytes", 10},
+			} {
+				result := <-ch
+				Expect(result).ToNot(BeNil())
+				Expect(result.Desc().String()).To(ContainSubstring(expected.name))
+
+				dto := &io_prometheus_client.Metric{}
+				Expect(result.Write(dto)).To(Succeed())
+				Expect(dto.GetGauge().GetValue()).To(Equal(expected.value))
+				Expect(dto.GetLabel()).To(ContainElement(HaveField("GetValue()", "rootdisk")))
+			}
+			Expect(ch).To(BeEmpty())
+		})
+
 		DescribeTable("CPU metrics", func(metricName string, MetricValue int, cpuStats *stats.DomainStatsCPU) {
 			ch := make(chan prometheus.Metric, 1)
 			defer close(ch)
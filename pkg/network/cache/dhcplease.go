@@ -0,0 +1,91 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package cache
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"kubevirt.io/kubevirt/pkg/util"
+)
+
+// DHCPLease holds the addresses the embedded DHCP servers handed out to the guest.
+type DHCPLease struct {
+	IP   string `json:"ip,omitempty"`
+	IPv6 string `json:"ipv6,omitempty"`
+}
+
+type DHCPLeaseCache struct {
+	cache *Cache
+}
+
+func ReadDHCPLeaseCache(c cacheCreator, pid, ifaceName string) (*DHCPLease, error) {
+	leaseCache, err := NewDHCPLeaseCache(c, pid).IfaceEntry(ifaceName)
+	if err != nil {
+		return nil, err
+	}
+	return leaseCache.Read()
+}
+
+func WriteDHCPLeaseCache(c cacheCreator, pid, ifaceName string, lease *DHCPLease) error {
+	leaseCache, err := NewDHCPLeaseCache(c, pid).IfaceEntry(ifaceName)
+	if err != nil {
+		return err
+	}
+	return leaseCache.Write(lease)
+}
+
+func DeleteDHCPLeaseCache(c cacheCreator, pid, ifaceName string) error {
+	leaseCache, err := NewDHCPLeaseCache(c, pid).IfaceEntry(ifaceName)
+	if err != nil {
+		return err
+	}
+	return leaseCache.Delete()
+}
+
+func NewDHCPLeaseCache(creator cacheCreator, pid string) DHCPLeaseCache {
+	podRootFilesystemPath := fmt.Sprintf("/proc/%s/root", pid)
+	return DHCPLeaseCache{creator.New(filepath.Join(podRootFilesystemPath, util.VirtPrivateDir))}
+}
+
+func (d DHCPLeaseCache) IfaceEntry(ifaceName string) (DHCPLeaseCache, error) {
+	const dhcpLeaseCacheFileFormat = "dhcp-lease-%s.json"
+	cacheFileName := fmt.Sprintf(dhcpLeaseCacheFileFormat, ifaceName)
+	cache, err := d.cache.Entry(cacheFileName)
+	if err != nil {
+		return DHCPLeaseCache{}, err
+	}
+
+	return DHCPLeaseCache{&cache}, nil
+}
+
+func (d DHCPLeaseCache) Read() (*DHCPLease, error) {
+	lease := &DHCPLease{}
+	_, err := d.cache.Read(lease)
+	return lease, err
+}
+
+func (d DHCPLeaseCache) Write(lease *DHCPLease) error {
+	return d.cache.Write(lease)
+}
+
+func (d DHCPLeaseCache) Delete() error {
+	return d.cache.Delete()
+}
@@ -0,0 +1,42 @@
+package cache_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	dutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
+	"kubevirt.io/kubevirt/pkg/network/cache"
+)
+
+var _ = Describe("DHCPLeaseCache", func() {
+	var cacheCreator tempCacheCreator
+
+	lease := &cache.DHCPLease{IP: "10.0.0.10", IPv6: "fd10::10"}
+
+	BeforeEach(dutils.MockDefaultOwnershipManager)
+
+	AfterEach(func() {
+		Expect(cacheCreator.New("").Delete()).To(Succeed())
+	})
+
+	It("should return os.ErrNotExist if no cache entry exists", func() {
+		_, err := cache.ReadDHCPLeaseCache(&cacheCreator, "123", "eth0")
+		Expect(err).To(MatchError(os.ErrNotExist))
+	})
+
+	It("should save and restore the lease", func() {
+		Expect(cache.WriteDHCPLeaseCache(&cacheCreator, "123", "eth0", lease)).To(Succeed())
+		newLease, err := cache.ReadDHCPLeaseCache(&cacheCreator, "123", "eth0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(newLease).To(Equal(lease))
+	})
+
+	It("should delete the lease from the cache", func() {
+		Expect(cache.WriteDHCPLeaseCache(&cacheCreator, "123", "eth0", lease)).To(Succeed())
+		Expect(cache.DeleteDHCPLeaseCache(&cacheCreator, "123", "eth0")).To(Succeed())
+		_, err := cache.ReadDHCPLeaseCache(&cacheCreator, "123", "eth0")
+		Expect(err).To(MatchError(os.ErrNotExist))
+	})
+})
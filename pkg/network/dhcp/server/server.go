@@ -60,7 +60,8 @@ func SingleClientDHCPServer(
 	routes *[]netlink.Route,
 	searchDomains []string,
 	mtu uint16,
-	customDHCPOptions *v1.DHCPOptions) error {
+	customDHCPOptions *v1.DHCPOptions,
+	leaseObserver func(net.IP)) error {
 
 	log.Log.Info("Starting SingleClientDHCPServer")
 
@@ -80,6 +81,7 @@ func SingleClientDHCPServer(
 		serverIP:      serverIP.To4(),
 		leaseDuration: infiniteLease,
 		options:       options,
+		leaseObserver: leaseObserver,
 	}
 
 	l, err := NewUDP4FilterListener(serverIface, ":67")
@@ -192,6 +194,7 @@ type DHCPHandler struct {
 	clientMAC     net.HardwareAddr
 	leaseDuration time.Duration
 	options       dhcp.Options
+	leaseObserver func(net.IP)
 }
 
 func (h *DHCPHandler) ServeDHCP(p dhcp.Packet, msgType dhcp.MessageType, _ dhcp.Options) (d dhcp.Packet) {
@@ -212,6 +215,9 @@ func (h *DHCPHandler) ServeDHCP(p dhcp.Packet, msgType dhcp.MessageType, _ dhcp.
 
 	case dhcp.Request:
 		log.Log.V(4).Info("The request has message type REQUEST")
+		if h.leaseObserver != nil {
+			h.leaseObserver(h.clientIP)
+		}
 		return dhcp.ReplyPacket(p, dhcp.ACK, h.serverIP, h.clientIP, h.leaseDuration,
 			h.options.SelectOrderOrAll(nil))
 
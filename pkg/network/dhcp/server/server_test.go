@@ -249,4 +249,35 @@ var _ = Describe("DHCP Server", func() {
 			})
 		})
 	})
+	Context("ServeDHCP", func() {
+		var (
+			handler  *DHCPHandler
+			observed []net.IP
+		)
+
+		BeforeEach(func() {
+			observed = nil
+			handler = &DHCPHandler{
+				serverIP:      net.ParseIP("10.0.0.1").To4(),
+				clientIP:      net.ParseIP("10.0.0.10").To4(),
+				clientMAC:     net.HardwareAddr{0x02, 0, 0, 0, 0, 0x01},
+				leaseDuration: infiniteLease,
+				leaseObserver: func(ip net.IP) { observed = append(observed, ip) },
+			}
+		})
+
+		newPacket := func(msgType dhcp4.MessageType) dhcp4.Packet {
+			return dhcp4.RequestPacket(msgType, handler.clientMAC, nil, nil, false, nil)
+		}
+
+		It("should report the lease when the request is acknowledged", func() {
+			Expect(handler.ServeDHCP(newPacket(dhcp4.Request), dhcp4.Request, nil)).ToNot(BeNil())
+			Expect(observed).To(Equal([]net.IP{handler.clientIP}))
+		})
+
+		It("should not report the lease on discover", func() {
+			Expect(handler.ServeDHCP(newPacket(dhcp4.Discover), dhcp4.Discover, nil)).ToNot(BeNil())
+			Expect(observed).To(BeEmpty())
+		})
+	})
 })
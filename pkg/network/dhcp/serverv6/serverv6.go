@@ -36,11 +36,13 @@ const (
 )
 
 type DHCPv6Handler struct {
-	clientIP  net.IP
-	modifiers []dhcpv6.Modifier
+	clientIP      net.IP
+	modifiers     []dhcpv6.Modifier
+	leaseObserver func(net.IP)
 }
 
-func SingleClientDHCPv6Server(clientIP net.IP, serverIfaceName string) error {
+// SingleClientDHCPv6Server serves the client IP to the guest, leaseObserver (when set) is called each time the client is granted the lease.
+func SingleClientDHCPv6Server(clientIP net.IP, serverIfaceName string, leaseObserver func(net.IP)) error {
 	log.Log.Info("Starting SingleClientDHCPv6Server")
 
 	iface, err := net.InterfaceByName(serverIfaceName)
@@ -51,8 +53,9 @@ func SingleClientDHCPv6Server(clientIP net.IP, serverIfaceName string) error {
 	modifiers := prepareDHCPv6Modifiers(clientIP, iface.HardwareAddr)
 
 	handler := &DHCPv6Handler{
-		clientIP:  clientIP,
-		modifiers: modifiers,
+		clientIP:      clientIP,
+		modifiers:     modifiers,
+		leaseObserver: leaseObserver,
 	}
 
 	conn, err := NewConnection(iface)
@@ -86,6 +89,23 @@ func (h *DHCPv6Handler) ServeDHCPv6(conn net.PacketConn, peer net.Addr, m dhcpv6
 
 	if _, err := conn.WriteTo(response.ToBytes(), peer); err != nil {
 		log.Log.Reason(err).Error("DHCPv6 failed sending a response to the client")
+		return
+	}
+
+	if h.leaseObserver != nil && isLeaseGranted(m) {
+		h.leaseObserver(h.clientIP)
+	}
+}
+
+// isLeaseGranted reports whether replying to the given client message commits the address to the client.
+func isLeaseGranted(msg dhcpv6.DHCPv6) bool {
+	switch msg.Type() {
+	case dhcpv6.MessageTypeRequest, dhcpv6.MessageTypeRenew, dhcpv6.MessageTypeRebind:
+		return true
+	case dhcpv6.MessageTypeSolicit:
+		return msg.GetOneOption(dhcpv6.OptionRapidCommit) != nil
+	default:
+		return false
 	}
 }
 
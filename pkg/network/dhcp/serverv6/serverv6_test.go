@@ -53,6 +53,21 @@ var _ = Describe("DHCPv6", func() {
 			Expect(msg.GetOneOption(dhcpv6.OptionServerID).String()).To(Equal(expectedServerId.String()))
 		})
 	})
+	DescribeTable("isLeaseGranted", func(messageType dhcpv6.MessageType, rapidCommit, expected bool) {
+		clientMessage, err := newMessage(messageType)
+		Expect(err).ToNot(HaveOccurred())
+		if rapidCommit {
+			dhcpv6.WithRapidCommit(clientMessage)
+		}
+		Expect(isLeaseGranted(clientMessage)).To(Equal(expected))
+	},
+		Entry("on solicit request", dhcpv6.MessageTypeSolicit, false, false),
+		Entry("on rapid commit solicit request", dhcpv6.MessageTypeSolicit, true, true),
+		Entry("on request", dhcpv6.MessageTypeRequest, false, true),
+		Entry("on renew request", dhcpv6.MessageTypeRenew, false, true),
+		Entry("on rebind request", dhcpv6.MessageTypeRebind, false, true),
+		Entry("on information request", dhcpv6.MessageTypeInformationRequest, false, false),
+	)
 	Context("buildResponse should build a response with", func() {
 		var handler *DHCPv6Handler
 
@@ -317,8 +317,8 @@ func (b *PasstLibvirtSpecGenerator) Generate() error {
 		return fmt.Errorf("failed to find interface %s in vmi spec", b.vmiSpecIface.Name)
 	}
 
-	ports := b.generatePorts()
-	args := append([]string{"--runas", "107", "-e"}, ports...)
+	args := append([]string{"--runas", "107", "-e"}, b.generatePorts()...)
+	args = append(args, b.generateDNSServers()...)
 	cmd := exec.Command("/usr/bin/passt", args...)
 	cmd.SysProcAttr = &syscall.SysProcAttr{
 		AmbientCaps: []uintptr{unix.CAP_NET_BIND_SERVICE},
@@ -359,6 +359,18 @@ func (b *PasstLibvirtSpecGenerator) Generate() error {
 	return nil
 }
 
+// generateDNSServers sets the DNS servers passt announces through its router advertisements and DHCP replies.
+func (b *PasstLibvirtSpecGenerator) generateDNSServers() []string {
+	if b.vmiSpecIface.RouterAdvertisement == nil {
+		return nil
+	}
+	var args []string
+	for _, server := range b.vmiSpecIface.RouterAdvertisement.DNSServers {
+		args = append(args, "--dns", server)
+	}
+	return args
+}
+
 func (b *PasstLibvirtSpecGenerator) generatePorts() []string {
 	tcpPorts := []string{}
 	udpPorts := []string{}
@@ -208,6 +208,20 @@ var _ = Describe("Pod Network", func() {
 					passtIface, nil, istioVmi)
 				Expect(getPorts(specGenerator)).To(Equal("-t ~15000,~15001,~15004,~15006,~15008,~15009,~15020,~15021,~15053,~15090 -u all"))
 			})
+
+			It("Should not set DNS servers by default", func() {
+				specGenerator = NewPasstLibvirtSpecGenerator(
+					createPasstInterface(), nil, api2.NewMinimalVMI("passtVmi"))
+				Expect(specGenerator.generateDNSServers()).To(BeEmpty())
+			})
+
+			It("Should set the router advertisement DNS servers", func() {
+				passtIface := createPasstInterface()
+				passtIface.RouterAdvertisement = &v1.RouterAdvertisementOptions{DNSServers: []string{"fd00::53", "fd00::54"}}
+				specGenerator = NewPasstLibvirtSpecGenerator(
+					passtIface, nil, api2.NewMinimalVMI("passtVmi"))
+				Expect(specGenerator.generateDNSServers()).To(Equal([]string{"--dns", "fd00::53", "--dns", "fd00::54"}))
+			})
 		})
 	})
 })
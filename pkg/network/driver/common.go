@@ -26,6 +26,7 @@ import (
 	"net"
 	"os"
 	"os/exec"
+	"sync"
 
 	"github.com/vishvananda/netlink"
 
@@ -315,6 +316,8 @@ func (h *NetworkUtilsHandler) StartDHCP(nic *cache.DHCPConfig, bridgeInterfaceNa
 		searchDomains = append([]string{domain}, searchDomains...)
 	}
 
+	leases := &dhcpLeaseRecorder{ifaceName: nic.Name}
+
 	if nic.IP.IPNet != nil {
 		// panic in case the DHCP server failed during the vm creation
 		// but ignore dhcp errors when the vm is destroyed or shutting down
@@ -331,6 +334,7 @@ func (h *NetworkUtilsHandler) StartDHCP(nic *cache.DHCPConfig, bridgeInterfaceNa
 				searchDomains,
 				nic.Mtu,
 				dhcpOptions,
+				leases.recordIPv4,
 			); err != nil {
 				log.Log.Errorf("failed to run DHCP: %v", err)
 				panic(err)
@@ -343,6 +347,7 @@ func (h *NetworkUtilsHandler) StartDHCP(nic *cache.DHCPConfig, bridgeInterfaceNa
 			if err = DHCPv6Server(
 				nic.IPv6.IP,
 				bridgeInterfaceName,
+				leases.recordIPv6,
 			); err != nil {
 				log.Log.Reason(err).Error("failed to run DHCPv6")
 				panic(err)
@@ -353,6 +358,36 @@ func (h *NetworkUtilsHandler) StartDHCP(nic *cache.DHCPConfig, bridgeInterfaceNa
 	return nil
 }
 
+// dhcpLeaseRecorder persists the addresses granted by the DHCP servers of a single interface,
+// allowing virt-handler to report them when the guest agent is not available.
+type dhcpLeaseRecorder struct {
+	lock      sync.Mutex
+	ifaceName string
+	lease     cache.DHCPLease
+}
+
+func (r *dhcpLeaseRecorder) recordIPv4(ip net.IP) {
+	r.record(func(lease *cache.DHCPLease) { lease.IP = ip.String() })
+}
+
+func (r *dhcpLeaseRecorder) recordIPv6(ip net.IP) {
+	r.record(func(lease *cache.DHCPLease) { lease.IPv6 = ip.String() })
+}
+
+func (r *dhcpLeaseRecorder) record(update func(lease *cache.DHCPLease)) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	current := r.lease
+	update(&r.lease)
+	if current == r.lease {
+		return
+	}
+	if err := cache.WriteDHCPLeaseCache(cache.CacheCreator{}, "self", r.ifaceName, &r.lease); err != nil {
+		log.Log.Reason(err).Warningf("failed to record the DHCP lease of interface %s", r.ifaceName)
+	}
+}
+
 func (h *NetworkUtilsHandler) CreateTapDevice(tapName string, queueNumber uint32, launcherPID int, mtu int, tapOwner string) error {
 	tapDeviceSELinuxCmdExecutor, err := buildTapDeviceMaker(tapName, queueNumber, launcherPID, mtu, tapOwner)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/vishvananda/netlink"
+	netutils "k8s.io/utils/net"
 
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/log"
@@ -26,6 +27,9 @@ const (
 	strFmt                     = "{ %s }"
 	LibvirtDirectMigrationPort = 49152
 	LibvirtBlockMigrationPort  = 49153
+
+	firewallIngressChain = "KUBEVIRT_FW_INGRESS"
+	firewallEgressChain  = "KUBEVIRT_FW_EGRESS"
 )
 
 type MasqueradePodNetworkConfigurator struct {
@@ -228,7 +232,10 @@ func (b *MasqueradePodNetworkConfigurator) createNatRules(ipVersion netdriver.IP
 	}
 
 	if b.handler.CheckNftables() == nil {
-		return b.createNatRulesUsingNftables(ipVersion)
+		if err := b.createNatRulesUsingNftables(ipVersion); err != nil {
+			return err
+		}
+		return b.createFirewallRulesUsingNftables(ipVersion)
 	}
 	return fmt.Errorf("Couldn't configure ip nat rules")
 }
@@ -382,6 +389,100 @@ func (b *MasqueradePodNetworkConfigurator) createNatRulesUsingNftables(ipVersion
 	return nil
 }
 
+// createFirewallRulesUsingNftables filters the traffic forwarded between the pod and the VM according to the
+// interface firewall policies. The rules are placed in the nat table alongside the NAT rules.
+func (b *MasqueradePodNetworkConfigurator) createFirewallRulesUsingNftables(ipVersion netdriver.IPVersion) error {
+	if b.vmiSpecIface.Masquerade == nil || b.vmiSpecIface.Masquerade.Firewall == nil {
+		return nil
+	}
+	firewall := b.vmiSpecIface.Masquerade.Firewall
+
+	err := b.handler.NftablesNewChain(ipVersion, "nat", "forward { type filter hook forward priority 0; }")
+	if err != nil {
+		return err
+	}
+
+	err = b.createFirewallPolicyUsingNftables(ipVersion, firewall.Ingress, firewallIngressChain, "saddr", "oifname")
+	if err != nil {
+		return err
+	}
+
+	return b.createFirewallPolicyUsingNftables(ipVersion, firewall.Egress, firewallEgressChain, "daddr", "iifname")
+}
+
+func (b *MasqueradePodNetworkConfigurator) createFirewallPolicyUsingNftables(ipVersion netdriver.IPVersion, policy *v1.FirewallPolicy, chain, peerSelector, bridgeSelector string) error {
+	if policy == nil {
+		return nil
+	}
+
+	err := b.handler.NftablesNewChain(ipVersion, "nat", chain)
+	if err != nil {
+		return err
+	}
+
+	err = b.handler.NftablesAppendRule(ipVersion, "nat", "forward", bridgeSelector, b.bridgeInterfaceName, "counter", "jump", chain)
+	if err != nil {
+		return err
+	}
+
+	err = b.handler.NftablesAppendRule(ipVersion, "nat", chain, "ct", "state", "{ established, related }", "counter", "accept")
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range policy.Rules {
+		ruleSpec, applicable := firewallRuleSpec(b.handler.GetNFTIPString(ipVersion), ipVersion, peerSelector, rule)
+		if !applicable {
+			continue
+		}
+		if err = b.handler.NftablesAppendRule(ipVersion, "nat", chain, ruleSpec...); err != nil {
+			return err
+		}
+	}
+
+	if policy.DefaultAction == v1.FirewallActionDeny {
+		return b.handler.NftablesAppendRule(ipVersion, "nat", chain, "counter", "drop")
+	}
+	return nil
+}
+
+// firewallRuleSpec renders the rule for the given IP family.
+// A rule restricted to remote CIDRs of the other IP family is not applicable.
+func firewallRuleSpec(nftIPString string, ipVersion netdriver.IPVersion, peerSelector string, rule v1.FirewallRule) ([]string, bool) {
+	var ruleSpec []string
+
+	if len(rule.RemoteCIDRs) > 0 {
+		var cidrs []string
+		for _, cidr := range rule.RemoteCIDRs {
+			if netutils.IsIPv6CIDRString(cidr) == (ipVersion == netdriver.IPv6) {
+				cidrs = append(cidrs, cidr)
+			}
+		}
+		if len(cidrs) == 0 {
+			return nil, false
+		}
+		ruleSpec = append(ruleSpec, nftIPString, peerSelector, fmt.Sprintf(strFmt, strings.Join(cidrs, ", ")))
+	}
+
+	if rule.Protocol != "" {
+		protocol := strings.ToLower(rule.Protocol)
+		if protocol == "icmp" && ipVersion == netdriver.IPv6 {
+			protocol = "icmpv6"
+		}
+		if len(rule.Ports) > 0 {
+			ruleSpec = append(ruleSpec, protocol, "dport", fmt.Sprintf(strFmt, strings.Join(rule.Ports, ", ")))
+		} else {
+			ruleSpec = append(ruleSpec, "meta", "l4proto", protocol)
+		}
+	}
+
+	verdict := "accept"
+	if rule.Action == v1.FirewallActionDeny {
+		verdict = "drop"
+	}
+	return append(ruleSpec, "counter", verdict), true
+}
+
 func (b *MasqueradePodNetworkConfigurator) skipForwardingForPortsUsingNftables(ipVersion netdriver.IPVersion, ports []string) error {
 	if len(ports) == 0 {
 		return nil
@@ -311,6 +311,85 @@ var _ = Describe("Masquerade infrastructure configurator", func() {
 			)
 		})
 	})
+
+	Context("firewall", func() {
+		const ifaceName = "eth0"
+
+		var masqueradeConfigurator *MasqueradePodNetworkConfigurator
+
+		BeforeEach(func() {
+			vmi := newVMIMasqueradeInterface("default", "vm1")
+			vmi.Spec.Domain.Devices.Interfaces[0].Masquerade.Firewall = &v1.InterfaceFirewall{
+				Ingress: &v1.FirewallPolicy{
+					DefaultAction: v1.FirewallActionDeny,
+					Rules: []v1.FirewallRule{
+						{
+							Action:      v1.FirewallActionAllow,
+							RemoteCIDRs: []string{"10.10.0.0/16", "fd20::/64"},
+							Protocol:    "TCP",
+							Ports:       []string{"22", "8000-9000"},
+						},
+						{Action: v1.FirewallActionAllow, Protocol: "ICMP"},
+					},
+				},
+				Egress: &v1.FirewallPolicy{
+					Rules: []v1.FirewallRule{
+						{Action: v1.FirewallActionDeny, RemoteCIDRs: []string{"192.168.0.0/24"}},
+					},
+				},
+			}
+			masqueradeConfigurator = NewMasqueradePodNetworkConfigurator(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], 1000, handler)
+			masqueradeConfigurator.bridgeInterfaceName = bridgeIfaceName
+			masqueradeConfigurator.podNicLink = &netlink.GenericLink{LinkAttrs: netlink.LinkAttrs{Name: ifaceName}}
+		})
+
+		It("should render the IPv4 policies", func() {
+			ipVersion := netdriver.IPv4
+			handler.EXPECT().GetNFTIPString(ipVersion).Return("ip").AnyTimes()
+			gomock.InOrder(
+				handler.EXPECT().NftablesNewChain(ipVersion, "nat", "forward { type filter hook forward priority 0; }").Return(nil),
+				handler.EXPECT().NftablesNewChain(ipVersion, "nat", "KUBEVIRT_FW_INGRESS").Return(nil),
+				handler.EXPECT().NftablesAppendRule(ipVersion, "nat", "forward", "oifname", bridgeIfaceName, "counter", "jump", "KUBEVIRT_FW_INGRESS").Return(nil),
+				handler.EXPECT().NftablesAppendRule(ipVersion, "nat", "KUBEVIRT_FW_INGRESS", "ct", "state", "{ established, related }", "counter", "accept").Return(nil),
+				handler.EXPECT().NftablesAppendRule(ipVersion, "nat", "KUBEVIRT_FW_INGRESS",
+					"ip", "saddr", "{ 10.10.0.0/16 }", "tcp", "dport", "{ 22, 8000-9000 }", "counter", "accept").Return(nil),
+				handler.EXPECT().NftablesAppendRule(ipVersion, "nat", "KUBEVIRT_FW_INGRESS", "meta", "l4proto", "icmp", "counter", "accept").Return(nil),
+				handler.EXPECT().NftablesAppendRule(ipVersion, "nat", "KUBEVIRT_FW_INGRESS", "counter", "drop").Return(nil),
+				handler.EXPECT().NftablesNewChain(ipVersion, "nat", "KUBEVIRT_FW_EGRESS").Return(nil),
+				handler.EXPECT().NftablesAppendRule(ipVersion, "nat", "forward", "iifname", bridgeIfaceName, "counter", "jump", "KUBEVIRT_FW_EGRESS").Return(nil),
+				handler.EXPECT().NftablesAppendRule(ipVersion, "nat", "KUBEVIRT_FW_EGRESS", "ct", "state", "{ established, related }", "counter", "accept").Return(nil),
+				handler.EXPECT().NftablesAppendRule(ipVersion, "nat", "KUBEVIRT_FW_EGRESS", "ip", "daddr", "{ 192.168.0.0/24 }", "counter", "drop").Return(nil),
+			)
+
+			Expect(masqueradeConfigurator.createFirewallRulesUsingNftables(ipVersion)).To(Succeed())
+		})
+
+		It("should render the IPv6 policies, skipping rules restricted to IPv4 peers", func() {
+			ipVersion := netdriver.IPv6
+			handler.EXPECT().GetNFTIPString(ipVersion).Return("ip6").AnyTimes()
+			gomock.InOrder(
+				handler.EXPECT().NftablesNewChain(ipVersion, "nat", "forward { type filter hook forward priority 0; }").Return(nil),
+				handler.EXPECT().NftablesNewChain(ipVersion, "nat", "KUBEVIRT_FW_INGRESS").Return(nil),
+				handler.EXPECT().NftablesAppendRule(ipVersion, "nat", "forward", "oifname", bridgeIfaceName, "counter", "jump", "KUBEVIRT_FW_INGRESS").Return(nil),
+				handler.EXPECT().NftablesAppendRule(ipVersion, "nat", "KUBEVIRT_FW_INGRESS", "ct", "state", "{ established, related }", "counter", "accept").Return(nil),
+				handler.EXPECT().NftablesAppendRule(ipVersion, "nat", "KUBEVIRT_FW_INGRESS",
+					"ip6", "saddr", "{ fd20::/64 }", "tcp", "dport", "{ 22, 8000-9000 }", "counter", "accept").Return(nil),
+				handler.EXPECT().NftablesAppendRule(ipVersion, "nat", "KUBEVIRT_FW_INGRESS", "meta", "l4proto", "icmpv6", "counter", "accept").Return(nil),
+				handler.EXPECT().NftablesAppendRule(ipVersion, "nat", "KUBEVIRT_FW_INGRESS", "counter", "drop").Return(nil),
+				handler.EXPECT().NftablesNewChain(ipVersion, "nat", "KUBEVIRT_FW_EGRESS").Return(nil),
+				handler.EXPECT().NftablesAppendRule(ipVersion, "nat", "forward", "iifname", bridgeIfaceName, "counter", "jump", "KUBEVIRT_FW_EGRESS").Return(nil),
+				handler.EXPECT().NftablesAppendRule(ipVersion, "nat", "KUBEVIRT_FW_EGRESS", "ct", "state", "{ established, related }", "counter", "accept").Return(nil),
+			)
+
+			Expect(masqueradeConfigurator.createFirewallRulesUsingNftables(ipVersion)).To(Succeed())
+		})
+
+		It("should not render anything when no firewall is set", func() {
+			masqueradeConfigurator.vmiSpecIface.Masquerade.Firewall = nil
+
+			Expect(masqueradeConfigurator.createFirewallRulesUsingNftables(netdriver.IPv4)).To(Succeed())
+		})
+	})
 })
 
 func portsUsedByLiveMigration(isMigrationOverSockets bool) []string {
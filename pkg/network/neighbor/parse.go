@@ -0,0 +1,96 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package neighbor
+
+import (
+	"encoding/binary"
+	"net"
+)
+
+const (
+	ethernetHeaderLen = 14
+	etherTypeARP      = 0x0806
+	etherTypeIPv6     = 0x86dd
+
+	arpPayloadLen      = 28
+	arpSenderIPOffset  = 14
+	arpProtocolTypeIP4 = 0x0800
+
+	ipv6HeaderLen         = 40
+	ipv6NextHeaderOffset  = 6
+	ipv6SourceOffset      = 8
+	ipv6NextHeaderICMPv6  = 58
+	icmpv6NDPTargetOffset = 8
+	icmpv6NDPMinLen       = 24
+	icmpv6NeighborSolicit = 135
+	icmpv6NeighborAdvert  = 136
+)
+
+// ParseFrame extracts the address a guest announces as its own from an ethernet frame it sent.
+// ARP packets expose the sender protocol address, IPv6 neighbor solicitations the source address and
+// neighbor advertisements the target address.
+// Unspecified and link-local addresses are ignored, as well as any other frame.
+func ParseFrame(frame []byte) (net.IP, bool) {
+	if len(frame) < ethernetHeaderLen {
+		return nil, false
+	}
+
+	payload := frame[ethernetHeaderLen:]
+	var ip net.IP
+	switch binary.BigEndian.Uint16(frame[12:ethernetHeaderLen]) {
+	case etherTypeARP:
+		ip = parseARP(payload)
+	case etherTypeIPv6:
+		ip = parseNDP(payload)
+	}
+
+	if ip == nil || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+		return nil, false
+	}
+	return ip, true
+}
+
+func parseARP(payload []byte) net.IP {
+	if len(payload) < arpPayloadLen || binary.BigEndian.Uint16(payload[2:4]) != arpProtocolTypeIP4 {
+		return nil
+	}
+	return copyIP(payload[arpSenderIPOffset : arpSenderIPOffset+net.IPv4len])
+}
+
+func parseNDP(payload []byte) net.IP {
+	if len(payload) < ipv6HeaderLen+icmpv6NDPMinLen || payload[ipv6NextHeaderOffset] != ipv6NextHeaderICMPv6 {
+		return nil
+	}
+
+	icmp := payload[ipv6HeaderLen:]
+	switch icmp[0] {
+	case icmpv6NeighborSolicit:
+		return copyIP(payload[ipv6SourceOffset : ipv6SourceOffset+net.IPv6len])
+	case icmpv6NeighborAdvert:
+		return copyIP(icmp[icmpv6NDPTargetOffset : icmpv6NDPTargetOffset+net.IPv6len])
+	}
+	return nil
+}
+
+func copyIP(b []byte) net.IP {
+	ip := make(net.IP, len(b))
+	copy(ip, b)
+	return ip
+}
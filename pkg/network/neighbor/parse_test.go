@@ -0,0 +1,85 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package neighbor_test
+
+import (
+	"encoding/binary"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/kubevirt/pkg/network/neighbor"
+)
+
+var _ = Describe("ParseFrame", func() {
+	DescribeTable("should learn the guest address", func(frame []byte, expectedIP string) {
+		ip, ok := neighbor.ParseFrame(frame)
+		Expect(ok).To(BeTrue())
+		Expect(ip.String()).To(Equal(expectedIP))
+	},
+		Entry("from an ARP request", arpFrame("10.0.2.2"), "10.0.2.2"),
+		Entry("from an NDP neighbor solicitation", ndpFrame(135, "fd10:0:2::2", "fd10:0:2::1"), "fd10:0:2::2"),
+		Entry("from an NDP neighbor advertisement", ndpFrame(136, "fd10:0:2::2", "fd10:0:2::3"), "fd10:0:2::3"),
+	)
+
+	DescribeTable("should ignore", func(frame []byte) {
+		_, ok := neighbor.ParseFrame(frame)
+		Expect(ok).To(BeFalse())
+	},
+		Entry("a truncated frame", []byte{0x02, 0x00}),
+		Entry("an ARP probe", arpFrame("0.0.0.0")),
+		Entry("a truncated ARP packet", arpFrame("10.0.2.2")[:30]),
+		Entry("a duplicate address detection solicitation", ndpFrame(135, "::", "fd10:0:2::2")),
+		Entry("a link-local neighbor advertisement", ndpFrame(136, "fe80::1", "fe80::1")),
+		Entry("an NDP router solicitation", ndpFrame(133, "fd10:0:2::2", "fd10:0:2::1")),
+		Entry("an IPv4 frame", ethernetFrame(0x0800, make([]byte, 28))),
+	)
+})
+
+func ethernetFrame(etherType uint16, payload []byte) []byte {
+	frame := make([]byte, 14, 14+len(payload))
+	copy(frame[6:12], []byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x01})
+	binary.BigEndian.PutUint16(frame[12:14], etherType)
+	return append(frame, payload...)
+}
+
+func arpFrame(senderIP string) []byte {
+	payload := make([]byte, 28)
+	binary.BigEndian.PutUint16(payload[0:2], 1)
+	binary.BigEndian.PutUint16(payload[2:4], 0x0800)
+	payload[4], payload[5] = 6, 4
+	binary.BigEndian.PutUint16(payload[6:8], 1)
+	copy(payload[14:18], net.ParseIP(senderIP).To4())
+	copy(payload[24:28], net.ParseIP("10.0.2.1").To4())
+	return ethernetFrame(0x0806, payload)
+}
+
+func ndpFrame(icmpType byte, srcIP, targetIP string) []byte {
+	payload := make([]byte, 40+24)
+	payload[0] = 0x60
+	payload[6] = 58
+	payload[7] = 255
+	copy(payload[8:24], net.ParseIP(srcIP).To16())
+	copy(payload[24:40], net.ParseIP("ff02::1").To16())
+	payload[40] = icmpType
+	copy(payload[48:64], net.ParseIP(targetIP).To16())
+	return ethernetFrame(0x86dd, payload)
+}
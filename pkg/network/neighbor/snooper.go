@@ -0,0 +1,147 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package neighbor
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	// maxAddresses bounds the addresses learned per interface, protecting against a guest flooding announcements.
+	maxAddresses = 16
+	readTimeout  = time.Second
+	frameBufSize = 1514
+)
+
+// Snooper learns the addresses a guest uses by listening to the ARP and NDP traffic it sends through an interface.
+type Snooper struct {
+	fd   int
+	done chan struct{}
+	once sync.Once
+
+	lock      sync.Mutex
+	addresses []net.IP
+}
+
+// NewSnooper starts snooping on the given interface, it must be called from within the network namespace
+// the interface resides in. The snooper keeps working when the calling thread leaves the namespace.
+func NewSnooper(ifaceName string) (*Snooper, error) {
+	link, err := netlink.LinkByName(ifaceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find interface %s: %v", ifaceName, err)
+	}
+
+	protocol := htons(unix.ETH_P_ALL)
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW|unix.SOCK_CLOEXEC, int(protocol))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open packet socket: %v", err)
+	}
+
+	if err := unix.Bind(fd, &unix.SockaddrLinklayer{Protocol: protocol, Ifindex: link.Attrs().Index}); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("failed to bind packet socket to %s: %v", ifaceName, err)
+	}
+
+	timeout := unix.NsecToTimeval(readTimeout.Nanoseconds())
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &timeout); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("failed to set packet socket timeout: %v", err)
+	}
+
+	s := &Snooper{fd: fd, done: make(chan struct{})}
+	go s.run(ifaceName)
+	return s, nil
+}
+
+// Addresses returns the addresses learned so far, in the order they were first seen.
+func (s *Snooper) Addresses() []net.IP {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	addresses := make([]net.IP, len(s.addresses))
+	copy(addresses, s.addresses)
+	return addresses
+}
+
+// Close stops snooping, the underlying socket is released by the reading loop.
+func (s *Snooper) Close() {
+	s.once.Do(func() { close(s.done) })
+}
+
+func (s *Snooper) run(ifaceName string) {
+	defer unix.Close(s.fd)
+
+	buf := make([]byte, frameBufSize)
+	for {
+		n, from, err := unix.Recvfrom(s.fd, buf, 0)
+		select {
+		case <-s.done:
+			return
+		default:
+		}
+
+		if err != nil {
+			if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+				continue
+			}
+			log.Log.Reason(err).Errorf("stopped snooping neighbor traffic on %s", ifaceName)
+			return
+		}
+
+		// Frames sent towards the guest are not an indication of the addresses it owns.
+		if ll, ok := from.(*unix.SockaddrLinklayer); ok && ll.Pkttype == unix.PACKET_OUTGOING {
+			continue
+		}
+
+		if ip, ok := ParseFrame(buf[:n]); ok {
+			s.learn(ip)
+		}
+	}
+}
+
+func (s *Snooper) learn(ip net.IP) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, known := range s.addresses {
+		if known.Equal(ip) {
+			return
+		}
+	}
+	if len(s.addresses) < maxAddresses {
+		s.addresses = append(s.addresses, ip)
+	}
+}
+
+// htons converts to network byte order, as expected by the packet socket protocol field.
+func htons(v uint16) uint16 {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], v)
+	return *(*uint16)(unsafe.Pointer(&b[0]))
+}
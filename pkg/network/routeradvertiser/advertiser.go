@@ -0,0 +1,178 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package routeradvertiser
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/net/ipv6"
+
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	// DefaultRouterLifetime is the lifetime advertised when the configuration does not set one.
+	DefaultRouterLifetime = 1800 * time.Second
+
+	unsolicitedInterval = 200 * time.Second
+	ndpHopLimit         = 255
+	msgBufSize          = 1500
+)
+
+var (
+	allNodesAddr   = net.ParseIP("ff02::1")
+	allRoutersAddr = net.ParseIP("ff02::2")
+)
+
+// Advertiser sends router advertisements through an interface, periodically and in reply to router solicitations.
+type Advertiser struct {
+	conn  *ipv6.PacketConn
+	iface *net.Interface
+	msg   []byte
+	done  chan struct{}
+	once  sync.Once
+}
+
+// New starts advertising on the given interface, it must be called from within the network namespace
+// the interface resides in. The advertiser keeps working when the calling thread leaves the namespace.
+func New(ifaceName string, config Config) (*Advertiser, error) {
+	iface, err := net.InterfaceByName(ifaceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find interface %s: %v", ifaceName, err)
+	}
+	if config.RouterLifetime == 0 {
+		config.RouterLifetime = DefaultRouterLifetime
+	}
+	if config.SourceMAC == nil {
+		config.SourceMAC = iface.HardwareAddr
+	}
+
+	c, err := net.ListenPacket("ip6:ipv6-icmp", "::")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open icmpv6 socket: %v", err)
+	}
+	conn := ipv6.NewPacketConn(c)
+	if err := configureConn(conn, iface); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to configure icmpv6 socket on %s: %v", ifaceName, err)
+	}
+
+	a := &Advertiser{
+		conn:  conn,
+		iface: iface,
+		msg:   marshalRouterAdvertisement(config),
+		done:  make(chan struct{}),
+	}
+	go a.advertise()
+	go a.reply()
+	return a, nil
+}
+
+// Close stops advertising and releases the underlying socket.
+func (a *Advertiser) Close() {
+	a.once.Do(func() {
+		close(a.done)
+		a.conn.Close()
+	})
+}
+
+func configureConn(conn *ipv6.PacketConn, iface *net.Interface) error {
+	if err := conn.SetHopLimit(ndpHopLimit); err != nil {
+		return err
+	}
+	if err := conn.SetMulticastHopLimit(ndpHopLimit); err != nil {
+		return err
+	}
+	if err := conn.SetMulticastInterface(iface); err != nil {
+		return err
+	}
+	if err := conn.JoinGroup(iface, &net.IPAddr{IP: allRoutersAddr}); err != nil {
+		return err
+	}
+	if err := conn.SetControlMessage(ipv6.FlagInterface|ipv6.FlagHopLimit, true); err != nil {
+		return err
+	}
+
+	var filter ipv6.ICMPFilter
+	filter.SetAll(true)
+	filter.Accept(ipv6.ICMPTypeRouterSolicitation)
+	return conn.SetICMPFilter(&filter)
+}
+
+func (a *Advertiser) advertise() {
+	ticker := time.NewTicker(unsolicitedInterval)
+	defer ticker.Stop()
+	for {
+		a.send(&net.IPAddr{IP: allNodesAddr, Zone: a.iface.Name})
+		select {
+		case <-a.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *Advertiser) reply() {
+	buf := make([]byte, msgBufSize)
+	for {
+		n, cm, src, err := a.conn.ReadFrom(buf)
+		if err != nil {
+			select {
+			case <-a.done:
+				return
+			default:
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			log.Log.Reason(err).Errorf("stopped replying to router solicitations on %s", a.iface.Name)
+			return
+		}
+
+		// Solicitations must originate from the link itself, as guaranteed by the untouched hop limit.
+		if cm == nil || cm.IfIndex != a.iface.Index || cm.HopLimit != ndpHopLimit {
+			continue
+		}
+		if !isRouterSolicitation(buf[:n]) {
+			continue
+		}
+
+		// Replies go to all-nodes, as the guest may solicit before its link-local address is usable.
+		dst := &net.IPAddr{IP: allNodesAddr, Zone: a.iface.Name}
+		if srcAddr, ok := src.(*net.IPAddr); ok && !srcAddr.IP.IsUnspecified() {
+			dst = &net.IPAddr{IP: srcAddr.IP, Zone: a.iface.Name}
+		}
+		a.send(dst)
+	}
+}
+
+func (a *Advertiser) send(dst net.Addr) {
+	if _, err := a.conn.WriteTo(a.msg, nil, dst); err != nil {
+		select {
+		case <-a.done:
+		default:
+			log.Log.Reason(err).Warningf("failed to send router advertisement on %s", a.iface.Name)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package routeradvertiser
+
+import (
+	"encoding/binary"
+	"net"
+	"time"
+)
+
+const (
+	icmpTypeRouterSolicitation  = 133
+	icmpTypeRouterAdvertisement = 134
+
+	optionSourceLinkLayerAddress = 1
+	optionPrefixInformation      = 3
+	optionMTU                    = 5
+	optionRDNSS                  = 25
+
+	flagManaged    = 0x80
+	flagOther      = 0x40
+	flagOnLink     = 0x80
+	flagAutonomous = 0x40
+
+	raHeaderLen    = 16
+	curHopLimit    = 64
+	infiniteLife   = 0xffffffff
+	slaacPrefixLen = 64
+)
+
+// Config is the content of the router advertisements.
+type Config struct {
+	// SourceMAC is the link-layer address of the advertising interface.
+	SourceMAC net.HardwareAddr
+	// Prefix is advertised as on-link, and for autoconfiguration when it is a /64.
+	Prefix *net.IPNet
+	// DNSServers are advertised through the RDNSS option, when set.
+	DNSServers []net.IP
+	// MTU is advertised when set.
+	MTU uint32
+	// Managed and Other set the corresponding DHCPv6 flags.
+	Managed bool
+	Other   bool
+	// RouterLifetime is the time the guest should keep using the router as its default gateway.
+	RouterLifetime time.Duration
+}
+
+// marshalRouterAdvertisement builds an ICMPv6 router advertisement, leaving the checksum to the kernel.
+func marshalRouterAdvertisement(config Config) []byte {
+	msg := make([]byte, raHeaderLen)
+	msg[0] = icmpTypeRouterAdvertisement
+	msg[4] = curHopLimit
+	if config.Managed {
+		msg[5] |= flagManaged
+	}
+	if config.Other {
+		msg[5] |= flagOther
+	}
+	binary.BigEndian.PutUint16(msg[6:8], uint16(config.RouterLifetime/time.Second))
+
+	if len(config.SourceMAC) != 0 {
+		msg = append(msg, marshalOption(optionSourceLinkLayerAddress, config.SourceMAC)...)
+	}
+
+	if config.MTU != 0 {
+		mtu := make([]byte, 6)
+		binary.BigEndian.PutUint32(mtu[2:], config.MTU)
+		msg = append(msg, marshalOption(optionMTU, mtu)...)
+	}
+
+	if config.Prefix != nil {
+		prefixLen, _ := config.Prefix.Mask.Size()
+		prefix := make([]byte, 30)
+		prefix[0] = uint8(prefixLen)
+		prefix[1] = flagOnLink
+		if prefixLen == slaacPrefixLen {
+			prefix[1] |= flagAutonomous
+		}
+		binary.BigEndian.PutUint32(prefix[2:6], infiniteLife)
+		binary.BigEndian.PutUint32(prefix[6:10], infiniteLife)
+		copy(prefix[14:], config.Prefix.IP.Mask(config.Prefix.Mask).To16())
+		msg = append(msg, marshalOption(optionPrefixInformation, prefix)...)
+	}
+
+	if len(config.DNSServers) != 0 {
+		rdnss := make([]byte, 6, 6+net.IPv6len*len(config.DNSServers))
+		binary.BigEndian.PutUint32(rdnss[2:6], uint32(config.RouterLifetime/time.Second))
+		for _, server := range config.DNSServers {
+			rdnss = append(rdnss, server.To16()...)
+		}
+		msg = append(msg, marshalOption(optionRDNSS, rdnss)...)
+	}
+
+	return msg
+}
+
+// marshalOption encodes an NDP option, padding its body to a multiple of 8 octets.
+func marshalOption(optionType uint8, body []byte) []byte {
+	const unit = 8
+	optionLen := (2 + len(body) + unit - 1) / unit
+	option := make([]byte, optionLen*unit)
+	option[0] = optionType
+	option[1] = uint8(optionLen)
+	copy(option[2:], body)
+	return option
+}
+
+func isRouterSolicitation(msg []byte) bool {
+	const rsHeaderLen = 8
+	return len(msg) >= rsHeaderLen && msg[0] == icmpTypeRouterSolicitation && msg[1] == 0
+}
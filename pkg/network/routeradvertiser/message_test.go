@@ -0,0 +1,93 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package routeradvertiser
+
+import (
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("router advertisement message", func() {
+	sourceMAC := net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01}
+
+	It("carries the flags and router lifetime in the header", func() {
+		msg := marshalRouterAdvertisement(Config{Managed: true, Other: true, RouterLifetime: 1800 * time.Second})
+
+		Expect(msg).To(Equal([]byte{134, 0, 0, 0, 64, 0xc0, 0x07, 0x08, 0, 0, 0, 0, 0, 0, 0, 0}))
+	})
+
+	It("leaves the flags unset by default", func() {
+		msg := marshalRouterAdvertisement(Config{})
+
+		Expect(msg[5]).To(BeZero())
+	})
+
+	It("encodes the source link-layer address and MTU options", func() {
+		msg := marshalRouterAdvertisement(Config{SourceMAC: sourceMAC, MTU: 1400})
+
+		Expect(msg[16:]).To(Equal([]byte{
+			1, 1, 0x02, 0x00, 0x00, 0x00, 0x00, 0x01,
+			5, 1, 0, 0, 0x00, 0x00, 0x05, 0x78,
+		}))
+	})
+
+	DescribeTable("encodes the prefix information option", func(cidr string, expectedFlags byte) {
+		_, prefix, err := net.ParseCIDR(cidr)
+		Expect(err).ToNot(HaveOccurred())
+
+		msg := marshalRouterAdvertisement(Config{Prefix: prefix})
+
+		option := msg[16:]
+		Expect(option).To(HaveLen(32))
+		Expect(option[:2]).To(Equal([]byte{3, 4}))
+		ones, _ := prefix.Mask.Size()
+		Expect(option[2]).To(BeEquivalentTo(ones))
+		Expect(option[3]).To(Equal(expectedFlags))
+		Expect(net.IP(option[16:])).To(Equal(prefix.IP))
+	},
+		Entry("allowing autoconfiguration for a /64", "fd10:0:2::/64", byte(0xc0)),
+		Entry("only on-link for other prefix lengths", "fd10:0:2::/120", byte(0x80)),
+	)
+
+	It("encodes the recursive DNS servers option", func() {
+		dnsServers := []net.IP{net.ParseIP("fd00::53"), net.ParseIP("fd00::54")}
+
+		msg := marshalRouterAdvertisement(Config{DNSServers: dnsServers, RouterLifetime: 600 * time.Second})
+
+		option := msg[16:]
+		Expect(option).To(HaveLen(40))
+		Expect(option[:8]).To(Equal([]byte{25, 5, 0, 0, 0, 0, 0x02, 0x58}))
+		Expect(net.IP(option[8:24])).To(Equal(dnsServers[0]))
+		Expect(net.IP(option[24:40])).To(Equal(dnsServers[1]))
+	})
+
+	DescribeTable("recognizes router solicitations", func(msg []byte, expected bool) {
+		Expect(isRouterSolicitation(msg)).To(Equal(expected))
+	},
+		Entry("with a valid header", []byte{133, 0, 0, 0, 0, 0, 0, 0}, true),
+		Entry("with options", []byte{133, 0, 0, 0, 0, 0, 0, 0, 1, 1, 2, 0, 0, 0, 0, 1}, true),
+		Entry("when truncated", []byte{133, 0, 0, 0}, false),
+		Entry("with a non zero code", []byte{133, 1, 0, 0, 0, 0, 0, 0}, false),
+		Entry("of another type", []byte{134, 0, 0, 0, 0, 0, 0, 0}, false),
+	)
+})
@@ -0,0 +1,58 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"fmt"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/network/driver"
+	"kubevirt.io/kubevirt/pkg/network/link"
+	"kubevirt.io/kubevirt/pkg/network/neighbor"
+	"kubevirt.io/kubevirt/pkg/network/netns"
+)
+
+// newNeighborSnooper snoops the ARP/NDP traffic the guest sends through the tap device of a bridged interface.
+// The packet socket is opened from within the virt-launcher network namespace, since virt-launcher
+// itself lacks the capabilities required to do so.
+func newNeighborSnooper(launcherPID int, networks []v1.Network, network v1.Network) (string, GuestIPSnooper, error) {
+	var (
+		podInterfaceName string
+		snooper          *neighbor.Snooper
+	)
+	err := netns.New(launcherPID).Do(func() error {
+		podIfaceLink, err := link.DiscoverByNetwork(&driver.NetworkUtilsHandler{}, networks, network)
+		if err != nil {
+			return err
+		}
+		if podIfaceLink == nil {
+			return fmt.Errorf("pod interface of network %s not found", network.Name)
+		}
+		podInterfaceName = podIfaceLink.Attrs().Name
+
+		snooper, err = neighbor.NewSnooper(link.GenerateTapDeviceName(podInterfaceName))
+		return err
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return podInterfaceName, snooper, nil
+}
@@ -49,6 +49,10 @@ type NetConf struct {
 	nsFactory        nsFactory
 	configState      map[string]ConfigStateExecutor
 	configStateMutex *sync.RWMutex
+
+	newRouterAdvertiser RouterAdvertiserFactory
+	// Router advertisers of masquerade interfaces, key is the VMI UID followed by the interface name.
+	routerAdvertisers sync.Map
 }
 
 type nsFactory func(int) NSExecutor
@@ -65,11 +69,16 @@ func NewNetConf() *NetConf {
 }
 
 func NewNetConfWithCustomFactoryAndConfigState(nsFactory nsFactory, cacheCreator cacheCreator, configState map[string]ConfigStateExecutor) *NetConf {
+	return NewNetConfWithCustomFactories(nsFactory, cacheCreator, configState, newRouterAdvertiser)
+}
+
+func NewNetConfWithCustomFactories(nsFactory nsFactory, cacheCreator cacheCreator, configState map[string]ConfigStateExecutor, routerAdvertiserFactory RouterAdvertiserFactory) *NetConf {
 	return &NetConf{
-		configState:      configState,
-		configStateMutex: &sync.RWMutex{},
-		cacheCreator:     cacheCreator,
-		nsFactory:        nsFactory,
+		configState:         configState,
+		configStateMutex:    &sync.RWMutex{},
+		cacheCreator:        cacheCreator,
+		nsFactory:           nsFactory,
+		newRouterAdvertiser: routerAdvertiserFactory,
 	}
 }
 
@@ -148,6 +157,7 @@ func (c *NetConf) Teardown(vmi *v1.VirtualMachineInstance) error {
 	c.configStateMutex.Lock()
 	delete(c.configState, string(vmi.UID))
 	c.configStateMutex.Unlock()
+	c.stopRouterAdvertisement(vmi)
 	podCache := cache.NewPodInterfaceCache(c.cacheCreator, string(vmi.UID))
 	if err := podCache.Remove(); err != nil {
 		return fmt.Errorf("teardown failed, err: %w", err)
@@ -115,8 +115,109 @@ var _ = Describe("netconf", func() {
 			Expect(configState.RunWasExecuted).To(BeTrue())
 		})
 	})
+
+	Context("router advertisement", func() {
+		var (
+			advertisers    []*routerAdvertiserStub
+			advertiserErr  error
+			advertisedNets []string
+			noIPv6         bool
+		)
+
+		BeforeEach(func() {
+			advertisers = nil
+			advertiserErr = nil
+			advertisedNets = nil
+			noIPv6 = false
+			netConf = netsetup.NewNetConfWithCustomFactories(nsNoopFactory, &tempCacheCreator{}, configMap,
+				func(_ int, _ []v1.Network, network v1.Network, _ v1.Interface) (netsetup.RouterAdvertiser, error) {
+					if advertiserErr != nil {
+						return nil, advertiserErr
+					}
+					advertisedNets = append(advertisedNets, network.Name)
+					if noIPv6 {
+						return nil, nil
+					}
+					advertiser := &routerAdvertiserStub{}
+					advertisers = append(advertisers, advertiser)
+					return advertiser, nil
+				})
+
+			vmi.Spec.Networks = []v1.Network{
+				{Name: "default", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}},
+				{Name: "blue", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-nad"}}},
+			}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{
+					Name:                   "default",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}},
+					RouterAdvertisement:    &v1.RouterAdvertisementOptions{},
+				},
+				{Name: "blue", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+			}
+		})
+
+		It("advertises on masquerade interfaces only", func() {
+			Expect(netConf.StartRouterAdvertisement(vmi, launcherPid)).To(Succeed())
+			Expect(advertisedNets).To(Equal([]string{"default"}))
+		})
+
+		It("does not advertise on interfaces without router advertisement options", func() {
+			vmi.Spec.Domain.Devices.Interfaces[0].RouterAdvertisement = nil
+
+			Expect(netConf.RouterAdvertisementPending(vmi)).To(BeFalse())
+			Expect(netConf.StartRouterAdvertisement(vmi, launcherPid)).To(Succeed())
+			Expect(advertisedNets).To(BeEmpty())
+		})
+
+		It("advertises once per interface", func() {
+			Expect(netConf.RouterAdvertisementPending(vmi)).To(BeTrue())
+			Expect(netConf.StartRouterAdvertisement(vmi, launcherPid)).To(Succeed())
+			Expect(netConf.RouterAdvertisementPending(vmi)).To(BeFalse())
+			Expect(netConf.StartRouterAdvertisement(vmi, launcherPid)).To(Succeed())
+			Expect(advertisers).To(HaveLen(1))
+		})
+
+		It("does not probe again an interface without IPv6 connectivity", func() {
+			noIPv6 = true
+			Expect(netConf.StartRouterAdvertisement(vmi, launcherPid)).To(Succeed())
+			Expect(netConf.RouterAdvertisementPending(vmi)).To(BeFalse())
+			Expect(netConf.StartRouterAdvertisement(vmi, launcherPid)).To(Succeed())
+			Expect(advertisedNets).To(Equal([]string{"default"}))
+		})
+
+		It("retries an interface whose advertiser failed to start", func() {
+			advertiserErr = fmt.Errorf("boom")
+			Expect(netConf.StartRouterAdvertisement(vmi, launcherPid)).NotTo(Succeed())
+			Expect(netConf.RouterAdvertisementPending(vmi)).To(BeTrue())
+		})
+
+		It("fails when the advertiser cannot be started", func() {
+			advertiserErr = fmt.Errorf("boom")
+			Expect(netConf.StartRouterAdvertisement(vmi, launcherPid)).To(MatchError(ContainSubstring("boom")))
+		})
+
+		It("stops advertising on teardown", func() {
+			Expect(netConf.StartRouterAdvertisement(vmi, launcherPid)).To(Succeed())
+
+			Expect(netConf.Teardown(vmi)).To(Succeed())
+
+			Expect(advertisers).To(HaveLen(1))
+			Expect(advertisers[0].closed).To(BeTrue())
+			Expect(netConf.StartRouterAdvertisement(vmi, launcherPid)).To(Succeed())
+			Expect(advertisers).To(HaveLen(2))
+		})
+	})
 })
 
+type routerAdvertiserStub struct {
+	closed bool
+}
+
+func (r *routerAdvertiserStub) Close() {
+	r.closed = true
+}
+
 type netnsStub struct {
 	shouldFail bool
 }
@@ -21,6 +21,8 @@ package network
 
 import (
 	"fmt"
+	"net"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -39,6 +41,21 @@ const (
 	UnknownInterfaceQueueCount = 0
 )
 
+// GuestIPSnooper reports the addresses a guest has been observed using on an interface.
+type GuestIPSnooper interface {
+	Addresses() []net.IP
+	Close()
+}
+
+// GuestIPSnooperFactory starts snooping on the guest side of the given network, returning the pod interface name backing it.
+type GuestIPSnooperFactory func(launcherPID int, networks []v1.Network, network v1.Network) (string, GuestIPSnooper, error)
+
+type guestIPLearner struct {
+	launcherPID      int
+	podInterfaceName string
+	snooper          GuestIPSnooper
+}
+
 type NetStat struct {
 	cacheCreator cacheCreator
 
@@ -46,6 +63,11 @@ type NetStat struct {
 	// key is the file path, value is the contents.
 	// if key exists, then don't read directly from file.
 	podInterfaceVolatileCache sync.Map
+
+	newGuestIPSnooper GuestIPSnooperFactory
+	// Guest IP learners of bridged interfaces, used when no guest agent is available.
+	// key is the same as of podInterfaceVolatileCache, value is a *guestIPLearner.
+	guestIPLearners sync.Map
 }
 
 func NewNetStat() *NetStat {
@@ -53,9 +75,14 @@ func NewNetStat() *NetStat {
 }
 
 func NewNetStateWithCustomFactory(cacheCreator cacheCreator) *NetStat {
+	return NewNetStateWithCustomFactories(cacheCreator, newNeighborSnooper)
+}
+
+func NewNetStateWithCustomFactories(cacheCreator cacheCreator, snooperFactory GuestIPSnooperFactory) *NetStat {
 	return &NetStat{
 		cacheCreator:              cacheCreator,
 		podInterfaceVolatileCache: sync.Map{},
+		newGuestIPSnooper:         snooperFactory,
 	}
 }
 
@@ -66,6 +93,80 @@ func (c *NetStat) Teardown(vmi *v1.VirtualMachineInstance) {
 		}
 		return true
 	})
+	c.StopGuestIPLearning(vmi)
+}
+
+// StopGuestIPLearning stops learning the IP addresses used by the guest, e.g. once the guest agent reports them.
+func (c *NetStat) StopGuestIPLearning(vmi *v1.VirtualMachineInstance) {
+	c.guestIPLearners.Range(func(key, value interface{}) bool {
+		if strings.HasPrefix(key.(string), string(vmi.UID)) {
+			c.stopGuestIPLearner(key.(string))
+		}
+		return true
+	})
+}
+
+// StartGuestIPLearning starts learning the IP addresses used by the guest on its bridged interfaces,
+// out of the DHCP leases granted by virt-launcher and the neighbor traffic the guest sends.
+// It allows reporting the guest addresses when no guest agent is running.
+// Interfaces which are already learned or not yet plugged into the domain are skipped.
+func (c *NetStat) StartGuestIPLearning(vmi *v1.VirtualMachineInstance, launcherPID int) error {
+	networksByName := netvmispec.IndexNetworkSpecByName(vmi.Spec.Networks)
+
+	var errs []string
+	for _, iface := range c.pendingGuestIPLearners(vmi) {
+		network, exists := networksByName[iface.Name]
+		if !exists {
+			continue
+		}
+
+		podInterfaceName, snooper, err := c.newGuestIPSnooper(launcherPID, vmi.Spec.Networks, network)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", iface.Name, err))
+			continue
+		}
+		c.guestIPLearners.Store(vmiInterfaceKey(vmi.UID, iface.Name), &guestIPLearner{
+			launcherPID:      launcherPID,
+			podInterfaceName: podInterfaceName,
+			snooper:          snooper,
+		})
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to start learning the guest IPs of interfaces: %s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// GuestIPLearningPending returns true if some plugged bridged interface of the VMI
+// is not learned yet by StartGuestIPLearning.
+func (c *NetStat) GuestIPLearningPending(vmi *v1.VirtualMachineInstance) bool {
+	return len(c.pendingGuestIPLearners(vmi)) > 0
+}
+
+func (c *NetStat) pendingGuestIPLearners(vmi *v1.VirtualMachineInstance) []v1.Interface {
+	pluggedIfacesByName := netvmispec.IndexInterfacesFromStatus(
+		vmi.Status.Interfaces,
+		func(ifaceStatus v1.VirtualMachineInstanceNetworkInterface) bool {
+			return netvmispec.ContainsInfoSource(ifaceStatus.InfoSource, netvmispec.InfoSourceDomain)
+		},
+	)
+	return netvmispec.FilterInterfacesSpec(vmi.Spec.Domain.Devices.Interfaces, func(iface v1.Interface) bool {
+		if iface.Bridge == nil || iface.State == v1.InterfaceStateAbsent {
+			return false
+		}
+		if _, isPlugged := pluggedIfacesByName[iface.Name]; !isPlugged {
+			return false
+		}
+		_, exists := c.guestIPLearners.Load(vmiInterfaceKey(vmi.UID, iface.Name))
+		return !exists
+	})
+}
+
+func (c *NetStat) stopGuestIPLearner(key string) {
+	if learner, exists := c.guestIPLearners.LoadAndDelete(key); exists {
+		learner.(*guestIPLearner).snooper.Close()
+	}
 }
 
 func (c *NetStat) PodInterfaceVolatileDataIsCached(vmi *v1.VirtualMachineInstance, ifaceName string) bool {
@@ -124,6 +225,7 @@ func (c *NetStat) UpdateStatus(vmi *v1.VirtualMachineInstance, domain *api.Domai
 		if err != nil {
 			return err
 		}
+		c.updateIfacesStatusFromLearnedIPs(interfacesStatus, vmi)
 	}
 
 	primaryInterfaceStatus, interfacesStatus := netvmispec.PopInterfaceByNetwork(interfacesStatus, netvmispec.LookupPodNetwork(vmi.Spec.Networks))
@@ -164,6 +266,76 @@ func (c *NetStat) updateIfacesStatusFromPodCache(ifacesStatus []v1.VirtualMachin
 	return ifacesStatus, nil
 }
 
+// updateIfacesStatusFromLearnedIPs merges the addresses learned from the DHCP leases and neighbor snooping
+// into the provided interfaces statuses, recording the origin of each address.
+// Interfaces with no learned addresses are left untouched.
+func (c *NetStat) updateIfacesStatusFromLearnedIPs(ifacesStatus []v1.VirtualMachineInstanceNetworkInterface, vmi *v1.VirtualMachineInstance) {
+	for i := range ifacesStatus {
+		ifaceStatus := &ifacesStatus[i]
+		value, exists := c.guestIPLearners.Load(vmiInterfaceKey(vmi.UID, ifaceStatus.Name))
+		if !exists {
+			continue
+		}
+		learner := value.(*guestIPLearner)
+
+		ipSources := newIPSources(ifaceStatus.IPs)
+		learned := false
+		lease, err := cache.ReadDHCPLeaseCache(c.cacheCreator, strconv.Itoa(learner.launcherPID), learner.podInterfaceName)
+		if err == nil {
+			for _, ip := range []string{lease.IP, lease.IPv6} {
+				learned = ipSources.add(ip, netvmispec.IPSourceDHCPLease) || learned
+			}
+		}
+		for _, ip := range learner.snooper.Addresses() {
+			learned = ipSources.add(ip.String(), netvmispec.IPSourceNeighborSnooping) || learned
+		}
+		if !learned {
+			continue
+		}
+
+		ifaceStatus.IPSources = ipSources
+		ifaceStatus.IPs = ipSources.ips()
+		ifaceStatus.IP = ifaceStatus.IPs[0]
+	}
+}
+
+type ipSources []v1.VirtualMachineInstanceNetworkInterfaceIPSource
+
+func newIPSources(podIPs []string) ipSources {
+	var sources ipSources
+	for _, ip := range podIPs {
+		sources.add(ip, netvmispec.IPSourcePodInterface)
+	}
+	return sources
+}
+
+// add records the address with its source and reports if it was learned.
+// An address already known from the pod interface is attributed to the learned source, confirming the guest uses it.
+func (s *ipSources) add(ip, source string) bool {
+	if ip == "" {
+		return false
+	}
+	for i := range *s {
+		if (*s)[i].IP == ip {
+			if (*s)[i].Source != netvmispec.IPSourcePodInterface {
+				return false
+			}
+			(*s)[i].Source = source
+			return source != netvmispec.IPSourcePodInterface
+		}
+	}
+	*s = append(*s, v1.VirtualMachineInstanceNetworkInterfaceIPSource{IP: ip, Source: source})
+	return source != netvmispec.IPSourcePodInterface
+}
+
+func (s ipSources) ips() []string {
+	var ips []string
+	for _, source := range s {
+		ips = append(ips, source.IP)
+	}
+	return ips
+}
+
 func (c *NetStat) getPodInterfacefromFileCache(vmi *v1.VirtualMachineInstance, ifaceName string) (*cache.PodIfaceCacheData, error) {
 	// Once the Interface files are set on the handler, they don't change
 	// If already present in the map, don't read again
@@ -192,6 +364,15 @@ func (c *NetStat) removeAbsentIfacesFromVolatileCache(vmi *v1.VirtualMachineInst
 			}
 		}
 
+		return true
+	})
+	c.guestIPLearners.Range(func(key, value interface{}) bool {
+		if strings.HasPrefix(key.(string), string(vmi.UID)) {
+			if iface, ok := interfaceByName[ifaceNameFromKey(key.(string), vmi.UID)]; ok && iface.State == v1.InterfaceStateAbsent {
+				c.stopGuestIPLearner(key.(string))
+			}
+		}
+
 		return true
 	})
 }
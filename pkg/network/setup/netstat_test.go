@@ -20,6 +20,10 @@
 package network_test
 
 import (
+	"errors"
+	"net"
+	"strconv"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -674,6 +678,198 @@ var _ = Describe("netstat", func() {
 			Expect(setup.Vmi.Status.Interfaces).To(BeEmpty())
 		})
 	})
+
+	Context("guest IP learning", func() {
+		const (
+			networkName      = "primary"
+			podInterfaceName = "eth0"
+			launcherPID      = 1234
+			MAC              = "1C:CE:C0:01:BE:E7"
+			podIPv4          = "1.1.1.1"
+			guestIPv6        = "fd10:0:2::2"
+		)
+
+		var snooper *guestIPSnooperStub
+
+		BeforeEach(func() {
+			snooper = &guestIPSnooperStub{}
+			setup.NetStat = netsetup.NewNetStateWithCustomFactories(setup.cacheCreator,
+				func(_ int, _ []v1.Network, _ v1.Network) (string, netsetup.GuestIPSnooper, error) {
+					return podInterfaceName, snooper, nil
+				},
+			)
+		})
+
+		startLearning := func() {
+			Expect(setup.NetStat.UpdateStatus(setup.Vmi, setup.Domain)).To(Succeed())
+			Expect(setup.NetStat.StartGuestIPLearning(setup.Vmi, launcherPID)).To(Succeed())
+		}
+
+		writeLease := func(lease *cache.DHCPLease) {
+			Expect(cache.WriteDHCPLeaseCache(setup.cacheCreator, strconv.Itoa(launcherPID), podInterfaceName, lease)).To(Succeed())
+		}
+
+		It("reports the DHCP lease and snooped addresses of a bridged interface", func() {
+			Expect(setup.addNetworkInterface(
+				newVMISpecIfaceWithBridgeBinding(networkName),
+				newVMISpecPodNetwork(networkName),
+				newDomainSpecIface(networkName, MAC),
+				podIPv4,
+			)).To(Succeed())
+			startLearning()
+
+			writeLease(&cache.DHCPLease{IP: podIPv4})
+			snooper.addresses = []net.IP{net.ParseIP(podIPv4), net.ParseIP(guestIPv6)}
+
+			Expect(setup.NetStat.UpdateStatus(setup.Vmi, setup.Domain)).To(Succeed())
+
+			expectedIface := newVMIStatusIface(networkName, []string{podIPv4, guestIPv6}, MAC, "", netvmispec.InfoSourceDomain, netsetup.DefaultInterfaceQueueCount)
+			expectedIface.IPSources = []v1.VirtualMachineInstanceNetworkInterfaceIPSource{
+				{IP: podIPv4, Source: netvmispec.IPSourceDHCPLease},
+				{IP: guestIPv6, Source: netvmispec.IPSourceNeighborSnooping},
+			}
+			Expect(setup.Vmi.Status.Interfaces).To(Equal([]v1.VirtualMachineInstanceNetworkInterface{expectedIface}))
+		})
+
+		It("keeps reporting the pod interface addresses when nothing was learned", func() {
+			Expect(setup.addNetworkInterface(
+				newVMISpecIfaceWithBridgeBinding(networkName),
+				newVMISpecPodNetwork(networkName),
+				newDomainSpecIface(networkName, MAC),
+				podIPv4,
+			)).To(Succeed())
+			startLearning()
+
+			Expect(setup.NetStat.UpdateStatus(setup.Vmi, setup.Domain)).To(Succeed())
+
+			Expect(setup.Vmi.Status.Interfaces).To(Equal([]v1.VirtualMachineInstanceNetworkInterface{
+				newVMIStatusIface(networkName, []string{podIPv4}, MAC, "", netvmispec.InfoSourceDomain, netsetup.DefaultInterfaceQueueCount),
+			}))
+		})
+
+		It("prefers the guest-agent report over the learned addresses", func() {
+			Expect(setup.addNetworkInterface(
+				newVMISpecIfaceWithBridgeBinding(networkName),
+				newVMISpecPodNetwork(networkName),
+				newDomainSpecIface(networkName, MAC),
+				podIPv4,
+			)).To(Succeed())
+			startLearning()
+			snooper.addresses = []net.IP{net.ParseIP(guestIPv6)}
+
+			setup.addGuestAgentInterfaces(newDomainStatusIface([]string{podIPv4}, MAC, "eth0"))
+			Expect(setup.NetStat.UpdateStatus(setup.Vmi, setup.Domain)).To(Succeed())
+
+			Expect(setup.Vmi.Status.Interfaces).To(Equal([]v1.VirtualMachineInstanceNetworkInterface{
+				newVMIStatusIface(networkName, []string{podIPv4}, MAC, "eth0", netvmispec.InfoSourceDomainAndGA, netsetup.DefaultInterfaceQueueCount),
+			}))
+		})
+
+		It("does not learn the addresses of a masquerade interface", func() {
+			Expect(setup.addNetworkInterface(
+				newVMISpecIfaceWithMasqueradeBinding(networkName),
+				newVMISpecPodNetwork(networkName),
+				newDomainSpecIface(networkName, MAC),
+				podIPv4,
+			)).To(Succeed())
+			setup.NetStat = netsetup.NewNetStateWithCustomFactories(setup.cacheCreator,
+				func(_ int, _ []v1.Network, _ v1.Network) (string, netsetup.GuestIPSnooper, error) {
+					Fail("no snooper is expected")
+					return "", nil, nil
+				},
+			)
+
+			startLearning()
+		})
+
+		It("is pending until the plugged bridged interfaces are learned", func() {
+			Expect(setup.addNetworkInterface(
+				newVMISpecIfaceWithBridgeBinding(networkName),
+				newVMISpecPodNetwork(networkName),
+				newDomainSpecIface(networkName, MAC),
+				podIPv4,
+			)).To(Succeed())
+			Expect(setup.NetStat.GuestIPLearningPending(setup.Vmi)).To(BeFalse(), "the interface is not plugged yet")
+
+			Expect(setup.NetStat.UpdateStatus(setup.Vmi, setup.Domain)).To(Succeed())
+			Expect(setup.NetStat.GuestIPLearningPending(setup.Vmi)).To(BeTrue())
+
+			Expect(setup.NetStat.StartGuestIPLearning(setup.Vmi, launcherPID)).To(Succeed())
+			Expect(setup.NetStat.GuestIPLearningPending(setup.Vmi)).To(BeFalse())
+		})
+
+		It("is not pending for a masquerade interface", func() {
+			Expect(setup.addNetworkInterface(
+				newVMISpecIfaceWithMasqueradeBinding(networkName),
+				newVMISpecPodNetwork(networkName),
+				newDomainSpecIface(networkName, MAC),
+				podIPv4,
+			)).To(Succeed())
+			Expect(setup.NetStat.UpdateStatus(setup.Vmi, setup.Domain)).To(Succeed())
+
+			Expect(setup.NetStat.GuestIPLearningPending(setup.Vmi)).To(BeFalse())
+		})
+
+		It("fails to start learning when the snooper cannot be created", func() {
+			Expect(setup.addNetworkInterface(
+				newVMISpecIfaceWithBridgeBinding(networkName),
+				newVMISpecPodNetwork(networkName),
+				newDomainSpecIface(networkName, MAC),
+				podIPv4,
+			)).To(Succeed())
+			setup.NetStat = netsetup.NewNetStateWithCustomFactories(setup.cacheCreator,
+				func(_ int, _ []v1.Network, _ v1.Network) (string, netsetup.GuestIPSnooper, error) {
+					return "", nil, errors.New("test")
+				},
+			)
+
+			Expect(setup.NetStat.UpdateStatus(setup.Vmi, setup.Domain)).To(Succeed())
+			Expect(setup.NetStat.StartGuestIPLearning(setup.Vmi, launcherPID)).NotTo(Succeed())
+		})
+
+		It("stops snooping on teardown", func() {
+			Expect(setup.addNetworkInterface(
+				newVMISpecIfaceWithBridgeBinding(networkName),
+				newVMISpecPodNetwork(networkName),
+				newDomainSpecIface(networkName, MAC),
+				podIPv4,
+			)).To(Succeed())
+			startLearning()
+
+			setup.NetStat.Teardown(setup.Vmi)
+
+			Expect(snooper.closed).To(BeTrue())
+		})
+
+		It("stops snooping once the guest agent reports the addresses", func() {
+			Expect(setup.addNetworkInterface(
+				newVMISpecIfaceWithBridgeBinding(networkName),
+				newVMISpecPodNetwork(networkName),
+				newDomainSpecIface(networkName, MAC),
+				podIPv4,
+			)).To(Succeed())
+			startLearning()
+
+			setup.NetStat.StopGuestIPLearning(setup.Vmi)
+
+			Expect(snooper.closed).To(BeTrue())
+		})
+
+		It("stops snooping on an absent interface", func() {
+			Expect(setup.addNetworkInterface(
+				newVMISpecIfaceWithBridgeBinding(networkName),
+				newVMISpecPodNetwork(networkName),
+				newDomainSpecIface(networkName, MAC),
+				podIPv4,
+			)).To(Succeed())
+			startLearning()
+
+			setup.Vmi.Spec.Domain.Devices.Interfaces[0].State = v1.InterfaceStateAbsent
+			Expect(setup.NetStat.UpdateStatus(setup.Vmi, setup.Domain)).To(Succeed())
+
+			Expect(snooper.closed).To(BeTrue())
+		})
+	})
 })
 
 type testSetup struct {
@@ -863,3 +1059,16 @@ func newVMISpecMultusNetwork(name string) v1.Network {
 			}},
 	}
 }
+
+type guestIPSnooperStub struct {
+	addresses []net.IP
+	closed    bool
+}
+
+func (s *guestIPSnooperStub) Addresses() []net.IP {
+	return s.addresses
+}
+
+func (s *guestIPSnooperStub) Close() {
+	s.closed = true
+}
@@ -291,6 +291,30 @@ var _ = Describe("VMNetworkConfigurator", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(nics).To(BeEmpty())
 			})
+
+			It("should not process vhostuser networks", func() {
+				vmi := api2.NewMinimalVMIWithNS("testnamespace", "testVmName")
+				const networkName = "dpdk"
+				vmi.Spec.Networks = []v1.Network{{
+					Name: networkName,
+					NetworkSource: v1.NetworkSource{
+						Multus: &v1.MultusNetwork{NetworkName: "dpdk-nad"},
+					},
+				}}
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+					Name: networkName, InterfaceBindingMethod: v1.InterfaceBindingMethod{VhostUser: &v1.InterfaceVhostUser{}},
+				}}
+
+				launcherPID := 0
+				vmNetworkConfigurator := NewVMNetworkConfigurator(vmi, nil, &launcherPID)
+				nics, err := vmNetworkConfigurator.getPhase1NICs(&launcherPID, vmi.Spec.Networks)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(nics).To(BeEmpty())
+
+				nics, err = vmNetworkConfigurator.getPhase2NICs(&api.Domain{}, vmi.Spec.Networks)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(nics).To(BeEmpty())
+			})
 		})
 	})
 
@@ -0,0 +1,124 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vishvananda/netlink"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	netdriver "kubevirt.io/kubevirt/pkg/network/driver"
+	virtnetlink "kubevirt.io/kubevirt/pkg/network/link"
+	"kubevirt.io/kubevirt/pkg/network/vmispec"
+)
+
+// Reattach connects the pod interfaces that have been replaced by the CNI, following a change
+// of the network they are attached to, back to the bridge serving the guest interface.
+func (c *NetConf) Reattach(vmi *v1.VirtualMachineInstance, launcherPid int) error {
+	networks := reattachableNetworks(vmi)
+	if len(networks) == 0 {
+		return nil
+	}
+
+	netConfigurator := NewVMNetworkConfigurator(vmi, c.cacheCreator, &launcherPid)
+	return c.nsFactory(launcherPid).Do(func() error {
+		return netConfigurator.ReattachPodNetworks(networks)
+	})
+}
+
+// ReattachPodNetworks is expected to be executed in the pod network namespace.
+func (n *VMNetworkConfigurator) ReattachPodNetworks(networks []v1.Network) error {
+	for _, network := range networks {
+		if err := reattachPodInterface(n.handler, n.vmi.Spec.Networks, network); err != nil {
+			return fmt.Errorf("failed to re-attach network %q: %w", network.Name, err)
+		}
+	}
+	return nil
+}
+
+func reattachableNetworks(vmi *v1.VirtualMachineInstance) []v1.Network {
+	ifaces := vmispec.FilterInterfacesSpec(vmi.Spec.Domain.Devices.Interfaces, func(iface v1.Interface) bool {
+		return iface.Bridge != nil && iface.State != v1.InterfaceStateAbsent
+	})
+	var networks []v1.Network
+	for _, network := range vmispec.FilterNetworksByInterfaces(vmi.Spec.Networks, ifaces) {
+		if vmispec.IsSecondaryMultusNetwork(network) {
+			networks = append(networks, network)
+		}
+	}
+	return networks
+}
+
+func reattachPodInterface(handler netdriver.NetworkHandler, networks []v1.Network, network v1.Network) error {
+	podLink, err := virtnetlink.DiscoverByNetwork(handler, networks, network)
+	if err != nil {
+		return err
+	}
+	// The pod interface is either not plugged yet or still connected to the bridge
+	if podLink == nil || podLink.Attrs().MasterIndex != 0 {
+		return nil
+	}
+
+	podIfaceName := podLink.Attrs().Name
+	bridgeLink, err := handler.LinkByName(virtnetlink.GenerateBridgeName(podIfaceName))
+	if err != nil {
+		var linkNotFoundErr netlink.LinkNotFoundError
+		if errors.As(err, &linkNotFoundErr) {
+			// The interface was never wired, the regular setup flow takes care of it
+			return nil
+		}
+		return err
+	}
+	bridge, isBridge := bridgeLink.(*netlink.Bridge)
+	if !isBridge {
+		return fmt.Errorf("link %s is not a bridge", bridgeLink.Attrs().Name)
+	}
+
+	addrs, err := handler.AddrList(podLink, netlink.FAMILY_V4)
+	if err != nil {
+		return err
+	}
+	if len(addrs) > 0 {
+		return fmt.Errorf("pod interface %s has an IP address, re-attaching networks with IPAM is not supported", podIfaceName)
+	}
+
+	log.Log.Infof("re-attaching pod interface %s to bridge %s", podIfaceName, bridge.Name)
+
+	if err := handler.LinkSetDown(podLink); err != nil {
+		return fmt.Errorf("failed to bring link down for interface %s: %w", podIfaceName, err)
+	}
+	if err := handler.LinkSetHardwareAddr(podLink, bridge.Attrs().HardwareAddr); err != nil {
+		return fmt.Errorf("failed to set on pod interface %s the mac %s: %w", podIfaceName, bridge.Attrs().HardwareAddr, err)
+	}
+	if err := handler.LinkSetMaster(podLink, bridge); err != nil {
+		return fmt.Errorf("failed to connect interface %s to bridge %s: %w", podIfaceName, bridge.Name, err)
+	}
+	if err := handler.LinkSetUp(podLink); err != nil {
+		return fmt.Errorf("failed to bring link up for interface %s: %w", podIfaceName, err)
+	}
+	if err := handler.LinkSetLearningOff(podLink); err != nil {
+		return fmt.Errorf("failed to disable mac learning for interface %s: %w", podIfaceName, err)
+	}
+	return nil
+}
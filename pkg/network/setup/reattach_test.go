@@ -0,0 +1,146 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"errors"
+	"net"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vishvananda/netlink"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	netdriver "kubevirt.io/kubevirt/pkg/network/driver"
+	virtnetlink "kubevirt.io/kubevirt/pkg/network/link"
+	"kubevirt.io/kubevirt/pkg/network/namescheme"
+)
+
+var _ = Describe("network re-attachment", func() {
+	const networkName = "blue"
+
+	var (
+		mockHandler           *netdriver.MockNetworkHandler
+		vmNetworkConfigurator *VMNetworkConfigurator
+		vmi                   *v1.VirtualMachineInstance
+		podIfaceName          string
+		bridgeName            string
+	)
+
+	BeforeEach(func() {
+		mockHandler = netdriver.NewMockNetworkHandler(gomock.NewController(GinkgoT()))
+
+		vmi = newVMIBridgeInterface("testnamespace", "testVmName")
+		vmi.Spec.Domain.Devices.Interfaces = append(vmi.Spec.Domain.Devices.Interfaces, v1.Interface{Name: networkName, InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}})
+		vmi.Spec.Networks = append(vmi.Spec.Networks, multusNetwork(networkName))
+
+		launcherPID := 0
+		vmNetworkConfigurator = newVMNetworkConfiguratorWithHandlerAndCache(vmi, mockHandler, nil, &launcherPID)
+
+		podIfaceName = namescheme.GenerateHashedInterfaceName(networkName)
+		bridgeName = virtnetlink.GenerateBridgeName(podIfaceName)
+	})
+
+	It("selects only the bridged secondary networks", func() {
+		vmi.Spec.Domain.Devices.Interfaces = append(vmi.Spec.Domain.Devices.Interfaces,
+			v1.Interface{Name: "red", State: v1.InterfaceStateAbsent, InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+			v1.Interface{Name: "green", InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}},
+		)
+		vmi.Spec.Networks = append(vmi.Spec.Networks, multusNetwork("red"), multusNetwork("green"))
+
+		Expect(reattachableNetworks(vmi)).To(Equal([]v1.Network{multusNetwork(networkName)}))
+	})
+
+	It("does nothing when the pod interface is connected to the bridge", func() {
+		podLink := &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: podIfaceName, MasterIndex: 10}}
+		mockHandler.EXPECT().LinkByName(podIfaceName).Return(podLink, nil)
+
+		Expect(vmNetworkConfigurator.ReattachPodNetworks([]v1.Network{multusNetwork(networkName)})).To(Succeed())
+	})
+
+	It("does nothing when the pod interface does not exist", func() {
+		mockHandler.EXPECT().LinkByName(gomock.Any()).Return(nil, netlink.LinkNotFoundError{}).Times(2)
+
+		Expect(vmNetworkConfigurator.ReattachPodNetworks([]v1.Network{multusNetwork(networkName)})).To(Succeed())
+	})
+
+	It("does nothing when the bridge does not exist", func() {
+		podLink := &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: podIfaceName}}
+		mockHandler.EXPECT().LinkByName(podIfaceName).Return(podLink, nil)
+		mockHandler.EXPECT().LinkByName(bridgeName).Return(nil, netlink.LinkNotFoundError{})
+
+		Expect(vmNetworkConfigurator.ReattachPodNetworks([]v1.Network{multusNetwork(networkName)})).To(Succeed())
+	})
+
+	Context("when the pod interface was replaced", func() {
+		var (
+			podLink    *netlink.Dummy
+			bridgeLink *netlink.Bridge
+		)
+
+		BeforeEach(func() {
+			podLink = &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: podIfaceName}}
+			bridgeLink = &netlink.Bridge{LinkAttrs: netlink.LinkAttrs{
+				Name:         bridgeName,
+				HardwareAddr: net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+			}}
+			mockHandler.EXPECT().LinkByName(podIfaceName).Return(podLink, nil)
+			mockHandler.EXPECT().LinkByName(bridgeName).Return(bridgeLink, nil)
+		})
+
+		It("connects it to the bridge", func() {
+			mockHandler.EXPECT().AddrList(podLink, netlink.FAMILY_V4).Return(nil, nil)
+			gomock.InOrder(
+				mockHandler.EXPECT().LinkSetDown(podLink).Return(nil),
+				mockHandler.EXPECT().LinkSetHardwareAddr(podLink, bridgeLink.HardwareAddr).Return(nil),
+				mockHandler.EXPECT().LinkSetMaster(podLink, bridgeLink).Return(nil),
+				mockHandler.EXPECT().LinkSetUp(podLink).Return(nil),
+				mockHandler.EXPECT().LinkSetLearningOff(podLink).Return(nil),
+			)
+
+			Expect(vmNetworkConfigurator.ReattachPodNetworks([]v1.Network{multusNetwork(networkName)})).To(Succeed())
+		})
+
+		It("fails when the new pod interface has an IP address", func() {
+			mockHandler.EXPECT().AddrList(podLink, netlink.FAMILY_V4).Return([]netlink.Addr{{}}, nil)
+
+			Expect(vmNetworkConfigurator.ReattachPodNetworks([]v1.Network{multusNetwork(networkName)})).NotTo(Succeed())
+		})
+
+		It("fails when the pod interface cannot be connected to the bridge", func() {
+			mockHandler.EXPECT().AddrList(podLink, netlink.FAMILY_V4).Return(nil, nil)
+			mockHandler.EXPECT().LinkSetDown(podLink).Return(nil)
+			mockHandler.EXPECT().LinkSetHardwareAddr(podLink, bridgeLink.HardwareAddr).Return(nil)
+			mockHandler.EXPECT().LinkSetMaster(podLink, bridgeLink).Return(errors.New("boom"))
+
+			Expect(vmNetworkConfigurator.ReattachPodNetworks([]v1.Network{multusNetwork(networkName)})).To(MatchError(ContainSubstring("boom")))
+		})
+	})
+})
+
+func multusNetwork(name string) v1.Network {
+	return v1.Network{
+		Name:          name,
+		NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: name + "-nad"}},
+	}
+}
@@ -0,0 +1,175 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/network/driver"
+	"kubevirt.io/kubevirt/pkg/network/link"
+	"kubevirt.io/kubevirt/pkg/network/netns"
+	"kubevirt.io/kubevirt/pkg/network/routeradvertiser"
+	netvmispec "kubevirt.io/kubevirt/pkg/network/vmispec"
+)
+
+// RouterAdvertiser sends IPv6 router advertisements to the guest.
+type RouterAdvertiser interface {
+	Close()
+}
+
+// RouterAdvertiserFactory starts advertising on the guest side of the given network.
+// A nil advertiser is returned when the network has no IPv6 connectivity.
+type RouterAdvertiserFactory func(launcherPID int, networks []v1.Network, network v1.Network, iface v1.Interface) (RouterAdvertiser, error)
+
+// noRouterAdvertiser marks the interfaces whose network has no IPv6 connectivity,
+// so they are not probed again on every sync.
+type noRouterAdvertiser struct{}
+
+func (noRouterAdvertiser) Close() {}
+
+// StartRouterAdvertisement sends IPv6 router advertisements on the bridge of masquerade interfaces
+// which opt in through their router advertisement options, letting the guest learn its default route
+// next to the address it is leased by the DHCPv6 server.
+// Interfaces which are already advertised or known to lack IPv6 connectivity are skipped.
+func (c *NetConf) StartRouterAdvertisement(vmi *v1.VirtualMachineInstance, launcherPID int) error {
+	networksByName := netvmispec.IndexNetworkSpecByName(vmi.Spec.Networks)
+
+	var errs []string
+	for _, iface := range c.pendingRouterAdvertisements(vmi) {
+		network, exists := networksByName[iface.Name]
+		if !exists {
+			continue
+		}
+
+		advertiser, err := c.newRouterAdvertiser(launcherPID, vmi.Spec.Networks, network, iface)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", iface.Name, err))
+			continue
+		}
+		if advertiser == nil {
+			advertiser = noRouterAdvertiser{}
+		}
+		c.routerAdvertisers.Store(vmiInterfaceKey(vmi.UID, iface.Name), advertiser)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to start router advertisement on interfaces: %s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// RouterAdvertisementPending returns true if some interface of the VMI is to be advertised
+// and was not handled yet by StartRouterAdvertisement.
+func (c *NetConf) RouterAdvertisementPending(vmi *v1.VirtualMachineInstance) bool {
+	return len(c.pendingRouterAdvertisements(vmi)) > 0
+}
+
+func (c *NetConf) pendingRouterAdvertisements(vmi *v1.VirtualMachineInstance) []v1.Interface {
+	return netvmispec.FilterInterfacesSpec(vmi.Spec.Domain.Devices.Interfaces, func(iface v1.Interface) bool {
+		if iface.Masquerade == nil || iface.RouterAdvertisement == nil || iface.State == v1.InterfaceStateAbsent {
+			return false
+		}
+		_, exists := c.routerAdvertisers.Load(vmiInterfaceKey(vmi.UID, iface.Name))
+		return !exists
+	})
+}
+
+func (c *NetConf) stopRouterAdvertisement(vmi *v1.VirtualMachineInstance) {
+	c.routerAdvertisers.Range(func(key, value interface{}) bool {
+		if strings.HasPrefix(key.(string), keyPrefix(vmi.UID)) {
+			c.routerAdvertisers.Delete(key)
+			value.(RouterAdvertiser).Close()
+		}
+		return true
+	})
+}
+
+// newRouterAdvertiser opens the advertiser socket from within the virt-launcher network namespace,
+// since virt-launcher itself lacks the capabilities required to do so.
+func newRouterAdvertiser(launcherPID int, networks []v1.Network, network v1.Network, iface v1.Interface) (RouterAdvertiser, error) {
+	config, err := routerAdvertisementConfig(&network, iface.RouterAdvertisement)
+	if err != nil {
+		return nil, err
+	}
+
+	var advertiser *routeradvertiser.Advertiser
+	err = netns.New(launcherPID).Do(func() error {
+		handler := &driver.NetworkUtilsHandler{}
+		podIfaceLink, err := link.DiscoverByNetwork(handler, networks, network)
+		if err != nil {
+			return err
+		}
+		if podIfaceLink == nil {
+			return fmt.Errorf("pod interface of network %s not found", network.Name)
+		}
+		podIfaceName := podIfaceLink.Attrs().Name
+
+		ipv6Enabled, err := handler.HasIPv6GlobalUnicastAddress(podIfaceName)
+		if err != nil || !ipv6Enabled {
+			return err
+		}
+
+		config.MTU = uint32(podIfaceLink.Attrs().MTU)
+		advertiser, err = routeradvertiser.New(link.GenerateBridgeName(podIfaceName), config)
+		return err
+	})
+	if err != nil || advertiser == nil {
+		return nil, err
+	}
+	return advertiser, nil
+}
+
+func routerAdvertisementConfig(network *v1.Network, options *v1.RouterAdvertisementOptions) (routeradvertiser.Config, error) {
+	config := routeradvertiser.Config{Managed: true}
+
+	if options != nil && options.Prefix != "" {
+		_, prefix, err := net.ParseCIDR(options.Prefix)
+		if err != nil {
+			return config, fmt.Errorf("failed to parse router advertisement prefix %s: %v", options.Prefix, err)
+		}
+		config.Prefix = prefix
+	} else if network.Pod != nil {
+		gateway, _, err := link.GenerateMasqueradeGatewayAndVmIPAddrs(network, driver.IPv6)
+		if err != nil {
+			return config, err
+		}
+		config.Prefix = &net.IPNet{IP: gateway.IP.Mask(gateway.Mask), Mask: gateway.Mask}
+	}
+
+	if options == nil {
+		return config, nil
+	}
+	for _, server := range options.DNSServers {
+		ip := net.ParseIP(server)
+		if ip == nil || ip.To4() != nil {
+			return config, fmt.Errorf("invalid IPv6 DNS server %s", server)
+		}
+		config.DNSServers = append(config.DNSServers, ip)
+	}
+	if options.ManagedAddressConfiguration != nil {
+		config.Managed = *options.ManagedAddressConfiguration
+	}
+	config.Other = options.OtherConfiguration
+	return config, nil
+}
@@ -0,0 +1,80 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/network/routeradvertiser"
+)
+
+var _ = Describe("router advertisement config", func() {
+	podNetwork := &v1.Network{Name: "default", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}}
+
+	mustParseCIDR := func(cidr string) *net.IPNet {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		Expect(err).ToNot(HaveOccurred())
+		return ipNet
+	}
+
+	It("advertises the masquerade network by default", func() {
+		config, err := routerAdvertisementConfig(podNetwork, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config).To(Equal(routeradvertiser.Config{Prefix: mustParseCIDR("fd10:0:2::/120"), Managed: true}))
+	})
+
+	It("advertises the custom masquerade network", func() {
+		network := &v1.Network{Name: "default", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{VMIPv6NetworkCIDR: "fd20::/64"}}}
+		config, err := routerAdvertisementConfig(network, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config.Prefix).To(Equal(mustParseCIDR("fd20::/64")))
+	})
+
+	It("applies the router advertisement options", func() {
+		options := &v1.RouterAdvertisementOptions{
+			Prefix:                      "fd30::/64",
+			DNSServers:                  []string{"fd00::53"},
+			ManagedAddressConfiguration: pointer.Bool(false),
+			OtherConfiguration:          true,
+		}
+		config, err := routerAdvertisementConfig(podNetwork, options)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config).To(Equal(routeradvertiser.Config{
+			Prefix:     mustParseCIDR("fd30::/64"),
+			DNSServers: []net.IP{net.ParseIP("fd00::53")},
+			Other:      true,
+		}))
+	})
+
+	DescribeTable("fails on invalid options", func(options *v1.RouterAdvertisementOptions) {
+		_, err := routerAdvertisementConfig(podNetwork, options)
+		Expect(err).To(HaveOccurred())
+	},
+		Entry("with an invalid prefix", &v1.RouterAdvertisementOptions{Prefix: "fd30::"}),
+		Entry("with an IPv4 DNS server", &v1.RouterAdvertisementOptions{DNSServers: []string{"8.8.8.8"}}),
+		Entry("with an invalid DNS server", &v1.RouterAdvertisementOptions{DNSServers: []string{"dns"}}),
+	)
+})
@@ -83,6 +83,11 @@ func (c Unpluggedpodnic) UnplugPhase1() error {
 		unplugErrors = append(unplugErrors, err)
 	}
 
+	err = cache.DeleteDHCPLeaseCache(c.cacheCreator, strconv.Itoa(c.launcherPID), podInterfaceName)
+	if err != nil {
+		unplugErrors = append(unplugErrors, err)
+	}
+
 	// the PodInterface cache should be the last one to be cleaned.
 	// It should be cleaned as the last step of the cleanup, since it is the indicator the cleanup should be done/not over yet.
 	if len(unplugErrors) == 0 {
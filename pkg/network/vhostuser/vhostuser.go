@@ -0,0 +1,42 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package vhostuser
+
+import (
+	"path/filepath"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/network/namescheme"
+)
+
+const (
+	// VolumeName is the name of the launcher pod volume holding the vhost-user sockets.
+	// The CNI plugin locates the pod volume by this name and hands its host path over to the userspace switch.
+	VolumeName = "vhostuser-sockets"
+	// SocketDir is the path the vhost-user sockets volume is mounted at in the compute container.
+	SocketDir = "/var/run/kubevirt/vhostuser"
+)
+
+// SocketPath returns the path of the vhost-user socket serving the given network.
+// The socket is named after the pod interface name of the network, the same name the CNI plugin is called with.
+func SocketPath(network v1.Network) string {
+	return filepath.Join(SocketDir, namescheme.HashedPodInterfaceName(network))
+}
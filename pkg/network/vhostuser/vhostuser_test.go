@@ -0,0 +1,47 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package vhostuser_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/network/vhostuser"
+)
+
+var _ = Describe("VhostUser", func() {
+	It("socket path is named after the hashed pod interface name", func() {
+		network := v1.Network{
+			Name:          "foo",
+			NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "net-attach-def"}},
+		}
+		Expect(vhostuser.SocketPath(network)).To(Equal("/var/run/kubevirt/vhostuser/pod2c26b46b68f"))
+	})
+
+	It("socket path of a default multus network is named after the primary pod interface", func() {
+		network := v1.Network{
+			Name:          "foo",
+			NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "net-attach-def", Default: true}},
+		}
+		Expect(vhostuser.SocketPath(network)).To(Equal("/var/run/kubevirt/vhostuser/eth0"))
+	})
+})
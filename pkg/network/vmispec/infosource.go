@@ -11,6 +11,12 @@ const (
 	seperator = ", "
 )
 
+const (
+	IPSourcePodInterface     string = "pod-interface"
+	IPSourceDHCPLease        string = "dhcp-lease"
+	IPSourceNeighborSnooping string = "neighbor-snooping"
+)
+
 func AddInfoSource(infoSourceData, name string) string {
 	var infoSources []string
 	if infoSourceData != "" {
@@ -92,6 +92,38 @@ var _ = Describe("VMI network spec", func() {
 		})
 	})
 
+	Context("vhost-user", func() {
+		It("finds no vhost-user interfaces in list", func() {
+			ifaces := []v1.Interface{
+				{
+					Name:                   "net0",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}},
+				},
+				{
+					Name:                   "net1",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}},
+				},
+			}
+
+			Expect(netvmispec.VhostUserInterfaceExist(ifaces)).To(BeFalse())
+		})
+
+		It("finds a vhost-user interface in list", func() {
+			ifaces := []v1.Interface{
+				{
+					Name:                   "net0",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}},
+				},
+				{
+					Name:                   "net1",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{VhostUser: &v1.InterfaceVhostUser{}},
+				},
+			}
+
+			Expect(netvmispec.VhostUserInterfaceExist(ifaces)).To(BeTrue())
+		})
+	})
+
 	const iface1, iface2, iface3, iface4, iface5 = "iface1", "iface2", "iface3", "iface4", "iface5"
 
 	DescribeTable("return VMI spec interface names, given",
@@ -53,15 +53,31 @@ func HasPersistentTPMDevice(vmiSpec *corev1.VirtualMachineInstanceSpec) bool {
 	return false
 }
 
+func HasPersistentEFI(vmiSpec *corev1.VirtualMachineInstanceSpec) bool {
+	if vmiSpec.Domain.Firmware != nil &&
+		vmiSpec.Domain.Firmware.Bootloader != nil &&
+		vmiSpec.Domain.Firmware.Bootloader.EFI != nil &&
+		vmiSpec.Domain.Firmware.Bootloader.EFI.Persistent != nil &&
+		*vmiSpec.Domain.Firmware.Bootloader.EFI.Persistent {
+		return true
+	}
+
+	return false
+}
+
+func IsBackendStorageNeeded(vmiSpec *corev1.VirtualMachineInstanceSpec) bool {
+	return HasPersistentTPMDevice(vmiSpec) || HasPersistentEFI(vmiSpec)
+}
+
 func isBackendStorageNeededForVMI(vmi *corev1.VirtualMachineInstance) bool {
-	return HasPersistentTPMDevice(&vmi.Spec)
+	return IsBackendStorageNeeded(&vmi.Spec)
 }
 
 func IsBackendStorageNeededForVM(vm *corev1.VirtualMachine) bool {
 	if vm.Spec.Template == nil {
 		return false
 	}
-	return HasPersistentTPMDevice(&vm.Spec.Template.Spec)
+	return IsBackendStorageNeeded(&vm.Spec.Template.Spec)
 }
 
 func CreateIfNeeded(vmi *corev1.VirtualMachineInstance, clusterConfig *virtconfig.ClusterConfig, client kubecli.KubevirtClient) error {
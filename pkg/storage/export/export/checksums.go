@@ -0,0 +1,246 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package export
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	exportv1 "kubevirt.io/api/export/v1alpha1"
+	"kubevirt.io/client-go/log"
+
+	kutil "kubevirt.io/kubevirt/pkg/util"
+)
+
+const (
+	// The status listener of the exporter pod is not exposed by the export
+	// service, it is only used by the controller
+	statusListenerPort    = 8444
+	statusListenerTimeout = time.Second * 10
+
+	// The status listener requires a token of its own, it is kept next to
+	// the certificate of the exporter pod, which is only readable by the
+	// controller and the pod
+	statusTokenKey       = "status-token"
+	statusTokenFile      = "/cert/" + statusTokenKey
+	statusTokenHeader    = "x-kubevirt-export-token"
+	statusTokenCacheTTL  = time.Hour
+	statusTokenCacheSize = 1024
+
+	checksumsURI          = "/checksums"
+	checksumsPollInterval = time.Second * 30
+)
+
+// variable so can be overridden in tests
+var fetchChecksums = func(url, serverName, token string, caBundle []byte) (map[string]string, error) {
+	checksums := map[string]string{}
+	if err := getFromStatusListener(url, serverName, token, caBundle, &checksums); err != nil {
+		return nil, err
+	}
+	return checksums, nil
+}
+
+// getFromStatusListener gets a JSON document from the status listener of an
+// exporter pod, which serves the certificate of the export service and
+// requires the status token of the pod
+func getFromStatusListener(url, serverName, token string, caBundle []byte, v interface{}) error {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caBundle) {
+		return fmt.Errorf("no valid export CA certificate")
+	}
+	client := &http.Client{
+		Timeout: statusListenerTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    pool,
+				ServerName: serverName,
+				MinVersion: tls.VersionTLS12,
+			},
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(statusTokenHeader, token)
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func statusListenerURL(exporterPod *corev1.Pod, uri string) string {
+	return fmt.Sprintf("https://%s%s", net.JoinHostPort(exporterPod.Status.PodIP, strconv.Itoa(statusListenerPort)), uri)
+}
+
+func statusListenerServerName(service *corev1.Service) string {
+	return fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace)
+}
+
+func addStatusListenerToPod(podManifest *corev1.Pod) {
+	podManifest.Spec.Containers[0].Env = append(podManifest.Spec.Containers[0].Env, corev1.EnvVar{
+		Name:  "STATUS_LISTEN_ADDR",
+		Value: fmt.Sprintf(":%d", statusListenerPort),
+	}, corev1.EnvVar{
+		Name:  "STATUS_TOKEN_FILE",
+		Value: statusTokenFile,
+	}, corev1.EnvVar{
+		Name:  "CHECKSUMS_URI",
+		Value: checksumsURI,
+	})
+}
+
+// addStatusTokenToSecret adds a new status token to the certificate secret of
+// the exporter pod
+func addStatusTokenToSecret(secret *corev1.Secret) error {
+	token, err := kutil.GenerateSecureRandomString(secretTokenLength)
+	if err != nil {
+		return err
+	}
+	secret.Data[statusTokenKey] = []byte(token)
+	return nil
+}
+
+// statusListenerToken returns the token the status listener of the exporter
+// pod requires. The certificate secret lives in the export namespace, which
+// is not watched, so the token is cached per pod.
+func (ctrl *VMExportController) statusListenerToken(exporterPod *corev1.Pod) (string, error) {
+	if token, exists := ctrl.statusTokens.Get(exporterPod.UID); exists {
+		return token.(string), nil
+	}
+	secret, err := ctrl.Client.CoreV1().Secrets(exporterPod.Namespace).Get(context.Background(), ctrl.getExportSecretName(exporterPod), metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	token, exists := secret.Data[statusTokenKey]
+	if !exists {
+		return "", fmt.Errorf("secret %s/%s has no status token", secret.Namespace, secret.Name)
+	}
+	ctrl.statusTokens.Add(exporterPod.UID, string(token), statusTokenCacheTTL)
+	return string(token), nil
+}
+
+func getRawFormat(volume *exportv1.VirtualMachineExportVolume) *exportv1.VirtualMachineExportVolumeFormat {
+	for i := range volume.Formats {
+		if volume.Formats[i].Format == exportv1.KubeVirtRaw {
+			return &volume.Formats[i]
+		}
+	}
+	return nil
+}
+
+// hasMissingChecksums returns true if a raw volume of the links has no checksum
+func hasMissingChecksums(link *exportv1.VirtualMachineExportLink, checksums map[string]string) bool {
+	if link == nil {
+		return false
+	}
+	for i := range link.Volumes {
+		if format := getRawFormat(&link.Volumes[i]); format != nil && checksums[link.Volumes[i].Name] == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func setChecksums(link *exportv1.VirtualMachineExportLink, checksums map[string]string) {
+	if link == nil {
+		return
+	}
+	for i := range link.Volumes {
+		if format := getRawFormat(&link.Volumes[i]); format != nil {
+			format.Sha256 = checksums[link.Volumes[i].Name]
+		}
+	}
+}
+
+// areChecksumsPending returns true if the exporter pod is still calculating
+// checksums, so they have to be polled
+func areChecksumsPending(vmExport *exportv1.VirtualMachineExport, exporterPod *corev1.Pod) bool {
+	if exporterPod == nil || exporterPod.Status.Phase != corev1.PodRunning || exporterPod.Status.PodIP == "" {
+		return false
+	}
+	if vmExport.Status == nil || vmExport.Status.Links == nil {
+		return false
+	}
+	return hasMissingChecksums(vmExport.Status.Links.Internal, publishedChecksums(vmExport))
+}
+
+// publishedChecksums returns the checksums of the raw volumes already in the status
+func publishedChecksums(vmExport *exportv1.VirtualMachineExport) map[string]string {
+	checksums := map[string]string{}
+	if vmExport.Status == nil || vmExport.Status.Links == nil || vmExport.Status.Links.Internal == nil {
+		return checksums
+	}
+	for i, volume := range vmExport.Status.Links.Internal.Volumes {
+		if format := getRawFormat(&vmExport.Status.Links.Internal.Volumes[i]); format != nil && format.Sha256 != "" {
+			checksums[volume.Name] = format.Sha256
+		}
+	}
+	return checksums
+}
+
+// updateChecksums publishes the checksums of the raw volumes in the links.
+// They are calculated by the exporter pod in the background and kept once
+// known.
+func (ctrl *VMExportController) updateChecksums(vmExport, vmExportCopy *exportv1.VirtualMachineExport, exporterPod *corev1.Pod, service *corev1.Service, pvcs []*corev1.PersistentVolumeClaim, getVolumeName getExportVolumeName) {
+	links := vmExportCopy.Status.Links
+	if links == nil || links.Internal == nil {
+		return
+	}
+
+	checksums := publishedChecksums(vmExport)
+	if hasMissingChecksums(links.Internal, checksums) && exporterPod.Status.PodIP != "" {
+		caBundle, err := ctrl.internalExportCa()
+		if err != nil {
+			log.Log.Object(vmExport).Reason(err).Error("Unable to get the export CA")
+		} else if token, err := ctrl.statusListenerToken(exporterPod); err != nil {
+			log.Log.Object(vmExport).Reason(err).Error("Unable to get the status token")
+		} else if fetched, err := fetchChecksums(statusListenerURL(exporterPod, checksumsURI), statusListenerServerName(service), token, []byte(caBundle)); err != nil {
+			// The exporter may not be serving yet
+			log.Log.Object(vmExport).Reason(err).V(3).Info("Unable to get the checksums")
+		} else {
+			// The exporter knows the volumes by the name of their PVC
+			for _, pvc := range pvcs {
+				if checksum, ok := fetched[pvc.Name]; ok {
+					checksums[getVolumeName(pvc, vmExport)] = checksum
+				}
+			}
+		}
+	}
+
+	setChecksums(links.Internal, checksums)
+	setChecksums(links.External, checksums)
+}
@@ -0,0 +1,256 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	exportv1 "kubevirt.io/api/export/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
+)
+
+var _ = Describe("Export checksums", func() {
+	const (
+		checksum1 = "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+		checksum2 = "fcde2b2edba56bf408601fb721fe9b5c338d10ee429ea04fae5511b68fbf8fb9"
+	)
+
+	var (
+		controller *VMExportController
+		cmInformer cache.SharedIndexInformer
+		k8sClient  *k8sfake.Clientset
+		service    *k8sv1.Service
+
+		orgFetchChecksums = fetchChecksums
+	)
+
+	newExporterPod := func(phase k8sv1.PodPhase) *k8sv1.Pod {
+		return &k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "virt-export-test",
+				Namespace: testNamespace,
+				UID:       "virt-export-test-uid",
+			},
+			Spec: k8sv1.PodSpec{
+				Containers: []k8sv1.Container{{Name: "test"}},
+				Volumes: []k8sv1.Volume{{
+					Name: certificates,
+					VolumeSource: k8sv1.VolumeSource{
+						Secret: &k8sv1.SecretVolumeSource{SecretName: "virt-export-test-cert"},
+					},
+				}},
+			},
+			Status: k8sv1.PodStatus{
+				Phase: phase,
+				PodIP: "10.244.0.10",
+			},
+		}
+	}
+
+	newLink := func(volumes ...string) *exportv1.VirtualMachineExportLink {
+		link := &exportv1.VirtualMachineExportLink{}
+		for _, name := range volumes {
+			link.Volumes = append(link.Volumes, exportv1.VirtualMachineExportVolume{
+				Name: name,
+				Formats: []exportv1.VirtualMachineExportVolumeFormat{
+					{Format: exportv1.KubeVirtRaw, Url: "https://example.com/volumes/" + name + "/disk.img"},
+					{Format: exportv1.KubeVirtGz, Url: "https://example.com/volumes/" + name + "/disk.img.gz"},
+				},
+			})
+		}
+		return link
+	}
+
+	newExport := func(volumes ...string) *exportv1.VirtualMachineExport {
+		vmExport := createPVCVMExport()
+		populateInitialVMExportStatus(vmExport)
+		vmExport.Status.Links = &exportv1.VirtualMachineExportLinks{
+			Internal: newLink(volumes...),
+			External: newLink(volumes...),
+		}
+		return vmExport
+	}
+
+	BeforeEach(func() {
+		cmInformer, _ = testutils.NewFakeInformerFor(&k8sv1.ConfigMap{})
+		k8sClient = k8sfake.NewSimpleClientset(&k8sv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "virt-export-test-cert",
+				Namespace: testNamespace,
+			},
+			Data: map[string][]byte{
+				statusTokenKey: []byte("status-token"),
+			},
+		})
+		virtClient := kubecli.NewMockKubevirtClient(gomock.NewController(GinkgoT()))
+		virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+		controller = &VMExportController{
+			Client:            virtClient,
+			ConfigMapInformer: cmInformer,
+			KubevirtNamespace: "kubevirt",
+			statusTokens:      utilcache.NewLRUExpireCache(statusTokenCacheSize),
+		}
+		Expect(cmInformer.GetStore().Add(&k8sv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: controller.KubevirtNamespace,
+				Name:      components.KubeVirtExportCASecretName,
+			},
+			Data: map[string]string{
+				caBundle: "ca bundle",
+			},
+		})).To(Succeed())
+		service = &k8sv1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "virt-export-test",
+				Namespace: testNamespace,
+			},
+		}
+	})
+
+	AfterEach(func() {
+		fetchChecksums = orgFetchChecksums
+	})
+
+	It("should configure the status listener of the exporter pod", func() {
+		pod := newExporterPod(k8sv1.PodPending)
+		addStatusListenerToPod(pod)
+		Expect(pod.Spec.Containers[0].Env).To(ConsistOf(
+			k8sv1.EnvVar{Name: "STATUS_LISTEN_ADDR", Value: ":8444"},
+			k8sv1.EnvVar{Name: "STATUS_TOKEN_FILE", Value: "/cert/status-token"},
+			k8sv1.EnvVar{Name: "CHECKSUMS_URI", Value: checksumsURI},
+		))
+	})
+
+	It("should add a status token to the certificate secret", func() {
+		secret := &k8sv1.Secret{Data: map[string][]byte{"tls.crt": []byte("cert")}}
+		Expect(addStatusTokenToSecret(secret)).To(Succeed())
+		Expect(secret.Data[statusTokenKey]).To(HaveLen(secretTokenLength))
+		Expect(secret.Data).To(HaveKey("tls.crt"))
+	})
+
+	It("should read the status token from the certificate secret once per exporter pod", func() {
+		pod := newExporterPod(k8sv1.PodRunning)
+		Expect(controller.statusListenerToken(pod)).To(Equal("status-token"))
+
+		Expect(k8sClient.CoreV1().Secrets(testNamespace).Delete(context.Background(), "virt-export-test-cert", metav1.DeleteOptions{})).To(Succeed())
+		Expect(controller.statusListenerToken(pod)).To(Equal("status-token"))
+
+		pod.UID = "recreated-uid"
+		_, err := controller.statusListenerToken(pod)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should publish the checksums of the raw volumes fetched from the exporter pod", func() {
+		fetchChecksums = func(url, serverName, token string, caBundle []byte) (map[string]string, error) {
+			Expect(url).To(Equal("https://10.244.0.10:8444/checksums"))
+			Expect(serverName).To(Equal("virt-export-test.default.svc"))
+			Expect(token).To(Equal("status-token"))
+			Expect(string(caBundle)).To(Equal("ca bundle"))
+			return map[string]string{"test-volume1": checksum1}, nil
+		}
+		vmExport := createSnapshotVMExport()
+		vmExportCopy := newExport("volume1", "volume2")
+		pvcs := []*k8sv1.PersistentVolumeClaim{
+			createPVC("test-volume1", "kubevirt"),
+			createPVC("test-volume2", "kubevirt"),
+		}
+
+		controller.updateChecksums(vmExport, vmExportCopy, newExporterPod(k8sv1.PodRunning), service, pvcs, getSnapshotVolumeName)
+
+		for _, link := range []*exportv1.VirtualMachineExportLink{vmExportCopy.Status.Links.Internal, vmExportCopy.Status.Links.External} {
+			Expect(link.Volumes[0].Formats[0].Sha256).To(Equal(checksum1))
+			Expect(link.Volumes[0].Formats[1].Sha256).To(BeEmpty())
+			Expect(link.Volumes[1].Formats[0].Sha256).To(BeEmpty())
+		}
+	})
+
+	It("should keep the known checksums without fetching them again", func() {
+		fetchChecksums = func(_, _, _ string, _ []byte) (map[string]string, error) {
+			Fail("checksums must not be fetched")
+			return nil, nil
+		}
+		vmExport := newExport(testPVCName)
+		vmExport.Status.Links.Internal.Volumes[0].Formats[0].Sha256 = checksum2
+		vmExportCopy := newExport(testPVCName)
+
+		controller.updateChecksums(vmExport, vmExportCopy, newExporterPod(k8sv1.PodRunning), service, []*k8sv1.PersistentVolumeClaim{createPVC(testPVCName, "kubevirt")}, getVolumeName)
+
+		Expect(vmExportCopy.Status.Links.Internal.Volumes[0].Formats[0].Sha256).To(Equal(checksum2))
+		Expect(vmExportCopy.Status.Links.External.Volumes[0].Formats[0].Sha256).To(Equal(checksum2))
+	})
+
+	It("should not publish checksums if they cannot be fetched", func() {
+		fetchChecksums = func(_, _, _ string, _ []byte) (map[string]string, error) {
+			return nil, fmt.Errorf("connection refused")
+		}
+		vmExportCopy := newExport(testPVCName)
+
+		controller.updateChecksums(createPVCVMExport(), vmExportCopy, newExporterPod(k8sv1.PodRunning), service, []*k8sv1.PersistentVolumeClaim{createPVC(testPVCName, "kubevirt")}, getVolumeName)
+
+		Expect(vmExportCopy.Status.Links.Internal.Volumes[0].Formats[0].Sha256).To(BeEmpty())
+	})
+
+	DescribeTable("should be polled while missing", func(vmExport *exportv1.VirtualMachineExport, podPhase k8sv1.PodPhase, expected bool) {
+		Expect(areChecksumsPending(vmExport, newExporterPod(podPhase))).To(Equal(expected))
+	},
+		Entry("no links yet", createPVCVMExport(), k8sv1.PodRunning, false),
+		Entry("missing checksum", newExport(testPVCName), k8sv1.PodRunning, true),
+		Entry("known checksum", func() *exportv1.VirtualMachineExport {
+			vmExport := newExport(testPVCName)
+			vmExport.Status.Links.Internal.Volumes[0].Formats[0].Sha256 = checksum1
+			return vmExport
+		}(), k8sv1.PodRunning, false),
+		Entry("pod not running", newExport(testPVCName), k8sv1.PodPending, false),
+		Entry("no raw volumes", newExport(), k8sv1.PodRunning, false),
+	)
+
+	It("should fetch the checksums from the exporter over TLS", func() {
+		checksums := map[string]string{testPVCName: checksum1}
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Path).To(Equal(checksumsURI))
+			Expect(r.Header.Get(statusTokenHeader)).To(Equal("status-token"))
+			Expect(json.NewEncoder(w).Encode(checksums)).To(Succeed())
+		}))
+		defer server.Close()
+		ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+		fetched, err := fetchChecksums(server.URL+checksumsURI, "example.com", "status-token", ca)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetched).To(Equal(checksums))
+	})
+})
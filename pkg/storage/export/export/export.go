@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/rand"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -212,6 +213,9 @@ type VMExportController struct {
 	clusterConfig *virtconfig.ClusterConfig
 
 	instancetypeMethods instancetype.Methods
+
+	// statusTokens holds the status tokens of the exporter pods, keyed by pod UID
+	statusTokens *utilcache.LRUExpireCache
 }
 
 type CertParams struct {
@@ -255,6 +259,7 @@ func (ctrl *VMExportController) Init() error {
 		return err
 	}
 	ctrl.vmExportQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-export-vmexport")
+	ctrl.statusTokens = utilcache.NewLRUExpireCache(statusTokenCacheSize)
 
 	_, err = ctrl.VMExportInformer.AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
@@ -531,7 +536,16 @@ func (ctrl *VMExportController) handleSource(vmExport *exportv1.VirtualMachineEx
 		return 0, err
 	}
 
-	return updateStatus(vmExport, pod, service, sourceVolumes)
+	requeue, err := updateStatus(vmExport, pod, service, sourceVolumes)
+	if err == nil && requeue == 0 && isPushInProgress(vmExport, pod) {
+		// Poll the progress of the push
+		requeue = pushStatusPollInterval
+	}
+	if err == nil && requeue == 0 && areChecksumsPending(vmExport, pod) {
+		// Poll the checksums of the volumes
+		requeue = checksumsPollInterval
+	}
+	return requeue, err
 }
 
 func (ctrl *VMExportController) manageExporterPod(vmExport *exportv1.VirtualMachineExport, service *corev1.Service, sourceVolumes *sourceVolumes) (*corev1.Pod, error) {
@@ -633,6 +647,9 @@ func (ctrl *VMExportController) createCertSecret(vmExport *exportv1.VirtualMachi
 	if err != nil {
 		return err
 	}
+	if err := addStatusTokenToSecret(secret); err != nil {
+		return err
+	}
 	_, err = ctrl.Client.CoreV1().Secrets(vmExport.Namespace).Create(context.Background(), secret, metav1.CreateOptions{})
 	if err != nil && !errors.IsAlreadyExists(err) {
 		return err
@@ -950,6 +967,9 @@ func (ctrl *VMExportController) createExporterPodManifest(vmExport *exportv1.Vir
 		MountPath: "/token",
 	})
 
+	addStatusListenerToPod(podManifest)
+	ctrl.addDestinationToPod(vmExport, podManifest)
+
 	if vm, err := ctrl.getVmFromExport(vmExport); err != nil {
 		return nil, err
 	} else {
@@ -1166,6 +1186,7 @@ func (ctrl *VMExportController) updateCommonVMExportStatusFields(vmExport, vmExp
 			if err != nil {
 				return err
 			}
+			ctrl.updateChecksums(vmExport, vmExportCopy, exporterPod, service, sourceVolumes.volumes, getVolumeName)
 		} else if exporterPod.Status.Phase == corev1.PodSucceeded {
 			vmExportCopy.Status.Conditions = updateCondition(vmExportCopy.Status.Conditions, newReadyCondition(corev1.ConditionFalse, podCompletedReason, ""))
 			vmExportCopy.Status.Phase = exportv1.Terminated
@@ -1177,6 +1198,7 @@ func (ctrl *VMExportController) updateCommonVMExportStatusFields(vmExport, vmExp
 			vmExportCopy.Status.Phase = exportv1.Pending
 		}
 	}
+	ctrl.updatePushStatus(vmExportCopy, exporterPod, service)
 
 	return nil
 }
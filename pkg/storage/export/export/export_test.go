@@ -911,6 +911,33 @@ var _ = Describe("Export controller", func() {
 		Entry("Snapshot", populateVmExportVMSnapshot, 4),
 	)
 
+	It("Should configure the exporter pod to push to the destination", func() {
+		testVMExport := createPVCVMExport()
+		testVMExport.Spec.Destination = &exportv1.VirtualMachineExportDestination{
+			S3: &exportv1.S3ExportDestination{
+				Endpoint:             "http://minio.minio:9000",
+				Bucket:               "backups",
+				CredentialsSecretRef: "s3-credentials",
+			},
+		}
+		populateInitialVMExportStatus(testVMExport)
+		service := &k8sv1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      controller.getExportServiceName(testVMExport),
+				Namespace: testNamespace,
+			},
+		}
+		pod, err := controller.createExporterPodManifest(testVMExport, service, []*k8sv1.PersistentVolumeClaim{createPVC(testPVCName, string(cdiv1.DataVolumeKubeVirt))})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pod.Spec.Containers[0].Env).To(ContainElements(expectedPodEnvVars[1:]))
+		Expect(pod.Spec.Containers[0].Env).To(ContainElement(k8sv1.EnvVar{
+			Name:  "DESTINATION_S3_ENDPOINT",
+			Value: "http://minio.minio:9000",
+		}))
+		Expect(pod.Spec.Volumes).To(ContainElement(HaveField("Name", destinationCredentials)))
+		Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(HaveField("MountPath", destinationCredentialsPath)))
+	})
+
 	It("Should create a secret based on the vm export", func() {
 		cp := &CertParams{Duration: 24 * time.Hour, RenewBefore: 2 * time.Hour}
 		scp, err := serializeCertParams(cp)
@@ -946,6 +973,7 @@ var _ = Describe("Export controller", func() {
 			Expect(ok).To(BeTrue())
 			Expect(secret.GetName()).To(Equal(controller.getExportSecretName(testExportPod)))
 			Expect(secret.GetNamespace()).To(Equal(testNamespace))
+			Expect(secret.Data[statusTokenKey]).To(HaveLen(secretTokenLength))
 			return true, secret, nil
 		})
 		err = controller.createCertSecret(testVMExport, testExportPod)
@@ -0,0 +1,202 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package export
+
+import (
+	"path"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	exportv1 "kubevirt.io/api/export/v1alpha1"
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	// name of the destination credentials secret volume in pod
+	destinationCredentials     = "destination-credentials"
+	destinationCredentialsPath = "/destination-credentials"
+
+	pushStatusURI          = "/push/status"
+	pushStatusPollInterval = time.Second * 10
+
+	pushPendingReason    = "PushPending"
+	pushInProgressReason = "PushInProgress"
+	pushSucceededReason  = "PushSucceeded"
+	pushFailedReason     = "PushFailed"
+
+	pushInterruptedMessage = "the exporter pod stopped before the push completed"
+)
+
+// variable so can be overridden in tests
+var fetchPushStatus = func(url, serverName, token string, caBundle []byte) (*exportv1.VirtualMachineExportPushStatus, error) {
+	status := &exportv1.VirtualMachineExportPushStatus{}
+	if err := getFromStatusListener(url, serverName, token, caBundle, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+func isPushSucceeded(vmExport *exportv1.VirtualMachineExport) bool {
+	return vmExport.Status != nil && vmExport.Status.Push != nil && vmExport.Status.Push.Phase == exportv1.PushSucceeded
+}
+
+// isPushInProgress returns true if the exporter pod is pushing the export, so
+// its progress has to be polled
+func isPushInProgress(vmExport *exportv1.VirtualMachineExport, exporterPod *corev1.Pod) bool {
+	if vmExport.Spec.Destination == nil || exporterPod == nil || exporterPod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	if vmExport.Status == nil || vmExport.Status.Push == nil {
+		return true
+	}
+	push := vmExport.Status.Push
+	if push.Phase == exportv1.PushFailed && push.CompletionTime != nil {
+		// An exporter pod created after the push failed pushes again
+		return exporterPod.CreationTimestamp.After(push.CompletionTime.Time)
+	}
+	return push.Phase == exportv1.PushPending || push.Phase == exportv1.PushInProgress
+}
+
+// isExporterGone returns true if no exporter pod runs anymore, e.g. because
+// the TTL of the export expired
+func isExporterGone(exporterPod *corev1.Pod) bool {
+	return exporterPod == nil || exporterPod.Status.Phase == corev1.PodSucceeded || exporterPod.Status.Phase == corev1.PodFailed
+}
+
+func getPushPrefix(vmExport *exportv1.VirtualMachineExport) string {
+	if prefix := vmExport.Spec.Destination.S3.Prefix; prefix != "" {
+		return prefix
+	}
+	return path.Join(vmExport.Namespace, vmExport.Name)
+}
+
+// addDestinationToPod configures the exporter pod to push the export, unless
+// it was already pushed by a previous exporter pod
+func (ctrl *VMExportController) addDestinationToPod(vmExport *exportv1.VirtualMachineExport, podManifest *corev1.Pod) {
+	destination := vmExport.Spec.Destination
+	if destination == nil || destination.S3 == nil || isPushSucceeded(vmExport) {
+		return
+	}
+
+	container := &podManifest.Spec.Containers[0]
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  "DESTINATION_FORMAT",
+		Value: string(destination.Format),
+	}, corev1.EnvVar{
+		Name:  "DESTINATION_S3_ENDPOINT",
+		Value: destination.S3.Endpoint,
+	}, corev1.EnvVar{
+		Name:  "DESTINATION_S3_BUCKET",
+		Value: destination.S3.Bucket,
+	}, corev1.EnvVar{
+		Name:  "DESTINATION_S3_PREFIX",
+		Value: getPushPrefix(vmExport),
+	}, corev1.EnvVar{
+		Name:  "DESTINATION_S3_REGION",
+		Value: destination.S3.Region,
+	}, corev1.EnvVar{
+		Name:  "DESTINATION_S3_INSECURE_SKIP_TLS_VERIFY",
+		Value: strconv.FormatBool(destination.S3.InsecureSkipTLSVerify),
+	}, corev1.EnvVar{
+		Name:  "DESTINATION_CREDENTIALS_DIR",
+		Value: destinationCredentialsPath,
+	}, corev1.EnvVar{
+		Name:  "DESTINATION_CREDENTIALS_SECRET",
+		Value: destination.S3.CredentialsSecretRef,
+	}, corev1.EnvVar{
+		Name:  "PUSH_STATUS_URI",
+		Value: pushStatusURI,
+	})
+
+	podManifest.Spec.Volumes = append(podManifest.Spec.Volumes, corev1.Volume{
+		Name: destinationCredentials,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: destination.S3.CredentialsSecretRef,
+			},
+		},
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      destinationCredentials,
+		ReadOnly:  true,
+		MountPath: destinationCredentialsPath,
+	})
+}
+
+// updatePushStatus copies the progress of the push from the exporter pod into
+// the status. The push status is served on a port of the pod which is not
+// exposed by the export service.
+func (ctrl *VMExportController) updatePushStatus(vmExport *exportv1.VirtualMachineExport, exporterPod *corev1.Pod, service *corev1.Service) {
+	if vmExport.Spec.Destination == nil {
+		return
+	}
+	if vmExport.Status.Push == nil {
+		vmExport.Status.Push = &exportv1.VirtualMachineExportPushStatus{
+			Phase: exportv1.PushPending,
+		}
+	}
+
+	if isPushInProgress(vmExport, exporterPod) && exporterPod.Status.PodIP != "" {
+		caBundle, err := ctrl.internalExportCa()
+		if err != nil {
+			log.Log.Object(vmExport).Reason(err).Error("Unable to get the export CA")
+		} else if token, err := ctrl.statusListenerToken(exporterPod); err != nil {
+			log.Log.Object(vmExport).Reason(err).Error("Unable to get the status token")
+		} else {
+			url := statusListenerURL(exporterPod, pushStatusURI)
+			if status, err := fetchPushStatus(url, statusListenerServerName(service), token, []byte(caBundle)); err != nil {
+				// The exporter may not be serving yet, keep the last known status
+				log.Log.Object(vmExport).Reason(err).V(3).Info("Unable to get the push status")
+			} else {
+				vmExport.Status.Push = status
+			}
+		}
+	} else if vmExport.Status.Push.Phase == exportv1.PushInProgress && isExporterGone(exporterPod) {
+		// Nothing completes the push anymore
+		vmExport.Status.Push.Phase = exportv1.PushFailed
+		vmExport.Status.Push.Message = pushInterruptedMessage
+		vmExport.Status.Push.CompletionTime = currentTime()
+	}
+
+	vmExport.Status.Conditions = updateCondition(vmExport.Status.Conditions, newPushedCondition(vmExport.Status.Push))
+}
+
+func newPushedCondition(status *exportv1.VirtualMachineExportPushStatus) exportv1.Condition {
+	cond := exportv1.Condition{
+		Type:               exportv1.ConditionPushed,
+		Status:             corev1.ConditionFalse,
+		Message:            status.Message,
+		LastTransitionTime: *currentTime(),
+	}
+	switch status.Phase {
+	case exportv1.PushSucceeded:
+		cond.Status = corev1.ConditionTrue
+		cond.Reason = pushSucceededReason
+	case exportv1.PushFailed:
+		cond.Reason = pushFailedReason
+	case exportv1.PushInProgress:
+		cond.Reason = pushInProgressReason
+	default:
+		cond.Reason = pushPendingReason
+	}
+	return cond
+}
@@ -0,0 +1,311 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package export
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/client-go/tools/cache"
+
+	exportv1 "kubevirt.io/api/export/v1alpha1"
+
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
+)
+
+var _ = Describe("Export push", func() {
+	var (
+		controller *VMExportController
+		cmInformer cache.SharedIndexInformer
+		service    *k8sv1.Service
+
+		orgFetchPushStatus = fetchPushStatus
+	)
+
+	newDestinationExport := func() *exportv1.VirtualMachineExport {
+		vmExport := createPVCVMExport()
+		vmExport.Spec.Destination = &exportv1.VirtualMachineExportDestination{
+			Format: exportv1.KubeVirtRaw,
+			S3: &exportv1.S3ExportDestination{
+				Endpoint:             "http://minio.minio:9000",
+				Bucket:               "backups",
+				CredentialsSecretRef: "s3-credentials",
+			},
+		}
+		populateInitialVMExportStatus(vmExport)
+		return vmExport
+	}
+
+	newExporterPod := func(phase k8sv1.PodPhase) *k8sv1.Pod {
+		return &k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "virt-export-test",
+				Namespace: testNamespace,
+				UID:       "virt-export-test-uid",
+			},
+			Spec: k8sv1.PodSpec{
+				Containers: []k8sv1.Container{{Name: "test"}},
+			},
+			Status: k8sv1.PodStatus{
+				Phase: phase,
+				PodIP: "10.244.0.10",
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		cmInformer, _ = testutils.NewFakeInformerFor(&k8sv1.ConfigMap{})
+		controller = &VMExportController{
+			ConfigMapInformer: cmInformer,
+			KubevirtNamespace: "kubevirt",
+			statusTokens:      utilcache.NewLRUExpireCache(statusTokenCacheSize),
+		}
+		controller.statusTokens.Add(types.UID("virt-export-test-uid"), "status-token", time.Hour)
+		Expect(cmInformer.GetStore().Add(&k8sv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: controller.KubevirtNamespace,
+				Name:      components.KubeVirtExportCASecretName,
+			},
+			Data: map[string]string{
+				caBundle: "ca bundle",
+			},
+		})).To(Succeed())
+		service = &k8sv1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "virt-export-test",
+				Namespace: testNamespace,
+			},
+		}
+	})
+
+	AfterEach(func() {
+		fetchPushStatus = orgFetchPushStatus
+	})
+
+	Context("exporter pod", func() {
+		It("should be configured to push to the destination", func() {
+			vmExport := newDestinationExport()
+			vmExport.Spec.Destination.S3.InsecureSkipTLSVerify = true
+			pod := newExporterPod(k8sv1.PodPending)
+			controller.addDestinationToPod(vmExport, pod)
+
+			Expect(pod.Spec.Containers[0].Env).To(ConsistOf(
+				k8sv1.EnvVar{Name: "DESTINATION_FORMAT", Value: "raw"},
+				k8sv1.EnvVar{Name: "DESTINATION_S3_ENDPOINT", Value: "http://minio.minio:9000"},
+				k8sv1.EnvVar{Name: "DESTINATION_S3_BUCKET", Value: "backups"},
+				k8sv1.EnvVar{Name: "DESTINATION_S3_PREFIX", Value: "default/test"},
+				k8sv1.EnvVar{Name: "DESTINATION_S3_REGION", Value: ""},
+				k8sv1.EnvVar{Name: "DESTINATION_S3_INSECURE_SKIP_TLS_VERIFY", Value: "true"},
+				k8sv1.EnvVar{Name: "DESTINATION_CREDENTIALS_DIR", Value: destinationCredentialsPath},
+				k8sv1.EnvVar{Name: "DESTINATION_CREDENTIALS_SECRET", Value: "s3-credentials"},
+				k8sv1.EnvVar{Name: "PUSH_STATUS_URI", Value: pushStatusURI},
+			))
+			Expect(pod.Spec.Volumes).To(ConsistOf(k8sv1.Volume{
+				Name: destinationCredentials,
+				VolumeSource: k8sv1.VolumeSource{
+					Secret: &k8sv1.SecretVolumeSource{
+						SecretName: "s3-credentials",
+					},
+				},
+			}))
+			Expect(pod.Spec.Containers[0].VolumeMounts).To(ConsistOf(k8sv1.VolumeMount{
+				Name:      destinationCredentials,
+				ReadOnly:  true,
+				MountPath: destinationCredentialsPath,
+			}))
+		})
+
+		It("should use the configured prefix", func() {
+			vmExport := newDestinationExport()
+			vmExport.Spec.Destination.S3.Prefix = "dr/site-a"
+			pod := newExporterPod(k8sv1.PodPending)
+			controller.addDestinationToPod(vmExport, pod)
+			Expect(pod.Spec.Containers[0].Env).To(ContainElement(k8sv1.EnvVar{Name: "DESTINATION_S3_PREFIX", Value: "dr/site-a"}))
+		})
+
+		DescribeTable("should not push", func(vmExport *exportv1.VirtualMachineExport) {
+			pod := newExporterPod(k8sv1.PodPending)
+			controller.addDestinationToPod(vmExport, pod)
+			Expect(pod.Spec.Containers[0].Env).To(BeEmpty())
+			Expect(pod.Spec.Volumes).To(BeEmpty())
+		},
+			Entry("without a destination", createPVCVMExport()),
+			Entry("if the export was already pushed", func() *exportv1.VirtualMachineExport {
+				vmExport := newDestinationExport()
+				vmExport.Status.Push = &exportv1.VirtualMachineExportPushStatus{Phase: exportv1.PushSucceeded}
+				return vmExport
+			}()),
+		)
+	})
+
+	Context("status", func() {
+		It("should not be set without a destination", func() {
+			vmExport := createPVCVMExport()
+			populateInitialVMExportStatus(vmExport)
+			controller.updatePushStatus(vmExport, newExporterPod(k8sv1.PodRunning), service)
+			Expect(vmExport.Status.Push).To(BeNil())
+			Expect(vmExport.Status.Conditions).To(HaveLen(2))
+		})
+
+		It("should be pending until the exporter pod runs", func() {
+			fetchPushStatus = func(_, _, _ string, _ []byte) (*exportv1.VirtualMachineExportPushStatus, error) {
+				Fail("status must not be fetched")
+				return nil, nil
+			}
+			vmExport := newDestinationExport()
+			controller.updatePushStatus(vmExport, newExporterPod(k8sv1.PodPending), service)
+			Expect(vmExport.Status.Push.Phase).To(Equal(exportv1.PushPending))
+			Expect(vmExport.Status.Conditions).To(ContainElement(
+				HaveField("Type", exportv1.ConditionPushed)))
+			Expect(vmExport.Status.Conditions[2].Reason).To(Equal(pushPendingReason))
+		})
+
+		DescribeTable("should be fetched from the exporter pod", func(phase exportv1.VirtualMachineExportPushPhase, conditionStatus k8sv1.ConditionStatus, reason string) {
+			pushStatus := &exportv1.VirtualMachineExportPushStatus{
+				Phase:   phase,
+				Message: "message",
+				Volumes: []exportv1.VirtualMachineExportPushedObject{
+					{
+						Name:        testPVCName,
+						Format:      exportv1.KubeVirtRaw,
+						URL:         "http://minio.minio:9000/backups/default/test/test-pvc/disk.img",
+						Phase:       phase,
+						BytesPushed: 1024,
+					},
+				},
+			}
+			fetchPushStatus = func(url, serverName, token string, caBundle []byte) (*exportv1.VirtualMachineExportPushStatus, error) {
+				Expect(url).To(Equal("https://10.244.0.10:8444/push/status"))
+				Expect(serverName).To(Equal("virt-export-test.default.svc"))
+				Expect(token).To(Equal("status-token"))
+				Expect(string(caBundle)).To(Equal("ca bundle"))
+				return pushStatus, nil
+			}
+			vmExport := newDestinationExport()
+			controller.updatePushStatus(vmExport, newExporterPod(k8sv1.PodRunning), service)
+			Expect(vmExport.Status.Push).To(Equal(pushStatus))
+			Expect(vmExport.Status.Conditions).To(ContainElement(And(
+				HaveField("Type", exportv1.ConditionPushed),
+				HaveField("Status", conditionStatus),
+				HaveField("Reason", reason),
+				HaveField("Message", "message"),
+			)))
+		},
+			Entry("in progress", exportv1.PushInProgress, k8sv1.ConditionFalse, pushInProgressReason),
+			Entry("succeeded", exportv1.PushSucceeded, k8sv1.ConditionTrue, pushSucceededReason),
+			Entry("failed", exportv1.PushFailed, k8sv1.ConditionFalse, pushFailedReason),
+		)
+
+		It("should keep the last known status if it cannot be fetched", func() {
+			fetchPushStatus = func(_, _, _ string, _ []byte) (*exportv1.VirtualMachineExportPushStatus, error) {
+				return nil, fmt.Errorf("connection refused")
+			}
+			vmExport := newDestinationExport()
+			vmExport.Status.Push = &exportv1.VirtualMachineExportPushStatus{Phase: exportv1.PushInProgress}
+			controller.updatePushStatus(vmExport, newExporterPod(k8sv1.PodRunning), service)
+			Expect(vmExport.Status.Push.Phase).To(Equal(exportv1.PushInProgress))
+		})
+
+		It("should not be fetched once the export was pushed", func() {
+			fetchPushStatus = func(_, _, _ string, _ []byte) (*exportv1.VirtualMachineExportPushStatus, error) {
+				Fail("status must not be fetched")
+				return nil, nil
+			}
+			vmExport := newDestinationExport()
+			vmExport.Status.Push = &exportv1.VirtualMachineExportPushStatus{Phase: exportv1.PushSucceeded}
+			controller.updatePushStatus(vmExport, newExporterPod(k8sv1.PodRunning), service)
+			Expect(vmExport.Status.Push.Phase).To(Equal(exportv1.PushSucceeded))
+		})
+
+		DescribeTable("should be polled while the push is in progress", func(phase exportv1.VirtualMachineExportPushPhase, podPhase k8sv1.PodPhase, expected bool) {
+			vmExport := newDestinationExport()
+			if phase != "" {
+				vmExport.Status.Push = &exportv1.VirtualMachineExportPushStatus{Phase: phase}
+			}
+			Expect(isPushInProgress(vmExport, newExporterPod(podPhase))).To(Equal(expected))
+		},
+			Entry("no status yet", exportv1.VirtualMachineExportPushPhase(""), k8sv1.PodRunning, true),
+			Entry("pending", exportv1.PushPending, k8sv1.PodRunning, true),
+			Entry("in progress", exportv1.PushInProgress, k8sv1.PodRunning, true),
+			Entry("succeeded", exportv1.PushSucceeded, k8sv1.PodRunning, false),
+			Entry("failed", exportv1.PushFailed, k8sv1.PodRunning, false),
+			Entry("pod not running", exportv1.PushInProgress, k8sv1.PodPending, false),
+		)
+
+		DescribeTable("should fail an interrupted push once the exporter pod is gone", func(exporterPod *k8sv1.Pod) {
+			vmExport := newDestinationExport()
+			vmExport.Status.Push = &exportv1.VirtualMachineExportPushStatus{Phase: exportv1.PushInProgress}
+			controller.updatePushStatus(vmExport, exporterPod, service)
+			Expect(vmExport.Status.Push.Phase).To(Equal(exportv1.PushFailed))
+			Expect(vmExport.Status.Push.Message).To(Equal(pushInterruptedMessage))
+			Expect(vmExport.Status.Push.CompletionTime).ToNot(BeNil())
+			Expect(vmExport.Status.Conditions).To(ContainElement(And(
+				HaveField("Type", exportv1.ConditionPushed),
+				HaveField("Reason", pushFailedReason),
+			)))
+		},
+			Entry("deleted", nil),
+			Entry("completed", newExporterPod(k8sv1.PodSucceeded)),
+			Entry("failed", newExporterPod(k8sv1.PodFailed)),
+		)
+
+		It("should poll a new exporter pod pushing a failed export again", func() {
+			vmExport := newDestinationExport()
+			completionTime := metav1.NewTime(time.Now().Add(-time.Minute))
+			vmExport.Status.Push = &exportv1.VirtualMachineExportPushStatus{Phase: exportv1.PushFailed, CompletionTime: &completionTime}
+
+			exporterPod := newExporterPod(k8sv1.PodRunning)
+			exporterPod.CreationTimestamp = metav1.NewTime(completionTime.Add(-time.Minute))
+			Expect(isPushInProgress(vmExport, exporterPod)).To(BeFalse())
+			exporterPod.CreationTimestamp = metav1.Now()
+			Expect(isPushInProgress(vmExport, exporterPod)).To(BeTrue())
+		})
+
+		It("should fetch the status from the exporter over TLS", func() {
+			pushStatus := &exportv1.VirtualMachineExportPushStatus{Phase: exportv1.PushInProgress}
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Path).To(Equal(pushStatusURI))
+				Expect(r.Header.Get(statusTokenHeader)).To(Equal("status-token"))
+				Expect(json.NewEncoder(w).Encode(pushStatus)).To(Succeed())
+			}))
+			defer server.Close()
+			ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+			status, err := fetchPushStatus(server.URL+pushStatusURI, "example.com", "status-token", ca)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status).To(Equal(pushStatus))
+
+			_, err = fetchPushStatus(server.URL+pushStatusURI, "virt-export-test.default.svc", "status-token", ca)
+			Expect(err).To(MatchError(ContainSubstring("certificate")))
+		})
+	})
+})
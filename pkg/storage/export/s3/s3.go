@@ -0,0 +1,481 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+// Package s3 implements the subset of the S3 API needed to push exports to an
+// S3 compatible object storage: single and multipart uploads, signed with
+// AWS Signature Version 4 and using path-style bucket addressing.
+package s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRegion is used to sign the requests if no region is configured
+	DefaultRegion = "us-east-1"
+
+	// DefaultPartSize is the size of the parts of multipart uploads, unless
+	// the expected size of the object requires bigger parts
+	DefaultPartSize = 64 * 1024 * 1024
+	// maxParts is the maximum number of parts of a multipart upload
+	maxParts = 10000
+
+	maxAttempts = 3
+
+	amzDateFormat   = "20060102T150405Z"
+	shortDateFormat = "20060102"
+	signAlgorithm   = "AWS4-HMAC-SHA256"
+)
+
+// Config defines the bucket to upload to and the credentials to use
+type Config struct {
+	// Endpoint is the URL of the S3 API, e.g. https://s3.us-east-1.amazonaws.com
+	Endpoint string
+	Bucket   string
+	Region   string
+
+	AccessKeyID string
+	SecretKey   string
+
+	InsecureSkipTLSVerify bool
+}
+
+// Client uploads objects to a single bucket
+type Client struct {
+	Config
+
+	endpoint   *url.URL
+	httpClient *http.Client
+
+	// variables so they can be overridden in tests
+	now     func() time.Time
+	backoff time.Duration
+}
+
+// Error is the error returned by the S3 API
+type Error struct {
+	StatusCode int    `xml:"-"`
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("s3 request failed with status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("s3 request failed with status %d: %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+type initiateMultipartUploadResult struct {
+	UploadID string `xml:"UploadId"`
+}
+
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+type completeMultipartUpload struct {
+	XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+	Parts   []completedPart `xml:"Part"`
+}
+
+// NewClient creates a client for the configured bucket
+func NewClient(config Config) (*Client, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(config.Endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %v", config.Endpoint, err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return nil, fmt.Errorf("invalid endpoint %q: scheme must be http or https", config.Endpoint)
+	}
+	if endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint %q: missing host", config.Endpoint)
+	}
+	if config.Bucket == "" {
+		return nil, fmt.Errorf("bucket must not be empty")
+	}
+	if config.Region == "" {
+		config.Region = DefaultRegion
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.InsecureSkipTLSVerify {
+		// #nosec cause: InsecureSkipVerify: true
+		// resolution: explicitly requested for the destination
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	return &Client{
+		Config:     config,
+		endpoint:   endpoint,
+		httpClient: &http.Client{Transport: transport},
+		now:        time.Now,
+		backoff:    time.Second,
+	}, nil
+}
+
+// ObjectURL returns the path-style URL of the object with the given key
+func (c *Client) ObjectURL(key string) string {
+	u := *c.endpoint
+	u.Path = c.objectPath(key)
+	u.RawPath = uriEncode(u.Path, false)
+	return u.String()
+}
+
+// PutObject uploads a small object in a single request
+func (c *Client) PutObject(ctx context.Context, key string, data []byte, contentType string) error {
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	resp, err := c.do(ctx, http.MethodPut, key, nil, header, data)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Upload streams the content of r into the object with the given key. Objects
+// larger than a single part are uploaded with a multipart upload, buffering one
+// part at a time. sizeHint is the expected size of the object, or 0 if it is
+// unknown, and is used to choose a part size which keeps the number of parts
+// below the limit. progress, if set, is called with the number of bytes uploaded
+// so far after every part. Upload returns the hex encoded sha256 checksum and the
+// size of the object.
+func (c *Client) Upload(ctx context.Context, key string, r io.Reader, sizeHint int64, progress func(int64)) (string, int64, error) {
+	checksum := sha256.New()
+	buf := make([]byte, PartSize(sizeHint))
+
+	n, err := io.ReadFull(r, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		// Small enough for a single request
+		if err := c.PutObject(ctx, key, buf[:n], ""); err != nil {
+			return "", 0, err
+		}
+		checksum.Write(buf[:n])
+		reportProgress(progress, int64(n))
+		return hex.EncodeToString(checksum.Sum(nil)), int64(n), nil
+	} else if err != nil {
+		return "", 0, err
+	}
+
+	uploadID, err := c.createMultipartUpload(ctx, key)
+	if err != nil {
+		return "", 0, err
+	}
+
+	total, err := c.uploadParts(ctx, key, uploadID, r, buf, n, checksum, progress)
+	if err != nil {
+		if abortErr := c.abortMultipartUpload(ctx, key, uploadID); abortErr != nil {
+			return "", 0, fmt.Errorf("%v, failed to abort the multipart upload: %v", err, abortErr)
+		}
+		return "", 0, err
+	}
+	return hex.EncodeToString(checksum.Sum(nil)), total, nil
+}
+
+func (c *Client) uploadParts(ctx context.Context, key, uploadID string, r io.Reader, buf []byte, n int, checksum hash.Hash, progress func(int64)) (int64, error) {
+	var parts []completedPart
+	var total int64
+	for partNumber := 1; ; partNumber++ {
+		etag, err := c.uploadPart(ctx, key, uploadID, partNumber, buf[:n])
+		if err != nil {
+			return total, err
+		}
+		parts = append(parts, completedPart{PartNumber: partNumber, ETag: etag})
+		checksum.Write(buf[:n])
+		total += int64(n)
+		reportProgress(progress, total)
+
+		n, err = io.ReadFull(r, buf)
+		if err == io.EOF {
+			break
+		} else if err != nil && err != io.ErrUnexpectedEOF {
+			return total, err
+		}
+		if partNumber == maxParts {
+			return total, fmt.Errorf("object exceeds the maximum of %d parts of %d bytes", maxParts, len(buf))
+		}
+	}
+	return total, c.completeMultipartUpload(ctx, key, uploadID, parts)
+}
+
+func (c *Client) createMultipartUpload(ctx context.Context, key string) (string, error) {
+	resp, err := c.do(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	result := &initiateMultipartUploadResult{}
+	if err := xml.NewDecoder(resp.Body).Decode(result); err != nil {
+		return "", fmt.Errorf("failed to decode the multipart upload: %v", err)
+	}
+	if result.UploadID == "" {
+		return "", fmt.Errorf("missing upload ID of the multipart upload")
+	}
+	return result.UploadID, nil
+}
+
+func (c *Client) uploadPart(ctx context.Context, key, uploadID string, partNumber int, data []byte) (string, error) {
+	query := url.Values{
+		"partNumber": {strconv.Itoa(partNumber)},
+		"uploadId":   {uploadID},
+	}
+	resp, err := c.do(ctx, http.MethodPut, key, query, nil, data)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	return resp.Header.Get("ETag"), nil
+}
+
+func (c *Client) completeMultipartUpload(ctx context.Context, key, uploadID string, parts []completedPart) error {
+	body, err := xml.Marshal(&completeMultipartUpload{Parts: parts})
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(ctx, http.MethodPost, key, url.Values{"uploadId": {uploadID}}, nil, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Completing a multipart upload can fail after the response status was sent
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if bytes.Contains(respBody, []byte("<Error>")) {
+		return parseError(http.StatusOK, respBody)
+	}
+	return nil
+}
+
+func (c *Client) abortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	resp, err := c.do(ctx, http.MethodDelete, key, url.Values{"uploadId": {uploadID}}, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// do sends a signed request, retrying on connection errors and server errors
+func (c *Client) do(ctx context.Context, method, key string, query url.Values, header http.Header, body []byte) (*http.Response, error) {
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		var resp *http.Response
+		resp, err = c.send(ctx, method, key, query, header, body)
+		if err == nil {
+			return resp, nil
+		}
+		if s3Err, ok := err.(*Error); ok && s3Err.StatusCode < http.StatusInternalServerError {
+			return nil, err
+		}
+		if attempt < maxAttempts {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(c.backoff * time.Duration(attempt)):
+			}
+		}
+	}
+	return nil, err
+}
+
+func (c *Client) send(ctx context.Context, method, key string, query url.Values, header http.Header, body []byte) (*http.Response, error) {
+	u := *c.endpoint
+	u.Path = c.objectPath(key)
+	u.RawPath = uriEncode(u.Path, false)
+	u.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	payloadHash := sha256.Sum256(body)
+	signV4(req, c.AccessKeyID, c.SecretKey, c.Region, hex.EncodeToString(payloadHash[:]), c.now())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, parseError(resp.StatusCode, respBody)
+	}
+	return resp, nil
+}
+
+func (c *Client) objectPath(key string) string {
+	return strings.TrimSuffix(c.endpoint.Path, "/") + "/" + c.Bucket + "/" + strings.TrimPrefix(key, "/")
+}
+
+// PartSize returns the part size used to upload an object of the given size
+func PartSize(sizeHint int64) int64 {
+	const mi = 1024 * 1024
+	// Leave room for objects ending up bigger than expected, e.g. compressed
+	// incompressible data
+	partSize := 2 * sizeHint / maxParts
+	partSize = (partSize + mi - 1) / mi * mi
+	if partSize < DefaultPartSize {
+		return DefaultPartSize
+	}
+	return partSize
+}
+
+func reportProgress(progress func(int64), n int64) {
+	if progress != nil {
+		progress(n)
+	}
+}
+
+func parseError(statusCode int, body []byte) error {
+	s3Err := &Error{}
+	if len(body) > 0 {
+		// The body is not always an S3 error, e.g. errors of proxies
+		_ = xml.Unmarshal(body, s3Err)
+	}
+	s3Err.StatusCode = statusCode
+	return s3Err
+}
+
+// signV4 signs the request with AWS Signature Version 4. The host and all
+// headers set on the request are signed.
+func signV4(req *http.Request, accessKeyID, secretKey, region, payloadHash string, t time.Time) {
+	t = t.UTC()
+	amzDate := t.Format(amzDateFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		trimmed := make([]string, 0, len(values))
+		for _, value := range values {
+			trimmed = append(trimmed, strings.Join(strings.Fields(value), " "))
+		}
+		headers[strings.ToLower(name)] = strings.Join(trimmed, ",")
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	canonicalHeaders := &strings.Builder{}
+	for _, name := range names {
+		fmt.Fprintf(canonicalHeaders, "%s:%s\n", name, headers[name])
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path, false),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{t.Format(shortDateFormat), region, "s3", "aws4_request"}, "/")
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		signAlgorithm,
+		amzDate,
+		scope,
+		hex.EncodeToString(canonicalRequestHash[:]),
+	}, "\n")
+
+	signingKey := []byte("AWS4" + secretKey)
+	for _, part := range []string{t.Format(shortDateFormat), region, "s3", "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signAlgorithm, accessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, key := range keys {
+		values := append([]string{}, query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode encodes everything but the unreserved characters, as required by
+// Signature Version 4. Slashes are only encoded if encodeSlash is set.
+func uriEncode(s string, encodeSlash bool) string {
+	encoded := &strings.Builder{}
+	for _, b := range []byte(s) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '.', b == '_', b == '~':
+			encoded.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
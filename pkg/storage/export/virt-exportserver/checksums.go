@@ -0,0 +1,164 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package virtexportserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"kubevirt.io/client-go/log"
+)
+
+// reprDigestHeader publishes the digest of the complete volume, see RFC 9530
+const reprDigestHeader = "Repr-Digest"
+
+// checksummer calculates the sha256 checksums of the disk images in the
+// background, so they can be published in the status of the export
+type checksummer struct {
+	lock      sync.Mutex
+	checksums map[string]string
+}
+
+func newChecksummer() *checksummer {
+	return &checksummer{
+		checksums: map[string]string{},
+	}
+}
+
+// rawPath returns the path of the disk image of a volume
+func rawPath(vi VolumeInfo) (string, error) {
+	fi, err := os.Stat(vi.Path)
+	if err != nil {
+		return "", err
+	}
+	if fi.IsDir() {
+		return path.Join(vi.Path, "disk.img"), nil
+	}
+	return vi.Path, nil
+}
+
+// contextReader stops reading once the context is done
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
+
+func (c *checksummer) run(ctx context.Context, volumes []VolumeInfo) {
+	for _, vi := range volumes {
+		if vi.RawURI == "" {
+			continue
+		}
+		checksum, err := calculateChecksum(ctx, vi)
+		if err != nil {
+			log.Log.Reason(err).Errorf("error calculating checksum of volume %s", volumeName(vi))
+			continue
+		}
+		log.Log.Infof("Checksum of volume %s is %s", volumeName(vi), checksum)
+		c.lock.Lock()
+		c.checksums[volumeName(vi)] = checksum
+		c.lock.Unlock()
+	}
+}
+
+func calculateChecksum(ctx context.Context, vi VolumeInfo) (string, error) {
+	p, err := rawPath(vi)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, &contextReader{ctx: ctx, r: f}); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (c *checksummer) get(name string) (string, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	checksum, ok := c.checksums[name]
+	return checksum, ok
+}
+
+func (c *checksummer) getAll() map[string]string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	result := make(map[string]string, len(c.checksums))
+	for name, checksum := range c.checksums {
+		result[name] = checksum
+	}
+	return result
+}
+
+// handler serves the checksums calculated so far, by volume name
+func (c *checksummer) handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, err := json.Marshal(c.getAll())
+		if err != nil {
+			log.Log.Reason(err).Error("error marshalling checksums")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", runtime.ContentTypeJSON)
+		if _, err := w.Write(data); err != nil {
+			log.Log.Reason(err).Error("error writing checksums")
+		}
+	})
+}
+
+// digestHandler adds the digest of the volume to the responses of the next
+// handler, once it is known
+func (c *checksummer) digestHandler(name string, nextHandler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if checksum, ok := c.get(name); ok {
+			if digest, err := hex.DecodeString(checksum); err == nil {
+				w.Header().Set(reprDigestHeader, fmt.Sprintf("sha-256=:%s:", base64.StdEncoding.EncodeToString(digest)))
+			}
+		}
+		nextHandler.ServeHTTP(w, req)
+	})
+}
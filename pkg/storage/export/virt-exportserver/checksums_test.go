@@ -0,0 +1,157 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package virtexportserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checksums", func() {
+	var (
+		diskDir     string
+		archiveDir  string
+		diskContent []byte
+		checksum    string
+	)
+
+	BeforeEach(func() {
+		tempDir := GinkgoT().TempDir()
+		diskDir = filepath.Join(tempDir, "disk-pvc")
+		archiveDir = filepath.Join(tempDir, "archive-pvc")
+		Expect(os.Mkdir(diskDir, 0755)).To(Succeed())
+		Expect(os.Mkdir(archiveDir, 0755)).To(Succeed())
+		diskContent = []byte("0123456789abcdefghijklmnopqrstuvwxyz")
+		Expect(os.WriteFile(filepath.Join(diskDir, "disk.img"), diskContent, 0644)).To(Succeed())
+		sum := sha256.Sum256(diskContent)
+		checksum = hex.EncodeToString(sum[:])
+	})
+
+	It("should be calculated for the disk images", func() {
+		c := newChecksummer()
+		c.run(context.Background(), []VolumeInfo{
+			{Path: diskDir, RawURI: "/volumes/disk-pvc/disk.img"},
+			{Path: archiveDir, ArchiveURI: "/volumes/archive-pvc/disk.tar.gz"},
+		})
+		Expect(c.getAll()).To(Equal(map[string]string{"disk-pvc": checksum}))
+	})
+
+	It("should not be calculated once the context is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		c := newChecksummer()
+		c.run(ctx, []VolumeInfo{{Path: diskDir, RawURI: "/volumes/disk-pvc/disk.img"}})
+		Expect(c.getAll()).To(BeEmpty())
+	})
+
+	It("should be served as JSON", func() {
+		c := newChecksummer()
+		c.run(context.Background(), []VolumeInfo{{Path: diskDir, RawURI: "/volumes/disk-pvc/disk.img"}})
+
+		recorder := httptest.NewRecorder()
+		c.handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/checksums", nil))
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		checksums := map[string]string{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &checksums)).To(Succeed())
+		Expect(checksums).To(Equal(map[string]string{"disk-pvc": checksum}))
+	})
+
+	It("should add the digest to the responses once known", func() {
+		c := newChecksummer()
+		handler := c.digestHandler("disk-pvc", http.HandlerFunc(successHandler))
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/volumes/disk-pvc/disk.img", nil))
+		Expect(recorder.Header().Get(reprDigestHeader)).To(BeEmpty())
+
+		c.run(context.Background(), []VolumeInfo{{Path: diskDir, RawURI: "/volumes/disk-pvc/disk.img"}})
+		recorder = httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/volumes/disk-pvc/disk.img", nil))
+		sum := sha256.Sum256(diskContent)
+		Expect(recorder.Header().Get(reprDigestHeader)).To(Equal("sha-256=:" + base64.StdEncoding.EncodeToString(sum[:]) + ":"))
+	})
+
+	Context("raw file handler", func() {
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			server = httptest.NewServer(fileHandler(filepath.Join(diskDir, "disk.img")))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		get := func(headers map[string]string) *http.Response {
+			req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			Expect(err).ToNot(HaveOccurred())
+			for k, v := range headers {
+				req.Header.Set(k, v)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			return resp
+		}
+
+		readBody := func(resp *http.Response) string {
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			Expect(err).ToNot(HaveOccurred())
+			return string(body)
+		}
+
+		It("should serve ranges", func() {
+			resp := get(map[string]string{"Range": "bytes=10-"})
+			Expect(resp.StatusCode).To(Equal(http.StatusPartialContent))
+			Expect(resp.Header.Get("Content-Range")).To(Equal("bytes 10-35/36"))
+			Expect(readBody(resp)).To(Equal(string(diskContent[10:])))
+		})
+
+		It("should resume with a matching If-Range", func() {
+			resp := get(nil)
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(resp.ContentLength).To(BeEquivalentTo(len(diskContent)))
+			etag := resp.Header.Get("ETag")
+			Expect(etag).ToNot(BeEmpty())
+			Expect(readBody(resp)).To(Equal(string(diskContent)))
+
+			resp = get(map[string]string{"Range": "bytes=30-", "If-Range": etag})
+			Expect(resp.StatusCode).To(Equal(http.StatusPartialContent))
+			Expect(readBody(resp)).To(Equal(string(diskContent[30:])))
+		})
+
+		It("should serve the whole file if If-Range does not match", func() {
+			resp := get(map[string]string{"Range": "bytes=30-", "If-Range": `"other"`})
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(readBody(resp)).To(Equal(string(diskContent)))
+		})
+	})
+})
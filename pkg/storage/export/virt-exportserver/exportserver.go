@@ -87,6 +87,22 @@ type ExportServerConfig struct {
 
 	Volumes []VolumeInfo
 
+	// StatusListenAddr is the address of a separate listener, which is not
+	// exposed by the export service. It serves the checksums of the volumes
+	// and the progress of the push.
+	StatusListenAddr string
+
+	// StatusTokenFile holds the token the status listener requires, it is
+	// only known to the controller
+	StatusTokenFile string
+
+	// ChecksumsURI is where the checksums of the volumes are served on the
+	// status listener
+	ChecksumsURI string
+
+	// Push, if set, makes the server push the volumes and manifests to a destination
+	Push *PushConfig
+
 	// unit testing helpers
 	ArchiveHandler     func(string) http.Handler
 	DirHandler         func(string, string) http.Handler
@@ -95,7 +111,8 @@ type ExportServerConfig struct {
 	VmHandler          func(string, []VolumeInfo, func() (string, error), func() (*corev1.ConfigMap, error)) http.Handler
 	TokenSecretHandler func(TokenGetterFunc) http.Handler
 
-	TokenGetter TokenGetterFunc
+	TokenGetter       TokenGetterFunc
+	StatusTokenGetter TokenGetterFunc
 }
 
 type execReader struct {
@@ -106,7 +123,8 @@ type execReader struct {
 
 type exportServer struct {
 	ExportServerConfig
-	handler http.Handler
+	handler     http.Handler
+	checksummer *checksummer
 }
 
 func (er *execReader) Read(p []byte) (int, error) {
@@ -180,7 +198,7 @@ func (s *exportServer) getHandlerMap(vi VolumeInfo) map[string]http.Handler {
 	}
 
 	if vi.RawURI != "" {
-		result[vi.RawURI] = s.FileHandler(p)
+		result[vi.RawURI] = s.checksummer.digestHandler(volumeName(vi), s.FileHandler(p))
 	}
 
 	if vi.RawGzURI != "" {
@@ -205,6 +223,11 @@ func (s *exportServer) Run() {
 		ch <- err
 	}()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.checksummer.run(ctx, s.Volumes)
+	statusSrv := s.startStatusServer(ctx, ch)
+
 	if !s.Deadline.IsZero() {
 		log.Log.Infof("Deadline set to %s", s.Deadline)
 		select {
@@ -212,6 +235,9 @@ func (s *exportServer) Run() {
 			panic(err)
 		case <-time.After(time.Until(s.Deadline)):
 			log.Log.Info("Deadline exceeded, shutting down")
+			if statusSrv != nil {
+				statusSrv.Shutdown(context.TODO())
+			}
 			srv.Shutdown(context.TODO())
 		}
 	} else {
@@ -220,12 +246,49 @@ func (s *exportServer) Run() {
 	}
 }
 
+// startStatusServer starts the status listener, which is not exposed by the
+// export service, and pushes the export in the background if a destination is
+// configured.
+func (s *exportServer) startStatusServer(ctx context.Context, ch chan<- error) *http.Server {
+	if s.StatusListenAddr == "" {
+		return nil
+	}
+
+	statusSrv := &http.Server{
+		Addr:    s.StatusListenAddr,
+		Handler: s.statusHandler(ctx),
+	}
+	go func() {
+		if err := statusSrv.ListenAndServeTLS(s.CertFile, s.KeyFile); err != http.ErrServerClosed {
+			ch <- err
+		}
+	}()
+	return statusSrv
+}
+
+// statusHandler serves the checksums and the push status to the controller
+func (s *exportServer) statusHandler(ctx context.Context) http.Handler {
+	mux := http.NewServeMux()
+	if s.ChecksumsURI != "" {
+		mux.Handle(s.ChecksumsURI, tokenChecker(s.StatusTokenGetter, s.checksummer.handler()))
+	}
+	if s.Push != nil {
+		p := newPusher(*s.Push, s.Volumes)
+		mux.Handle(s.Push.StatusURI, tokenChecker(s.StatusTokenGetter, p.statusHandler()))
+		go p.run(ctx)
+	}
+	return mux
+}
+
 func (s *exportServer) AddFlags() {
 	flag.CommandLine.AddGoFlag(goflag.CommandLine.Lookup("v"))
 }
 
 func NewExportServer(config ExportServerConfig) service.Service {
-	es := &exportServer{ExportServerConfig: config}
+	es := &exportServer{
+		ExportServerConfig: config,
+		checksummer:        newChecksummer(),
+	}
 
 	if es.ArchiveHandler == nil {
 		es.ArchiveHandler = archiveHandler
@@ -257,6 +320,15 @@ func NewExportServer(config ExportServerConfig) service.Service {
 		}
 	}
 
+	if es.StatusTokenGetter == nil {
+		es.StatusTokenGetter = func() (string, error) {
+			if es.StatusTokenFile == "" {
+				return "", fmt.Errorf("no status token file set")
+			}
+			return getToken(es.StatusTokenFile)
+		}
+	}
+
 	return es
 }
 
@@ -417,6 +489,9 @@ func pipeToGzip(reader io.ReadCloser) io.ReadCloser {
 		n, err := io.Copy(zw, reader)
 		if err != nil {
 			log.Log.Reason(err).Error("error piping to gzip")
+			// Let the reader fail instead of handing out a truncated stream
+			pw.CloseWithError(err)
+			return
 		}
 		if err = zw.Close(); err != nil {
 			log.Log.Reason(err).Error("error closing gzip writer")
@@ -649,7 +724,23 @@ func fileHandler(file string) http.Handler {
 			return
 		}
 		defer f.Close()
-		http.ServeContent(w, r, "disk.img", time.Time{}, f)
+		// Seeking works for block devices as well, unlike stat
+		size, err := f.Seek(0, io.SeekEnd)
+		if err == nil {
+			_, err = f.Seek(0, io.SeekStart)
+		}
+		if err != nil {
+			log.Log.Reason(err).Errorf("error seeking %s", file)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		modTime := time.Time{}
+		if fi, err := f.Stat(); err == nil {
+			modTime = fi.ModTime()
+		}
+		// A strong validator is required for resuming downloads with If-Range
+		w.Header().Set("ETag", fmt.Sprintf(`"%x-%x"`, modTime.UnixNano(), size))
+		http.ServeContent(w, r, "disk.img", modTime, f)
 	})
 }
 
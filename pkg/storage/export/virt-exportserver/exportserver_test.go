@@ -20,11 +20,14 @@
 package virtexportserver
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -730,3 +733,58 @@ var _ = Describe("exportserver", func() {
 		})
 	})
 })
+
+var _ = Describe("status listener", func() {
+	DescribeTable("should require the status token", func(statusToken, requestToken string, expectedStatusCode int) {
+		es := newTestServer("foo")
+		es.Push = &PushConfig{
+			Uploader:  &fakeUploader{objects: map[string][]byte{}, failing: map[string]bool{}},
+			StatusURI: "/push-status",
+		}
+		if statusToken != "" {
+			es.StatusTokenFile = filepath.Join(GinkgoT().TempDir(), "status-token")
+			Expect(os.WriteFile(es.StatusTokenFile, []byte(statusToken), 0600)).To(Succeed())
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		httpServer := httptest.NewServer(es.statusHandler(ctx))
+		defer httpServer.Close()
+
+		req, err := http.NewRequest(http.MethodGet, httpServer.URL+"/push-status", nil)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("x-kubevirt-export-token", requestToken)
+		res, err := http.DefaultClient.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		defer res.Body.Close()
+		Expect(res.StatusCode).To(Equal(expectedStatusCode))
+	},
+		Entry("with the status token", "status-token", "status-token", http.StatusOK),
+		Entry("without a token", "status-token", "", http.StatusUnauthorized),
+		Entry("with the export token", "status-token", "foo", http.StatusUnauthorized),
+		Entry("unless no status token file is set", "", "", http.StatusInternalServerError),
+	)
+
+	It("should require the status token for the checksums", func() {
+		es := newTestServer("foo")
+		es.ChecksumsURI = "/checksums"
+		es.StatusTokenFile = filepath.Join(GinkgoT().TempDir(), "status-token")
+		Expect(os.WriteFile(es.StatusTokenFile, []byte("status-token"), 0600)).To(Succeed())
+
+		httpServer := httptest.NewServer(es.statusHandler(context.Background()))
+		defer httpServer.Close()
+
+		res, err := http.Get(httpServer.URL + "/checksums")
+		Expect(err).ToNot(HaveOccurred())
+		defer res.Body.Close()
+		Expect(res.StatusCode).To(Equal(http.StatusUnauthorized))
+
+		req, err := http.NewRequest(http.MethodGet, httpServer.URL+"/checksums", nil)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("x-kubevirt-export-token", "status-token")
+		res, err = http.DefaultClient.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		defer res.Body.Close()
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+	})
+})
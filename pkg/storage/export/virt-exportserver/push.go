@@ -0,0 +1,403 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package virtexportserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	exportv1 "kubevirt.io/api/export/v1alpha1"
+	"kubevirt.io/client-go/log"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+const (
+	allManifestsName      = "all"
+	checksumsManifestName = "checksums"
+
+	allManifestsFile = "manifests/all.yaml"
+	checksumsFile    = "SHA256SUMS"
+)
+
+// Uploader uploads objects to the destination of a push
+type Uploader interface {
+	ObjectURL(key string) string
+	PutObject(ctx context.Context, key string, data []byte, contentType string) error
+	Upload(ctx context.Context, key string, r io.Reader, sizeHint int64, progress func(int64)) (string, int64, error)
+}
+
+// PushConfig defines the destination the export is pushed to
+type PushConfig struct {
+	Uploader Uploader
+
+	// Prefix is prepended to the keys of all pushed objects
+	Prefix string
+
+	// Format is the format volumes containing a disk image are pushed in
+	Format exportv1.ExportVolumeFormat
+
+	// CredentialsSecretName is used by the DataVolumes of the pushed manifests to
+	// import the volumes from the destination
+	CredentialsSecretName string
+
+	// StatusURI is where the progress of the push is served on the status listener
+	StatusURI string
+}
+
+// variable so can be overridden in tests
+var now = func() *metav1.Time {
+	t := metav1.Now()
+	return &t
+}
+
+type pusher struct {
+	PushConfig
+
+	volumes []VolumeInfo
+
+	lock   sync.Mutex
+	status exportv1.VirtualMachineExportPushStatus
+}
+
+type readCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (rc *readCloser) Close() error {
+	var err error
+	for _, closer := range rc.closers {
+		if closeErr := closer.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func newPusher(config PushConfig, volumes []VolumeInfo) *pusher {
+	if config.Format == "" {
+		config.Format = exportv1.KubeVirtGz
+	}
+	p := &pusher{
+		PushConfig: config,
+		volumes:    volumes,
+		status: exportv1.VirtualMachineExportPushStatus{
+			Phase: exportv1.PushPending,
+		},
+	}
+	for _, vi := range volumes {
+		p.status.Volumes = append(p.status.Volumes, exportv1.VirtualMachineExportPushedObject{
+			Name:   volumeName(vi),
+			Format: p.volumeFormat(vi),
+			URL:    p.Uploader.ObjectURL(p.volumeKey(vi)),
+			Phase:  exportv1.PushPending,
+		})
+	}
+	return p
+}
+
+func volumeName(vi VolumeInfo) string {
+	return filepath.Base(vi.Path)
+}
+
+// volumeFormat returns the format the volume is pushed in. Volumes which do
+// not contain a disk image can only be pushed as archive.
+func (p *pusher) volumeFormat(vi VolumeInfo) exportv1.ExportVolumeFormat {
+	if vi.RawURI == "" {
+		return exportv1.ArchiveGz
+	}
+	return p.Format
+}
+
+func (p *pusher) volumeFile(vi VolumeInfo) string {
+	switch p.volumeFormat(vi) {
+	case exportv1.ArchiveGz:
+		return path.Join(volumeName(vi), "disk.tar.gz")
+	case exportv1.KubeVirtRaw:
+		return path.Join(volumeName(vi), "disk.img")
+	default:
+		return path.Join(volumeName(vi), "disk.img.gz")
+	}
+}
+
+func (p *pusher) volumeKey(vi VolumeInfo) string {
+	return path.Join(p.Prefix, p.volumeFile(vi))
+}
+
+func (p *pusher) getStatus() *exportv1.VirtualMachineExportPushStatus {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.status.DeepCopy()
+}
+
+func (p *pusher) updateStatus(update func(status *exportv1.VirtualMachineExportPushStatus)) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	update(&p.status)
+}
+
+func (p *pusher) updateVolume(i int, update func(volume *exportv1.VirtualMachineExportPushedObject)) {
+	p.updateStatus(func(status *exportv1.VirtualMachineExportPushStatus) {
+		update(&status.Volumes[i])
+	})
+}
+
+// run pushes all volumes, then the manifests and finally the checksums of all
+// pushed objects. Volumes are pushed even if others failed, the manifests and
+// checksums only if all volumes were pushed.
+func (p *pusher) run(ctx context.Context) {
+	p.updateStatus(func(status *exportv1.VirtualMachineExportPushStatus) {
+		status.Phase = exportv1.PushInProgress
+		status.StartTime = now()
+	})
+
+	checksums := &strings.Builder{}
+	failed := 0
+	for i, vi := range p.volumes {
+		checksum, err := p.pushVolume(ctx, i, vi)
+		if err != nil {
+			log.Log.Reason(err).Errorf("error pushing volume %s", volumeName(vi))
+			failed++
+			continue
+		}
+		fmt.Fprintf(checksums, "%s  %s\n", checksum, p.volumeFile(vi))
+	}
+
+	err := fmt.Errorf("failed to push %d of %d volumes", failed, len(p.volumes))
+	if failed == 0 {
+		err = p.pushManifests(ctx, checksums)
+	}
+
+	p.updateStatus(func(status *exportv1.VirtualMachineExportPushStatus) {
+		status.CompletionTime = now()
+		if err != nil {
+			log.Log.Reason(err).Error("error pushing export")
+			status.Phase = exportv1.PushFailed
+			status.Message = err.Error()
+		} else {
+			log.Log.Info("Pushed export")
+			status.Phase = exportv1.PushSucceeded
+		}
+	})
+}
+
+func (p *pusher) pushVolume(ctx context.Context, i int, vi VolumeInfo) (string, error) {
+	p.updateVolume(i, func(volume *exportv1.VirtualMachineExportPushedObject) {
+		volume.Phase = exportv1.PushInProgress
+	})
+
+	checksum, n, err := p.uploadVolume(ctx, i, vi)
+
+	p.updateVolume(i, func(volume *exportv1.VirtualMachineExportPushedObject) {
+		if err != nil {
+			volume.Phase = exportv1.PushFailed
+			volume.Message = err.Error()
+			return
+		}
+		volume.Phase = exportv1.PushSucceeded
+		volume.BytesPushed = n
+		volume.Sha256 = checksum
+	})
+	return checksum, err
+}
+
+func (p *pusher) uploadVolume(ctx context.Context, i int, vi VolumeInfo) (string, int64, error) {
+	reader, sizeHint, err := openVolume(vi, p.volumeFormat(vi))
+	if err != nil {
+		return "", 0, err
+	}
+	defer reader.Close()
+
+	log.Log.Infof("Pushing volume %s to %s", volumeName(vi), p.volumeKey(vi))
+	return p.Uploader.Upload(ctx, p.volumeKey(vi), reader, sizeHint, func(n int64) {
+		p.updateVolume(i, func(volume *exportv1.VirtualMachineExportPushedObject) {
+			volume.BytesPushed = n
+		})
+	})
+}
+
+// openVolume returns a reader of the volume in the given format and the size
+// of the volume, if known
+func openVolume(vi VolumeInfo, format exportv1.ExportVolumeFormat) (io.ReadCloser, int64, error) {
+	if format == exportv1.ArchiveGz {
+		tarReader, err := newTarReader(vi.Path)
+		if err != nil {
+			return nil, 0, err
+		}
+		gzipReader := pipeToGzip(tarReader)
+		return &readCloser{Reader: gzipReader, closers: []io.Closer{gzipReader, tarReader}}, 0, nil
+	}
+
+	p, err := rawPath(vi)
+	if err != nil {
+		return nil, 0, err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, 0, err
+	}
+	// Seeking works for block devices as well, unlike stat
+	size, err := f.Seek(0, io.SeekEnd)
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+
+	if format == exportv1.KubeVirtGz {
+		gzipReader := pipeToGzip(f)
+		return &readCloser{Reader: gzipReader, closers: []io.Closer{gzipReader, f}}, size, nil
+	}
+	return f, size, nil
+}
+
+func (p *pusher) pushManifests(ctx context.Context, checksums *strings.Builder) error {
+	manifests, err := p.vmManifests()
+	if err != nil {
+		return err
+	}
+	if manifests != nil {
+		checksum, err := p.pushManifest(ctx, allManifestsName, allManifestsFile, manifests, runtime.ContentTypeYAML)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(checksums, "%s  %s\n", checksum, allManifestsFile)
+	}
+	_, err = p.pushManifest(ctx, checksumsManifestName, checksumsFile, []byte(checksums.String()), "text/plain")
+	return err
+}
+
+func (p *pusher) pushManifest(ctx context.Context, name, file string, data []byte, contentType string) (string, error) {
+	key := path.Join(p.Prefix, file)
+	manifest := exportv1.VirtualMachineExportPushedObject{
+		Name:  name,
+		URL:   p.Uploader.ObjectURL(key),
+		Phase: exportv1.PushSucceeded,
+	}
+
+	log.Log.Infof("Pushing manifest %s to %s", name, key)
+	err := p.Uploader.PutObject(ctx, key, data, contentType)
+	if err != nil {
+		manifest.Phase = exportv1.PushFailed
+		manifest.Message = err.Error()
+	} else {
+		manifest.BytesPushed = int64(len(data))
+		manifest.Sha256 = sha256Hex(data)
+	}
+
+	p.updateStatus(func(status *exportv1.VirtualMachineExportPushStatus) {
+		status.Manifests = append(status.Manifests, manifest)
+	})
+	return manifest.Sha256, err
+}
+
+// vmManifests returns the manifests of the exported VM, with the DataVolumes
+// importing the pushed volumes from the destination. Exports without a VM
+// have no manifests.
+func (p *pusher) vmManifests() ([]byte, error) {
+	expandedVm := getExpandedVM()
+	if expandedVm == nil {
+		return nil, nil
+	}
+	expandedVm.TypeMeta = metav1.TypeMeta{
+		Kind:       virtv1.VirtualMachineGroupVersionKind.Kind,
+		APIVersion: virtv1.VirtualMachineGroupVersionKind.GroupVersion().String(),
+	}
+	for i := range expandedVm.Spec.DataVolumeTemplates {
+		dvTemplate := &expandedVm.Spec.DataVolumeTemplates[i]
+		if source := p.dataVolumeSource(dvTemplate.Name); source != nil {
+			dvTemplate.Spec.Source = source
+		}
+	}
+	resources := []runtime.Object{expandedVm}
+
+	datavolumes, err := getDataVolumes(expandedVm)
+	if err != nil {
+		return nil, err
+	}
+	for _, dv := range datavolumes {
+		dv.TypeMeta = metav1.TypeMeta{
+			Kind:       "DataVolume",
+			APIVersion: "cdi.kubevirt.io/v1beta1",
+		}
+		if source := p.dataVolumeSource(dv.Name); source != nil {
+			dv.Spec.Source = source
+		}
+		resources = append(resources, dv)
+	}
+	return resourceToBytesYaml(resources)
+}
+
+// dataVolumeSource returns the source importing the pushed volume of the
+// DataVolume, volumes are named after the PVC of the DataVolume
+func (p *pusher) dataVolumeSource(name string) *cdiv1.DataVolumeSource {
+	status := p.getStatus()
+	for i, vi := range p.volumes {
+		if volumeName(vi) == name {
+			return &cdiv1.DataVolumeSource{
+				S3: &cdiv1.DataVolumeSourceS3{
+					URL:       status.Volumes[i].URL,
+					SecretRef: p.CredentialsSecretName,
+				},
+			}
+		}
+	}
+	return nil
+}
+
+func sha256Hex(data []byte) string {
+	checksum := sha256.Sum256(data)
+	return hex.EncodeToString(checksum[:])
+}
+
+func (p *pusher) statusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, err := json.Marshal(p.getStatus())
+		if err != nil {
+			log.Log.Reason(err).Error("error marshalling push status")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", runtime.ContentTypeJSON)
+		if _, err := w.Write(data); err != nil {
+			log.Log.Reason(err).Error("error writing push status")
+		}
+	})
+}
@@ -0,0 +1,301 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package virtexportserver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	exportv1 "kubevirt.io/api/export/v1alpha1"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+type fakeUploader struct {
+	objects map[string][]byte
+	failing map[string]bool
+}
+
+func (u *fakeUploader) ObjectURL(key string) string {
+	return "https://minio.invalid/bucket/" + key
+}
+
+func (u *fakeUploader) PutObject(_ context.Context, key string, data []byte, _ string) error {
+	if u.failing[key] {
+		return fmt.Errorf("upload of %s failed", key)
+	}
+	u.objects[key] = data
+	return nil
+}
+
+func (u *fakeUploader) Upload(_ context.Context, key string, r io.Reader, _ int64, progress func(int64)) (string, int64, error) {
+	if u.failing[key] {
+		return "", 0, fmt.Errorf("upload of %s failed", key)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", 0, err
+	}
+	u.objects[key] = data
+	progress(int64(len(data)))
+	return sha256Hex(data), int64(len(data)), nil
+}
+
+var _ = Describe("pusher", func() {
+	var (
+		uploader    *fakeUploader
+		diskDir     string
+		archiveDir  string
+		diskContent = []byte("disk image content")
+		startTime   = metav1.Now()
+
+		orgGetExpandedVM  = getExpandedVM
+		orgGetDataVolumes = getDataVolumes
+		orgNow            = now
+	)
+
+	newTestPusher := func(format exportv1.ExportVolumeFormat, volumes ...VolumeInfo) *pusher {
+		return newPusher(PushConfig{
+			Uploader:              uploader,
+			Prefix:                "default/export",
+			Format:                format,
+			CredentialsSecretName: "s3-credentials",
+		}, volumes)
+	}
+
+	gunzip := func(data []byte) []byte {
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		Expect(err).ToNot(HaveOccurred())
+		content, err := io.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		return content
+	}
+
+	BeforeEach(func() {
+		uploader = &fakeUploader{objects: map[string][]byte{}, failing: map[string]bool{}}
+		tempDir := GinkgoT().TempDir()
+		diskDir = filepath.Join(tempDir, "disk-pvc")
+		archiveDir = filepath.Join(tempDir, "data-pvc")
+		Expect(os.Mkdir(diskDir, 0755)).To(Succeed())
+		Expect(os.Mkdir(archiveDir, 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(diskDir, "disk.img"), diskContent, 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(archiveDir, "file.txt"), []byte("data"), 0644)).To(Succeed())
+
+		getExpandedVM = func() *virtv1.VirtualMachine {
+			return nil
+		}
+		now = func() *metav1.Time {
+			return &startTime
+		}
+	})
+
+	AfterEach(func() {
+		getExpandedVM = orgGetExpandedVM
+		getDataVolumes = orgGetDataVolumes
+		now = orgNow
+	})
+
+	It("should start pending with the object URLs", func() {
+		p := newTestPusher("", VolumeInfo{Path: diskDir, RawURI: "/volumes/disk-pvc/disk.img"}, VolumeInfo{Path: archiveDir})
+		status := p.getStatus()
+		Expect(status.Phase).To(Equal(exportv1.PushPending))
+		Expect(status.Volumes).To(Equal([]exportv1.VirtualMachineExportPushedObject{
+			{
+				Name:   "disk-pvc",
+				Format: exportv1.KubeVirtGz,
+				URL:    "https://minio.invalid/bucket/default/export/disk-pvc/disk.img.gz",
+				Phase:  exportv1.PushPending,
+			},
+			{
+				Name:   "data-pvc",
+				Format: exportv1.ArchiveGz,
+				URL:    "https://minio.invalid/bucket/default/export/data-pvc/disk.tar.gz",
+				Phase:  exportv1.PushPending,
+			},
+		}))
+	})
+
+	DescribeTable("should push disk images", func(format exportv1.ExportVolumeFormat, key string, decode func([]byte) []byte) {
+		p := newTestPusher(format, VolumeInfo{Path: diskDir, RawURI: "/volumes/disk-pvc/disk.img"})
+		p.run(context.Background())
+
+		Expect(uploader.objects).To(HaveKey(key))
+		pushed := uploader.objects[key]
+		Expect(decode(pushed)).To(Equal(diskContent))
+
+		status := p.getStatus()
+		Expect(status.Phase).To(Equal(exportv1.PushSucceeded))
+		Expect(status.StartTime).To(Equal(&startTime))
+		Expect(status.CompletionTime).To(Equal(&startTime))
+		Expect(status.Volumes).To(HaveLen(1))
+		Expect(status.Volumes[0].Phase).To(Equal(exportv1.PushSucceeded))
+		Expect(status.Volumes[0].Format).To(Equal(format))
+		Expect(status.Volumes[0].BytesPushed).To(BeEquivalentTo(len(pushed)))
+		Expect(status.Volumes[0].Sha256).To(Equal(sha256Hex(pushed)))
+	},
+		Entry("raw", exportv1.KubeVirtRaw, "default/export/disk-pvc/disk.img", func(data []byte) []byte { return data }),
+		Entry("gzip", exportv1.KubeVirtGz, "default/export/disk-pvc/disk.img.gz", gunzip),
+	)
+
+	It("should push volumes without a disk image as archive", func() {
+		p := newTestPusher(exportv1.KubeVirtRaw, VolumeInfo{Path: archiveDir})
+		p.run(context.Background())
+
+		Expect(p.getStatus().Phase).To(Equal(exportv1.PushSucceeded))
+		Expect(uploader.objects).To(HaveKey("default/export/data-pvc/disk.tar.gz"))
+		Expect(gunzip(uploader.objects["default/export/data-pvc/disk.tar.gz"])).ToNot(BeEmpty())
+	})
+
+	It("should push the checksums of all objects", func() {
+		p := newTestPusher(exportv1.KubeVirtRaw, VolumeInfo{Path: diskDir, RawURI: "/volumes/disk-pvc/disk.img"})
+		p.run(context.Background())
+
+		checksums := string(uploader.objects["default/export/SHA256SUMS"])
+		Expect(checksums).To(Equal(fmt.Sprintf("%s  disk-pvc/disk.img\n", sha256Hex(diskContent))))
+		Expect(p.getStatus().Manifests).To(Equal([]exportv1.VirtualMachineExportPushedObject{
+			{
+				Name:        checksumsManifestName,
+				URL:         "https://minio.invalid/bucket/default/export/SHA256SUMS",
+				Phase:       exportv1.PushSucceeded,
+				BytesPushed: int64(len(checksums)),
+				Sha256:      sha256Hex([]byte(checksums)),
+			},
+		}))
+	})
+
+	It("should push the VM manifests importing the volumes from the destination", func() {
+		getExpandedVM = func() *virtv1.VirtualMachine {
+			return &virtv1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-vm", Namespace: testNamespace},
+				Spec: virtv1.VirtualMachineSpec{
+					DataVolumeTemplates: []virtv1.DataVolumeTemplateSpec{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "disk-pvc"},
+							Spec: cdiv1.DataVolumeSpec{
+								Source: &cdiv1.DataVolumeSource{HTTP: &cdiv1.DataVolumeSourceHTTP{}},
+							},
+						},
+					},
+					Template: &virtv1.VirtualMachineInstanceTemplateSpec{},
+				},
+			}
+		}
+		getDataVolumes = func(vm *virtv1.VirtualMachine) ([]*cdiv1.DataVolume, error) {
+			return []*cdiv1.DataVolume{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "data-pvc", Namespace: testNamespace},
+					Spec: cdiv1.DataVolumeSpec{
+						Source: &cdiv1.DataVolumeSource{HTTP: &cdiv1.DataVolumeSourceHTTP{}},
+					},
+				},
+			}, nil
+		}
+
+		p := newTestPusher(exportv1.KubeVirtGz, VolumeInfo{Path: diskDir, RawURI: "/volumes/disk-pvc/disk.img"}, VolumeInfo{Path: archiveDir})
+		p.run(context.Background())
+		Expect(p.getStatus().Phase).To(Equal(exportv1.PushSucceeded))
+
+		manifests := string(uploader.objects["default/export/manifests/all.yaml"])
+		Expect(manifests).To(ContainSubstring("kind: VirtualMachine"))
+		Expect(manifests).To(ContainSubstring("kind: DataVolume"))
+		Expect(manifests).To(ContainSubstring("url: https://minio.invalid/bucket/default/export/disk-pvc/disk.img.gz"))
+		Expect(manifests).To(ContainSubstring("url: https://minio.invalid/bucket/default/export/data-pvc/disk.tar.gz"))
+		Expect(strings.Count(manifests, "secretRef: s3-credentials")).To(Equal(2))
+		Expect(manifests).ToNot(ContainSubstring("http:"))
+
+		checksums := string(uploader.objects["default/export/SHA256SUMS"])
+		Expect(checksums).To(ContainSubstring(fmt.Sprintf("%s  manifests/all.yaml\n", sha256Hex([]byte(manifests)))))
+		Expect(p.getStatus().Manifests).To(HaveLen(2))
+		Expect(p.getStatus().Manifests[0].Name).To(Equal(allManifestsName))
+	})
+
+	It("should only import DataVolumes from the volume of the same name", func() {
+		p := newTestPusher(exportv1.KubeVirtGz, VolumeInfo{Path: diskDir, RawURI: "/volumes/disk-pvc/disk.img"})
+
+		Expect(p.dataVolumeSource("disk-pvc")).ToNot(BeNil())
+		Expect(p.dataVolumeSource("disk")).To(BeNil())
+		Expect(p.dataVolumeSource("root-disk-pvc")).To(BeNil())
+	})
+
+	It("should push the other volumes but no manifests if a volume fails", func() {
+		uploader.failing["default/export/disk-pvc/disk.img.gz"] = true
+		p := newTestPusher("", VolumeInfo{Path: diskDir, RawURI: "/volumes/disk-pvc/disk.img"}, VolumeInfo{Path: archiveDir})
+		p.run(context.Background())
+
+		status := p.getStatus()
+		Expect(status.Phase).To(Equal(exportv1.PushFailed))
+		Expect(status.Message).To(Equal("failed to push 1 of 2 volumes"))
+		Expect(status.Volumes[0].Phase).To(Equal(exportv1.PushFailed))
+		Expect(status.Volumes[0].Message).To(Equal("upload of default/export/disk-pvc/disk.img.gz failed"))
+		Expect(status.Volumes[1].Phase).To(Equal(exportv1.PushSucceeded))
+		Expect(status.Manifests).To(BeEmpty())
+		Expect(uploader.objects).To(HaveLen(1))
+	})
+
+	It("should fail if a manifest fails", func() {
+		uploader.failing["default/export/SHA256SUMS"] = true
+		p := newTestPusher("", VolumeInfo{Path: diskDir, RawURI: "/volumes/disk-pvc/disk.img"})
+		p.run(context.Background())
+
+		status := p.getStatus()
+		Expect(status.Phase).To(Equal(exportv1.PushFailed))
+		Expect(status.Message).To(Equal("upload of default/export/SHA256SUMS failed"))
+		Expect(status.Manifests[0].Phase).To(Equal(exportv1.PushFailed))
+	})
+
+	It("should serve the status", func() {
+		p := newTestPusher("", VolumeInfo{Path: diskDir, RawURI: "/volumes/disk-pvc/disk.img"})
+		p.run(context.Background())
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "https://test.blah.invalid/push/status", nil)
+		Expect(err).ToNot(HaveOccurred())
+		p.statusHandler().ServeHTTP(resp, req)
+		Expect(resp.Code).To(Equal(http.StatusOK))
+
+		status := &exportv1.VirtualMachineExportPushStatus{}
+		Expect(json.Unmarshal(resp.Body.Bytes(), status)).To(Succeed())
+		Expect(status.Phase).To(Equal(exportv1.PushSucceeded))
+		Expect(status.Volumes).To(Equal(p.getStatus().Volumes))
+		Expect(status.Manifests).To(Equal(p.getStatus().Manifests))
+	})
+
+	It("should reject non GET status requests", func() {
+		p := newTestPusher("", VolumeInfo{Path: diskDir, RawURI: "/volumes/disk-pvc/disk.img"})
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "https://test.blah.invalid/push/status", nil)
+		Expect(err).ToNot(HaveOccurred())
+		p.statusHandler().ServeHTTP(resp, req)
+		Expect(resp.Code).To(Equal(http.StatusBadRequest))
+	})
+})
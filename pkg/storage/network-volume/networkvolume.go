@@ -0,0 +1,197 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package networkvolume
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/config"
+)
+
+const (
+	// ISCSIUsernameKey and ISCSIPasswordKey hold the CHAP credentials, as in Secrets of type kubernetes.io/iscsi-chap
+	ISCSIUsernameKey = "node.session.auth.username"
+	ISCSIPasswordKey = "node.session.auth.password"
+	// RBDKeyKey holds the base64 encoded cephx key, as in Secrets of type kubernetes.io/rbd
+	RBDKeyKey = "key"
+
+	DefaultNBDPort   = "10809"
+	DefaultISCSIPort = "3260"
+	DefaultRBDPort   = "6789"
+	DefaultRBDPool   = "rbd"
+	DefaultRBDUser   = "admin"
+)
+
+// Credentials are the username and the secret value libvirt authenticates with
+type Credentials struct {
+	Username string
+	Secret   []byte
+}
+
+// IsNetworkVolume returns true for the volumes which qemu connects to over the network
+func IsNetworkVolume(volume *v1.Volume) bool {
+	return volume.NBD != nil || volume.ISCSI != nil || volume.RBD != nil
+}
+
+// SecretRef returns the Secret holding the credentials of the volume, if any
+func SecretRef(volume *v1.Volume) *k8sv1.LocalObjectReference {
+	switch {
+	case volume.ISCSI != nil:
+		return volume.ISCSI.CHAPSecretRef
+	case volume.RBD != nil:
+		return volume.RBD.KeyringSecretRef
+	}
+	return nil
+}
+
+// SecretVolumeName returns the name of the pod volume carrying the credentials of the volume
+func SecretVolumeName(volumeName string) string {
+	return volumeName + "-auth"
+}
+
+// SecretDir returns the directory the credentials Secret of the volume is mounted to
+func SecretDir(volumeName string) string {
+	return filepath.Join(config.SecretSourceDir, SecretVolumeName(volumeName))
+}
+
+// SecretUUID returns the UUID of the libvirt secret holding the credentials of the volume.
+// It is stable for a VMI, so that migration targets define the same secret.
+func SecretUUID(vmiUID types.UID, volumeName string) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(string(vmiUID)+"/"+volumeName+"/auth")).String()
+}
+
+// SecretKeys returns the keys of the credentials Secret which are used by the volume
+func SecretKeys(volume *v1.Volume) []string {
+	switch {
+	case volume.ISCSI != nil:
+		return []string{ISCSIUsernameKey, ISCSIPasswordKey}
+	case volume.RBD != nil:
+		return []string{RBDKeyKey}
+	}
+	return nil
+}
+
+// ReadCredentials reads the credentials of the volume from the directory its Secret is mounted to
+func ReadCredentials(dir string, volume *v1.Volume) (*Credentials, error) {
+	read := func(key string) (string, error) {
+		value, err := os.ReadFile(filepath.Join(dir, key))
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s of volume %s: %v", key, volume.Name, err)
+		}
+		return strings.TrimSpace(string(value)), nil
+	}
+
+	switch {
+	case volume.ISCSI != nil:
+		username, err := read(ISCSIUsernameKey)
+		if err != nil {
+			return nil, err
+		}
+		password, err := read(ISCSIPasswordKey)
+		if err != nil {
+			return nil, err
+		}
+		return &Credentials{Username: username, Secret: []byte(password)}, nil
+	case volume.RBD != nil:
+		encoded, err := read(RBDKeyKey)
+		if err != nil {
+			return nil, err
+		}
+		// libvirt expects the raw key, the keyring holds it base64 encoded
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("the cephx key of volume %s is not base64 encoded: %v", volume.Name, err)
+		}
+		return &Credentials{Username: RBDUser(volume.RBD), Secret: key}, nil
+	}
+	return nil, fmt.Errorf("volume %s has no credentials", volume.Name)
+}
+
+// RBDUser returns the Ceph user of the volume
+func RBDUser(rbd *v1.RBDVolumeSource) string {
+	if rbd.User != "" {
+		return rbd.User
+	}
+	return DefaultRBDUser
+}
+
+// RBDPool returns the Ceph pool of the volume
+func RBDPool(rbd *v1.RBDVolumeSource) string {
+	if rbd.Pool != "" {
+		return rbd.Pool
+	}
+	return DefaultRBDPool
+}
+
+// SplitHostPort splits an address into host and port, falling back to the default port
+func SplitHostPort(address, defaultPort string) (string, string, error) {
+	if address == "" {
+		return "", "", fmt.Errorf("empty address")
+	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		// no port, IPv6 addresses without port are expected in brackets
+		host, port = strings.TrimSuffix(strings.TrimPrefix(address, "["), "]"), defaultPort
+		if strings.ContainsAny(host, "[]/") || (strings.Contains(host, ":") && net.ParseIP(host) == nil) {
+			return "", "", fmt.Errorf("invalid address %q", address)
+		}
+		return host, port, nil
+	}
+	if host == "" || port == "" {
+		return "", "", fmt.Errorf("invalid address %q", address)
+	}
+	return host, port, nil
+}
+
+// ParseNBDURI parses an nbd://host[:port][/export] URI
+func ParseNBDURI(uri string) (host, port, export string, err error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid NBD URI %q: %v", uri, err)
+	}
+	if u.Scheme != "nbd" {
+		return "", "", "", fmt.Errorf("invalid NBD URI %q: only the nbd scheme is supported", uri)
+	}
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return "", "", "", fmt.Errorf("invalid NBD URI %q: credentials, queries and fragments are not supported", uri)
+	}
+	host, port, err = SplitHostPort(u.Host, DefaultNBDPort)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid NBD URI %q: %v", uri, err)
+	}
+	return host, port, strings.TrimPrefix(u.Path, "/"), nil
+}
+
+// ReadVolumeCredentials reads the credentials of the volume from its mounted Secret
+func ReadVolumeCredentials(volume *v1.Volume) (*Credentials, error) {
+	return ReadCredentials(SecretDir(volume.Name), volume)
+}
@@ -0,0 +1,255 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	snapshotv1 "kubevirt.io/api/snapshot/v1alpha1"
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	snapshotHookFailedEvent = "SnapshotHookFailed"
+
+	defaultSnapshotHookTimeoutSeconds = 30
+
+	// the hook output is kept in the snapshot status, so it is truncated
+	maxSnapshotHookOutputLength = 4096
+)
+
+func snapshotHooks(vmSnapshot *snapshotv1.VirtualMachineSnapshot, hookType snapshotv1.SnapshotHookType) []snapshotv1.SnapshotHook {
+	if vmSnapshot == nil || vmSnapshot.Spec.Hooks == nil {
+		return nil
+	}
+
+	if hookType == snapshotv1.PreFreezeHook {
+		return vmSnapshot.Spec.Hooks.PreFreeze
+	}
+	return vmSnapshot.Spec.Hooks.PostThaw
+}
+
+func snapshotHookTimeoutSeconds(hook *snapshotv1.SnapshotHook) int32 {
+	if hook.TimeoutSeconds == nil {
+		return defaultSnapshotHookTimeoutSeconds
+	}
+	return *hook.TimeoutSeconds
+}
+
+// preFreezeHookTimeoutSeconds caps the timeout of a pre-freeze hook by the time left
+// until the failure deadline of the snapshot, so that the hooks cannot delay freezing past it.
+// Post-thaw hooks are not capped, they restore the guest even after the deadline.
+func preFreezeHookTimeoutSeconds(vmSnapshot *snapshotv1.VirtualMachineSnapshot, hook *snapshotv1.SnapshotHook) int32 {
+	timeoutSeconds := snapshotHookTimeoutSeconds(hook)
+	if getFailureDeadline(vmSnapshot) == 0 {
+		return timeoutSeconds
+	}
+	if remaining := int32(timeUntilDeadline(vmSnapshot) / time.Second); remaining < timeoutSeconds {
+		return remaining
+	}
+	return timeoutSeconds
+}
+
+func snapshotHookFailurePolicy(hook *snapshotv1.SnapshotHook) snapshotv1.SnapshotHookFailurePolicy {
+	if hook.FailurePolicy == nil {
+		return snapshotv1.SnapshotHookFailurePolicyAbort
+	}
+	return *hook.FailurePolicy
+}
+
+func findSnapshotHookResult(vmSnapshot *snapshotv1.VirtualMachineSnapshot, hookType snapshotv1.SnapshotHookType, name string) *snapshotv1.SnapshotHookResult {
+	if vmSnapshot == nil || vmSnapshot.Status == nil {
+		return nil
+	}
+
+	for i, result := range vmSnapshot.Status.HookResults {
+		if result.Type == hookType && result.Name == name {
+			return &vmSnapshot.Status.HookResults[i]
+		}
+	}
+	return nil
+}
+
+func snapshotHooksStarted(vmSnapshot *snapshotv1.VirtualMachineSnapshot, hookType snapshotv1.SnapshotHookType) bool {
+	for _, hook := range snapshotHooks(vmSnapshot, hookType) {
+		if findSnapshotHookResult(vmSnapshot, hookType, hook.Name) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// abortingSnapshotHookResult returns the result of the first failed hook
+// whose failure policy aborts the snapshot
+func abortingSnapshotHookResult(vmSnapshot *snapshotv1.VirtualMachineSnapshot) *snapshotv1.SnapshotHookResult {
+	for _, hookType := range []snapshotv1.SnapshotHookType{snapshotv1.PreFreezeHook, snapshotv1.PostThawHook} {
+		for _, hook := range snapshotHooks(vmSnapshot, hookType) {
+			result := findSnapshotHookResult(vmSnapshot, hookType, hook.Name)
+			if result != nil && !result.Succeeded &&
+				snapshotHookFailurePolicy(&hook) == snapshotv1.SnapshotHookFailurePolicyAbort {
+				return result
+			}
+		}
+	}
+	return nil
+}
+
+func snapshotHookFailureMessage(result *snapshotv1.SnapshotHookResult) string {
+	reason := ""
+	if result.Error != nil {
+		reason = *result.Error
+	} else if result.ExitCode != nil {
+		reason = fmt.Sprintf("exited with code %d", *result.ExitCode)
+	}
+	return fmt.Sprintf("%s hook %s failed: %s", result.Type, result.Name, reason)
+}
+
+func truncateSnapshotHookOutput(output string) string {
+	if len(output) <= maxSnapshotHookOutputLength {
+		return output
+	}
+	return output[:maxSnapshotHookOutputLength]
+}
+
+// runSnapshotHooks executes the hooks of the given type in the guest, in order,
+// and records their results in the snapshot status.
+// Hooks which already have a result are not executed again.
+// It returns whether a failed hook aborted the snapshot, in which case the snapshot
+// is marked as failed and the remaining pre-freeze hooks are skipped.
+func (ctrl *VMSnapshotController) runSnapshotHooks(vmSnapshot *snapshotv1.VirtualMachineSnapshot, source snapshotSource, hookType snapshotv1.SnapshotHookType) (bool, error) {
+	if len(snapshotHooks(vmSnapshot, hookType)) == 0 || source == nil {
+		return false, nil
+	}
+
+	online, err := source.Online()
+	if err != nil || !online {
+		return false, err
+	}
+
+	guestAgent, err := source.GuestAgent()
+	if err != nil {
+		return false, err
+	}
+
+	// the cached snapshot may miss results recorded moments ago
+	// and hooks must not be executed twice
+	vmSnapshot, err = ctrl.Client.VirtualMachineSnapshot(vmSnapshot.Namespace).Get(context.Background(), vmSnapshot.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	aborted := false
+	for _, hook := range snapshotHooks(vmSnapshot, hookType) {
+		if aborted && hookType == snapshotv1.PreFreezeHook {
+			break
+		}
+
+		if result := findSnapshotHookResult(vmSnapshot, hookType, hook.Name); result != nil {
+			if !result.Succeeded && snapshotHookFailurePolicy(&hook) == snapshotv1.SnapshotHookFailurePolicyAbort {
+				aborted = true
+			}
+			continue
+		}
+
+		result := snapshotv1.SnapshotHookResult{
+			Name: hook.Name,
+			Type: hookType,
+		}
+		timeoutSeconds := snapshotHookTimeoutSeconds(&hook)
+		if hookType == snapshotv1.PreFreezeHook {
+			timeoutSeconds = preFreezeHookTimeoutSeconds(vmSnapshot, &hook)
+		}
+		switch {
+		case !guestAgent:
+			msg := "guest agent is not connected"
+			result.Error = &msg
+			result.CompletionTime = currentTime()
+		case timeoutSeconds <= 0:
+			msg := "failure deadline of the snapshot exceeded"
+			result.Error = &msg
+			result.CompletionTime = currentTime()
+		default:
+			ctrl.execSnapshotHook(source, &hook, timeoutSeconds, &result)
+		}
+
+		vmSnapshotCpy := vmSnapshot.DeepCopy()
+		if vmSnapshotCpy.Status == nil {
+			vmSnapshotCpy.Status = &snapshotv1.VirtualMachineSnapshotStatus{}
+		}
+		vmSnapshotCpy.Status.HookResults = append(vmSnapshotCpy.Status.HookResults, result)
+
+		if !result.Succeeded {
+			msg := snapshotHookFailureMessage(&result)
+			ctrl.Recorder.Event(vmSnapshot, corev1.EventTypeWarning, snapshotHookFailedEvent, msg)
+
+			if snapshotHookFailurePolicy(&hook) == snapshotv1.SnapshotHookFailurePolicyAbort {
+				aborted = true
+				if !vmSnapshotFailed(vmSnapshotCpy) {
+					vmSnapshotCpy.Status.Phase = snapshotv1.Failed
+					vmSnapshotCpy.Status.Error = &snapshotv1.Error{
+						Time:    currentTime(),
+						Message: &msg,
+					}
+					updateSnapshotCondition(vmSnapshotCpy, newProgressingCondition(corev1.ConditionFalse, msg))
+					updateSnapshotCondition(vmSnapshotCpy, newFailureCondition(corev1.ConditionTrue, msg))
+				}
+			}
+		}
+
+		// record every result right away so that no hook is executed twice
+		vmSnapshot, err = ctrl.Client.VirtualMachineSnapshot(vmSnapshotCpy.Namespace).Update(context.Background(), vmSnapshotCpy, metav1.UpdateOptions{})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return aborted, nil
+}
+
+func (ctrl *VMSnapshotController) execSnapshotHook(source snapshotSource, hook *snapshotv1.SnapshotHook, timeoutSeconds int32, result *snapshotv1.SnapshotHookResult) {
+	defer func() {
+		result.CompletionTime = currentTime()
+	}()
+
+	if len(hook.Command) == 0 {
+		msg := "command is empty"
+		result.Error = &msg
+		return
+	}
+
+	log.Log.V(3).Infof("Executing %s hook %s: %s", result.Type, hook.Name, strings.Join(hook.Command, " "))
+	execResult, err := source.Exec(hook.Command, timeoutSeconds)
+	if err != nil {
+		msg := err.Error()
+		result.Error = &msg
+		return
+	}
+
+	exitCode := execResult.ExitCode
+	result.ExitCode = &exitCode
+	result.Output = truncateSnapshotHookOutput(execResult.StdOut)
+	result.Succeeded = exitCode == 0
+}
@@ -244,7 +244,7 @@ func (ctrl *VMRestoreController) reconcileVolumeRestores(vmRestore *snapshotv1.V
 		return false, err
 	}
 
-	noRestore := volumesNotForRestore(content)
+	noRestore := volumesNotForRestore(vmRestore, content)
 
 	var restores []snapshotv1.VolumeRestore
 	for _, vb := range content.Spec.VolumeBackups {
@@ -431,6 +431,8 @@ func (t *vmRestoreTarget) reconcileSpec() (bool, error) {
 	var newTemplates = make([]kubevirtv1.DataVolumeTemplateSpec, len(snapshotVM.Spec.DataVolumeTemplates))
 	var newVolumes []kubevirtv1.Volume
 	var deletedDataVolumes []string
+	var keptTemplates []kubevirtv1.DataVolumeTemplateSpec
+	replacedTemplates := sets.NewString()
 	updatedStatus := false
 
 	for i, t := range snapshotVM.Spec.DataVolumeTemplates {
@@ -438,8 +440,22 @@ func (t *vmRestoreTarget) reconcileSpec() (bool, error) {
 	}
 
 	for _, v := range snapshotVM.Spec.Template.Spec.Volumes {
+		if currentVolume := t.unrestoredVolume(v.Name); currentVolume != nil {
+			// the volume is not restored, the target keeps its current one
+			if v.DataVolume != nil {
+				replacedTemplates.Insert(v.DataVolume.Name)
+			}
+			if currentVolume.DataVolume != nil {
+				if dvt := getDataVolumeTemplate(t.vm, currentVolume.DataVolume.Name); dvt != nil {
+					keptTemplates = append(keptTemplates, *dvt.DeepCopy())
+				}
+			}
+			newVolumes = append(newVolumes, *currentVolume.DeepCopy())
+			continue
+		}
+
 		nv := v.DeepCopy()
-		if nv.DataVolume != nil || nv.PersistentVolumeClaim != nil {
+		if nv.DataVolume != nil || nv.PersistentVolumeClaim != nil || nv.Overlay != nil {
 			for k := range t.vmRestore.Status.Restores {
 				vr := &t.vmRestore.Status.Restores[k]
 				if vr.VolumeName != nv.Name {
@@ -504,6 +520,9 @@ func (t *vmRestoreTarget) reconcileSpec() (bool, error) {
 							},
 						}
 					}
+				} else if nv.Overlay != nil {
+					// the base claim is shared by the overlays, only the overlay claim is restored
+					nv.Overlay.ClaimName = vr.PersistentVolumeClaimName
 				} else {
 					nv.PersistentVolumeClaim.ClaimName = vr.PersistentVolumeClaimName
 				}
@@ -515,6 +534,10 @@ func (t *vmRestoreTarget) reconcileSpec() (bool, error) {
 		newVolumes = append(newVolumes, *nv)
 	}
 
+	if len(replacedTemplates) > 0 || len(keptTemplates) > 0 {
+		newTemplates = mergeDataVolumeTemplates(newTemplates, replacedTemplates, keptTemplates)
+	}
+
 	if t.doesTargetVMExist() && updatedStatus {
 		// find DataVolumes that will no longer exist
 		for _, cdv := range t.vm.Spec.DataVolumeTemplates {
@@ -590,6 +613,52 @@ func (t *vmRestoreTarget) reconcileSpec() (bool, error) {
 	return true, nil
 }
 
+// unrestoredVolume returns the volume of the existing target which replaces
+// the volume of the snapshot when it is not selected for the restore
+func (t *vmRestoreTarget) unrestoredVolume(name string) *kubevirtv1.Volume {
+	if !t.doesTargetVMExist() ||
+		volumeSelected(name, t.vmRestore.Spec.IncludedVolumes, t.vmRestore.Spec.ExcludedVolumes) {
+		return nil
+	}
+
+	for i, volume := range t.vm.Spec.Template.Spec.Volumes {
+		if volume.Name == name {
+			return &t.vm.Spec.Template.Spec.Volumes[i]
+		}
+	}
+	return nil
+}
+
+func getDataVolumeTemplate(vm *kubevirtv1.VirtualMachine, name string) *kubevirtv1.DataVolumeTemplateSpec {
+	for i, dvt := range vm.Spec.DataVolumeTemplates {
+		if dvt.Name == name {
+			return &vm.Spec.DataVolumeTemplates[i]
+		}
+	}
+	return nil
+}
+
+// mergeDataVolumeTemplates replaces the templates of the snapshot volumes
+// which are not restored with the templates of the current target volumes
+func mergeDataVolumeTemplates(templates []kubevirtv1.DataVolumeTemplateSpec, replaced sets.String, kept []kubevirtv1.DataVolumeTemplateSpec) []kubevirtv1.DataVolumeTemplateSpec {
+	var merged []kubevirtv1.DataVolumeTemplateSpec
+	names := sets.NewString()
+	for _, dvt := range templates {
+		if !replaced.Has(dvt.Name) {
+			merged = append(merged, dvt)
+			names.Insert(dvt.Name)
+		}
+	}
+
+	for _, dvt := range kept {
+		if !names.Has(dvt.Name) {
+			merged = append(merged, dvt)
+			names.Insert(dvt.Name)
+		}
+	}
+	return merged
+}
+
 func (t *vmRestoreTarget) reconcileDataVolumes() (bool, error) {
 	createdDV := false
 	waitingDV := false
@@ -1074,13 +1143,14 @@ func updateRestoreCondition(r *snapshotv1.VirtualMachineRestore, c snapshotv1.Co
 }
 
 // Returns a set of volumes not for restore
-// Currently only memory dump volumes should not be restored
-func volumesNotForRestore(content *snapshotv1.VirtualMachineSnapshotContent) sets.String {
+// Memory dump volumes and the volumes not selected by the restore are not restored
+func volumesNotForRestore(vmRestore *snapshotv1.VirtualMachineRestore, content *snapshotv1.VirtualMachineSnapshotContent) sets.String {
 	volumes := content.Spec.Source.VirtualMachine.Spec.Template.Spec.Volumes
 	noRestore := sets.NewString()
 
 	for _, volume := range volumes {
-		if volume.MemoryDump != nil {
+		if volume.MemoryDump != nil ||
+			!volumeSelected(volume.Name, vmRestore.Spec.IncludedVolumes, vmRestore.Spec.ExcludedVolumes) {
 			noRestore.Insert(volume.Name)
 		}
 	}
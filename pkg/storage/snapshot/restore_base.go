@@ -46,6 +46,9 @@ type VMRestoreController struct {
 	Client kubecli.KubevirtClient
 
 	VMRestoreInformer         cache.SharedIndexInformer
+	VMRestoreGroupInformer    cache.SharedIndexInformer
+	VMSnapshotGroupInformer   cache.SharedIndexInformer
+	VMSnapshotMountInformer   cache.SharedIndexInformer
 	VMSnapshotInformer        cache.SharedIndexInformer
 	VMSnapshotContentInformer cache.SharedIndexInformer
 	VMInformer                cache.SharedIndexInformer
@@ -59,7 +62,9 @@ type VMRestoreController struct {
 
 	Recorder record.EventRecorder
 
-	vmRestoreQueue workqueue.RateLimitingInterface
+	vmRestoreQueue       workqueue.RateLimitingInterface
+	vmRestoreGroupQueue  workqueue.RateLimitingInterface
+	vmSnapshotMountQueue workqueue.RateLimitingInterface
 
 	vmStatusUpdater *status.VMStatusUpdater
 }
@@ -67,6 +72,8 @@ type VMRestoreController struct {
 // Init initializes the restore controller
 func (ctrl *VMRestoreController) Init() error {
 	ctrl.vmRestoreQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-restore-vmrestore")
+	ctrl.vmRestoreGroupQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-restore-vmrestoregroup")
+	ctrl.vmSnapshotMountQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-restore-vmsnapshotmount")
 
 	_, err := ctrl.VMRestoreInformer.AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
@@ -79,6 +86,37 @@ func (ctrl *VMRestoreController) Init() error {
 		return err
 	}
 
+	_, err = ctrl.VMRestoreGroupInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMRestoreGroup,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMRestoreGroup(newObj) },
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = ctrl.VMSnapshotMountInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMSnapshotMount,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMSnapshotMount(newObj) },
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = ctrl.VMIInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMI,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMI(newObj) },
+			DeleteFunc: ctrl.handleVMI,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
 	_, err = ctrl.DataVolumeInformer.AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
 			AddFunc:    ctrl.handleDataVolume,
@@ -117,6 +155,8 @@ func (ctrl *VMRestoreController) Init() error {
 func (ctrl *VMRestoreController) Run(threadiness int, stopCh <-chan struct{}) error {
 	defer utilruntime.HandleCrash()
 	defer ctrl.vmRestoreQueue.ShutDown()
+	defer ctrl.vmRestoreGroupQueue.ShutDown()
+	defer ctrl.vmSnapshotMountQueue.ShutDown()
 
 	log.Log.Info("Starting restore controller.")
 	defer log.Log.Info("Shutting down restore controller.")
@@ -124,6 +164,9 @@ func (ctrl *VMRestoreController) Run(threadiness int, stopCh <-chan struct{}) er
 	if !cache.WaitForCacheSync(
 		stopCh,
 		ctrl.VMRestoreInformer.HasSynced,
+		ctrl.VMRestoreGroupInformer.HasSynced,
+		ctrl.VMSnapshotGroupInformer.HasSynced,
+		ctrl.VMSnapshotMountInformer.HasSynced,
 		ctrl.VMSnapshotInformer.HasSynced,
 		ctrl.VMSnapshotContentInformer.HasSynced,
 		ctrl.VMInformer.HasSynced,
@@ -136,6 +179,8 @@ func (ctrl *VMRestoreController) Run(threadiness int, stopCh <-chan struct{}) er
 
 	for i := 0; i < threadiness; i++ {
 		go wait.Until(ctrl.vmRestoreWorker, time.Second, stopCh)
+		go wait.Until(ctrl.vmRestoreGroupWorker, time.Second, stopCh)
+		go wait.Until(ctrl.vmSnapshotMountWorker, time.Second, stopCh)
 	}
 
 	<-stopCh
@@ -148,6 +193,16 @@ func (ctrl *VMRestoreController) vmRestoreWorker() {
 	}
 }
 
+func (ctrl *VMRestoreController) vmRestoreGroupWorker() {
+	for ctrl.processVMRestoreGroupWorkItem() {
+	}
+}
+
+func (ctrl *VMRestoreController) vmSnapshotMountWorker() {
+	for ctrl.processVMSnapshotMountWorkItem() {
+	}
+}
+
 func (ctrl *VMRestoreController) processVMRestoreWorkItem() bool {
 	return watchutil.ProcessWorkItem(ctrl.vmRestoreQueue, func(key string) (time.Duration, error) {
 		log.Log.V(3).Infof("vmRestore worker processing key [%s]", key)
@@ -166,6 +221,42 @@ func (ctrl *VMRestoreController) processVMRestoreWorkItem() bool {
 	})
 }
 
+func (ctrl *VMRestoreController) processVMRestoreGroupWorkItem() bool {
+	return watchutil.ProcessWorkItem(ctrl.vmRestoreGroupQueue, func(key string) (time.Duration, error) {
+		log.Log.V(3).Infof("vmRestoreGroup worker processing key [%s]", key)
+
+		storeObj, exists, err := ctrl.VMRestoreGroupInformer.GetStore().GetByKey(key)
+		if !exists || err != nil {
+			return 0, err
+		}
+
+		group, ok := storeObj.(*snapshotv1.VirtualMachineRestoreGroup)
+		if !ok {
+			return 0, fmt.Errorf("unexpected resource %+v", storeObj)
+		}
+
+		return ctrl.updateVMRestoreGroup(group.DeepCopy())
+	})
+}
+
+func (ctrl *VMRestoreController) processVMSnapshotMountWorkItem() bool {
+	return watchutil.ProcessWorkItem(ctrl.vmSnapshotMountQueue, func(key string) (time.Duration, error) {
+		log.Log.V(3).Infof("vmSnapshotMount worker processing key [%s]", key)
+
+		storeObj, exists, err := ctrl.VMSnapshotMountInformer.GetStore().GetByKey(key)
+		if !exists || err != nil {
+			return 0, err
+		}
+
+		mount, ok := storeObj.(*snapshotv1.VirtualMachineSnapshotMount)
+		if !ok {
+			return 0, fmt.Errorf("unexpected resource %+v", storeObj)
+		}
+
+		return ctrl.updateVMSnapshotMount(mount.DeepCopy())
+	})
+}
+
 func (ctrl *VMRestoreController) handleVMRestore(obj interface{}) {
 	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
 		obj = unknown.Obj
@@ -180,6 +271,44 @@ func (ctrl *VMRestoreController) handleVMRestore(obj interface{}) {
 
 		log.Log.V(3).Infof("enqueued %q for sync", objName)
 		ctrl.vmRestoreQueue.Add(objName)
+
+		if groupName, ok := vmRestore.Labels[vmRestoreGroupLabel]; ok {
+			ctrl.vmRestoreGroupQueue.Add(cacheKeyFunc(vmRestore.Namespace, groupName))
+		}
+	}
+}
+
+func (ctrl *VMRestoreController) handleVMRestoreGroup(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if group, ok := obj.(*snapshotv1.VirtualMachineRestoreGroup); ok {
+		objName, err := cache.DeletionHandlingMetaNamespaceKeyFunc(group)
+		if err != nil {
+			log.Log.Errorf("failed to get key from object: %v, %v", err, group)
+			return
+		}
+
+		log.Log.V(3).Infof("enqueued %q for sync", objName)
+		ctrl.vmRestoreGroupQueue.Add(objName)
+	}
+}
+
+func (ctrl *VMRestoreController) handleVMSnapshotMount(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if mount, ok := obj.(*snapshotv1.VirtualMachineSnapshotMount); ok {
+		objName, err := cache.DeletionHandlingMetaNamespaceKeyFunc(mount)
+		if err != nil {
+			log.Log.Errorf("failed to get key from object: %v, %v", err, mount)
+			return
+		}
+
+		log.Log.V(3).Infof("enqueued %q for sync", objName)
+		ctrl.vmSnapshotMountQueue.Add(objName)
 	}
 }
 
@@ -237,3 +366,22 @@ func (ctrl *VMRestoreController) handleVM(obj interface{}) {
 		}
 	}
 }
+
+func (ctrl *VMRestoreController) handleVMI(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vmi, ok := obj.(*kubevirtv1.VirtualMachineInstance); ok {
+		k, _ := cache.MetaNamespaceKeyFunc(vmi)
+		keys, err := ctrl.VMSnapshotMountInformer.GetIndexer().IndexKeys("vm", k)
+		if err != nil {
+			utilruntime.HandleError(err)
+			return
+		}
+
+		for _, k := range keys {
+			ctrl.vmSnapshotMountQueue.Add(k)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1alpha1"
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	vmRestoreGroupLabel = "restore.kubevirt.io/restore-group"
+
+	restoreGroupCompleteEvent = "VirtualMachineRestoreGroupComplete"
+
+	restoreGroupRetryInterval = 5 * time.Second
+)
+
+func vmRestoreGroupProgressing(group *snapshotv1.VirtualMachineRestoreGroup) bool {
+	return group.Status == nil || group.Status.Complete == nil || !*group.Status.Complete
+}
+
+func vmRestoreGroupMemberName(group *snapshotv1.VirtualMachineRestoreGroup, vmName string) string {
+	return fmt.Sprintf("%s-%s", group.Name, vmName)
+}
+
+func (ctrl *VMRestoreController) updateVMRestoreGroup(groupIn *snapshotv1.VirtualMachineRestoreGroup) (time.Duration, error) {
+	logger := log.Log.Object(groupIn)
+	logger.V(1).Infof("Updating VirtualMachineRestoreGroup")
+
+	if !vmRestoreGroupProgressing(groupIn) {
+		return 0, nil
+	}
+
+	groupOut := groupIn.DeepCopy()
+	if groupOut.Status == nil {
+		f := false
+		groupOut.Status = &snapshotv1.VirtualMachineRestoreGroupStatus{
+			Complete: &f,
+		}
+	}
+
+	var retry time.Duration
+	var err error
+	if len(groupOut.Status.VirtualMachineRestores) == 0 {
+		retry, err = ctrl.startVMRestoreGroup(groupOut)
+	} else {
+		retry, err = ctrl.updateVMRestoreGroupStatus(groupOut)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if !equality.Semantic.DeepEqual(groupIn, groupOut) {
+		if _, err := ctrl.Client.VirtualMachineRestoreGroup(groupOut.Namespace).Update(context.Background(), groupOut, metav1.UpdateOptions{}); err != nil {
+			return 0, err
+		}
+	}
+
+	return retry, nil
+}
+
+// startVMRestoreGroup creates a VirtualMachineRestore for every VM of the
+// snapshot group, but only once none of the VMs is running so that they
+// are all restored together
+func (ctrl *VMRestoreController) startVMRestoreGroup(group *snapshotv1.VirtualMachineRestoreGroup) (time.Duration, error) {
+	snapshotGroup, err := ctrl.getVMSnapshotGroup(group.Namespace, group.Spec.VirtualMachineSnapshotGroupName)
+	if err != nil {
+		return 0, err
+	}
+
+	if snapshotGroup == nil {
+		waitVMRestoreGroup(group, fmt.Sprintf("VirtualMachineSnapshotGroup %s does not exist", group.Spec.VirtualMachineSnapshotGroupName))
+		return restoreGroupRetryInterval, nil
+	}
+
+	if !vmSnapshotGroupSucceeded(snapshotGroup) {
+		waitVMRestoreGroup(group, fmt.Sprintf("VirtualMachineSnapshotGroup %s not ready", snapshotGroup.Name))
+		return restoreGroupRetryInterval, nil
+	}
+
+	var running []string
+	for _, member := range snapshotGroup.Status.VirtualMachineSnapshots {
+		isRunning, err := ctrl.vmRunning(group.Namespace, member.VirtualMachineName)
+		if err != nil {
+			return 0, err
+		}
+		if isRunning {
+			running = append(running, member.VirtualMachineName)
+		}
+	}
+
+	if len(running) > 0 {
+		waitVMRestoreGroup(group, fmt.Sprintf("Waiting for VirtualMachines (%s) to stop", strings.Join(running, ",")))
+		return restoreGroupRetryInterval, nil
+	}
+
+	var members []snapshotv1.VirtualMachineRestoreGroupMember
+	for _, snapshotMember := range snapshotGroup.Status.VirtualMachineSnapshots {
+		member := snapshotv1.VirtualMachineRestoreGroupMember{
+			VirtualMachineName:        snapshotMember.VirtualMachineName,
+			VirtualMachineRestoreName: vmRestoreGroupMemberName(group, snapshotMember.VirtualMachineName),
+		}
+
+		if err := ctrl.createVMRestoreGroupMember(group, member, snapshotMember.VirtualMachineSnapshotName); err != nil {
+			return 0, err
+		}
+
+		members = append(members, member)
+	}
+
+	group.Status.VirtualMachineRestores = members
+	reason := fmt.Sprintf("Restoring %d VirtualMachines", len(members))
+	updateRestoreGroupCondition(group, newProgressingCondition(corev1.ConditionTrue, reason))
+	updateRestoreGroupCondition(group, newReadyCondition(corev1.ConditionFalse, reason))
+
+	return 0, nil
+}
+
+func (ctrl *VMRestoreController) createVMRestoreGroupMember(group *snapshotv1.VirtualMachineRestoreGroup, member snapshotv1.VirtualMachineRestoreGroupMember, vmSnapshotName string) error {
+	// the restores are owned by their target VMs like any other VirtualMachineRestore
+	// so they are only linked to the group by label
+	apiGroup := kubevirtv1.SchemeGroupVersion.Group
+	vmRestore := &snapshotv1.VirtualMachineRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      member.VirtualMachineRestoreName,
+			Namespace: group.Namespace,
+			Labels: map[string]string{
+				vmRestoreGroupLabel: group.Name,
+			},
+		},
+		Spec: snapshotv1.VirtualMachineRestoreSpec{
+			Target: corev1.TypedLocalObjectReference{
+				APIGroup: &apiGroup,
+				Kind:     "VirtualMachine",
+				Name:     member.VirtualMachineName,
+			},
+			VirtualMachineSnapshotName: vmSnapshotName,
+		},
+	}
+
+	_, err := ctrl.Client.VirtualMachineRestore(group.Namespace).Create(context.Background(), vmRestore, metav1.CreateOptions{})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (ctrl *VMRestoreController) updateVMRestoreGroupStatus(group *snapshotv1.VirtualMachineRestoreGroup) (time.Duration, error) {
+	var missing []string
+	var restoreTime *metav1.Time
+	complete := true
+	for _, member := range group.Status.VirtualMachineRestores {
+		obj, exists, err := ctrl.VMRestoreInformer.GetStore().GetByKey(cacheKeyFunc(group.Namespace, member.VirtualMachineRestoreName))
+		if err != nil {
+			return 0, err
+		}
+
+		if !exists {
+			missing = append(missing, member.VirtualMachineRestoreName)
+			continue
+		}
+
+		vmRestore := obj.(*snapshotv1.VirtualMachineRestore)
+		if VmRestoreProgressing(vmRestore) {
+			complete = false
+			continue
+		}
+
+		if restoreTime == nil || (vmRestore.Status.RestoreTime != nil && restoreTime.Before(vmRestore.Status.RestoreTime)) {
+			restoreTime = vmRestore.Status.RestoreTime
+		}
+	}
+
+	if len(missing) > 0 {
+		reason := fmt.Sprintf("VirtualMachineRestores (%s) missing", strings.Join(missing, ","))
+		updateRestoreGroupCondition(group, newProgressingCondition(corev1.ConditionFalse, reason))
+		updateRestoreGroupCondition(group, newReadyCondition(corev1.ConditionFalse, reason))
+		return 0, nil
+	}
+
+	if !complete {
+		return 0, nil
+	}
+
+	ctrl.Recorder.Eventf(
+		group,
+		corev1.EventTypeNormal,
+		restoreGroupCompleteEvent,
+		"Successfully completed VirtualMachineRestoreGroup %s",
+		group.Name,
+	)
+
+	t := true
+	group.Status.Complete = &t
+	group.Status.RestoreTime = restoreTime
+	updateRestoreGroupCondition(group, newProgressingCondition(corev1.ConditionFalse, "Operation complete"))
+	updateRestoreGroupCondition(group, newReadyCondition(corev1.ConditionTrue, "Operation complete"))
+
+	return 0, nil
+}
+
+func (ctrl *VMRestoreController) vmRunning(namespace, name string) (bool, error) {
+	vm, err := ctrl.getVM(namespace, name)
+	if err != nil || vm == nil {
+		return false, err
+	}
+
+	running, err := checkVMRunning(vm)
+	if err != nil || running {
+		return running, err
+	}
+
+	_, exists, err := ctrl.VMIInformer.GetStore().GetByKey(cacheKeyFunc(namespace, name))
+	return exists, err
+}
+
+func (ctrl *VMRestoreController) getVMSnapshotGroup(namespace, name string) (*snapshotv1.VirtualMachineSnapshotGroup, error) {
+	obj, exists, err := ctrl.VMSnapshotGroupInformer.GetStore().GetByKey(cacheKeyFunc(namespace, name))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return obj.(*snapshotv1.VirtualMachineSnapshotGroup).DeepCopy(), nil
+}
+
+func waitVMRestoreGroup(group *snapshotv1.VirtualMachineRestoreGroup, reason string) {
+	updateRestoreGroupCondition(group, newProgressingCondition(corev1.ConditionFalse, reason))
+	updateRestoreGroupCondition(group, newReadyCondition(corev1.ConditionFalse, reason))
+}
+
+func updateRestoreGroupCondition(group *snapshotv1.VirtualMachineRestoreGroup, c snapshotv1.Condition) {
+	group.Status.Conditions = updateCondition(group.Status.Conditions, c, true)
+}
@@ -217,6 +217,9 @@ var _ = Describe("Restore controller", func() {
 
 		var vmRestoreSource *framework.FakeControllerSource
 		var vmRestoreInformer cache.SharedIndexInformer
+		var vmRestoreGroupInformer cache.SharedIndexInformer
+		var vmSnapshotMountInformer cache.SharedIndexInformer
+		var vmSnapshotGroupInformer cache.SharedIndexInformer
 
 		var vmSnapshotSource *framework.FakeControllerSource
 		var vmSnapshotInformer cache.SharedIndexInformer
@@ -284,6 +287,9 @@ var _ = Describe("Restore controller", func() {
 			vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
 
 			vmRestoreInformer, vmRestoreSource = testutils.NewFakeInformerWithIndexersFor(&snapshotv1.VirtualMachineRestore{}, virtcontroller.GetVirtualMachineRestoreInformerIndexers())
+			vmRestoreGroupInformer, _ = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineRestoreGroup{})
+			vmSnapshotMountInformer, _ = testutils.NewFakeInformerWithIndexersFor(&snapshotv1.VirtualMachineSnapshotMount{}, virtcontroller.GetVirtualMachineSnapshotMountInformerIndexers())
+			vmSnapshotGroupInformer, _ = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshotGroup{})
 			vmSnapshotInformer, vmSnapshotSource = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshot{})
 			vmSnapshotContentInformer, vmSnapshotContentSource = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshotContent{})
 			vmiInformer, vmiSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
@@ -303,6 +309,9 @@ var _ = Describe("Restore controller", func() {
 			controller = &VMRestoreController{
 				Client:                    virtClient,
 				VMRestoreInformer:         vmRestoreInformer,
+				VMRestoreGroupInformer:    vmRestoreGroupInformer,
+				VMSnapshotMountInformer:   vmSnapshotMountInformer,
+				VMSnapshotGroupInformer:   vmSnapshotGroupInformer,
 				VMSnapshotInformer:        vmSnapshotInformer,
 				VMSnapshotContentInformer: vmSnapshotContentInformer,
 				VMInformer:                vmInformer,
@@ -653,6 +662,32 @@ var _ = Describe("Restore controller", func() {
 				controller.processVMRestoreWorkItem()
 			})
 
+			It("should keep the current volumes of the VM which are not restored", func() {
+				r := createRestoreWithOwner()
+				r.Spec.ExcludedVolumes = []string{diskName}
+				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
+					Complete: &f,
+					Conditions: []snapshotv1.Condition{
+						newProgressingCondition(corev1.ConditionTrue, "Updating target spec"),
+						newReadyCondition(corev1.ConditionFalse, "Waiting for target update"),
+					},
+				}
+				vm := createModifiedVM()
+				vm.Status.RestoreInProgress = &vmRestoreName
+				vm.Spec.DataVolumeTemplates[0].Name = "current-dv"
+				vm.Spec.Template.Spec.Volumes[0].DataVolume.Name = "current-dv"
+				updatedVM := createSnapshotVM()
+				updatedVM.Status.RestoreInProgress = &vmRestoreName
+				updatedVM.ResourceVersion = "1"
+				updatedVM.Annotations = map[string]string{"restore.kubevirt.io/lastRestoreUID": "restore-uid"}
+				updatedVM.Spec.DataVolumeTemplates[0].Name = "current-dv"
+				updatedVM.Spec.Template.Spec.Volumes[0].DataVolume.Name = "current-dv"
+				vmSource.Add(vm)
+				vmInterface.EXPECT().Update(context.Background(), updatedVM).Return(updatedVM, nil)
+				addVirtualMachineRestore(r)
+				controller.processVMRestoreWorkItem()
+			})
+
 			It("should cleanup and unlock vm", func() {
 				r := createRestoreWithOwner()
 				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
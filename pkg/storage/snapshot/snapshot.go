@@ -237,8 +237,23 @@ func (ctrl *VMSnapshotController) unfreezeSource(vmSnapshot *snapshotv1.VirtualM
 		return err
 	}
 
-	if source != nil {
-		if err := source.Unfreeze(); err != nil {
+	if source == nil || !source.Locked() {
+		return nil
+	}
+
+	frozen, err := source.Frozen()
+	if err != nil {
+		return err
+	}
+
+	if err := source.Unfreeze(); err != nil {
+		return err
+	}
+
+	// post-thaw hooks also undo what the pre-freeze hooks did
+	// when the snapshot was aborted before freezing
+	if frozen || snapshotHooksStarted(vmSnapshot, snapshotv1.PreFreezeHook) {
+		if _, err := ctrl.runSnapshotHooks(vmSnapshot, source, snapshotv1.PostThawHook); err != nil {
 			return err
 		}
 	}
@@ -292,8 +307,14 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 
 	}
 
+	group, err := ctrl.getVMSnapshotGroup(vmSnapshot)
+	if err != nil {
+		return 0, err
+	}
+
 	currentlyCreated := vmSnapshotContentCreated(content)
-	currentlyError := (content.Status != nil && content.Status.Error != nil) || vmSnapshotError(vmSnapshot) != nil
+	currentlyError := (content.Status != nil && content.Status.Error != nil) || vmSnapshotError(vmSnapshot) != nil ||
+		vmSnapshotGroupFailed(group)
 
 	for _, volumeBackup := range content.Spec.VolumeBackups {
 		if volumeBackup.VolumeSnapshotName == nil {
@@ -350,7 +371,17 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 				}
 
 				if !frozen {
-					err := source.Freeze()
+					aborted, err := ctrl.runSnapshotHooks(vmSnapshot, source, snapshotv1.PreFreezeHook)
+					if err != nil {
+						return 0, err
+					}
+
+					if aborted {
+						// the failed snapshot is cleaned up once it is reconciled
+						return 0, nil
+					}
+
+					err = source.Freeze()
 					if err != nil {
 						return 0, err
 					}
@@ -362,6 +393,20 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 				}
 
 				didFreeze = true
+
+				// all VMs of a group have to be quiesced before
+				// the first volume snapshot of the group is taken
+				if group != nil {
+					quiesced, err := ctrl.vmSnapshotGroupQuiesced(group)
+					if err != nil {
+						return 0, err
+					}
+
+					if !quiesced {
+						log.Log.V(3).Infof("Waiting for snapshot group %s/%s to be quiesced", group.Namespace, group.Name)
+						return snapshotRetryInterval, nil
+					}
+				}
 			}
 
 			volumeSnapshot, err = ctrl.createVolumeSnapshot(content, volumeBackup)
@@ -413,12 +458,37 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 		}
 	}
 
+	var retry time.Duration
 	if created && contentCpy.Status.CreationTime == nil {
 		contentCpy.Status.CreationTime = currentTime()
 
-		err = ctrl.unfreezeSource(vmSnapshot)
-		if err != nil {
-			return 0, err
+		if group == nil {
+			err = ctrl.unfreezeSource(vmSnapshot)
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if group != nil {
+		// keep the VMs of a group frozen until the volume snapshots
+		// of all of them were taken, and the content not ready so the
+		// VM is not unlocked in the meantime
+		cut := false
+		if created {
+			cut, err = ctrl.vmSnapshotGroupCutComplete(group, contentCpy)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		if cut || vmSnapshotGroupFailed(group) {
+			if err = ctrl.unfreezeGroupMember(vmSnapshot); err != nil {
+				return 0, err
+			}
+		} else if created {
+			ready = false
+			retry = snapshotRetryInterval
 		}
 	}
 
@@ -438,7 +508,7 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 		}
 	}
 
-	return 0, nil
+	return retry, nil
 }
 
 func (ctrl *VMSnapshotController) createVolumeSnapshot(
@@ -538,6 +608,11 @@ func (ctrl *VMSnapshotController) createContent(vmSnapshot *snapshotv1.VirtualMa
 		return err
 	}
 	for volumeName, pvcName := range pvcs {
+		if !volumeSelected(volumeName, vmSnapshot.Spec.IncludedVolumes, vmSnapshot.Spec.ExcludedVolumes) {
+			log.Log.V(3).Infof("Volume %s not selected for snapshot %s/%s", volumeName, vmSnapshot.Namespace, vmSnapshot.Name)
+			continue
+		}
+
 		pvc, err := ctrl.getSnapshotPVC(vmSnapshot.Namespace, pvcName)
 		if err != nil {
 			return err
@@ -698,14 +773,20 @@ func (ctrl *VMSnapshotController) updateSnapshotStatus(vmSnapshot *snapshotv1.Vi
 			vmSnapshotCpy.Status.VirtualMachineSnapshotContentName = &content.Name
 			vmSnapshotCpy.Status.CreationTime = content.Status.CreationTime
 			vmSnapshotCpy.Status.ReadyToUse = content.Status.ReadyToUse
-			vmSnapshotCpy.Status.Error = content.Status.Error
+			if abortingSnapshotHookResult(vmSnapshotCpy) == nil {
+				vmSnapshotCpy.Status.Error = content.Status.Error
+			}
 		}
 	}
 
 	if vmSnapshotDeadlineExceeded(vmSnapshotCpy) {
+		reason := vmSnapshotDeadlineExceededError
+		if result := abortingSnapshotHookResult(vmSnapshotCpy); result != nil {
+			reason = snapshotHookFailureMessage(result)
+		}
 		vmSnapshotCpy.Status.Phase = snapshotv1.Failed
-		updateSnapshotCondition(vmSnapshotCpy, newProgressingCondition(corev1.ConditionFalse, vmSnapshotDeadlineExceededError))
-		updateSnapshotCondition(vmSnapshotCpy, newFailureCondition(corev1.ConditionTrue, vmSnapshotDeadlineExceededError))
+		updateSnapshotCondition(vmSnapshotCpy, newProgressingCondition(corev1.ConditionFalse, reason))
+		updateSnapshotCondition(vmSnapshotCpy, newFailureCondition(corev1.ConditionTrue, reason))
 	} else if vmSnapshotProgressing(vmSnapshotCpy) {
 		vmSnapshotCpy.Status.Phase = snapshotv1.InProgress
 		if source != nil {
@@ -0,0 +1,439 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1alpha1"
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	vmSnapshotGroupLabel = "snapshot.kubevirt.io/snapshot-group"
+
+	vmSnapshotGroupMembersCreateEvent = "SuccessfulVirtualMachineSnapshotGroupMembersCreate"
+
+	vmSnapshotGroupDeadlineExceededError = "snapshot group deadline exceeded"
+)
+
+func vmSnapshotGroupFailed(group *snapshotv1.VirtualMachineSnapshotGroup) bool {
+	return group != nil && group.Status != nil && group.Status.Phase == snapshotv1.Failed
+}
+
+func vmSnapshotGroupSucceeded(group *snapshotv1.VirtualMachineSnapshotGroup) bool {
+	return group != nil && group.Status != nil && group.Status.Phase == snapshotv1.Succeeded
+}
+
+func getGroupFailureDeadline(group *snapshotv1.VirtualMachineSnapshotGroup) time.Duration {
+	failureDeadline := snapshotv1.DefaultFailureDeadline
+	if group.Spec.FailureDeadline != nil {
+		failureDeadline = group.Spec.FailureDeadline.Duration
+	}
+
+	return failureDeadline
+}
+
+// timeUntilGroupDeadline returns 0 if the group has no deadline
+func timeUntilGroupDeadline(group *snapshotv1.VirtualMachineSnapshotGroup) time.Duration {
+	failureDeadline := getGroupFailureDeadline(group)
+	if failureDeadline == 0 {
+		return 0
+	}
+
+	return time.Until(group.CreationTimestamp.Add(failureDeadline))
+}
+
+func vmSnapshotGroupDeadlineExceeded(group *snapshotv1.VirtualMachineSnapshotGroup) bool {
+	return getGroupFailureDeadline(group) != 0 && timeUntilGroupDeadline(group) <= 0
+}
+
+func vmSnapshotGroupMemberName(group *snapshotv1.VirtualMachineSnapshotGroup, vmName string) string {
+	return fmt.Sprintf("%s-%s", group.Name, vmName)
+}
+
+func (ctrl *VMSnapshotController) updateVMSnapshotGroup(group *snapshotv1.VirtualMachineSnapshotGroup) (time.Duration, error) {
+	log.Log.V(3).Infof("Updating VirtualMachineSnapshotGroup %s/%s", group.Namespace, group.Name)
+
+	// member snapshots are owned by the group and garbage collected with it
+	if group.DeletionTimestamp != nil || vmSnapshotGroupFailed(group) || vmSnapshotGroupSucceeded(group) {
+		return 0, nil
+	}
+
+	groupCpy := group.DeepCopy()
+	if groupCpy.Status == nil {
+		if err := ctrl.initVMSnapshotGroup(groupCpy); err != nil {
+			return 0, err
+		}
+	} else if err := ctrl.updateVMSnapshotGroupStatus(groupCpy); err != nil {
+		return 0, err
+	}
+
+	if !equality.Semantic.DeepEqual(group, groupCpy) {
+		if _, err := ctrl.Client.VirtualMachineSnapshotGroup(groupCpy.Namespace).Update(context.Background(), groupCpy, metav1.UpdateOptions{}); err != nil {
+			return 0, err
+		}
+	}
+
+	if groupCpy.Status.Phase == snapshotv1.InProgress {
+		return timeUntilGroupDeadline(groupCpy), nil
+	}
+
+	return 0, nil
+}
+
+// initVMSnapshotGroup selects the VMs of the group once and creates a
+// VirtualMachineSnapshot for each of them, sharing the group deadline
+func (ctrl *VMSnapshotController) initVMSnapshotGroup(group *snapshotv1.VirtualMachineSnapshotGroup) error {
+	f := false
+	group.Status = &snapshotv1.VirtualMachineSnapshotGroupStatus{
+		ReadyToUse: &f,
+	}
+
+	vms, err := ctrl.selectVMSnapshotGroupVMs(group)
+	if err != nil {
+		return err
+	}
+
+	if len(vms) == 0 {
+		failVMSnapshotGroup(group, fmt.Sprintf("no VirtualMachine matches the selector of snapshot group %s", group.Name))
+		return nil
+	}
+
+	var failureDeadline *metav1.Duration
+	if getGroupFailureDeadline(group) != 0 {
+		remaining := timeUntilGroupDeadline(group)
+		if remaining <= 0 {
+			failVMSnapshotGroup(group, vmSnapshotGroupDeadlineExceededError)
+			return nil
+		}
+		failureDeadline = &metav1.Duration{Duration: remaining}
+	}
+
+	var members []snapshotv1.VirtualMachineSnapshotGroupMember
+	for _, vm := range vms {
+		member := snapshotv1.VirtualMachineSnapshotGroupMember{
+			VirtualMachineName:         vm.Name,
+			VirtualMachineSnapshotName: vmSnapshotGroupMemberName(group, vm.Name),
+		}
+
+		if err := ctrl.createVMSnapshotGroupMember(group, member, failureDeadline); err != nil {
+			return err
+		}
+
+		members = append(members, member)
+	}
+
+	ctrl.Recorder.Eventf(
+		group,
+		corev1.EventTypeNormal,
+		vmSnapshotGroupMembersCreateEvent,
+		"Successfully created VirtualMachineSnapshots for %d VirtualMachines",
+		len(members),
+	)
+
+	group.Status.Phase = snapshotv1.InProgress
+	group.Status.VirtualMachineSnapshots = members
+	reason := fmt.Sprintf("Snapshotting %d VirtualMachines", len(members))
+	updateSnapshotGroupCondition(group, newProgressingCondition(corev1.ConditionTrue, reason))
+	updateSnapshotGroupCondition(group, newReadyCondition(corev1.ConditionFalse, "Not ready"))
+
+	return nil
+}
+
+func (ctrl *VMSnapshotController) selectVMSnapshotGroupVMs(group *snapshotv1.VirtualMachineSnapshotGroup) ([]*kubevirtv1.VirtualMachine, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&group.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var vms []*kubevirtv1.VirtualMachine
+	for _, obj := range ctrl.VMInformer.GetStore().List() {
+		vm := obj.(*kubevirtv1.VirtualMachine)
+		if vm.Namespace != group.Namespace || vm.DeletionTimestamp != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(vm.Labels)) {
+			vms = append(vms, vm)
+		}
+	}
+
+	sort.Slice(vms, func(i, j int) bool {
+		return vms[i].Name < vms[j].Name
+	})
+
+	return vms, nil
+}
+
+func (ctrl *VMSnapshotController) createVMSnapshotGroupMember(group *snapshotv1.VirtualMachineSnapshotGroup, member snapshotv1.VirtualMachineSnapshotGroupMember, failureDeadline *metav1.Duration) error {
+	t := true
+	apiGroup := kubevirtv1.SchemeGroupVersion.Group
+	vmSnapshot := &snapshotv1.VirtualMachineSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      member.VirtualMachineSnapshotName,
+			Namespace: group.Namespace,
+			Labels: map[string]string{
+				vmSnapshotGroupLabel: group.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         snapshotv1.SchemeGroupVersion.String(),
+					Kind:               "VirtualMachineSnapshotGroup",
+					Name:               group.Name,
+					UID:                group.UID,
+					Controller:         &t,
+					BlockOwnerDeletion: &t,
+				},
+			},
+		},
+		Spec: snapshotv1.VirtualMachineSnapshotSpec{
+			Source: corev1.TypedLocalObjectReference{
+				APIGroup: &apiGroup,
+				Kind:     "VirtualMachine",
+				Name:     member.VirtualMachineName,
+			},
+			DeletionPolicy:  group.Spec.DeletionPolicy,
+			FailureDeadline: failureDeadline,
+		},
+	}
+
+	_, err := ctrl.Client.VirtualMachineSnapshot(group.Namespace).Create(context.Background(), vmSnapshot, metav1.CreateOptions{})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (ctrl *VMSnapshotController) updateVMSnapshotGroupStatus(group *snapshotv1.VirtualMachineSnapshotGroup) error {
+	members, err := ctrl.getVMSnapshotGroupMembers(group)
+	if err != nil {
+		return err
+	}
+
+	var failed []string
+	var creationTime *metav1.Time
+	ready := true
+	for i, member := range group.Status.VirtualMachineSnapshots {
+		vmSnapshot := members[i]
+		switch {
+		case vmSnapshot == nil:
+			failed = append(failed, fmt.Sprintf("%s missing", member.VirtualMachineSnapshotName))
+		case vmSnapshotFailed(vmSnapshot) || vmSnapshotError(vmSnapshot) != nil:
+			failed = append(failed, fmt.Sprintf("%s failed", member.VirtualMachineSnapshotName))
+		case !VmSnapshotReady(vmSnapshot):
+			ready = false
+		default:
+			if creationTime == nil || (vmSnapshot.Status.CreationTime != nil && creationTime.Before(vmSnapshot.Status.CreationTime)) {
+				creationTime = vmSnapshot.Status.CreationTime
+			}
+		}
+	}
+
+	switch {
+	case len(failed) > 0:
+		failVMSnapshotGroup(group, fmt.Sprintf("VirtualMachineSnapshots (%s)", strings.Join(failed, ",")))
+	case ready:
+		t := true
+		group.Status.Phase = snapshotv1.Succeeded
+		group.Status.ReadyToUse = &t
+		group.Status.CreationTime = creationTime
+		updateSnapshotGroupCondition(group, newProgressingCondition(corev1.ConditionFalse, "Operation complete"))
+		updateSnapshotGroupCondition(group, newReadyCondition(corev1.ConditionTrue, "Operation complete"))
+	case vmSnapshotGroupDeadlineExceeded(group):
+		failVMSnapshotGroup(group, vmSnapshotGroupDeadlineExceededError)
+	}
+
+	return nil
+}
+
+func failVMSnapshotGroup(group *snapshotv1.VirtualMachineSnapshotGroup, message string) {
+	group.Status.Phase = snapshotv1.Failed
+	group.Status.Error = &snapshotv1.Error{
+		Time:    currentTime(),
+		Message: &message,
+	}
+	updateSnapshotGroupCondition(group, newProgressingCondition(corev1.ConditionFalse, message))
+	updateSnapshotGroupCondition(group, newReadyCondition(corev1.ConditionFalse, "Error"))
+	updateSnapshotGroupCondition(group, newFailureCondition(corev1.ConditionTrue, message))
+}
+
+// getVMSnapshotGroupMembers returns the member snapshots in the order of the group status,
+// missing members are returned as nil
+func (ctrl *VMSnapshotController) getVMSnapshotGroupMembers(group *snapshotv1.VirtualMachineSnapshotGroup) ([]*snapshotv1.VirtualMachineSnapshot, error) {
+	if group.Status == nil {
+		return nil, nil
+	}
+
+	members := make([]*snapshotv1.VirtualMachineSnapshot, len(group.Status.VirtualMachineSnapshots))
+	for i, member := range group.Status.VirtualMachineSnapshots {
+		obj, exists, err := ctrl.VMSnapshotInformer.GetStore().GetByKey(cacheKeyFunc(group.Namespace, member.VirtualMachineSnapshotName))
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			members[i] = obj.(*snapshotv1.VirtualMachineSnapshot).DeepCopy()
+		}
+	}
+
+	return members, nil
+}
+
+// getVMSnapshotGroup returns the group a VirtualMachineSnapshot was created for, if any
+func (ctrl *VMSnapshotController) getVMSnapshotGroup(vmSnapshot *snapshotv1.VirtualMachineSnapshot) (*snapshotv1.VirtualMachineSnapshotGroup, error) {
+	if vmSnapshot == nil {
+		return nil, nil
+	}
+
+	groupName, ok := vmSnapshot.Labels[vmSnapshotGroupLabel]
+	if !ok {
+		return nil, nil
+	}
+
+	obj, exists, err := ctrl.VMSnapshotGroupInformer.GetStore().GetByKey(cacheKeyFunc(vmSnapshot.Namespace, groupName))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return obj.(*snapshotv1.VirtualMachineSnapshotGroup).DeepCopy(), nil
+}
+
+// vmSnapshotGroupQuiesced returns true once every VM of the group is locked
+// and either offline, without guest agent or frozen, so that the volume
+// snapshots of all of them capture the same point in time
+func (ctrl *VMSnapshotController) vmSnapshotGroupQuiesced(group *snapshotv1.VirtualMachineSnapshotGroup) (bool, error) {
+	members, err := ctrl.getVMSnapshotGroupMembers(group)
+	if err != nil {
+		return false, err
+	}
+
+	for i, vmSnapshot := range members {
+		if vmSnapshot == nil {
+			log.Log.V(3).Infof("Snapshot %s of group %s/%s does not exist yet", group.Status.VirtualMachineSnapshots[i].VirtualMachineSnapshotName, group.Namespace, group.Name)
+			return false, nil
+		}
+
+		source, err := ctrl.getSnapshotSource(vmSnapshot)
+		if err != nil {
+			return false, err
+		}
+
+		if source == nil || !source.Locked() {
+			return false, nil
+		}
+
+		online, err := source.Online()
+		if err != nil {
+			return false, err
+		}
+		if !online {
+			continue
+		}
+
+		ga, err := source.GuestAgent()
+		if err != nil {
+			return false, err
+		}
+		if !ga {
+			continue
+		}
+
+		frozen, err := source.Frozen()
+		if err != nil || !frozen {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// vmSnapshotGroupCutComplete returns true once the volume snapshots
+// of every VM of the group were taken
+func (ctrl *VMSnapshotController) vmSnapshotGroupCutComplete(group *snapshotv1.VirtualMachineSnapshotGroup, content *snapshotv1.VirtualMachineSnapshotContent) (bool, error) {
+	members, err := ctrl.getVMSnapshotGroupMembers(group)
+	if err != nil {
+		return false, err
+	}
+
+	for _, vmSnapshot := range members {
+		if vmSnapshot == nil {
+			return false, nil
+		}
+
+		if content.Spec.VirtualMachineSnapshotName != nil && *content.Spec.VirtualMachineSnapshotName == vmSnapshot.Name {
+			if !vmSnapshotContentCreated(content) {
+				return false, nil
+			}
+			continue
+		}
+
+		memberContent, err := ctrl.getContent(vmSnapshot)
+		if err != nil {
+			return false, err
+		}
+
+		if memberContent == nil || !vmSnapshotContentCreated(memberContent) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// unfreezeGroupMember only thaws the VM if it is frozen since group members
+// are reconciled until the whole group is done, post-thaw hooks which
+// already ran are not executed again
+func (ctrl *VMSnapshotController) unfreezeGroupMember(vmSnapshot *snapshotv1.VirtualMachineSnapshot) error {
+	source, err := ctrl.getSnapshotSource(vmSnapshot)
+	if err != nil || source == nil {
+		return err
+	}
+
+	frozen, err := source.Frozen()
+	if err != nil {
+		return err
+	}
+
+	if frozen {
+		if err := source.Unfreeze(); err != nil {
+			return err
+		}
+	}
+
+	if frozen || snapshotHooksStarted(vmSnapshot, snapshotv1.PreFreezeHook) {
+		_, err = ctrl.runSnapshotHooks(vmSnapshot, source, snapshotv1.PostThawHook)
+	}
+	return err
+}
+
+func updateSnapshotGroupCondition(group *snapshotv1.VirtualMachineSnapshotGroup, c snapshotv1.Condition) {
+	group.Status.Conditions = updateCondition(group.Status.Conditions, c, true)
+}
@@ -0,0 +1,412 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package snapshot
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Snapshot group", func() {
+	const groupName = "tier"
+
+	var (
+		vmInformer              cache.SharedIndexInformer
+		vmiInformer             cache.SharedIndexInformer
+		vmSnapshotInformer      cache.SharedIndexInformer
+		vmSnapshotContentInfmr  cache.SharedIndexInformer
+		vmSnapshotGroupInformer cache.SharedIndexInformer
+		vmRestoreInformer       cache.SharedIndexInformer
+		kubevirtClient          *kubevirtfake.Clientset
+		virtClient              *kubecli.MockKubevirtClient
+		recorder                *record.FakeRecorder
+	)
+
+	createGroupVM := func(name string, tier string) *v1.VirtualMachine {
+		vm := createVirtualMachine(testNamespace, name)
+		vm.UID = "uid-" + vm.UID
+		vm.Labels["tier"] = tier
+		return vm
+	}
+
+	createSnapshotGroup := func() *snapshotv1.VirtualMachineSnapshotGroup {
+		return &snapshotv1.VirtualMachineSnapshotGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              groupName,
+				Namespace:         testNamespace,
+				UID:               "group-uid",
+				CreationTimestamp: metav1.Now(),
+			},
+			Spec: snapshotv1.VirtualMachineSnapshotGroupSpec{
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"tier": "app"},
+				},
+			},
+		}
+	}
+
+	createInProgressSnapshotGroup := func(vmNames ...string) *snapshotv1.VirtualMachineSnapshotGroup {
+		group := createSnapshotGroup()
+		group.Status = &snapshotv1.VirtualMachineSnapshotGroupStatus{
+			ReadyToUse: &f,
+			Phase:      snapshotv1.InProgress,
+		}
+		for _, vmName := range vmNames {
+			group.Status.VirtualMachineSnapshots = append(group.Status.VirtualMachineSnapshots, snapshotv1.VirtualMachineSnapshotGroupMember{
+				VirtualMachineName:         vmName,
+				VirtualMachineSnapshotName: vmSnapshotGroupMemberName(group, vmName),
+			})
+		}
+		return group
+	}
+
+	createMemberSnapshot := func(vmName string, phase snapshotv1.VirtualMachineSnapshotPhase, ready bool) *snapshotv1.VirtualMachineSnapshot {
+		vmSnapshot := createVirtualMachineSnapshot(testNamespace, groupName+"-"+vmName, vmName)
+		vmSnapshot.UID = types.UID("uid-" + vmName)
+		vmSnapshot.Labels = map[string]string{vmSnapshotGroupLabel: groupName}
+		vmSnapshot.Status = &snapshotv1.VirtualMachineSnapshotStatus{
+			Phase:        phase,
+			ReadyToUse:   &ready,
+			CreationTime: currentTime(),
+		}
+		return vmSnapshot
+	}
+
+	getSnapshotGroup := func() *snapshotv1.VirtualMachineSnapshotGroup {
+		group, err := kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshotGroups(testNamespace).Get(context.Background(), groupName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return group
+	}
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		virtClient = kubecli.NewMockKubevirtClient(ctrl)
+		kubevirtClient = kubevirtfake.NewSimpleClientset()
+		recorder = record.NewFakeRecorder(100)
+
+		vmInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+		vmiInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+		vmSnapshotInformer, _ = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshot{})
+		vmSnapshotContentInfmr, _ = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshotContent{})
+		vmSnapshotGroupInformer, _ = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshotGroup{})
+		vmRestoreInformer, _ = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineRestore{})
+
+		virtClient.EXPECT().VirtualMachineSnapshot(testNamespace).
+			Return(kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshots(testNamespace)).AnyTimes()
+		virtClient.EXPECT().VirtualMachineSnapshotGroup(testNamespace).
+			Return(kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshotGroups(testNamespace)).AnyTimes()
+		virtClient.EXPECT().VirtualMachineRestore(testNamespace).
+			Return(kubevirtClient.SnapshotV1alpha1().VirtualMachineRestores(testNamespace)).AnyTimes()
+		virtClient.EXPECT().VirtualMachineRestoreGroup(testNamespace).
+			Return(kubevirtClient.SnapshotV1alpha1().VirtualMachineRestoreGroups(testNamespace)).AnyTimes()
+	})
+
+	Context("VirtualMachineSnapshotGroup", func() {
+		var controller *VMSnapshotController
+
+		BeforeEach(func() {
+			controller = &VMSnapshotController{
+				Client:                    virtClient,
+				VMInformer:                vmInformer,
+				VMIInformer:               vmiInformer,
+				VMSnapshotInformer:        vmSnapshotInformer,
+				VMSnapshotContentInformer: vmSnapshotContentInfmr,
+				VMSnapshotGroupInformer:   vmSnapshotGroupInformer,
+				Recorder:                  recorder,
+			}
+		})
+
+		updateGroup := func(group *snapshotv1.VirtualMachineSnapshotGroup) *snapshotv1.VirtualMachineSnapshotGroup {
+			_, err := kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshotGroups(testNamespace).Create(context.Background(), group, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = controller.updateVMSnapshotGroup(group)
+			Expect(err).ToNot(HaveOccurred())
+			return getSnapshotGroup()
+		}
+
+		It("should fail when no VirtualMachine matches the selector", func() {
+			Expect(vmInformer.GetStore().Add(createGroupVM("db", "db"))).To(Succeed())
+
+			group := updateGroup(createSnapshotGroup())
+			Expect(group.Status.Phase).To(Equal(snapshotv1.Failed))
+			Expect(*group.Status.Error.Message).To(ContainSubstring("no VirtualMachine matches the selector"))
+			Expect(group.Status.VirtualMachineSnapshots).To(BeEmpty())
+		})
+
+		It("should create a VirtualMachineSnapshot for every selected VirtualMachine", func() {
+			Expect(vmInformer.GetStore().Add(createGroupVM("web", "app"))).To(Succeed())
+			Expect(vmInformer.GetStore().Add(createGroupVM("queue", "app"))).To(Succeed())
+			Expect(vmInformer.GetStore().Add(createGroupVM("db", "db"))).To(Succeed())
+
+			group := updateGroup(createSnapshotGroup())
+			Expect(group.Status.Phase).To(Equal(snapshotv1.InProgress))
+			Expect(group.Status.VirtualMachineSnapshots).To(Equal([]snapshotv1.VirtualMachineSnapshotGroupMember{
+				{VirtualMachineName: "queue", VirtualMachineSnapshotName: "tier-queue"},
+				{VirtualMachineName: "web", VirtualMachineSnapshotName: "tier-web"},
+			}))
+			Expect(recorder.Events).To(Receive(ContainSubstring(vmSnapshotGroupMembersCreateEvent)))
+
+			vmSnapshots, err := kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshots(testNamespace).List(context.Background(), metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vmSnapshots.Items).To(HaveLen(2))
+			for _, vmSnapshot := range vmSnapshots.Items {
+				Expect(vmSnapshot.Labels).To(HaveKeyWithValue(vmSnapshotGroupLabel, groupName))
+				Expect(vmSnapshot.OwnerReferences).To(HaveLen(1))
+				Expect(vmSnapshot.OwnerReferences[0].Kind).To(Equal("VirtualMachineSnapshotGroup"))
+				Expect(vmSnapshot.OwnerReferences[0].UID).To(Equal(group.UID))
+				// the members share the deadline of the group
+				Expect(vmSnapshot.Spec.FailureDeadline).ToNot(BeNil())
+				Expect(vmSnapshot.Spec.FailureDeadline.Duration).To(BeNumerically("<=", snapshotv1.DefaultFailureDeadline))
+				Expect(vmSnapshot.Spec.FailureDeadline.Duration).To(BeNumerically(">", snapshotv1.DefaultFailureDeadline-time.Minute))
+			}
+		})
+
+		It("should succeed once all VirtualMachineSnapshots are ready", func() {
+			Expect(vmSnapshotInformer.GetStore().Add(createMemberSnapshot("web", snapshotv1.Succeeded, true))).To(Succeed())
+			Expect(vmSnapshotInformer.GetStore().Add(createMemberSnapshot("queue", snapshotv1.Succeeded, true))).To(Succeed())
+
+			group := updateGroup(createInProgressSnapshotGroup("queue", "web"))
+			Expect(group.Status.Phase).To(Equal(snapshotv1.Succeeded))
+			Expect(*group.Status.ReadyToUse).To(BeTrue())
+			Expect(group.Status.CreationTime).ToNot(BeNil())
+		})
+
+		It("should stay in progress while a VirtualMachineSnapshot is not ready", func() {
+			Expect(vmSnapshotInformer.GetStore().Add(createMemberSnapshot("web", snapshotv1.Succeeded, true))).To(Succeed())
+			Expect(vmSnapshotInformer.GetStore().Add(createMemberSnapshot("queue", snapshotv1.InProgress, false))).To(Succeed())
+
+			group := updateGroup(createInProgressSnapshotGroup("queue", "web"))
+			Expect(group.Status.Phase).To(Equal(snapshotv1.InProgress))
+			Expect(*group.Status.ReadyToUse).To(BeFalse())
+		})
+
+		It("should fail when a VirtualMachineSnapshot failed or is missing", func() {
+			Expect(vmSnapshotInformer.GetStore().Add(createMemberSnapshot("web", snapshotv1.Failed, false))).To(Succeed())
+
+			group := updateGroup(createInProgressSnapshotGroup("queue", "web"))
+			Expect(group.Status.Phase).To(Equal(snapshotv1.Failed))
+			Expect(*group.Status.Error.Message).To(Equal("VirtualMachineSnapshots (tier-queue missing,tier-web failed)"))
+		})
+
+		It("should fail when the group deadline is exceeded", func() {
+			Expect(vmSnapshotInformer.GetStore().Add(createMemberSnapshot("web", snapshotv1.InProgress, false))).To(Succeed())
+
+			group := createInProgressSnapshotGroup("web")
+			group.CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * snapshotv1.DefaultFailureDeadline))
+			group = updateGroup(group)
+			Expect(group.Status.Phase).To(Equal(snapshotv1.Failed))
+			Expect(*group.Status.Error.Message).To(Equal(vmSnapshotGroupDeadlineExceededError))
+		})
+
+		Context("coordinating the VirtualMachineSnapshotContents", func() {
+			lockVM := func(vm *v1.VirtualMachine) *v1.VirtualMachine {
+				vm.Finalizers = []string{sourceFinalizer}
+				vm.Status.SnapshotInProgress = &[]string{vmSnapshotGroupMemberName(createSnapshotGroup(), vm.Name)}[0]
+				return vm
+			}
+
+			BeforeEach(func() {
+				Expect(vmSnapshotInformer.GetStore().Add(createMemberSnapshot("web", snapshotv1.InProgress, false))).To(Succeed())
+				Expect(vmSnapshotInformer.GetStore().Add(createMemberSnapshot("queue", snapshotv1.InProgress, false))).To(Succeed())
+			})
+
+			It("should not be quiesced until all VirtualMachines are locked", func() {
+				Expect(vmInformer.GetStore().Add(lockVM(createGroupVM("web", "app")))).To(Succeed())
+				Expect(vmInformer.GetStore().Add(createGroupVM("queue", "app"))).To(Succeed())
+
+				quiesced, err := controller.vmSnapshotGroupQuiesced(createInProgressSnapshotGroup("queue", "web"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(quiesced).To(BeFalse())
+			})
+
+			It("should be quiesced once all VirtualMachines are locked and offline", func() {
+				Expect(vmInformer.GetStore().Add(lockVM(createGroupVM("web", "app")))).To(Succeed())
+				Expect(vmInformer.GetStore().Add(lockVM(createGroupVM("queue", "app")))).To(Succeed())
+
+				quiesced, err := controller.vmSnapshotGroupQuiesced(createInProgressSnapshotGroup("queue", "web"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(quiesced).To(BeTrue())
+			})
+
+			It("should not be quiesced while a running VirtualMachine with guest agent is not frozen", func() {
+				web := lockVM(createGroupVM("web", "app"))
+				web.Spec.Running = &t
+				Expect(vmInformer.GetStore().Add(web)).To(Succeed())
+				Expect(vmInformer.GetStore().Add(lockVM(createGroupVM("queue", "app")))).To(Succeed())
+				vmi := &v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: testNamespace},
+					Status: v1.VirtualMachineInstanceStatus{
+						Conditions: []v1.VirtualMachineInstanceCondition{
+							{Type: v1.VirtualMachineInstanceAgentConnected, Status: corev1.ConditionTrue},
+						},
+					},
+				}
+				Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+
+				group := createInProgressSnapshotGroup("queue", "web")
+				quiesced, err := controller.vmSnapshotGroupQuiesced(group)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(quiesced).To(BeFalse())
+
+				vmi.Status.FSFreezeStatus = "frozen"
+				Expect(vmiInformer.GetStore().Update(vmi)).To(Succeed())
+				quiesced, err = controller.vmSnapshotGroupQuiesced(group)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(quiesced).To(BeTrue())
+			})
+
+			It("should only complete the cut once the contents of all VirtualMachines are created", func() {
+				group := createInProgressSnapshotGroup("queue", "web")
+				webSnapshot := createMemberSnapshot("web", snapshotv1.InProgress, false)
+				queueSnapshot := createMemberSnapshot("queue", snapshotv1.InProgress, false)
+				webContent := createVirtualMachineSnapshotContent(webSnapshot, createGroupVM("web", "app"), nil)
+				webContent.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{CreationTime: currentTime()}
+				queueContent := createVirtualMachineSnapshotContent(queueSnapshot, createGroupVM("queue", "app"), nil)
+				queueContent.Name = GetVMSnapshotContentName(queueSnapshot)
+				Expect(vmSnapshotContentInfmr.GetStore().Add(queueContent)).To(Succeed())
+
+				cut, err := controller.vmSnapshotGroupCutComplete(group, webContent)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cut).To(BeFalse())
+
+				queueContent.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{CreationTime: currentTime()}
+				Expect(vmSnapshotContentInfmr.GetStore().Update(queueContent)).To(Succeed())
+				cut, err = controller.vmSnapshotGroupCutComplete(group, webContent)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cut).To(BeTrue())
+			})
+		})
+	})
+
+	Context("VirtualMachineRestoreGroup", func() {
+		const restoreGroupName = "tier-restore"
+
+		var controller *VMRestoreController
+
+		BeforeEach(func() {
+			controller = &VMRestoreController{
+				Client:                  virtClient,
+				VMInformer:              vmInformer,
+				VMIInformer:             vmiInformer,
+				VMRestoreInformer:       vmRestoreInformer,
+				VMSnapshotGroupInformer: vmSnapshotGroupInformer,
+				Recorder:                recorder,
+			}
+
+			group := createInProgressSnapshotGroup("queue", "web")
+			group.Status.Phase = snapshotv1.Succeeded
+			group.Status.ReadyToUse = &t
+			Expect(vmSnapshotGroupInformer.GetStore().Add(group)).To(Succeed())
+		})
+
+		updateRestoreGroup := func(restoreGroup *snapshotv1.VirtualMachineRestoreGroup) *snapshotv1.VirtualMachineRestoreGroup {
+			_, err := kubevirtClient.SnapshotV1alpha1().VirtualMachineRestoreGroups(testNamespace).Create(context.Background(), restoreGroup, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = controller.updateVMRestoreGroup(restoreGroup)
+			Expect(err).ToNot(HaveOccurred())
+			restoreGroup, err = kubevirtClient.SnapshotV1alpha1().VirtualMachineRestoreGroups(testNamespace).Get(context.Background(), restoreGroupName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			return restoreGroup
+		}
+
+		createRestoreGroup := func() *snapshotv1.VirtualMachineRestoreGroup {
+			return &snapshotv1.VirtualMachineRestoreGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      restoreGroupName,
+					Namespace: testNamespace,
+				},
+				Spec: snapshotv1.VirtualMachineRestoreGroupSpec{
+					VirtualMachineSnapshotGroupName: groupName,
+				},
+			}
+		}
+
+		It("should wait for all VirtualMachines to stop", func() {
+			web := createGroupVM("web", "app")
+			web.Spec.Running = &t
+			Expect(vmInformer.GetStore().Add(web)).To(Succeed())
+			Expect(vmInformer.GetStore().Add(createGroupVM("queue", "app"))).To(Succeed())
+
+			restoreGroup := updateRestoreGroup(createRestoreGroup())
+			Expect(restoreGroup.Status.VirtualMachineRestores).To(BeEmpty())
+			Expect(restoreGroup.Status.Conditions).To(ContainElement(HaveField("Reason", "Waiting for VirtualMachines (web) to stop")))
+
+			vmRestores, err := kubevirtClient.SnapshotV1alpha1().VirtualMachineRestores(testNamespace).List(context.Background(), metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vmRestores.Items).To(BeEmpty())
+		})
+
+		It("should create a VirtualMachineRestore for every VirtualMachine of the group", func() {
+			Expect(vmInformer.GetStore().Add(createGroupVM("web", "app"))).To(Succeed())
+			Expect(vmInformer.GetStore().Add(createGroupVM("queue", "app"))).To(Succeed())
+
+			restoreGroup := updateRestoreGroup(createRestoreGroup())
+			Expect(restoreGroup.Status.VirtualMachineRestores).To(Equal([]snapshotv1.VirtualMachineRestoreGroupMember{
+				{VirtualMachineName: "queue", VirtualMachineRestoreName: "tier-restore-queue"},
+				{VirtualMachineName: "web", VirtualMachineRestoreName: "tier-restore-web"},
+			}))
+
+			vmRestore, err := kubevirtClient.SnapshotV1alpha1().VirtualMachineRestores(testNamespace).Get(context.Background(), "tier-restore-web", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vmRestore.Labels).To(HaveKeyWithValue(vmRestoreGroupLabel, restoreGroupName))
+			Expect(vmRestore.Spec.Target.Name).To(Equal("web"))
+			Expect(vmRestore.Spec.VirtualMachineSnapshotName).To(Equal("tier-web"))
+		})
+
+		It("should complete once all VirtualMachineRestores are complete", func() {
+			restoreGroup := createRestoreGroup()
+			restoreGroup.Status = &snapshotv1.VirtualMachineRestoreGroupStatus{
+				Complete: &f,
+				VirtualMachineRestores: []snapshotv1.VirtualMachineRestoreGroupMember{
+					{VirtualMachineName: "web", VirtualMachineRestoreName: "tier-restore-web"},
+				},
+			}
+			Expect(vmRestoreInformer.GetStore().Add(&snapshotv1.VirtualMachineRestore{
+				ObjectMeta: metav1.ObjectMeta{Name: "tier-restore-web", Namespace: testNamespace},
+				Status: &snapshotv1.VirtualMachineRestoreStatus{
+					Complete:    &t,
+					RestoreTime: currentTime(),
+				},
+			})).To(Succeed())
+
+			restoreGroup = updateRestoreGroup(restoreGroup)
+			Expect(*restoreGroup.Status.Complete).To(BeTrue())
+			Expect(restoreGroup.Status.RestoreTime).ToNot(BeNil())
+			Expect(recorder.Events).To(Receive(ContainSubstring(restoreGroupCompleteEvent)))
+		})
+	})
+})
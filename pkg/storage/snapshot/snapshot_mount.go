@@ -0,0 +1,322 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1alpha1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+)
+
+const (
+	vmSnapshotMountFinalizer = "snapshot.kubevirt.io/vmsnapshotmount-protection"
+
+	snapshotMountReadyEvent = "VirtualMachineSnapshotMountReady"
+
+	snapshotMountRetryInterval = 5 * time.Second
+)
+
+// snapshotMountPVCName is both the name of the temporary PVC and of the
+// volume hotplugged into the target VM
+func snapshotMountPVCName(mount *snapshotv1.VirtualMachineSnapshotMount) string {
+	return fmt.Sprintf("mount-%s", mount.UID)
+}
+
+func snapshotMountExpirationTime(mount *snapshotv1.VirtualMachineSnapshotMount) time.Time {
+	ttl := snapshotv1.DefaultMountDurationTTL
+	if mount.Spec.TTLDuration != nil {
+		ttl = mount.Spec.TTLDuration.Duration
+	}
+
+	return mount.CreationTimestamp.Time.Add(ttl)
+}
+
+func (ctrl *VMRestoreController) updateVMSnapshotMount(mountIn *snapshotv1.VirtualMachineSnapshotMount) (time.Duration, error) {
+	logger := log.Log.Object(mountIn)
+	logger.V(1).Infof("Updating VirtualMachineSnapshotMount")
+
+	if mountIn.DeletionTimestamp != nil {
+		return ctrl.unmountVMSnapshotMount(mountIn)
+	}
+
+	expiration := snapshotMountExpirationTime(mountIn)
+	if !time.Now().Before(expiration) {
+		logger.Infof("VirtualMachineSnapshotMount expired, deleting it")
+		err := ctrl.Client.VirtualMachineSnapshotMount(mountIn.Namespace).Delete(context.Background(), mountIn.Name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return 0, err
+		}
+		return 0, nil
+	}
+
+	mountOut := mountIn.DeepCopy()
+	if !controller.HasFinalizer(mountOut, vmSnapshotMountFinalizer) {
+		controller.AddFinalizer(mountOut, vmSnapshotMountFinalizer)
+	}
+
+	if mountOut.Status == nil {
+		mountOut.Status = &snapshotv1.VirtualMachineSnapshotMountStatus{
+			Phase: snapshotv1.MountPending,
+		}
+	}
+	mountOut.Status.TTLExpirationTime = &metav1.Time{Time: expiration}
+
+	if mountOut.Status.Phase != snapshotv1.MountFailed {
+		if err := ctrl.reconcileVMSnapshotMount(mountOut); err != nil {
+			return 0, err
+		}
+	}
+
+	if !equality.Semantic.DeepEqual(mountIn, mountOut) {
+		if _, err := ctrl.Client.VirtualMachineSnapshotMount(mountOut.Namespace).Update(context.Background(), mountOut, metav1.UpdateOptions{}); err != nil {
+			return 0, err
+		}
+	}
+
+	retry := time.Until(expiration)
+	if mountOut.Status.Phase == snapshotv1.MountPending && retry > snapshotMountRetryInterval {
+		retry = snapshotMountRetryInterval
+	}
+
+	return retry, nil
+}
+
+// reconcileVMSnapshotMount restores the volume into the temporary PVC and
+// hotplugs it read-only into the target VM, if any
+func (ctrl *VMRestoreController) reconcileVMSnapshotMount(mount *snapshotv1.VirtualMachineSnapshotMount) error {
+	pvcName := snapshotMountPVCName(mount)
+	if mount.Status.PersistentVolumeClaimName == nil {
+		created, err := ctrl.createVMSnapshotMountPVC(mount, pvcName)
+		if err != nil || !created {
+			return err
+		}
+		mount.Status.PersistentVolumeClaimName = &pvcName
+	}
+
+	if mount.Spec.Target == nil {
+		ctrl.setVMSnapshotMountReady(mount, fmt.Sprintf("Volume %s available in PVC %s", mount.Spec.VolumeName, pvcName))
+		return nil
+	}
+
+	vmi, err := ctrl.getVMSnapshotMountTarget(mount)
+	if err != nil {
+		return err
+	}
+
+	if vmi == nil || !vmi.IsRunning() {
+		waitVMSnapshotMount(mount, fmt.Sprintf("Waiting for VirtualMachine %s to run", mount.Spec.Target.Name))
+		return nil
+	}
+
+	if !hasVMIVolume(vmi, pvcName) {
+		err := ctrl.Client.VirtualMachineInstance(vmi.Namespace).AddVolume(context.Background(), vmi.Name, &kubevirtv1.AddVolumeOptions{
+			Name: pvcName,
+			Disk: &kubevirtv1.Disk{
+				DiskDevice: kubevirtv1.DiskDevice{
+					Disk: &kubevirtv1.DiskTarget{
+						Bus:      kubevirtv1.DiskBusSCSI,
+						ReadOnly: true,
+					},
+				},
+			},
+			VolumeSource: &kubevirtv1.HotplugVolumeSource{
+				PersistentVolumeClaim: &kubevirtv1.PersistentVolumeClaimVolumeSource{
+					PersistentVolumeClaimVolumeSource: corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: pvcName,
+						ReadOnly:  true,
+					},
+					Hotpluggable: true,
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		waitVMSnapshotMount(mount, fmt.Sprintf("Hotplugging volume %s into VirtualMachine %s", pvcName, vmi.Name))
+		return nil
+	}
+
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		if volumeStatus.Name == pvcName && volumeStatus.Phase == kubevirtv1.VolumeReady {
+			ctrl.setVMSnapshotMountReady(mount, fmt.Sprintf("Volume %s mounted read-only in VirtualMachine %s", pvcName, vmi.Name))
+			return nil
+		}
+	}
+
+	waitVMSnapshotMount(mount, fmt.Sprintf("Waiting for volume %s to be attached to VirtualMachine %s", pvcName, vmi.Name))
+	return nil
+}
+
+func (ctrl *VMRestoreController) createVMSnapshotMountPVC(mount *snapshotv1.VirtualMachineSnapshotMount, pvcName string) (bool, error) {
+	objKey := cacheKeyFunc(mount.Namespace, mount.Spec.VirtualMachineSnapshotName)
+	obj, exists, err := ctrl.VMSnapshotInformer.GetStore().GetByKey(objKey)
+	if err != nil {
+		return false, err
+	}
+
+	if !exists {
+		waitVMSnapshotMount(mount, fmt.Sprintf("VirtualMachineSnapshot %s does not exist", mount.Spec.VirtualMachineSnapshotName))
+		return false, nil
+	}
+
+	vmSnapshot := obj.(*snapshotv1.VirtualMachineSnapshot)
+	if !VmSnapshotReady(vmSnapshot) || vmSnapshot.Status.VirtualMachineSnapshotContentName == nil {
+		waitVMSnapshotMount(mount, fmt.Sprintf("VirtualMachineSnapshot %s not ready", vmSnapshot.Name))
+		return false, nil
+	}
+
+	objKey = cacheKeyFunc(mount.Namespace, *vmSnapshot.Status.VirtualMachineSnapshotContentName)
+	obj, exists, err = ctrl.VMSnapshotContentInformer.GetStore().GetByKey(objKey)
+	if err != nil {
+		return false, err
+	}
+
+	if !exists || !vmSnapshotContentReady(obj.(*snapshotv1.VirtualMachineSnapshotContent)) {
+		waitVMSnapshotMount(mount, fmt.Sprintf("VirtualMachineSnapshotContent %s not ready", objKey))
+		return false, nil
+	}
+
+	content := obj.(*snapshotv1.VirtualMachineSnapshotContent)
+	volumeBackup, err := getRestoreVolumeBackup(mount.Spec.VolumeName, content)
+	if err != nil || volumeBackup.VolumeSnapshotName == nil {
+		failVMSnapshotMount(mount, fmt.Sprintf("Volume %s is not part of VirtualMachineSnapshot %s", mount.Spec.VolumeName, vmSnapshot.Name))
+		return false, nil
+	}
+
+	volumeSnapshot, err := ctrl.VolumeSnapshotProvider.GetVolumeSnapshot(mount.Namespace, *volumeBackup.VolumeSnapshotName)
+	if err != nil {
+		return false, err
+	}
+
+	if volumeSnapshot == nil {
+		waitVMSnapshotMount(mount, fmt.Sprintf("VolumeSnapshot %s does not exist", *volumeBackup.VolumeSnapshotName))
+		return false, nil
+	}
+
+	pvc := CreateRestorePVCDef(pvcName, volumeSnapshot, volumeBackup)
+	pvc.OwnerReferences = []metav1.OwnerReference{
+		*metav1.NewControllerRef(mount, snapshotv1.SchemeGroupVersion.WithKind("VirtualMachineSnapshotMount")),
+	}
+
+	_, err = ctrl.Client.CoreV1().PersistentVolumeClaims(mount.Namespace).Create(context.Background(), pvc, metav1.CreateOptions{})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// unmountVMSnapshotMount unplugs the volume from the target VM before
+// releasing the mount, the PVC is then garbage collected with it
+func (ctrl *VMRestoreController) unmountVMSnapshotMount(mount *snapshotv1.VirtualMachineSnapshotMount) (time.Duration, error) {
+	if !controller.HasFinalizer(mount, vmSnapshotMountFinalizer) {
+		return 0, nil
+	}
+
+	if mount.Spec.Target != nil && mount.Status != nil && mount.Status.PersistentVolumeClaimName != nil {
+		vmi, err := ctrl.getVMSnapshotMountTarget(mount)
+		if err != nil {
+			return 0, err
+		}
+
+		if vmi != nil && hasVMIVolume(vmi, *mount.Status.PersistentVolumeClaimName) {
+			err := ctrl.Client.VirtualMachineInstance(vmi.Namespace).RemoveVolume(context.Background(), vmi.Name, &kubevirtv1.RemoveVolumeOptions{
+				Name: *mount.Status.PersistentVolumeClaimName,
+			})
+			if err != nil && !errors.IsNotFound(err) {
+				return 0, err
+			}
+
+			return snapshotMountRetryInterval, nil
+		}
+	}
+
+	mountCpy := mount.DeepCopy()
+	controller.RemoveFinalizer(mountCpy, vmSnapshotMountFinalizer)
+	_, err := ctrl.Client.VirtualMachineSnapshotMount(mountCpy.Namespace).Update(context.Background(), mountCpy, metav1.UpdateOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return 0, err
+	}
+
+	return 0, nil
+}
+
+func (ctrl *VMRestoreController) getVMSnapshotMountTarget(mount *snapshotv1.VirtualMachineSnapshotMount) (*kubevirtv1.VirtualMachineInstance, error) {
+	obj, exists, err := ctrl.VMIInformer.GetStore().GetByKey(cacheKeyFunc(mount.Namespace, mount.Spec.Target.Name))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return obj.(*kubevirtv1.VirtualMachineInstance), nil
+}
+
+func (ctrl *VMRestoreController) setVMSnapshotMountReady(mount *snapshotv1.VirtualMachineSnapshotMount, reason string) {
+	if mount.Status.Phase != snapshotv1.MountReady {
+		ctrl.Recorder.Event(mount, corev1.EventTypeNormal, snapshotMountReadyEvent, reason)
+	}
+
+	mount.Status.Phase = snapshotv1.MountReady
+	updateSnapshotMountCondition(mount, newProgressingCondition(corev1.ConditionFalse, reason))
+	updateSnapshotMountCondition(mount, newReadyCondition(corev1.ConditionTrue, reason))
+}
+
+func hasVMIVolume(vmi *kubevirtv1.VirtualMachineInstance, name string) bool {
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func waitVMSnapshotMount(mount *snapshotv1.VirtualMachineSnapshotMount, reason string) {
+	mount.Status.Phase = snapshotv1.MountPending
+	updateSnapshotMountCondition(mount, newProgressingCondition(corev1.ConditionTrue, reason))
+	updateSnapshotMountCondition(mount, newReadyCondition(corev1.ConditionFalse, reason))
+}
+
+func failVMSnapshotMount(mount *snapshotv1.VirtualMachineSnapshotMount, reason string) {
+	now := metav1.Now()
+	mount.Status.Phase = snapshotv1.MountFailed
+	mount.Status.Error = &snapshotv1.Error{
+		Time:    &now,
+		Message: &reason,
+	}
+	updateSnapshotMountCondition(mount, newProgressingCondition(corev1.ConditionFalse, reason))
+	updateSnapshotMountCondition(mount, newReadyCondition(corev1.ConditionFalse, reason))
+	updateSnapshotMountCondition(mount, newFailureCondition(corev1.ConditionTrue, reason))
+}
+
+func updateSnapshotMountCondition(mount *snapshotv1.VirtualMachineSnapshotMount, c snapshotv1.Condition) {
+	mount.Status.Conditions = updateCondition(mount.Status.Conditions, c, true)
+}
@@ -0,0 +1,275 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package snapshot
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	vsv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+
+	virtcontroller "kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Snapshot mount", func() {
+	const (
+		mountName      = "mount"
+		mountPVCName   = "mount-mount-uid"
+		vmName         = "testvm"
+		vmSnapshotName = "test-snapshot"
+	)
+
+	var (
+		controller                *VMRestoreController
+		vmiInformer               cache.SharedIndexInformer
+		vmSnapshotInformer        cache.SharedIndexInformer
+		vmSnapshotContentInformer cache.SharedIndexInformer
+		vmiInterface              *kubecli.MockVirtualMachineInstanceInterface
+		kubevirtClient            *kubevirtfake.Clientset
+		k8sClient                 *k8sfake.Clientset
+		recorder                  *record.FakeRecorder
+		volumeSnapshotProvider    *MockVolumeSnapshotProvider
+	)
+
+	createMount := func(withTarget bool) *snapshotv1.VirtualMachineSnapshotMount {
+		mount := &snapshotv1.VirtualMachineSnapshotMount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              mountName,
+				Namespace:         testNamespace,
+				UID:               "mount-uid",
+				CreationTimestamp: metav1.Now(),
+			},
+			Spec: snapshotv1.VirtualMachineSnapshotMountSpec{
+				VirtualMachineSnapshotName: vmSnapshotName,
+				VolumeName:                 diskName,
+			},
+		}
+		if withTarget {
+			mount.Spec.Target = &corev1.TypedLocalObjectReference{
+				APIGroup: &vmAPIGroup,
+				Kind:     "VirtualMachine",
+				Name:     vmName,
+			}
+		}
+		return mount
+	}
+
+	addReadySnapshot := func() {
+		vm := createVirtualMachine(testNamespace, vmName)
+		vmSnapshot := createVirtualMachineSnapshot(testNamespace, vmSnapshotName, vmName)
+		content := createVirtualMachineSnapshotContent(vmSnapshot, vm, createPVCsForVM(vm))
+		content.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{ReadyToUse: &t}
+		vmSnapshot.Status = &snapshotv1.VirtualMachineSnapshotStatus{
+			ReadyToUse:                        &t,
+			VirtualMachineSnapshotContentName: &content.Name,
+		}
+		Expect(vmSnapshotInformer.GetStore().Add(vmSnapshot)).To(Succeed())
+		Expect(vmSnapshotContentInformer.GetStore().Add(content)).To(Succeed())
+		volumeSnapshotProvider.Add(&vsv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      *content.Spec.VolumeBackups[0].VolumeSnapshotName,
+				Namespace: testNamespace,
+			},
+		})
+	}
+
+	createRunningVMI := func(volumes ...string) *v1.VirtualMachineInstance {
+		vmi := &v1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      vmName,
+				Namespace: testNamespace,
+			},
+			Status: v1.VirtualMachineInstanceStatus{
+				Phase: v1.Running,
+			},
+		}
+		for _, volume := range volumes {
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{Name: volume})
+		}
+		return vmi
+	}
+
+	updateMount := func(mount *snapshotv1.VirtualMachineSnapshotMount) *snapshotv1.VirtualMachineSnapshotMount {
+		_, err := kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshotMounts(testNamespace).Create(context.Background(), mount, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = controller.updateVMSnapshotMount(mount)
+		Expect(err).ToNot(HaveOccurred())
+		mount, err = kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshotMounts(testNamespace).Get(context.Background(), mountName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return mount
+	}
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+		kubevirtClient = kubevirtfake.NewSimpleClientset()
+		k8sClient = k8sfake.NewSimpleClientset()
+		recorder = record.NewFakeRecorder(100)
+		volumeSnapshotProvider = &MockVolumeSnapshotProvider{}
+
+		vmiInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+		vmSnapshotInformer, _ = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshot{})
+		vmSnapshotContentInformer, _ = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshotContent{})
+		vmSnapshotMountInformer, _ := testutils.NewFakeInformerWithIndexersFor(&snapshotv1.VirtualMachineSnapshotMount{}, virtcontroller.GetVirtualMachineSnapshotMountInformerIndexers())
+
+		virtClient.EXPECT().VirtualMachineSnapshotMount(testNamespace).
+			Return(kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshotMounts(testNamespace)).AnyTimes()
+		virtClient.EXPECT().VirtualMachineInstance(testNamespace).Return(vmiInterface).AnyTimes()
+		virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+
+		controller = &VMRestoreController{
+			Client:                    virtClient,
+			VMSnapshotMountInformer:   vmSnapshotMountInformer,
+			VMSnapshotInformer:        vmSnapshotInformer,
+			VMSnapshotContentInformer: vmSnapshotContentInformer,
+			VMIInformer:               vmiInformer,
+			VolumeSnapshotProvider:    volumeSnapshotProvider,
+			Recorder:                  recorder,
+		}
+	})
+
+	It("should wait for the VirtualMachineSnapshot", func() {
+		mount := updateMount(createMount(false))
+		Expect(mount.Finalizers).To(ContainElement(vmSnapshotMountFinalizer))
+		Expect(mount.Status.Phase).To(Equal(snapshotv1.MountPending))
+		Expect(mount.Status.PersistentVolumeClaimName).To(BeNil())
+		Expect(mount.Status.TTLExpirationTime.Time).To(Equal(mount.CreationTimestamp.Add(snapshotv1.DefaultMountDurationTTL)))
+		Expect(mount.Status.Conditions).To(ContainElement(HaveField("Reason", ContainSubstring("does not exist"))))
+	})
+
+	It("should restore the volume into a PVC owned by the mount", func() {
+		addReadySnapshot()
+
+		mount := updateMount(createMount(false))
+		Expect(mount.Status.Phase).To(Equal(snapshotv1.MountReady))
+		Expect(*mount.Status.PersistentVolumeClaimName).To(Equal(mountPVCName))
+		Expect(recorder.Events).To(Receive(ContainSubstring(snapshotMountReadyEvent)))
+
+		pvc, err := k8sClient.CoreV1().PersistentVolumeClaims(testNamespace).Get(context.Background(), mountPVCName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pvc.Spec.DataSource.Kind).To(Equal("VolumeSnapshot"))
+		Expect(pvc.OwnerReferences).To(HaveLen(1))
+		Expect(pvc.OwnerReferences[0].Kind).To(Equal("VirtualMachineSnapshotMount"))
+		Expect(pvc.OwnerReferences[0].UID).To(Equal(mount.UID))
+	})
+
+	It("should fail when the volume is not part of the snapshot", func() {
+		addReadySnapshot()
+
+		mount := createMount(false)
+		mount.Spec.VolumeName = "disk9"
+		mount = updateMount(mount)
+		Expect(mount.Status.Phase).To(Equal(snapshotv1.MountFailed))
+		Expect(*mount.Status.Error.Message).To(ContainSubstring("is not part of VirtualMachineSnapshot"))
+	})
+
+	It("should hotplug the PVC read-only into the running target VM", func() {
+		addReadySnapshot()
+		Expect(vmiInformer.GetStore().Add(createRunningVMI())).To(Succeed())
+
+		vmiInterface.EXPECT().AddVolume(gomock.Any(), vmName, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, opts *v1.AddVolumeOptions) error {
+				Expect(opts.Name).To(Equal(mountPVCName))
+				Expect(opts.Disk.Disk.ReadOnly).To(BeTrue())
+				Expect(opts.VolumeSource.PersistentVolumeClaim.ClaimName).To(Equal(mountPVCName))
+				Expect(opts.VolumeSource.PersistentVolumeClaim.ReadOnly).To(BeTrue())
+				return nil
+			})
+
+		mount := updateMount(createMount(true))
+		Expect(mount.Status.Phase).To(Equal(snapshotv1.MountPending))
+		Expect(*mount.Status.PersistentVolumeClaimName).To(Equal(mountPVCName))
+	})
+
+	It("should be ready once the volume is attached", func() {
+		vmi := createRunningVMI(mountPVCName)
+		vmi.Status.VolumeStatus = []v1.VolumeStatus{{Name: mountPVCName, Phase: v1.VolumeReady}}
+		Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+
+		mount := createMount(true)
+		pvcName := mountPVCName
+		mount.Status = &snapshotv1.VirtualMachineSnapshotMountStatus{
+			Phase:                     snapshotv1.MountPending,
+			PersistentVolumeClaimName: &pvcName,
+		}
+		mount = updateMount(mount)
+		Expect(mount.Status.Phase).To(Equal(snapshotv1.MountReady))
+		Expect(recorder.Events).To(Receive(ContainSubstring(snapshotMountReadyEvent)))
+	})
+
+	It("should delete the mount once its TTL expired", func() {
+		mount := createMount(false)
+		mount.CreationTimestamp = metav1.NewTime(time.Now().Add(-snapshotv1.DefaultMountDurationTTL))
+		_, err := kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshotMounts(testNamespace).Create(context.Background(), mount, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = controller.updateVMSnapshotMount(mount)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshotMounts(testNamespace).Get(context.Background(), mountName, metav1.GetOptions{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should unplug the volume before releasing a deleted mount", func() {
+		vmi := createRunningVMI(mountPVCName)
+		Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+
+		mount := createMount(true)
+		pvcName := mountPVCName
+		now := metav1.Now()
+		mount.DeletionTimestamp = &now
+		mount.Finalizers = []string{vmSnapshotMountFinalizer}
+		mount.Status = &snapshotv1.VirtualMachineSnapshotMountStatus{
+			Phase:                     snapshotv1.MountReady,
+			PersistentVolumeClaimName: &pvcName,
+		}
+
+		vmiInterface.EXPECT().RemoveVolume(gomock.Any(), vmName, &v1.RemoveVolumeOptions{Name: mountPVCName}).Return(nil)
+
+		mount = updateMount(mount)
+		Expect(mount.Finalizers).To(ContainElement(vmSnapshotMountFinalizer))
+
+		By("releasing the mount once the volume is gone from the VMI")
+		Expect(vmiInformer.GetStore().Update(createRunningVMI())).To(Succeed())
+		_, err := controller.updateVMSnapshotMount(mount)
+		Expect(err).ToNot(HaveOccurred())
+
+		mount, err = kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshotMounts(testNamespace).Get(context.Background(), mountName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mount.Finalizers).To(BeEmpty())
+	})
+})
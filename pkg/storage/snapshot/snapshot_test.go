@@ -233,6 +233,7 @@ var _ = Describe("Snapshot controlleer", func() {
 		var vmSnapshotInformer cache.SharedIndexInformer
 		var vmSnapshotContentSource *framework.FakeControllerSource
 		var vmSnapshotContentInformer cache.SharedIndexInformer
+		var vmSnapshotGroupInformer cache.SharedIndexInformer
 		var vmInformer cache.SharedIndexInformer
 		var vmSource *framework.FakeControllerSource
 		var vmiInformer cache.SharedIndexInformer
@@ -301,6 +302,7 @@ var _ = Describe("Snapshot controlleer", func() {
 
 			vmSnapshotInformer, vmSnapshotSource = testutils.NewFakeInformerWithIndexersFor(&snapshotv1.VirtualMachineSnapshot{}, virtcontroller.GetVirtualMachineSnapshotInformerIndexers())
 			vmSnapshotContentInformer, vmSnapshotContentSource = testutils.NewFakeInformerWithIndexersFor(&snapshotv1.VirtualMachineSnapshotContent{}, virtcontroller.GetVirtualMachineSnapshotContentInformerIndexers())
+			vmSnapshotGroupInformer, _ = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshotGroup{})
 			crInformer, crSource = testutils.NewFakeInformerWithIndexersFor(&appsv1.ControllerRevision{}, virtcontroller.GetControllerRevisionInformerIndexers())
 			vmInformer, vmSource = testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachine{}, virtcontroller.GetVirtualMachineInformerIndexers())
 			vmiInformer, vmiSource = testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachineInstance{}, virtcontroller.GetVMIInformerIndexers())
@@ -319,6 +321,7 @@ var _ = Describe("Snapshot controlleer", func() {
 				Client:                    virtClient,
 				VMSnapshotInformer:        vmSnapshotInformer,
 				VMSnapshotContentInformer: vmSnapshotContentInformer,
+				VMSnapshotGroupInformer:   vmSnapshotGroupInformer,
 				VMInformer:                vmInformer,
 				VMIInformer:               vmiInformer,
 				PodInformer:               podInformer,
@@ -916,6 +919,46 @@ var _ = Describe("Snapshot controlleer", func() {
 				testutils.ExpectEvent(recorder, "SuccessfulVirtualMachineSnapshotContentCreate")
 			})
 
+			DescribeTable("should create VirtualMachineSnapshotContent without the volumes not selected", func(includedVolumes, excludedVolumes []string) {
+				vmSnapshot := createVMSnapshotInProgress()
+				vmSnapshot.Spec.IncludedVolumes = includedVolumes
+				vmSnapshot.Spec.ExcludedVolumes = excludedVolumes
+				vm := createLockedVM()
+				storageClass := createStorageClass()
+				volumeSnapshotClass := createVolumeSnapshotClasses()[0]
+				pvcs := createPersistentVolumeClaims()
+				vmSnapshotContent := createVirtualMachineSnapshotContent(vmSnapshot, vm, nil)
+
+				vmSource.Add(vm)
+				storageClassSource.Add(storageClass)
+				for i := range pvcs {
+					pvcSource.Add(&pvcs[i])
+				}
+				expectVMSnapshotContentCreate(vmSnapshotClient, vmSnapshotContent)
+				vmSnapshotSource.Add(vmSnapshot)
+				addVolumeSnapshotClass(volumeSnapshotClass)
+
+				updatedSnapshot := vmSnapshot.DeepCopy()
+				updatedSnapshot.ResourceVersion = "1"
+				updatedSnapshot.Status = &snapshotv1.VirtualMachineSnapshotStatus{
+					SourceUID:  &vmUID,
+					ReadyToUse: &f,
+					Phase:      snapshotv1.InProgress,
+					Conditions: []snapshotv1.Condition{
+						newProgressingCondition(corev1.ConditionTrue, "Source locked and operation in progress"),
+						newReadyCondition(corev1.ConditionFalse, "Not ready"),
+					},
+					Indications: []snapshotv1.Indication{},
+				}
+				expectVMSnapshotUpdate(vmSnapshotClient, updatedSnapshot)
+
+				controller.processVMSnapshotWorkItem()
+				testutils.ExpectEvent(recorder, "SuccessfulVirtualMachineSnapshotContentCreate")
+			},
+				Entry("with the volume excluded", nil, []string{diskName}),
+				Entry("with another volume included", []string{"scratch"}, nil),
+			)
+
 			It("create VirtualMachineSnapshotContent online snapshot", func() {
 				vmSnapshot := createVMSnapshotInProgress()
 				vm := createLockedVM()
@@ -1493,6 +1536,298 @@ var _ = Describe("Snapshot controlleer", func() {
 				controller.processVMSnapshotContentWorkItem()
 			})
 
+			Context("with snapshot hooks", func() {
+				var vm *v1.VirtualMachine
+				var vmi *v1.VirtualMachineInstance
+				var updatedVMSnapshots []*snapshotv1.VirtualMachineSnapshot
+
+				createHook := func(name string, policy snapshotv1.SnapshotHookFailurePolicy) snapshotv1.SnapshotHook {
+					return snapshotv1.SnapshotHook{
+						Name:          name,
+						Command:       []string{"/usr/bin/" + name, "--quiesce"},
+						FailurePolicy: &policy,
+					}
+				}
+
+				expectGuestExec := func(name string, exitCode int32) *gomock.Call {
+					options := &v1.GuestExecOptions{
+						Command:        "/usr/bin/" + name,
+						Args:           []string{"--quiesce"},
+						TimeoutSeconds: defaultSnapshotHookTimeoutSeconds,
+					}
+					return vmiInterface.EXPECT().GuestExec(context.Background(), vm.Name, options).
+						Return(&v1.GuestExecResult{ExitCode: exitCode, StdOut: name}, nil)
+				}
+
+				expectVMSnapshotGetAndUpdates := func(vmSnapshot *snapshotv1.VirtualMachineSnapshot) {
+					vmSnapshotClient.Fake.PrependReactor("get", "virtualmachinesnapshots", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+						return true, vmSnapshot, nil
+					})
+					vmSnapshotClient.Fake.PrependReactor("update", "virtualmachinesnapshots", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+						update, ok := action.(testing.UpdateAction)
+						Expect(ok).To(BeTrue())
+
+						updateObj := update.GetObject().(*snapshotv1.VirtualMachineSnapshot)
+						updatedVMSnapshots = append(updatedVMSnapshots, updateObj)
+						return true, updateObj, nil
+					})
+				}
+
+				expectVolumeSnapshotsCreation := func() {
+					storageClassSource.Add(createStorageClass())
+					pvcs := createPersistentVolumeClaims()
+					for i := range pvcs {
+						pvcSource.Add(&pvcs[i])
+					}
+
+					volumeSnapshotClass := createVolumeSnapshotClasses()[0]
+					addVolumeSnapshotClass(volumeSnapshotClass)
+
+					vmSnapshotContent := createVMSnapshotContent()
+					vmSnapshotContent.UID = contentUID
+					vmSnapshotContentSource.Add(vmSnapshotContent)
+
+					updatedContent := vmSnapshotContent.DeepCopy()
+					updatedContent.ResourceVersion = "1"
+					updatedContent.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
+						ReadyToUse: &f,
+					}
+					for _, volumeSnapshot := range createVolumeSnapshots(vmSnapshotContent) {
+						updatedContent.Status.VolumeSnapshotStatus = append(updatedContent.Status.VolumeSnapshotStatus, snapshotv1.VolumeSnapshotStatus{
+							VolumeSnapshotName: volumeSnapshot.Name,
+						})
+					}
+
+					expectVolumeSnapshotCreates(k8sSnapshotClient, volumeSnapshotClass.Name, vmSnapshotContent)
+					expectVMSnapshotContentUpdate(vmSnapshotClient, updatedContent)
+				}
+
+				hookResults := func() []snapshotv1.SnapshotHookResult {
+					Expect(updatedVMSnapshots).ToNot(BeEmpty())
+					return updatedVMSnapshots[len(updatedVMSnapshots)-1].Status.HookResults
+				}
+
+				BeforeEach(func() {
+					updatedVMSnapshots = nil
+
+					vm = createLockedVM()
+					vmSource.Add(vm)
+					vmi = createVMI(vm)
+					vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+						Type:          v1.VirtualMachineInstanceAgentConnected,
+						LastProbeTime: metav1.Now(),
+						Status:        corev1.ConditionTrue,
+					})
+				})
+
+				It("should run pre-freeze hooks in order before freezing the vm", func() {
+					vmiSource.Add(vmi)
+					vmSnapshot := createVMSnapshotInProgress()
+					vmSnapshot.Spec.Hooks = &snapshotv1.SnapshotHooks{
+						PreFreeze: []snapshotv1.SnapshotHook{
+							createHook("flush-db", snapshotv1.SnapshotHookFailurePolicyAbort),
+							createHook("flush-cache", snapshotv1.SnapshotHookFailurePolicyAbort),
+						},
+					}
+					vmSnapshotSource.Add(vmSnapshot)
+					expectVMSnapshotGetAndUpdates(vmSnapshot)
+					expectVolumeSnapshotsCreation()
+
+					gomock.InOrder(
+						expectGuestExec("flush-db", 0),
+						expectGuestExec("flush-cache", 0),
+						vmiInterface.EXPECT().Freeze(context.Background(), vm.Name, 0*time.Second).Return(nil),
+					)
+
+					controller.processVMSnapshotContentWorkItem()
+					results := hookResults()
+					Expect(results).To(HaveLen(2))
+					for i, name := range []string{"flush-db", "flush-cache"} {
+						Expect(results[i].Name).To(Equal(name))
+						Expect(results[i].Type).To(Equal(snapshotv1.PreFreezeHook))
+						Expect(results[i].Succeeded).To(BeTrue())
+						Expect(*results[i].ExitCode).To(BeEquivalentTo(0))
+						Expect(results[i].Output).To(Equal(name))
+						Expect(results[i].CompletionTime).ToNot(BeNil())
+					}
+					testutils.ExpectEvent(recorder, "SuccessfulVolumeSnapshotCreate")
+				})
+
+				It("should fail the snapshot without freezing when a pre-freeze hook aborts", func() {
+					vmiSource.Add(vmi)
+					vmSnapshot := createVMSnapshotInProgress()
+					vmSnapshot.Spec.Hooks = &snapshotv1.SnapshotHooks{
+						PreFreeze: []snapshotv1.SnapshotHook{
+							createHook("flush-db", snapshotv1.SnapshotHookFailurePolicyAbort),
+							createHook("flush-cache", snapshotv1.SnapshotHookFailurePolicyAbort),
+						},
+					}
+					vmSnapshotContent := createVMSnapshotContent()
+					vmSnapshotContent.UID = contentUID
+					vmSnapshotContentSource.Add(vmSnapshotContent)
+					expectVMSnapshotGetAndUpdates(vmSnapshot)
+					expectGuestExec("flush-db", 1)
+					addVirtualMachineSnapshot(vmSnapshot)
+
+					controller.processVMSnapshotContentWorkItem()
+					results := hookResults()
+					Expect(results).To(HaveLen(1))
+					Expect(results[0].Succeeded).To(BeFalse())
+					Expect(*results[0].ExitCode).To(BeEquivalentTo(1))
+
+					updated := updatedVMSnapshots[len(updatedVMSnapshots)-1]
+					Expect(updated.Status.Phase).To(Equal(snapshotv1.Failed))
+					Expect(*updated.Status.Error.Message).To(Equal("PreFreeze hook flush-db failed: exited with code 1"))
+					testutils.ExpectEvent(recorder, snapshotHookFailedEvent)
+				})
+
+				It("should continue the snapshot when a hook with the Continue policy fails", func() {
+					vmiSource.Add(vmi)
+					vmSnapshot := createVMSnapshotInProgress()
+					vmSnapshot.Spec.Hooks = &snapshotv1.SnapshotHooks{
+						PreFreeze: []snapshotv1.SnapshotHook{
+							createHook("flush-db", snapshotv1.SnapshotHookFailurePolicyContinue),
+						},
+					}
+					vmSnapshotSource.Add(vmSnapshot)
+					expectVMSnapshotGetAndUpdates(vmSnapshot)
+					expectVolumeSnapshotsCreation()
+					expectGuestExec("flush-db", 1)
+					vmiInterface.EXPECT().Freeze(context.Background(), vm.Name, 0*time.Second).Return(nil)
+
+					controller.processVMSnapshotContentWorkItem()
+					results := hookResults()
+					Expect(results).To(HaveLen(1))
+					Expect(results[0].Succeeded).To(BeFalse())
+					Expect(updatedVMSnapshots[len(updatedVMSnapshots)-1].Status.Phase).To(Equal(snapshotv1.InProgress))
+					testutils.ExpectEvent(recorder, snapshotHookFailedEvent)
+					testutils.ExpectEvent(recorder, "SuccessfulVolumeSnapshotCreate")
+				})
+
+				It("should cap the timeout of pre-freeze hooks by the failure deadline", func() {
+					vmiSource.Add(vmi)
+					vmSnapshot := createVMSnapshotInProgress()
+					vmSnapshot.CreationTimestamp = metav1.NewTime(time.Now().Add(-50 * time.Second))
+					vmSnapshot.Spec.FailureDeadline = &metav1.Duration{Duration: time.Minute}
+					vmSnapshot.Spec.Hooks = &snapshotv1.SnapshotHooks{
+						PreFreeze: []snapshotv1.SnapshotHook{
+							createHook("flush-db", snapshotv1.SnapshotHookFailurePolicyAbort),
+						},
+					}
+					vmSnapshotSource.Add(vmSnapshot)
+					expectVMSnapshotGetAndUpdates(vmSnapshot)
+					expectVolumeSnapshotsCreation()
+
+					gomock.InOrder(
+						vmiInterface.EXPECT().GuestExec(context.Background(), vm.Name, gomock.Any()).
+							DoAndReturn(func(_ context.Context, _ string, options *v1.GuestExecOptions) (*v1.GuestExecResult, error) {
+								Expect(options.TimeoutSeconds).To(BeNumerically(">", 0))
+								Expect(options.TimeoutSeconds).To(BeNumerically("<=", 10))
+								return &v1.GuestExecResult{}, nil
+							}),
+						vmiInterface.EXPECT().Freeze(context.Background(), vm.Name, time.Minute).Return(nil),
+					)
+
+					controller.processVMSnapshotContentWorkItem()
+					results := hookResults()
+					Expect(results).To(HaveLen(1))
+					Expect(results[0].Succeeded).To(BeTrue())
+					testutils.ExpectEvent(recorder, "SuccessfulVolumeSnapshotCreate")
+				})
+
+				It("should fail pre-freeze hooks without running them when no second is left until the failure deadline", func() {
+					vmiSource.Add(vmi)
+					vmSnapshot := createVMSnapshotInProgress()
+					vmSnapshot.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Minute + 500*time.Millisecond))
+					vmSnapshot.Spec.FailureDeadline = &metav1.Duration{Duration: time.Minute}
+					vmSnapshot.Spec.Hooks = &snapshotv1.SnapshotHooks{
+						PreFreeze: []snapshotv1.SnapshotHook{
+							createHook("flush-db", snapshotv1.SnapshotHookFailurePolicyAbort),
+						},
+					}
+					vmSnapshotContent := createVMSnapshotContent()
+					vmSnapshotContent.UID = contentUID
+					vmSnapshotContentSource.Add(vmSnapshotContent)
+					expectVMSnapshotGetAndUpdates(vmSnapshot)
+					addVirtualMachineSnapshot(vmSnapshot)
+
+					controller.processVMSnapshotContentWorkItem()
+					results := hookResults()
+					Expect(results).To(HaveLen(1))
+					Expect(results[0].Succeeded).To(BeFalse())
+					Expect(results[0].ExitCode).To(BeNil())
+
+					updated := updatedVMSnapshots[len(updatedVMSnapshots)-1]
+					Expect(updated.Status.Phase).To(Equal(snapshotv1.Failed))
+					Expect(*updated.Status.Error.Message).To(Equal("PreFreeze hook flush-db failed: failure deadline of the snapshot exceeded"))
+					testutils.ExpectEvent(recorder, snapshotHookFailedEvent)
+				})
+
+				It("should not run hooks which already have a result", func() {
+					vmiSource.Add(vmi)
+					vmSnapshot := createVMSnapshotInProgress()
+					vmSnapshot.Spec.Hooks = &snapshotv1.SnapshotHooks{
+						PreFreeze: []snapshotv1.SnapshotHook{
+							createHook("flush-db", snapshotv1.SnapshotHookFailurePolicyAbort),
+						},
+					}
+					vmSnapshotSource.Add(vmSnapshot)
+					recorded := vmSnapshot.DeepCopy()
+					recorded.Status.HookResults = []snapshotv1.SnapshotHookResult{
+						{
+							Name:      "flush-db",
+							Type:      snapshotv1.PreFreezeHook,
+							Succeeded: true,
+						},
+					}
+					expectVMSnapshotGetAndUpdates(recorded)
+					expectVolumeSnapshotsCreation()
+					vmiInterface.EXPECT().Freeze(context.Background(), vm.Name, 0*time.Second).Return(nil)
+
+					controller.processVMSnapshotContentWorkItem()
+					for _, updated := range updatedVMSnapshots {
+						Expect(updated.Status.HookResults).To(BeEmpty())
+					}
+					testutils.ExpectEvent(recorder, "SuccessfulVolumeSnapshotCreate")
+				})
+
+				It("should run post-thaw hooks after unfreezing the vm", func() {
+					vmi.Status.FSFreezeStatus = "frozen"
+					vmiSource.Add(vmi)
+					vmSnapshot := createVMSnapshotInProgress()
+					vmSnapshot.DeletionTimestamp = timeFunc()
+					vmSnapshot.Spec.Hooks = &snapshotv1.SnapshotHooks{
+						PostThaw: []snapshotv1.SnapshotHook{
+							createHook("resume-db", snapshotv1.SnapshotHookFailurePolicyAbort),
+						},
+					}
+					vmSnapshotContent := createVMSnapshotContent()
+					vmSnapshotContent.UID = contentUID
+					vmSnapshotContent.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
+						ReadyToUse: &f,
+					}
+					vmSnapshotContentSource.Add(vmSnapshotContent)
+
+					updatedContent := vmSnapshotContent.DeepCopy()
+					updatedContent.ResourceVersion = "1"
+					updatedContent.Finalizers = []string{}
+
+					expectVMSnapshotGetAndUpdates(vmSnapshot)
+					expectVMSnapshotContentUpdate(vmSnapshotClient, updatedContent)
+					gomock.InOrder(
+						vmiInterface.EXPECT().Unfreeze(context.Background(), vm.Name).Return(nil),
+						expectGuestExec("resume-db", 0),
+					)
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotContentWorkItem()
+
+					results := hookResults()
+					Expect(results).To(HaveLen(1))
+					Expect(results[0].Type).To(Equal(snapshotv1.PostThawHook))
+					Expect(results[0].Succeeded).To(BeTrue())
+				})
+			})
+
 			DescribeTable("should delete informer", func(crdName string) {
 				crd := &extv1.CustomResourceDefinition{
 					ObjectMeta: metav1.ObjectMeta{
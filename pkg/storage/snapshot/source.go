@@ -58,6 +58,7 @@ type snapshotSource interface {
 	Frozen() (bool, error)
 	Freeze() error
 	Unfreeze() error
+	Exec(command []string, timeoutSeconds int32) (*kubevirtv1.GuestExecResult, error)
 	Spec() (snapshotv1.SourceSpec, error)
 	PersistentVolumeClaims() (map[string]string, error)
 }
@@ -368,6 +369,21 @@ func (s *vmSnapshotSource) Unfreeze() error {
 	return nil
 }
 
+func (s *vmSnapshotSource) Exec(command []string, timeoutSeconds int32) (*kubevirtv1.GuestExecResult, error) {
+	if !s.Locked() {
+		return nil, fmt.Errorf("attempting to run a hook in unlocked VM")
+	}
+
+	options := &kubevirtv1.GuestExecOptions{
+		Command:        command[0],
+		Args:           command[1:],
+		TimeoutSeconds: timeoutSeconds,
+	}
+
+	defer timeTrack(time.Now(), fmt.Sprintf("Executing command in vmi %s", s.vm.Name))
+	return s.controller.Client.VirtualMachineInstance(s.vm.Namespace).GuestExec(context.Background(), s.vm.Name, options)
+}
+
 func (s *vmSnapshotSource) PersistentVolumeClaims() (map[string]string, error) {
 	return storagetypes.GetPVCsFromVolumes(s.vm.Spec.Template.Spec.Volumes), nil
 }
@@ -44,6 +44,27 @@ func timeTrack(start time.Time, name string) {
 	log.Log.Infof("%s took %s", name, elapsed)
 }
 
+// volumeSelected returns whether a volume is part of a snapshot or restore
+// limited by lists of included and excluded volumes
+func volumeSelected(name string, includedVolumes, excludedVolumes []string) bool {
+	for _, excluded := range excludedVolumes {
+		if excluded == name {
+			return false
+		}
+	}
+
+	if len(includedVolumes) == 0 {
+		return true
+	}
+
+	for _, included := range includedVolumes {
+		if included == name {
+			return true
+		}
+	}
+	return false
+}
+
 func cacheKeyFunc(namespace, name string) string {
 	return fmt.Sprintf("%s/%s", namespace, name)
 }
@@ -103,6 +103,9 @@ func PVCNameFromVirtVolume(volume *virtv1.Volume) string {
 		return volume.PersistentVolumeClaim.ClaimName
 	} else if volume.MemoryDump != nil {
 		return volume.MemoryDump.ClaimName
+	} else if volume.Overlay != nil {
+		// the base claim is shared read-only, the overlay claim holds the data of the volume
+		return volume.Overlay.ClaimName
 	}
 
 	return ""
@@ -25,6 +25,8 @@ import (
 	kubev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
+
+	virtv1 "kubevirt.io/api/core/v1"
 )
 
 var _ = Describe("PVC utils test", func() {
@@ -98,4 +100,14 @@ var _ = Describe("PVC utils test", func() {
 		})
 	})
 
+	It("should return the overlay claim of an overlay volume", func() {
+		volume := &virtv1.Volume{
+			Name: "overlay",
+			VolumeSource: virtv1.VolumeSource{
+				Overlay: &virtv1.OverlayVolumeSource{ClaimName: "overlay-pvc", BaseClaimName: "base-pvc"},
+			},
+		}
+		Expect(PVCNameFromVirtVolume(volume)).To(Equal("overlay-pvc"))
+	})
+
 })
@@ -37,6 +37,9 @@ const NonRootUserString = "qemu"
 const RootUser = 0
 const memoryDumpOverhead = 100 * 1024 * 1024
 
+// NVMeMaxSerialLength is the longest serial number an NVMe controller reports
+const NVMeMaxSerialLength = 20
+
 func IsNonRootVMI(vmi *v1.VirtualMachineInstance) bool {
 	_, ok := vmi.Annotations[v1.DeprecatedNonRootVMIAnnotation]
 
@@ -73,6 +76,16 @@ func IsVMIVirtiofsEnabled(vmi *v1.VirtualMachineInstance) bool {
 	return false
 }
 
+// IsFilesystemVolume checks if the volume is shared with the guest through a virtiofs filesystem
+func IsFilesystemVolume(vmi *v1.VirtualMachineInstance, volumeName string) bool {
+	for _, fs := range vmi.Spec.Domain.Devices.Filesystems {
+		if fs.Name == volumeName && fs.Virtiofs != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // Check if a VMI spec requests a HostDevice
 func IsHostDevVMI(vmi *v1.VirtualMachineInstance) bool {
 	if vmi.Spec.Domain.Devices.HostDevices != nil && len(vmi.Spec.Domain.Devices.HostDevices) != 0 {
@@ -218,6 +231,62 @@ func IsReadOnlyDisk(disk *v1.Disk) bool {
 	return isReadOnlyCDRom
 }
 
+// IsHotplugVolume checks if the volume source is marked as hotpluggable
+func IsHotplugVolume(volume *v1.Volume) bool {
+	switch {
+	case volume.DataVolume != nil:
+		return volume.DataVolume.Hotpluggable
+	case volume.PersistentVolumeClaim != nil:
+		return volume.PersistentVolumeClaim.Hotpluggable
+	case volume.ContainerDisk != nil:
+		return volume.ContainerDisk.Hotpluggable
+	case volume.ConfigMap != nil:
+		return volume.ConfigMap.Hotpluggable
+	case volume.Secret != nil:
+		return volume.Secret.Hotpluggable
+	case volume.ServiceAccount != nil:
+		return volume.ServiceAccount.Hotpluggable
+	}
+	return false
+}
+
+// IsHotplugConfigVolume checks if the volume is a hotplugged ConfigMap, Secret or ServiceAccount,
+// which are attached as iso images
+func IsHotplugConfigVolume(volume *v1.Volume) bool {
+	return IsHotplugVolume(volume) && (volume.ConfigMap != nil || volume.Secret != nil || volume.ServiceAccount != nil)
+}
+
+// IsHotplugContainerDiskVolume checks if the volume is a hotplugged ContainerDisk
+func IsHotplugContainerDiskVolume(volume *v1.Volume) bool {
+	return volume.ContainerDisk != nil && volume.ContainerDisk.Hotpluggable
+}
+
+// HotplugVolumeSourceToVolumeSource converts a hotplug volume source into a volume source marked as hotpluggable
+func HotplugVolumeSourceToVolumeSource(source *v1.HotplugVolumeSource) v1.VolumeSource {
+	volumeSource := v1.VolumeSource{}
+	switch {
+	case source.PersistentVolumeClaim != nil:
+		volumeSource.PersistentVolumeClaim = source.PersistentVolumeClaim.DeepCopy()
+		volumeSource.PersistentVolumeClaim.Hotpluggable = true
+	case source.DataVolume != nil:
+		volumeSource.DataVolume = source.DataVolume.DeepCopy()
+		volumeSource.DataVolume.Hotpluggable = true
+	case source.ContainerDisk != nil:
+		volumeSource.ContainerDisk = source.ContainerDisk.DeepCopy()
+		volumeSource.ContainerDisk.Hotpluggable = true
+	case source.ConfigMap != nil:
+		volumeSource.ConfigMap = source.ConfigMap.DeepCopy()
+		volumeSource.ConfigMap.Hotpluggable = true
+	case source.Secret != nil:
+		volumeSource.Secret = source.Secret.DeepCopy()
+		volumeSource.Secret.Hotpluggable = true
+	case source.ServiceAccount != nil:
+		volumeSource.ServiceAccount = source.ServiceAccount.DeepCopy()
+		volumeSource.ServiceAccount.Hotpluggable = true
+	}
+	return volumeSource
+}
+
 // AlignImageSizeTo1MiB rounds down the size to the nearest multiple of 1MiB
 // A warning or an error may get logged
 // The caller is responsible for ensuring the rounded-down size is not 0
@@ -0,0 +1,307 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+const filesystemHotplugNotEnabled = "filesystem hotplug is not enabled, spec.domain.devices.filesystemHotplug must be set before the VMI starts"
+
+func (app *SubresourceAPIApp) addFilesystemRequestHandler(request *restful.Request, response *restful.Response, ephemeral bool) {
+	if err := app.filesystemHotplugFeatureGatesEnabled("Add"); err != nil {
+		writeError(err, response)
+		return
+	}
+
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+	opts, err := decodeInterfaceRequest(request, &v1.AddFilesystemOptions{})
+	if err != nil {
+		writeError(errors.NewBadRequest(err.Error()), response)
+		return
+	}
+
+	if opts.Name == "" {
+		writeError(errors.NewBadRequest("AddFilesystemOptions requires name to be set"), response)
+		return
+	} else if opts.VolumeSource == nil || (opts.VolumeSource.PersistentVolumeClaim == nil && opts.VolumeSource.DataVolume == nil) {
+		writeError(errors.NewBadRequest("AddFilesystemOptions requires a PersistentVolumeClaim or DataVolume VolumeSource"), response)
+		return
+	}
+	setHotpluggable(opts.VolumeSource)
+
+	// patch the VMI if ephemeral, else set as a request on the VM to both make permanent and hotplug.
+	if ephemeral {
+		if err := app.vmiFilesystemPatch(name, namespace, opts.DryRun, func(spec *v1.VirtualMachineInstanceSpec) error {
+			return applyAddFilesystem(spec, opts)
+		}); err != nil {
+			writeError(err, response)
+			return
+		}
+	} else {
+		if err := app.vmVolumePatchStatus(name, namespace, &v1.VirtualMachineVolumeRequest{AddFilesystemOptions: opts}); err != nil {
+			writeError(err, response)
+			return
+		}
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func (app *SubresourceAPIApp) removeFilesystemRequestHandler(request *restful.Request, response *restful.Response, ephemeral bool) {
+	if err := app.filesystemHotplugFeatureGatesEnabled("Remove"); err != nil {
+		writeError(err, response)
+		return
+	}
+
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+	opts, err := decodeInterfaceRequest(request, &v1.RemoveFilesystemOptions{})
+	if err != nil {
+		writeError(errors.NewBadRequest(err.Error()), response)
+		return
+	}
+
+	if opts.Name == "" {
+		writeError(errors.NewBadRequest("RemoveFilesystemOptions requires name to be set"), response)
+		return
+	}
+
+	// patch the VMI if ephemeral, else set as a request on the VM to both make permanent and unplug.
+	if ephemeral {
+		if err := app.vmiFilesystemPatch(name, namespace, opts.DryRun, func(spec *v1.VirtualMachineInstanceSpec) error {
+			return applyRemoveFilesystem(spec, opts)
+		}); err != nil {
+			writeError(err, response)
+			return
+		}
+	} else {
+		if err := app.vmVolumePatchStatus(name, namespace, &v1.VirtualMachineVolumeRequest{RemoveFilesystemOptions: opts}); err != nil {
+			writeError(err, response)
+			return
+		}
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+// VMAddFilesystemRequestHandler handles the subresource for hot plugging a virtiofs filesystem and persisting it in the VM.
+func (app *SubresourceAPIApp) VMAddFilesystemRequestHandler(request *restful.Request, response *restful.Response) {
+	app.addFilesystemRequestHandler(request, response, false)
+}
+
+// VMRemoveFilesystemRequestHandler handles the subresource for hot unplugging a virtiofs filesystem and removing it from the VM.
+func (app *SubresourceAPIApp) VMRemoveFilesystemRequestHandler(request *restful.Request, response *restful.Response) {
+	app.removeFilesystemRequestHandler(request, response, false)
+}
+
+// VMIAddFilesystemRequestHandler handles the subresource for hot plugging a virtiofs filesystem.
+func (app *SubresourceAPIApp) VMIAddFilesystemRequestHandler(request *restful.Request, response *restful.Response) {
+	app.addFilesystemRequestHandler(request, response, true)
+}
+
+// VMIRemoveFilesystemRequestHandler handles the subresource for hot unplugging a virtiofs filesystem.
+func (app *SubresourceAPIApp) VMIRemoveFilesystemRequestHandler(request *restful.Request, response *restful.Response) {
+	app.removeFilesystemRequestHandler(request, response, true)
+}
+
+func (app *SubresourceAPIApp) filesystemHotplugFeatureGatesEnabled(action string) *errors.StatusError {
+	if !app.clusterConfig.HotplugVolumesEnabled() {
+		return errors.NewBadRequest(fmt.Sprintf("Unable to %s Filesystem because the %q feature gate is not enabled.", action, virtconfig.HotplugVolumesGate))
+	}
+	if !app.clusterConfig.VirtiofsEnabled() {
+		return errors.NewBadRequest(fmt.Sprintf("Unable to %s Filesystem because the %q feature gate is not enabled.", action, virtconfig.VirtIOFSGate))
+	}
+	return nil
+}
+
+func applyAddFilesystem(spec *v1.VirtualMachineInstanceSpec, opts *v1.AddFilesystemOptions) error {
+	if !spec.Domain.Devices.FilesystemHotplug {
+		return fmt.Errorf(filesystemHotplugNotEnabled)
+	}
+
+	sourceName := volumeSourceName(opts.VolumeSource)
+	for _, volume := range spec.Volumes {
+		if volumeNameExists(volume, opts.Name) {
+			return fmt.Errorf("unable to add filesystem [%s] because volume with that name already exists", opts.Name)
+		}
+		if volumeSourceExists(volume, sourceName) {
+			return fmt.Errorf("unable to add filesystem source [%s] because it already exists", sourceName)
+		}
+	}
+
+	spec.Volumes = append(spec.Volumes, v1.Volume{
+		Name: opts.Name,
+		VolumeSource: v1.VolumeSource{
+			PersistentVolumeClaim: opts.VolumeSource.PersistentVolumeClaim,
+			DataVolume:            opts.VolumeSource.DataVolume,
+		},
+	})
+	spec.Domain.Devices.Filesystems = append(spec.Domain.Devices.Filesystems, v1.Filesystem{
+		Name:     opts.Name,
+		Virtiofs: &v1.FilesystemVirtiofs{},
+	})
+	return nil
+}
+
+func applyRemoveFilesystem(spec *v1.VirtualMachineInstanceSpec, opts *v1.RemoveFilesystemOptions) error {
+	if !spec.Domain.Devices.FilesystemHotplug {
+		return fmt.Errorf(filesystemHotplugNotEnabled)
+	}
+
+	var filesystems []v1.Filesystem
+	for _, fs := range spec.Domain.Devices.Filesystems {
+		if fs.Name != opts.Name {
+			filesystems = append(filesystems, fs)
+		}
+	}
+	if len(filesystems) == len(spec.Domain.Devices.Filesystems) {
+		return fmt.Errorf("unable to remove filesystem [%s] because it does not exist", opts.Name)
+	}
+
+	var volumes []v1.Volume
+	for _, volume := range spec.Volumes {
+		if volume.Name != opts.Name {
+			volumes = append(volumes, volume)
+			continue
+		}
+		if !volumeHotpluggable(volume) {
+			return fmt.Errorf("unable to remove filesystem [%s] because it is not hotpluggable", opts.Name)
+		}
+	}
+
+	spec.Volumes = volumes
+	spec.Domain.Devices.Filesystems = filesystems
+	return nil
+}
+
+// verifyFilesystemOption checks that a filesystem request can be applied on the given spec
+func verifyFilesystemOption(spec *v1.VirtualMachineInstanceSpec, volumeRequest *v1.VirtualMachineVolumeRequest) error {
+	specCopy := spec.DeepCopy()
+	if volumeRequest.AddFilesystemOptions != nil {
+		return applyAddFilesystem(specCopy, volumeRequest.AddFilesystemOptions)
+	}
+	return applyRemoveFilesystem(specCopy, volumeRequest.RemoveFilesystemOptions)
+}
+
+func filesystemRequestName(request v1.VirtualMachineVolumeRequest) string {
+	if request.AddFilesystemOptions != nil {
+		return request.AddFilesystemOptions.Name
+	}
+	if request.RemoveFilesystemOptions != nil {
+		return request.RemoveFilesystemOptions.Name
+	}
+	return ""
+}
+
+func addFilesystemRequests(vm *v1.VirtualMachine, volumeRequest *v1.VirtualMachineVolumeRequest, vmCopy *v1.VirtualMachine) error {
+	name := filesystemRequestName(*volumeRequest)
+	for _, request := range vm.Status.VolumeRequests {
+		if filesystemRequestName(request) == name || addVolumeRequestExists(request, name) || removeVolumeRequestExists(request, name) {
+			return fmt.Errorf("a volume request for filesystem [%s] already exists and is still being processed", name)
+		}
+	}
+	vmCopy.Status.VolumeRequests = append(vm.Status.VolumeRequests, *volumeRequest)
+	return nil
+}
+
+func generateVMIFilesystemPatch(oldVMI, newVMI *v1.VirtualMachineInstance) (string, error) {
+	volumeVerb := "add"
+	if len(oldVMI.Spec.Volumes) > 0 {
+		volumeVerb = "replace"
+	}
+	filesystemVerb := "add"
+	if len(oldVMI.Spec.Domain.Devices.Filesystems) > 0 {
+		filesystemVerb = "replace"
+	}
+
+	oldVolumesJson, err := json.Marshal(oldVMI.Spec.Volumes)
+	if err != nil {
+		return "", err
+	}
+	newVolumesJson, err := json.Marshal(newVMI.Spec.Volumes)
+	if err != nil {
+		return "", err
+	}
+	oldFilesystemsJson, err := json.Marshal(oldVMI.Spec.Domain.Devices.Filesystems)
+	if err != nil {
+		return "", err
+	}
+	newFilesystemsJson, err := json.Marshal(newVMI.Spec.Domain.Devices.Filesystems)
+	if err != nil {
+		return "", err
+	}
+
+	testVolumes := fmt.Sprintf(`{ "op": "test", "path": "/spec/volumes", "value": %s}`, string(oldVolumesJson))
+	updateVolumes := fmt.Sprintf(`{ "op": "%s", "path": "/spec/volumes", "value": %s}`, volumeVerb, string(newVolumesJson))
+
+	testFilesystems := fmt.Sprintf(`{ "op": "test", "path": "/spec/domain/devices/filesystems", "value": %s}`, string(oldFilesystemsJson))
+	updateFilesystems := fmt.Sprintf(`{ "op": "%s", "path": "/spec/domain/devices/filesystems", "value": %s}`, filesystemVerb, string(newFilesystemsJson))
+
+	return fmt.Sprintf("[%s, %s, %s, %s]", testVolumes, testFilesystems, updateVolumes, updateFilesystems), nil
+}
+
+func (app *SubresourceAPIApp) vmiFilesystemPatch(name, namespace string, dryRun []string, apply func(spec *v1.VirtualMachineInstanceSpec) error) *errors.StatusError {
+	vmi, statErr := app.FetchVirtualMachineInstance(namespace, name)
+	if statErr != nil {
+		return statErr
+	}
+
+	if !vmi.IsRunning() {
+		return errors.NewConflict(v1.Resource("virtualmachineinstance"), name, fmt.Errorf(vmiNotRunning))
+	}
+
+	vmiCopy := vmi.DeepCopy()
+	if err := apply(&vmiCopy.Spec); err != nil {
+		return errors.NewConflict(v1.Resource("virtualmachineinstance"), name, err)
+	}
+
+	patch, err := generateVMIFilesystemPatch(vmi, vmiCopy)
+	if err != nil {
+		return errors.NewConflict(v1.Resource("virtualmachineinstance"), name, err)
+	}
+
+	log.Log.Object(vmi).V(4).Infof("Patching VMI: %s", patch)
+	if _, err := app.virtCli.VirtualMachineInstance(vmi.Namespace).Patch(context.Background(), vmi.Name, types.JSONPatchType, []byte(patch), &k8smetav1.PatchOptions{DryRun: dryRun}); err != nil {
+		log.Log.Object(vmi).Errorf("unable to patch vmi: %v", err)
+		if errors.IsInvalid(err) {
+			if statErr, ok := err.(*errors.StatusError); ok {
+				return statErr
+			}
+		}
+		return errors.NewInternalError(fmt.Errorf("unable to patch vmi: %v", err))
+	}
+	return nil
+}
@@ -0,0 +1,311 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/util/status"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("Filesystem Hotplug Subresource", func() {
+	var (
+		request    *restful.Request
+		response   *restful.Response
+		recorder   *httptest.ResponseRecorder
+		virtClient *kubecli.MockKubevirtClient
+		vmiClient  *kubecli.MockVirtualMachineInstanceInterface
+		vmClient   *kubecli.MockVirtualMachineInterface
+	)
+
+	kv := &v1.KubeVirt{
+		ObjectMeta: k8smetav1.ObjectMeta{
+			Name:      "kubevirt",
+			Namespace: "kubevirt",
+		},
+		Spec: v1.KubeVirtSpec{
+			Configuration: v1.KubeVirtConfiguration{
+				DeveloperConfiguration: &v1.DeveloperConfiguration{},
+			},
+		},
+		Status: v1.KubeVirtStatus{
+			Phase: v1.KubeVirtPhaseDeploying,
+		},
+	}
+
+	config, _, kvInformer := testutils.NewFakeClusterConfigUsingKV(kv)
+	app := SubresourceAPIApp{}
+
+	enableFeatureGates := func(featureGates ...string) {
+		kvConfig := kv.DeepCopy()
+		kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = featureGates
+		testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+	}
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		virtClient = kubecli.NewMockKubevirtClient(ctrl)
+		vmiClient = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+		vmClient = kubecli.NewMockVirtualMachineInterface(ctrl)
+		virtClient.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiClient).AnyTimes()
+		virtClient.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmClient).AnyTimes()
+
+		app.virtCli = virtClient
+		app.statusUpdater = status.NewVMStatusUpdater(app.virtCli)
+		app.clusterConfig = config
+
+		request = restful.NewRequest(&http.Request{})
+		request.PathParameters()["name"] = testVMIName
+		request.PathParameters()["namespace"] = k8smetav1.NamespaceDefault
+		recorder = httptest.NewRecorder()
+		response = restful.NewResponse(recorder)
+
+		enableFeatureGates(virtconfig.HotplugVolumesGate, virtconfig.VirtIOFSGate)
+	})
+
+	AfterEach(func() {
+		testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kv)
+	})
+
+	setBody := func(opts interface{}) {
+		body, err := json.Marshal(opts)
+		Expect(err).ToNot(HaveOccurred())
+		request.Request.Body = &readCloserWrapper{bytes.NewReader(body)}
+	}
+
+	newRunningVMI := func(filesystemHotplug bool) *v1.VirtualMachineInstance {
+		vmi := &v1.VirtualMachineInstance{
+			ObjectMeta: k8smetav1.ObjectMeta{Name: testVMIName, Namespace: k8smetav1.NamespaceDefault},
+		}
+		vmi.Spec.Domain.Devices.FilesystemHotplug = filesystemHotplug
+		vmi.Status.Phase = v1.Running
+		return vmi
+	}
+
+	withHotplugFilesystem := func(vmi *v1.VirtualMachineInstance, name string) *v1.VirtualMachineInstance {
+		vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+			Name: name,
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					Hotpluggable: true,
+				},
+			},
+		})
+		vmi.Spec.Domain.Devices.Filesystems = append(vmi.Spec.Domain.Devices.Filesystems, v1.Filesystem{
+			Name:     name,
+			Virtiofs: &v1.FilesystemVirtiofs{},
+		})
+		return vmi
+	}
+
+	newVM := func(vmi *v1.VirtualMachineInstance, volumeRequests ...v1.VirtualMachineVolumeRequest) *v1.VirtualMachine {
+		vm := newMinimalVM(testVMIName)
+		vm.Namespace = k8smetav1.NamespaceDefault
+		vm.Spec.Template = &v1.VirtualMachineInstanceTemplateSpec{Spec: vmi.Spec}
+		vm.Status.VolumeRequests = volumeRequests
+		return vm
+	}
+
+	addOpts := func() *v1.AddFilesystemOptions {
+		return &v1.AddFilesystemOptions{
+			Name: "dataset",
+			VolumeSource: &v1.HotplugVolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "dataset-pvc"},
+				},
+			},
+		}
+	}
+
+	Context("Add Filesystem", func() {
+		DescribeTable("should fail without the required feature gates", func(featureGates ...string) {
+			enableFeatureGates(featureGates...)
+			setBody(addOpts())
+
+			app.VMIAddFilesystemRequestHandler(request, response)
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		},
+			Entry("without HotplugVolumes", virtconfig.VirtIOFSGate),
+			Entry("without ExperimentalVirtiofsSupport", virtconfig.HotplugVolumesGate),
+		)
+
+		DescribeTable("should reject an invalid request", func(opts *v1.AddFilesystemOptions) {
+			setBody(opts)
+
+			app.VMIAddFilesystemRequestHandler(request, response)
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		},
+			Entry("without name", &v1.AddFilesystemOptions{VolumeSource: addOpts().VolumeSource}),
+			Entry("without volume source", &v1.AddFilesystemOptions{Name: "dataset"}),
+			Entry("with a config map source", &v1.AddFilesystemOptions{
+				Name: "dataset",
+				VolumeSource: &v1.HotplugVolumeSource{
+					ConfigMap: &v1.ConfigMapVolumeSource{},
+				},
+			}),
+		)
+
+		It("should fail if filesystem hotplug is not enabled on the VMI", func() {
+			setBody(addOpts())
+			vmiClient.EXPECT().Get(context.Background(), testVMIName, &k8smetav1.GetOptions{}).Return(newRunningVMI(false), nil)
+
+			app.VMIAddFilesystemRequestHandler(request, response)
+			Expect(recorder.Code).To(Equal(http.StatusConflict))
+		})
+
+		It("should fail if the filesystem already exists", func() {
+			setBody(addOpts())
+			vmi := withHotplugFilesystem(newRunningVMI(true), "dataset")
+			vmiClient.EXPECT().Get(context.Background(), testVMIName, &k8smetav1.GetOptions{}).Return(vmi, nil)
+
+			app.VMIAddFilesystemRequestHandler(request, response)
+			Expect(recorder.Code).To(Equal(http.StatusConflict))
+		})
+
+		It("should patch the VMI volumes and filesystems", func() {
+			opts := addOpts()
+			opts.DryRun = getDryRunOption()
+			setBody(opts)
+			vmiClient.EXPECT().Get(context.Background(), testVMIName, &k8smetav1.GetOptions{}).Return(newRunningVMI(true), nil)
+			vmiClient.EXPECT().Patch(context.Background(), testVMIName, types.JSONPatchType, gomock.Any(), &k8smetav1.PatchOptions{DryRun: getDryRunOption()}).DoAndReturn(
+				func(ctx context.Context, name string, patchType types.PatchType, body interface{}, opts *k8smetav1.PatchOptions, _ ...string) (interface{}, interface{}) {
+					Expect(string(body.([]byte))).To(Equal(`[{ "op": "test", "path": "/spec/volumes", "value": null}, ` +
+						`{ "op": "test", "path": "/spec/domain/devices/filesystems", "value": null}, ` +
+						`{ "op": "add", "path": "/spec/volumes", "value": [{"name":"dataset","persistentVolumeClaim":{"claimName":"dataset-pvc","hotpluggable":true}}]}, ` +
+						`{ "op": "add", "path": "/spec/domain/devices/filesystems", "value": [{"name":"dataset","virtiofs":{}}]}]`))
+					return nil, nil
+				})
+
+			app.VMIAddFilesystemRequestHandler(request, response)
+			Expect(recorder.Code).To(Equal(http.StatusAccepted))
+		})
+	})
+
+	Context("Remove Filesystem", func() {
+		It("should fail if the filesystem does not exist", func() {
+			setBody(&v1.RemoveFilesystemOptions{Name: "dataset"})
+			vmiClient.EXPECT().Get(context.Background(), testVMIName, &k8smetav1.GetOptions{}).Return(newRunningVMI(true), nil)
+
+			app.VMIRemoveFilesystemRequestHandler(request, response)
+			Expect(recorder.Code).To(Equal(http.StatusConflict))
+		})
+
+		It("should fail if the filesystem was not hotplugged", func() {
+			setBody(&v1.RemoveFilesystemOptions{Name: "dataset"})
+			vmi := withHotplugFilesystem(newRunningVMI(true), "dataset")
+			vmi.Spec.Volumes[0].PersistentVolumeClaim.Hotpluggable = false
+			vmiClient.EXPECT().Get(context.Background(), testVMIName, &k8smetav1.GetOptions{}).Return(vmi, nil)
+
+			app.VMIRemoveFilesystemRequestHandler(request, response)
+			Expect(recorder.Code).To(Equal(http.StatusConflict))
+		})
+
+		It("should remove the volume and the filesystem", func() {
+			setBody(&v1.RemoveFilesystemOptions{Name: "dataset"})
+			vmi := withHotplugFilesystem(withHotplugFilesystem(newRunningVMI(true), "other"), "dataset")
+			vmiClient.EXPECT().Get(context.Background(), testVMIName, &k8smetav1.GetOptions{}).Return(vmi, nil)
+			vmiClient.EXPECT().Patch(context.Background(), testVMIName, types.JSONPatchType, gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, name string, patchType types.PatchType, body interface{}, opts *k8smetav1.PatchOptions, _ ...string) (interface{}, interface{}) {
+					Expect(string(body.([]byte))).To(ContainSubstring(`{ "op": "replace", "path": "/spec/volumes", "value": [{"name":"other","persistentVolumeClaim":{"claimName":"","hotpluggable":true}}]}`))
+					Expect(string(body.([]byte))).To(ContainSubstring(`{ "op": "replace", "path": "/spec/domain/devices/filesystems", "value": [{"name":"other","virtiofs":{}}]}`))
+					return nil, nil
+				})
+
+			app.VMIRemoveFilesystemRequestHandler(request, response)
+			Expect(recorder.Code).To(Equal(http.StatusAccepted))
+		})
+	})
+
+	Context("VM Filesystem requests", func() {
+		It("should fail to add if filesystem hotplug is not enabled on the VM template", func() {
+			setBody(addOpts())
+			vmClient.EXPECT().Get(context.Background(), testVMIName, &k8smetav1.GetOptions{}).Return(newVM(newRunningVMI(false)), nil)
+
+			app.VMAddFilesystemRequestHandler(request, response)
+			Expect(recorder.Code).To(Equal(http.StatusConflict))
+		})
+
+		It("should fail to add if a request for the filesystem is still being processed", func() {
+			setBody(addOpts())
+			vm := newVM(newRunningVMI(true), v1.VirtualMachineVolumeRequest{RemoveFilesystemOptions: &v1.RemoveFilesystemOptions{Name: "dataset"}})
+			vmClient.EXPECT().Get(context.Background(), testVMIName, &k8smetav1.GetOptions{}).Return(vm, nil)
+
+			app.VMAddFilesystemRequestHandler(request, response)
+			Expect(recorder.Code).To(Equal(http.StatusConflict))
+		})
+
+		It("should add an add filesystem request to the VM status", func() {
+			opts := addOpts()
+			opts.DryRun = getDryRunOption()
+			setBody(opts)
+			vm := newVM(newRunningVMI(true))
+			vmClient.EXPECT().Get(context.Background(), testVMIName, &k8smetav1.GetOptions{}).Return(vm, nil)
+			vmClient.EXPECT().PatchStatus(context.Background(), testVMIName, types.JSONPatchType, gomock.Any(), &k8smetav1.PatchOptions{DryRun: getDryRunOption()}).DoAndReturn(
+				func(ctx context.Context, name string, patchType types.PatchType, body interface{}, opts *k8smetav1.PatchOptions) (interface{}, interface{}) {
+					Expect(string(body.([]byte))).To(Equal(`[{"op":"test","path":"/status/volumeRequests","value":null},` +
+						`{"op":"add","path":"/status/volumeRequests","value":[{"addFilesystemOptions":{"name":"dataset","volumeSource":{"persistentVolumeClaim":{"claimName":"dataset-pvc","hotpluggable":true}},"dryRun":["All"]}}]}]`))
+					return vm, nil
+				})
+
+			app.VMAddFilesystemRequestHandler(request, response)
+			Expect(recorder.Code).To(Equal(http.StatusAccepted))
+		})
+
+		It("should fail to remove a filesystem missing from the VM template", func() {
+			setBody(&v1.RemoveFilesystemOptions{Name: "dataset"})
+			vmClient.EXPECT().Get(context.Background(), testVMIName, &k8smetav1.GetOptions{}).Return(newVM(newRunningVMI(true)), nil)
+
+			app.VMRemoveFilesystemRequestHandler(request, response)
+			Expect(recorder.Code).To(Equal(http.StatusConflict))
+		})
+
+		It("should add a remove filesystem request to the VM status", func() {
+			setBody(&v1.RemoveFilesystemOptions{Name: "dataset"})
+			vm := newVM(withHotplugFilesystem(newRunningVMI(true), "dataset"))
+			vmClient.EXPECT().Get(context.Background(), testVMIName, &k8smetav1.GetOptions{}).Return(vm, nil)
+			vmClient.EXPECT().PatchStatus(context.Background(), testVMIName, types.JSONPatchType, gomock.Any(), &k8smetav1.PatchOptions{}).DoAndReturn(
+				func(ctx context.Context, name string, patchType types.PatchType, body interface{}, opts *k8smetav1.PatchOptions) (interface{}, interface{}) {
+					Expect(string(body.([]byte))).To(ContainSubstring(`"value":[{"removeFilesystemOptions":{"name":"dataset"}}]`))
+					return vm, nil
+				})
+
+			app.VMRemoveFilesystemRequestHandler(request, response)
+			Expect(recorder.Code).To(Equal(http.StatusAccepted))
+		})
+	})
+})
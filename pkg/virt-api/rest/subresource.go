@@ -20,6 +20,7 @@
 package rest
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	goerror "errors"
@@ -839,6 +840,32 @@ func (app *SubresourceAPIApp) SoftRebootVMIRequestHandler(request *restful.Reque
 	app.putRequestHandler(request, response, validate, getURL, false)
 }
 
+// FSTrimVMIRequestHandler queues a trim of the guest filesystems of the VMI on its node,
+// the outcome is reported by virt-handler as events on the VMI
+func (app *SubresourceAPIApp) FSTrimVMIRequestHandler(request *restful.Request, response *restful.Response) {
+	if !app.clusterConfig.FilesystemTrimEnabled() {
+		writeError(errors.NewBadRequest("Unable to trim the guest filesystems because FilesystemTrim feature gate is not enabled."), response)
+		return
+	}
+
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf(vmiNotRunning))
+		}
+		condManager := controller.NewVirtualMachineInstanceConditionManager()
+		if !condManager.HasConditionWithStatus(vmi, v1.VirtualMachineInstanceAgentConnected, v12.ConditionTrue) {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI does not have guest agent connected"))
+		}
+		return nil
+	}
+
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.FSTrimURI(vmi)
+	}
+
+	app.putRequestHandler(request, response, validate, getURL, false)
+}
+
 func (app *SubresourceAPIApp) fetchVirtualMachine(name string, namespace string) (*v1.VirtualMachine, *errors.StatusError) {
 
 	vm, err := app.virtCli.VirtualMachine(namespace).Get(context.Background(), name, &k8smetav1.GetOptions{})
@@ -905,6 +932,84 @@ func writeError(error *errors.StatusError, response *restful.Response) {
 	}
 }
 
+// GuestExecVMIRequestHandler executes a command in the guest through the guest agent
+// and returns its exit code and output
+func (app *SubresourceAPIApp) GuestExecVMIRequestHandler(request *restful.Request, response *restful.Response) {
+	if !app.clusterConfig.SnapshotEnabled() {
+		writeError(errors.NewBadRequest(fmt.Sprintf(featureGateDisabledErrFmt, virtconfig.SnapshotGate)), response)
+		return
+	}
+
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("request body with the command to execute is required"), response)
+		return
+	}
+
+	options := &v1.GuestExecOptions{}
+	if err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(options); err != nil {
+		writeError(errors.NewBadRequest(fmt.Sprintf(unmarshalRequestErrFmt, err)), response)
+		return
+	}
+
+	if options.Command == "" {
+		writeError(errors.NewBadRequest("command is required"), response)
+		return
+	}
+
+	if options.TimeoutSeconds <= 0 || options.TimeoutSeconds > v1.GuestExecMaxTimeoutSeconds {
+		writeError(errors.NewBadRequest(fmt.Sprintf("timeoutSeconds must be between 1 and %d", v1.GuestExecMaxTimeoutSeconds)), response)
+		return
+	}
+
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf(vmiNotRunning))
+		}
+		condManager := controller.NewVirtualMachineInstanceConditionManager()
+		if !condManager.HasConditionWithStatus(vmi, v1.VirtualMachineInstanceAgentConnected, v12.ConditionTrue) {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf(vmiGuestAgentErr))
+		}
+		return nil
+	}
+
+	vmi, statusErr := app.fetchAndValidateVirtualMachineInstance(request.PathParameter("namespace"), request.PathParameter("name"), validate)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	// the command may run longer than requests to virt-handler usually take
+	httpClient := *app.handlerHttpClient
+	httpClient.Timeout += time.Duration(options.TimeoutSeconds) * time.Second
+	conn := kubecli.NewVirtHandlerClient(app.virtCli, &httpClient).Port(app.consoleServerPort).ForNode(vmi.Status.NodeName)
+
+	url, err := conn.GuestExecURI(vmi)
+	if err != nil {
+		writeError(errors.NewBadRequest(err.Error()), response)
+		return
+	}
+
+	body, err := json.Marshal(options)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	resp, err := conn.PutWithResponse(url, io.NopCloser(bytes.NewReader(body)))
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	result := &v1.GuestExecResult{}
+	if err := json.Unmarshal([]byte(resp), result); err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	response.WriteEntity(result)
+}
+
 // GuestOSInfo handles the subresource for providing VM guest agent information
 func (app *SubresourceAPIApp) GuestOSInfo(request *restful.Request, response *restful.Response) {
 	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
@@ -976,6 +1081,14 @@ func generateVMVolumeRequestPatch(vm *v1.VirtualMachine, volumeRequest *v1.Virtu
 		if err := addRemoveVolumeRequests(vm, volumeRequest, vmCopy); err != nil {
 			return "", err
 		}
+	} else if volumeRequest.InsertMediaOptions != nil || volumeRequest.EjectMediaOptions != nil {
+		if err := addMediaRequests(vm, volumeRequest, vmCopy); err != nil {
+			return "", err
+		}
+	} else if volumeRequest.AddFilesystemOptions != nil || volumeRequest.RemoveFilesystemOptions != nil {
+		if err := addFilesystemRequests(vm, volumeRequest, vmCopy); err != nil {
+			return "", err
+		}
 	}
 
 	patchBytes, err := patch.GeneratePatchPayload(
@@ -1041,6 +1154,27 @@ func addRemoveVolumeRequests(vm *v1.VirtualMachine, volumeRequest *v1.VirtualMac
 	return nil
 }
 
+func addMediaRequests(vm *v1.VirtualMachine, volumeRequest *v1.VirtualMachineVolumeRequest, vmCopy *v1.VirtualMachine) error {
+	name := mediaRequestName(*volumeRequest)
+	for _, request := range vm.Status.VolumeRequests {
+		if mediaRequestName(request) == name || addVolumeRequestExists(request, name) || removeVolumeRequestExists(request, name) {
+			return fmt.Errorf("a volume request for cdrom [%s] already exists and is still being processed", name)
+		}
+	}
+	vmCopy.Status.VolumeRequests = append(vm.Status.VolumeRequests, *volumeRequest)
+	return nil
+}
+
+func mediaRequestName(request v1.VirtualMachineVolumeRequest) string {
+	if request.InsertMediaOptions != nil {
+		return request.InsertMediaOptions.Name
+	}
+	if request.EjectMediaOptions != nil {
+		return request.EjectMediaOptions.Name
+	}
+	return ""
+}
+
 func removeVolumeRequestExists(request v1.VirtualMachineVolumeRequest, name string) bool {
 	return request.RemoveVolumeOptions != nil && request.RemoveVolumeOptions.Name == name
 }
@@ -1050,7 +1184,28 @@ func addVolumeRequestExists(request v1.VirtualMachineVolumeRequest, name string)
 }
 
 func volumeHotpluggable(volume v1.Volume) bool {
-	return (volume.DataVolume != nil && volume.DataVolume.Hotpluggable) || (volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.Hotpluggable)
+	return kutil.IsHotplugVolume(&volume)
+}
+
+// setHotpluggable marks the volume source as hotpluggable, it returns false if no source is set
+func setHotpluggable(volumeSource *v1.HotplugVolumeSource) bool {
+	switch {
+	case volumeSource.DataVolume != nil:
+		volumeSource.DataVolume.Hotpluggable = true
+	case volumeSource.PersistentVolumeClaim != nil:
+		volumeSource.PersistentVolumeClaim.Hotpluggable = true
+	case volumeSource.ContainerDisk != nil:
+		volumeSource.ContainerDisk.Hotpluggable = true
+	case volumeSource.ConfigMap != nil:
+		volumeSource.ConfigMap.Hotpluggable = true
+	case volumeSource.Secret != nil:
+		volumeSource.Secret.Hotpluggable = true
+	case volumeSource.ServiceAccount != nil:
+		volumeSource.ServiceAccount.Hotpluggable = true
+	default:
+		return false
+	}
+	return true
 }
 
 func volumeNameExists(volume v1.Volume, volumeName string) bool {
@@ -1087,6 +1242,9 @@ func verifyVolumeOption(volumes []v1.Volume, volumeRequest *v1.VirtualMachineVol
 			if volumeSourceExists(volume, volSourceName) {
 				return fmt.Errorf("Unable to add volume source [%s] because it already exists", volSourceName)
 			}
+			if volume.ServiceAccount != nil && volumeRequest.AddVolumeOptions.VolumeSource.ServiceAccount != nil {
+				return fmt.Errorf("Unable to add volume [%s] because only one serviceAccount volume is allowed", volumeRequest.AddVolumeOptions.Name)
+			}
 		} else if volumeRequest.RemoveVolumeOptions != nil && volumeExists(volume, volumeRequest.RemoveVolumeOptions.Name) {
 			if !volumeHotpluggable(volume) {
 				return fmt.Errorf("Unable to remove volume [%s] because it is not hotpluggable", volume.Name)
@@ -1103,6 +1261,59 @@ func verifyVolumeOption(volumes []v1.Volume, volumeRequest *v1.VirtualMachineVol
 	return nil
 }
 
+func verifyMediaOption(spec *v1.VirtualMachineInstanceSpec, volumeRequest *v1.VirtualMachineVolumeRequest) error {
+	name := mediaRequestName(*volumeRequest)
+
+	var cdrom *v1.Disk
+	for i, disk := range spec.Domain.Devices.Disks {
+		if disk.Name == name {
+			cdrom = &spec.Domain.Devices.Disks[i]
+			break
+		}
+	}
+	if cdrom == nil {
+		return fmt.Errorf("Unable to change the media of [%s] because the disk does not exist", name)
+	}
+	if cdrom.CDRom == nil {
+		return fmt.Errorf("Unable to change the media of [%s] because the disk is not a cdrom", name)
+	}
+
+	var media *v1.Volume
+	for i, volume := range spec.Volumes {
+		if volumeNameExists(volume, name) {
+			media = &spec.Volumes[i]
+		} else if volumeRequest.InsertMediaOptions != nil && volumeSourceExists(volume, volumeSourceName(volumeRequest.InsertMediaOptions.VolumeSource)) {
+			return fmt.Errorf("Unable to insert media [%s] because it is already used by volume [%s]", volumeSourceName(volumeRequest.InsertMediaOptions.VolumeSource), volume.Name)
+		} else if volumeRequest.InsertMediaOptions != nil && volume.ServiceAccount != nil && volumeRequest.InsertMediaOptions.VolumeSource.ServiceAccount != nil {
+			return fmt.Errorf("Unable to insert media into cdrom [%s] because only one serviceAccount volume is allowed", name)
+		}
+	}
+
+	if volumeRequest.InsertMediaOptions != nil && media != nil {
+		return fmt.Errorf("Unable to insert media into cdrom [%s] because it already contains a media, eject it first", name)
+	}
+	if volumeRequest.EjectMediaOptions != nil {
+		if media == nil {
+			return fmt.Errorf("Unable to eject cdrom [%s] because it contains no media", name)
+		}
+		if !volumeHotpluggable(*media) {
+			return fmt.Errorf("Unable to eject cdrom [%s] because its media is not hotpluggable", name)
+		}
+	}
+
+	return nil
+}
+
+func verifyVolumeRequest(spec *v1.VirtualMachineInstanceSpec, volumeRequest *v1.VirtualMachineVolumeRequest) error {
+	if volumeRequest.InsertMediaOptions != nil || volumeRequest.EjectMediaOptions != nil {
+		return verifyMediaOption(spec, volumeRequest)
+	}
+	if volumeRequest.AddFilesystemOptions != nil || volumeRequest.RemoveFilesystemOptions != nil {
+		return verifyFilesystemOption(spec, volumeRequest)
+	}
+	return verifyVolumeOption(spec.Volumes, volumeRequest)
+}
+
 func generateVMIVolumeRequestPatch(vmi *v1.VirtualMachineInstance, volumeRequest *v1.VirtualMachineVolumeRequest) (string, error) {
 
 	volumeVerb := "add"
@@ -1190,11 +1401,7 @@ func (app *SubresourceAPIApp) addVolumeRequestHandler(request *restful.Request,
 	volumeRequest := v1.VirtualMachineVolumeRequest{
 		AddVolumeOptions: opts,
 	}
-	if opts.VolumeSource.DataVolume != nil {
-		opts.VolumeSource.DataVolume.Hotpluggable = true
-	} else if opts.VolumeSource.PersistentVolumeClaim != nil {
-		opts.VolumeSource.PersistentVolumeClaim.Hotpluggable = true
-	}
+	setHotpluggable(opts.VolumeSource)
 
 	// inject into VMI if ephemeral, else set as a request on the VM to both make permanent and hotplug.
 	if ephemeral {
@@ -1273,7 +1480,7 @@ func (app *SubresourceAPIApp) vmiVolumePatch(name, namespace string, volumeReque
 		return errors.NewConflict(v1.Resource("virtualmachineinstance"), name, fmt.Errorf(vmiNotRunning))
 	}
 
-	err := verifyVolumeOption(vmi.Spec.Volumes, volumeRequest)
+	err := verifyVolumeRequest(&vmi.Spec, volumeRequest)
 	if err != nil {
 		return errors.NewConflict(v1.Resource("virtualmachineinstance"), name, err)
 	}
@@ -1303,7 +1510,7 @@ func (app *SubresourceAPIApp) vmVolumePatchStatus(name, namespace string, volume
 		return statErr
 	}
 
-	err := verifyVolumeOption(vm.Spec.Template.Spec.Volumes, volumeRequest)
+	err := verifyVolumeRequest(&vm.Spec.Template.Spec, volumeRequest)
 	if err != nil {
 		return errors.NewConflict(v1.Resource("virtualmachine"), name, err)
 	}
@@ -1333,6 +1540,14 @@ func (app *SubresourceAPIApp) getDryRunOption(volumeRequest *v1.VirtualMachineVo
 		dryRunOption = volumeRequest.AddVolumeOptions.DryRun
 	} else if options := volumeRequest.RemoveVolumeOptions; options != nil && options.DryRun != nil && options.DryRun[0] == k8smetav1.DryRunAll {
 		dryRunOption = volumeRequest.RemoveVolumeOptions.DryRun
+	} else if options := volumeRequest.InsertMediaOptions; options != nil && options.DryRun != nil && options.DryRun[0] == k8smetav1.DryRunAll {
+		dryRunOption = volumeRequest.InsertMediaOptions.DryRun
+	} else if options := volumeRequest.EjectMediaOptions; options != nil && options.DryRun != nil && options.DryRun[0] == k8smetav1.DryRunAll {
+		dryRunOption = volumeRequest.EjectMediaOptions.DryRun
+	} else if options := volumeRequest.AddFilesystemOptions; options != nil && options.DryRun != nil && options.DryRun[0] == k8smetav1.DryRunAll {
+		dryRunOption = volumeRequest.AddFilesystemOptions.DryRun
+	} else if options := volumeRequest.RemoveFilesystemOptions; options != nil && options.DryRun != nil && options.DryRun[0] == k8smetav1.DryRunAll {
+		dryRunOption = volumeRequest.RemoveFilesystemOptions.DryRun
 	}
 	return dryRunOption
 }
@@ -1357,6 +1572,128 @@ func (app *SubresourceAPIApp) VMIRemoveVolumeRequestHandler(request *restful.Req
 	app.removeVolumeRequestHandler(request, response, true)
 }
 
+func (app *SubresourceAPIApp) insertMediaRequestHandler(request *restful.Request, response *restful.Response, ephemeral bool) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	if !app.clusterConfig.HotplugVolumesEnabled() {
+		writeError(errors.NewBadRequest("Unable to Insert Media because HotplugVolumes feature gate is not enabled."), response)
+		return
+	}
+
+	opts := &v1.InsertMediaOptions{}
+	if request.Request.Body != nil {
+		defer request.Request.Body.Close()
+		err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+		switch err {
+		case io.EOF, nil:
+			break
+		default:
+			writeError(errors.NewBadRequest(fmt.Sprintf(unmarshalRequestErrFmt, err)), response)
+			return
+		}
+	} else {
+		writeError(errors.NewBadRequest("Request with no body, a cdrom name is expected as the request body"), response)
+		return
+	}
+
+	if opts.Name == "" {
+		writeError(errors.NewBadRequest("InsertMediaOptions requires name to be set"), response)
+		return
+	} else if opts.VolumeSource == nil || !setHotpluggable(opts.VolumeSource) {
+		writeError(errors.NewBadRequest("InsertMediaOptions requires VolumeSource to not be empty"), response)
+		return
+	}
+
+	volumeRequest := v1.VirtualMachineVolumeRequest{
+		InsertMediaOptions: opts,
+	}
+
+	// inject into VMI if ephemeral, else set as a request on the VM to both make permanent and insert.
+	if ephemeral {
+		if err := app.vmiVolumePatch(name, namespace, &volumeRequest); err != nil {
+			writeError(err, response)
+			return
+		}
+	} else {
+		if err := app.vmVolumePatchStatus(name, namespace, &volumeRequest); err != nil {
+			writeError(err, response)
+			return
+		}
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func (app *SubresourceAPIApp) ejectMediaRequestHandler(request *restful.Request, response *restful.Response, ephemeral bool) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	if !app.clusterConfig.HotplugVolumesEnabled() {
+		writeError(errors.NewBadRequest("Unable to Eject Media because HotplugVolumes feature gate is not enabled."), response)
+		return
+	}
+
+	opts := &v1.EjectMediaOptions{}
+	if request.Request.Body != nil {
+		defer request.Request.Body.Close()
+		err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+		switch err {
+		case io.EOF, nil:
+			break
+		default:
+			writeError(errors.NewBadRequest(fmt.Sprintf(unmarshalRequestErrFmt, err)), response)
+			return
+		}
+	} else {
+		writeError(errors.NewBadRequest("Request with no body, a cdrom name is expected as the request body"), response)
+		return
+	}
+
+	if opts.Name == "" {
+		writeError(errors.NewBadRequest("EjectMediaOptions requires name to be set"), response)
+		return
+	}
+	volumeRequest := v1.VirtualMachineVolumeRequest{
+		EjectMediaOptions: opts,
+	}
+
+	// inject into VMI if ephemeral, else set as a request on the VM to both make permanent and eject.
+	if ephemeral {
+		if err := app.vmiVolumePatch(name, namespace, &volumeRequest); err != nil {
+			writeError(err, response)
+			return
+		}
+	} else {
+		if err := app.vmVolumePatchStatus(name, namespace, &volumeRequest); err != nil {
+			writeError(err, response)
+			return
+		}
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+// VMInsertMediaRequestHandler handles the subresource for inserting a media into a cdrom.
+func (app *SubresourceAPIApp) VMInsertMediaRequestHandler(request *restful.Request, response *restful.Response) {
+	app.insertMediaRequestHandler(request, response, false)
+}
+
+// VMEjectMediaRequestHandler handles the subresource for ejecting the media of a cdrom.
+func (app *SubresourceAPIApp) VMEjectMediaRequestHandler(request *restful.Request, response *restful.Response) {
+	app.ejectMediaRequestHandler(request, response, false)
+}
+
+// VMIInsertMediaRequestHandler handles the subresource for inserting a media into a cdrom.
+func (app *SubresourceAPIApp) VMIInsertMediaRequestHandler(request *restful.Request, response *restful.Response) {
+	app.insertMediaRequestHandler(request, response, true)
+}
+
+// VMIEjectMediaRequestHandler handles the subresource for ejecting the media of a cdrom.
+func (app *SubresourceAPIApp) VMIEjectMediaRequestHandler(request *restful.Request, response *restful.Response) {
+	app.ejectMediaRequestHandler(request, response, true)
+}
+
 func getMemoryDumpPatchVerb(request *v1.VirtualMachineMemoryDumpRequest) string {
 	verb := "add"
 	if request != nil {
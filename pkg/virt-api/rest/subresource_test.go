@@ -1081,6 +1081,29 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 					},
 				},
 				"Unable to add volume [vol1] because volume with that name already exists"),
+			Entry("add a second serviceAccount volume should fail",
+				&v1.VirtualMachineVolumeRequest{
+					AddVolumeOptions: &v1.AddVolumeOptions{
+						Name: "sa2",
+						Disk: &v1.Disk{},
+						VolumeSource: &v1.HotplugVolumeSource{
+							ServiceAccount: &v1.ServiceAccountVolumeSource{
+								ServiceAccountName: "other",
+							},
+						},
+					},
+				},
+				[]v1.Volume{
+					{
+						Name: "sa1",
+						VolumeSource: v1.VolumeSource{
+							ServiceAccount: &v1.ServiceAccountVolumeSource{
+								ServiceAccountName: "default",
+							},
+						},
+					},
+				},
+				"Unable to add volume [sa2] because only one serviceAccount volume is allowed"),
 			Entry("add volume source which already exists should fail(existing dv)",
 				&v1.VirtualMachineVolumeRequest{
 					AddVolumeOptions: &v1.AddVolumeOptions{
@@ -1170,6 +1193,204 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 		)
 	})
 
+	Context("Insert/Eject Media Subresource api", func() {
+
+		newMediaBody := func(opts interface{}) io.ReadCloser {
+			optsJson, _ := json.Marshal(opts)
+			return &readCloserWrapper{bytes.NewReader(optsJson)}
+		}
+
+		newVMIWithCDRom := func(withMedia bool) *v1.VirtualMachineInstance {
+			vmi := api.NewMinimalVMI(request.PathParameter("name"))
+			vmi.Namespace = k8smetav1.NamespaceDefault
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testdisk",
+			})
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "testdisk",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "testpvcdiskclaim",
+					}},
+				},
+			})
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "cdrom1",
+				DiskDevice: v1.DiskDevice{
+					CDRom: &v1.CDRomTarget{Bus: v1.DiskBusSATA},
+				},
+			})
+			if withMedia {
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: "cdrom1",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+							PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+								ClaimName: "iso-pvc",
+							},
+							Hotpluggable: true,
+						},
+					},
+				})
+			}
+			return vmi
+		}
+
+		isoVolumeSource := func() *v1.HotplugVolumeSource {
+			return &v1.HotplugVolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "other-iso-pvc",
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			request.PathParameters()["name"] = testVMName
+			request.PathParameters()["namespace"] = k8smetav1.NamespaceDefault
+		})
+
+		DescribeTable("Should handle an insert media request", func(opts *v1.InsertMediaOptions, isVM bool, code int, enableGate bool) {
+			if enableGate {
+				enableFeatureGate(virtconfig.HotplugVolumesGate)
+			}
+			request.Request.Body = newMediaBody(opts)
+			vmi := newVMIWithCDRom(false)
+
+			if isVM {
+				vm := newMinimalVM(request.PathParameter("name"))
+				vm.Namespace = k8smetav1.NamespaceDefault
+				vm.Spec.Template = &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: vmi.Spec,
+				}
+				vmClient.EXPECT().Get(context.Background(), vm.Name, &k8smetav1.GetOptions{}).Return(vm, nil).AnyTimes()
+				vmClient.EXPECT().PatchStatus(context.Background(), vm.Name, types.JSONPatchType, gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, name string, patchType types.PatchType, body interface{}, opts *k8smetav1.PatchOptions) (interface{}, interface{}) {
+						Expect(string(body.([]byte))).To(ContainSubstring(`"hotpluggable":true`))
+						return vm, nil
+					}).AnyTimes()
+				app.VMInsertMediaRequestHandler(request, response)
+			} else {
+				vmiClient.EXPECT().Get(context.Background(), vmi.Name, &k8smetav1.GetOptions{}).Return(vmi, nil).AnyTimes()
+				vmiClient.EXPECT().Patch(context.Background(), vmi.Name, types.JSONPatchType, gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, name string, patchType types.PatchType, body interface{}, opts *k8smetav1.PatchOptions, _ ...string) (interface{}, interface{}) {
+						Expect(string(body.([]byte))).To(ContainSubstring(`{"name":"cdrom1","persistentVolumeClaim":{"claimName":"other-iso-pvc","hotpluggable":true}}`))
+						return vmi, nil
+					}).AnyTimes()
+				app.VMIInsertMediaRequestHandler(request, response)
+			}
+
+			Expect(response.StatusCode()).To(Equal(code))
+		},
+			Entry("VM with a valid request", &v1.InsertMediaOptions{Name: "cdrom1", VolumeSource: isoVolumeSource()}, true, http.StatusAccepted, true),
+			Entry("VMI with a valid request", &v1.InsertMediaOptions{Name: "cdrom1", VolumeSource: isoVolumeSource()}, false, http.StatusAccepted, true),
+			Entry("VM with a containerDisk media", &v1.InsertMediaOptions{Name: "cdrom1", VolumeSource: &v1.HotplugVolumeSource{
+				ContainerDisk: &v1.ContainerDiskSource{Image: "registry:5000/iso:latest"},
+			}}, true, http.StatusAccepted, true),
+			Entry("VMI with a request missing a name", &v1.InsertMediaOptions{VolumeSource: isoVolumeSource()}, false, http.StatusBadRequest, true),
+			Entry("VMI with a request missing a volume source", &v1.InsertMediaOptions{Name: "cdrom1"}, false, http.StatusBadRequest, true),
+			Entry("VMI with a request for a disk that is not a cdrom", &v1.InsertMediaOptions{Name: "testdisk", VolumeSource: isoVolumeSource()}, false, http.StatusConflict, true),
+			Entry("VM with a request for a disk that is not a cdrom", &v1.InsertMediaOptions{Name: "testdisk", VolumeSource: isoVolumeSource()}, true, http.StatusConflict, true),
+			Entry("VMI with a containerDisk media for a disk that is not a cdrom", &v1.InsertMediaOptions{Name: "testdisk", VolumeSource: &v1.HotplugVolumeSource{
+				ContainerDisk: &v1.ContainerDiskSource{Image: "registry:5000/iso:latest"},
+			}}, false, http.StatusConflict, true),
+			Entry("VM with a configMap media", &v1.InsertMediaOptions{Name: "cdrom1", VolumeSource: &v1.HotplugVolumeSource{
+				ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: k8sv1.LocalObjectReference{Name: "drivers"}},
+			}}, true, http.StatusAccepted, true),
+			Entry("VMI with a valid request but no feature gate", &v1.InsertMediaOptions{Name: "cdrom1", VolumeSource: isoVolumeSource()}, false, http.StatusBadRequest, false),
+		)
+
+		It("Should reject a second serviceAccount media", func() {
+			vmi := newVMIWithCDRom(false)
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name:         "sa1",
+				VolumeSource: v1.VolumeSource{ServiceAccount: &v1.ServiceAccountVolumeSource{ServiceAccountName: "default"}},
+			})
+			volumeRequest := &v1.VirtualMachineVolumeRequest{
+				InsertMediaOptions: &v1.InsertMediaOptions{Name: "cdrom1", VolumeSource: &v1.HotplugVolumeSource{
+					ServiceAccount: &v1.ServiceAccountVolumeSource{ServiceAccountName: "other"},
+				}},
+			}
+
+			Expect(verifyVolumeRequest(&vmi.Spec, volumeRequest)).To(MatchError("Unable to insert media into cdrom [cdrom1] because only one serviceAccount volume is allowed"))
+		})
+
+		DescribeTable("Should handle an eject media request", func(opts *v1.EjectMediaOptions, isVM bool, code int, enableGate bool) {
+			if enableGate {
+				enableFeatureGate(virtconfig.HotplugVolumesGate)
+			}
+			request.Request.Body = newMediaBody(opts)
+			vmi := newVMIWithCDRom(true)
+
+			if isVM {
+				vm := newMinimalVM(request.PathParameter("name"))
+				vm.Namespace = k8smetav1.NamespaceDefault
+				vm.Spec.Template = &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: vmi.Spec,
+				}
+				vmClient.EXPECT().Get(context.Background(), vm.Name, &k8smetav1.GetOptions{}).Return(vm, nil).AnyTimes()
+				vmClient.EXPECT().PatchStatus(context.Background(), vm.Name, types.JSONPatchType, gomock.Any(), gomock.Any()).Return(vm, nil).AnyTimes()
+				app.VMEjectMediaRequestHandler(request, response)
+			} else {
+				vmiClient.EXPECT().Get(context.Background(), vmi.Name, &k8smetav1.GetOptions{}).Return(vmi, nil).AnyTimes()
+				vmiClient.EXPECT().Patch(context.Background(), vmi.Name, types.JSONPatchType, gomock.Any(), gomock.Any()).Return(vmi, nil).AnyTimes()
+				app.VMIEjectMediaRequestHandler(request, response)
+			}
+
+			Expect(response.StatusCode()).To(Equal(code))
+		},
+			Entry("VM with a valid request", &v1.EjectMediaOptions{Name: "cdrom1"}, true, http.StatusAccepted, true),
+			Entry("VMI with a valid request", &v1.EjectMediaOptions{Name: "cdrom1"}, false, http.StatusAccepted, true),
+			Entry("VMI with a request missing a name", &v1.EjectMediaOptions{}, false, http.StatusBadRequest, true),
+			Entry("VMI with a request for an unknown disk", &v1.EjectMediaOptions{Name: "unknown"}, false, http.StatusConflict, true),
+			Entry("VMI with a valid request but no feature gate", &v1.EjectMediaOptions{Name: "cdrom1"}, false, http.StatusBadRequest, false),
+		)
+
+		DescribeTable("Should verify media option", func(volumeRequest *v1.VirtualMachineVolumeRequest, withMedia, hotpluggable bool, expectedError string) {
+			vmi := newVMIWithCDRom(withMedia)
+			if withMedia && !hotpluggable {
+				vmi.Spec.Volumes[len(vmi.Spec.Volumes)-1].PersistentVolumeClaim.Hotpluggable = false
+			}
+			err := verifyVolumeRequest(&vmi.Spec, volumeRequest)
+			if expectedError != "" {
+				Expect(err).To(MatchError(expectedError))
+			} else {
+				Expect(err).ToNot(HaveOccurred())
+			}
+		},
+			Entry("insert into an empty cdrom",
+				&v1.VirtualMachineVolumeRequest{InsertMediaOptions: &v1.InsertMediaOptions{Name: "cdrom1", VolumeSource: isoVolumeSource()}},
+				false, false, ""),
+			Entry("insert into a cdrom holding media should fail",
+				&v1.VirtualMachineVolumeRequest{InsertMediaOptions: &v1.InsertMediaOptions{Name: "cdrom1", VolumeSource: isoVolumeSource()}},
+				true, true, "Unable to insert media into cdrom [cdrom1] because it already contains a media, eject it first"),
+			Entry("insert media used by another volume should fail",
+				&v1.VirtualMachineVolumeRequest{InsertMediaOptions: &v1.InsertMediaOptions{Name: "cdrom1", VolumeSource: &v1.HotplugVolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "testpvcdiskclaim",
+					}},
+				}}},
+				false, false, "Unable to insert media [testpvcdiskclaim] because it is already used by volume [testdisk]"),
+			Entry("insert into a disk that is not a cdrom should fail",
+				&v1.VirtualMachineVolumeRequest{InsertMediaOptions: &v1.InsertMediaOptions{Name: "testdisk", VolumeSource: isoVolumeSource()}},
+				false, false, "Unable to change the media of [testdisk] because the disk is not a cdrom"),
+			Entry("insert into a disk that does not exist should fail",
+				&v1.VirtualMachineVolumeRequest{InsertMediaOptions: &v1.InsertMediaOptions{Name: "unknown", VolumeSource: isoVolumeSource()}},
+				false, false, "Unable to change the media of [unknown] because the disk does not exist"),
+			Entry("eject hotplugged media",
+				&v1.VirtualMachineVolumeRequest{EjectMediaOptions: &v1.EjectMediaOptions{Name: "cdrom1"}},
+				true, true, ""),
+			Entry("eject an empty cdrom should fail",
+				&v1.VirtualMachineVolumeRequest{EjectMediaOptions: &v1.EjectMediaOptions{Name: "cdrom1"}},
+				false, false, "Unable to eject cdrom [cdrom1] because it contains no media"),
+			Entry("eject media that is not hotpluggable should fail",
+				&v1.VirtualMachineVolumeRequest{EjectMediaOptions: &v1.EjectMediaOptions{Name: "cdrom1"}},
+				true, false, "Unable to eject cdrom [cdrom1] because its media is not hotpluggable"),
+		)
+	})
+
 	Context("Memory dump Subresource api", func() {
 		const (
 			fs          = false
@@ -2077,6 +2298,113 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 		})
 	})
 
+	Context("FSTrim", func() {
+		It("Should trim the guest filesystems of a running VMI", func() {
+			enableFeatureGate(virtconfig.FilesystemTrimGate)
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/fstrim"),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+
+			expectVMI(Running, UnPaused, guestAgentConnected)
+
+			app.FSTrimVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+
+		It("Should fail trimming without the feature gate", func() {
+			app.FSTrimVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		})
+
+		It("Should fail trimming a not running VMI", func() {
+			enableFeatureGate(virtconfig.FilesystemTrimGate)
+			expectVMI(NotRunning, UnPaused, guestAgentConnected)
+
+			app.FSTrimVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+
+		It("Should fail trimming a VMI without guest agent", func() {
+			enableFeatureGate(virtconfig.FilesystemTrimGate)
+			expectVMI(Running, UnPaused)
+
+			app.FSTrimVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+	})
+
+	Context("GuestExec", func() {
+		guestExecBody := func(options *v1.GuestExecOptions) io.ReadCloser {
+			body, err := json.Marshal(options)
+			Expect(err).ToNot(HaveOccurred())
+			return io.NopCloser(bytes.NewReader(body))
+		}
+
+		BeforeEach(func() {
+			enableFeatureGate(virtconfig.SnapshotGate)
+		})
+
+		It("Should fail if the Snapshot feature gate is not enabled", func() {
+			disableFeatureGates()
+			request.Request.Body = guestExecBody(&v1.GuestExecOptions{Command: "/usr/bin/sync", TimeoutSeconds: 10})
+
+			app.GuestExecVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		})
+
+		It("Should return the result of the command executed in the guest", func() {
+			options := &v1.GuestExecOptions{Command: "/usr/bin/sync", TimeoutSeconds: 10}
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/guestexec"),
+					ghttp.VerifyJSONRepresenting(options),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, v1.GuestExecResult{ExitCode: 2, StdOut: "flushed"}),
+				),
+			)
+
+			expectVMI(Running, UnPaused, guestAgentConnected)
+			request.Request.Body = guestExecBody(options)
+			response.SetRequestAccepts(restful.MIME_JSON)
+
+			app.GuestExecVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			result := &v1.GuestExecResult{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), result)).To(Succeed())
+			Expect(result.ExitCode).To(Equal(int32(2)))
+			Expect(result.StdOut).To(Equal("flushed"))
+		})
+
+		DescribeTable("Should reject invalid options", func(options *v1.GuestExecOptions) {
+			request.Request.Body = guestExecBody(options)
+
+			app.GuestExecVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		},
+			Entry("without command", &v1.GuestExecOptions{TimeoutSeconds: 10}),
+			Entry("without timeout", &v1.GuestExecOptions{Command: "/usr/bin/sync"}),
+			Entry("with a too long timeout", &v1.GuestExecOptions{Command: "/usr/bin/sync", TimeoutSeconds: v1.GuestExecMaxTimeoutSeconds + 1}),
+		)
+
+		It("Should fail executing a command in a VMI without guest agent", func() {
+			expectVMI(Running, UnPaused)
+			request.Request.Body = guestExecBody(&v1.GuestExecOptions{Command: "/usr/bin/sync", TimeoutSeconds: 10})
+
+			app.GuestExecVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+	})
+
 	Context("Pausing", func() {
 		DescribeTable("Should pause a running, not paused VMI according to options", func(pauseOptions *v1.PauseOptions) {
 
@@ -89,10 +89,11 @@ func validateDiskBus(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec,
 		}
 
 		for i, disk := range spec.Domain.Devices.Disks {
-			if disk.Disk != nil && !checkIfBusAvailable(disk.Disk.Bus) {
+			// the nvme controller is a plain pci device and is available for hard-disks on Arm64 as well
+			if disk.Disk != nil && !checkIfBusAvailable(disk.Disk.Bus) && disk.Disk.Bus != v1.DiskBusNVMe {
 				*statusCauses = append(*statusCauses, metav1.StatusCause{
 					Type:    metav1.CauseTypeFieldValueNotSupported,
-					Message: "Arm64 not support this disk bus type, please use virtio or scsi",
+					Message: "Arm64 not support this disk bus type, please use virtio, scsi or nvme",
 					Field:   field.Child("domain", "devices", "disks").Index(i).Child("disk", "bus").String(),
 				})
 			}
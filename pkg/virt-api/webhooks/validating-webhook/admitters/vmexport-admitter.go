@@ -22,6 +22,7 @@ package admitters
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -98,6 +99,10 @@ func (admitter *VMExportAdmitter) Admit(ar *admissionv1.AdmissionReview) *admiss
 			}
 		}
 
+		if vmExport.Spec.Destination != nil {
+			causes = append(causes, admitter.validateDestination(k8sfield.NewPath("spec", "destination"), vmExport.Spec.Destination)...)
+		}
+
 	case admissionv1.Update:
 		prevObj := &exportv1.VirtualMachineExport{}
 		err = json.Unmarshal(ar.Request.OldObject.Raw, prevObj)
@@ -211,3 +216,50 @@ func (admitter *VMExportAdmitter) validateVMApiGroup(field *k8sfield.Path, apigr
 
 	return []metav1.StatusCause{}
 }
+
+func (admitter *VMExportAdmitter) validateDestination(field *k8sfield.Path, destination *exportv1.VirtualMachineExportDestination) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	switch destination.Format {
+	case "", exportv1.KubeVirtRaw, exportv1.KubeVirtGz:
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("destination format must be %s or %s", exportv1.KubeVirtRaw, exportv1.KubeVirtGz),
+			Field:   field.Child("format").String(),
+		})
+	}
+
+	if destination.S3 == nil {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "destination must specify s3",
+			Field:   field.Child("s3").String(),
+		})
+	}
+
+	s3Field := field.Child("s3")
+	if endpoint, err := url.Parse(destination.S3.Endpoint); err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "S3 endpoint must be an http or https URL",
+			Field:   s3Field.Child("endpoint").String(),
+		})
+	}
+	if destination.S3.Bucket == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "S3 bucket must not be empty",
+			Field:   s3Field.Child("bucket").String(),
+		})
+	}
+	if destination.S3.CredentialsSecretRef == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "S3 credentials secret must not be empty",
+			Field:   s3Field.Child("credentialsSecretRef").String(),
+		})
+	}
+
+	return causes
+}
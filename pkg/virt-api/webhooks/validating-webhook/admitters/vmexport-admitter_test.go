@@ -256,6 +256,60 @@ var _ = Describe("Validating VirtualMachineExport Admitter", func() {
 			Entry("virtual machine snapshot", "invalid", vmSnapshotKind),
 			Entry("virtual machine", "invalid", vmKind),
 		)
+
+		Context("with a destination", func() {
+			newDestination := func() *exportv1.VirtualMachineExportDestination {
+				return &exportv1.VirtualMachineExportDestination{
+					Format: exportv1.KubeVirtGz,
+					S3: &exportv1.S3ExportDestination{
+						Endpoint:             "https://minio.minio:9000",
+						Bucket:               "backups",
+						CredentialsSecretRef: "s3-credentials",
+					},
+				}
+			}
+
+			newExport := func(destination *exportv1.VirtualMachineExportDestination) *exportv1.VirtualMachineExport {
+				return &exportv1.VirtualMachineExport{
+					Spec: exportv1.VirtualMachineExportSpec{
+						Source: corev1.TypedLocalObjectReference{
+							APIGroup: &kubevirtApiGroup,
+							Kind:     vmKind,
+							Name:     "test",
+						},
+						Destination: destination,
+					},
+				}
+			}
+
+			It("should allow a valid destination", func() {
+				ar := createExportAdmissionReview(newExport(newDestination()))
+				resp := createTestVMExportAdmitter(config).Admit(ar)
+				Expect(resp.Allowed).To(BeTrue())
+			})
+
+			DescribeTable("should reject", func(update func(*exportv1.VirtualMachineExportDestination), field, message string) {
+				destination := newDestination()
+				update(destination)
+				ar := createExportAdmissionReview(newExport(destination))
+				resp := createTestVMExportAdmitter(config).Admit(ar)
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Details.Causes).To(HaveLen(1))
+				Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+				Expect(resp.Result.Details.Causes[0].Message).To(Equal(message))
+			},
+				Entry("unsupported format", func(d *exportv1.VirtualMachineExportDestination) { d.Format = exportv1.ArchiveGz },
+					"spec.destination.format", "destination format must be raw or gzip"),
+				Entry("missing s3", func(d *exportv1.VirtualMachineExportDestination) { d.S3 = nil },
+					"spec.destination.s3", "destination must specify s3"),
+				Entry("endpoint without scheme", func(d *exportv1.VirtualMachineExportDestination) { d.S3.Endpoint = "minio.minio:9000" },
+					"spec.destination.s3.endpoint", "S3 endpoint must be an http or https URL"),
+				Entry("empty bucket", func(d *exportv1.VirtualMachineExportDestination) { d.S3.Bucket = "" },
+					"spec.destination.s3.bucket", "S3 bucket must not be empty"),
+				Entry("empty credentials", func(d *exportv1.VirtualMachineExportDestination) { d.S3.CredentialsSecretRef = "" },
+					"spec.destination.s3.credentialsSecretRef", "S3 credentials secret must not be empty"),
+			)
+		})
 	})
 })
 
@@ -29,6 +29,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	backendstorage "kubevirt.io/kubevirt/pkg/storage/backend-storage"
 
@@ -43,9 +44,13 @@ import (
 
 	v1 "kubevirt.io/api/core/v1"
 
+	ephemeraldisk "kubevirt.io/kubevirt/pkg/ephemeral-disk"
 	"kubevirt.io/kubevirt/pkg/hooks"
 	"kubevirt.io/kubevirt/pkg/network/link"
+	netvmispec "kubevirt.io/kubevirt/pkg/network/vmispec"
+	networkvolume "kubevirt.io/kubevirt/pkg/storage/network-volume"
 	"kubevirt.io/kubevirt/pkg/storage/reservation"
+	"kubevirt.io/kubevirt/pkg/util"
 	hwutil "kubevirt.io/kubevirt/pkg/util/hardware"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
@@ -59,6 +64,8 @@ const (
 	arrayLenMax = 256
 	maxStrLen   = 256
 
+	minFilesystemTrimInterval = time.Hour
+
 	// cloudInitNetworkMaxLen and CloudInitUserMaxLen are being limited
 	// to 2K to allow scaling of config as edits will cause entire object
 	// to be distributed to large no of nodes. For larger than 2K, user should
@@ -154,6 +161,7 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 	causes = append(causes, validateCpuRequestDoesNotExceedLimit(field, spec)...)
 	causes = append(causes, validateCpuPinning(field, spec, config)...)
 	causes = append(causes, validateNUMA(field, spec, config)...)
+	causes = append(causes, validateVhostUserMemory(field, spec)...)
 	causes = append(causes, validateCPUIsolatorThread(field, spec)...)
 	causes = append(causes, validateCPUFeaturePolicies(field, spec)...)
 	causes = append(causes, validateCPUHotplug(field, spec)...)
@@ -220,12 +228,15 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 	causes = append(causes, validateLiveMigration(field, spec, config)...)
 	causes = append(causes, validateGPUsWithPassthroughEnabled(field, spec, config)...)
 	causes = append(causes, validateFilesystemsWithVirtIOFSEnabled(field, spec, config)...)
+	causes = append(causes, validateHotplugFilesystems(field, spec)...)
 	causes = append(causes, validateHostDevicesWithPassthroughEnabled(field, spec, config)...)
 	causes = append(causes, validateSoundDevices(field, spec)...)
 	causes = append(causes, validateLaunchSecurity(field, spec, config)...)
 	causes = append(causes, validateVSOCK(field, spec, config)...)
 	causes = append(causes, validatePersistentReservation(field, spec, config)...)
 	causes = append(causes, validatePersistentState(field, spec, config)...)
+	causes = append(causes, validateDiskEncryption(field, spec, config)...)
+	causes = append(causes, validateFilesystemTrim(field, spec, config)...)
 
 	return causes
 }
@@ -296,6 +307,8 @@ func validateNetworksMatchInterfaces(field *k8sfield.Path, spec *v1.VirtualMachi
 		}
 
 		causes = append(causes, validateDHCPNTPServersAreValidIPv4Addresses(field, iface, idx)...)
+		causes = append(causes, validateMasqueradeFirewall(field, iface, idx)...)
+		causes = append(causes, validateRouterAdvertisement(field, iface, idx)...)
 	}
 	return networkInterfaceMap, causes, done
 }
@@ -323,6 +336,10 @@ func validateInterfaceNetworkBasics(field *k8sfield.Path, networkExists bool, id
 		causes = appendStatusCauseForPasstWithoutPodNetwork(field, causes, idx)
 	} else if iface.Passt != nil && numOfInterfaces > 1 {
 		causes = appendStatusCauseForPasstWithMultipleInterfaces(field, causes, idx)
+	} else if iface.VhostUser != nil && !config.VhostUserEnabled() {
+		causes = appendStatusCauseForVhostUserFeatureGateNotEnabled(field, causes, idx)
+	} else if iface.VhostUser != nil && networkData.Multus == nil {
+		causes = appendStatusCauseForVhostUserOnlyAllowedWithMultus(field, causes, idx)
 	}
 	return causes
 }
@@ -358,6 +375,141 @@ func validateDHCPNTPServersAreValidIPv4Addresses(field *k8sfield.Path, iface v1.
 	return causes
 }
 
+func validateRouterAdvertisement(field *k8sfield.Path, iface v1.Interface, idx int) (causes []metav1.StatusCause) {
+	options := iface.RouterAdvertisement
+	if options == nil {
+		return nil
+	}
+	raField := field.Child("domain", "devices", "interfaces").Index(idx).Child("routerAdvertisement")
+
+	if iface.Masquerade == nil && iface.Passt == nil {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "Router advertisement options are only supported with masquerade or passt interfaces.",
+			Field:   raField.String(),
+		})
+	}
+	if iface.Passt != nil && (options.Prefix != "" || options.ManagedAddressConfiguration != nil || options.OtherConfiguration) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: "Only the DNS servers of the router advertisement can be set on passt interfaces.",
+			Field:   raField.String(),
+		})
+	}
+
+	if options.Prefix != "" {
+		if ip, _, err := net.ParseCIDR(options.Prefix); err != nil || ip.To4() != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "Router advertisement prefix must be a valid IPv6 CIDR.",
+				Field:   raField.Child("prefix").String(),
+			})
+		}
+	}
+	for index, server := range options.DNSServers {
+		if ip := net.ParseIP(server); ip == nil || ip.To4() != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "Router advertisement DNS servers must be a list of valid IPv6 addresses.",
+				Field:   raField.Child("dnsServers").Index(index).String(),
+			})
+		}
+	}
+	return causes
+}
+
+func validateMasqueradeFirewall(field *k8sfield.Path, iface v1.Interface, idx int) (causes []metav1.StatusCause) {
+	if iface.Masquerade == nil || iface.Masquerade.Firewall == nil {
+		return nil
+	}
+	firewallField := field.Child("domain", "devices", "interfaces").Index(idx).Child("masquerade", "firewall")
+	causes = append(causes, validateFirewallPolicy(firewallField.Child("ingress"), iface.Masquerade.Firewall.Ingress)...)
+	causes = append(causes, validateFirewallPolicy(firewallField.Child("egress"), iface.Masquerade.Firewall.Egress)...)
+	return causes
+}
+
+func validateFirewallPolicy(field *k8sfield.Path, policy *v1.FirewallPolicy) (causes []metav1.StatusCause) {
+	if policy == nil {
+		return nil
+	}
+	if policy.DefaultAction != "" && !isValidFirewallAction(policy.DefaultAction) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("firewall action %q is not supported, must be %s or %s", policy.DefaultAction, v1.FirewallActionAllow, v1.FirewallActionDeny),
+			Field:   field.Child("defaultAction").String(),
+		})
+	}
+	for ruleIdx, rule := range policy.Rules {
+		causes = append(causes, validateFirewallRule(field.Child("rules").Index(ruleIdx), rule)...)
+	}
+	return causes
+}
+
+func validateFirewallRule(field *k8sfield.Path, rule v1.FirewallRule) (causes []metav1.StatusCause) {
+	if !isValidFirewallAction(rule.Action) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("firewall action %q is not supported, must be %s or %s", rule.Action, v1.FirewallActionAllow, v1.FirewallActionDeny),
+			Field:   field.Child("action").String(),
+		})
+	}
+	for cidrIdx, cidr := range rule.RemoteCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%q is not a valid CIDR", cidr),
+				Field:   field.Child("remoteCIDRs").Index(cidrIdx).String(),
+			})
+		}
+	}
+
+	protocol := strings.ToUpper(rule.Protocol)
+	switch protocol {
+	case "", "TCP", "UDP", "SCTP", "ICMP":
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("firewall protocol %q is not supported, must be one of TCP, UDP, SCTP or ICMP", rule.Protocol),
+			Field:   field.Child("protocol").String(),
+		})
+	}
+
+	if len(rule.Ports) > 0 && (protocol == "" || protocol == "ICMP") {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "firewall ports can only be set along with the TCP, UDP or SCTP protocols",
+			Field:   field.Child("ports").String(),
+		})
+	}
+	for portIdx, port := range rule.Ports {
+		if !isValidFirewallPortRange(port) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%q is not a valid port or port range, expected a port between 1 and 65535 or a range in the form of first-last", port),
+				Field:   field.Child("ports").Index(portIdx).String(),
+			})
+		}
+	}
+	return causes
+}
+
+func isValidFirewallAction(action v1.FirewallAction) bool {
+	return action == v1.FirewallActionAllow || action == v1.FirewallActionDeny
+}
+
+func isValidFirewallPortRange(portRange string) bool {
+	first, last, isRange := strings.Cut(portRange, "-")
+	firstPort, err := strconv.Atoi(first)
+	if err != nil || firstPort < 1 || firstPort > 65535 {
+		return false
+	}
+	if !isRange {
+		return true
+	}
+	lastPort, err := strconv.Atoi(last)
+	return err == nil && lastPort >= firstPort && lastPort <= 65535
+}
+
 func validateDHCPPrivateOptionsWithinRange(field *k8sfield.Path, DHCPPrivateOption v1.DHCPPrivateOptions) (causes []metav1.StatusCause) {
 	if !(DHCPPrivateOption.Option >= 224 && DHCPPrivateOption.Option <= 254) {
 		causes = append(causes, metav1.StatusCause{
@@ -543,6 +695,24 @@ func appendStatusCauseForMacvtapFeatureGateNotEnabled(field *k8sfield.Path, caus
 	return causes
 }
 
+func appendStatusCauseForVhostUserOnlyAllowedWithMultus(field *k8sfield.Path, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
+	causes = append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: "VhostUser interface only implemented with Multus network",
+		Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("name").String(),
+	})
+	return causes
+}
+
+func appendStatusCauseForVhostUserFeatureGateNotEnabled(field *k8sfield.Path, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
+	causes = append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: "VhostUser feature gate is not enabled",
+		Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("name").String(),
+	})
+	return causes
+}
+
 func appendStatusCauseForBridgeNotEnabled(field *k8sfield.Path, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
 	causes = append(causes, metav1.StatusCause{
 		Type:    metav1.CauseTypeFieldValueInvalid,
@@ -844,6 +1014,36 @@ func validateFilesystemsWithVirtIOFSEnabled(field *k8sfield.Path, spec *v1.Virtu
 			Field:   field.Child("Filesystems").String(),
 		})
 	}
+	if spec.Domain.Devices.FilesystemHotplug && !config.VirtiofsEnabled() {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "virtiofs feature gate is not enabled in kubevirt-config, filesystem hotplug is not supported",
+			Field:   field.Child("domain", "devices", "filesystemHotplug").String(),
+		})
+	}
+	return causes
+}
+
+// validateHotplugFilesystems rejects hotplugged filesystems when filesystem hotplug is not enabled,
+// the guest memory is only shared with virtiofsd when it is set before the VMI starts.
+func validateHotplugFilesystems(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	if spec.Domain.Devices.FilesystemHotplug {
+		return nil
+	}
+
+	volumes := make(map[string]*v1.Volume, len(spec.Volumes))
+	for i := range spec.Volumes {
+		volumes[spec.Volumes[i].Name] = &spec.Volumes[i]
+	}
+	for i, fs := range spec.Domain.Devices.Filesystems {
+		if volume, exists := volumes[fs.Name]; exists && util.IsHotplugVolume(volume) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("filesystem %s uses a hotpluggable volume, which requires filesystemHotplug to be enabled", fs.Name),
+				Field:   field.Child("domain", "devices", "filesystems").Index(i).Child("name").String(),
+			})
+		}
+	}
 	return causes
 }
 
@@ -1017,7 +1217,9 @@ func validateBootOrder(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec
 
 		matchingVolume, volumeExists := volumeNameMap[disk.Name]
 
-		if !volumeExists {
+		// A cdrom without a matching volume is an empty cdrom
+		isCDRom := disk.CDRom != nil && disk.Disk == nil && disk.LUN == nil
+		if !volumeExists && !isCDRom {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
 				Message: fmt.Sprintf(nameOfTypeNotFoundMessagePattern, field.Child("domain", "devices", "disks").Index(idx).Child("Name").String(), disk.Name),
@@ -1026,10 +1228,10 @@ func validateBootOrder(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec
 		}
 
 		// Verify Lun disks are only mapped to network/block devices.
-		if disk.LUN != nil && volumeExists && matchingVolume.PersistentVolumeClaim == nil && matchingVolume.DataVolume == nil {
+		if disk.LUN != nil && volumeExists && matchingVolume.PersistentVolumeClaim == nil && matchingVolume.DataVolume == nil && matchingVolume.ISCSI == nil {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: fmt.Sprintf("%s can only be mapped to a DataVolume or PersistentVolumeClaim volume.", field.Child("domain", "devices", "disks").Index(idx).Child("lun").String()),
+				Message: fmt.Sprintf("%s can only be mapped to a DataVolume, PersistentVolumeClaim or iSCSI volume.", field.Child("domain", "devices", "disks").Index(idx).Child("lun").String()),
 				Field:   field.Child("domain", "devices", "disks").Index(idx).Child("lun").String(),
 			})
 		}
@@ -1429,6 +1631,32 @@ func validateGuestMemoryLimit(field *k8sfield.Path, spec *v1.VirtualMachineInsta
 	return causes
 }
 
+// vhost-user exposes the guest memory to the userspace switch, which requires it to be backed by
+// shared hugepages on a guest NUMA cell.
+func validateVhostUserMemory(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	if !netvmispec.VhostUserInterfaceExist(spec.Domain.Devices.Interfaces) {
+		return causes
+	}
+	if spec.Domain.Memory == nil || spec.Domain.Memory.Hugepages == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s must be requested when a vhostuser interface is used",
+				field.Child("domain", "memory", "hugepages").String()),
+			Field: field.Child("domain", "memory", "hugepages").String(),
+		})
+	}
+	// The guest memory is shared with the vhost-user backend per guest NUMA cell
+	if spec.Domain.CPU == nil || spec.Domain.CPU.NUMA == nil || spec.Domain.CPU.NUMA.GuestMappingPassthrough == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s must be set when a vhostuser interface is used",
+				field.Child("domain", "cpu", "numa", "guestMappingPassthrough").String()),
+			Field: field.Child("domain", "cpu", "numa", "guestMappingPassthrough").String(),
+		})
+	}
+	return causes
+}
+
 func validateHugepagesMemoryRequests(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
 	if spec.Domain.Memory != nil && spec.Domain.Memory.Hugepages != nil {
 		hugepagesSize, err := resource.ParseQuantity(spec.Domain.Memory.Hugepages.PageSize)
@@ -2041,6 +2269,10 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 		if volume.Ephemeral != nil {
 			volumeSourceSetCount++
 		}
+		if volume.Overlay != nil {
+			volumeSourceSetCount++
+			causes = append(causes, validateOverlayVolume(field.Index(idx).Child("overlay"), volume.Overlay)...)
+		}
 		if volume.EmptyDisk != nil {
 			volumeSourceSetCount++
 		}
@@ -2086,6 +2318,25 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 			memoryDumpVolumeCount++
 			volumeSourceSetCount++
 		}
+		if volume.NBD != nil {
+			volumeSourceSetCount++
+			causes = append(causes, validateNBDVolume(field.Index(idx).Child("nbd"), volume.NBD)...)
+		}
+		if volume.ISCSI != nil {
+			volumeSourceSetCount++
+			causes = append(causes, validateISCSIVolume(field.Index(idx).Child("iscsi"), volume.ISCSI)...)
+		}
+		if volume.RBD != nil {
+			volumeSourceSetCount++
+			causes = append(causes, validateRBDVolume(field.Index(idx).Child("rbd"), volume.RBD)...)
+		}
+		if networkvolume.IsNetworkVolume(&volume) && !config.NetworkVolumesEnabled() {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s feature gate is not enabled", virtconfig.NetworkVolumesGate),
+				Field:   field.Index(idx).String(),
+			})
+		}
 
 		if volumeSourceSetCount != 1 {
 			causes = append(causes, metav1.StatusCause{
@@ -2277,6 +2528,21 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 		}
 	}
 
+	// the base image of an overlay is attached to the pod as additional volume
+	for idx, volume := range volumes {
+		if volume.Overlay == nil {
+			continue
+		}
+		baseVolumeName := ephemeraldisk.OverlayBaseVolumeName(volume.Name)
+		if otherIdx, exists := nameMap[baseVolumeName]; exists {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must not be named %s, the name is reserved for the base image of %s", field.Index(otherIdx).String(), baseVolumeName, field.Index(idx).String()),
+				Field:   field.Index(otherIdx).Child("name").String(),
+			})
+		}
+	}
+
 	if serviceAccountVolumeCount > 1 {
 		causes = append(causes, metav1.StatusCause{
 			Type:    metav1.CauseTypeFieldValueInvalid,
@@ -2303,6 +2569,121 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 	return causes
 }
 
+func validateOverlayVolume(field *k8sfield.Path, overlay *v1.OverlayVolumeSource) (causes []metav1.StatusCause) {
+	if overlay.ClaimName == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf(requiredFieldFmt, field.Child("claimName").String()),
+			Field:   field.Child("claimName").String(),
+		})
+	}
+	if overlay.BaseClaimName == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf(requiredFieldFmt, field.Child("baseClaimName").String()),
+			Field:   field.Child("baseClaimName").String(),
+		})
+	}
+	if overlay.ClaimName != "" && overlay.ClaimName == overlay.BaseClaimName {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must differ from %s", field.Child("claimName").String(), field.Child("baseClaimName").String()),
+			Field:   field.Child("claimName").String(),
+		})
+	}
+	return causes
+}
+
+func validateNBDVolume(field *k8sfield.Path, nbd *v1.NBDVolumeSource) (causes []metav1.StatusCause) {
+	if nbd.URI == "" {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf(requiredFieldFmt, field.Child("uri").String()),
+			Field:   field.Child("uri").String(),
+		})
+	}
+	if _, _, _, err := networkvolume.ParseNBDURI(nbd.URI); err != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is invalid: %v", field.Child("uri").String(), err),
+			Field:   field.Child("uri").String(),
+		})
+	}
+	return causes
+}
+
+func validateISCSIVolume(field *k8sfield.Path, iscsi *v1.ISCSIVolumeSource) (causes []metav1.StatusCause) {
+	if iscsi.TargetPortal == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf(requiredFieldFmt, field.Child("targetPortal").String()),
+			Field:   field.Child("targetPortal").String(),
+		})
+	} else if _, _, err := networkvolume.SplitHostPort(iscsi.TargetPortal, networkvolume.DefaultISCSIPort); err != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is invalid: %v", field.Child("targetPortal").String(), err),
+			Field:   field.Child("targetPortal").String(),
+		})
+	}
+	if iscsi.IQN == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf(requiredFieldFmt, field.Child("iqn").String()),
+			Field:   field.Child("iqn").String(),
+		})
+	}
+	if iscsi.Lun < 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must not be negative", field.Child("lun").String()),
+			Field:   field.Child("lun").String(),
+		})
+	}
+	if iscsi.CHAPSecretRef != nil && iscsi.CHAPSecretRef.Name == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf(requiredFieldFmt, field.Child("chapSecretRef", "name").String()),
+			Field:   field.Child("chapSecretRef", "name").String(),
+		})
+	}
+	return causes
+}
+
+func validateRBDVolume(field *k8sfield.Path, rbd *v1.RBDVolumeSource) (causes []metav1.StatusCause) {
+	if len(rbd.Monitors) == 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf(requiredFieldFmt, field.Child("monitors").String()),
+			Field:   field.Child("monitors").String(),
+		})
+	}
+	for i, monitor := range rbd.Monitors {
+		if _, _, err := networkvolume.SplitHostPort(monitor, networkvolume.DefaultRBDPort); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is invalid: %v", field.Child("monitors").Index(i).String(), err),
+				Field:   field.Child("monitors").Index(i).String(),
+			})
+		}
+	}
+	if rbd.Image == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf(requiredFieldFmt, field.Child("image").String()),
+			Field:   field.Child("image").String(),
+		})
+	}
+	if rbd.KeyringSecretRef != nil && rbd.KeyringSecretRef.Name == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf(requiredFieldFmt, field.Child("keyringSecretRef", "name").String()),
+			Field:   field.Child("keyringSecretRef", "name").String(),
+		})
+	}
+	return causes
+}
+
 func validateDevices(field *k8sfield.Path, devices *v1.Devices) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	causes = append(causes, validateDisks(field.Child("disks"), devices.Disks)...)
@@ -2373,10 +2754,10 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 
 		// Verify pci address
 		if disk.Disk != nil && disk.Disk.PciAddress != "" {
-			if disk.Disk.Bus != v1.DiskBusVirtio {
+			if disk.Disk.Bus != v1.DiskBusVirtio && disk.Disk.Bus != v1.DiskBusNVMe {
 				causes = append(causes, metav1.StatusCause{
 					Type:    metav1.CauseTypeFieldValueInvalid,
-					Message: fmt.Sprintf("disk %s - setting a PCI address is only possible with bus type virtio.", field.Child("domain", "devices", "disks", "disk").Index(idx).Child("name").String()),
+					Message: fmt.Sprintf("disk %s - setting a PCI address is only possible with bus type virtio or nvme.", field.Child("domain", "devices", "disks", "disk").Index(idx).Child("name").String()),
 					Field:   field.Child("domain", "devices", "disks", "disk").Index(idx).Child("pciAddress").String(),
 				})
 			}
@@ -2409,7 +2790,7 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 					Field:   field.Index(idx).Child(diskType, "bus").String(),
 				})
 			} else {
-				buses := []v1.DiskBus{v1.DiskBusVirtio, v1.DiskBusSCSI, v1.DiskBusSATA, v1.DiskBusUSB}
+				buses := []v1.DiskBus{v1.DiskBusVirtio, v1.DiskBusSCSI, v1.DiskBusSATA, v1.DiskBusUSB, v1.DiskBusNVMe}
 				validBus := false
 				for _, b := range buses {
 					if b == bus {
@@ -2433,6 +2814,14 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 					})
 
 				}
+				// nvme only emulates hard-disks
+				if diskType != "disk" && bus == v1.DiskBusNVMe {
+					causes = append(causes, metav1.StatusCause{
+						Type:    metav1.CauseTypeFieldValueInvalid,
+						Message: fmt.Sprintf("Bus type %s is only supported for hard-disks", bus),
+						Field:   field.Index(idx).Child(diskType, "bus").String(),
+					})
+				}
 				// sata disks (in contrast to sata cdroms) don't support readOnly
 				if disk.Disk != nil && bus == v1.DiskBusSATA && disk.Disk.ReadOnly {
 					causes = append(causes, metav1.StatusCause{
@@ -2454,6 +2843,17 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 					Field:   field.Child("domain", "devices", "disks").Index(idx).String(),
 				})
 			}
+
+			// The NVMe controller of a disk does not support IOThreads either
+			isIOThreadsWithNVMeBus := disk.DedicatedIOThread != nil && *disk.DedicatedIOThread &&
+				(disk.DiskDevice.Disk != nil) && (disk.DiskDevice.Disk.Bus == v1.DiskBusNVMe)
+			if isIOThreadsWithNVMeBus {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: "IOThreads are not supported for disks on a NVMe bus",
+					Field:   field.Child("domain", "devices", "disks").Index(idx).String(),
+				})
+			}
 		}
 
 		// Verify serial number is made up of valid characters for libvirt, if provided
@@ -2475,6 +2875,33 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 			})
 		}
 
+		// NVMe controllers report serial numbers of at most 20 characters
+		if disk.Serial != "" && bus == v1.DiskBusNVMe && len(disk.Serial) > util.NVMeMaxSerialLength {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be less than or equal to %d in length on a nvme bus, if specified", field.Index(idx).String(), util.NVMeMaxSerialLength),
+				Field:   field.Index(idx).Child("serial").String(),
+			})
+		}
+
+		// Verify the WWN is made of 16 hexadecimal digits and set on a bus presenting it to the guest, if provided
+		if disk.WWN != "" {
+			if !isValidWWN(disk.WWN) {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s must be made up of 16 hexadecimal digits, if specified", field.Index(idx).Child("wwn").String()),
+					Field:   field.Index(idx).Child("wwn").String(),
+				})
+			}
+			if bus != v1.DiskBusSCSI && bus != v1.DiskBusSATA && bus != v1.DiskBusNVMe {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("%s is only supported on the scsi, sata and nvme buses", field.Index(idx).Child("wwn").String()),
+					Field:   field.Index(idx).Child("wwn").String(),
+				})
+			}
+		}
+
 		// Verify if cache mode is valid
 		if disk.Cache != "" && disk.Cache != v1.CacheNone && disk.Cache != v1.CacheWriteThrough && disk.Cache != v1.CacheWriteBack {
 			causes = append(causes, metav1.StatusCause{
@@ -2550,8 +2977,89 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 				}
 			}
 		}
+
+		if disk.IOTune != nil {
+			causes = append(causes, validateDiskIOTune(field.Index(idx).Child("ioTune"), disk.IOTune)...)
+		}
+	}
+
+	return causes
+}
+
+var wwnRegex = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{16}$`)
+
+func isValidWWN(wwn string) bool {
+	return wwnRegex.MatchString(wwn)
+}
+
+func validateDiskIOTune(field *k8sfield.Path, ioTune *v1.DiskIOTune) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	for _, limit := range []struct {
+		name  string
+		value int64
+	}{
+		{"totalBytesSec", ioTune.TotalBytesSec},
+		{"readBytesSec", ioTune.ReadBytesSec},
+		{"writeBytesSec", ioTune.WriteBytesSec},
+		{"totalIOPSSec", ioTune.TotalIOPSSec},
+		{"readIOPSSec", ioTune.ReadIOPSSec},
+		{"writeIOPSSec", ioTune.WriteIOPSSec},
+		{"totalBytesSecMax", ioTune.TotalBytesSecMax},
+		{"readBytesSecMax", ioTune.ReadBytesSecMax},
+		{"writeBytesSecMax", ioTune.WriteBytesSecMax},
+		{"totalIOPSSecMax", ioTune.TotalIOPSSecMax},
+		{"readIOPSSecMax", ioTune.ReadIOPSSecMax},
+		{"writeIOPSSecMax", ioTune.WriteIOPSSecMax},
+	} {
+		if limit.value < 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must not be negative", field.Child(limit.name).String()),
+				Field:   field.Child(limit.name).String(),
+			})
+		}
+	}
+	if len(causes) > 0 {
+		return causes
 	}
 
+	exclusive := func(total int64, totalName string, read int64, readName string, write int64, writeName string) {
+		if total != 0 && (read != 0 || write != 0) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s can't be set together with %s or %s", field.Child(totalName).String(), readName, writeName),
+				Field:   field.Child(totalName).String(),
+			})
+		}
+	}
+	exclusive(ioTune.TotalBytesSec, "totalBytesSec", ioTune.ReadBytesSec, "readBytesSec", ioTune.WriteBytesSec, "writeBytesSec")
+	exclusive(ioTune.TotalIOPSSec, "totalIOPSSec", ioTune.ReadIOPSSec, "readIOPSSec", ioTune.WriteIOPSSec, "writeIOPSSec")
+	exclusive(ioTune.TotalBytesSecMax, "totalBytesSecMax", ioTune.ReadBytesSecMax, "readBytesSecMax", ioTune.WriteBytesSecMax, "writeBytesSecMax")
+	exclusive(ioTune.TotalIOPSSecMax, "totalIOPSSecMax", ioTune.ReadIOPSSecMax, "readIOPSSecMax", ioTune.WriteIOPSSecMax, "writeIOPSSecMax")
+
+	burst := func(limit int64, limitName string, max int64, maxName string) {
+		if max != 0 && max < limit {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be greater than or equal to %s", field.Child(maxName).String(), limitName),
+				Field:   field.Child(maxName).String(),
+			})
+		} else if max != 0 && limit == 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s requires %s to be set", field.Child(maxName).String(), limitName),
+				Field:   field.Child(limitName).String(),
+			})
+		}
+	}
+	burst(ioTune.TotalBytesSec, "totalBytesSec", ioTune.TotalBytesSecMax, "totalBytesSecMax")
+	burst(ioTune.ReadBytesSec, "readBytesSec", ioTune.ReadBytesSecMax, "readBytesSecMax")
+	burst(ioTune.WriteBytesSec, "writeBytesSec", ioTune.WriteBytesSecMax, "writeBytesSecMax")
+	burst(ioTune.TotalIOPSSec, "totalIOPSSec", ioTune.TotalIOPSSecMax, "totalIOPSSecMax")
+	burst(ioTune.ReadIOPSSec, "readIOPSSec", ioTune.ReadIOPSSecMax, "readIOPSSecMax")
+	burst(ioTune.WriteIOPSSec, "writeIOPSSec", ioTune.WriteIOPSSecMax, "writeIOPSSecMax")
+
 	return causes
 }
 
@@ -2676,18 +3184,99 @@ func validatePersistentReservation(field *k8sfield.Path, spec *v1.VirtualMachine
 }
 
 func validatePersistentState(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
-	if !backendstorage.HasPersistentTPMDevice(spec) {
+	if config.VMPersistentStateEnabled() {
 		return
 	}
 
-	if !config.VMPersistentStateEnabled() {
+	if backendstorage.HasPersistentTPMDevice(spec) {
 		causes = append(causes, metav1.StatusCause{
 			Type:    metav1.CauseTypeFieldValueInvalid,
 			Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config", virtconfig.VMPersistentState),
 			Field:   field.Child("domain", "devices", "tpm", "persistent").String(),
 		})
 	}
+	if backendstorage.HasPersistentEFI(spec) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config", virtconfig.VMPersistentState),
+			Field:   field.Child("domain", "firmware", "bootloader", "efi", "persistent").String(),
+		})
+	}
+
+	return
+}
+
+func validateDiskEncryption(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	volumes := make(map[string]*v1.Volume, len(spec.Volumes))
+	for i := range spec.Volumes {
+		volumes[spec.Volumes[i].Name] = &spec.Volumes[i]
+	}
+
+	for idx, disk := range spec.Domain.Devices.Disks {
+		if disk.Encryption == nil {
+			continue
+		}
+		diskField := field.Child("domain", "devices", "disks").Index(idx)
 
+		if !config.DiskEncryptionEnabled() {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config", virtconfig.DiskEncryptionGate),
+				Field:   diskField.Child("encryption").String(),
+			})
+			continue
+		}
+		if disk.Encryption.SecretRef.Name == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s is required", diskField.Child("encryption", "secretRef", "name").String()),
+				Field:   diskField.Child("encryption", "secretRef", "name").String(),
+			})
+		}
+		if disk.Disk == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s can only be used with disk targets", diskField.Child("encryption").String()),
+				Field:   diskField.Child("encryption").String(),
+			})
+		}
+		volume, ok := volumes[disk.Name]
+		if !ok {
+			continue
+		}
+		isPVC := volume.PersistentVolumeClaim != nil && !volume.PersistentVolumeClaim.Hotpluggable
+		isDV := volume.DataVolume != nil && !volume.DataVolume.Hotpluggable
+		if !isPVC && !isDV {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s requires a persistentVolumeClaim or dataVolume volume which is not hotpluggable", diskField.Child("encryption").String()),
+				Field:   diskField.Child("encryption").String(),
+			})
+		}
+	}
+
+	return
+}
+
+func validateFilesystemTrim(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if spec.FilesystemTrim == nil {
+		return
+	}
+	if !config.FilesystemTrimEnabled() {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config", virtconfig.FilesystemTrimGate),
+			Field:   field.Child("filesystemTrim").String(),
+		})
+	}
+	// Trimming makes the storage work through all the free blocks, doing it too often only creates load
+	if interval := spec.FilesystemTrim.Interval; interval != nil && interval.Duration < minFilesystemTrimInterval {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be at least %s", field.Child("filesystemTrim", "interval").String(), minFilesystemTrimInterval),
+			Field:   field.Child("filesystemTrim", "interval").String(),
+		})
+	}
 	return
 }
 
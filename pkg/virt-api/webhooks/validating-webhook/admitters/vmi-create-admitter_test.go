@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 
@@ -46,6 +47,7 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/hooks"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	nodelabellerutil "kubevirt.io/kubevirt/pkg/virt-handler/node-labeller/util"
@@ -72,9 +74,9 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 	vmiCreateAdmitter := &VMICreateAdmitter{ClusterConfig: config}
 
 	dnsConfigTestOption := "test"
-	enableFeatureGate := func(featureGate string) {
+	enableFeatureGate := func(featureGates ...string) {
 		kvConfig := kv.DeepCopy()
-		kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{featureGate}
+		kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = featureGates
 		testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
 	}
 	disableFeatureGates := func() {
@@ -623,6 +625,21 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes).To(HaveLen(1))
 			Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[0].name"))
 		})
+		It("should accept an empty cdrom", func() {
+			vmi := api.NewMinimalVMI("testvmi")
+
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "cdrom",
+				DiskDevice: v1.DiskDevice{
+					CDRom: &v1.CDRomTarget{
+						Bus: v1.DiskBusSATA,
+					},
+				},
+			})
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
 		It("should allow supported audio devices", func() {
 			supportedDevices := [...]string{"", "ich9", "ac97"}
 			vmi := api.NewMinimalVMI("testvmi")
@@ -1797,6 +1814,187 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
 			Expect(causes).To(BeEmpty())
 		})
+		Context("with a vhostuser interface", func() {
+			newVhostUserVMI := func(networkSource v1.NetworkSource) *v1.VirtualMachineInstance {
+				vmi := api.NewMinimalVMI("testvm")
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+					Name: "default",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{
+						VhostUser: &v1.InterfaceVhostUser{},
+					},
+				}}
+				vmi.Spec.Networks = []v1.Network{{Name: "default", NetworkSource: networkSource}}
+				vmi.Spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}}
+				vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{k8sv1.ResourceMemory: resource.MustParse("64Mi")}
+				vmi.Spec.Domain.CPU = &v1.CPU{
+					Cores:                 2,
+					DedicatedCPUPlacement: true,
+					NUMA:                  &v1.NUMA{GuestMappingPassthrough: &v1.NUMAGuestMappingPassthrough{}},
+				}
+				return vmi
+			}
+			multusNetwork := v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "test"}}
+
+			It("should reject it when the feature is inactive", func() {
+				vmi := newVhostUserVMI(multusNetwork)
+
+				enableFeatureGate(virtconfig.NUMAFeatureGate)
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].name"))
+				Expect(causes[0].Message).To(Equal("VhostUser feature gate is not enabled"))
+			})
+			It("should reject it on a network different than multus", func() {
+				vmi := newVhostUserVMI(v1.NetworkSource{Pod: &v1.PodNetwork{}})
+
+				enableFeatureGate(virtconfig.VhostUserGate, virtconfig.NUMAFeatureGate)
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].name"))
+				Expect(causes[0].Message).To(Equal("VhostUser interface only implemented with Multus network"))
+			})
+			It("should reject it without hugepages", func() {
+				vmi := newVhostUserVMI(multusNetwork)
+				vmi.Spec.Domain.Memory = nil
+
+				enableFeatureGate(virtconfig.VhostUserGate, virtconfig.NUMAFeatureGate)
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(ContainElement(And(
+					HaveField("Type", metav1.CauseTypeFieldValueRequired),
+					HaveField("Field", "fake.domain.memory.hugepages"),
+				)))
+			})
+			It("should reject it without a guest NUMA topology", func() {
+				vmi := newVhostUserVMI(multusNetwork)
+				vmi.Spec.Domain.CPU.NUMA = nil
+
+				enableFeatureGate(virtconfig.VhostUserGate, virtconfig.NUMAFeatureGate)
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Type).To(Equal(metav1.CauseTypeFieldValueRequired))
+				Expect(causes[0].Field).To(Equal("fake.domain.cpu.numa.guestMappingPassthrough"))
+			})
+			It("should accept it on a multus network with hugepages and NUMA when the feature is active", func() {
+				vmi := newVhostUserVMI(multusNetwork)
+
+				enableFeatureGate(virtconfig.VhostUserGate, virtconfig.NUMAFeatureGate)
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(BeEmpty())
+			})
+		})
+		Context("with a masquerade interface firewall", func() {
+			newMasqueradeFirewallVMI := func(policy *v1.FirewallPolicy) *v1.VirtualMachineInstance {
+				vmi := api.NewMinimalVMI("testvm")
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+					Name: "default",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{
+						Masquerade: &v1.InterfaceMasquerade{Firewall: &v1.InterfaceFirewall{Ingress: policy}},
+					},
+				}}
+				vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+				return vmi
+			}
+
+			It("should accept valid rules", func() {
+				vmi := newMasqueradeFirewallVMI(&v1.FirewallPolicy{
+					DefaultAction: v1.FirewallActionDeny,
+					Rules: []v1.FirewallRule{
+						{Action: v1.FirewallActionAllow, RemoteCIDRs: []string{"10.0.0.0/8", "fd10::/64"}, Protocol: "TCP", Ports: []string{"22", "8000-9000"}},
+						{Action: v1.FirewallActionAllow, Protocol: "ICMP"},
+					},
+				})
+
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(BeEmpty())
+			})
+
+			DescribeTable("should reject", func(policy *v1.FirewallPolicy, expectedField string) {
+				vmi := newMasqueradeFirewallVMI(policy)
+
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			},
+				Entry("an unknown default action",
+					&v1.FirewallPolicy{DefaultAction: "Reject"},
+					"fake.domain.devices.interfaces[0].masquerade.firewall.ingress.defaultAction"),
+				Entry("a rule without an action",
+					&v1.FirewallPolicy{Rules: []v1.FirewallRule{{Protocol: "TCP"}}},
+					"fake.domain.devices.interfaces[0].masquerade.firewall.ingress.rules[0].action"),
+				Entry("an invalid CIDR",
+					&v1.FirewallPolicy{Rules: []v1.FirewallRule{{Action: v1.FirewallActionDeny, RemoteCIDRs: []string{"10.0.0.1"}}}},
+					"fake.domain.devices.interfaces[0].masquerade.firewall.ingress.rules[0].remoteCIDRs[0]"),
+				Entry("an unknown protocol",
+					&v1.FirewallPolicy{Rules: []v1.FirewallRule{{Action: v1.FirewallActionDeny, Protocol: "GRE"}}},
+					"fake.domain.devices.interfaces[0].masquerade.firewall.ingress.rules[0].protocol"),
+				Entry("ports without a protocol",
+					&v1.FirewallPolicy{Rules: []v1.FirewallRule{{Action: v1.FirewallActionDeny, Ports: []string{"80"}}}},
+					"fake.domain.devices.interfaces[0].masquerade.firewall.ingress.rules[0].ports"),
+				Entry("an inverted port range",
+					&v1.FirewallPolicy{Rules: []v1.FirewallRule{{Action: v1.FirewallActionDeny, Protocol: "UDP", Ports: []string{"9000-8000"}}}},
+					"fake.domain.devices.interfaces[0].masquerade.firewall.ingress.rules[0].ports[0]"),
+				Entry("a port out of range",
+					&v1.FirewallPolicy{Rules: []v1.FirewallRule{{Action: v1.FirewallActionDeny, Protocol: "UDP", Ports: []string{"70000"}}}},
+					"fake.domain.devices.interfaces[0].masquerade.firewall.ingress.rules[0].ports[0]"),
+			)
+		})
+		Context("with router advertisement options", func() {
+			newRouterAdvertisementVMI := func(binding v1.InterfaceBindingMethod, options *v1.RouterAdvertisementOptions) *v1.VirtualMachineInstance {
+				vmi := api.NewMinimalVMI("testvm")
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+					Name:                   "default",
+					InterfaceBindingMethod: binding,
+					RouterAdvertisement:    options,
+				}}
+				vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+				return vmi
+			}
+			masquerade := v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}
+			passt := v1.InterfaceBindingMethod{Passt: &v1.InterfacePasst{}}
+
+			BeforeEach(func() {
+				enableFeatureGate(virtconfig.PasstGate)
+			})
+
+			DescribeTable("should accept", func(binding v1.InterfaceBindingMethod, options *v1.RouterAdvertisementOptions) {
+				vmi := newRouterAdvertisementVMI(binding, options)
+
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(BeEmpty())
+			},
+				Entry("all the options on a masquerade interface", masquerade, &v1.RouterAdvertisementOptions{
+					Prefix:                      "fd10:0:2::/64",
+					DNSServers:                  []string{"fd00::53"},
+					ManagedAddressConfiguration: pointer.Bool(false),
+					OtherConfiguration:          true,
+				}),
+				Entry("DNS servers on a passt interface", passt, &v1.RouterAdvertisementOptions{DNSServers: []string{"fd00::53"}}),
+			)
+
+			DescribeTable("should reject", func(binding v1.InterfaceBindingMethod, options *v1.RouterAdvertisementOptions, expectedField string) {
+				vmi := newRouterAdvertisementVMI(binding, options)
+
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			},
+				Entry("a bridge interface", v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+					&v1.RouterAdvertisementOptions{DNSServers: []string{"fd00::53"}},
+					"fake.domain.devices.interfaces[0].routerAdvertisement"),
+				Entry("a prefix on a passt interface", passt,
+					&v1.RouterAdvertisementOptions{Prefix: "fd10:0:2::/64"},
+					"fake.domain.devices.interfaces[0].routerAdvertisement"),
+				Entry("an IPv4 prefix", masquerade,
+					&v1.RouterAdvertisementOptions{Prefix: "10.0.2.0/24"},
+					"fake.domain.devices.interfaces[0].routerAdvertisement.prefix"),
+				Entry("a prefix without a mask", masquerade,
+					&v1.RouterAdvertisementOptions{Prefix: "fd10:0:2::"},
+					"fake.domain.devices.interfaces[0].routerAdvertisement.prefix"),
+				Entry("an IPv4 DNS server", masquerade,
+					&v1.RouterAdvertisementOptions{DNSServers: []string{"fd00::53", "8.8.8.8"}},
+					"fake.domain.devices.interfaces[0].routerAdvertisement.dnsServers[1]"),
+			)
+		})
 		It("should reject port out of range", func() {
 			enableSlirpInterface()
 			vm := api.NewMinimalVMI("testvm")
@@ -2151,6 +2349,51 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
 			Expect(causes).To(BeEmpty())
 		})
+		DescribeTable("filesystem hotplug", func(featureGates []string, expectedCauses int) {
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = featureGates
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+			vmi := api.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.FilesystemHotplug = true
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(expectedCauses))
+			if expectedCauses > 0 {
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.filesystemHotplug"))
+			}
+		},
+			Entry("should be rejected when the virtiofs feature gate is disabled", nil, 1),
+			Entry("should be allowed when the virtiofs feature gate is enabled", []string{virtconfig.VirtIOFSGate}, 0),
+		)
+		DescribeTable("hotplugged filesystems", func(filesystemHotplug bool, expectedCauses int) {
+			enableFeatureGate(virtconfig.VirtIOFSGate)
+			vmi := api.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.FilesystemHotplug = filesystemHotplug
+			vmi.Spec.Domain.Devices.Filesystems = []v1.Filesystem{
+				{
+					Name:     "dataset",
+					Virtiofs: &v1.FilesystemVirtiofs{},
+				},
+			}
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "dataset",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+						PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "dataset-pvc"},
+						Hotpluggable:                      true,
+					},
+				},
+			})
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(expectedCauses))
+			if expectedCauses > 0 {
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.filesystems[0].name"))
+			}
+		},
+			Entry("should be rejected when filesystem hotplug is not enabled", false, 1),
+			Entry("should be allowed when filesystem hotplug is enabled", true, 0),
+		)
 		It("should accept legacy GPU devices if PermittedHostDevices aren't set", func() {
 			kvConfig := kv.DeepCopy()
 			kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{virtconfig.GPUGate}
@@ -3255,6 +3498,72 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 
 		})
 
+		Context("With nvme bus", func() {
+			nvmeDisk := func(name string) v1.Disk {
+				return v1.Disk{
+					Name:       name,
+					DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusNVMe}},
+				}
+			}
+
+			It("should accept a nvme disk with serial, WWN and PCI address", func() {
+				disk := nvmeDisk("nvme-disk")
+				disk.Serial = "SN-1_a"
+				disk.WWN = "0x5000c50015ea71ac"
+				disk.Disk.PciAddress = "0000:00:0a.0"
+
+				causes := validateDisks(k8sfield.NewPath("fake"), []v1.Disk{disk})
+				Expect(causes).To(BeEmpty())
+			})
+
+			DescribeTable("should reject a nvme", func(disk v1.Disk, field string) {
+				causes := validateDisks(k8sfield.NewPath("fake"), []v1.Disk{disk})
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(field))
+			},
+				Entry("cdrom", v1.Disk{
+					Name:       "nvme-cdrom",
+					DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: v1.DiskBusNVMe}},
+				}, "fake[0].cdrom.bus"),
+				Entry("lun", v1.Disk{
+					Name:       "nvme-lun",
+					DiskDevice: v1.DiskDevice{LUN: &v1.LunTarget{Bus: v1.DiskBusNVMe}},
+				}, "fake[0].lun.bus"),
+				Entry("disk with a dedicated IOThread", v1.Disk{
+					Name:              "nvme-disk",
+					DedicatedIOThread: pointer.Bool(true),
+					DiskDevice:        v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusNVMe}},
+				}, "fake.domain.devices.disks[0]"),
+				Entry("disk with a serial longer than 20 characters", v1.Disk{
+					Name:       "nvme-disk",
+					Serial:     strings.Repeat("1", util.NVMeMaxSerialLength+1),
+					DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusNVMe}},
+				}, "fake[0].serial"),
+			)
+		})
+
+		DescribeTable("should validate the WWN", func(bus v1.DiskBus, wwn string, expectedField string) {
+			disk := v1.Disk{
+				Name:       "testdisk",
+				WWN:        wwn,
+				DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: bus}},
+			}
+			causes := validateDisks(k8sfield.NewPath("fake"), []v1.Disk{disk})
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			}
+		},
+			Entry("accepting it on a scsi disk", v1.DiskBusSCSI, "5000c50015ea71ac", ""),
+			Entry("accepting it on a sata disk", v1.DiskBusSATA, "0x5000C50015EA71AC", ""),
+			Entry("accepting it on a nvme disk", v1.DiskBusNVMe, "5000c50015ea71ac", ""),
+			Entry("rejecting it on a virtio disk", v1.DiskBusVirtio, "5000c50015ea71ac", "fake[0].wwn"),
+			Entry("rejecting a short value", v1.DiskBusSCSI, "5000c500", "fake[0].wwn"),
+			Entry("rejecting a non hexadecimal value", v1.DiskBusSCSI, "5000c50015ea71ag", "fake[0].wwn"),
+		)
+
 		Context("With block size", func() {
 
 			DescribeTable("It should accept a disk with a valid block size of", func(logicalSize, physicalSize int) {
@@ -3377,9 +3686,150 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				Expect(causes).To(BeEmpty())
 			})
 		})
+
+		Context("with I/O throttling", func() {
+			DescribeTable("should accept", func(ioTune *v1.DiskIOTune) {
+				vmi := api.NewMinimalVMI("testvmi")
+				vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+					Name:   "disk",
+					IOTune: ioTune,
+				})
+
+				causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+				Expect(causes).To(BeEmpty())
+			},
+				Entry("total limits", &v1.DiskIOTune{TotalBytesSec: 1048576, TotalIOPSSec: 100}),
+				Entry("read and write limits", &v1.DiskIOTune{ReadBytesSec: 1048576, WriteBytesSec: 524288, ReadIOPSSec: 100}),
+				Entry("burst limits above the sustained ones", &v1.DiskIOTune{TotalIOPSSec: 100, TotalIOPSSecMax: 200, ReadBytesSec: 1024, ReadBytesSecMax: 1024}),
+				Entry("a throttling group", &v1.DiskIOTune{GroupName: "group", TotalIOPSSec: 100}),
+			)
+
+			DescribeTable("should reject", func(ioTune *v1.DiskIOTune, expectedField string) {
+				vmi := api.NewMinimalVMI("testvmi")
+				vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+					Name:   "disk",
+					IOTune: ioTune,
+				})
+
+				causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			},
+				Entry("total bytes together with read bytes", &v1.DiskIOTune{TotalBytesSec: 1024, ReadBytesSec: 1024}, "fake[0].ioTune.totalBytesSec"),
+				Entry("total IOPS together with write IOPS", &v1.DiskIOTune{TotalIOPSSec: 100, WriteIOPSSec: 100}, "fake[0].ioTune.totalIOPSSec"),
+				Entry("a burst limit below the sustained one", &v1.DiskIOTune{WriteBytesSec: 2048, WriteBytesSecMax: 1024}, "fake[0].ioTune.writeBytesSecMax"),
+				Entry("a burst limit without the sustained one", &v1.DiskIOTune{ReadIOPSSecMax: 100}, "fake[0].ioTune.readIOPSSec"),
+				Entry("a negative limit", &v1.DiskIOTune{TotalBytesSec: -1}, "fake[0].ioTune.totalBytesSec"),
+				Entry("a negative burst limit", &v1.DiskIOTune{ReadIOPSSec: 100, ReadIOPSSecMax: -100}, "fake[0].ioTune.readIOPSSecMax"),
+			)
+		})
 	})
 
 	Context("with volume", func() {
+		DescribeTable("with an overlay volume", func(overlay *v1.OverlayVolumeSource, otherVolumeName string, expectedFields ...string) {
+			volumes := []v1.Volume{{
+				Name:         "vdi",
+				VolumeSource: v1.VolumeSource{Overlay: overlay},
+			}}
+			if otherVolumeName != "" {
+				volumes = append(volumes, v1.Volume{
+					Name:         otherVolumeName,
+					VolumeSource: v1.VolumeSource{EmptyDisk: &v1.EmptyDiskSource{Capacity: resource.MustParse("1Gi")}},
+				})
+			}
+
+			causes := validateVolumes(k8sfield.NewPath("fake"), volumes, config)
+			Expect(causes).To(HaveLen(len(expectedFields)))
+			for i, field := range expectedFields {
+				Expect(causes[i].Field).To(Equal(field))
+			}
+		},
+			Entry("should accept an overlay on a base image", &v1.OverlayVolumeSource{ClaimName: "vdi-overlay", BaseClaimName: "golden"}, ""),
+			Entry("should reject a missing claim name", &v1.OverlayVolumeSource{BaseClaimName: "golden"}, "", "fake[0].overlay.claimName"),
+			Entry("should reject a missing base claim name", &v1.OverlayVolumeSource{ClaimName: "vdi-overlay"}, "", "fake[0].overlay.baseClaimName"),
+			Entry("should reject the same claim for overlay and base", &v1.OverlayVolumeSource{ClaimName: "golden", BaseClaimName: "golden"}, "", "fake[0].overlay.claimName"),
+			Entry("should reject a volume named like the base image volume", &v1.OverlayVolumeSource{ClaimName: "vdi-overlay", BaseClaimName: "golden"}, "vdi-base", "fake[1].name"),
+		)
+
+		Context("with network volumes", func() {
+			BeforeEach(func() {
+				enableFeatureGate(virtconfig.NetworkVolumesGate)
+			})
+
+			AfterEach(func() {
+				disableFeatureGates()
+			})
+
+			DescribeTable("should validate the volume source", func(source v1.VolumeSource, expectedFields ...string) {
+				volumes := []v1.Volume{{Name: "network", VolumeSource: source}}
+
+				causes := validateVolumes(k8sfield.NewPath("fake"), volumes, config)
+				Expect(causes).To(HaveLen(len(expectedFields)))
+				for i, field := range expectedFields {
+					Expect(causes[i].Field).To(Equal(field))
+				}
+			},
+				Entry("should accept an NBD export", v1.VolumeSource{NBD: &v1.NBDVolumeSource{URI: "nbd://nbd.example.com:10809/disk0"}}),
+				Entry("should reject a missing NBD URI", v1.VolumeSource{NBD: &v1.NBDVolumeSource{}}, "fake[0].nbd.uri"),
+				Entry("should reject a non NBD URI", v1.VolumeSource{NBD: &v1.NBDVolumeSource{URI: "http://nbd.example.com/disk0"}}, "fake[0].nbd.uri"),
+				Entry("should accept an iSCSI target with CHAP", v1.VolumeSource{ISCSI: &v1.ISCSIVolumeSource{
+					TargetPortal:  "10.0.0.1:3260",
+					IQN:           "iqn.2024-01.com.example:storage",
+					CHAPSecretRef: &k8sv1.LocalObjectReference{Name: "chap"},
+				}}),
+				Entry("should reject an iSCSI target without portal and IQN", v1.VolumeSource{ISCSI: &v1.ISCSIVolumeSource{}},
+					"fake[0].iscsi.targetPortal", "fake[0].iscsi.iqn"),
+				Entry("should reject a negative iSCSI lun", v1.VolumeSource{ISCSI: &v1.ISCSIVolumeSource{
+					TargetPortal: "10.0.0.1",
+					IQN:          "iqn.2024-01.com.example:storage",
+					Lun:          -1,
+				}}, "fake[0].iscsi.lun"),
+				Entry("should accept an RBD image", v1.VolumeSource{RBD: &v1.RBDVolumeSource{
+					Monitors:         []string{"10.0.0.1", "10.0.0.2:6789"},
+					Image:            "vm-disk",
+					KeyringSecretRef: &k8sv1.LocalObjectReference{Name: "ceph"},
+				}}),
+				Entry("should reject an RBD image without monitors and image", v1.VolumeSource{RBD: &v1.RBDVolumeSource{}},
+					"fake[0].rbd.monitors", "fake[0].rbd.image"),
+				Entry("should reject an RBD keyring without Secret name", v1.VolumeSource{RBD: &v1.RBDVolumeSource{
+					Monitors:         []string{"10.0.0.1"},
+					Image:            "vm-disk",
+					KeyringSecretRef: &k8sv1.LocalObjectReference{},
+				}}, "fake[0].rbd.keyringSecretRef.name"),
+			)
+
+			It("should reject network volumes when the feature gate is disabled", func() {
+				disableFeatureGates()
+				volumes := []v1.Volume{{
+					Name:         "network",
+					VolumeSource: v1.VolumeSource{NBD: &v1.NBDVolumeSource{URI: "nbd://nbd.example.com"}},
+				}}
+
+				causes := validateVolumes(k8sfield.NewPath("fake"), volumes, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Message).To(ContainSubstring("NetworkVolumes feature gate is not enabled"))
+			})
+
+			It("should only accept iSCSI volumes for lun disks", func() {
+				vmi := api.NewMinimalVMI("testvmi")
+				vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+					{Name: "iscsi", DiskDevice: v1.DiskDevice{LUN: &v1.LunTarget{}}},
+					{Name: "nbd", DiskDevice: v1.DiskDevice{LUN: &v1.LunTarget{}}},
+				}
+				vmi.Spec.Volumes = []v1.Volume{
+					{Name: "iscsi", VolumeSource: v1.VolumeSource{ISCSI: &v1.ISCSIVolumeSource{
+						TargetPortal: "10.0.0.1",
+						IQN:          "iqn.2024-01.com.example:storage",
+					}}},
+					{Name: "nbd", VolumeSource: v1.VolumeSource{NBD: &v1.NBDVolumeSource{URI: "nbd://nbd.example.com"}}},
+				}
+
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[1].lun"))
+			})
+		})
+
 		It("should accept a single downwardmetrics volume", func() {
 			enableFeatureGate(virtconfig.DownwardMetricsFeatureGate)
 			vmi := api.NewMinimalVMI("testvmi")
@@ -3777,6 +4227,27 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[0].Field).To(Equal("fake.domain.devices.sound"))
 			Expect(causes[0].Message).To(Equal("Arm64 not support sound device"))
 		})
+
+		It("should accept nvme disks", func() {
+			vmi := api.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{
+				Name:       "nvme-disk",
+				DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusNVMe}},
+			}}
+			causes := webhooks.ValidateVirtualMachineInstanceArm64Setting(k8sfield.NewPath("fake"), &vmi.Spec)
+			Expect(causes).To(BeEmpty())
+		})
+
+		It("should reject sata disks", func() {
+			vmi := api.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{
+				Name:       "sata-disk",
+				DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusSATA}},
+			}}
+			causes := webhooks.ValidateVirtualMachineInstanceArm64Setting(k8sfield.NewPath("fake"), &vmi.Spec)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[0].disk.bus"))
+		})
 	})
 
 	Context("with realtime", func() {
@@ -4714,6 +5185,11 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 		addPersistentTPM := func(vmi *v1.VirtualMachineInstance) {
 			vmi.Spec.Domain.Devices.TPM = &v1.TPMDevice{Persistent: pointer.BoolPtr(true)}
 		}
+		addPersistentEFI := func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.Firmware = &v1.Firmware{Bootloader: &v1.Bootloader{
+				EFI: &v1.EFI{SecureBoot: pointer.BoolPtr(false), Persistent: pointer.BoolPtr(true)},
+			}}
+		}
 		BeforeEach(func() {
 			vmi = api.NewMinimalVMI("testvmi")
 			enableFeatureGate(virtconfig.VMPersistentState)
@@ -4728,6 +5204,11 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
 				Expect(causes).To(BeEmpty())
 			})
+			It("should accept vmi with persistent EFI defined", func() {
+				addPersistentEFI(vmi)
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(BeEmpty())
+			})
 		})
 		Context("feature gate disabled", func() {
 			It("should reject when the feature gate is disabled", func() {
@@ -4738,7 +5219,115 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				Expect(causes[0].Field).To(ContainSubstring("domain.devices.tpm.persistent"))
 				Expect(causes[0].Message).To(ContainSubstring(fmt.Sprintf("%s feature gate is not enabled", virtconfig.VMPersistentState)))
 			})
+			It("should reject persistent EFI when the feature gate is disabled", func() {
+				disableFeatureGates()
+				addPersistentEFI(vmi)
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.firmware.bootloader.efi.persistent"))
+				Expect(causes[0].Message).To(ContainSubstring(fmt.Sprintf("%s feature gate is not enabled", virtconfig.VMPersistentState)))
+			})
+		})
+	})
+
+	Context("with filesystem trim", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = api.NewMinimalVMI("testvmi")
+			vmi.Spec.FilesystemTrim = &v1.FilesystemTrim{}
+			enableFeatureGate(virtconfig.FilesystemTrimGate)
+		})
+
+		DescribeTable("should accept", func(interval *metav1.Duration) {
+			vmi.Spec.FilesystemTrim.Interval = interval
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		},
+			Entry("the default interval", nil),
+			Entry("the minimum interval", &metav1.Duration{Duration: time.Hour}),
+			Entry("a weekly interval", &metav1.Duration{Duration: 7 * 24 * time.Hour}),
+		)
+
+		It("should reject an interval shorter than an hour", func() {
+			vmi.Spec.FilesystemTrim.Interval = &metav1.Duration{Duration: 10 * time.Minute}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.filesystemTrim.interval"))
+		})
+
+		It("should reject when the feature gate is disabled", func() {
+			disableFeatureGates()
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.filesystemTrim"))
+			Expect(causes[0].Message).To(ContainSubstring(fmt.Sprintf("%s feature gate is not enabled", virtconfig.FilesystemTrimGate)))
+		})
+	})
+
+	Context("with disk encryption", func() {
+		var vmi *v1.VirtualMachineInstance
+		encryption := &v1.DiskEncryption{SecretRef: k8sv1.LocalObjectReference{Name: "tenant-key"}}
+
+		BeforeEach(func() {
+			vmi = api.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{
+				Name:       "encrypted",
+				DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusVirtio}},
+				Encryption: encryption.DeepCopy(),
+			}}
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "encrypted",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+						PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "data"},
+					},
+				},
+			}}
+			enableFeatureGate(virtconfig.DiskEncryptionGate)
+		})
+
+		It("should accept an encrypted persistentVolumeClaim", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+
+		It("should accept an encrypted dataVolume", func() {
+			vmi.Spec.Volumes[0].VolumeSource = v1.VolumeSource{DataVolume: &v1.DataVolumeSource{Name: "data"}}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
 		})
+
+		It("should reject when the feature gate is disabled", func() {
+			disableFeatureGates()
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[0].encryption"))
+			Expect(causes[0].Message).To(ContainSubstring(fmt.Sprintf("%s feature gate is not enabled", virtconfig.DiskEncryptionGate)))
+		})
+
+		It("should reject a missing secret name", func() {
+			vmi.Spec.Domain.Devices.Disks[0].Encryption.SecretRef.Name = ""
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[0].encryption.secretRef.name"))
+		})
+
+		It("should reject non disk targets", func() {
+			vmi.Spec.Domain.Devices.Disks[0].DiskDevice = v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: v1.DiskBusSATA}}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(ContainElement(HaveField("Message", ContainSubstring("can only be used with disk targets"))))
+		})
+
+		DescribeTable("should reject unsupported volumes", func(volumeSource v1.VolumeSource) {
+			vmi.Spec.Volumes[0].VolumeSource = volumeSource
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(ContainElement(HaveField("Message", ContainSubstring("requires a persistentVolumeClaim or dataVolume volume"))))
+		},
+			Entry("containerDisk", v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "fake"}}),
+			Entry("emptyDisk", v1.VolumeSource{EmptyDisk: &v1.EmptyDiskSource{Capacity: resource.MustParse("1Gi")}}),
+			Entry("hotpluggable dataVolume", v1.VolumeSource{DataVolume: &v1.DataVolumeSource{Name: "data", Hotpluggable: true}}),
+		)
 	})
 
 	Context("with multi-threaded QEMU migrations", func() {
@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 
+	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 
@@ -77,19 +78,40 @@ func (admitter *VMIUpdateAdmitter) Admit(ar *admissionv1.AdmissionReview) *admis
 	return &reviewResponse
 }
 
-func getExpectedDisks(newVolumes []v1.Volume) int {
+func getExpectedDisks(newVolumes []v1.Volume, newDisks []v1.Disk, filesystemVolumes map[string]struct{}) int {
 	numMemoryDumpVolumes := 0
+	numFilesystemVolumes := 0
+	volumeNames := make(map[string]struct{}, len(newVolumes))
 	for _, volume := range newVolumes {
+		volumeNames[volume.Name] = struct{}{}
 		if volume.MemoryDump != nil {
 			numMemoryDumpVolumes = numMemoryDumpVolumes + 1
+		} else if _, isFilesystem := filesystemVolumes[volume.Name]; isFilesystem {
+			numFilesystemVolumes = numFilesystemVolumes + 1
 		}
 	}
-	return len(newVolumes) - numMemoryDumpVolumes
+	// empty cdroms have no volume backing them
+	numEmptyCDRoms := 0
+	for _, disk := range newDisks {
+		if _, exists := volumeNames[disk.Name]; !exists && disk.CDRom != nil {
+			numEmptyCDRoms = numEmptyCDRoms + 1
+		}
+	}
+	return len(newVolumes) - numMemoryDumpVolumes - numFilesystemVolumes + numEmptyCDRoms
+}
+
+func getFilesystemVolumes(filesystems []v1.Filesystem) map[string]struct{} {
+	filesystemVolumes := make(map[string]struct{}, len(filesystems))
+	for _, fs := range filesystems {
+		filesystemVolumes[fs.Name] = struct{}{}
+	}
+	return filesystemVolumes
 }
 
 // admitHotplugStorage compares the old and new volumes and disks, and ensures that they match and are valid.
 func admitHotplugStorage(newVolumes, oldVolumes []v1.Volume, newDisks, oldDisks []v1.Disk, volumeStatuses []v1.VolumeStatus, newVMI *v1.VirtualMachineInstance, config *virtconfig.ClusterConfig) *admissionv1.AdmissionResponse {
-	expectedDisks := getExpectedDisks(newVolumes)
+	filesystemVolumes := getFilesystemVolumes(newVMI.Spec.Domain.Devices.Filesystems)
+	expectedDisks := getExpectedDisks(newVolumes, newDisks, filesystemVolumes)
 	if expectedDisks != len(newDisks) {
 		return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 			{
@@ -99,6 +121,10 @@ func admitHotplugStorage(newVolumes, oldVolumes []v1.Volume, newDisks, oldDisks
 		})
 	}
 	newHotplugVolumeMap := getHotplugVolumes(newVolumes, volumeStatuses)
+	// Hotplugged filesystems have no disk, they are verified by admitHotplugFilesystems
+	for name := range filesystemVolumes {
+		delete(newHotplugVolumeMap, name)
+	}
 	newPermanentVolumeMap := getPermanentVolumes(newVolumes, volumeStatuses)
 	oldHotplugVolumeMap := getHotplugVolumes(oldVolumes, volumeStatuses)
 	oldPermanentVolumeMap := getPermanentVolumes(oldVolumes, volumeStatuses)
@@ -145,7 +171,7 @@ func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1
 						},
 					})
 				}
-				if !equality.Semantic.DeepEqual(newDisks[k], oldDisks[k]) {
+				if !disksEqualIgnoringIOTune(newDisks[k], oldDisks[k]) {
 					return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 						{
 							Type:    metav1.CauseTypeFieldValueInvalid,
@@ -155,12 +181,12 @@ func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1
 				}
 			}
 		} else {
-			// This is a new volume, ensure that the volume is either DV, PVC or memoryDumpVolume
-			if v.DataVolume == nil && v.PersistentVolumeClaim == nil && v.MemoryDump == nil {
+			// This is a new volume, ensure that the volume is either DV, PVC, memoryDumpVolume or a hotpluggable read-only volume
+			if v.DataVolume == nil && v.PersistentVolumeClaim == nil && v.MemoryDump == nil && !util.IsHotplugVolume(&v) {
 				return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 					{
 						Type:    metav1.CauseTypeFieldValueInvalid,
-						Message: fmt.Sprintf("volume %s is not a PVC or DataVolume", k),
+						Message: fmt.Sprintf("volume %s is not a hotpluggable PVC, DataVolume, ContainerDisk, ConfigMap, Secret or ServiceAccount", k),
 					},
 				})
 			}
@@ -175,6 +201,10 @@ func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1
 					})
 				}
 				disk := newDisks[k]
+				if oldDisk, exists := oldDisks[k]; exists && oldDisk.CDRom != nil && equality.Semantic.DeepEqual(disk, oldDisk) {
+					// A media got inserted into an existing cdrom
+					continue
+				}
 				if disk.Disk == nil || disk.Disk.Bus != "scsi" {
 					return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 						{
@@ -220,7 +250,7 @@ func verifyPermanentVolumes(newPermanentVolumeMap, oldPermanentVolumeMap map[str
 				},
 			})
 		}
-		if !equality.Semantic.DeepEqual(newDisks[k], oldDisks[k]) {
+		if !disksEqualIgnoringIOTune(newDisks[k], oldDisks[k]) {
 			return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 				{
 					Type:    metav1.CauseTypeFieldValueInvalid,
@@ -232,6 +262,14 @@ func verifyPermanentVolumes(newPermanentVolumeMap, oldPermanentVolumeMap map[str
 	return nil
 }
 
+// disksEqualIgnoringIOTune compares two disks while allowing their I/O throttling to differ,
+// since it can be updated on a running VMI.
+func disksEqualIgnoringIOTune(newDisk, oldDisk v1.Disk) bool {
+	newDisk.IOTune = nil
+	oldDisk.IOTune = nil
+	return equality.Semantic.DeepEqual(newDisk, oldDisk)
+}
+
 func getDiskMap(disks []v1.Disk) map[string]v1.Disk {
 	newDiskMap := make(map[string]v1.Disk, 0)
 	for _, disk := range disks {
@@ -322,6 +360,10 @@ func admitHotplug(
 		return response
 	}
 
+	if response := admitHotplugFilesystems(oldVMI, newVMI); response != nil {
+		return response
+	}
+
 	return admitHotplugStorage(
 		newVMI.Spec.Volumes,
 		oldVMI.Spec.Volumes,
@@ -333,6 +375,79 @@ func admitHotplug(
 
 }
 
+// admitHotplugFilesystems ensures that only filesystems backed by hotpluggable volumes are added or removed
+func admitHotplugFilesystems(oldVMI, newVMI *v1.VirtualMachineInstance) *admissionv1.AdmissionResponse {
+	oldFilesystems := make(map[string]v1.Filesystem, len(oldVMI.Spec.Domain.Devices.Filesystems))
+	for _, fs := range oldVMI.Spec.Domain.Devices.Filesystems {
+		oldFilesystems[fs.Name] = fs
+	}
+	newFilesystems := make(map[string]v1.Filesystem, len(newVMI.Spec.Domain.Devices.Filesystems))
+	for _, fs := range newVMI.Spec.Domain.Devices.Filesystems {
+		newFilesystems[fs.Name] = fs
+	}
+	oldVolumes := getVolumeMap(oldVMI.Spec.Volumes)
+	newVolumes := getVolumeMap(newVMI.Spec.Volumes)
+
+	for name, fs := range newFilesystems {
+		if oldFs, exists := oldFilesystems[name]; exists {
+			if !equality.Semantic.DeepEqual(fs, oldFs) || !equality.Semantic.DeepEqual(newVolumes[name], oldVolumes[name]) {
+				return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+					{
+						Type:    metav1.CauseTypeFieldValueInvalid,
+						Message: fmt.Sprintf("filesystem %s, changed", name),
+					},
+				})
+			}
+			continue
+		}
+		if !newVMI.Spec.Domain.Devices.FilesystemHotplug {
+			return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("filesystem %s can't be hotplugged because filesystem hotplug is not enabled", name),
+				},
+			})
+		}
+		volume, exists := newVolumes[name]
+		if !exists || !isHotplugFilesystemVolume(&volume) {
+			return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("hotplugged filesystem %s requires a hotpluggable PVC or DataVolume", name),
+				},
+			})
+		}
+	}
+
+	for name := range oldFilesystems {
+		if _, exists := newFilesystems[name]; exists {
+			continue
+		}
+		volume := oldVolumes[name]
+		if !isHotplugFilesystemVolume(&volume) {
+			return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("filesystem %s is not hotplugged and can't be removed", name),
+				},
+			})
+		}
+	}
+	return nil
+}
+
+func isHotplugFilesystemVolume(volume *v1.Volume) bool {
+	return (volume.PersistentVolumeClaim != nil || volume.DataVolume != nil) && util.IsHotplugVolume(volume)
+}
+
+func getVolumeMap(volumes []v1.Volume) map[string]v1.Volume {
+	volumeMap := make(map[string]v1.Volume, len(volumes))
+	for _, volume := range volumes {
+		volumeMap[volume.Name] = volume
+	}
+	return volumeMap
+}
+
 func admitHotplugCPU(oldCPUTopology, newCPUTopology *v1.CPU) *admissionv1.AdmissionResponse {
 
 	if oldCPUTopology.MaxSockets != newCPUTopology.MaxSockets {
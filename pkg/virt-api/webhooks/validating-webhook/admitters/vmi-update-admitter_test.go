@@ -28,6 +28,7 @@ import (
 	"github.com/onsi/gomega/types"
 	admissionv1 "k8s.io/api/admission/v1"
 	authv1 "k8s.io/api/authentication/v1"
+	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -39,6 +40,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/testutils"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
 )
 
@@ -379,6 +381,26 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 		return res
 	}
 
+	makeDisksWithIOTune := func(indexes ...int) []v1.Disk {
+		res := makeDisks(indexes...)
+		for i := range res {
+			res[i].IOTune = &v1.DiskIOTune{TotalIOPSSec: 100}
+		}
+		return res
+	}
+
+	makeDisksWithCDRom := func(cdromIndex int, indexes ...int) []v1.Disk {
+		res := makeDisks(indexes...)
+		return append(res, v1.Disk{
+			Name: fmt.Sprintf("volume-name-%d", cdromIndex),
+			DiskDevice: v1.DiskDevice{
+				CDRom: &v1.CDRomTarget{
+					Bus: v1.DiskBusSATA,
+				},
+			},
+		})
+	}
+
 	makeDisksNoVolume := func(indexes ...int) []v1.Disk {
 		res := make([]v1.Disk, 0)
 		for _, index := range indexes {
@@ -488,13 +510,30 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 			makeDisks(0, 1),
 			makeStatus(1, 0),
 			makeExpected("hotplug volume volume-name-1, changed", "")),
+		Entry("Should accept if we add a hotpluggable containerDisk volume",
+			func() []v1.Volume {
+				volumes := makeVolumes(0)
+				containerDisk := testutils.NewFakeContainerDiskSource()
+				containerDisk.Hotpluggable = true
+				return append(volumes, v1.Volume{
+					Name: "volume-name-1",
+					VolumeSource: v1.VolumeSource{
+						ContainerDisk: containerDisk,
+					},
+				})
+			}(),
+			makeVolumes(0),
+			makeDisks(0, 1),
+			makeDisks(0),
+			makeStatus(1, 0),
+			nil),
 		Entry("Should reject if we add volumes that are not PVC or DV",
 			makeInvalidVolumes(2, 1),
 			makeVolumes(0),
 			makeDisks(0, 1),
 			makeDisks(0),
 			makeStatus(1, 0),
-			makeExpected("volume volume-name-1 is not a PVC or DataVolume", "")),
+			makeExpected("volume volume-name-1 is not a hotpluggable PVC, DataVolume, ContainerDisk, ConfigMap, Secret or ServiceAccount", "")),
 		Entry("Should accept if we add volumes and disk properly",
 			makeVolumes(0, 1),
 			makeVolumes(0, 1),
@@ -523,6 +562,48 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 			makeDisks(0, 1),
 			makeStatus(3, 1),
 			nil),
+		Entry("Should accept if the I/O throttling of a permanent disk changed",
+			makeVolumes(0, 1),
+			makeVolumes(0, 1),
+			makeDisksWithIOTune(0, 1),
+			makeDisks(0, 1),
+			makeStatus(2, 0),
+			nil),
+		Entry("Should accept if the I/O throttling of a hotplug disk changed",
+			makeVolumes(0, 1),
+			makeVolumes(0, 1),
+			makeDisksWithIOTune(0, 1),
+			makeDisks(0, 1),
+			makeStatus(2, 1),
+			nil),
+		Entry("Should accept an empty cdrom",
+			makeVolumes(0),
+			makeVolumes(0),
+			makeDisksWithCDRom(1, 0),
+			makeDisksWithCDRom(1, 0),
+			makeStatus(1, 0),
+			nil),
+		Entry("Should accept if a media is inserted into an existing cdrom",
+			makeVolumes(0, 1),
+			makeVolumes(0),
+			makeDisksWithCDRom(1, 0),
+			makeDisksWithCDRom(1, 0),
+			makeStatus(2, 1),
+			nil),
+		Entry("Should accept if the media of a cdrom is ejected",
+			makeVolumes(0),
+			makeVolumes(0, 1),
+			makeDisksWithCDRom(1, 0),
+			makeDisksWithCDRom(1, 0),
+			makeStatus(2, 1),
+			nil),
+		Entry("Should reject if a cdrom is hotplugged",
+			makeVolumes(0, 1),
+			makeVolumes(0),
+			makeDisksWithCDRom(1, 0),
+			makeDisks(0),
+			makeStatus(2, 1),
+			makeExpected("hotplugged Disk volume-name-1 does not use a scsi bus", "")),
 		Entry("Should reject if #volumes != #disks even when there is memory dump volume",
 			makeVolumesWithMemoryDumpVol(3, 2),
 			makeVolumesWithMemoryDumpVol(3, 2),
@@ -597,4 +678,76 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 				MaxSockets: 8,
 			},
 			BeFalse()))
+
+	Context("Hotplug of filesystems", func() {
+		withFilesystem := func(vmi *v1.VirtualMachineInstance, name string, hotpluggable bool) *v1.VirtualMachineInstance {
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: name,
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+						PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: name},
+						Hotpluggable:                      hotpluggable,
+					},
+				},
+			})
+			vmi.Spec.Domain.Devices.Filesystems = append(vmi.Spec.Domain.Devices.Filesystems, v1.Filesystem{
+				Name:     name,
+				Virtiofs: &v1.FilesystemVirtiofs{},
+			})
+			return vmi
+		}
+
+		newVMI := func(filesystemHotplug bool) *v1.VirtualMachineInstance {
+			vmi := api.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.FilesystemHotplug = filesystemHotplug
+			return vmi
+		}
+
+		DescribeTable("should", func(oldVMI, newVMI *v1.VirtualMachineInstance, expectedMessage string) {
+			result := admitHotplugFilesystems(oldVMI, newVMI)
+			if expectedMessage == "" {
+				Expect(result).To(BeNil())
+				return
+			}
+			Expect(result).ToNot(BeNil())
+			Expect(result.Allowed).To(BeFalse())
+			Expect(result.Result.Details.Causes[0].Message).To(Equal(expectedMessage))
+		},
+			Entry("accept unchanged filesystems",
+				withFilesystem(newVMI(false), "fs1", false),
+				withFilesystem(newVMI(false), "fs1", false),
+				""),
+			Entry("accept a hotplugged filesystem",
+				newVMI(true),
+				withFilesystem(newVMI(true), "fs1", true),
+				""),
+			Entry("accept the removal of a hotplugged filesystem",
+				withFilesystem(newVMI(true), "fs1", true),
+				newVMI(true),
+				""),
+			Entry("reject a hotplugged filesystem without filesystem hotplug",
+				newVMI(false),
+				withFilesystem(newVMI(false), "fs1", true),
+				"filesystem fs1 can't be hotplugged because filesystem hotplug is not enabled"),
+			Entry("reject a hotplugged filesystem with a non hotpluggable volume",
+				newVMI(true),
+				withFilesystem(newVMI(true), "fs1", false),
+				"hotplugged filesystem fs1 requires a hotpluggable PVC or DataVolume"),
+			Entry("reject the removal of a filesystem which was not hotplugged",
+				withFilesystem(newVMI(true), "fs1", false),
+				newVMI(true),
+				"filesystem fs1 is not hotplugged and can't be removed"),
+		)
+
+		It("should not require disks for hotplugged filesystem volumes", func() {
+			newVMI := withFilesystem(newVMI(true), "fs1", true)
+			volumeStatuses := []v1.VolumeStatus{{Name: "fs1", HotplugVolume: &v1.HotplugVolumeStatus{}}}
+			kvWithVirtiofs := kv.DeepCopy()
+			kvWithVirtiofs.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{virtconfig.VirtIOFSGate}
+			virtiofsConfig, _, _ := testutils.NewFakeClusterConfigUsingKV(kvWithVirtiofs)
+
+			result := admitHotplugStorage(newVMI.Spec.Volumes, nil, nil, nil, volumeStatuses, newVMI, virtiofsConfig)
+			Expect(result).To(BeNil())
+		})
+	})
 })
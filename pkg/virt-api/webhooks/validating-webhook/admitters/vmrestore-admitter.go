@@ -125,7 +125,7 @@ func (admitter *VMRestoreAdmitter) Admit(ar *admissionv1.AdmissionReview) *admis
 		snapshotCauses, err := admitter.validateSnapshot(
 			k8sfield.NewPath("spec", "virtualMachineSnapshotName"),
 			ar.Request.Namespace,
-			vmRestore.Spec.VirtualMachineSnapshotName,
+			&vmRestore.Spec,
 			targetUID,
 			targetVMExists,
 		)
@@ -263,7 +263,8 @@ func (admitter *VMRestoreAdmitter) validatePatches(patches []string, field *k8sf
 	return causes
 }
 
-func (admitter *VMRestoreAdmitter) validateSnapshot(field *k8sfield.Path, namespace, name string, targetUID *types.UID, targetVMExists bool) ([]metav1.StatusCause, error) {
+func (admitter *VMRestoreAdmitter) validateSnapshot(field *k8sfield.Path, namespace string, spec *snapshotv1.VirtualMachineRestoreSpec, targetUID *types.UID, targetVMExists bool) ([]metav1.StatusCause, error) {
+	name := spec.VirtualMachineSnapshotName
 	snapshot, err := admitter.Client.VirtualMachineSnapshot(namespace).Get(context.Background(), name, metav1.GetOptions{})
 	if errors.IsNotFound(err) {
 		return []metav1.StatusCause{
@@ -309,5 +310,25 @@ func (admitter *VMRestoreAdmitter) validateSnapshot(field *k8sfield.Path, namesp
 		causes = append(causes, cause)
 	}
 
+	if len(spec.IncludedVolumes) > 0 || len(spec.ExcludedVolumes) > 0 {
+		if !targetVMExists {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "restoring only some volumes requires the target VM to exist",
+				Field:   k8sfield.NewPath("spec", "target").String(),
+			})
+		}
+
+		if snapshot.Status != nil && snapshot.Status.SnapshotVolumes != nil {
+			causes = append(causes, validateVolumeSelection(
+				k8sfield.NewPath("spec"),
+				spec.IncludedVolumes,
+				spec.ExcludedVolumes,
+				snapshot.Status.SnapshotVolumes.IncludedVolumes,
+				"VirtualMachineSnapshot",
+			)...)
+		}
+	}
+
 	return causes, nil
 }
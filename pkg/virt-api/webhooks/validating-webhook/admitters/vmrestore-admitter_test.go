@@ -606,6 +606,71 @@ var _ = Describe("Validating VirtualMachineRestore Admitter", func() {
 				})
 			})
 
+			Context("when selecting volumes", func() {
+				var restore *snapshotv1.VirtualMachineRestore
+				var volumesSnapshot *snapshotv1.VirtualMachineSnapshot
+
+				BeforeEach(func() {
+					restore = &snapshotv1.VirtualMachineRestore{
+						Spec: snapshotv1.VirtualMachineRestoreSpec{
+							Target: corev1.TypedLocalObjectReference{
+								APIGroup: &apiGroup,
+								Kind:     "VirtualMachine",
+								Name:     vmName,
+							},
+							VirtualMachineSnapshotName: vmSnapshotName,
+						},
+					}
+
+					volumesSnapshot = snapshot.DeepCopy()
+					volumesSnapshot.Status.SnapshotVolumes = &snapshotv1.SnapshotVolumesLists{
+						IncludedVolumes: []string{"rootdisk", "datadisk"},
+						ExcludedVolumes: []string{"cloudinit"},
+					}
+					vm.Spec.Running = &f
+				})
+
+				It("should accept snapshotted volumes", func() {
+					restore.Spec.IncludedVolumes = []string{"rootdisk"}
+					restore.Spec.ExcludedVolumes = []string{"datadisk"}
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, vm, volumesSnapshot).Admit(ar)
+					Expect(resp.Allowed).To(BeTrue())
+				})
+
+				It("should reject volumes which were not snapshotted", func() {
+					restore.Spec.IncludedVolumes = []string{"cloudinit"}
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, vm, volumesSnapshot).Admit(ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.includedVolumes[0]"))
+				})
+
+				It("should reject volumes which are both included and excluded", func() {
+					restore.Spec.IncludedVolumes = []string{"rootdisk"}
+					restore.Spec.ExcludedVolumes = []string{"rootdisk"}
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, vm, volumesSnapshot).Admit(ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.excludedVolumes[0]"))
+				})
+
+				It("should reject when the target VM does not exist", func() {
+					restore.Spec.Target.Name = "new-vm"
+					restore.Spec.IncludedVolumes = []string{"rootdisk"}
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, nil, volumesSnapshot).Admit(ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.target"))
+				})
+			})
 		})
 	})
 })
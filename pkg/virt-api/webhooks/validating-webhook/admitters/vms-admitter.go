@@ -449,6 +449,8 @@ func (admitter *VMsAdmitter) validateVolumeRequests(vm *v1.VirtualMachine) ([]me
 
 	curVMAddRequestsMap := make(map[string]*v1.VirtualMachineVolumeRequest)
 	curVMRemoveRequestsMap := make(map[string]*v1.VirtualMachineVolumeRequest)
+	curVMMediaRequestsMap := make(map[string]*v1.VirtualMachineVolumeRequest)
+	curVMFilesystemRequestsMap := make(map[string]*v1.VirtualMachineVolumeRequest)
 
 	vmVolumeMap := make(map[string]v1.Volume)
 	vmiVolumeMap := make(map[string]v1.Volume)
@@ -483,10 +485,10 @@ func (admitter *VMsAdmitter) validateVolumeRequests(vm *v1.VirtualMachine) ([]me
 	for _, volumeRequest := range vm.Status.VolumeRequests {
 		volumeRequest := volumeRequest
 		name := ""
-		if volumeRequest.AddVolumeOptions != nil && volumeRequest.RemoveVolumeOptions != nil {
+		if countVolumeRequestOptions(&volumeRequest) > 1 {
 			return []metav1.StatusCause{{
 				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: "VolumeRequests require either addVolumeOptions or removeVolumeOptions to be set, not both",
+				Message: "VolumeRequests require only one of addVolumeOptions, removeVolumeOptions, insertMediaOptions, ejectMediaOptions, addFilesystemOptions or removeFilesystemOptions to be set",
 				Field:   k8sfield.NewPath("Status", "volumeRequests").String(),
 			}}, nil
 		} else if volumeRequest.AddVolumeOptions != nil {
@@ -522,13 +524,17 @@ func (admitter *VMsAdmitter) validateVolumeRequests(vm *v1.VirtualMachine) ([]me
 				}}, nil
 			}
 
+			volumeSource := volumeRequest.AddVolumeOptions.VolumeSource
 			newVolume := v1.Volume{
 				Name: volumeRequest.AddVolumeOptions.Name,
-			}
-			if volumeRequest.AddVolumeOptions.VolumeSource.PersistentVolumeClaim != nil {
-				newVolume.VolumeSource.PersistentVolumeClaim = volumeRequest.AddVolumeOptions.VolumeSource.PersistentVolumeClaim
-			} else if volumeRequest.AddVolumeOptions.VolumeSource.DataVolume != nil {
-				newVolume.VolumeSource.DataVolume = volumeRequest.AddVolumeOptions.VolumeSource.DataVolume
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: volumeSource.PersistentVolumeClaim,
+					DataVolume:            volumeSource.DataVolume,
+					ContainerDisk:         volumeSource.ContainerDisk,
+					ConfigMap:             volumeSource.ConfigMap,
+					Secret:                volumeSource.Secret,
+					ServiceAccount:        volumeSource.ServiceAccount,
+				},
 			}
 
 			vmVolume, ok := vmVolumeMap[name]
@@ -563,10 +569,75 @@ func (admitter *VMsAdmitter) validateVolumeRequests(vm *v1.VirtualMachine) ([]me
 			}
 
 			curVMRemoveRequestsMap[name] = &volumeRequest
+		} else if volumeRequest.InsertMediaOptions != nil || volumeRequest.EjectMediaOptions != nil {
+			if volumeRequest.InsertMediaOptions != nil {
+				name = volumeRequest.InsertMediaOptions.Name
+			} else {
+				name = volumeRequest.EjectMediaOptions.Name
+			}
+
+			if _, ok := curVMMediaRequestsMap[name]; ok {
+				return []metav1.StatusCause{{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("Media request for cdrom [%s] already exists", name),
+					Field:   k8sfield.NewPath("Status", "volumeRequests").String(),
+				}}, nil
+			}
+
+			if volumeRequest.InsertMediaOptions != nil && volumeRequest.InsertMediaOptions.VolumeSource == nil {
+				return []metav1.StatusCause{{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("InsertMedia request for [%s] requires the volumeSource field to be set.", name),
+					Field:   k8sfield.NewPath("Status", "volumeRequests").String(),
+				}}, nil
+			}
+
+			if !isCDRom(newSpec.Domain.Devices.Disks, name) {
+				return []metav1.StatusCause{{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("Media request for [%s] requires a cdrom with that name on the vmi template.", name),
+					Field:   k8sfield.NewPath("Status", "volumeRequests").String(),
+				}}, nil
+			}
+
+			curVMMediaRequestsMap[name] = &volumeRequest
+		} else if volumeRequest.AddFilesystemOptions != nil || volumeRequest.RemoveFilesystemOptions != nil {
+			if volumeRequest.AddFilesystemOptions != nil {
+				name = volumeRequest.AddFilesystemOptions.Name
+			} else {
+				name = volumeRequest.RemoveFilesystemOptions.Name
+			}
+
+			if _, ok := curVMFilesystemRequestsMap[name]; ok {
+				return []metav1.StatusCause{{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("Filesystem request for [%s] already exists", name),
+					Field:   k8sfield.NewPath("Status", "volumeRequests").String(),
+				}}, nil
+			}
+
+			if !newSpec.Domain.Devices.FilesystemHotplug {
+				return []metav1.StatusCause{{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("Filesystem request for [%s] requires filesystemHotplug to be enabled on the vmi template.", name),
+					Field:   k8sfield.NewPath("Status", "volumeRequests").String(),
+				}}, nil
+			}
+
+			if options := volumeRequest.AddFilesystemOptions; options != nil &&
+				(options.VolumeSource == nil || (options.VolumeSource.PersistentVolumeClaim == nil && options.VolumeSource.DataVolume == nil)) {
+				return []metav1.StatusCause{{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("AddFilesystem request for [%s] requires a persistentVolumeClaim or dataVolume volumeSource.", name),
+					Field:   k8sfield.NewPath("Status", "volumeRequests").String(),
+				}}, nil
+			}
+
+			curVMFilesystemRequestsMap[name] = &volumeRequest
 		} else {
 			return []metav1.StatusCause{{
 				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: "VolumeRequests require one of either addVolumeOptions or removeVolumeOptions to be set",
+				Message: "VolumeRequests require one of addVolumeOptions, removeVolumeOptions, insertMediaOptions, ejectMediaOptions, addFilesystemOptions or removeFilesystemOptions to be set",
 				Field:   k8sfield.NewPath("Status", "volumeRequests").String(),
 			}}, nil
 		}
@@ -603,6 +674,38 @@ func (admitter *VMsAdmitter) validateVolumeRequests(vm *v1.VirtualMachine) ([]me
 
 }
 
+func countVolumeRequestOptions(volumeRequest *v1.VirtualMachineVolumeRequest) int {
+	count := 0
+	if volumeRequest.AddVolumeOptions != nil {
+		count++
+	}
+	if volumeRequest.RemoveVolumeOptions != nil {
+		count++
+	}
+	if volumeRequest.InsertMediaOptions != nil {
+		count++
+	}
+	if volumeRequest.EjectMediaOptions != nil {
+		count++
+	}
+	if volumeRequest.AddFilesystemOptions != nil {
+		count++
+	}
+	if volumeRequest.RemoveFilesystemOptions != nil {
+		count++
+	}
+	return count
+}
+
+func isCDRom(disks []v1.Disk, name string) bool {
+	for _, disk := range disks {
+		if disk.Name == name {
+			return disk.CDRom != nil
+		}
+	}
+	return false
+}
+
 func validateRestoreStatus(ar *admissionv1.AdmissionRequest, vm *v1.VirtualMachine) []metav1.StatusCause {
 	if ar.Operation != admissionv1.Update || vm.Status.RestoreInProgress == nil {
 		return nil
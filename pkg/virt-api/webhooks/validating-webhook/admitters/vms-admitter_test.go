@@ -713,6 +713,192 @@ var _ = Describe("Validating VM Admitter", func() {
 			false),
 	)
 
+	DescribeTable("should validate media VolumeRequest on offline vm", func(requests []v1.VirtualMachineVolumeRequest, isValid bool) {
+		vmi := api.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+			Name: "testdisk",
+		}, v1.Disk{
+			Name: "cdrom",
+			DiskDevice: v1.DiskDevice{
+				CDRom: &v1.CDRomTarget{
+					Bus: v1.DiskBusSATA,
+				},
+			},
+		})
+		vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+			Name: "testdisk",
+			VolumeSource: v1.VolumeSource{
+				ContainerDisk: testutils.NewFakeContainerDiskSource(),
+			},
+		})
+
+		vm := &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      vmi.Name,
+				Namespace: vmi.Namespace,
+			},
+			Spec: v1.VirtualMachineSpec{
+				Running: &notRunning,
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: vmi.Spec,
+				},
+			},
+			Status: v1.VirtualMachineStatus{
+				VolumeRequests: requests,
+			},
+		}
+
+		resp := admitVm(vmsAdmitter, vm)
+		Expect(resp.Allowed).To(Equal(isValid))
+	},
+		Entry("with valid request to insert a media", []v1.VirtualMachineVolumeRequest{
+			{
+				InsertMediaOptions: &v1.InsertMediaOptions{
+					Name: "cdrom",
+					VolumeSource: &v1.HotplugVolumeSource{
+						DataVolume: &v1.DataVolumeSource{
+							Name:         "iso",
+							Hotpluggable: true,
+						},
+					},
+				},
+			},
+		},
+			true),
+		Entry("with invalid request to insert and eject the same cdrom", []v1.VirtualMachineVolumeRequest{
+			{
+				InsertMediaOptions: &v1.InsertMediaOptions{
+					Name: "cdrom",
+					VolumeSource: &v1.HotplugVolumeSource{
+						DataVolume: &v1.DataVolumeSource{
+							Name:         "iso",
+							Hotpluggable: true,
+						},
+					},
+				},
+			},
+			{
+				EjectMediaOptions: &v1.EjectMediaOptions{
+					Name: "cdrom",
+				},
+			},
+		},
+			false),
+		Entry("with invalid request to insert a media into a disk", []v1.VirtualMachineVolumeRequest{
+			{
+				InsertMediaOptions: &v1.InsertMediaOptions{
+					Name: "testdisk",
+					VolumeSource: &v1.HotplugVolumeSource{
+						DataVolume: &v1.DataVolumeSource{
+							Name:         "iso",
+							Hotpluggable: true,
+						},
+					},
+				},
+			},
+		},
+			false),
+		Entry("with invalid request to insert a media without source", []v1.VirtualMachineVolumeRequest{
+			{
+				InsertMediaOptions: &v1.InsertMediaOptions{
+					Name: "cdrom",
+				},
+			},
+		},
+			false),
+		Entry("with invalid request to eject a non existing cdrom", []v1.VirtualMachineVolumeRequest{
+			{
+				EjectMediaOptions: &v1.EjectMediaOptions{
+					Name: "missing",
+				},
+			},
+		},
+			false),
+		Entry("with invalid request to eject and remove at once", []v1.VirtualMachineVolumeRequest{
+			{
+				EjectMediaOptions: &v1.EjectMediaOptions{
+					Name: "cdrom",
+				},
+				RemoveVolumeOptions: &v1.RemoveVolumeOptions{
+					Name: "cdrom",
+				},
+			},
+		},
+			false),
+	)
+
+	DescribeTable("should validate filesystem VolumeRequest on offline vm", func(requests []v1.VirtualMachineVolumeRequest, filesystemHotplug, isValid bool) {
+		enableFeatureGate(virtconfig.VirtIOFSGate)
+		vmi := api.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.FilesystemHotplug = filesystemHotplug
+
+		vm := &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      vmi.Name,
+				Namespace: vmi.Namespace,
+			},
+			Spec: v1.VirtualMachineSpec{
+				Running: &notRunning,
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: vmi.Spec,
+				},
+			},
+			Status: v1.VirtualMachineStatus{
+				VolumeRequests: requests,
+			},
+		}
+
+		resp := admitVm(vmsAdmitter, vm)
+		Expect(resp.Allowed).To(Equal(isValid))
+	},
+		Entry("with valid request to add a filesystem", []v1.VirtualMachineVolumeRequest{
+			{
+				AddFilesystemOptions: &v1.AddFilesystemOptions{
+					Name: "dataset",
+					VolumeSource: &v1.HotplugVolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "dataset",
+						}, Hotpluggable: true},
+					},
+				},
+			},
+		}, true, true),
+		Entry("with invalid request to add a filesystem to a vm without filesystem hotplug", []v1.VirtualMachineVolumeRequest{
+			{
+				AddFilesystemOptions: &v1.AddFilesystemOptions{
+					Name: "dataset",
+					VolumeSource: &v1.HotplugVolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "dataset",
+						}, Hotpluggable: true},
+					},
+				},
+			},
+		}, false, false),
+		Entry("with invalid request to add a filesystem without a volume source", []v1.VirtualMachineVolumeRequest{
+			{
+				AddFilesystemOptions: &v1.AddFilesystemOptions{
+					Name: "dataset",
+				},
+			},
+		}, true, false),
+		Entry("with invalid request to add and remove the same filesystem", []v1.VirtualMachineVolumeRequest{
+			{
+				AddFilesystemOptions: &v1.AddFilesystemOptions{
+					Name: "dataset",
+					VolumeSource: &v1.HotplugVolumeSource{
+						DataVolume: &v1.DataVolumeSource{Name: "dataset", Hotpluggable: true},
+					},
+				},
+			},
+			{
+				RemoveFilesystemOptions: &v1.RemoveFilesystemOptions{
+					Name: "dataset",
+				},
+			},
+		}, true, false),
+	)
+
 	It("should accept valid DataVolumeTemplate", func() {
 		vmi := api.NewMinimalVMI("testvmi")
 		vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
@@ -30,9 +30,11 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 
 	"kubevirt.io/api/core"
+	v1 "kubevirt.io/api/core/v1"
 
 	snapshotv1 "kubevirt.io/api/snapshot/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
@@ -94,10 +96,11 @@ func (admitter *VMSnapshotAdmitter) Admit(ar *admissionv1.AdmissionReview) *admi
 		case core.GroupName:
 			switch vmSnapshot.Spec.Source.Kind {
 			case "VirtualMachine":
-				causes, err = admitter.validateCreateVM(sourceField.Child("name"), ar.Request.Namespace, vmSnapshot.Spec.Source.Name)
+				causes, err = admitter.validateCreateVM(sourceField.Child("name"), ar.Request.Namespace, &vmSnapshot.Spec)
 				if err != nil {
 					return webhookutils.ToAdmissionResponseError(err)
 				}
+				causes = append(causes, validateSnapshotHooks(k8sfield.NewPath("spec", "hooks"), vmSnapshot.Spec.Hooks)...)
 			default:
 				causes = []metav1.StatusCause{
 					{
@@ -147,7 +150,8 @@ func (admitter *VMSnapshotAdmitter) Admit(ar *admissionv1.AdmissionReview) *admi
 	return &reviewResponse
 }
 
-func (admitter *VMSnapshotAdmitter) validateCreateVM(field *k8sfield.Path, namespace, name string) ([]metav1.StatusCause, error) {
+func (admitter *VMSnapshotAdmitter) validateCreateVM(field *k8sfield.Path, namespace string, spec *snapshotv1.VirtualMachineSnapshotSpec) ([]metav1.StatusCause, error) {
+	name := spec.Source.Name
 	vm, err := admitter.Client.VirtualMachine(namespace).Get(context.Background(), name, &metav1.GetOptions{})
 	if errors.IsNotFound(err) {
 		return []metav1.StatusCause{
@@ -173,5 +177,112 @@ func (admitter *VMSnapshotAdmitter) validateCreateVM(field *k8sfield.Path, names
 		}, nil
 	}
 
-	return []metav1.StatusCause{}, nil
+	var volumeNames []string
+	if vm.Spec.Template != nil {
+		for _, volume := range vm.Spec.Template.Spec.Volumes {
+			volumeNames = append(volumeNames, volume.Name)
+		}
+	}
+
+	return validateVolumeSelection(k8sfield.NewPath("spec"), spec.IncludedVolumes, spec.ExcludedVolumes, volumeNames, "VirtualMachine"), nil
+}
+
+// validateVolumeSelection checks that the included and excluded volumes
+// exist and that no volume is both included and excluded
+func validateVolumeSelection(field *k8sfield.Path, includedVolumes, excludedVolumes, volumeNames []string, owner string) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	volumes := sets.NewString(volumeNames...)
+
+	for i, name := range includedVolumes {
+		if !volumes.Has(name) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("volume %q is not a volume of the %s", name, owner),
+				Field:   field.Child("includedVolumes").Index(i).String(),
+			})
+		}
+	}
+
+	included := sets.NewString(includedVolumes...)
+	for i, name := range excludedVolumes {
+		if !volumes.Has(name) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("volume %q is not a volume of the %s", name, owner),
+				Field:   field.Child("excludedVolumes").Index(i).String(),
+			})
+		} else if included.Has(name) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("volume %q cannot be both included and excluded", name),
+				Field:   field.Child("excludedVolumes").Index(i).String(),
+			})
+		}
+	}
+
+	return causes
+}
+
+func validateSnapshotHooks(field *k8sfield.Path, hooks *snapshotv1.SnapshotHooks) []metav1.StatusCause {
+	if hooks == nil {
+		return nil
+	}
+
+	var causes []metav1.StatusCause
+	causes = append(causes, validateSnapshotHookList(field.Child("preFreeze"), hooks.PreFreeze)...)
+	causes = append(causes, validateSnapshotHookList(field.Child("postThaw"), hooks.PostThaw)...)
+	return causes
+}
+
+func validateSnapshotHookList(field *k8sfield.Path, hooks []snapshotv1.SnapshotHook) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	names := map[string]struct{}{}
+
+	for i, hook := range hooks {
+		hookField := field.Index(i)
+
+		if hook.Name == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: "hook name is required",
+				Field:   hookField.Child("name").String(),
+			})
+		} else if _, exists := names[hook.Name]; exists {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("hook name %q is not unique", hook.Name),
+				Field:   hookField.Child("name").String(),
+			})
+		}
+		names[hook.Name] = struct{}{}
+
+		if len(hook.Command) == 0 || hook.Command[0] == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: "hook command is required",
+				Field:   hookField.Child("command").String(),
+			})
+		}
+
+		if hook.TimeoutSeconds != nil &&
+			(*hook.TimeoutSeconds < 1 || *hook.TimeoutSeconds > v1.GuestExecMaxTimeoutSeconds) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("hook timeout must be between 1 and %d seconds", v1.GuestExecMaxTimeoutSeconds),
+				Field:   hookField.Child("timeoutSeconds").String(),
+			})
+		}
+
+		if hook.FailurePolicy != nil &&
+			*hook.FailurePolicy != snapshotv1.SnapshotHookFailurePolicyAbort &&
+			*hook.FailurePolicy != snapshotv1.SnapshotHookFailurePolicyContinue {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("invalid hook failure policy %q", *hook.FailurePolicy),
+				Field:   hookField.Child("failurePolicy").String(),
+			})
+		}
+	}
+
+	return causes
 }
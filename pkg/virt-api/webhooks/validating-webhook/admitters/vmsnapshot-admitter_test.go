@@ -316,6 +316,135 @@ var _ = Describe("Validating VirtualMachineSnapshot Admitter", func() {
 				resp := createTestVMSnapshotAdmitter(config, vm).Admit(ar)
 				Expect(resp.Allowed).To(BeTrue())
 			})
+
+			Context("with volume selection", func() {
+				var snapshot *snapshotv1.VirtualMachineSnapshot
+
+				BeforeEach(func() {
+					vm.Spec.Template = &v1.VirtualMachineInstanceTemplateSpec{
+						Spec: v1.VirtualMachineInstanceSpec{
+							Volumes: []v1.Volume{
+								{Name: "rootdisk"},
+								{Name: "scratch"},
+							},
+						},
+					}
+					snapshot = &snapshotv1.VirtualMachineSnapshot{
+						Spec: snapshotv1.VirtualMachineSnapshotSpec{
+							Source: corev1.TypedLocalObjectReference{
+								APIGroup: &apiGroup,
+								Kind:     "VirtualMachine",
+								Name:     vmName,
+							},
+						},
+					}
+				})
+
+				It("should accept volumes of the VM", func() {
+					snapshot.Spec.IncludedVolumes = []string{"rootdisk"}
+					snapshot.Spec.ExcludedVolumes = []string{"scratch"}
+
+					ar := createSnapshotAdmissionReview(snapshot)
+					resp := createTestVMSnapshotAdmitter(config, vm).Admit(ar)
+					Expect(resp.Allowed).To(BeTrue())
+				})
+
+				DescribeTable("should reject", func(included, excluded []string, field string) {
+					snapshot.Spec.IncludedVolumes = included
+					snapshot.Spec.ExcludedVolumes = excluded
+
+					ar := createSnapshotAdmissionReview(snapshot)
+					resp := createTestVMSnapshotAdmitter(config, vm).Admit(ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+				},
+					Entry("unknown included volume", []string{"rootdisk", "logs"}, nil, "spec.includedVolumes[1]"),
+					Entry("unknown excluded volume", nil, []string{"logs"}, "spec.excludedVolumes[0]"),
+					Entry("volume both included and excluded", []string{"rootdisk"}, []string{"rootdisk"}, "spec.excludedVolumes[0]"),
+				)
+			})
+
+			Context("with hooks", func() {
+				createHook := func(name string) snapshotv1.SnapshotHook {
+					return snapshotv1.SnapshotHook{
+						Name:    name,
+						Command: []string{"/usr/bin/fsync"},
+					}
+				}
+
+				createSnapshotWithHooks := func(hooks *snapshotv1.SnapshotHooks) *snapshotv1.VirtualMachineSnapshot {
+					return &snapshotv1.VirtualMachineSnapshot{
+						Spec: snapshotv1.VirtualMachineSnapshotSpec{
+							Source: corev1.TypedLocalObjectReference{
+								APIGroup: &apiGroup,
+								Kind:     "VirtualMachine",
+								Name:     vmName,
+							},
+							Hooks: hooks,
+						},
+					}
+				}
+
+				It("should accept valid hooks", func() {
+					hook := createHook("flush")
+					hook.TimeoutSeconds = pointer.Int32(v1.GuestExecMaxTimeoutSeconds)
+					policy := snapshotv1.SnapshotHookFailurePolicyContinue
+					hook.FailurePolicy = &policy
+
+					snapshot := createSnapshotWithHooks(&snapshotv1.SnapshotHooks{
+						PreFreeze: []snapshotv1.SnapshotHook{hook},
+						PostThaw:  []snapshotv1.SnapshotHook{createHook("flush")},
+					})
+
+					ar := createSnapshotAdmissionReview(snapshot)
+					resp := createTestVMSnapshotAdmitter(config, vm).Admit(ar)
+					Expect(resp.Allowed).To(BeTrue())
+				})
+
+				DescribeTable("should reject invalid hooks", func(mutate func(*snapshotv1.SnapshotHook), field string) {
+					hook := createHook("flush")
+					mutate(&hook)
+					snapshot := createSnapshotWithHooks(&snapshotv1.SnapshotHooks{
+						PostThaw: []snapshotv1.SnapshotHook{hook},
+					})
+
+					ar := createSnapshotAdmissionReview(snapshot)
+					resp := createTestVMSnapshotAdmitter(config, vm).Admit(ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+				},
+					Entry("without name", func(hook *snapshotv1.SnapshotHook) {
+						hook.Name = ""
+					}, "spec.hooks.postThaw[0].name"),
+					Entry("without command", func(hook *snapshotv1.SnapshotHook) {
+						hook.Command = nil
+					}, "spec.hooks.postThaw[0].command"),
+					Entry("with zero timeout", func(hook *snapshotv1.SnapshotHook) {
+						hook.TimeoutSeconds = pointer.Int32(0)
+					}, "spec.hooks.postThaw[0].timeoutSeconds"),
+					Entry("with too long timeout", func(hook *snapshotv1.SnapshotHook) {
+						hook.TimeoutSeconds = pointer.Int32(v1.GuestExecMaxTimeoutSeconds + 1)
+					}, "spec.hooks.postThaw[0].timeoutSeconds"),
+					Entry("with unknown failure policy", func(hook *snapshotv1.SnapshotHook) {
+						policy := snapshotv1.SnapshotHookFailurePolicy("Retry")
+						hook.FailurePolicy = &policy
+					}, "spec.hooks.postThaw[0].failurePolicy"),
+				)
+
+				It("should reject duplicate hook names", func() {
+					snapshot := createSnapshotWithHooks(&snapshotv1.SnapshotHooks{
+						PreFreeze: []snapshotv1.SnapshotHook{createHook("flush"), createHook("flush")},
+					})
+
+					ar := createSnapshotAdmissionReview(snapshot)
+					resp := createTestVMSnapshotAdmitter(config, vm).Admit(ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.hooks.preFreeze[1].name"))
+				})
+			})
 		})
 	})
 })
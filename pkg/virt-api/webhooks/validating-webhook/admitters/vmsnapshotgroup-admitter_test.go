@@ -0,0 +1,212 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("Validating VirtualMachineSnapshotGroup and VirtualMachineRestoreGroup Admitters", func() {
+	var config *virtconfig.ClusterConfig
+
+	newSnapshotGroup := func() *snapshotv1.VirtualMachineSnapshotGroup {
+		return &snapshotv1.VirtualMachineSnapshotGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "group",
+				Namespace: "default",
+			},
+			Spec: snapshotv1.VirtualMachineSnapshotGroupSpec{
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "web"},
+				},
+			},
+		}
+	}
+
+	newRestoreGroup := func() *snapshotv1.VirtualMachineRestoreGroup {
+		return &snapshotv1.VirtualMachineRestoreGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "restore",
+				Namespace: "default",
+			},
+			Spec: snapshotv1.VirtualMachineRestoreGroupSpec{
+				VirtualMachineSnapshotGroupName: "group",
+			},
+		}
+	}
+
+	Context("Without feature gate enabled", func() {
+		BeforeEach(func() {
+			config, _, _ = testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{})
+		})
+
+		It("should reject snapshot group creation", func() {
+			ar := createGroupAdmissionReview("virtualmachinesnapshotgroups", nil, newSnapshotGroup())
+			resp := NewVMSnapshotGroupAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(Equal("snapshot feature gate not enabled"))
+		})
+
+		It("should reject restore group creation", func() {
+			ar := createGroupAdmissionReview("virtualmachinerestoregroups", nil, newRestoreGroup())
+			resp := createTestVMRestoreGroupAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(Equal("snapshot feature gate not enabled"))
+		})
+	})
+
+	Context("With feature gate enabled", func() {
+		BeforeEach(func() {
+			config, _, _ = testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+				DeveloperConfiguration: &v1.DeveloperConfiguration{
+					FeatureGates: []string{virtconfig.SnapshotGate},
+				},
+			})
+		})
+
+		It("should reject an unexpected resource", func() {
+			ar := createGroupAdmissionReview("virtualmachinesnapshots", nil, newSnapshotGroup())
+			resp := NewVMSnapshotGroupAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+		})
+
+		It("should accept a valid snapshot group", func() {
+			ar := createGroupAdmissionReview("virtualmachinesnapshotgroups", nil, newSnapshotGroup())
+			resp := NewVMSnapshotGroupAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should reject a snapshot group with an empty selector", func() {
+			group := newSnapshotGroup()
+			group.Spec.Selector = metav1.LabelSelector{}
+			ar := createGroupAdmissionReview("virtualmachinesnapshotgroups", nil, group)
+			resp := NewVMSnapshotGroupAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.selector"))
+		})
+
+		It("should reject a snapshot group with an invalid selector", func() {
+			group := newSnapshotGroup()
+			group.Spec.Selector.MatchExpressions = []metav1.LabelSelectorRequirement{
+				{Key: "tier", Operator: "Bogus"},
+			}
+			ar := createGroupAdmissionReview("virtualmachinesnapshotgroups", nil, group)
+			resp := NewVMSnapshotGroupAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.selector"))
+		})
+
+		It("should reject snapshot group spec updates", func() {
+			old := newSnapshotGroup()
+			group := newSnapshotGroup()
+			group.Spec.Selector.MatchLabels = map[string]string{"app": "db"}
+			ar := createGroupAdmissionReview("virtualmachinesnapshotgroups", old, group)
+			resp := NewVMSnapshotGroupAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec"))
+		})
+
+		It("should reject a restore group for a missing snapshot group", func() {
+			ar := createGroupAdmissionReview("virtualmachinerestoregroups", nil, newRestoreGroup())
+			resp := createTestVMRestoreGroupAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.virtualMachineSnapshotGroupName"))
+		})
+
+		It("should reject a restore group for a failed snapshot group", func() {
+			group := newSnapshotGroup()
+			group.Status = &snapshotv1.VirtualMachineSnapshotGroupStatus{Phase: snapshotv1.Failed}
+			ar := createGroupAdmissionReview("virtualmachinerestoregroups", nil, newRestoreGroup())
+			resp := createTestVMRestoreGroupAdmitter(config, group).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring("has failed"))
+		})
+
+		It("should accept a restore group for an existing snapshot group", func() {
+			ar := createGroupAdmissionReview("virtualmachinerestoregroups", nil, newRestoreGroup())
+			resp := createTestVMRestoreGroupAdmitter(config, newSnapshotGroup()).Admit(ar)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should reject restore group spec updates", func() {
+			old := newRestoreGroup()
+			restore := newRestoreGroup()
+			restore.Spec.VirtualMachineSnapshotGroupName = "other"
+			ar := createGroupAdmissionReview("virtualmachinerestoregroups", old, restore)
+			resp := createTestVMRestoreGroupAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec"))
+		})
+	})
+})
+
+func createGroupAdmissionReview(resource string, old, current interface{}) *admissionv1.AdmissionReview {
+	currentBytes, _ := json.Marshal(current)
+
+	ar := &admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Namespace: "default",
+			Resource: metav1.GroupVersionResource{
+				Group:    "snapshot.kubevirt.io",
+				Resource: resource,
+			},
+			Object: runtime.RawExtension{
+				Raw: currentBytes,
+			},
+		},
+	}
+
+	if old != nil {
+		oldBytes, _ := json.Marshal(old)
+		ar.Request.Operation = admissionv1.Update
+		ar.Request.OldObject = runtime.RawExtension{Raw: oldBytes}
+	}
+
+	return ar
+}
+
+func createTestVMRestoreGroupAdmitter(config *virtconfig.ClusterConfig, objs ...runtime.Object) *VMRestoreGroupAdmitter {
+	ctrl := gomock.NewController(GinkgoT())
+	virtClient := kubecli.NewMockKubevirtClient(ctrl)
+	kubevirtClient := kubevirtfake.NewSimpleClientset(objs...)
+
+	virtClient.EXPECT().VirtualMachineSnapshotGroup("default").
+		Return(kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshotGroups("default")).AnyTimes()
+
+	return NewVMRestoreGroupAdmitter(config, virtClient)
+}
@@ -0,0 +1,199 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package admitters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	"kubevirt.io/api/core"
+	snapshotv1 "kubevirt.io/api/snapshot/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+
+	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// VMSnapshotMountAdmitter validates VirtualMachineSnapshotMounts
+type VMSnapshotMountAdmitter struct {
+	Config *virtconfig.ClusterConfig
+	Client kubecli.KubevirtClient
+}
+
+// NewVMSnapshotMountAdmitter creates a VMSnapshotMountAdmitter
+func NewVMSnapshotMountAdmitter(config *virtconfig.ClusterConfig, client kubecli.KubevirtClient) *VMSnapshotMountAdmitter {
+	return &VMSnapshotMountAdmitter{
+		Config: config,
+		Client: client,
+	}
+}
+
+// Admit validates an AdmissionReview
+func (admitter *VMSnapshotMountAdmitter) Admit(ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
+	if ar.Request.Resource.Group != snapshotv1.SchemeGroupVersion.Group ||
+		ar.Request.Resource.Resource != "virtualmachinesnapshotmounts" {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected resource %+v", ar.Request.Resource))
+	}
+
+	if ar.Request.Operation == admissionv1.Create && !admitter.Config.SnapshotEnabled() {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("snapshot feature gate not enabled"))
+	}
+
+	vmSnapshotMount := &snapshotv1.VirtualMachineSnapshotMount{}
+	err := json.Unmarshal(ar.Request.Object.Raw, vmSnapshotMount)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	}
+
+	var causes []metav1.StatusCause
+
+	switch ar.Request.Operation {
+	case admissionv1.Create:
+		specField := k8sfield.NewPath("spec")
+		causes = admitter.validateTarget(specField.Child("target"), vmSnapshotMount.Spec.Target)
+
+		if ttl := vmSnapshotMount.Spec.TTLDuration; ttl != nil && ttl.Duration <= 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "ttlDuration must be positive",
+				Field:   specField.Child("ttlDuration").String(),
+			})
+		}
+
+		snapshotCauses, err := admitter.validateSnapshotVolume(specField, ar.Request.Namespace, &vmSnapshotMount.Spec)
+		if err != nil {
+			return webhookutils.ToAdmissionResponseError(err)
+		}
+		causes = append(causes, snapshotCauses...)
+	case admissionv1.Update:
+		prevObj := &snapshotv1.VirtualMachineSnapshotMount{}
+		err = json.Unmarshal(ar.Request.OldObject.Raw, prevObj)
+		if err != nil {
+			return webhookutils.ToAdmissionResponseError(err)
+		}
+
+		if !equality.Semantic.DeepEqual(prevObj.Spec, vmSnapshotMount.Spec) {
+			causes = []metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: "spec in immutable after creation",
+					Field:   k8sfield.NewPath("spec").String(),
+				},
+			}
+		}
+	default:
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected operation %s", ar.Request.Operation))
+	}
+
+	if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
+	reviewResponse := admissionv1.AdmissionResponse{
+		Allowed: true,
+	}
+	return &reviewResponse
+}
+
+func (admitter *VMSnapshotMountAdmitter) validateTarget(field *k8sfield.Path, target *corev1.TypedLocalObjectReference) []metav1.StatusCause {
+	if target == nil {
+		return nil
+	}
+
+	if target.APIGroup == nil || *target.APIGroup != core.GroupName || target.Kind != "VirtualMachine" {
+		return []metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "target must be a VirtualMachine",
+				Field:   field.String(),
+			},
+		}
+	}
+
+	if !admitter.Config.HotplugVolumesEnabled() {
+		return []metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "mounting a snapshot into a VirtualMachine requires the HotplugVolumes feature gate",
+				Field:   field.String(),
+			},
+		}
+	}
+
+	return nil
+}
+
+func (admitter *VMSnapshotMountAdmitter) validateSnapshotVolume(field *k8sfield.Path, namespace string, spec *snapshotv1.VirtualMachineSnapshotMountSpec) ([]metav1.StatusCause, error) {
+	snapshotField := field.Child("virtualMachineSnapshotName")
+	vmSnapshot, err := admitter.Client.VirtualMachineSnapshot(namespace).Get(context.Background(), spec.VirtualMachineSnapshotName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return []metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("VirtualMachineSnapshot %q does not exist", spec.VirtualMachineSnapshotName),
+				Field:   snapshotField.String(),
+			},
+		}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if vmSnapshot.Status == nil {
+		return nil, nil
+	}
+
+	if vmSnapshot.Status.Phase == snapshotv1.Failed {
+		return []metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("VirtualMachineSnapshot %q has failed and is invalid to use", spec.VirtualMachineSnapshotName),
+				Field:   snapshotField.String(),
+			},
+		}, nil
+	}
+
+	if volumes := vmSnapshot.Status.SnapshotVolumes; volumes != nil {
+		for _, name := range volumes.IncludedVolumes {
+			if name == spec.VolumeName {
+				return nil, nil
+			}
+		}
+
+		return []metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("volume %q is not included in VirtualMachineSnapshot %q", spec.VolumeName, spec.VirtualMachineSnapshotName),
+				Field:   field.Child("volumeName").String(),
+			},
+		}, nil
+	}
+
+	return nil, nil
+}
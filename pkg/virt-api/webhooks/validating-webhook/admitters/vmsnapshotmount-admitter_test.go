@@ -0,0 +1,172 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package admitters
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"kubevirt.io/api/core"
+	v1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("Validating VirtualMachineSnapshotMount Admitter", func() {
+	var config *virtconfig.ClusterConfig
+
+	newSnapshot := func() *snapshotv1.VirtualMachineSnapshot {
+		return &snapshotv1.VirtualMachineSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "snapshot",
+				Namespace: "default",
+			},
+			Status: &snapshotv1.VirtualMachineSnapshotStatus{
+				Phase: snapshotv1.Succeeded,
+				SnapshotVolumes: &snapshotv1.SnapshotVolumesLists{
+					IncludedVolumes: []string{"disk1"},
+				},
+			},
+		}
+	}
+
+	newMount := func() *snapshotv1.VirtualMachineSnapshotMount {
+		apiGroup := core.GroupName
+		return &snapshotv1.VirtualMachineSnapshotMount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mount",
+				Namespace: "default",
+			},
+			Spec: snapshotv1.VirtualMachineSnapshotMountSpec{
+				VirtualMachineSnapshotName: "snapshot",
+				VolumeName:                 "disk1",
+				Target: &corev1.TypedLocalObjectReference{
+					APIGroup: &apiGroup,
+					Kind:     "VirtualMachine",
+					Name:     "vm",
+				},
+			},
+		}
+	}
+
+	It("should reject creation without the snapshot feature gate", func() {
+		config, _, _ = testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{})
+		ar := createGroupAdmissionReview("virtualmachinesnapshotmounts", nil, newMount())
+		resp := createTestVMSnapshotMountAdmitter(config, newSnapshot()).Admit(ar)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).To(Equal("snapshot feature gate not enabled"))
+	})
+
+	It("should reject a target VM without the hotplug feature gate", func() {
+		config, _, _ = testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			DeveloperConfiguration: &v1.DeveloperConfiguration{
+				FeatureGates: []string{virtconfig.SnapshotGate},
+			},
+		})
+		ar := createGroupAdmissionReview("virtualmachinesnapshotmounts", nil, newMount())
+		resp := createTestVMSnapshotMountAdmitter(config, newSnapshot()).Admit(ar)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Details.Causes).To(HaveLen(1))
+		Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.target"))
+	})
+
+	Context("With feature gates enabled", func() {
+		BeforeEach(func() {
+			config, _, _ = testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+				DeveloperConfiguration: &v1.DeveloperConfiguration{
+					FeatureGates: []string{virtconfig.SnapshotGate, virtconfig.HotplugVolumesGate},
+				},
+			})
+		})
+
+		It("should accept a valid mount", func() {
+			ar := createGroupAdmissionReview("virtualmachinesnapshotmounts", nil, newMount())
+			resp := createTestVMSnapshotMountAdmitter(config, newSnapshot()).Admit(ar)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should accept a mount without target", func() {
+			mount := newMount()
+			mount.Spec.Target = nil
+			ar := createGroupAdmissionReview("virtualmachinesnapshotmounts", nil, mount)
+			resp := createTestVMSnapshotMountAdmitter(config, newSnapshot()).Admit(ar)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		DescribeTable("should reject", func(update func(*snapshotv1.VirtualMachineSnapshotMount, *snapshotv1.VirtualMachineSnapshot), field string) {
+			mount := newMount()
+			vmSnapshot := newSnapshot()
+			update(mount, vmSnapshot)
+			ar := createGroupAdmissionReview("virtualmachinesnapshotmounts", nil, mount)
+			resp := createTestVMSnapshotMountAdmitter(config, vmSnapshot).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+		},
+			Entry("a target which is not a VM", func(m *snapshotv1.VirtualMachineSnapshotMount, _ *snapshotv1.VirtualMachineSnapshot) {
+				m.Spec.Target.Kind = "VirtualMachineInstance"
+			}, "spec.target"),
+			Entry("a non positive TTL", func(m *snapshotv1.VirtualMachineSnapshotMount, _ *snapshotv1.VirtualMachineSnapshot) {
+				m.Spec.TTLDuration = &metav1.Duration{Duration: -time.Minute}
+			}, "spec.ttlDuration"),
+			Entry("a missing snapshot", func(m *snapshotv1.VirtualMachineSnapshotMount, _ *snapshotv1.VirtualMachineSnapshot) {
+				m.Spec.VirtualMachineSnapshotName = "other"
+			}, "spec.virtualMachineSnapshotName"),
+			Entry("a failed snapshot", func(_ *snapshotv1.VirtualMachineSnapshotMount, s *snapshotv1.VirtualMachineSnapshot) {
+				s.Status.Phase = snapshotv1.Failed
+			}, "spec.virtualMachineSnapshotName"),
+			Entry("a volume which is not in the snapshot", func(m *snapshotv1.VirtualMachineSnapshotMount, _ *snapshotv1.VirtualMachineSnapshot) {
+				m.Spec.VolumeName = "disk2"
+			}, "spec.volumeName"),
+		)
+
+		It("should reject spec updates", func() {
+			old := newMount()
+			mount := newMount()
+			mount.Spec.VolumeName = "disk2"
+			ar := createGroupAdmissionReview("virtualmachinesnapshotmounts", old, mount)
+			resp := createTestVMSnapshotMountAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec"))
+		})
+	})
+})
+
+func createTestVMSnapshotMountAdmitter(config *virtconfig.ClusterConfig, objs ...runtime.Object) *VMSnapshotMountAdmitter {
+	ctrl := gomock.NewController(GinkgoT())
+	virtClient := kubecli.NewMockKubevirtClient(ctrl)
+	kubevirtClient := kubevirtfake.NewSimpleClientset(objs...)
+
+	virtClient.EXPECT().VirtualMachineSnapshot("default").
+		Return(kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshots("default")).AnyTimes()
+
+	return NewVMSnapshotMountAdmitter(config, virtClient)
+}
@@ -70,6 +70,16 @@ const (
 	Multiarchitecture = "MultiArchitecture"
 	// VMLiveUpdateFeaturesGate allows updating ceratin VM fields, such as CPU sockets to enable hot-plug functionality.
 	VMLiveUpdateFeaturesGate = "VMLiveUpdateFeatures"
+	// VhostUserGate enables the vhost-user interface binding, used to connect VMs to userspace datapaths such as OVS-DPDK.
+	VhostUserGate = "VhostUser"
+	// DiskEncryptionGate enables qemu-native LUKS encryption of disk images with passphrases from Secrets
+	DiskEncryptionGate = "DiskEncryption"
+	// FilesystemTrimGate enables trimming the guest filesystems through the guest agent, periodically and on demand
+	FilesystemTrimGate = "FilesystemTrim"
+	// NetworkVolumesGate enables the NBD, iSCSI and RBD volume sources, which qemu connects to directly
+	NetworkVolumesGate = "NetworkVolumes"
+	// DirectHotplugVolumesGate lets virt-handler stage hotplugged CSI volumes on the node directly, without attachment pods
+	DirectHotplugVolumesGate = "DirectHotplugVolumes"
 )
 
 var deprecatedFeatureGates = [...]string{
@@ -231,3 +241,23 @@ func (config *ClusterConfig) MultiArchitectureEnabled() bool {
 func (config *ClusterConfig) VMLiveUpdateFeaturesEnabled() bool {
 	return config.isFeatureGateEnabled(VMLiveUpdateFeaturesGate)
 }
+
+func (config *ClusterConfig) VhostUserEnabled() bool {
+	return config.isFeatureGateEnabled(VhostUserGate)
+}
+
+func (config *ClusterConfig) DiskEncryptionEnabled() bool {
+	return config.isFeatureGateEnabled(DiskEncryptionGate)
+}
+
+func (config *ClusterConfig) FilesystemTrimEnabled() bool {
+	return config.isFeatureGateEnabled(FilesystemTrimGate)
+}
+
+func (config *ClusterConfig) NetworkVolumesEnabled() bool {
+	return config.isFeatureGateEnabled(NetworkVolumesGate)
+}
+
+func (config *ClusterConfig) DirectHotplugVolumesEnabled() bool {
+	return config.isFeatureGateEnabled(DirectHotplugVolumesGate)
+}
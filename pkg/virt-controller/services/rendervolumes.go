@@ -14,11 +14,16 @@ import (
 	"kubevirt.io/client-go/log"
 
 	"kubevirt.io/kubevirt/pkg/config"
+	diskencryption "kubevirt.io/kubevirt/pkg/disk-encryption"
+	ephemeraldisk "kubevirt.io/kubevirt/pkg/ephemeral-disk"
 	"kubevirt.io/kubevirt/pkg/hooks"
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
 	"kubevirt.io/kubevirt/pkg/network/sriov"
+	"kubevirt.io/kubevirt/pkg/network/vhostuser"
+	networkvolume "kubevirt.io/kubevirt/pkg/storage/network-volume"
 	"kubevirt.io/kubevirt/pkg/storage/types"
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/efi"
 	"kubevirt.io/kubevirt/pkg/virtiofs"
 )
 
@@ -140,6 +145,12 @@ func withVMIVolumes(pvcStore cache.Store, vmiSpecVolumes []v1.Volume, vmiVolumeS
 				}
 			}
 
+			if volume.Overlay != nil {
+				if err := renderer.handleOverlayVolume(volume, pvcStore); err != nil {
+					return err
+				}
+			}
+
 			if volume.HostDisk != nil {
 				renderer.handleHostDisk(volume)
 			}
@@ -174,6 +185,10 @@ func withVMIConfigVolumes(vmiDisks []v1.Disk, vmiVolumes []v1.Volume) VolumeRend
 	return func(renderer *VolumeRenderer) error {
 		volumes := make(map[string]v1.Volume)
 		for _, volume := range vmiVolumes {
+			// Hotplugged config volumes are rendered into the attachment pod
+			if util.IsHotplugVolume(&volume) {
+				continue
+			}
 			volumes[volume.Name] = volume
 
 			if volume.Secret != nil {
@@ -293,7 +308,7 @@ func hotplugVolumes(vmiVolumeStatus []v1.VolumeStatus, vmiSpecVolumes []v1.Volum
 	}
 	// This detects hotplug volumes for a started but not ready VMI
 	for _, volume := range vmiSpecVolumes {
-		if (volume.DataVolume != nil && volume.DataVolume.Hotpluggable) || (volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.Hotpluggable) {
+		if util.IsHotplugVolume(&volume) {
 			hotplugVolumeSet[volume.Name] = struct{}{}
 		}
 	}
@@ -332,6 +347,68 @@ func withAccessCredentials(accessCredentials []v1.AccessCredential) VolumeRender
 	}
 }
 
+func withDiskEncryption(vmiDisks []v1.Disk) VolumeRendererOption {
+	return func(renderer *VolumeRenderer) error {
+		for _, disk := range vmiDisks {
+			if disk.Encryption == nil {
+				continue
+			}
+			// the Secret is only mounted into the pod, the passphrase never touches a disk
+			volumeName := diskencryption.SecretVolumeName(disk.Name)
+			renderer.podVolumes = append(renderer.podVolumes, k8sv1.Volume{
+				Name: volumeName,
+				VolumeSource: k8sv1.VolumeSource{
+					Secret: &k8sv1.SecretVolumeSource{
+						SecretName: disk.Encryption.SecretRef.Name,
+						Items: []k8sv1.KeyToPath{{
+							Key:  diskencryption.PassphraseKey,
+							Path: diskencryption.PassphraseKey,
+						}},
+					},
+				},
+			})
+			renderer.podVolumeMounts = append(renderer.podVolumeMounts, k8sv1.VolumeMount{
+				Name:      volumeName,
+				MountPath: diskencryption.PassphraseDir(disk.Name),
+				ReadOnly:  true,
+			})
+		}
+		return nil
+	}
+}
+
+func withNetworkVolumeSecrets(vmiVolumes []v1.Volume) VolumeRendererOption {
+	return func(renderer *VolumeRenderer) error {
+		for i := range vmiVolumes {
+			volume := &vmiVolumes[i]
+			secretRef := networkvolume.SecretRef(volume)
+			if secretRef == nil {
+				continue
+			}
+			var items []k8sv1.KeyToPath
+			for _, key := range networkvolume.SecretKeys(volume) {
+				items = append(items, k8sv1.KeyToPath{Key: key, Path: key})
+			}
+			volumeName := networkvolume.SecretVolumeName(volume.Name)
+			renderer.podVolumes = append(renderer.podVolumes, k8sv1.Volume{
+				Name: volumeName,
+				VolumeSource: k8sv1.VolumeSource{
+					Secret: &k8sv1.SecretVolumeSource{
+						SecretName: secretRef.Name,
+						Items:      items,
+					},
+				},
+			})
+			renderer.podVolumeMounts = append(renderer.podVolumeMounts, k8sv1.VolumeMount{
+				Name:      volumeName,
+				MountPath: networkvolume.SecretDir(volume.Name),
+				ReadOnly:  true,
+			})
+		}
+		return nil
+	}
+}
+
 func withTPM(vmi *v1.VirtualMachineInstance) VolumeRendererOption {
 	return func(renderer *VolumeRenderer) error {
 		if backendstorage.HasPersistentTPMDevice(&vmi.Spec) {
@@ -387,6 +464,33 @@ func withTPM(vmi *v1.VirtualMachineInstance) VolumeRendererOption {
 	}
 }
 
+func withPersistentEFI(vmi *v1.VirtualMachineInstance) VolumeRendererOption {
+	return func(renderer *VolumeRenderer) error {
+		if !backendstorage.HasPersistentEFI(&vmi.Spec) {
+			return nil
+		}
+		// The NVRAM directory sits right below the private emptyDir, so unlike for the TPM state
+		// no intermediate directories have to be created for non-root VMIs
+		volumeName := vmi.Name + "-efi"
+		renderer.podVolumes = append(renderer.podVolumes, k8sv1.Volume{
+			Name: volumeName,
+			VolumeSource: k8sv1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+					ClaimName: backendstorage.PVCForVMI(vmi),
+					ReadOnly:  false,
+				},
+			},
+		})
+		renderer.podVolumeMounts = append(renderer.podVolumeMounts, k8sv1.VolumeMount{
+			Name:      volumeName,
+			ReadOnly:  false,
+			MountPath: efi.PersistentNVRAMDir,
+			SubPath:   efi.PersistentNVRAMSubPath,
+		})
+		return nil
+	}
+}
+
 func withSidecarVolumes(hookSidecars hooks.HookSidecarList) VolumeRendererOption {
 	return func(renderer *VolumeRenderer) error {
 		if len(hookSidecars) != 0 {
@@ -463,10 +567,18 @@ func withSRIOVPciMapAnnotation() VolumeRendererOption {
 	}
 }
 
+func withVhostUserSockets() VolumeRendererOption {
+	return func(renderer *VolumeRenderer) error {
+		renderer.podVolumeMounts = append(renderer.podVolumeMounts, mountPath(vhostuser.VolumeName, vhostuser.SocketDir))
+		renderer.podVolumes = append(renderer.podVolumes, emptyDirVolume(vhostuser.VolumeName))
+		return nil
+	}
+}
+
 func imgPullSecrets(volumes ...v1.Volume) []k8sv1.LocalObjectReference {
 	var imagePullSecrets []k8sv1.LocalObjectReference
 	for _, volume := range volumes {
-		if volume.ContainerDisk != nil && volume.ContainerDisk.ImagePullSecret != "" {
+		if volume.ContainerDisk != nil && volume.ContainerDisk.ImagePullSecret != "" && !volume.ContainerDisk.Hotpluggable {
 			imagePullSecrets = appendUniqueImagePullSecret(imagePullSecrets, k8sv1.LocalObjectReference{
 				Name: volume.ContainerDisk.ImagePullSecret,
 			})
@@ -477,7 +589,7 @@ func imgPullSecrets(volumes ...v1.Volume) []k8sv1.LocalObjectReference {
 
 func serviceAccount(volumes ...v1.Volume) string {
 	for _, volume := range volumes {
-		if volume.ServiceAccount != nil {
+		if volume.ServiceAccount != nil && !volume.ServiceAccount.Hotpluggable {
 			return volume.ServiceAccount.ServiceAccountName
 		}
 	}
@@ -541,6 +653,44 @@ func (vr *VolumeRenderer) handleEphemeralVolume(volume v1.Volume, pvcStore cache
 	return nil
 }
 
+// handleOverlayVolume attaches the PVC of the overlay read-write and its base PVC read-only
+func (vr *VolumeRenderer) handleOverlayVolume(volume v1.Volume, pvcStore cache.Store) error {
+	claimName := volume.Overlay.ClaimName
+	_, exists, isBlock, err := types.IsPVCBlockFromStore(pvcStore, vr.namespace, claimName)
+	if err != nil {
+		return err
+	}
+	if exists && isBlock {
+		return fmt.Errorf("the overlay of volume %s needs a filesystem PVC, %s is a block PVC", volume.Name, claimName)
+	}
+	if err := vr.addPVCToLaunchManifest(pvcStore, volume, claimName); err != nil {
+		return err
+	}
+	vr.podVolumes = append(vr.podVolumes, k8sv1.Volume{
+		Name: volume.Name,
+		VolumeSource: k8sv1.VolumeSource{
+			PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+				ClaimName: claimName,
+			},
+		},
+	})
+
+	baseVolume := v1.Volume{Name: ephemeraldisk.OverlayBaseVolumeName(volume.Name)}
+	if err := vr.addPVCToLaunchManifest(pvcStore, baseVolume, volume.Overlay.BaseClaimName); err != nil {
+		return err
+	}
+	vr.podVolumes = append(vr.podVolumes, k8sv1.Volume{
+		Name: baseVolume.Name,
+		VolumeSource: k8sv1.VolumeSource{
+			PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+				ClaimName: volume.Overlay.BaseClaimName,
+				ReadOnly:  true,
+			},
+		},
+	})
+	return nil
+}
+
 func (vr *VolumeRenderer) handleDataVolume(volume v1.Volume, pvcStore cache.Store) error {
 	claimName := volume.DataVolume.Name
 	if err := vr.addPVCToLaunchManifest(pvcStore, volume, claimName); err != nil {
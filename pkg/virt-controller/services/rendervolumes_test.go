@@ -8,9 +8,12 @@ import (
 	. "github.com/onsi/gomega"
 
 	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 
 	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/storage/types"
 )
 
 var _ = Describe("Container spec renderer", func() {
@@ -94,6 +97,86 @@ var _ = Describe("Container spec renderer", func() {
 		})
 	})
 
+	Context("with overlay volume option", func() {
+		const overlayVolumeName = "vdi"
+
+		overlayVolume := v1.Volume{
+			Name: overlayVolumeName,
+			VolumeSource: v1.VolumeSource{
+				Overlay: &v1.OverlayVolumeSource{
+					ClaimName:     "vdi-overlay",
+					BaseClaimName: "golden",
+				},
+			},
+		}
+
+		pvcStoreWithModes := func(modes map[string]k8sv1.PersistentVolumeMode) cache.Store {
+			return &cache.FakeCustomStore{
+				GetByKeyFunc: func(key string) (item interface{}, exists bool, err error) {
+					for claimName, mode := range modes {
+						if key == namespace+"/"+claimName {
+							volumeMode := mode
+							return &k8sv1.PersistentVolumeClaim{
+								Spec: k8sv1.PersistentVolumeClaimSpec{VolumeMode: &volumeMode},
+							}, true, nil
+						}
+					}
+					return nil, false, nil
+				},
+			}
+		}
+
+		It("should mount the overlay PVC and attach the base PVC read-only", func() {
+			pvcStore := pvcStoreWithModes(map[string]k8sv1.PersistentVolumeMode{
+				"vdi-overlay": k8sv1.PersistentVolumeFilesystem,
+				"golden":      k8sv1.PersistentVolumeBlock,
+			})
+			var err error
+			vsr, err = NewVolumeRenderer(namespace, ephemeralDisk, containerDisk, virtShareDir, withVMIVolumes(pvcStore, []v1.Volume{overlayVolume}, nil))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(vsr.Mounts()).To(ConsistOf(
+				append(
+					defaultVolumeMounts(),
+					k8sv1.VolumeMount{
+						Name:      overlayVolumeName,
+						MountPath: vmiDiskPath(overlayVolumeName)})))
+			Expect(vsr.VolumeDevices()).To(ConsistOf(k8sv1.VolumeDevice{Name: "vdi-base", DevicePath: "/dev/vdi-base"}))
+			Expect(vsr.Volumes()).To(ConsistOf(
+				append(
+					defaultVolumes(),
+					k8sv1.Volume{
+						Name: overlayVolumeName,
+						VolumeSource: k8sv1.VolumeSource{
+							PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "vdi-overlay"},
+						},
+					},
+					k8sv1.Volume{
+						Name: "vdi-base",
+						VolumeSource: k8sv1.VolumeSource{
+							PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "golden", ReadOnly: true},
+						},
+					})))
+		})
+
+		It("should reject a block PVC for the overlay", func() {
+			pvcStore := pvcStoreWithModes(map[string]k8sv1.PersistentVolumeMode{
+				"vdi-overlay": k8sv1.PersistentVolumeBlock,
+				"golden":      k8sv1.PersistentVolumeBlock,
+			})
+			_, err := NewVolumeRenderer(namespace, ephemeralDisk, containerDisk, virtShareDir, withVMIVolumes(pvcStore, []v1.Volume{overlayVolume}, nil))
+			Expect(err).To(MatchError(ContainSubstring("needs a filesystem PVC")))
+		})
+
+		It("should wait for the base PVC", func() {
+			pvcStore := pvcStoreWithModes(map[string]k8sv1.PersistentVolumeMode{
+				"vdi-overlay": k8sv1.PersistentVolumeFilesystem,
+			})
+			_, err := NewVolumeRenderer(namespace, ephemeralDisk, containerDisk, virtShareDir, withVMIVolumes(pvcStore, []v1.Volume{overlayVolume}, nil))
+			Expect(err).To(BeAssignableToTypeOf(types.PvcNotFoundError{}))
+		})
+	})
+
 	Context("with host disk volume option", func() {
 		const (
 			hostDiskName = "tiny-winy-disk"
@@ -334,6 +417,130 @@ var _ = Describe("Container spec renderer", func() {
 			Expect(vsr.VolumeDevices()).To(BeEmpty())
 		})
 	})
+
+	Context("with disk encryption option", func() {
+		BeforeEach(func() {
+			disks := []v1.Disk{
+				{Name: "clear"},
+				{Name: "encrypted", Encryption: &v1.DiskEncryption{SecretRef: k8sv1.LocalObjectReference{Name: "tenant-key"}}},
+			}
+
+			var err error
+			vsr, err = NewVolumeRenderer(namespace, ephemeralDisk, containerDisk, virtShareDir, withDiskEncryption(disks))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should feature the default mount points plus the passphrase mount of the encrypted disk", func() {
+			Expect(vsr.Mounts()).To(ConsistOf(
+				append(
+					defaultVolumeMounts(),
+					k8sv1.VolumeMount{
+						Name:      "encrypted-encryption",
+						ReadOnly:  true,
+						MountPath: "/var/run/kubevirt-private/secret/encrypted-encryption",
+					})))
+		})
+
+		It("should feature the default volumes plus the passphrase of the encrypted disk", func() {
+			Expect(vsr.Volumes()).To(ConsistOf(
+				append(
+					defaultVolumes(),
+					k8sv1.Volume{
+						Name: "encrypted-encryption",
+						VolumeSource: k8sv1.VolumeSource{
+							Secret: &k8sv1.SecretVolumeSource{
+								SecretName: "tenant-key",
+								Items:      []k8sv1.KeyToPath{{Key: "passphrase", Path: "passphrase"}},
+							},
+						},
+					})))
+		})
+	})
+
+	Context("with persistent EFI option", func() {
+		BeforeEach(func() {
+			persistent := true
+			vmi := &v1.VirtualMachineInstance{ObjectMeta: metav1.ObjectMeta{Name: "testvmi"}}
+			vmi.Spec.Domain.Firmware = &v1.Firmware{Bootloader: &v1.Bootloader{
+				EFI: &v1.EFI{Persistent: &persistent},
+			}}
+
+			var err error
+			vsr, err = NewVolumeRenderer(namespace, ephemeralDisk, containerDisk, virtShareDir, withPersistentEFI(vmi))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should feature the default mount points plus the NVRAM directory of the backend storage", func() {
+			Expect(vsr.Mounts()).To(ConsistOf(
+				append(
+					defaultVolumeMounts(),
+					k8sv1.VolumeMount{
+						Name:      "testvmi-efi",
+						MountPath: "/var/run/kubevirt-private/nvram",
+						SubPath:   "nvram",
+					})))
+		})
+
+		It("should feature the default volumes plus the backend storage", func() {
+			Expect(vsr.Volumes()).To(ConsistOf(
+				append(
+					defaultVolumes(),
+					k8sv1.Volume{
+						Name: "testvmi-efi",
+						VolumeSource: k8sv1.VolumeSource{
+							PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+								ClaimName: "persistent-state-for-testvmi",
+							},
+						},
+					})))
+		})
+	})
+
+	Context("with network volume secrets option", func() {
+		BeforeEach(func() {
+			volumes := []v1.Volume{
+				{Name: "nbd", VolumeSource: v1.VolumeSource{NBD: &v1.NBDVolumeSource{URI: "nbd://nbd.example.com"}}},
+				{Name: "iscsi", VolumeSource: v1.VolumeSource{ISCSI: &v1.ISCSIVolumeSource{
+					TargetPortal:  "10.0.0.1",
+					IQN:           "iqn.2024-01.com.example:storage",
+					CHAPSecretRef: &k8sv1.LocalObjectReference{Name: "chap"},
+				}}},
+			}
+
+			var err error
+			vsr, err = NewVolumeRenderer(namespace, ephemeralDisk, containerDisk, virtShareDir, withNetworkVolumeSecrets(volumes))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should feature the default mount points plus the credentials mount of the iSCSI volume", func() {
+			Expect(vsr.Mounts()).To(ConsistOf(
+				append(
+					defaultVolumeMounts(),
+					k8sv1.VolumeMount{
+						Name:      "iscsi-auth",
+						ReadOnly:  true,
+						MountPath: "/var/run/kubevirt-private/secret/iscsi-auth",
+					})))
+		})
+
+		It("should feature the default volumes plus the CHAP credentials of the iSCSI volume", func() {
+			Expect(vsr.Volumes()).To(ConsistOf(
+				append(
+					defaultVolumes(),
+					k8sv1.Volume{
+						Name: "iscsi-auth",
+						VolumeSource: k8sv1.VolumeSource{
+							Secret: &k8sv1.SecretVolumeSource{
+								SecretName: "chap",
+								Items: []k8sv1.KeyToPath{
+									{Key: "node.session.auth.username", Path: "node.session.auth.username"},
+									{Key: "node.session.auth.password", Path: "node.session.auth.password"},
+								},
+							},
+						},
+					})))
+		})
+	})
 })
 
 func vmiDiskPath(volumeName string) string {
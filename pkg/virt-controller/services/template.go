@@ -133,6 +133,7 @@ const customSELinuxType = "virt_launcher.process"
 type TemplateService interface {
 	RenderMigrationManifest(vmi *v1.VirtualMachineInstance, sourcePod *k8sv1.Pod) (*k8sv1.Pod, error)
 	RenderLaunchManifest(vmi *v1.VirtualMachineInstance) (*k8sv1.Pod, error)
+	RenderLaunchManifestWithImageIDs(vmi *v1.VirtualMachineInstance, imageIDs map[string]string) (*k8sv1.Pod, error)
 	RenderHotplugAttachmentPodTemplate(volume []*v1.Volume, ownerPod *k8sv1.Pod, vmi *v1.VirtualMachineInstance, claimMap map[string]*k8sv1.PersistentVolumeClaim, tempPod bool) (*k8sv1.Pod, error)
 	RenderHotplugAttachmentTriggerPodTemplate(volume *v1.Volume, ownerPod *k8sv1.Pod, vmi *v1.VirtualMachineInstance, pvcName string, isBlock bool, tempPod bool) (*k8sv1.Pod, error)
 	RenderLaunchManifestNoVm(*v1.VirtualMachineInstance) (*k8sv1.Pod, error)
@@ -281,6 +282,12 @@ func (t *templateService) RenderLaunchManifest(vmi *v1.VirtualMachineInstance) (
 	return t.renderLaunchManifest(vmi, nil, false)
 }
 
+// RenderLaunchManifestWithImageIDs renders the launch manifest with the containerDisk and kernel boot
+// images pinned to the given images, keyed by volume name
+func (t *templateService) RenderLaunchManifestWithImageIDs(vmi *v1.VirtualMachineInstance, imageIDs map[string]string) (*k8sv1.Pod, error) {
+	return t.renderLaunchManifest(vmi, imageIDs, false)
+}
+
 func (t *templateService) IsPPC64() bool {
 	return t.clusterConfig.GetClusterCPUArch() == "ppc64le"
 }
@@ -693,7 +700,10 @@ func (t *templateService) newVolumeRenderer(vmi *v1.VirtualMachineInstance, name
 		withVMIConfigVolumes(vmi.Spec.Domain.Devices.Disks, vmi.Spec.Volumes),
 		withVMIVolumes(t.persistentVolumeClaimStore, vmi.Spec.Volumes, vmi.Status.VolumeStatus),
 		withAccessCredentials(vmi.Spec.AccessCredentials),
+		withDiskEncryption(vmi.Spec.Domain.Devices.Disks),
+		withNetworkVolumeSecrets(vmi.Spec.Volumes),
 		withTPM(vmi),
+		withPersistentEFI(vmi),
 	}
 	if len(requestedHookSidecarList) != 0 {
 		volumeOpts = append(volumeOpts, withSidecarVolumes(requestedHookSidecarList))
@@ -715,6 +725,10 @@ func (t *templateService) newVolumeRenderer(vmi *v1.VirtualMachineInstance, name
 		volumeOpts = append(volumeOpts, withVirioFS())
 	}
 
+	if vmispec.VhostUserInterfaceExist(vmi.Spec.Domain.Devices.Interfaces) {
+		volumeOpts = append(volumeOpts, withVhostUserSockets())
+	}
+
 	volumeRenderer, err := NewVolumeRenderer(
 		namespace,
 		t.ephemeralDiskDir,
@@ -848,6 +862,10 @@ func (t *templateService) RenderHotplugAttachmentPodTemplate(volumes []*v1.Volum
 		}
 	}
 	for _, volume := range volumes {
+		if util.IsHotplugConfigVolume(volume) || util.IsHotplugContainerDiskVolume(volume) {
+			t.addHotplugVolumeWithoutClaim(pod, vmi, volume)
+			continue
+		}
 		claimName := types.PVCNameFromVirtVolume(volume)
 		if claimName == "" {
 			continue
@@ -886,6 +904,111 @@ func (t *templateService) RenderHotplugAttachmentPodTemplate(volumes []*v1.Volum
 	return pod, nil
 }
 
+// addHotplugVolumeWithoutClaim adds the pod volumes and containers which provide a hotplugged containerDisk,
+// ConfigMap, Secret or ServiceAccount volume. Config volumes are populated by the kubelet and turned into an
+// iso image by virt-handler, containerDisks are served through a socket by a container running their image.
+func (t *templateService) addHotplugVolumeWithoutClaim(pod *k8sv1.Pod, vmi *v1.VirtualMachineInstance, volume *v1.Volume) {
+	switch {
+	case volume.ContainerDisk != nil:
+		if !HasHotplugContainerDiskBinVolume(pod) {
+			initContainerCommand := []string{"/usr/bin/cp",
+				"/usr/bin/container-disk",
+				"/init/usr/bin/container-disk",
+			}
+			pod.Spec.Volumes = append(pod.Spec.Volumes, emptyDirVolume(virtBinDir))
+			pod.Spec.InitContainers = append(pod.Spec.InitContainers,
+				NewContainerSpecRenderer("container-disk-binary", t.launcherImage, t.clusterConfig.GetImagePullPolicy(),
+					WithVolumeMounts(initContainerVolumeMount()),
+					WithResourceRequirements(hotplugContainerResourceRequirementsForVMI(vmi, t.clusterConfig)),
+					WithNoCapabilities(),
+					WithNonRoot(util.NonRootUID)).Render(initContainerCommand))
+		}
+		pod.Spec.Volumes = append(pod.Spec.Volumes, emptyDirVolume(volume.Name))
+		container := containerdisk.GenerateHotplugContainer(vmi, t.clusterConfig, volume.Name, virtBinDir, volume)
+		// The image has to be readable by the qemu process of the virt-launcher pod
+		container.SecurityContext.SELinuxOptions = pod.Spec.Containers[0].SecurityContext.SELinuxOptions.DeepCopy()
+		pod.Spec.Containers = append(pod.Spec.Containers, *container)
+		if volume.ContainerDisk.ImagePullSecret != "" {
+			pod.Spec.ImagePullSecrets = appendUniqueImagePullSecret(pod.Spec.ImagePullSecrets, k8sv1.LocalObjectReference{
+				Name: volume.ContainerDisk.ImagePullSecret,
+			})
+		}
+	case volume.ConfigMap != nil:
+		pod.Spec.Volumes = append(pod.Spec.Volumes, k8sv1.Volume{
+			Name: volume.Name,
+			VolumeSource: k8sv1.VolumeSource{
+				ConfigMap: &k8sv1.ConfigMapVolumeSource{
+					LocalObjectReference: volume.ConfigMap.LocalObjectReference,
+					Optional:             volume.ConfigMap.Optional,
+				},
+			},
+		})
+	case volume.Secret != nil:
+		pod.Spec.Volumes = append(pod.Spec.Volumes, k8sv1.Volume{
+			Name: volume.Name,
+			VolumeSource: k8sv1.VolumeSource{
+				Secret: &k8sv1.SecretVolumeSource{
+					SecretName: volume.Secret.SecretName,
+					Optional:   volume.Secret.Optional,
+				},
+			},
+		})
+	case volume.ServiceAccount != nil:
+		// The volume mirrors the layout of the service account token mounted into regular pods
+		pod.Spec.ServiceAccountName = volume.ServiceAccount.ServiceAccountName
+		pod.Spec.Volumes = append(pod.Spec.Volumes, k8sv1.Volume{
+			Name: volume.Name,
+			VolumeSource: k8sv1.VolumeSource{
+				Projected: &k8sv1.ProjectedVolumeSource{
+					Sources: []k8sv1.VolumeProjection{
+						{
+							ServiceAccountToken: &k8sv1.ServiceAccountTokenProjection{
+								Path: "token",
+							},
+						},
+						{
+							ConfigMap: &k8sv1.ConfigMapProjection{
+								LocalObjectReference: k8sv1.LocalObjectReference{
+									Name: "kube-root-ca.crt",
+								},
+								Items: []k8sv1.KeyToPath{
+									{
+										Key:  "ca.crt",
+										Path: "ca.crt",
+									},
+								},
+							},
+						},
+						{
+							DownwardAPI: &k8sv1.DownwardAPIProjection{
+								Items: []k8sv1.DownwardAPIVolumeFile{
+									{
+										Path: "namespace",
+										FieldRef: &k8sv1.ObjectFieldSelector{
+											APIVersion: "v1",
+											FieldPath:  "metadata.namespace",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+}
+
+// HasHotplugContainerDiskBinVolume checks if the attachment pod provides the container-disk binary to hotplugged containerDisks
+func HasHotplugContainerDiskBinVolume(pod *k8sv1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name == virtBinDir {
+			return true
+		}
+	}
+	return false
+}
+
 func (t *templateService) RenderHotplugAttachmentTriggerPodTemplate(volume *v1.Volume, ownerPod *k8sv1.Pod, vmi *v1.VirtualMachineInstance, pvcName string, isBlock bool, tempPod bool) (*k8sv1.Pod, error) {
 	zero := int64(0)
 	runUser := int64(util.NonRootUID)
@@ -1093,7 +1216,7 @@ func HaveMasqueradeInterface(interfaces []v1.Interface) bool {
 
 func HaveContainerDiskVolume(volumes []v1.Volume) bool {
 	for _, volume := range volumes {
-		if volume.ContainerDisk != nil {
+		if volume.ContainerDisk != nil && !volume.ContainerDisk.Hotpluggable {
 			return true
 		}
 	}
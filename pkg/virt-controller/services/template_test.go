@@ -2709,6 +2709,29 @@ var _ = Describe("Template", func() {
 			})
 		})
 
+		Context("with vhostuser interface", func() {
+			It("should mount the vhost-user sockets directory into the compute container", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				vmi := api.NewMinimalVMI("testvmi")
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+					Name:                   "dpdk",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{VhostUser: &v1.InterfaceVhostUser{}},
+				}}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+					Name:         "vhostuser-sockets",
+					VolumeSource: kubev1.VolumeSource{EmptyDir: &kubev1.EmptyDirVolumeSource{}},
+				}))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+					Name:      "vhostuser-sockets",
+					MountPath: "/var/run/kubevirt/vhostuser",
+				}))
+			})
+		})
+
 		Context("with sriov interface", func() {
 
 			It("should not run privileged", func() {
@@ -3540,6 +3563,33 @@ var _ = Describe("Template", func() {
 					kubev1.ResourceMemory: resource.MustParse("80M"),
 				}, true, true),
 			)
+
+			It("should not add virtiofs containers for hotplugged filesystems", func() {
+				clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{})
+				vmi := api.NewMinimalVMI("fake-vmi")
+				vmi.Spec.Domain.Devices.Filesystems = []v1.Filesystem{
+					{Name: "static", Virtiofs: &v1.FilesystemVirtiofs{}},
+					{Name: "dataset", Virtiofs: &v1.FilesystemVirtiofs{}},
+				}
+				vmi.Spec.Volumes = []v1.Volume{
+					{
+						Name: "static",
+						VolumeSource: v1.VolumeSource{
+							PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{},
+						},
+					},
+					{
+						Name: "dataset",
+						VolumeSource: v1.VolumeSource{
+							PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{Hotpluggable: true},
+						},
+					},
+				}
+
+				containers := generateVirtioFSContainers(vmi, "virt-launcher", clusterConfig)
+				Expect(containers).To(HaveLen(1))
+				Expect(containers[0].Name).To(ContainSubstring("static"))
+			})
 		})
 
 		Context("Ephemeral storage request", func() {
@@ -3916,6 +3966,59 @@ var _ = Describe("Template", func() {
 			}))
 		})
 
+		It("should render containerDisk, configMap and serviceAccount volumes into hotplug attachment pods", func() {
+			vmi := api.NewMinimalVMI("fake-vmi")
+			ownerPod, err := svc.RenderLaunchManifest(vmi)
+			Expect(err).ToNot(HaveOccurred())
+
+			vmi.Status.SelinuxContext = "test_u:test_r:test_t:s0"
+			volumes := []*v1.Volume{
+				{
+					Name: "containerdisk",
+					VolumeSource: v1.VolumeSource{
+						ContainerDisk: &v1.ContainerDiskSource{
+							Image:           "someimage:v1.2.3.4",
+							ImagePullSecret: "pull-secret",
+							Hotpluggable:    true,
+						},
+					},
+				},
+				{
+					Name: "configmap",
+					VolumeSource: v1.VolumeSource{
+						ConfigMap: &v1.ConfigMapVolumeSource{
+							LocalObjectReference: kubev1.LocalObjectReference{Name: "test-config"},
+							Hotpluggable:         true,
+						},
+					},
+				},
+				{
+					Name: "serviceaccount",
+					VolumeSource: v1.VolumeSource{
+						ServiceAccount: &v1.ServiceAccountVolumeSource{
+							ServiceAccountName: "test-sa",
+							Hotpluggable:       true,
+						},
+					},
+				},
+			}
+			pod, err := svc.RenderHotplugAttachmentPodTemplate(volumes, ownerPod, vmi, map[string]*kubev1.PersistentVolumeClaim{}, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(HasHotplugContainerDiskBinVolume(pod)).To(BeTrue())
+			Expect(pod.Spec.InitContainers).To(HaveLen(1))
+			Expect(pod.Spec.Containers).To(HaveLen(2))
+			Expect(pod.Spec.Containers[1].Image).To(Equal("someimage:v1.2.3.4"))
+			Expect(pod.Spec.ImagePullSecrets).To(ConsistOf(kubev1.LocalObjectReference{Name: "pull-secret"}))
+			Expect(pod.Spec.ServiceAccountName).To(Equal("test-sa"))
+
+			volumeNames := []string{}
+			for _, volume := range pod.Spec.Volumes {
+				volumeNames = append(volumeNames, volume.Name)
+			}
+			Expect(volumeNames).To(ContainElements("containerdisk", "configmap", "serviceaccount"))
+		})
+
 		DescribeTable("should compute the correct security context when rendering hotplug attachment trigger pods", func(isBlock bool) {
 			vmi := api.NewMinimalVMI("fake-vmi")
 			ownerPod, err := svc.RenderLaunchManifest(vmi)
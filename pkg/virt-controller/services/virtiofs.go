@@ -26,6 +26,10 @@ func generateVirtioFSContainers(vmi *v1.VirtualMachineInstance, image string, co
 
 	containers := []k8sv1.Container{}
 	for _, volume := range vmi.Spec.Volumes {
+		// hotplugged filesystems are served by virt-launcher from the attachment pod mount
+		if util.IsHotplugVolume(&volume) {
+			continue
+		}
 		if _, isPassthroughFSVolume := passthroughFSVolumes[volume.Name]; isPassthroughFSVolume {
 
 			container := generateContainerFromVolume(&volume, image, config)
@@ -197,7 +197,12 @@ type VirtControllerApp struct {
 	vmSnapshotInformer           cache.SharedIndexInformer
 	vmSnapshotContentInformer    cache.SharedIndexInformer
 	vmRestoreInformer            cache.SharedIndexInformer
+	vmSnapshotGroupInformer      cache.SharedIndexInformer
+	vmRestoreGroupInformer       cache.SharedIndexInformer
+	vmSnapshotMountInformer      cache.SharedIndexInformer
 	storageClassInformer         cache.SharedIndexInformer
+	persistentVolumeInformer     cache.SharedIndexInformer
+	csiDriverInformer            cache.SharedIndexInformer
 	allPodInformer               cache.SharedIndexInformer
 	resourceQuotaInformer        cache.SharedIndexInformer
 
@@ -380,7 +385,12 @@ func Execute() {
 	app.vmSnapshotInformer = app.informerFactory.VirtualMachineSnapshot()
 	app.vmSnapshotContentInformer = app.informerFactory.VirtualMachineSnapshotContent()
 	app.vmRestoreInformer = app.informerFactory.VirtualMachineRestore()
+	app.vmSnapshotGroupInformer = app.informerFactory.VirtualMachineSnapshotGroup()
+	app.vmRestoreGroupInformer = app.informerFactory.VirtualMachineRestoreGroup()
+	app.vmSnapshotMountInformer = app.informerFactory.VirtualMachineSnapshotMount()
 	app.storageClassInformer = app.informerFactory.StorageClass()
+	app.persistentVolumeInformer = app.informerFactory.PersistentVolume()
+	app.csiDriverInformer = app.informerFactory.CSIDriver()
 	app.caExportConfigMapInformer = app.informerFactory.KubeVirtExportCAConfigMap()
 	app.exportRouteConfigMapInformer = app.informerFactory.ExportRouteConfigMap()
 	app.unmanagedSecretInformer = app.informerFactory.UnmanagedSecrets()
@@ -614,6 +624,8 @@ func (vca *VirtControllerApp) initCommon() {
 		vca.dataVolumeInformer,
 		vca.cdiInformer,
 		vca.cdiConfigInformer,
+		vca.persistentVolumeInformer,
+		vca.csiDriverInformer,
 		vca.clusterConfig,
 		topologyHinter,
 	)
@@ -757,6 +769,7 @@ func (vca *VirtControllerApp) initSnapshotController() {
 		Client:                    vca.clientSet,
 		VMSnapshotInformer:        vca.vmSnapshotInformer,
 		VMSnapshotContentInformer: vca.vmSnapshotContentInformer,
+		VMSnapshotGroupInformer:   vca.vmSnapshotGroupInformer,
 		VMInformer:                vca.vmInformer,
 		VMIInformer:               vca.vmiInformer,
 		StorageClassInformer:      vca.storageClassInformer,
@@ -778,6 +791,9 @@ func (vca *VirtControllerApp) initRestoreController() {
 	vca.restoreController = &snapshot.VMRestoreController{
 		Client:                    vca.clientSet,
 		VMRestoreInformer:         vca.vmRestoreInformer,
+		VMRestoreGroupInformer:    vca.vmRestoreGroupInformer,
+		VMSnapshotMountInformer:   vca.vmSnapshotMountInformer,
+		VMSnapshotGroupInformer:   vca.vmSnapshotGroupInformer,
 		VMSnapshotInformer:        vca.vmSnapshotInformer,
 		VMSnapshotContentInformer: vca.vmSnapshotContentInformer,
 		VMInformer:                vca.vmInformer,
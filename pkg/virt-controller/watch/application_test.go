@@ -98,10 +98,15 @@ var _ = Describe("Application", func() {
 		dataVolumeInformer, _ := testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
 		cdiInformer, _ := testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
 		cdiConfigInformer, _ := testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
+		pvInformer, _ := testutils.NewFakeInformerFor(&kubev1.PersistentVolume{})
+		csiDriverInformer, _ := testutils.NewFakeInformerFor(&storagev1.CSIDriver{})
 		rsInformer, _ := testutils.NewFakeInformerFor(&v1.VirtualMachineInstanceReplicaSet{})
 		storageClassInformer, _ := testutils.NewFakeInformerFor(&storagev1.StorageClass{})
 		crdInformer, _ := testutils.NewFakeInformerFor(&extv1.CustomResourceDefinition{})
 		vmRestoreInformer, _ := testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineRestore{})
+		vmSnapshotGroupInformer, _ := testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshotGroup{})
+		vmRestoreGroupInformer, _ := testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineRestoreGroup{})
+		vmSnapshotMountInformer, _ := testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshotMount{})
 		vmExportInformer, _ := testutils.NewFakeInformerFor(&exportv1.VirtualMachineExport{})
 		configMapInformer, _ := testutils.NewFakeInformerFor(&kubev1.ConfigMap{})
 		routeConfigMapInformer, _ := testutils.NewFakeInformerFor(&kubev1.ConfigMap{})
@@ -134,6 +139,8 @@ var _ = Describe("Application", func() {
 			dataVolumeInformer,
 			cdiInformer,
 			cdiConfigInformer,
+			pvInformer,
+			csiDriverInformer,
 			config,
 			topology.NewTopologyHinter(&cache.FakeCustomStore{}, &cache.FakeCustomStore{}, nil),
 		)
@@ -165,6 +172,7 @@ var _ = Describe("Application", func() {
 			Client:                    virtClient,
 			VMSnapshotInformer:        vmSnapshotInformer,
 			VMSnapshotContentInformer: vmSnapshotContentInformer,
+			VMSnapshotGroupInformer:   vmSnapshotGroupInformer,
 			VMInformer:                vmInformer,
 			VMIInformer:               vmiInformer,
 			PodInformer:               podInformer,
@@ -179,6 +187,9 @@ var _ = Describe("Application", func() {
 		app.restoreController = &snapshot.VMRestoreController{
 			Client:                    virtClient,
 			VMRestoreInformer:         vmRestoreInformer,
+			VMRestoreGroupInformer:    vmRestoreGroupInformer,
+			VMSnapshotMountInformer:   vmSnapshotMountInformer,
+			VMSnapshotGroupInformer:   vmSnapshotGroupInformer,
 			VMSnapshotInformer:        vmSnapshotInformer,
 			VMSnapshotContentInformer: vmSnapshotContentInformer,
 			VMInformer:                vmInformer,
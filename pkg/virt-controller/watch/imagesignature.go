@@ -0,0 +1,245 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package watch
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
+	"kubevirt.io/kubevirt/pkg/container-disk/signature"
+	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/util"
+)
+
+const (
+	imageVerificationTimeout = 30 * time.Second
+
+	// results are kept until the VMIs waiting for them are synced again,
+	// a failed verification is retried once its result expired
+	imageVerificationCacheSize = 1024
+	imageVerificationResultTTL = time.Minute
+)
+
+type imageVerificationResult struct {
+	pinned string
+	err    error
+}
+
+// imageVerifications tracks the signature verifications running off the sync loop.
+// The verifier caches the verified digests, so verifying a tag again only resolves its digest.
+type imageVerifications struct {
+	lock sync.Mutex
+	// waiting holds the keys of the VMIs waiting for a running verification
+	waiting map[string][]string
+	results *cache.LRUExpireCache
+}
+
+func newImageVerifications() *imageVerifications {
+	return &imageVerifications{
+		waiting: map[string][]string{},
+		results: cache.NewLRUExpireCache(imageVerificationCacheSize),
+	}
+}
+
+// lookup returns the result of a verification, or registers the VMI as waiting for it.
+// It returns true when the verification has yet to be started.
+func (v *imageVerifications) lookup(key, vmiKey string) (*imageVerificationResult, bool) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if result, exists := v.results.Get(key); exists {
+		return result.(*imageVerificationResult), false
+	}
+	waiting, running := v.waiting[key]
+	for _, k := range waiting {
+		if k == vmiKey {
+			return nil, false
+		}
+	}
+	v.waiting[key] = append(waiting, vmiKey)
+	return nil, !running
+}
+
+// done records the result of a verification and returns the keys of the VMIs waiting for it
+func (v *imageVerifications) done(key string, result *imageVerificationResult) []string {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.results.Add(key, result, imageVerificationResultTTL)
+	waiting := v.waiting[key]
+	delete(v.waiting, key)
+	return waiting
+}
+
+// verifyContainerDiskSignatures verifies the signatures of the containerDisk and kernel boot images of the VMI
+// when the cluster requires signed images. It returns the images pinned to their verified digests, keyed by
+// volume name, so that the launcher pod can't run anything else than what was verified.
+// The verifications run in the background, it returns true while some are pending and the VMI is enqueued
+// again once they finished.
+func (c *VMIController) verifyContainerDiskSignatures(vmi *virtv1.VirtualMachineInstance) (map[string]string, bool, syncError) {
+	if c.clusterConfig.GetContainerDiskVerification() == nil {
+		return nil, false, nil
+	}
+
+	imageIDs := map[string]string{}
+	pending := false
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.ContainerDisk == nil || volume.ContainerDisk.Hotpluggable {
+			continue
+		}
+		pinned, syncErr := c.verifyImageSignature(vmi, volume.ContainerDisk.Image, volume.ContainerDisk.ImagePullSecret)
+		if syncErr != nil {
+			return nil, false, syncErr
+		}
+		if pinned == "" {
+			pending = true
+		}
+		imageIDs[volume.Name] = pinned
+	}
+	if util.HasKernelBootContainerImage(vmi) {
+		kernelBoot := vmi.Spec.Domain.Firmware.KernelBoot.Container
+		pinned, syncErr := c.verifyImageSignature(vmi, kernelBoot.Image, kernelBoot.ImagePullSecret)
+		if syncErr != nil {
+			return nil, false, syncErr
+		}
+		if pinned == "" {
+			pending = true
+		}
+		imageIDs[containerdisk.KernelBootVolumeName] = pinned
+	}
+	if pending {
+		return nil, true, nil
+	}
+	return imageIDs, false, nil
+}
+
+// verifyHotplugContainerDiskSignatures returns the volumes with their hotplugged containerDisk images
+// pinned to their verified digests, when the cluster requires signed images.
+// It returns true while some verifications are pending.
+func (c *VMIController) verifyHotplugContainerDiskSignatures(vmi *virtv1.VirtualMachineInstance, volumes []*virtv1.Volume) ([]*virtv1.Volume, bool, syncError) {
+	if c.clusterConfig.GetContainerDiskVerification() == nil {
+		return volumes, false, nil
+	}
+
+	verified := make([]*virtv1.Volume, 0, len(volumes))
+	pending := false
+	for _, volume := range volumes {
+		if volume.ContainerDisk == nil {
+			verified = append(verified, volume)
+			continue
+		}
+		pinned, syncErr := c.verifyImageSignature(vmi, volume.ContainerDisk.Image, volume.ContainerDisk.ImagePullSecret)
+		if syncErr != nil {
+			return nil, false, syncErr
+		}
+		if pinned == "" {
+			pending = true
+			continue
+		}
+		volume = volume.DeepCopy()
+		volume.ContainerDisk.Image = pinned
+		verified = append(verified, volume)
+	}
+	if pending {
+		return nil, true, nil
+	}
+	return verified, false, nil
+}
+
+// verifyImageSignature returns the image pinned to its verified digest, or an empty string
+// while the verification is running
+func (c *VMIController) verifyImageSignature(vmi *virtv1.VirtualMachineInstance, image, pullSecret string) (string, syncError) {
+	publicKeys := c.clusterConfig.GetContainerDiskVerification().PublicKeys
+	key := imageVerificationKey(vmi.Namespace, image, pullSecret, publicKeys)
+	result, start := c.imageVerifications.lookup(key, controller.VirtualMachineInstanceKey(vmi))
+	if start {
+		go func() {
+			pinned, err := c.doVerifyImageSignature(vmi.Namespace, image, pullSecret, publicKeys)
+			for _, vmiKey := range c.imageVerifications.done(key, &imageVerificationResult{pinned: pinned, err: err}) {
+				c.Queue.Add(vmiKey)
+			}
+		}()
+	}
+	if result == nil {
+		log.Log.V(3).Object(vmi).Infof("Waiting for the verification of the signature of image %s", image)
+		return "", nil
+	}
+
+	if result.err != nil {
+		err := fmt.Errorf("failed to verify the signature of image %s: %v", image, result.err)
+		c.recorder.Event(vmi, k8sv1.EventTypeWarning, ImageSignatureVerificationFailedReason, err.Error())
+		return "", &syncErrorImpl{err, ImageSignatureVerificationFailedReason}
+	}
+	return result.pinned, nil
+}
+
+// imageVerificationKey identifies a verification, the pull secret and the public keys can change its outcome
+func imageVerificationKey(namespace, image, pullSecret string, publicKeys []string) string {
+	keys := sha256.Sum256([]byte(strings.Join(publicKeys, "\n")))
+	if pullSecret == "" {
+		return fmt.Sprintf("%s|%x", image, keys)
+	}
+	return fmt.Sprintf("%s|%s/%s|%x", image, namespace, pullSecret, keys)
+}
+
+func (c *VMIController) doVerifyImageSignature(namespace, image, pullSecret string, publicKeys []string) (string, error) {
+	keys, err := signature.ParsePublicKeys(publicKeys)
+	if err != nil {
+		return "", err
+	}
+	opts := signature.VerifyOptions{PublicKeys: keys}
+	if pullSecret != "" {
+		if opts.DockerConfig, err = c.getDockerConfig(namespace, pullSecret); err != nil {
+			return "", err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), imageVerificationTimeout)
+	defer cancel()
+	return c.imageVerifier.Verify(ctx, image, opts)
+}
+
+// getDockerConfig reads the pull secret of an image. virt-controller may not read secrets
+// cluster-wide, admins have to grant it access to the pull secrets of a namespace.
+func (c *VMIController) getDockerConfig(namespace, name string) ([]byte, error) {
+	secret, err := c.clientset.CoreV1().Secrets(namespace).Get(context.Background(), name, v1.GetOptions{})
+	if k8serrors.IsForbidden(err) {
+		return nil, fmt.Errorf("virt-controller is not allowed to read the pull secret %s, grant it access with a Role in namespace %s", name, namespace)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get the pull secret %s: %v", name, err)
+	}
+	if config, exists := secret.Data[k8sv1.DockerConfigJsonKey]; exists {
+		return config, nil
+	}
+	return secret.Data[k8sv1.DockerConfigKey], nil
+}
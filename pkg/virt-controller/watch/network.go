@@ -19,10 +19,16 @@
 package watch
 
 import (
+	"strings"
+
+	k8sv1 "k8s.io/api/core/v1"
+
 	v1 "kubevirt.io/api/core/v1"
 
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/network/namescheme"
 	"kubevirt.io/kubevirt/pkg/network/vmispec"
+	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 )
 
 func calculateDynamicInterfaces(vmi *v1.VirtualMachineInstance) ([]v1.Interface, []v1.Network, bool) {
@@ -44,6 +50,36 @@ func calculateDynamicInterfaces(vmi *v1.VirtualMachineInstance) ([]v1.Interface,
 	return vmiSpecIfaces, vmiSpecNets, isIfaceChangeRequired
 }
 
+// isNetworkAttachmentChangeRequired reports whether a secondary interface of the pod is attached
+// to a NetworkAttachmentDefinition other than the one currently specified by the VMI network.
+func isNetworkAttachmentChangeRequired(vmi *v1.VirtualMachineInstance, pod *k8sv1.Pod) bool {
+	if pod == nil {
+		return false
+	}
+	podNetworkStatusByIfaceName := services.NonDefaultMultusNetworksIndexedByIfaceName(pod)
+	podIfaceNamesByNetworkName := namescheme.CreateNetworkNameSchemeByPodNetworkStatus(vmi.Spec.Networks, podNetworkStatusByIfaceName)
+	for _, network := range vmi.Spec.Networks {
+		if !vmispec.IsSecondaryMultusNetwork(network) {
+			continue
+		}
+		podNetworkStatus, exists := podNetworkStatusByIfaceName[podIfaceNamesByNetworkName[network.Name]]
+		if !exists {
+			continue
+		}
+		if qualifiedNetworkName(vmi.Namespace, podNetworkStatus.Name) != qualifiedNetworkName(vmi.Namespace, network.Multus.NetworkName) {
+			return true
+		}
+	}
+	return false
+}
+
+func qualifiedNetworkName(namespace, networkName string) string {
+	if strings.Contains(networkName, "/") {
+		return networkName
+	}
+	return namespace + "/" + networkName
+}
+
 func trimDoneInterfaceRequests(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) {
 	if len(vm.Status.InterfaceRequests) == 0 {
 		return
@@ -31,6 +31,8 @@ import (
 	"kubevirt.io/client-go/api"
 	"kubevirt.io/client-go/kubecli"
 
+	"kubevirt.io/kubevirt/pkg/controller"
+
 	"kubevirt.io/kubevirt/tests/libvmi"
 
 	"kubevirt.io/kubevirt/pkg/pointer"
@@ -190,6 +192,71 @@ var _ = Describe("Network interface hot{un}plug", func() {
 		),
 	)
 
+	DescribeTable("calculate if a network attachment change is required",
+		func(networkName, podNetworkStatus string, expToChange bool) {
+			vmi := libvmi.New(
+				libvmi.WithNamespace("default"),
+				libvmi.WithInterface(v1.Interface{
+					Name:                   testNetworkName1,
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+				}),
+				libvmi.WithNetwork(&v1.Network{
+					Name:          testNetworkName1,
+					NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: networkName}},
+				}),
+			)
+			pod := &k8sv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+					networkv1.NetworkStatusAnnot: podNetworkStatus,
+				}},
+			}
+
+			Expect(isNetworkAttachmentChangeRequired(vmi, pod)).To(Equal(expToChange))
+		},
+		Entry("when the pod interface is attached to the specified network",
+			"red-net", `[{"interface":"net1", "name":"default/red-net"}]`, expectNoChange),
+		Entry("when the pod interface is attached to the specified namespaced network",
+			"default/red-net", `[{"interface":"net1", "name":"default/red-net"}]`, expectNoChange),
+		Entry("when the pod interface is not attached yet",
+			"red-net", `[]`, expectNoChange),
+		Entry("when the pod interface is attached to another network",
+			"blue-net", `[{"interface":"net1", "name":"default/red-net"}]`, expectToChange),
+		Entry("when the pod interface is attached to a network with the same name in another namespace",
+			"red-net", `[{"interface":"net1", "name":"other/red-net"}]`, expectToChange),
+	)
+
+	It("VMI spec networks are re-attached to the networks of the VM template", func() {
+		vmiSpec := &v1.VirtualMachineInstanceSpec{
+			Domain: v1.DomainSpec{Devices: v1.Devices{Interfaces: []v1.Interface{
+				*v1.DefaultMasqueradeNetworkInterface(),
+				{Name: "bridged", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+				{Name: "unplugged", State: v1.InterfaceStateAbsent, InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+				{Name: "sriov", InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}},
+			}}},
+			Networks: []v1.Network{
+				*v1.DefaultPodNetwork(),
+				{Name: "bridged", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "red-net"}}},
+				{Name: "unplugged", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "red-net"}}},
+				{Name: "sriov", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "red-net"}}},
+			},
+		}
+		vmNetworks := []v1.Network{
+			*v1.DefaultPodNetwork(),
+			{Name: "bridged", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}}},
+			{Name: "unplugged", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}}},
+			{Name: "sriov", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}}},
+		}
+
+		vmiSpec = controller.ApplyNetworkAttachmentChanges(vmiSpec, vmNetworks)
+
+		Expect(vmiSpec.Networks).To(Equal([]v1.Network{
+			*v1.DefaultPodNetwork(),
+			{Name: "bridged", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}}},
+			{Name: "unplugged", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "red-net"}}},
+			{Name: "sriov", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "red-net"}}},
+		}))
+	})
+
 	DescribeTable("Stopped VM status interfaces requests",
 		func(ifaces []v1.Interface, ifaceRequests, expectedIfaceRequests []v1.VirtualMachineInterfaceRequest) {
 			vm := kubecli.NewMinimalVM("test")
@@ -112,6 +112,7 @@ const (
 	FailedCreateReason                 = "FailedCreate"
 	VMIFailedDeleteReason              = "FailedDelete"
 	HotPlugNetworkInterfaceErrorReason = "HotPlugNetworkInterfaceError"
+	IOTuneChangeErrorReason            = "IOTuneChangeError"
 )
 
 const defaultMaxCrashLoopBackoffDelaySeconds = 300
@@ -635,6 +636,68 @@ func (c *VMController) handleCPUChangeRequest(vm *virtv1.VirtualMachine, vmi *vi
 	return nil
 }
 
+// handleIOTuneChangeRequest propagates I/O throttling changes of the VM template disks to the running VMI,
+// from where virt-handler applies them on the domain. Like CPU hotplug, this only happens for VMs which
+// opted into live updates while the VMLiveUpdateFeatures gate is enabled, otherwise the changes apply on restart.
+func (c *VMController) handleIOTuneChangeRequest(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	if vmi == nil || vmi.DeletionTimestamp != nil {
+		return nil
+	}
+
+	if vm.Spec.LiveUpdateFeatures == nil || !c.clusterConfig.VMLiveUpdateFeaturesEnabled() {
+		return nil
+	}
+
+	vmDisks := make(map[string]virtv1.Disk)
+	for _, disk := range vm.Spec.Template.Spec.Domain.Devices.Disks {
+		vmDisks[disk.Name] = disk
+	}
+
+	changed := false
+	newDisks := make([]virtv1.Disk, 0, len(vmi.Spec.Domain.Devices.Disks))
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if vmDisk, exists := vmDisks[disk.Name]; exists && !equality.Semantic.DeepEqual(disk.IOTune, vmDisk.IOTune) {
+			disk = *disk.DeepCopy()
+			disk.IOTune = vmDisk.IOTune.DeepCopy()
+			changed = true
+		}
+		newDisks = append(newDisks, disk)
+	}
+	if !changed {
+		return nil
+	}
+
+	if migrations.IsMigrating(vmi) {
+		return fmt.Errorf("I/O throttling change is not allowed while VMI is migrating")
+	}
+
+	if err := c.vmiDisksPatch(newDisks, vmi); err != nil {
+		log.Log.Object(vmi).Errorf("unable to patch vmi to update I/O throttling: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *VMController) vmiDisksPatch(newDisks []virtv1.Disk, vmi *virtv1.VirtualMachineInstance) error {
+	oldDisksJSON, err := json.Marshal(vmi.Spec.Domain.Devices.Disks)
+	if err != nil {
+		return err
+	}
+
+	newDisksJSON, err := json.Marshal(newDisks)
+	if err != nil {
+		return err
+	}
+
+	test := fmt.Sprintf(`{ "op": "test", "path": "/spec/domain/devices/disks", "value": %s}`, string(oldDisksJSON))
+	update := fmt.Sprintf(`{ "op": "replace", "path": "/spec/domain/devices/disks", "value": %s}`, string(newDisksJSON))
+	patch := fmt.Sprintf("[%s, %s]", test, update)
+
+	_, err = c.clientset.VirtualMachineInstance(vmi.Namespace).Patch(context.Background(), vmi.Name, types.JSONPatchType, []byte(patch), &v1.PatchOptions{})
+	return err
+}
+
 func (c *VMController) handleMemoryDumpRequest(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
 	if vm.Status.MemoryDumpRequest == nil {
 		return nil
@@ -727,6 +790,38 @@ func (c *VMController) handleVolumeRequests(vm *virtv1.VirtualMachine, vmi *virt
 			if err := c.clientset.VirtualMachineInstance(vmi.Namespace).RemoveVolume(context.Background(), vmi.Name, request.RemoveVolumeOptions); err != nil {
 				return err
 			}
+		} else if request.InsertMediaOptions != nil {
+			if _, exists := vmiVolumeMap[request.InsertMediaOptions.Name]; exists {
+				continue
+			}
+
+			if err := c.clientset.VirtualMachineInstance(vmi.Namespace).InsertMedia(context.Background(), vmi.Name, request.InsertMediaOptions); err != nil {
+				return err
+			}
+		} else if request.EjectMediaOptions != nil {
+			if _, exists := vmiVolumeMap[request.EjectMediaOptions.Name]; !exists {
+				continue
+			}
+
+			if err := c.clientset.VirtualMachineInstance(vmi.Namespace).EjectMedia(context.Background(), vmi.Name, request.EjectMediaOptions); err != nil {
+				return err
+			}
+		} else if request.AddFilesystemOptions != nil {
+			if _, exists := vmiVolumeMap[request.AddFilesystemOptions.Name]; exists {
+				continue
+			}
+
+			if err := c.clientset.VirtualMachineInstance(vmi.Namespace).AddFilesystem(context.Background(), vmi.Name, request.AddFilesystemOptions); err != nil {
+				return err
+			}
+		} else if request.RemoveFilesystemOptions != nil {
+			if _, exists := vmiVolumeMap[request.RemoveFilesystemOptions.Name]; !exists {
+				continue
+			}
+
+			if err := c.clientset.VirtualMachineInstance(vmi.Namespace).RemoveFilesystem(context.Background(), vmi.Name, request.RemoveFilesystemOptions); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -2521,6 +2616,10 @@ func (c *VMController) trimDoneVolumeRequests(vm *virtv1.VirtualMachine) {
 	for _, disk := range vm.Spec.Template.Spec.Domain.Devices.Disks {
 		diskMap[disk.Name] = disk
 	}
+	filesystemMap := make(map[string]virtv1.Filesystem)
+	for _, fs := range vm.Spec.Template.Spec.Domain.Devices.Filesystems {
+		filesystemMap[fs.Name] = fs
+	}
 
 	tmpVolRequests := vm.Status.VolumeRequests[:0]
 	for _, request := range vm.Status.VolumeRequests {
@@ -2538,10 +2637,39 @@ func (c *VMController) trimDoneVolumeRequests(vm *virtv1.VirtualMachine) {
 			added = false
 		}
 
+		// media requests only change the volume of an existing cdrom
+		mediaRequest := false
+		if request.InsertMediaOptions != nil {
+			volName = request.InsertMediaOptions.Name
+			added = true
+			mediaRequest = true
+		} else if request.EjectMediaOptions != nil {
+			volName = request.EjectMediaOptions.Name
+			added = false
+			mediaRequest = true
+		}
+
+		// filesystem requests pair the volume with a filesystem instead of a disk
+		filesystemRequest := false
+		if request.AddFilesystemOptions != nil {
+			volName = request.AddFilesystemOptions.Name
+			added = true
+			filesystemRequest = true
+		} else if request.RemoveFilesystemOptions != nil {
+			volName = request.RemoveFilesystemOptions.Name
+			added = false
+			filesystemRequest = true
+		}
+
 		_, volExists := volumeMap[volName]
 		_, diskExists := diskMap[volName]
+		if filesystemRequest {
+			_, diskExists = filesystemMap[volName]
+		}
 
-		if added && volExists && diskExists {
+		if mediaRequest {
+			removeRequest = added == volExists
+		} else if added && volExists && diskExists {
 			removeRequest = true
 		} else if !added && !volExists && !diskExists {
 			removeRequest = true
@@ -2636,6 +2764,11 @@ func (c *VMController) sync(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachin
 			syncErr = &syncErrorImpl{fmt.Errorf("Error encountered while handling CPU change request: %v", err), HotPlugCPUErrorReason}
 		}
 
+		err = c.handleIOTuneChangeRequest(vmCopy, vmi)
+		if err != nil {
+			syncErr = &syncErrorImpl{fmt.Errorf("Error encountered while handling I/O throttling change request: %v", err), IOTuneChangeErrorReason}
+		}
+
 		if syncErr == nil {
 			if !equality.Semantic.DeepEqual(vm, vmCopy) {
 				vm, err = c.clientset.VirtualMachine(vmCopy.Namespace).Update(context.Background(), vmCopy)
@@ -2649,6 +2782,9 @@ func (c *VMController) sync(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachin
 				if patchVMIErr := c.applyDynamicIfaceRequestOnVMI(vmi, vmCopy.Status.InterfaceRequests, updatedVMIfaces); err != nil {
 					syncErr = &syncErrorImpl{fmt.Errorf("Error encountered when trying to apply interface request on vmi: %v", patchVMIErr), HotPlugNetworkInterfaceErrorReason}
 				}
+				if patchVMIErr := c.applyNetworkAttachmentChangesOnVMI(vmCopy, vmi); patchVMIErr != nil {
+					syncErr = &syncErrorImpl{fmt.Errorf("Error encountered when trying to apply network attachment changes on vmi: %v", patchVMIErr), HotPlugNetworkInterfaceErrorReason}
+				}
 			}
 		}
 	}
@@ -2685,6 +2821,18 @@ func (c *VMController) applyDynamicIfaceRequestOnVMI(vmi *virtv1.VirtualMachineI
 	return c.vmiInterfacesPatch(vmiSpecCopy, vmi)
 }
 
+// applyNetworkAttachmentChangesOnVMI re-attaches the running VMI interfaces to the networks
+// currently referenced by the VM template, without unplugging them from the guest.
+// Pending interface requests are served first, the change is applied on a later sync.
+func (c *VMController) applyNetworkAttachmentChangesOnVMI(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	if vmi == nil || vmi.DeletionTimestamp != nil || len(vm.Status.InterfaceRequests) > 0 {
+		return nil
+	}
+
+	vmiSpecCopy := controller.ApplyNetworkAttachmentChanges(vmi.Spec.DeepCopy(), vm.Spec.Template.Spec.Networks)
+	return c.vmiInterfacesPatch(vmiSpecCopy, vmi)
+}
+
 // resolveControllerRef returns the controller referenced by a ControllerRef,
 // or nil if the ControllerRef could not be resolved to a matching controller
 // of the correct Kind.
@@ -2754,7 +2902,8 @@ func (c *VMController) hasOrdinalNetworkInterfaces(vmi *virtv1.VirtualMachineIns
 }
 
 func (c *VMController) vmiInterfacesPatch(newVmiSpec *virtv1.VirtualMachineInstanceSpec, vmi *virtv1.VirtualMachineInstance) error {
-	if equality.Semantic.DeepEqual(vmi.Spec.Domain.Devices.Interfaces, newVmiSpec.Domain.Devices.Interfaces) {
+	if equality.Semantic.DeepEqual(vmi.Spec.Domain.Devices.Interfaces, newVmiSpec.Domain.Devices.Interfaces) &&
+		equality.Semantic.DeepEqual(vmi.Spec.Networks, newVmiSpec.Networks) {
 		return nil
 	}
 
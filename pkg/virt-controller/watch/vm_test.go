@@ -501,6 +501,277 @@ var _ = Describe("VirtualMachine", func() {
 			Entry("that is not running", false),
 		)
 
+		DescribeTable("should insert media into an empty cdrom", func(isRunning bool) {
+			vm, vmi := DefaultVirtualMachine(isRunning)
+			vm.Status.Created = true
+			vm.Status.Ready = true
+			vm.Status.VolumeRequests = []virtv1.VirtualMachineVolumeRequest{
+				{
+					InsertMediaOptions: &virtv1.InsertMediaOptions{
+						Name: "cdrom1",
+						VolumeSource: &virtv1.HotplugVolumeSource{
+							PersistentVolumeClaim: &virtv1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+								ClaimName: "iso-pvc",
+							}},
+						},
+					},
+				},
+			}
+			vm.Spec.Template.Spec.Domain.Devices.Disks = append(vm.Spec.Template.Spec.Domain.Devices.Disks, virtv1.Disk{
+				Name: "cdrom1",
+				DiskDevice: virtv1.DiskDevice{
+					CDRom: &virtv1.CDRomTarget{Bus: virtv1.DiskBusSATA},
+				},
+			})
+
+			addVirtualMachine(vm)
+
+			if isRunning {
+				vmi.Spec.Domain.Devices.Disks = vm.Spec.Template.Spec.Domain.Devices.Disks
+				markAsReady(vmi)
+				vmiFeeder.Add(vmi)
+				vmiInterface.EXPECT().InsertMedia(context.Background(), vmi.ObjectMeta.Name, vm.Status.VolumeRequests[0].InsertMediaOptions)
+			}
+
+			vmInterface.EXPECT().Update(context.Background(), gomock.Any()).Do(func(ctx context.Context, arg interface{}) {
+				spec := arg.(*virtv1.VirtualMachine).Spec.Template.Spec
+				Expect(spec.Domain.Devices.Disks).To(HaveLen(1))
+				Expect(spec.Volumes).To(HaveLen(1))
+				Expect(spec.Volumes[0].Name).To(Equal("cdrom1"))
+				Expect(spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("iso-pvc"))
+				Expect(spec.Volumes[0].PersistentVolumeClaim.Hotpluggable).To(BeTrue())
+			}).Return(vm, nil)
+
+			vmInterface.EXPECT().UpdateStatus(context.Background(), gomock.Any()).Do(func(ctx context.Context, arg interface{}) {
+				Expect(arg.(*virtv1.VirtualMachine).Status.VolumeRequests).To(HaveLen(1))
+			}).Return(vm, nil)
+
+			controller.Execute()
+		},
+
+			Entry("that is running", true),
+			Entry("that is not running", false),
+		)
+
+		DescribeTable("should eject media from a cdrom", func(isRunning bool) {
+			vm, vmi := DefaultVirtualMachine(isRunning)
+			vm.Status.Created = true
+			vm.Status.Ready = true
+			vm.Status.VolumeRequests = []virtv1.VirtualMachineVolumeRequest{
+				{
+					EjectMediaOptions: &virtv1.EjectMediaOptions{
+						Name: "cdrom1",
+					},
+				},
+			}
+			vm.Spec.Template.Spec.Domain.Devices.Disks = append(vm.Spec.Template.Spec.Domain.Devices.Disks, virtv1.Disk{
+				Name: "cdrom1",
+				DiskDevice: virtv1.DiskDevice{
+					CDRom: &virtv1.CDRomTarget{Bus: virtv1.DiskBusSATA},
+				},
+			})
+			vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, virtv1.Volume{
+				Name: "cdrom1",
+				VolumeSource: virtv1.VolumeSource{
+					PersistentVolumeClaim: &virtv1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "iso-pvc",
+					}, Hotpluggable: true},
+				},
+			})
+
+			addVirtualMachine(vm)
+
+			if isRunning {
+				vmi.Spec.Volumes = vm.Spec.Template.Spec.Volumes
+				vmi.Spec.Domain.Devices.Disks = vm.Spec.Template.Spec.Domain.Devices.Disks
+				markAsReady(vmi)
+				vmiFeeder.Add(vmi)
+				vmiInterface.EXPECT().EjectMedia(context.Background(), vmi.ObjectMeta.Name, vm.Status.VolumeRequests[0].EjectMediaOptions)
+			}
+
+			vmInterface.EXPECT().Update(context.Background(), gomock.Any()).Do(func(ctx context.Context, arg interface{}) {
+				spec := arg.(*virtv1.VirtualMachine).Spec.Template.Spec
+				Expect(spec.Domain.Devices.Disks).To(HaveLen(1))
+				Expect(spec.Volumes).To(BeEmpty())
+			}).Return(vm, nil)
+
+			vmInterface.EXPECT().UpdateStatus(context.Background(), gomock.Any()).Do(func(ctx context.Context, arg interface{}) {
+				Expect(arg.(*virtv1.VirtualMachine).Status.VolumeRequests).To(HaveLen(1))
+			}).Return(vm, nil)
+
+			controller.Execute()
+		},
+
+			Entry("that is running", true),
+			Entry("that is not running", false),
+		)
+
+		DescribeTable("should clear VolumeRequests for media changes that are satisfied", func(request virtv1.VirtualMachineVolumeRequest, withMedia bool) {
+			vm, _ := DefaultVirtualMachine(false)
+			vm.Status.Created = true
+			vm.Status.Ready = true
+			vm.Status.VolumeRequests = []virtv1.VirtualMachineVolumeRequest{request}
+			vm.Spec.Template.Spec.Domain.Devices.Disks = []virtv1.Disk{{
+				Name: "cdrom1",
+				DiskDevice: virtv1.DiskDevice{
+					CDRom: &virtv1.CDRomTarget{Bus: virtv1.DiskBusSATA},
+				},
+			}}
+			vm.Spec.Template.Spec.Volumes = []virtv1.Volume{}
+			if withMedia {
+				vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, virtv1.Volume{
+					Name: "cdrom1",
+					VolumeSource: virtv1.VolumeSource{
+						PersistentVolumeClaim: &virtv1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "iso-pvc",
+						}, Hotpluggable: true},
+					},
+				})
+			}
+
+			addVirtualMachine(vm)
+
+			vmInterface.EXPECT().UpdateStatus(context.Background(), gomock.Any()).Do(func(ctx context.Context, arg interface{}) {
+				Expect(arg.(*virtv1.VirtualMachine).Status.VolumeRequests).To(BeEmpty())
+			}).Return(nil, nil)
+
+			controller.Execute()
+		},
+			Entry("with inserted media", virtv1.VirtualMachineVolumeRequest{
+				InsertMediaOptions: &virtv1.InsertMediaOptions{Name: "cdrom1", VolumeSource: &virtv1.HotplugVolumeSource{}},
+			}, true),
+			Entry("with ejected media", virtv1.VirtualMachineVolumeRequest{
+				EjectMediaOptions: &virtv1.EjectMediaOptions{Name: "cdrom1"},
+			}, false),
+		)
+
+		DescribeTable("should hotplug a filesystem", func(isRunning bool) {
+			vm, vmi := DefaultVirtualMachine(isRunning)
+			vm.Status.Created = true
+			vm.Status.Ready = true
+			vm.Status.VolumeRequests = []virtv1.VirtualMachineVolumeRequest{
+				{
+					AddFilesystemOptions: &virtv1.AddFilesystemOptions{
+						Name: "dataset",
+						VolumeSource: &virtv1.HotplugVolumeSource{
+							PersistentVolumeClaim: &virtv1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+								ClaimName: "dataset-pvc",
+							}, Hotpluggable: true},
+						},
+					},
+				},
+			}
+
+			addVirtualMachine(vm)
+
+			if isRunning {
+				markAsReady(vmi)
+				vmiFeeder.Add(vmi)
+				vmiInterface.EXPECT().AddFilesystem(context.Background(), vmi.ObjectMeta.Name, vm.Status.VolumeRequests[0].AddFilesystemOptions)
+			}
+
+			vmInterface.EXPECT().Update(context.Background(), gomock.Any()).Do(func(ctx context.Context, arg interface{}) {
+				spec := arg.(*virtv1.VirtualMachine).Spec.Template.Spec
+				Expect(spec.Domain.Devices.Filesystems).To(ConsistOf(virtv1.Filesystem{Name: "dataset", Virtiofs: &virtv1.FilesystemVirtiofs{}}))
+				Expect(spec.Volumes).To(HaveLen(1))
+				Expect(spec.Volumes[0].Name).To(Equal("dataset"))
+				Expect(spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("dataset-pvc"))
+				Expect(spec.Volumes[0].PersistentVolumeClaim.Hotpluggable).To(BeTrue())
+			}).Return(vm, nil)
+
+			vmInterface.EXPECT().UpdateStatus(context.Background(), gomock.Any()).Do(func(ctx context.Context, arg interface{}) {
+				Expect(arg.(*virtv1.VirtualMachine).Status.VolumeRequests).To(HaveLen(1))
+			}).Return(vm, nil)
+
+			controller.Execute()
+		},
+
+			Entry("that is running", true),
+			Entry("that is not running", false),
+		)
+
+		DescribeTable("should hot unplug a filesystem", func(isRunning bool) {
+			vm, vmi := DefaultVirtualMachine(isRunning)
+			vm.Status.Created = true
+			vm.Status.Ready = true
+			vm.Status.VolumeRequests = []virtv1.VirtualMachineVolumeRequest{
+				{
+					RemoveFilesystemOptions: &virtv1.RemoveFilesystemOptions{
+						Name: "dataset",
+					},
+				},
+			}
+			vm.Spec.Template.Spec.Domain.Devices.Filesystems = []virtv1.Filesystem{{Name: "dataset", Virtiofs: &virtv1.FilesystemVirtiofs{}}}
+			vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, virtv1.Volume{
+				Name: "dataset",
+				VolumeSource: virtv1.VolumeSource{
+					PersistentVolumeClaim: &virtv1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "dataset-pvc",
+					}, Hotpluggable: true},
+				},
+			})
+
+			addVirtualMachine(vm)
+
+			if isRunning {
+				vmi.Spec.Volumes = vm.Spec.Template.Spec.Volumes
+				vmi.Spec.Domain.Devices.Filesystems = vm.Spec.Template.Spec.Domain.Devices.Filesystems
+				markAsReady(vmi)
+				vmiFeeder.Add(vmi)
+				vmiInterface.EXPECT().RemoveFilesystem(context.Background(), vmi.ObjectMeta.Name, vm.Status.VolumeRequests[0].RemoveFilesystemOptions)
+			}
+
+			vmInterface.EXPECT().Update(context.Background(), gomock.Any()).Do(func(ctx context.Context, arg interface{}) {
+				spec := arg.(*virtv1.VirtualMachine).Spec.Template.Spec
+				Expect(spec.Domain.Devices.Filesystems).To(BeEmpty())
+				Expect(spec.Volumes).To(BeEmpty())
+			}).Return(vm, nil)
+
+			vmInterface.EXPECT().UpdateStatus(context.Background(), gomock.Any()).Do(func(ctx context.Context, arg interface{}) {
+				Expect(arg.(*virtv1.VirtualMachine).Status.VolumeRequests).To(HaveLen(1))
+			}).Return(vm, nil)
+
+			controller.Execute()
+		},
+
+			Entry("that is running", true),
+			Entry("that is not running", false),
+		)
+
+		DescribeTable("should clear VolumeRequests for filesystem changes that are satisfied", func(request virtv1.VirtualMachineVolumeRequest, withFilesystem bool) {
+			vm, _ := DefaultVirtualMachine(false)
+			vm.Status.Created = true
+			vm.Status.Ready = true
+			vm.Status.VolumeRequests = []virtv1.VirtualMachineVolumeRequest{request}
+			vm.Spec.Template.Spec.Volumes = []virtv1.Volume{}
+			if withFilesystem {
+				vm.Spec.Template.Spec.Domain.Devices.Filesystems = []virtv1.Filesystem{{Name: "dataset", Virtiofs: &virtv1.FilesystemVirtiofs{}}}
+				vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, virtv1.Volume{
+					Name: "dataset",
+					VolumeSource: virtv1.VolumeSource{
+						PersistentVolumeClaim: &virtv1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "dataset-pvc",
+						}, Hotpluggable: true},
+					},
+				})
+			}
+
+			addVirtualMachine(vm)
+
+			vmInterface.EXPECT().UpdateStatus(context.Background(), gomock.Any()).Do(func(ctx context.Context, arg interface{}) {
+				Expect(arg.(*virtv1.VirtualMachine).Status.VolumeRequests).To(BeEmpty())
+			}).Return(nil, nil)
+
+			controller.Execute()
+		},
+			Entry("with an added filesystem", virtv1.VirtualMachineVolumeRequest{
+				AddFilesystemOptions: &virtv1.AddFilesystemOptions{Name: "dataset", VolumeSource: &virtv1.HotplugVolumeSource{}},
+			}, true),
+			Entry("with a removed filesystem", virtv1.VirtualMachineVolumeRequest{
+				RemoveFilesystemOptions: &virtv1.RemoveFilesystemOptions{Name: "dataset"},
+			}, false),
+		)
+
 		It("should not delete failed DataVolume for VirtualMachineInstance", func() {
 			vm, _ := DefaultVirtualMachine(true)
 			vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, virtv1.Volume{
@@ -5023,6 +5294,68 @@ var _ = Describe("VirtualMachine", func() {
 				})
 			})
 		})
+
+		Context("I/O throttling", func() {
+			var vm *virtv1.VirtualMachine
+			var vmi *virtv1.VirtualMachineInstance
+
+			BeforeEach(func() {
+				vm, vmi = DefaultVirtualMachine(true)
+				vmi.Spec.Domain.Devices.Disks = []virtv1.Disk{{Name: "disk0"}, {Name: "disk1"}}
+				vm.Spec.Template.Spec.Domain.Devices.Disks = []virtv1.Disk{
+					{Name: "disk0"},
+					{Name: "disk1", IOTune: &virtv1.DiskIOTune{TotalIOPSSec: 100}},
+				}
+				vm.Spec.LiveUpdateFeatures = &virtv1.LiveUpdateFeatures{}
+				testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, &v1.KubeVirt{
+					Spec: v1.KubeVirtSpec{
+						Configuration: v1.KubeVirtConfiguration{
+							DeveloperConfiguration: &v1.DeveloperConfiguration{
+								FeatureGates: []string{virtconfig.VMLiveUpdateFeaturesGate},
+							},
+						},
+					},
+				})
+			})
+
+			It("should patch the changed throttling onto the vmi", func() {
+				patch := `[{ "op": "test", "path": "/spec/domain/devices/disks", "value": [{"name":"disk0"},{"name":"disk1"}]}, ` +
+					`{ "op": "replace", "path": "/spec/domain/devices/disks", "value": [{"name":"disk0"},{"name":"disk1","ioTune":{"totalIOPSSec":100}}]}]`
+				vmiInterface.EXPECT().Patch(context.Background(), vmi.Name, types.JSONPatchType, []byte(patch), &metav1.PatchOptions{}).Return(vmi, nil)
+
+				Expect(controller.handleIOTuneChangeRequest(vm, vmi)).To(Succeed())
+			})
+
+			It("should not patch the vmi when the throttling did not change", func() {
+				vmi.Spec.Domain.Devices.Disks[1].IOTune = &virtv1.DiskIOTune{TotalIOPSSec: 100}
+
+				Expect(controller.handleIOTuneChangeRequest(vm, vmi)).To(Succeed())
+			})
+
+			It("should not patch the vmi when the vm did not opt into live updates", func() {
+				vm.Spec.LiveUpdateFeatures = nil
+
+				Expect(controller.handleIOTuneChangeRequest(vm, vmi)).To(Succeed())
+			})
+
+			It("should not patch the vmi when live updates are disabled in the cluster", func() {
+				testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, &v1.KubeVirt{})
+
+				Expect(controller.handleIOTuneChangeRequest(vm, vmi)).To(Succeed())
+			})
+
+			It("should not patch the vmi while it is migrating", func() {
+				vmi.Status.MigrationState = &virtv1.VirtualMachineInstanceMigrationState{StartTimestamp: now()}
+
+				Expect(controller.handleIOTuneChangeRequest(vm, vmi)).ToNot(Succeed())
+			})
+
+			It("should not patch a vmi which is being deleted", func() {
+				vmi.DeletionTimestamp = now()
+
+				Expect(controller.handleIOTuneChangeRequest(vm, vmi)).To(Succeed())
+			})
+		})
 	})
 })
 
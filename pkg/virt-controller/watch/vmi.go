@@ -34,6 +34,7 @@ import (
 	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 
 	k8sv1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -50,6 +51,7 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 
 	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	"kubevirt.io/kubevirt/pkg/container-disk/signature"
 	"kubevirt.io/kubevirt/pkg/controller"
 	"kubevirt.io/kubevirt/pkg/network/namescheme"
 	"kubevirt.io/kubevirt/pkg/network/sriov"
@@ -115,8 +117,15 @@ const (
 	MissingAttachmentPodReason = "MissingAttachmentPod"
 	// PVCNotReadyReason is set when the PVC is not ready to be hot plugged.
 	PVCNotReadyReason = "PVCNotReady"
+	// AttachmentPodPendingReason is set when a hotplugged volume not backed by a PVC waits for its attachment pod.
+	AttachmentPodPendingReason = "AttachmentPodPending"
 	// FailedHotplugSyncReason is set when a hotplug specific failure occurs during sync
 	FailedHotplugSyncReason = "FailedHotplugSync"
+	// DirectHotplugVolumeReason is set when a hotplugged volume is staged on the node by virt-handler through its CSI driver.
+	DirectHotplugVolumeReason = "DirectHotplugVolume"
+	// DirectHotplugVolumeInUseReason is set when a hotplugged volume can't be staged on the node, because its claim
+	// only allows a single writer and is in use elsewhere.
+	DirectHotplugVolumeInUseReason = "DirectHotplugVolumeInUse"
 	// ErrImagePullReason is set when an error has occured while pulling an image for a containerDisk VM volume.
 	ErrImagePullReason = "ErrImagePull"
 	// ImagePullBackOffReason is set when an error has occured while pulling an image for a containerDisk VM volume,
@@ -130,10 +139,17 @@ const (
 	// MigrationBackoffReason is set when an error has occured while migrating
 	// and virt-controller is backing off before retrying.
 	MigrationBackoffReason = "MigrationBackoff"
+	// ImageSignatureVerificationFailedReason is set when a containerDisk or kernel boot image
+	// does not carry a valid signature while the cluster requires one.
+	ImageSignatureVerificationFailedReason = "ImageSignatureVerificationFailed"
 )
 
 const failedToRenderLaunchManifestErrFormat = "failed to render launch manifest: %v"
 
+// directHotplugFinalizerPrefix prefixes the finalizer, followed by the VMI UID, which protects a claim while the VMI
+// has it staged directly on the node. No pod mounts the claim, so the pvc-protection controller doesn't see the usage.
+const directHotplugFinalizerPrefix = "kubevirt.io/direct-hotplug-"
+
 func NewVMIController(templateService services.TemplateService,
 	vmiInformer cache.SharedIndexInformer,
 	vmInformer cache.SharedIndexInformer,
@@ -144,6 +160,8 @@ func NewVMIController(templateService services.TemplateService,
 	dataVolumeInformer cache.SharedIndexInformer,
 	cdiInformer cache.SharedIndexInformer,
 	cdiConfigInformer cache.SharedIndexInformer,
+	pvInformer cache.SharedIndexInformer,
+	csiDriverInformer cache.SharedIndexInformer,
 	clusterConfig *virtconfig.ClusterConfig,
 	topologyHinter topology.Hinter,
 ) (*VMIController, error) {
@@ -162,9 +180,13 @@ func NewVMIController(templateService services.TemplateService,
 		dataVolumeInformer: dataVolumeInformer,
 		cdiInformer:        cdiInformer,
 		cdiConfigInformer:  cdiConfigInformer,
+		pvInformer:         pvInformer,
+		csiDriverInformer:  csiDriverInformer,
 		clusterConfig:      clusterConfig,
 		topologyHinter:     topologyHinter,
 		cidsMap:            newCIDsMap(),
+		imageVerifier:      signature.NewVerifier(nil),
+		imageVerifications: newImageVerifications(),
 	}
 
 	_, err := c.vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -263,8 +285,12 @@ type VMIController struct {
 	dataVolumeInformer cache.SharedIndexInformer
 	cdiInformer        cache.SharedIndexInformer
 	cdiConfigInformer  cache.SharedIndexInformer
+	pvInformer         cache.SharedIndexInformer
+	csiDriverInformer  cache.SharedIndexInformer
 	clusterConfig      *virtconfig.ClusterConfig
 	cidsMap            *cidsMap
+	imageVerifier      signature.Verifier
+	imageVerifications *imageVerifications
 }
 
 func (c *VMIController) Run(threadiness int, stopCh <-chan struct{}) {
@@ -281,6 +307,8 @@ func (c *VMIController) Run(threadiness int, stopCh <-chan struct{}) {
 		c.cdiConfigInformer.HasSynced,
 		c.cdiInformer.HasSynced,
 		c.pvcInformer.HasSynced,
+		c.pvInformer.HasSynced,
+		c.csiDriverInformer.HasSynced,
 	)
 	// Sync the CIDs from exist VMIs
 	var vmis []*virtv1.VirtualMachineInstance
@@ -698,6 +726,9 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 		}
 
 		if allDeleted {
+			if err := c.releaseDirectHotplugClaims(vmi); err != nil {
+				return err
+			}
 			log.Log.V(3).Object(vmi).Infof("All pods have been deleted, removing finalizer")
 			controller.RemoveFinalizer(vmiCopy, virtv1.VirtualMachineInstanceFinalizer)
 			if vmiCopy.Labels != nil {
@@ -1164,6 +1195,10 @@ func (c *VMIController) sync(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod,
 		// do not return; just log the error
 	}
 
+	if err := c.releaseDirectHotplugClaims(vmi); err != nil {
+		return &syncErrorImpl{fmt.Errorf("failed to release directly hotplugged claims: %v", err), FailedHotplugSyncReason}
+	}
+
 	dataVolumesReady, isWaitForFirstConsumer, syncErr := c.handleSyncDataVolumes(vmi, dataVolumes)
 	if syncErr != nil {
 		return syncErr
@@ -1200,7 +1235,15 @@ func (c *VMIController) sync(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod,
 			log.Log.V(3).Object(vmi).Infof("Scheduling temporary pod for WaitForFirstConsumer DV")
 			templatePod, err = c.templateService.RenderLaunchManifestNoVm(vmi)
 		} else {
-			templatePod, err = c.templateService.RenderLaunchManifest(vmi)
+			imageIDs, pending, syncErr := c.verifyContainerDiskSignatures(vmi)
+			if syncErr != nil {
+				return syncErr
+			}
+			if pending {
+				log.Log.V(3).Object(vmi).Infof("Delaying pod creation until the image signatures are verified")
+				return nil
+			}
+			templatePod, err = c.templateService.RenderLaunchManifestWithImageIDs(vmi, imageIDs)
 		}
 		if _, ok := err.(storagetypes.PvcNotFoundError); ok {
 			c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, FailedPvcNotFoundReason, failedToRenderLaunchManifestErrFormat, err)
@@ -1246,7 +1289,10 @@ func (c *VMIController) sync(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod,
 			*pod = *patchedPod
 		}
 
-		hotplugVolumes := getHotplugVolumes(vmi, pod)
+		hotplugVolumes, err := c.withoutDirectHotplugVolumes(vmi, getHotplugVolumes(vmi, pod))
+		if err != nil {
+			return &syncErrorImpl{fmt.Errorf("failed to determine directly staged hotplug volumes: %v", err), FailedHotplugSyncReason}
+		}
 		hotplugAttachmentPods, err := controller.AttachmentPods(pod, c.podInformer)
 		if err != nil {
 			return &syncErrorImpl{fmt.Errorf("failed to get attachment pods: %v", err), FailedHotplugSyncReason}
@@ -1267,7 +1313,11 @@ func (c *VMIController) sync(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod,
 			}
 		}
 
-		if vmiSpecIfaces, vmiSpecNets, dynamicIfacesExist := calculateDynamicInterfaces(vmi); dynamicIfacesExist {
+		vmiSpecIfaces, vmiSpecNets, dynamicIfacesExist := calculateDynamicInterfaces(vmi)
+		if !dynamicIfacesExist && isNetworkAttachmentChangeRequired(vmi, pod) {
+			vmiSpecIfaces, vmiSpecNets, dynamicIfacesExist = vmi.Spec.Domain.Devices.Interfaces, vmi.Spec.Networks, true
+		}
+		if dynamicIfacesExist {
 			if err := c.handleDynamicInterfaceRequests(vmi.Namespace, vmiSpecIfaces, vmiSpecNets, pod); err != nil {
 				return &syncErrorImpl{
 					err:    fmt.Errorf("failed to hot{un}plug network interfaces for vmi [%s/%s]: %w", vmi.GetNamespace(), vmi.GetName(), err),
@@ -1744,7 +1794,7 @@ func getHotplugVolumes(vmi *virtv1.VirtualMachineInstance, virtlauncherPod *k8sv
 		podVolumeMap[podVolume.Name] = podVolume
 	}
 	for _, vmiVolume := range vmiVolumes {
-		if _, ok := podVolumeMap[vmiVolume.Name]; !ok && (vmiVolume.DataVolume != nil || vmiVolume.PersistentVolumeClaim != nil || vmiVolume.MemoryDump != nil) {
+		if _, ok := podVolumeMap[vmiVolume.Name]; !ok && (vmiVolume.DataVolume != nil || vmiVolume.PersistentVolumeClaim != nil || vmiVolume.MemoryDump != nil || util.IsHotplugVolume(&vmiVolume)) {
 			hotplugVolumes = append(hotplugVolumes, vmiVolume.DeepCopy())
 		}
 	}
@@ -1833,6 +1883,11 @@ func (c *VMIController) handleHotplugVolumes(hotplugVolumes []*virtv1.Volume, ho
 	readyHotplugVolumes := make([]*virtv1.Volume, 0)
 	// Find all ready volumes
 	for _, volume := range hotplugVolumes {
+		// Volumes which are not backed by a PVC are populated by the attachment pod itself
+		if storagetypes.PVCNameFromVirtVolume(volume) == "" {
+			readyHotplugVolumes = append(readyHotplugVolumes, volume)
+			continue
+		}
 		var err error
 		ready, wffc, err := storagetypes.VolumeReadyToAttachToNode(vmi.Namespace, *volume, dataVolumes, c.dataVolumeInformer, c.pvcInformer)
 		if err != nil {
@@ -1868,7 +1923,16 @@ func (c *VMIController) handleHotplugVolumes(hotplugVolumes []*virtv1.Volume, ho
 	if len(currentPod) == 0 && len(readyHotplugVolumes) > 0 {
 		// ready volumes have changed
 		// Create new attachment pod that holds all the ready volumes
-		if err := c.createAttachmentPod(vmi, virtLauncherPod, readyHotplugVolumes); err != nil {
+		volumes, pending, syncErr := c.verifyHotplugContainerDiskSignatures(vmi, readyHotplugVolumes)
+		if syncErr != nil {
+			return syncErr
+		}
+		if pending {
+			// the old attachment pods are kept until the new one can be created
+			log.Log.V(3).Object(vmi).Infof("Delaying attachment pod creation until the image signatures are verified")
+			return nil
+		}
+		if err := c.createAttachmentPod(vmi, virtLauncherPod, volumes); err != nil {
 			return err
 		}
 	}
@@ -1882,20 +1946,24 @@ func (c *VMIController) handleHotplugVolumes(hotplugVolumes []*virtv1.Volume, ho
 }
 
 func (c *VMIController) podVolumesMatchesReadyVolumes(attachmentPod *k8sv1.Pod, volumes []*virtv1.Volume) bool {
-	// -2 for empty dir and token
-	if len(attachmentPod.Spec.Volumes)-2 != len(volumes) {
+	// -2 for empty dir and token, -1 for the binary dir of hotplugged containerDisks
+	infraVolumes := 2
+	if services.HasHotplugContainerDiskBinVolume(attachmentPod) {
+		infraVolumes++
+	}
+	if len(attachmentPod.Spec.Volumes)-infraVolumes != len(volumes) {
 		return false
 	}
 	podVolumeMap := make(map[string]k8sv1.Volume)
 	for _, volume := range attachmentPod.Spec.Volumes {
-		if volume.PersistentVolumeClaim != nil {
-			podVolumeMap[volume.Name] = volume
-		}
+		podVolumeMap[volume.Name] = volume
 	}
 	for _, volume := range volumes {
-		delete(podVolumeMap, volume.Name)
+		if _, ok := podVolumeMap[volume.Name]; !ok {
+			return false
+		}
 	}
-	return len(podVolumeMap) == 0
+	return true
 }
 
 func (c *VMIController) createAttachmentPod(vmi *virtv1.VirtualMachineInstance, virtLauncherPod *k8sv1.Pod, volumes []*virtv1.Volume) syncError {
@@ -2006,7 +2074,13 @@ func (c *VMIController) createAttachmentPodTemplate(vmi *virtv1.VirtualMachineIn
 	var pod *k8sv1.Pod
 	var err error
 
-	volumeNamesPVCMap, err := storagetypes.VirtVolumesToPVCMap(volumes, c.pvcInformer.GetStore(), virtlauncherPod.Namespace)
+	var pvcVolumes []*virtv1.Volume
+	for _, volume := range volumes {
+		if storagetypes.PVCNameFromVirtVolume(volume) != "" {
+			pvcVolumes = append(pvcVolumes, volume)
+		}
+	}
+	volumeNamesPVCMap, err := storagetypes.VirtVolumesToPVCMap(pvcVolumes, c.pvcInformer.GetStore(), virtlauncherPod.Namespace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get PVC map: %v", err)
 	}
@@ -2028,7 +2102,7 @@ func (c *VMIController) createAttachmentPodTemplate(vmi *virtv1.VirtualMachineIn
 		}
 	}
 
-	if len(volumeNamesPVCMap) > 0 {
+	if len(volumeNamesPVCMap) > 0 || len(pvcVolumes) < len(volumes) {
 		pod, err = c.templateService.RenderHotplugAttachmentPodTemplate(volumes, virtlauncherPod, vmi, volumeNamesPVCMap, false)
 	}
 	return pod, err
@@ -2142,8 +2216,28 @@ func (c *VMIController) updateVolumeStatus(vmi *virtv1.VirtualMachineInstance, v
 					ClaimName: volume.Name,
 				}
 			}
+			csiVolume, err := c.directHotplugCSIVolume(vmi, &vmi.Spec.Volumes[i])
+			var inUseErr *claimInUseError
+			if err != nil && !errors.As(err, &inUseErr) {
+				return err
+			}
 			attachmentPod := c.findAttachmentPodByVolumeName(volume.Name, attachmentPods)
-			if attachmentPod == nil {
+			if inUseErr != nil {
+				status.Phase = virtv1.VolumePending
+				status.Message = fmt.Sprintf("Volume %s can't be hotplugged, %v", volume.Name, inUseErr)
+				if status.Reason != DirectHotplugVolumeInUseReason {
+					status.Reason = DirectHotplugVolumeInUseReason
+					c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, status.Reason, status.Message)
+				}
+			} else if csiVolume != nil {
+				status.HotplugVolume.CSIVolume = csiVolume
+				if status.Phase != virtv1.HotplugVolumeAttachedToNode && c.canMoveToAttachedPhase(status.Phase) {
+					status.Phase = virtv1.HotplugVolumeAttachedToNode
+					status.Message = fmt.Sprintf("Volume %s is staged on the node through the CSI driver %s", volume.Name, csiVolume.Driver)
+					status.Reason = DirectHotplugVolumeReason
+					c.recorder.Eventf(vmi, k8sv1.EventTypeNormal, status.Reason, status.Message)
+				}
+			} else if attachmentPod == nil {
 				status.HotplugVolume.AttachPodName = ""
 				status.HotplugVolume.AttachPodUID = ""
 				// Pod is gone, or hasn't been created yet, check for the PVC associated with the volume to set phase and message
@@ -2153,7 +2247,7 @@ func (c *VMIController) updateVolumeStatus(vmi *virtv1.VirtualMachineInstance, v
 				status.Reason = reason
 			} else {
 				status.HotplugVolume.AttachPodName = attachmentPod.Name
-				if len(attachmentPod.Status.ContainerStatuses) == 1 && attachmentPod.Status.ContainerStatuses[0].Ready {
+				if allContainersReady(attachmentPod) {
 					status.HotplugVolume.AttachPodUID = attachmentPod.UID
 				}
 				if c.canMoveToAttachedPhase(status.Phase) {
@@ -2165,7 +2259,7 @@ func (c *VMIController) updateVolumeStatus(vmi *virtv1.VirtualMachineInstance, v
 			}
 		}
 
-		if volume.VolumeSource.PersistentVolumeClaim != nil || volume.VolumeSource.DataVolume != nil || volume.VolumeSource.MemoryDump != nil {
+		if volume.VolumeSource.PersistentVolumeClaim != nil || volume.VolumeSource.DataVolume != nil || volume.VolumeSource.MemoryDump != nil || volume.VolumeSource.Overlay != nil {
 
 			pvcName := storagetypes.PVCNameFromVirtVolume(&volume)
 
@@ -2194,6 +2288,13 @@ func (c *VMIController) updateVolumeStatus(vmi *virtv1.VirtualMachineInstance, v
 	// We have updated the status of current volumes, but if a volume was removed, we want to keep that status, until there is no
 	// associated pod, then remove it. Any statuses left in the map are statuses without a matching volume in the spec.
 	for k, v := range oldStatusMap {
+		if v.HotplugVolume != nil && v.HotplugVolume.CSIVolume != nil {
+			// Volumes staged directly have no pod, virt-handler removes the status once it unpublished the volume
+			v.Phase = virtv1.HotplugVolumeDetaching
+			v.Message = fmt.Sprintf("Waiting for virt-handler to unpublish volume %s", k)
+			newStatus = append(newStatus, v)
+			continue
+		}
 		attachmentPod := c.findAttachmentPodByVolumeName(k, attachmentPods)
 		if attachmentPod != nil {
 			v.HotplugVolume.AttachPodName = attachmentPod.Name
@@ -2215,6 +2316,276 @@ func (c *VMIController) updateVolumeStatus(vmi *virtv1.VirtualMachineInstance, v
 	return nil
 }
 
+// withoutDirectHotplugVolumes filters out the hotplugged volumes which virt-handler stages directly on the node,
+// they don't need an attachment pod. Volumes whose claim is in use elsewhere are filtered out as well, an attachment
+// pod would bypass the usage of the claim by other VMIs.
+func (c *VMIController) withoutDirectHotplugVolumes(vmi *virtv1.VirtualMachineInstance, hotplugVolumes []*virtv1.Volume) ([]*virtv1.Volume, error) {
+	if !c.clusterConfig.DirectHotplugVolumesEnabled() {
+		return hotplugVolumes, nil
+	}
+	podVolumes := make([]*virtv1.Volume, 0)
+	for _, volume := range hotplugVolumes {
+		csiVolume, err := c.directHotplugCSIVolume(vmi, volume)
+		var inUseErr *claimInUseError
+		if errors.As(err, &inUseErr) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if csiVolume == nil {
+			podVolumes = append(podVolumes, volume)
+		}
+	}
+	return podVolumes, nil
+}
+
+// directHotplugCSIVolume returns the CSI volume virt-handler has to stage on the node for a hotplugged volume, or nil
+// if the volume has to be mounted from an attachment pod. Only ready PVCs bound to CSI volumes which need neither an
+// attach operation, secrets nor pod information are staged directly. Once taken, the decision is kept in the volume
+// status, so the persistent volume and the CSI driver are only looked up once. A claimInUseError is returned if the
+// claim only allows a single writer which is somebody else, the volume must neither be staged nor attached then.
+func (c *VMIController) directHotplugCSIVolume(vmi *virtv1.VirtualMachineInstance, volume *virtv1.Volume) (*virtv1.HotplugCSIVolumeSource, error) {
+	if !c.clusterConfig.DirectHotplugVolumesEnabled() {
+		return nil, nil
+	}
+	for _, status := range vmi.Status.VolumeStatus {
+		if status.Name != volume.Name || status.HotplugVolume == nil {
+			continue
+		}
+		if status.HotplugVolume.CSIVolume != nil {
+			return status.HotplugVolume.CSIVolume, nil
+		}
+		if status.HotplugVolume.AttachPodName != "" {
+			return nil, nil
+		}
+	}
+
+	claimName := storagetypes.PVCNameFromVirtVolume(volume)
+	if claimName == "" || volume.MemoryDump != nil {
+		return nil, nil
+	}
+	ready, _, err := storagetypes.VolumeReadyToAttachToNode(vmi.Namespace, *volume, nil, c.dataVolumeInformer, c.pvcInformer)
+	if _, ok := err.(storagetypes.PvcNotFoundError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if !ready {
+		return nil, nil
+	}
+	obj, exists, err := c.pvcInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", vmi.Namespace, claimName))
+	if err != nil || !exists {
+		return nil, err
+	}
+	pvc := obj.(*k8sv1.PersistentVolumeClaim)
+	if pvc.Status.Phase != k8sv1.ClaimBound || pvc.Spec.VolumeName == "" {
+		return nil, nil
+	}
+
+	obj, exists, err = c.pvInformer.GetStore().GetByKey(pvc.Spec.VolumeName)
+	if err != nil || !exists {
+		return nil, err
+	}
+	pv := obj.(*k8sv1.PersistentVolume)
+	csi := pv.Spec.CSI
+	if csi == nil || csi.NodeStageSecretRef != nil || csi.NodePublishSecretRef != nil {
+		return nil, nil
+	}
+	obj, exists, err = c.csiDriverInformer.GetStore().GetByKey(csi.Driver)
+	if err != nil || !exists {
+		// Without a CSIDriver object the volume is attached by default
+		return nil, err
+	}
+	driver := obj.(*storagev1.CSIDriver)
+	if driver.Spec.AttachRequired == nil || *driver.Spec.AttachRequired ||
+		(driver.Spec.PodInfoOnMount != nil && *driver.Spec.PodInfoOnMount) ||
+		len(driver.Spec.TokenRequests) > 0 {
+		return nil, nil
+	}
+
+	user, err := c.singleWriterClaimUser(vmi, pvc)
+	if err != nil {
+		return nil, err
+	} else if user != "" {
+		return nil, &claimInUseError{claimName: claimName, user: user}
+	}
+	if err := c.addDirectHotplugFinalizer(vmi, pvc); err != nil {
+		return nil, err
+	}
+
+	return &virtv1.HotplugCSIVolumeSource{
+		Driver:           csi.Driver,
+		VolumeHandle:     csi.VolumeHandle,
+		FSType:           csi.FSType,
+		MountOptions:     pv.Spec.MountOptions,
+		VolumeAttributes: csi.VolumeAttributes,
+	}, nil
+}
+
+type claimInUseError struct {
+	claimName string
+	user      string
+}
+
+func (e *claimInUseError) Error() string {
+	return fmt.Sprintf("claim %s is in use by %s", e.claimName, e.user)
+}
+
+func directHotplugFinalizer(vmi *virtv1.VirtualMachineInstance) string {
+	return directHotplugFinalizerPrefix + string(vmi.UID)
+}
+
+// singleWriterClaimUser returns who else uses a ReadWriteOnce or ReadWriteOncePod claim: a pod, or another VMI
+// staging it directly. Kubernetes only enforces access modes for pods mounting a claim, so they have to be checked
+// before virt-handler stages the volume. ReadWriteOnce claims may still be shared on the node of the VMI.
+func (c *VMIController) singleWriterClaimUser(vmi *virtv1.VirtualMachineInstance, pvc *k8sv1.PersistentVolumeClaim) (string, error) {
+	singlePod := false
+	for _, accessMode := range pvc.Spec.AccessModes {
+		switch accessMode {
+		case k8sv1.ReadWriteMany, k8sv1.ReadOnlyMany:
+			return "", nil
+		case k8sv1.ReadWriteOncePod:
+			singlePod = true
+		}
+	}
+	sharedOnNode := func(nodeName string) bool {
+		return !singlePod && nodeName != "" && nodeName == vmi.Status.NodeName
+	}
+
+	objs, err := c.podInformer.GetIndexer().ByIndex(cache.NamespaceIndex, pvc.Namespace)
+	if err != nil {
+		return "", err
+	}
+	for _, obj := range objs {
+		pod := obj.(*k8sv1.Pod)
+		if podIsDown(pod) || controller.IsControlledBy(pod, vmi) || sharedOnNode(pod.Spec.NodeName) {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == pvc.Name {
+				return fmt.Sprintf("pod %s", pod.Name), nil
+			}
+		}
+	}
+
+	for _, finalizer := range pvc.Finalizers {
+		if !strings.HasPrefix(finalizer, directHotplugFinalizerPrefix) || finalizer == directHotplugFinalizer(vmi) {
+			continue
+		}
+		uid := strings.TrimPrefix(finalizer, directHotplugFinalizerPrefix)
+		objs, err := c.vmiInformer.GetIndexer().ByIndex(cache.NamespaceIndex, pvc.Namespace)
+		if err != nil {
+			return "", err
+		}
+		for _, obj := range objs {
+			other := obj.(*virtv1.VirtualMachineInstance)
+			if string(other.UID) == uid && !sharedOnNode(other.Status.NodeName) {
+				return fmt.Sprintf("VMI %s", other.Name), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// addDirectHotplugFinalizer marks the claim as used by the VMI before virt-handler stages it. The resource version
+// is tested, so that two VMIs can't mark a single writer claim concurrently.
+func (c *VMIController) addDirectHotplugFinalizer(vmi *virtv1.VirtualMachineInstance, pvc *k8sv1.PersistentVolumeClaim) error {
+	finalizer := directHotplugFinalizer(vmi)
+	for _, f := range pvc.Finalizers {
+		if f == finalizer {
+			return nil
+		}
+	}
+	return c.patchClaimFinalizers(pvc, append(append([]string{}, pvc.Finalizers...), finalizer))
+}
+
+// releaseDirectHotplugClaims removes the finalizer of the VMI from the claims it no longer stages directly. All of
+// them are released once the VMI is final.
+func (c *VMIController) releaseDirectHotplugClaims(vmi *virtv1.VirtualMachineInstance) error {
+	if vmi.UID == "" {
+		return nil
+	}
+	staged := map[string]struct{}{}
+	// unpublishing holds the CSI volumes removed from the spec which virt-handler still has to unpublish
+	unpublishing := map[string]struct{}{}
+	if !vmi.IsFinal() {
+		for _, status := range vmi.Status.VolumeStatus {
+			if status.HotplugVolume == nil || status.HotplugVolume.CSIVolume == nil {
+				continue
+			}
+			inSpec := false
+			for i := range vmi.Spec.Volumes {
+				if vmi.Spec.Volumes[i].Name == status.Name {
+					staged[storagetypes.PVCNameFromVirtVolume(&vmi.Spec.Volumes[i])] = struct{}{}
+					inSpec = true
+				}
+			}
+			if !inSpec {
+				unpublishing[csiVolumeKey(status.HotplugVolume.CSIVolume.Driver, status.HotplugVolume.CSIVolume.VolumeHandle)] = struct{}{}
+			}
+		}
+	}
+
+	finalizer := directHotplugFinalizer(vmi)
+	objs, err := c.pvcInformer.GetIndexer().ByIndex(cache.NamespaceIndex, vmi.Namespace)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objs {
+		pvc := obj.(*k8sv1.PersistentVolumeClaim)
+		if _, ok := staged[pvc.Name]; ok {
+			continue
+		}
+		finalizers := make([]string, 0, len(pvc.Finalizers))
+		for _, f := range pvc.Finalizers {
+			if f != finalizer {
+				finalizers = append(finalizers, f)
+			}
+		}
+		if len(finalizers) == len(pvc.Finalizers) || c.claimUnpublishing(pvc, unpublishing) {
+			continue
+		}
+		if err := c.patchClaimFinalizers(pvc, finalizers); err != nil {
+			return err
+		}
+		log.Log.Object(vmi).V(3).Infof("Released claim %s, it is no longer staged directly", pvc.Name)
+	}
+	return nil
+}
+
+// claimUnpublishing checks if the claim is bound to one of the given CSI volumes
+func (c *VMIController) claimUnpublishing(pvc *k8sv1.PersistentVolumeClaim, unpublishing map[string]struct{}) bool {
+	if len(unpublishing) == 0 || pvc.Spec.VolumeName == "" {
+		return false
+	}
+	obj, exists, err := c.pvInformer.GetStore().GetByKey(pvc.Spec.VolumeName)
+	if err != nil || !exists {
+		return false
+	}
+	csi := obj.(*k8sv1.PersistentVolume).Spec.CSI
+	if csi == nil {
+		return false
+	}
+	_, ok := unpublishing[csiVolumeKey(csi.Driver, csi.VolumeHandle)]
+	return ok
+}
+
+func csiVolumeKey(driver, volumeHandle string) string {
+	return driver + "/" + volumeHandle
+}
+
+func (c *VMIController) patchClaimFinalizers(pvc *k8sv1.PersistentVolumeClaim, finalizers []string) error {
+	patchBytes, err := patch.GeneratePatchPayload(
+		patch.PatchOperation{Op: patch.PatchTestOp, Path: "/metadata/resourceVersion", Value: pvc.ResourceVersion},
+		patch.PatchOperation{Op: patch.PatchAddOp, Path: "/metadata/finalizers", Value: finalizers},
+	)
+	if err != nil {
+		return err
+	}
+	_, err = c.clientset.CoreV1().PersistentVolumeClaims(pvc.Namespace).Patch(context.Background(), pvc.Name, types.JSONPatchType, patchBytes, v1.PatchOptions{})
+	return err
+}
+
 func (c *VMIController) getFilesystemOverhead(pvc *k8sv1.PersistentVolumeClaim) (cdiv1.Percent, error) {
 	// To avoid conflicts, we only allow having one CDI instance
 	if cdiInstances := len(c.cdiInformer.GetStore().List()); cdiInstances != 1 {
@@ -2254,8 +2625,23 @@ func (c *VMIController) findAttachmentPodByVolumeName(volumeName string, attachm
 	return nil
 }
 
+func allContainersReady(pod *k8sv1.Pod) bool {
+	if len(pod.Status.ContainerStatuses) == 0 {
+		return false
+	}
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if !containerStatus.Ready {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *VMIController) getVolumePhaseMessageReason(volume *virtv1.Volume, namespace string) (virtv1.VolumePhase, string, string) {
 	claimName := storagetypes.PVCNameFromVirtVolume(volume)
+	if claimName == "" {
+		return virtv1.VolumePending, AttachmentPodPendingReason, "Waiting for the attachment pod to be created"
+	}
 
 	pvcInterface, pvcExists, _ := c.pvcInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", namespace, claimName))
 	if !pvcExists {
@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"kubevirt.io/kubevirt/pkg/network/vmispec"
@@ -41,6 +42,7 @@ import (
 	. "github.com/onsi/gomega/gstruct"
 	gomegaTypes "github.com/onsi/gomega/types"
 	k8sv1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -60,10 +62,13 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	"kubevirt.io/kubevirt/pkg/container-disk/signature"
 	kvcontroller "kubevirt.io/kubevirt/pkg/controller"
 	"kubevirt.io/kubevirt/pkg/network/sriov"
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/topology"
 )
@@ -97,6 +102,8 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 	var dataVolumeInformer cache.SharedIndexInformer
 	var cdiInformer cache.SharedIndexInformer
 	var cdiConfigInformer cache.SharedIndexInformer
+	var pvInformer cache.SharedIndexInformer
+	var csiDriverInformer cache.SharedIndexInformer
 	var dataVolumeFeeder *testutils.DataVolumeFeeder
 	var qemuGid int64 = 107
 	controllerOf := true
@@ -263,6 +270,8 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 		pvcInformer, _ = testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
 		cdiInformer, _ = testutils.NewFakeInformerFor(&cdiv1.CDIConfig{})
 		cdiConfigInformer, _ = testutils.NewFakeInformerFor(&cdiv1.CDIConfig{})
+		pvInformer, _ = testutils.NewFakeInformerFor(&k8sv1.PersistentVolume{})
+		csiDriverInformer, _ = testutils.NewFakeInformerFor(&storagev1.CSIDriver{})
 		controller, _ = NewVMIController(
 			services.NewTemplateService("a", 240, "b", "c", "d", "e", "f", "g", pvcInformer.GetStore(), virtClient, config, qemuGid, "h"),
 			vmiInformer,
@@ -274,6 +283,8 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			dataVolumeInformer,
 			cdiInformer,
 			cdiConfigInformer,
+			pvInformer,
+			csiDriverInformer,
 			config,
 			topology.NewTopologyHinter(&cache.FakeCustomStore{}, &cache.FakeCustomStore{}, config),
 		)
@@ -823,6 +834,189 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 
 			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
 		})
+		Context("with containerDisk signature verification", func() {
+			const pinnedDigest = "@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+			var verifier *fakeImageVerifier
+
+			BeforeEach(func() {
+				controller.clusterConfig, _, _ = testutils.NewFakeClusterConfigUsingKVConfig(&virtv1.KubeVirtConfiguration{
+					ContainerDiskVerification: &virtv1.ContainerDiskVerification{
+						PublicKeys: []string{testPublicKey},
+					},
+				})
+				verifier = &fakeImageVerifier{verified: map[string]signature.VerifyOptions{}}
+				controller.imageVerifier = verifier
+			})
+
+			// the images are verified in the background, the VMI is enqueued again once each verification finished
+			executeVerified := func(verifications int) {
+				mockQueue.ExpectAdds(verifications)
+				controller.Execute()
+				mockQueue.Wait()
+				controller.Execute()
+			}
+
+			newVMIWithContainerDisks := func() *virtv1.VirtualMachineInstance {
+				vmi := NewPendingVirtualMachine("testvmi")
+				vmi.Spec.Volumes = []virtv1.Volume{{
+					Name: "disk0",
+					VolumeSource: virtv1.VolumeSource{
+						ContainerDisk: &virtv1.ContainerDiskSource{Image: "registry:5000/disk:v1", ImagePullSecret: "pull-secret"},
+					},
+				}}
+				vmi.Spec.Domain.Firmware = &virtv1.Firmware{
+					KernelBoot: &virtv1.KernelBoot{
+						Container: &virtv1.KernelBootContainer{Image: "registry:5000/kernel:v1", KernelPath: "/vmlinuz"},
+					},
+				}
+				return vmi
+			}
+
+			It("should create the pod with the verified images pinned to their digest", func() {
+				vmi := newVMIWithContainerDisks()
+				addVirtualMachine(vmi)
+
+				kubeClient.Fake.PrependReactor("get", "secrets", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
+					Expect(action.(testing.GetAction).GetName()).To(Equal("pull-secret"))
+					return true, &k8sv1.Secret{Data: map[string][]byte{k8sv1.DockerConfigJsonKey: []byte(`{"auths":{}}`)}}, nil
+				})
+				containerImages := func(pod *k8sv1.Pod) []string {
+					var images []string
+					for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+						images = append(images, container.Image)
+					}
+					return images
+				}
+				shouldExpectMatchingPodCreation(vmi.UID, WithTransform(containerImages, And(
+					ContainElement("registry:5000/disk"+pinnedDigest),
+					ContainElement("registry:5000/kernel"+pinnedDigest),
+					Not(ContainElement("registry:5000/disk:v1")),
+					Not(ContainElement("registry:5000/kernel:v1")),
+				)))
+
+				executeVerified(2)
+
+				testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+				Expect(verifier.verified).To(HaveKey("registry:5000/disk:v1"))
+				Expect(verifier.verified).To(HaveKey("registry:5000/kernel:v1"))
+				Expect(verifier.verified["registry:5000/disk:v1"].PublicKeys).To(HaveLen(1))
+				Expect(verifier.verified["registry:5000/disk:v1"].DockerConfig).To(Equal([]byte(`{"auths":{}}`)))
+				Expect(verifier.verified["registry:5000/kernel:v1"].DockerConfig).To(BeEmpty())
+			})
+
+			It("should not create the pod and set the Synchronized condition when the verification fails", func() {
+				vmi := newVMIWithContainerDisks()
+				vmi.Spec.Volumes[0].ContainerDisk.ImagePullSecret = ""
+				addVirtualMachine(vmi)
+				verifier.err = signature.ErrNoSignature
+
+				vmiInterface.EXPECT().Update(context.Background(), gomock.Any()).Do(func(ctx context.Context, vmi *virtv1.VirtualMachineInstance) {
+					Expect(vmi.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Type":    Equal(virtv1.VirtualMachineInstanceSynchronized),
+						"Status":  Equal(k8sv1.ConditionFalse),
+						"Reason":  Equal(ImageSignatureVerificationFailedReason),
+						"Message": ContainSubstring("failed to verify the signature of image registry:5000/disk:v1: image has no signature"),
+					})))
+				}).Return(vmi, nil)
+
+				executeVerified(2)
+
+				testutils.ExpectEvent(recorder, ImageSignatureVerificationFailedReason)
+				Expect(mockQueue.GetRateLimitedEnqueueCount()).To(Equal(1))
+			})
+
+			It("should not create the pod while the images are verified", func() {
+				vmi := newVMIWithContainerDisks()
+				vmi.Spec.Volumes[0].ContainerDisk.ImagePullSecret = ""
+				addVirtualMachine(vmi)
+				verifier.block = make(chan struct{})
+				defer close(verifier.block)
+
+				controller.Execute()
+
+				Expect(mockQueue.Len()).To(BeZero())
+				Expect(mockQueue.GetRateLimitedEnqueueCount()).To(BeZero())
+			})
+
+			It("should reuse the result of a verification", func() {
+				vmi := newVMIWithContainerDisks()
+				vmi.Spec.Volumes[0].ContainerDisk.ImagePullSecret = ""
+				vmi.Spec.Domain.Firmware = nil
+				addVirtualMachine(vmi)
+
+				mockQueue.ExpectAdds(1)
+				controller.Execute()
+				mockQueue.Wait()
+
+				_, pending, syncErr := controller.verifyContainerDiskSignatures(vmi)
+				Expect(syncErr).ToNot(HaveOccurred())
+				Expect(pending).To(BeFalse())
+				Expect(verifier.calls).To(Equal(1))
+			})
+
+			It("should reject images whose pull secret virt-controller may not read", func() {
+				vmi := newVMIWithContainerDisks()
+				addVirtualMachine(vmi)
+
+				kubeClient.Fake.PrependReactor("get", "secrets", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
+					return true, nil, k8serrors.NewForbidden(k8sv1.Resource("secrets"), "pull-secret", fmt.Errorf("forbidden"))
+				})
+				vmiInterface.EXPECT().Update(context.Background(), gomock.Any()).Do(func(ctx context.Context, vmi *virtv1.VirtualMachineInstance) {
+					Expect(vmi.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Reason":  Equal(ImageSignatureVerificationFailedReason),
+						"Message": ContainSubstring("virt-controller is not allowed to read the pull secret pull-secret, grant it access with a Role in namespace default"),
+					})))
+				}).Return(vmi, nil)
+
+				executeVerified(2)
+
+				testutils.ExpectEvent(recorder, ImageSignatureVerificationFailedReason)
+				Expect(verifier.verified).ToNot(HaveKey("registry:5000/disk:v1"))
+			})
+
+			It("should pin hotplugged containerDisks to their verified digest", func() {
+				vmi := NewPendingVirtualMachine("testvmi")
+				containerDisk := &virtv1.Volume{
+					Name: "hotplug",
+					VolumeSource: virtv1.VolumeSource{
+						ContainerDisk: &virtv1.ContainerDiskSource{Image: "registry:5000/disk:v1", Hotpluggable: true},
+					},
+				}
+				pvc := &virtv1.Volume{
+					Name: "pvc",
+					VolumeSource: virtv1.VolumeSource{
+						PersistentVolumeClaim: &virtv1.PersistentVolumeClaimVolumeSource{},
+					},
+				}
+
+				mockQueue.ExpectAdds(1)
+				_, pending, syncErr := controller.verifyHotplugContainerDiskSignatures(vmi, []*virtv1.Volume{containerDisk, pvc})
+				Expect(syncErr).ToNot(HaveOccurred())
+				Expect(pending).To(BeTrue())
+				mockQueue.Wait()
+
+				volumes, pending, syncErr := controller.verifyHotplugContainerDiskSignatures(vmi, []*virtv1.Volume{containerDisk, pvc})
+				Expect(syncErr).ToNot(HaveOccurred())
+				Expect(pending).To(BeFalse())
+				Expect(volumes).To(HaveLen(2))
+				Expect(volumes[0].ContainerDisk.Image).To(Equal("registry:5000/disk" + pinnedDigest))
+				Expect(volumes[1]).To(BeIdenticalTo(pvc))
+				Expect(containerDisk.ContainerDisk.Image).To(Equal("registry:5000/disk:v1"))
+			})
+
+			It("should not verify images when the cluster does not require it", func() {
+				controller.clusterConfig, _, _ = testutils.NewFakeClusterConfigUsingKVConfig(&virtv1.KubeVirtConfiguration{})
+				vmi := newVMIWithContainerDisks()
+				addVirtualMachine(vmi)
+
+				shouldExpectPodCreation(vmi.UID)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+				Expect(verifier.verified).To(BeEmpty())
+			})
+		})
 		DescribeTable("should delete the corresponding Pods on VirtualMachineInstance deletion with vmi", func(phase virtv1.VirtualMachineInstancePhase) {
 			vmi := NewPendingVirtualMachine("testvmi")
 
@@ -2109,6 +2303,27 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			Expect(err).To(HaveOccurred())
 		})
 
+		It("CreateAttachmentPodTemplate should render a pod for hotpluggable config volumes", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Status.SelinuxContext = "system_u:system_r:container_file_t:s0:c1,c2"
+			virtlauncherPod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			addVirtualMachine(vmi)
+			podFeeder.Add(virtlauncherPod)
+			configVolume := &virtv1.Volume{
+				Name: "config",
+				VolumeSource: virtv1.VolumeSource{
+					ConfigMap: &virtv1.ConfigMapVolumeSource{
+						LocalObjectReference: k8sv1.LocalObjectReference{Name: "test-config"},
+						Hotpluggable:         true,
+					},
+				},
+			}
+			pod, err := controller.createAttachmentPodTemplate(vmi, virtlauncherPod, []*virtv1.Volume{configVolume})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pod).ToNot(BeNil())
+			Expect(pod.Spec.Volumes).To(ContainElement(HaveField("Name", "config")))
+		})
+
 		It("CreateAttachmentPodTemplate should return error if volume has PVC that doesn't exist", func() {
 			kubeClient.Fake.PrependReactor("get", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
 				return true, nil, k8serrors.NewNotFound(k8sv1.Resource("persistentvolumeclaim"), "noclaim")
@@ -2700,6 +2915,333 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 				[]string{SuccessfulCreatePodReason}),
 		)
 
+		Context("with the DirectHotplugVolumes feature gate", func() {
+			const csiDriverName = "csi.example.com"
+
+			BeforeEach(func() {
+				config, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&virtv1.KubeVirtConfiguration{
+					DeveloperConfiguration: &virtv1.DeveloperConfiguration{
+						FeatureGates: []string{virtconfig.HotplugVolumesGate, virtconfig.DirectHotplugVolumesGate},
+					},
+				})
+				controller.clusterConfig = config
+			})
+
+			prepareCSIVolume := func(index int, attachRequired *bool, accessModes ...k8sv1.PersistentVolumeAccessMode) *k8sv1.PersistentVolumeClaim {
+				preparePVC(index)
+				obj, exists, err := pvcInformer.GetStore().GetByKey(fmt.Sprintf("%s/claim%d", k8sv1.NamespaceDefault, index))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(exists).To(BeTrue())
+				pvc := obj.(*k8sv1.PersistentVolumeClaim).DeepCopy()
+				pvc.ResourceVersion = "1"
+				pvc.Spec.VolumeName = fmt.Sprintf("pv%d", index)
+				if len(accessModes) > 0 {
+					pvc.Spec.AccessModes = accessModes
+				}
+				Expect(pvcInformer.GetIndexer().Update(pvc)).To(Succeed())
+
+				Expect(pvInformer.GetIndexer().Add(&k8sv1.PersistentVolume{
+					ObjectMeta: metav1.ObjectMeta{Name: pvc.Spec.VolumeName},
+					Spec: k8sv1.PersistentVolumeSpec{
+						MountOptions: []string{"noatime"},
+						PersistentVolumeSource: k8sv1.PersistentVolumeSource{
+							CSI: &k8sv1.CSIPersistentVolumeSource{
+								Driver:           csiDriverName,
+								VolumeHandle:     fmt.Sprintf("handle%d", index),
+								FSType:           "ext4",
+								VolumeAttributes: map[string]string{"pool": "fast"},
+							},
+						},
+					},
+				})).To(Succeed())
+				Expect(csiDriverInformer.GetIndexer().Add(&storagev1.CSIDriver{
+					ObjectMeta: metav1.ObjectMeta{Name: csiDriverName},
+					Spec: storagev1.CSIDriverSpec{
+						AttachRequired: attachRequired,
+					},
+				})).To(Succeed())
+				return pvc
+			}
+
+			expectFinalizerPatch := func(claimName string, finalizers []string) {
+				kubeClient.Fake.PrependReactor("patch", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
+					patchAction, ok := action.(testing.PatchAction)
+					Expect(ok).To(BeTrue())
+					Expect(patchAction.GetName()).To(Equal(claimName))
+					Expect(patchAction.GetPatchType()).To(Equal(types.JSONPatchType))
+					expectedPatch, err := patch.GeneratePatchPayload(
+						patch.PatchOperation{Op: patch.PatchTestOp, Path: "/metadata/resourceVersion", Value: "1"},
+						patch.PatchOperation{Op: patch.PatchAddOp, Path: "/metadata/finalizers", Value: finalizers},
+					)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(patchAction.GetPatch()).To(MatchJSON(expectedPatch))
+					return true, &k8sv1.PersistentVolumeClaim{}, nil
+				})
+			}
+
+			expectedCSIVolume := func(index int) *virtv1.HotplugCSIVolumeSource {
+				return &virtv1.HotplugCSIVolumeSource{
+					Driver:           csiDriverName,
+					VolumeHandle:     fmt.Sprintf("handle%d", index),
+					FSType:           "ext4",
+					MountOptions:     []string{"noatime"},
+					VolumeAttributes: map[string]string{"pool": "fast"},
+				}
+			}
+
+			DescribeTable("should only leave the volumes needing an attachment pod", func(attachRequired *bool, expectedVolumes []*virtv1.Volume) {
+				prepareCSIVolume(1, attachRequired)
+				if len(expectedVolumes) == 0 {
+					expectFinalizerPatch("claim1", []string{"kubevirt.io/direct-hotplug-1234"})
+				}
+				vmi := NewPendingVirtualMachine("testvmi")
+
+				volumes, err := controller.withoutDirectHotplugVolumes(vmi, makeVolumes(1))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(volumes).To(Equal(expectedVolumes))
+			},
+				Entry("when the CSI driver does not require attach", pointer.Bool(false), []*virtv1.Volume{}),
+				Entry("when the CSI driver requires attach", pointer.Bool(true), makeVolumes(1)),
+				Entry("when the CSI driver does not specify attach", nil, makeVolumes(1)),
+			)
+
+			It("should keep volumes without a CSIDriver object for an attachment pod", func() {
+				prepareCSIVolume(1, pointer.Bool(false))
+				Expect(csiDriverInformer.GetIndexer().Delete(&storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{Name: csiDriverName}})).To(Succeed())
+				vmi := NewPendingVirtualMachine("testvmi")
+
+				volumes, err := controller.withoutDirectHotplugVolumes(vmi, makeVolumes(1))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(volumes).To(Equal(makeVolumes(1)))
+			})
+
+			It("should keep volumes which are not ready for an attachment pod", func() {
+				pvc := NewHotplugPVC("claim1", k8sv1.NamespaceDefault, k8sv1.ClaimPending)
+				Expect(pvcInformer.GetIndexer().Add(pvc)).To(Succeed())
+				Expect(dataVolumeInformer.GetIndexer().Add(&cdiv1.DataVolume{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "claim1",
+						Namespace: k8sv1.NamespaceDefault,
+					},
+					Status: cdiv1.DataVolumeStatus{
+						Phase: cdiv1.ImportInProgress,
+					},
+				})).To(Succeed())
+				vmi := NewPendingVirtualMachine("testvmi")
+
+				volumes, err := controller.withoutDirectHotplugVolumes(vmi, makeVolumes(1))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(volumes).To(Equal(makeVolumes(1)))
+			})
+
+			It("should not look up volumes again once they are in an attachment pod", func() {
+				vmi := NewPendingVirtualMachine("testvmi")
+				vmi.Status.VolumeStatus = makeVolumeStatusesForUpdate(1)
+
+				volumes, err := controller.withoutDirectHotplugVolumes(vmi, makeVolumes(1))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(volumes).To(Equal(makeVolumes(1)))
+			})
+
+			It("should hand the CSI volume to virt-handler in the volume status", func() {
+				prepareCSIVolume(1, pointer.Bool(false))
+				expectFinalizerPatch("claim1", []string{"kubevirt.io/direct-hotplug-1234"})
+				vmi := NewPendingVirtualMachine("testvmi")
+				for _, volume := range makeVolumes(1) {
+					vmi.Spec.Volumes = append(vmi.Spec.Volumes, *volume)
+				}
+				virtlauncherPod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+
+				Expect(controller.updateVolumeStatus(vmi, virtlauncherPod)).To(Succeed())
+				testutils.ExpectEvent(recorder, DirectHotplugVolumeReason)
+				Expect(vmi.Status.VolumeStatus).To(HaveLen(1))
+				status := vmi.Status.VolumeStatus[0]
+				Expect(status.Phase).To(Equal(virtv1.HotplugVolumeAttachedToNode))
+				Expect(status.Reason).To(Equal(DirectHotplugVolumeReason))
+				Expect(status.HotplugVolume.AttachPodName).To(BeEmpty())
+				Expect(status.HotplugVolume.CSIVolume).To(Equal(expectedCSIVolume(1)))
+
+				By("keeping the status without looking up the volume again")
+				kubeClient.Fake.ClearActions()
+				Expect(controller.updateVolumeStatus(vmi, virtlauncherPod)).To(Succeed())
+				Expect(kubeClient.Fake.Actions()).To(BeEmpty())
+				Expect(vmi.Status.VolumeStatus[0].HotplugVolume.CSIVolume).To(Equal(expectedCSIVolume(1)))
+			})
+
+			It("should not mark a claim again which is already marked by the VMI", func() {
+				pvc := prepareCSIVolume(1, pointer.Bool(false))
+				pvc.Finalizers = []string{"kubevirt.io/direct-hotplug-1234"}
+				Expect(pvcInformer.GetIndexer().Update(pvc)).To(Succeed())
+				vmi := NewPendingVirtualMachine("testvmi")
+
+				volumes, err := controller.withoutDirectHotplugVolumes(vmi, makeVolumes(1))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(volumes).To(BeEmpty())
+			})
+
+			Context("with a single writer claim", func() {
+				const otherNode = "othernode"
+
+				newVMI := func() *virtv1.VirtualMachineInstance {
+					vmi := NewPendingVirtualMachine("testvmi")
+					vmi.Status.NodeName = "node"
+					for _, volume := range makeVolumes(1) {
+						vmi.Spec.Volumes = append(vmi.Spec.Volumes, *volume)
+					}
+					return vmi
+				}
+
+				addPodUsingClaim := func(nodeName string, phase k8sv1.PodPhase) {
+					Expect(podInformer.GetIndexer().Add(&k8sv1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "user", Namespace: k8sv1.NamespaceDefault},
+						Spec: k8sv1.PodSpec{
+							NodeName: nodeName,
+							Volumes: []k8sv1.Volume{{
+								Name: "data",
+								VolumeSource: k8sv1.VolumeSource{
+									PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "claim1"},
+								},
+							}},
+						},
+						Status: k8sv1.PodStatus{Phase: phase},
+					})).To(Succeed())
+				}
+
+				addVMIUsingClaim := func(pvc *k8sv1.PersistentVolumeClaim, nodeName string) {
+					other := api.NewMinimalVMI("othervmi")
+					other.UID = "5678"
+					other.Status.NodeName = nodeName
+					Expect(vmiInformer.GetIndexer().Add(other)).To(Succeed())
+					pvc.Finalizers = []string{"kubevirt.io/direct-hotplug-5678"}
+					Expect(pvcInformer.GetIndexer().Update(pvc)).To(Succeed())
+				}
+
+				DescribeTable("should refuse to hotplug the volume in use elsewhere", func(accessMode k8sv1.PersistentVolumeAccessMode, setUser func(*k8sv1.PersistentVolumeClaim), expectedUser string) {
+					pvc := prepareCSIVolume(1, pointer.Bool(false), accessMode)
+					setUser(pvc)
+					vmi := newVMI()
+
+					volumes, err := controller.withoutDirectHotplugVolumes(vmi, makeVolumes(1))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(volumes).To(BeEmpty())
+
+					Expect(controller.updateVolumeStatus(vmi, NewPodForVirtualMachine(vmi, k8sv1.PodRunning))).To(Succeed())
+					testutils.ExpectEvent(recorder, DirectHotplugVolumeInUseReason)
+					Expect(vmi.Status.VolumeStatus).To(HaveLen(1))
+					status := vmi.Status.VolumeStatus[0]
+					Expect(status.Phase).To(Equal(virtv1.VolumePending))
+					Expect(status.Reason).To(Equal(DirectHotplugVolumeInUseReason))
+					Expect(status.Message).To(ContainSubstring(expectedUser))
+					Expect(status.HotplugVolume.CSIVolume).To(BeNil())
+				},
+					Entry("by a pod on another node", k8sv1.ReadWriteOnce, func(*k8sv1.PersistentVolumeClaim) {
+						addPodUsingClaim(otherNode, k8sv1.PodRunning)
+					}, "pod user"),
+					Entry("by a pod on the same node for ReadWriteOncePod", k8sv1.ReadWriteOncePod, func(*k8sv1.PersistentVolumeClaim) {
+						addPodUsingClaim("node", k8sv1.PodRunning)
+					}, "pod user"),
+					Entry("by a VMI on another node", k8sv1.ReadWriteOnce, func(pvc *k8sv1.PersistentVolumeClaim) {
+						addVMIUsingClaim(pvc, otherNode)
+					}, "VMI othervmi"),
+					Entry("by a VMI on the same node for ReadWriteOncePod", k8sv1.ReadWriteOncePod, func(pvc *k8sv1.PersistentVolumeClaim) {
+						addVMIUsingClaim(pvc, "node")
+					}, "VMI othervmi"),
+				)
+
+				DescribeTable("should hotplug the volume", func(accessMode k8sv1.PersistentVolumeAccessMode, setUser func(*k8sv1.PersistentVolumeClaim), expectedFinalizers []string) {
+					pvc := prepareCSIVolume(1, pointer.Bool(false), accessMode)
+					setUser(pvc)
+					expectFinalizerPatch("claim1", expectedFinalizers)
+
+					volumes, err := controller.withoutDirectHotplugVolumes(newVMI(), makeVolumes(1))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(volumes).To(BeEmpty())
+				},
+					Entry("used by a pod on the same node", k8sv1.ReadWriteOnce, func(*k8sv1.PersistentVolumeClaim) {
+						addPodUsingClaim("node", k8sv1.PodRunning)
+					}, []string{"kubevirt.io/direct-hotplug-1234"}),
+					Entry("used by a finished pod", k8sv1.ReadWriteOncePod, func(*k8sv1.PersistentVolumeClaim) {
+						addPodUsingClaim(otherNode, k8sv1.PodSucceeded)
+					}, []string{"kubevirt.io/direct-hotplug-1234"}),
+					Entry("used by a VMI on the same node", k8sv1.ReadWriteOnce, func(pvc *k8sv1.PersistentVolumeClaim) {
+						addVMIUsingClaim(pvc, "node")
+					}, []string{"kubevirt.io/direct-hotplug-5678", "kubevirt.io/direct-hotplug-1234"}),
+				)
+			})
+
+			Context("releasing claims", func() {
+				markedPVC := func(name string) *k8sv1.PersistentVolumeClaim {
+					pvc := NewHotplugPVC(name, k8sv1.NamespaceDefault, k8sv1.ClaimBound)
+					pvc.ResourceVersion = "1"
+					pvc.Finalizers = []string{"kubernetes.io/pvc-protection", "kubevirt.io/direct-hotplug-1234"}
+					Expect(pvcInformer.GetIndexer().Add(pvc)).To(Succeed())
+					return pvc
+				}
+
+				stagedVMI := func() *virtv1.VirtualMachineInstance {
+					vmi := NewPendingVirtualMachine("testvmi")
+					vmi.Status.Phase = virtv1.Running
+					for _, volume := range makeVolumes(1) {
+						vmi.Spec.Volumes = append(vmi.Spec.Volumes, *volume)
+					}
+					vmi.Status.VolumeStatus = []virtv1.VolumeStatus{{
+						Name:          "volume1",
+						HotplugVolume: &virtv1.HotplugVolumeStatus{CSIVolume: expectedCSIVolume(1)},
+					}}
+					return vmi
+				}
+
+				It("should keep the finalizer on claims staged by the VMI", func() {
+					markedPVC("claim1")
+
+					Expect(controller.releaseDirectHotplugClaims(stagedVMI())).To(Succeed())
+					Expect(kubeClient.Fake.Actions()).To(BeEmpty())
+				})
+
+				It("should remove the finalizer from claims no longer staged by the VMI", func() {
+					markedPVC("claim1")
+					markedPVC("claim2")
+					expectFinalizerPatch("claim2", []string{"kubernetes.io/pvc-protection"})
+
+					Expect(controller.releaseDirectHotplugClaims(stagedVMI())).To(Succeed())
+					Expect(kubeClient.Fake.Actions()).To(HaveLen(1))
+				})
+
+				It("should keep the finalizer of an unplugged claim until virt-handler removed its status", func() {
+					pvc := prepareCSIVolume(1, pointer.Bool(false))
+					pvc.Finalizers = []string{"kubernetes.io/pvc-protection", "kubevirt.io/direct-hotplug-1234"}
+					Expect(pvcInformer.GetIndexer().Update(pvc)).To(Succeed())
+					vmi := stagedVMI()
+					vmi.Spec.Volumes = nil
+					virtlauncherPod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+
+					By("Keeping the status as detaching while the volume is unpublished")
+					Expect(controller.updateVolumeStatus(vmi, virtlauncherPod)).To(Succeed())
+					Expect(vmi.Status.VolumeStatus).To(HaveLen(1))
+					Expect(vmi.Status.VolumeStatus[0].Phase).To(Equal(virtv1.HotplugVolumeDetaching))
+					Expect(vmi.Status.VolumeStatus[0].HotplugVolume.CSIVolume).To(Equal(expectedCSIVolume(1)))
+					Expect(controller.releaseDirectHotplugClaims(vmi)).To(Succeed())
+					Expect(kubeClient.Fake.Actions()).To(BeEmpty())
+
+					By("Removing the finalizer once virt-handler removed the status")
+					vmi.Status.VolumeStatus = nil
+					expectFinalizerPatch("claim1", []string{"kubernetes.io/pvc-protection"})
+					Expect(controller.releaseDirectHotplugClaims(vmi)).To(Succeed())
+					Expect(kubeClient.Fake.Actions()).To(HaveLen(1))
+				})
+
+				It("should remove the finalizer from all claims once the VMI is final", func() {
+					markedPVC("claim1")
+					expectFinalizerPatch("claim1", []string{"kubernetes.io/pvc-protection"})
+					vmi := stagedVMI()
+					vmi.Status.Phase = virtv1.Succeeded
+
+					Expect(controller.releaseDirectHotplugClaims(vmi)).To(Succeed())
+					Expect(kubeClient.Fake.Actions()).To(HaveLen(1))
+				})
+			})
+		})
+
 		It("Should get default filesystem overhead if there are multiple CDI instances", func() {
 			cdi := cdiv1.CDI{
 				ObjectMeta: metav1.ObjectMeta{
@@ -3660,3 +4202,30 @@ func newVMIWithGuestAgentInterface(vmi *virtv1.VirtualMachineInstance, ifaceName
 	})
 	return vmi
 }
+
+const testPublicKey = `-----BEGIN PUBLIC KEY-----
+MCowBQYDK2VwAyEAXJRSKksv9iR9r6Yh1n4Z3QvXro/SuhTrOyT9qQzAnPc=
+-----END PUBLIC KEY-----`
+
+type fakeImageVerifier struct {
+	lock     sync.Mutex
+	verified map[string]signature.VerifyOptions
+	calls    int
+	err      error
+	// block holds the verifications back until it is closed
+	block chan struct{}
+}
+
+func (v *fakeImageVerifier) Verify(_ context.Context, image string, opts signature.VerifyOptions) (string, error) {
+	if v.block != nil {
+		<-v.block
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.calls++
+	if v.err != nil {
+		return "", v.err
+	}
+	v.verified[image] = opts
+	return strings.Split(image, ":v1")[0] + "@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", nil
+}
@@ -87,6 +87,7 @@ type LauncherClient interface {
 	UnpauseVirtualMachine(vmi *v1.VirtualMachineInstance) error
 	FreezeVirtualMachine(vmi *v1.VirtualMachineInstance, unfreezeTimeoutSeconds int32) error
 	UnfreezeVirtualMachine(vmi *v1.VirtualMachineInstance) error
+	GuestFSTrim(vmi *v1.VirtualMachineInstance) ([]api.FSTrimmed, error)
 	SyncMigrationTarget(vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) error
 	SoftRebootVirtualMachine(vmi *v1.VirtualMachineInstance) error
 	SignalTargetPodCleanup(vmi *v1.VirtualMachineInstance) error
@@ -109,6 +110,7 @@ type LauncherClient interface {
 	VirtualMachineMemoryDump(vmi *v1.VirtualMachineInstance, dumpPath string) error
 	GetQemuVersion() (string, error)
 	SyncVirtualMachineCPUs(vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) error
+	SyncVirtualMachineIOTune(vmi *v1.VirtualMachineInstance) error
 	GetSEVInfo() (*v1.SEVPlatformInfo, error)
 	GetLaunchMeasurement(*v1.VirtualMachineInstance) (*v1.SEVMeasurementInfo, error)
 	InjectLaunchSecret(*v1.VirtualMachineInstance, *v1.SEVSecretOptions) error
@@ -122,6 +124,8 @@ type VirtLauncherClient struct {
 const (
 	shortTimeout time.Duration = 5 * time.Second
 	longTimeout  time.Duration = 20 * time.Second
+	// guest-fstrim walks every mounted filesystem of the guest and may take minutes on large disks
+	fsTrimTimeout time.Duration = 10 * time.Minute
 )
 
 func SetLegacyBaseDir(baseDir string) {
@@ -465,6 +469,43 @@ func (c *VirtLauncherClient) UnfreezeVirtualMachine(vmi *v1.VirtualMachineInstan
 	return c.genericSendVMICmd("Unfreeze", c.v1client.UnfreezeVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
 }
 
+// GuestFSTrim trims the guest filesystems and returns the bytes trimmed on each of them
+func (c *VirtLauncherClient) GuestFSTrim(vmi *v1.VirtualMachineInstance) ([]api.FSTrimmed, error) {
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &cmdv1.VMIRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), fsTrimTimeout)
+	defer cancel()
+
+	trimResponse, err := c.v1client.GuestFSTrim(ctx, request)
+	var response *cmdv1.Response
+	if trimResponse != nil {
+		response = trimResponse.Response
+	}
+
+	if err = handleError(err, "GuestFSTrim", response); err != nil {
+		return nil, err
+	}
+
+	trimmed := []api.FSTrimmed{}
+	if trimResponse.GetGuestFSTrimResponse() != "" {
+		if err := json.Unmarshal([]byte(trimResponse.GetGuestFSTrimResponse()), &trimmed); err != nil {
+			log.Log.Reason(err).Error("error unmarshalling guest fstrim response")
+			return nil, err
+		}
+	}
+
+	return trimmed, nil
+}
+
 func (c *VirtLauncherClient) VirtualMachineMemoryDump(vmi *v1.VirtualMachineInstance, dumpPath string) error {
 	vmiJson, err := json.Marshal(vmi)
 	if err != nil {
@@ -541,6 +582,10 @@ func (c *VirtLauncherClient) SyncVirtualMachineCPUs(vmi *v1.VirtualMachineInstan
 	return c.genericSendVMICmd("SyncVirtualMachineCPUs", c.v1client.SyncVirtualMachineCPUs, vmi, options)
 }
 
+func (c *VirtLauncherClient) SyncVirtualMachineIOTune(vmi *v1.VirtualMachineInstance) error {
+	return c.genericSendVMICmd("SyncVirtualMachineIOTune", c.v1client.SyncVirtualMachineIOTune, vmi, &cmdv1.VirtualMachineOptions{})
+}
+
 func (c *VirtLauncherClient) SignalTargetPodCleanup(vmi *v1.VirtualMachineInstance) error {
 	return c.genericSendVMICmd("SignalTargetPodCleanup", c.v1client.SignalTargetPodCleanup, vmi, &cmdv1.VirtualMachineOptions{})
 }
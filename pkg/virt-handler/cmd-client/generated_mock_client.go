@@ -83,6 +83,17 @@ func (_mr *_MockLauncherClientRecorder) UnfreezeVirtualMachine(arg0 interface{})
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "UnfreezeVirtualMachine", arg0)
 }
 
+func (_m *MockLauncherClient) GuestFSTrim(vmi *v1.VirtualMachineInstance) ([]api.FSTrimmed, error) {
+	ret := _m.ctrl.Call(_m, "GuestFSTrim", vmi)
+	ret0, _ := ret[0].([]api.FSTrimmed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockLauncherClientRecorder) GuestFSTrim(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GuestFSTrim", arg0)
+}
+
 func (_m *MockLauncherClient) SyncMigrationTarget(vmi *v1.VirtualMachineInstance, options *v10.VirtualMachineOptions) error {
 	ret := _m.ctrl.Call(_m, "SyncMigrationTarget", vmi, options)
 	ret0, _ := ret[0].(error)
@@ -311,6 +322,16 @@ func (_mr *_MockLauncherClientRecorder) SyncVirtualMachineCPUs(arg0, arg1 interf
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "SyncVirtualMachineCPUs", arg0, arg1)
 }
 
+func (_m *MockLauncherClient) SyncVirtualMachineIOTune(vmi *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "SyncVirtualMachineIOTune", vmi)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockLauncherClientRecorder) SyncVirtualMachineIOTune(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SyncVirtualMachineIOTune", arg0)
+}
+
 func (_m *MockLauncherClient) GetSEVInfo() (*v1.SEVPlatformInfo, error) {
 	ret := _m.ctrl.Call(_m, "GetSEVInfo")
 	ret0, _ := ret[0].(*v1.SEVPlatformInfo)
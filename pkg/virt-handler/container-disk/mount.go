@@ -228,7 +228,7 @@ func (m *mounter) MountAndVerify(vmi *v1.VirtualMachineInstance) (map[string]*co
 	disksInfo := map[string]*containerdisk.DiskInfo{}
 
 	for i, volume := range vmi.Spec.Volumes {
-		if volume.ContainerDisk != nil {
+		if volume.ContainerDisk != nil && !volume.ContainerDisk.Hotpluggable {
 			diskTargetDir, err := containerdisk.GetDiskTargetDirFromHostView(vmi)
 			if err != nil {
 				return nil, err
@@ -270,7 +270,7 @@ func (m *mounter) MountAndVerify(vmi *v1.VirtualMachineInstance) (map[string]*co
 	}
 
 	for i, volume := range vmi.Spec.Volumes {
-		if volume.ContainerDisk != nil {
+		if volume.ContainerDisk != nil && !volume.ContainerDisk.Hotpluggable {
 			diskTargetDir, err := containerdisk.GetDiskTargetDirFromHostView(vmi)
 			if err != nil {
 				return nil, err
@@ -382,7 +382,7 @@ func (m *mounter) Unmount(vmi *v1.VirtualMachineInstance) error {
 
 func (m *mounter) ContainerDisksReady(vmi *v1.VirtualMachineInstance, notInitializedSince time.Time) (bool, error) {
 	for i, volume := range vmi.Spec.Volumes {
-		if volume.ContainerDisk != nil {
+		if volume.ContainerDisk != nil && !volume.ContainerDisk.Hotpluggable {
 			_, err := m.socketPathGetter(vmi, i)
 			if err != nil {
 				log.DefaultLogger().Object(vmi).Reason(err).Infof("containerdisk %s not yet ready", volume.Name)
@@ -0,0 +1,60 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package fstrim
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	TrimmedBytesMetricName = "kubevirt_vmi_filesystem_trimmed_bytes_total"
+	TrimsMetricName        = "kubevirt_vmi_filesystem_trims_total"
+
+	resultSucceeded = "succeeded"
+	resultFailed    = "failed"
+)
+
+var (
+	trimmedBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: TrimmedBytesMetricName,
+			Help: "The total amount of unused space returned to the storage by trimming the guest filesystem, in bytes.",
+		},
+		[]string{"namespace", "name", "mountpoint"},
+	)
+
+	trims = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: TrimsMetricName,
+			Help: "The total number of trims of the guest filesystems of the VMI.",
+		},
+		[]string{"namespace", "name", "result"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(trimmedBytes, trims)
+}
+
+func deleteMetrics(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+	trimmedBytes.DeletePartialMatch(labels)
+	trims.DeletePartialMatch(labels)
+}
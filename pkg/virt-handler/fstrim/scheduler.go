@@ -0,0 +1,300 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package fstrim
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	"kubevirt.io/kubevirt/pkg/controller"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+const (
+	// DefaultInterval is used for VMIs which don't set their own trim interval
+	DefaultInterval = 24 * time.Hour
+	// checkInterval is how often the VMIs are checked for a due trim
+	checkInterval = time.Minute
+
+	FilesystemsTrimmedReason   = "FilesystemsTrimmed"
+	FilesystemTrimFailedReason = "FilesystemTrimFailed"
+)
+
+type trimState struct {
+	namespace string
+	name      string
+	lastTrim  time.Time
+}
+
+// Scheduler trims the guest filesystems of the VMIs running on this node, periodically for the
+// VMIs which have a filesystem trim policy and on demand for any of them.
+// Trims are processed one at a time, to not put the storage of the node under pressure.
+type Scheduler struct {
+	recorder       record.EventRecorder
+	virtClient     kubecli.KubevirtClient
+	vmiStore       cache.Store
+	clusterConfig  *virtconfig.ClusterConfig
+	queue          workqueue.Interface
+	clock          clock.Clock
+	launcherClient func(vmi *v1.VirtualMachineInstance) (cmdclient.LauncherClient, error)
+
+	lock  sync.Mutex
+	state map[types.UID]*trimState
+}
+
+func NewScheduler(recorder record.EventRecorder, virtClient kubecli.KubevirtClient, vmiStore cache.Store, clusterConfig *virtconfig.ClusterConfig) *Scheduler {
+	return &Scheduler{
+		recorder:       recorder,
+		virtClient:     virtClient,
+		vmiStore:       vmiStore,
+		clusterConfig:  clusterConfig,
+		queue:          workqueue.NewNamed("virt-handler-fstrim"),
+		clock:          clock.RealClock{},
+		launcherClient: newLauncherClient,
+		state:          map[types.UID]*trimState{},
+	}
+}
+
+func newLauncherClient(vmi *v1.VirtualMachineInstance) (cmdclient.LauncherClient, error) {
+	sockFile, err := cmdclient.FindSocketOnHost(vmi)
+	if err != nil {
+		return nil, err
+	}
+	return cmdclient.NewClient(sockFile)
+}
+
+// Run checks every minute for VMIs which are due for a trim and processes the trims until stopCh is closed
+func (s *Scheduler) Run(stopCh <-chan struct{}) {
+	defer s.queue.ShutDown()
+
+	log.Log.Info("Starting filesystem trim scheduler")
+	go wait.Until(s.runWorker, time.Second, stopCh)
+	wait.Until(s.schedule, checkInterval, stopCh)
+	log.Log.Info("Stopping filesystem trim scheduler")
+}
+
+// TrimNow queues a trim of the guest filesystems of the VMI, independently from its trim policy
+func (s *Scheduler) TrimNow(vmi *v1.VirtualMachineInstance) error {
+	if err := canTrim(vmi); err != nil {
+		return err
+	}
+	key, err := controller.KeyFunc(vmi)
+	if err != nil {
+		return err
+	}
+	s.queue.Add(key)
+	return nil
+}
+
+func canTrim(vmi *v1.VirtualMachineInstance) error {
+	if !vmi.IsRunning() {
+		return fmt.Errorf("VMI is not running")
+	}
+	if vmi.Status.MigrationState != nil && !vmi.Status.MigrationState.Completed {
+		return fmt.Errorf("VMI is migrating")
+	}
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if !condManager.HasConditionWithStatus(vmi, v1.VirtualMachineInstanceAgentConnected, k8sv1.ConditionTrue) {
+		return fmt.Errorf("VMI does not have guest agent connected")
+	}
+	return nil
+}
+
+func trimInterval(vmi *v1.VirtualMachineInstance) time.Duration {
+	if vmi.Spec.FilesystemTrim.Interval != nil {
+		return vmi.Spec.FilesystemTrim.Interval.Duration
+	}
+	return DefaultInterval
+}
+
+// lastTrimTime returns the last trim time recorded in the status of the VMI, if any
+func lastTrimTime(vmi *v1.VirtualMachineInstance) (time.Time, bool) {
+	if vmi.Status.FilesystemTrim == nil || vmi.Status.FilesystemTrim.LastTrimTime == nil {
+		return time.Time{}, false
+	}
+	return vmi.Status.FilesystemTrim.LastTrimTime.Time, true
+}
+
+// schedule queues the VMIs whose last trim is older than their interval.
+// The last trim is taken from the VMI status, so that the schedule survives virt-handler restarts.
+// A VMI which was never trimmed waits a full interval from when it is first seen,
+// so that the VMIs of a node are not all trimmed at once.
+func (s *Scheduler) schedule() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.clock.Now()
+	for _, obj := range s.vmiStore.List() {
+		vmi := obj.(*v1.VirtualMachineInstance)
+		if !s.clusterConfig.FilesystemTrimEnabled() || vmi.Spec.FilesystemTrim == nil || canTrim(vmi) != nil {
+			continue
+		}
+		state, exists := s.state[vmi.UID]
+		if !exists {
+			state = &trimState{namespace: vmi.Namespace, name: vmi.Name, lastTrim: now}
+			s.state[vmi.UID] = state
+		}
+		// The status lags behind the trims of this virt-handler until the VMI store catches up
+		lastTrim := state.lastTrim
+		if recorded, ok := lastTrimTime(vmi); ok && (!exists || recorded.After(lastTrim)) {
+			lastTrim = recorded
+			state.lastTrim = recorded
+		}
+		if now.Sub(lastTrim) < trimInterval(vmi) {
+			continue
+		}
+		key, err := controller.KeyFunc(vmi)
+		if err != nil {
+			log.Log.Object(vmi).Reason(err).Error("Failed to schedule the filesystem trim")
+			continue
+		}
+		s.queue.Add(key)
+	}
+	s.prune()
+}
+
+// prune forgets the VMIs which are gone from this node, together with their metrics
+func (s *Scheduler) prune() {
+	existing := map[types.UID]struct{}{}
+	for _, obj := range s.vmiStore.List() {
+		existing[obj.(*v1.VirtualMachineInstance).UID] = struct{}{}
+	}
+	for uid, state := range s.state {
+		if _, exists := existing[uid]; !exists {
+			deleteMetrics(state.namespace, state.name)
+			delete(s.state, uid)
+		}
+	}
+}
+
+func (s *Scheduler) runWorker() {
+	for s.Execute() {
+	}
+}
+
+// Execute processes one queued trim, it returns false once the queue is shut down
+func (s *Scheduler) Execute() bool {
+	key, quit := s.queue.Get()
+	if quit {
+		return false
+	}
+	defer s.queue.Done(key)
+
+	obj, exists, err := s.vmiStore.GetByKey(key.(string))
+	if err != nil {
+		log.Log.Reason(err).Errorf("Failed to get VMI %s for the filesystem trim", key)
+		return true
+	}
+	if !exists {
+		return true
+	}
+	s.trim(obj.(*v1.VirtualMachineInstance))
+	return true
+}
+
+func (s *Scheduler) trim(vmi *v1.VirtualMachineInstance) {
+	// A failed trim is not retried before the next interval, the failure is most likely
+	// permanent, e.g. the guest agent doesn't support guest-fstrim
+	now := s.clock.Now()
+	s.lock.Lock()
+	s.state[vmi.UID] = &trimState{namespace: vmi.Namespace, name: vmi.Name, lastTrim: now}
+	s.lock.Unlock()
+
+	if err := canTrim(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Info("Skipping the filesystem trim")
+		return
+	}
+
+	log.Log.Object(vmi).Info("Trimming the guest filesystems")
+	trimmed, err := s.guestFSTrim(vmi)
+	if patchErr := s.recordLastTrimTime(vmi, now); patchErr != nil {
+		log.Log.Object(vmi).Reason(patchErr).Error("Failed to record the last filesystem trim time")
+	}
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to trim the guest filesystems")
+		s.recorder.Eventf(vmi, k8sv1.EventTypeWarning, FilesystemTrimFailedReason, "Failed to trim the guest filesystems: %v", err)
+		trims.WithLabelValues(vmi.Namespace, vmi.Name, resultFailed).Inc()
+		return
+	}
+	s.report(vmi, trimmed)
+}
+
+func (s *Scheduler) recordLastTrimTime(vmi *v1.VirtualMachineInstance, lastTrim time.Time) error {
+	patchBytes, err := patch.GeneratePatchPayload(patch.PatchOperation{
+		Op:    patch.PatchAddOp,
+		Path:  "/status/filesystemTrim",
+		Value: v1.FilesystemTrimStatus{LastTrimTime: &metav1.Time{Time: lastTrim}},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = s.virtClient.VirtualMachineInstance(vmi.Namespace).Patch(context.Background(), vmi.Name, types.JSONPatchType, patchBytes, &metav1.PatchOptions{})
+	return err
+}
+
+func (s *Scheduler) guestFSTrim(vmi *v1.VirtualMachineInstance) ([]api.FSTrimmed, error) {
+	client, err := s.launcherClient(vmi)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	return client.GuestFSTrim(vmi)
+}
+
+func (s *Scheduler) report(vmi *v1.VirtualMachineInstance, trimmed []api.FSTrimmed) {
+	var succeeded, failed []string
+	for _, fs := range trimmed {
+		if fs.Error != "" {
+			failed = append(failed, fmt.Sprintf("%s: %s", fs.Mountpoint, fs.Error))
+			continue
+		}
+		succeeded = append(succeeded, fmt.Sprintf("%s: %d bytes", fs.Mountpoint, fs.TrimmedBytes))
+		trimmedBytes.WithLabelValues(vmi.Namespace, vmi.Name, fs.Mountpoint).Add(float64(fs.TrimmedBytes))
+	}
+
+	if len(succeeded) > 0 {
+		s.recorder.Eventf(vmi, k8sv1.EventTypeNormal, FilesystemsTrimmedReason, "Trimmed the guest filesystems, %s", strings.Join(succeeded, ", "))
+	}
+	if len(failed) > 0 {
+		s.recorder.Eventf(vmi, k8sv1.EventTypeWarning, FilesystemTrimFailedReason, "Failed to trim guest filesystems, %s", strings.Join(failed, ", "))
+		trims.WithLabelValues(vmi.Namespace, vmi.Name, resultFailed).Inc()
+		return
+	}
+	trims.WithLabelValues(vmi.Namespace, vmi.Name, resultSucceeded).Inc()
+}
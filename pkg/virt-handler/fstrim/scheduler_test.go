@@ -0,0 +1,308 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package fstrim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+var _ = Describe("Filesystem trim scheduler", func() {
+	var (
+		scheduler *Scheduler
+		store     cache.Store
+		recorder  *record.FakeRecorder
+		fakeClock *clocktesting.FakeClock
+		client    *cmdclient.MockLauncherClient
+		vmiClient *kubecli.MockVirtualMachineInstanceInterface
+	)
+
+	newVMI := func(name string, filesystemTrim *v1.FilesystemTrim) *v1.VirtualMachineInstance {
+		return &v1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name)},
+			Spec:       v1.VirtualMachineInstanceSpec{FilesystemTrim: filesystemTrim},
+			Status: v1.VirtualMachineInstanceStatus{
+				Phase: v1.Running,
+				Conditions: []v1.VirtualMachineInstanceCondition{
+					{Type: v1.VirtualMachineInstanceAgentConnected, Status: k8sv1.ConditionTrue},
+				},
+			},
+		}
+	}
+
+	counterValue := func(counter *io_prometheus_client.Metric) float64 {
+		return counter.GetCounter().GetValue()
+	}
+
+	trimmedBytesValue := func(name, mountpoint string) float64 {
+		metric := &io_prometheus_client.Metric{}
+		Expect(trimmedBytes.WithLabelValues("default", name, mountpoint).Write(metric)).To(Succeed())
+		return counterValue(metric)
+	}
+
+	trimsValue := func(name, result string) float64 {
+		metric := &io_prometheus_client.Metric{}
+		Expect(trims.WithLabelValues("default", name, result).Write(metric)).To(Succeed())
+		return counterValue(metric)
+	}
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		client = cmdclient.NewMockLauncherClient(ctrl)
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		vmiClient = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+		virtClient.EXPECT().VirtualMachineInstance("default").Return(vmiClient).AnyTimes()
+		store = cache.NewStore(cache.MetaNamespaceKeyFunc)
+		recorder = record.NewFakeRecorder(10)
+		recorder.IncludeObject = true
+		fakeClock = clocktesting.NewFakeClock(time.Now())
+
+		clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			DeveloperConfiguration: &v1.DeveloperConfiguration{
+				FeatureGates: []string{virtconfig.FilesystemTrimGate},
+			},
+		})
+		scheduler = NewScheduler(recorder, virtClient, store, clusterConfig)
+		scheduler.clock = fakeClock
+		scheduler.launcherClient = func(_ *v1.VirtualMachineInstance) (cmdclient.LauncherClient, error) {
+			return client, nil
+		}
+		DeferCleanup(func() {
+			trimmedBytes.Reset()
+			trims.Reset()
+		})
+	})
+
+	expectTrim := func(vmi *v1.VirtualMachineInstance, trimmed []api.FSTrimmed, err error) {
+		client.EXPECT().GuestFSTrim(vmi).Return(trimmed, err)
+		client.EXPECT().Close()
+		vmiClient.EXPECT().Patch(context.Background(), vmi.Name, types.JSONPatchType, gomock.Any(), gomock.Any()).Return(vmi, nil)
+	}
+
+	withLastTrimTime := func(vmi *v1.VirtualMachineInstance, lastTrim time.Time) *v1.VirtualMachineInstance {
+		vmi.Status.FilesystemTrim = &v1.FilesystemTrimStatus{LastTrimTime: &metav1.Time{Time: lastTrim}}
+		return vmi
+	}
+
+	It("should wait a full interval before the first trim of a VMI", func() {
+		vmi := newVMI("testvmi", &v1.FilesystemTrim{})
+		Expect(store.Add(vmi)).To(Succeed())
+
+		scheduler.schedule()
+		Expect(scheduler.queue.Len()).To(BeZero())
+
+		fakeClock.Step(DefaultInterval - time.Minute)
+		scheduler.schedule()
+		Expect(scheduler.queue.Len()).To(BeZero())
+
+		fakeClock.Step(time.Minute)
+		scheduler.schedule()
+		Expect(scheduler.queue.Len()).To(Equal(1))
+	})
+
+	It("should trim with the interval of the VMI and report the trimmed bytes", func() {
+		vmi := newVMI("testvmi", &v1.FilesystemTrim{Interval: &metav1.Duration{Duration: 2 * time.Hour}})
+		Expect(store.Add(vmi)).To(Succeed())
+		scheduler.schedule()
+
+		fakeClock.Step(2 * time.Hour)
+		scheduler.schedule()
+		expectTrim(vmi, []api.FSTrimmed{
+			{Mountpoint: "/", TrimmedBytes: 4096},
+			{Mountpoint: "/boot", TrimmedBytes: 1024},
+		}, nil)
+		Expect(scheduler.Execute()).To(BeTrue())
+
+		Expect(recorder.Events).To(Receive(ContainSubstring("FilesystemsTrimmed Trimmed the guest filesystems, /: 4096 bytes, /boot: 1024 bytes")))
+		Expect(trimmedBytesValue("testvmi", "/")).To(Equal(4096.0))
+		Expect(trimmedBytesValue("testvmi", "/boot")).To(Equal(1024.0))
+		Expect(trimsValue("testvmi", resultSucceeded)).To(Equal(1.0))
+
+		By("not trimming again before the next interval")
+		fakeClock.Step(time.Hour)
+		scheduler.schedule()
+		Expect(scheduler.queue.Len()).To(BeZero())
+	})
+
+	It("should schedule from the last trim time recorded in the VMI status", func() {
+		vmi := withLastTrimTime(newVMI("testvmi", &v1.FilesystemTrim{}), fakeClock.Now().Add(-DefaultInterval+time.Hour))
+		Expect(store.Add(vmi)).To(Succeed())
+
+		scheduler.schedule()
+		Expect(scheduler.queue.Len()).To(BeZero())
+
+		fakeClock.Step(time.Hour)
+		scheduler.schedule()
+		Expect(scheduler.queue.Len()).To(Equal(1))
+	})
+
+	It("should trim a VMI right away if its last recorded trim is older than its interval", func() {
+		vmi := withLastTrimTime(newVMI("testvmi", &v1.FilesystemTrim{}), fakeClock.Now().Add(-DefaultInterval))
+		Expect(store.Add(vmi)).To(Succeed())
+
+		scheduler.schedule()
+		Expect(scheduler.queue.Len()).To(Equal(1))
+	})
+
+	It("should record the last trim time in the VMI status", func() {
+		vmi := newVMI("testvmi", nil)
+		Expect(store.Add(vmi)).To(Succeed())
+		client.EXPECT().GuestFSTrim(vmi).Return([]api.FSTrimmed{{Mountpoint: "/"}}, nil)
+		client.EXPECT().Close()
+		vmiClient.EXPECT().Patch(context.Background(), vmi.Name, types.JSONPatchType, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, _ types.PatchType, data []byte, _ *metav1.PatchOptions, _ ...string) (*v1.VirtualMachineInstance, error) {
+				lastTrim, err := json.Marshal(metav1.Time{Time: fakeClock.Now()})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(data)).To(Equal(`[{"op":"add","path":"/status/filesystemTrim","value":{"lastTrimTime":` + string(lastTrim) + `}}]`))
+				return vmi, nil
+			})
+
+		Expect(scheduler.TrimNow(vmi)).To(Succeed())
+		Expect(scheduler.Execute()).To(BeTrue())
+	})
+
+	It("should not go back to an older trim time until the VMI status catches up", func() {
+		vmi := withLastTrimTime(newVMI("testvmi", &v1.FilesystemTrim{}), fakeClock.Now().Add(-DefaultInterval))
+		Expect(store.Add(vmi)).To(Succeed())
+		expectTrim(vmi, []api.FSTrimmed{{Mountpoint: "/"}}, nil)
+		Expect(scheduler.TrimNow(vmi)).To(Succeed())
+		Expect(scheduler.Execute()).To(BeTrue())
+
+		scheduler.schedule()
+		Expect(scheduler.queue.Len()).To(BeZero())
+	})
+
+	It("should report the filesystems which could not be trimmed", func() {
+		vmi := newVMI("testvmi", &v1.FilesystemTrim{})
+		Expect(store.Add(vmi)).To(Succeed())
+		expectTrim(vmi, []api.FSTrimmed{
+			{Mountpoint: "/", TrimmedBytes: 4096},
+			{Mountpoint: "/mnt", Error: "discard operation is not supported"},
+		}, nil)
+
+		Expect(scheduler.TrimNow(vmi)).To(Succeed())
+		Expect(scheduler.Execute()).To(BeTrue())
+
+		Expect(recorder.Events).To(Receive(ContainSubstring("FilesystemsTrimmed Trimmed the guest filesystems, /: 4096 bytes")))
+		Expect(recorder.Events).To(Receive(ContainSubstring("FilesystemTrimFailed Failed to trim guest filesystems, /mnt: discard operation is not supported")))
+		Expect(trimsValue("testvmi", resultFailed)).To(Equal(1.0))
+	})
+
+	It("should report a failed trim", func() {
+		vmi := newVMI("testvmi", nil)
+		Expect(store.Add(vmi)).To(Succeed())
+		expectTrim(vmi, nil, errors.New("guest agent command timed out"))
+
+		Expect(scheduler.TrimNow(vmi)).To(Succeed())
+		Expect(scheduler.Execute()).To(BeTrue())
+
+		Expect(recorder.Events).To(Receive(ContainSubstring("FilesystemTrimFailed Failed to trim the guest filesystems: guest agent command timed out")))
+		Expect(trimsValue("testvmi", resultFailed)).To(Equal(1.0))
+	})
+
+	It("should restart the interval after an on-demand trim", func() {
+		vmi := newVMI("testvmi", &v1.FilesystemTrim{})
+		Expect(store.Add(vmi)).To(Succeed())
+		scheduler.schedule()
+
+		fakeClock.Step(DefaultInterval - time.Hour)
+		expectTrim(vmi, []api.FSTrimmed{{Mountpoint: "/"}}, nil)
+		Expect(scheduler.TrimNow(vmi)).To(Succeed())
+		Expect(scheduler.Execute()).To(BeTrue())
+
+		fakeClock.Step(time.Hour)
+		scheduler.schedule()
+		Expect(scheduler.queue.Len()).To(BeZero())
+	})
+
+	DescribeTable("should not trim a VMI", func(mutate func(vmi *v1.VirtualMachineInstance), expectedErr string) {
+		vmi := newVMI("testvmi", &v1.FilesystemTrim{})
+		mutate(vmi)
+		Expect(store.Add(vmi)).To(Succeed())
+
+		Expect(scheduler.TrimNow(vmi)).To(MatchError(expectedErr))
+		scheduler.schedule()
+		fakeClock.Step(DefaultInterval)
+		scheduler.schedule()
+		Expect(scheduler.queue.Len()).To(BeZero())
+	},
+		Entry("which is not running", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Status.Phase = v1.Scheduled
+		}, "VMI is not running"),
+		Entry("without guest agent", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Status.Conditions = nil
+		}, "VMI does not have guest agent connected"),
+		Entry("which is migrating", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{}
+		}, "VMI is migrating"),
+	)
+
+	It("should not schedule trims when the feature gate is disabled", func() {
+		clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{})
+		scheduler.clusterConfig = clusterConfig
+		Expect(store.Add(newVMI("testvmi", &v1.FilesystemTrim{}))).To(Succeed())
+
+		scheduler.schedule()
+		fakeClock.Step(DefaultInterval)
+		scheduler.schedule()
+		Expect(scheduler.queue.Len()).To(BeZero())
+	})
+
+	It("should forget a deleted VMI together with its metrics", func() {
+		vmi := newVMI("testvmi", &v1.FilesystemTrim{})
+		Expect(store.Add(vmi)).To(Succeed())
+		expectTrim(vmi, []api.FSTrimmed{{Mountpoint: "/", TrimmedBytes: 4096}}, nil)
+		Expect(scheduler.TrimNow(vmi)).To(Succeed())
+		Expect(scheduler.Execute()).To(BeTrue())
+		Expect(scheduler.state).To(HaveKey(vmi.UID))
+
+		Expect(store.Delete(vmi)).To(Succeed())
+		scheduler.schedule()
+		Expect(scheduler.state).To(BeEmpty())
+		Expect(trimmedBytes.DeleteLabelValues("default", "testvmi", "/")).To(BeFalse())
+	})
+
+	It("should stop processing once the queue is shut down", func() {
+		scheduler.queue.ShutDown()
+		Expect(scheduler.Execute()).To(BeFalse())
+	})
+})
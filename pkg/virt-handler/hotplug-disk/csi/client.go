@@ -0,0 +1,87 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package csi
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	grpcutil "kubevirt.io/kubevirt/pkg/util/net/grpc"
+)
+
+const nodeServicePrefix = "/csi.v1.Node/"
+
+// NodeClient calls the Node service of a CSI driver over its socket on the node
+type NodeClient interface {
+	// StageUnstageSupported returns if the driver needs volumes to be staged before they get published
+	StageUnstageSupported(ctx context.Context) (bool, error)
+	NodeStageVolume(ctx context.Context, req *NodeStageVolumeRequest) error
+	NodeUnstageVolume(ctx context.Context, req *NodeUnstageVolumeRequest) error
+	NodePublishVolume(ctx context.Context, req *NodePublishVolumeRequest) error
+	NodeUnpublishVolume(ctx context.Context, req *NodeUnpublishVolumeRequest) error
+	Close() error
+}
+
+type nodeClient struct {
+	conn *grpc.ClientConn
+}
+
+// NewNodeClient connects to the CSI driver listening on socketPath
+func NewNodeClient(socketPath string) (NodeClient, error) {
+	conn, err := grpcutil.DialSocket(socketPath)
+	if err != nil {
+		return nil, err
+	}
+	return &nodeClient{conn: conn}, nil
+}
+
+func (c *nodeClient) StageUnstageSupported(ctx context.Context) (bool, error) {
+	resp := &NodeGetCapabilitiesResponse{}
+	if err := c.conn.Invoke(ctx, nodeServicePrefix+"NodeGetCapabilities", &NodeGetCapabilitiesRequest{}, resp); err != nil {
+		return false, err
+	}
+	for _, capability := range resp.Capabilities {
+		if capability.Rpc != nil && capability.Rpc.Type == NodeServiceCapability_RPC_STAGE_UNSTAGE_VOLUME {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c *nodeClient) NodeStageVolume(ctx context.Context, req *NodeStageVolumeRequest) error {
+	return c.conn.Invoke(ctx, nodeServicePrefix+"NodeStageVolume", req, &NodeStageVolumeResponse{})
+}
+
+func (c *nodeClient) NodeUnstageVolume(ctx context.Context, req *NodeUnstageVolumeRequest) error {
+	return c.conn.Invoke(ctx, nodeServicePrefix+"NodeUnstageVolume", req, &NodeUnstageVolumeResponse{})
+}
+
+func (c *nodeClient) NodePublishVolume(ctx context.Context, req *NodePublishVolumeRequest) error {
+	return c.conn.Invoke(ctx, nodeServicePrefix+"NodePublishVolume", req, &NodePublishVolumeResponse{})
+}
+
+func (c *nodeClient) NodeUnpublishVolume(ctx context.Context, req *NodeUnpublishVolumeRequest) error {
+	return c.conn.Invoke(ctx, nodeServicePrefix+"NodeUnpublishVolume", req, &NodeUnpublishVolumeResponse{})
+}
+
+func (c *nodeClient) Close() error {
+	return c.conn.Close()
+}
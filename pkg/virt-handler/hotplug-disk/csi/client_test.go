@@ -0,0 +1,234 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package csi
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// fakeNodeServer records the requests it receives
+type fakeNodeServer struct {
+	stageUnstage bool
+	stage        *NodeStageVolumeRequest
+	unstage      *NodeUnstageVolumeRequest
+	publish      *NodePublishVolumeRequest
+	unpublish    *NodeUnpublishVolumeRequest
+}
+
+type fakeNodeService interface{}
+
+func fakeNodeHandler[Req any](method string, handle func(*fakeNodeServer, *Req) interface{}) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: method,
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+			req := new(Req)
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+			return handle(srv.(*fakeNodeServer), req), nil
+		},
+	}
+}
+
+var fakeNodeServiceDesc = grpc.ServiceDesc{
+	ServiceName: "csi.v1.Node",
+	HandlerType: (*fakeNodeService)(nil),
+	Methods: []grpc.MethodDesc{
+		fakeNodeHandler("NodeGetCapabilities", func(s *fakeNodeServer, _ *NodeGetCapabilitiesRequest) interface{} {
+			resp := &NodeGetCapabilitiesResponse{}
+			if s.stageUnstage {
+				resp.Capabilities = append(resp.Capabilities, &NodeServiceCapability{
+					Rpc: &NodeServiceCapability_RPC{Type: NodeServiceCapability_RPC_STAGE_UNSTAGE_VOLUME},
+				})
+			}
+			return resp
+		}),
+		fakeNodeHandler("NodeStageVolume", func(s *fakeNodeServer, req *NodeStageVolumeRequest) interface{} {
+			s.stage = req
+			return &NodeStageVolumeResponse{}
+		}),
+		fakeNodeHandler("NodeUnstageVolume", func(s *fakeNodeServer, req *NodeUnstageVolumeRequest) interface{} {
+			s.unstage = req
+			return &NodeUnstageVolumeResponse{}
+		}),
+		fakeNodeHandler("NodePublishVolume", func(s *fakeNodeServer, req *NodePublishVolumeRequest) interface{} {
+			s.publish = req
+			return &NodePublishVolumeResponse{}
+		}),
+		fakeNodeHandler("NodeUnpublishVolume", func(s *fakeNodeServer, req *NodeUnpublishVolumeRequest) interface{} {
+			s.unpublish = req
+			return &NodeUnpublishVolumeResponse{}
+		}),
+	},
+}
+
+var _ = Describe("CSI node client", func() {
+	var (
+		fakeServer *fakeNodeServer
+		server     *grpc.Server
+		client     NodeClient
+	)
+
+	BeforeEach(func() {
+		socketDir, err := os.MkdirTemp("", "csi")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, socketDir)
+		socketPath := filepath.Join(socketDir, "csi.sock")
+		listener, err := net.Listen("unix", socketPath)
+		Expect(err).ToNot(HaveOccurred())
+
+		fakeServer = &fakeNodeServer{}
+		server = grpc.NewServer()
+		server.RegisterService(&fakeNodeServiceDesc, fakeServer)
+		go server.Serve(listener)
+		DeferCleanup(server.Stop)
+
+		client, err = NewNodeClient(socketPath)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(client.Close)
+	})
+
+	DescribeTable("should report if volumes have to be staged", func(stageUnstage bool) {
+		fakeServer.stageUnstage = stageUnstage
+		Expect(client.StageUnstageSupported(context.Background())).To(Equal(stageUnstage))
+	},
+		Entry("when the driver supports it", true),
+		Entry("when the driver does not support it", false),
+	)
+
+	It("should stage and publish a filesystem volume", func() {
+		capability := &VolumeCapability{
+			Mount: &VolumeCapability_MountVolume{
+				FsType:     "ext4",
+				MountFlags: []string{"noatime"},
+			},
+			AccessMode: &VolumeCapability_AccessMode{Mode: VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+		}
+		Expect(client.NodeStageVolume(context.Background(), &NodeStageVolumeRequest{
+			VolumeId:          "handle",
+			StagingTargetPath: "/staging",
+			VolumeCapability:  capability,
+			VolumeContext:     map[string]string{"pool": "fast"},
+		})).To(Succeed())
+		Expect(fakeServer.stage.VolumeId).To(Equal("handle"))
+		Expect(fakeServer.stage.StagingTargetPath).To(Equal("/staging"))
+		Expect(fakeServer.stage.VolumeContext).To(Equal(map[string]string{"pool": "fast"}))
+		Expect(fakeServer.stage.VolumeCapability.Block).To(BeNil())
+		Expect(fakeServer.stage.VolumeCapability.Mount.FsType).To(Equal("ext4"))
+		Expect(fakeServer.stage.VolumeCapability.Mount.MountFlags).To(Equal([]string{"noatime"}))
+		Expect(fakeServer.stage.VolumeCapability.AccessMode.Mode).To(Equal(VolumeCapability_AccessMode_SINGLE_NODE_WRITER))
+
+		Expect(client.NodePublishVolume(context.Background(), &NodePublishVolumeRequest{
+			VolumeId:          "handle",
+			StagingTargetPath: "/staging",
+			TargetPath:        "/publish",
+			VolumeCapability:  capability,
+			Readonly:          true,
+		})).To(Succeed())
+		Expect(fakeServer.publish.VolumeId).To(Equal("handle"))
+		Expect(fakeServer.publish.StagingTargetPath).To(Equal("/staging"))
+		Expect(fakeServer.publish.TargetPath).To(Equal("/publish"))
+		Expect(fakeServer.publish.Readonly).To(BeTrue())
+		Expect(fakeServer.publish.VolumeCapability.Mount.FsType).To(Equal("ext4"))
+	})
+
+	It("should publish a block volume", func() {
+		Expect(client.NodePublishVolume(context.Background(), &NodePublishVolumeRequest{
+			VolumeId:   "handle",
+			TargetPath: "/publish",
+			VolumeCapability: &VolumeCapability{
+				Block:      &VolumeCapability_BlockVolume{},
+				AccessMode: &VolumeCapability_AccessMode{Mode: VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER},
+			},
+		})).To(Succeed())
+		Expect(fakeServer.publish.VolumeCapability.Block).ToNot(BeNil())
+		Expect(fakeServer.publish.VolumeCapability.Mount).To(BeNil())
+		Expect(fakeServer.publish.VolumeCapability.AccessMode.Mode).To(Equal(VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER))
+	})
+
+	It("should unpublish and unstage a volume", func() {
+		Expect(client.NodeUnpublishVolume(context.Background(), &NodeUnpublishVolumeRequest{
+			VolumeId:   "handle",
+			TargetPath: "/publish",
+		})).To(Succeed())
+		Expect(fakeServer.unpublish.VolumeId).To(Equal("handle"))
+		Expect(fakeServer.unpublish.TargetPath).To(Equal("/publish"))
+
+		Expect(client.NodeUnstageVolume(context.Background(), &NodeUnstageVolumeRequest{
+			VolumeId:          "handle",
+			StagingTargetPath: "/staging",
+		})).To(Succeed())
+		Expect(fakeServer.unstage.VolumeId).To(Equal("handle"))
+		Expect(fakeServer.unstage.StagingTargetPath).To(Equal("/staging"))
+	})
+
+	It("should use the field numbers of csi.proto", func() {
+		fieldNumbers := func(msg proto.Message) []protowire.Number {
+			b, err := proto.Marshal(msg)
+			Expect(err).ToNot(HaveOccurred())
+			var numbers []protowire.Number
+			for len(b) > 0 {
+				num, typ, n := protowire.ConsumeTag(b)
+				Expect(n).To(BeNumerically(">", 0))
+				b = b[n:]
+				n = protowire.ConsumeFieldValue(num, typ, b)
+				Expect(n).To(BeNumerically(">=", 0))
+				b = b[n:]
+				numbers = append(numbers, num)
+			}
+			return numbers
+		}
+
+		Expect(fieldNumbers(&NodePublishVolumeRequest{
+			VolumeId:          "handle",
+			PublishContext:    map[string]string{"a": "b"},
+			StagingTargetPath: "/staging",
+			TargetPath:        "/publish",
+			VolumeCapability:  &VolumeCapability{},
+			Readonly:          true,
+			VolumeContext:     map[string]string{"c": "d"},
+		})).To(Equal([]protowire.Number{1, 2, 3, 4, 5, 6, 8}))
+		Expect(fieldNumbers(&NodeStageVolumeRequest{
+			VolumeId:          "handle",
+			PublishContext:    map[string]string{"a": "b"},
+			StagingTargetPath: "/staging",
+			VolumeCapability:  &VolumeCapability{},
+			VolumeContext:     map[string]string{"c": "d"},
+		})).To(Equal([]protowire.Number{1, 2, 3, 4, 6}))
+		Expect(fieldNumbers(&VolumeCapability{
+			Block:      &VolumeCapability_BlockVolume{},
+			AccessMode: &VolumeCapability_AccessMode{Mode: VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+		})).To(Equal([]protowire.Number{1, 3}))
+		Expect(fieldNumbers(&VolumeCapability_MountVolume{
+			FsType:     "xfs",
+			MountFlags: []string{"noatime"},
+		})).To(Equal([]protowire.Number{1, 2}))
+	})
+})
@@ -0,0 +1,181 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package csi
+
+import "github.com/golang/protobuf/proto"
+
+// The CSI spec is not vendored, the messages below mirror the subset of the csi.v1 Node service virt-handler uses
+// to stage hotplugged volumes. Field numbers and names follow csi.proto of the CSI spec v1. The access_type oneof of
+// VolumeCapability is flattened into its two fields, which is the same on the wire.
+
+type NodeServiceCapability_RPC_Type int32
+
+const (
+	NodeServiceCapability_RPC_UNKNOWN              NodeServiceCapability_RPC_Type = 0
+	NodeServiceCapability_RPC_STAGE_UNSTAGE_VOLUME NodeServiceCapability_RPC_Type = 1
+)
+
+type VolumeCapability_AccessMode_Mode int32
+
+const (
+	VolumeCapability_AccessMode_UNKNOWN                  VolumeCapability_AccessMode_Mode = 0
+	VolumeCapability_AccessMode_SINGLE_NODE_WRITER       VolumeCapability_AccessMode_Mode = 1
+	VolumeCapability_AccessMode_SINGLE_NODE_READER_ONLY  VolumeCapability_AccessMode_Mode = 2
+	VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY   VolumeCapability_AccessMode_Mode = 3
+	VolumeCapability_AccessMode_MULTI_NODE_SINGLE_WRITER VolumeCapability_AccessMode_Mode = 4
+	VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER  VolumeCapability_AccessMode_Mode = 5
+)
+
+type VolumeCapability struct {
+	Block      *VolumeCapability_BlockVolume `protobuf:"bytes,1,opt,name=block" json:"block,omitempty"`
+	Mount      *VolumeCapability_MountVolume `protobuf:"bytes,2,opt,name=mount" json:"mount,omitempty"`
+	AccessMode *VolumeCapability_AccessMode  `protobuf:"bytes,3,opt,name=access_mode" json:"access_mode,omitempty"`
+}
+
+func (m *VolumeCapability) Reset()         { *m = VolumeCapability{} }
+func (m *VolumeCapability) String() string { return proto.CompactTextString(m) }
+func (*VolumeCapability) ProtoMessage()    {}
+
+type VolumeCapability_BlockVolume struct {
+}
+
+func (m *VolumeCapability_BlockVolume) Reset()         { *m = VolumeCapability_BlockVolume{} }
+func (m *VolumeCapability_BlockVolume) String() string { return proto.CompactTextString(m) }
+func (*VolumeCapability_BlockVolume) ProtoMessage()    {}
+
+type VolumeCapability_MountVolume struct {
+	FsType     string   `protobuf:"bytes,1,opt,name=fs_type" json:"fs_type,omitempty"`
+	MountFlags []string `protobuf:"bytes,2,rep,name=mount_flags" json:"mount_flags,omitempty"`
+}
+
+func (m *VolumeCapability_MountVolume) Reset()         { *m = VolumeCapability_MountVolume{} }
+func (m *VolumeCapability_MountVolume) String() string { return proto.CompactTextString(m) }
+func (*VolumeCapability_MountVolume) ProtoMessage()    {}
+
+type VolumeCapability_AccessMode struct {
+	Mode VolumeCapability_AccessMode_Mode `protobuf:"varint,1,opt,name=mode" json:"mode,omitempty"`
+}
+
+func (m *VolumeCapability_AccessMode) Reset()         { *m = VolumeCapability_AccessMode{} }
+func (m *VolumeCapability_AccessMode) String() string { return proto.CompactTextString(m) }
+func (*VolumeCapability_AccessMode) ProtoMessage()    {}
+
+type NodeGetCapabilitiesRequest struct {
+}
+
+func (m *NodeGetCapabilitiesRequest) Reset()         { *m = NodeGetCapabilitiesRequest{} }
+func (m *NodeGetCapabilitiesRequest) String() string { return proto.CompactTextString(m) }
+func (*NodeGetCapabilitiesRequest) ProtoMessage()    {}
+
+type NodeGetCapabilitiesResponse struct {
+	Capabilities []*NodeServiceCapability `protobuf:"bytes,1,rep,name=capabilities" json:"capabilities,omitempty"`
+}
+
+func (m *NodeGetCapabilitiesResponse) Reset()         { *m = NodeGetCapabilitiesResponse{} }
+func (m *NodeGetCapabilitiesResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeGetCapabilitiesResponse) ProtoMessage()    {}
+
+type NodeServiceCapability struct {
+	Rpc *NodeServiceCapability_RPC `protobuf:"bytes,1,opt,name=rpc" json:"rpc,omitempty"`
+}
+
+func (m *NodeServiceCapability) Reset()         { *m = NodeServiceCapability{} }
+func (m *NodeServiceCapability) String() string { return proto.CompactTextString(m) }
+func (*NodeServiceCapability) ProtoMessage()    {}
+
+type NodeServiceCapability_RPC struct {
+	Type NodeServiceCapability_RPC_Type `protobuf:"varint,1,opt,name=type" json:"type,omitempty"`
+}
+
+func (m *NodeServiceCapability_RPC) Reset()         { *m = NodeServiceCapability_RPC{} }
+func (m *NodeServiceCapability_RPC) String() string { return proto.CompactTextString(m) }
+func (*NodeServiceCapability_RPC) ProtoMessage()    {}
+
+type NodeStageVolumeRequest struct {
+	VolumeId          string            `protobuf:"bytes,1,opt,name=volume_id" json:"volume_id,omitempty"`
+	PublishContext    map[string]string `protobuf:"bytes,2,rep,name=publish_context" json:"publish_context,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	StagingTargetPath string            `protobuf:"bytes,3,opt,name=staging_target_path" json:"staging_target_path,omitempty"`
+	VolumeCapability  *VolumeCapability `protobuf:"bytes,4,opt,name=volume_capability" json:"volume_capability,omitempty"`
+	VolumeContext     map[string]string `protobuf:"bytes,6,rep,name=volume_context" json:"volume_context,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *NodeStageVolumeRequest) Reset()         { *m = NodeStageVolumeRequest{} }
+func (m *NodeStageVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*NodeStageVolumeRequest) ProtoMessage()    {}
+
+type NodeStageVolumeResponse struct {
+}
+
+func (m *NodeStageVolumeResponse) Reset()         { *m = NodeStageVolumeResponse{} }
+func (m *NodeStageVolumeResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeStageVolumeResponse) ProtoMessage()    {}
+
+type NodeUnstageVolumeRequest struct {
+	VolumeId          string `protobuf:"bytes,1,opt,name=volume_id" json:"volume_id,omitempty"`
+	StagingTargetPath string `protobuf:"bytes,2,opt,name=staging_target_path" json:"staging_target_path,omitempty"`
+}
+
+func (m *NodeUnstageVolumeRequest) Reset()         { *m = NodeUnstageVolumeRequest{} }
+func (m *NodeUnstageVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*NodeUnstageVolumeRequest) ProtoMessage()    {}
+
+type NodeUnstageVolumeResponse struct {
+}
+
+func (m *NodeUnstageVolumeResponse) Reset()         { *m = NodeUnstageVolumeResponse{} }
+func (m *NodeUnstageVolumeResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeUnstageVolumeResponse) ProtoMessage()    {}
+
+type NodePublishVolumeRequest struct {
+	VolumeId          string            `protobuf:"bytes,1,opt,name=volume_id" json:"volume_id,omitempty"`
+	PublishContext    map[string]string `protobuf:"bytes,2,rep,name=publish_context" json:"publish_context,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	StagingTargetPath string            `protobuf:"bytes,3,opt,name=staging_target_path" json:"staging_target_path,omitempty"`
+	TargetPath        string            `protobuf:"bytes,4,opt,name=target_path" json:"target_path,omitempty"`
+	VolumeCapability  *VolumeCapability `protobuf:"bytes,5,opt,name=volume_capability" json:"volume_capability,omitempty"`
+	Readonly          bool              `protobuf:"varint,6,opt,name=readonly" json:"readonly,omitempty"`
+	VolumeContext     map[string]string `protobuf:"bytes,8,rep,name=volume_context" json:"volume_context,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *NodePublishVolumeRequest) Reset()         { *m = NodePublishVolumeRequest{} }
+func (m *NodePublishVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*NodePublishVolumeRequest) ProtoMessage()    {}
+
+type NodePublishVolumeResponse struct {
+}
+
+func (m *NodePublishVolumeResponse) Reset()         { *m = NodePublishVolumeResponse{} }
+func (m *NodePublishVolumeResponse) String() string { return proto.CompactTextString(m) }
+func (*NodePublishVolumeResponse) ProtoMessage()    {}
+
+type NodeUnpublishVolumeRequest struct {
+	VolumeId   string `protobuf:"bytes,1,opt,name=volume_id" json:"volume_id,omitempty"`
+	TargetPath string `protobuf:"bytes,2,opt,name=target_path" json:"target_path,omitempty"`
+}
+
+func (m *NodeUnpublishVolumeRequest) Reset()         { *m = NodeUnpublishVolumeRequest{} }
+func (m *NodeUnpublishVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*NodeUnpublishVolumeRequest) ProtoMessage()    {}
+
+type NodeUnpublishVolumeResponse struct {
+}
+
+func (m *NodeUnpublishVolumeResponse) Reset()         { *m = NodeUnpublishVolumeResponse{} }
+func (m *NodeUnpublishVolumeResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeUnpublishVolumeResponse) ProtoMessage()    {}
@@ -80,3 +80,14 @@ func (_m *MockVolumeMounter) IsMounted(vmi *v1.VirtualMachineInstance, volume st
 func (_mr *_MockVolumeMounterRecorder) IsMounted(arg0, arg1, arg2 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "IsMounted", arg0, arg1, arg2)
 }
+
+func (_m *MockVolumeMounter) IsCSIVolumePublished(vmi *v1.VirtualMachineInstance, volume string) (bool, error) {
+	ret := _m.ctrl.Call(_m, "IsCSIVolumePublished", vmi, volume)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVolumeMounterRecorder) IsCSIVolumePublished(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "IsCSIVolumePublished", arg0, arg1)
+}
@@ -16,9 +16,12 @@ import (
 	"kubevirt.io/kubevirt/pkg/safepath"
 	virt_chroot "kubevirt.io/kubevirt/pkg/virt-handler/virt-chroot"
 
+	"kubevirt.io/kubevirt/pkg/config"
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	diskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
 	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
+	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/virt-handler/cgroup"
 	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
 
@@ -54,6 +57,34 @@ var (
 		return fmt.Sprintf("pods/%s/volumes/kubernetes.io~empty-dir/hotplug-disks/hp.sock", string(podUID))
 	}
 
+	containerDiskSocketPath = func(podUID types.UID, volumeName string) string {
+		return fmt.Sprintf("pods/%s/volumes/kubernetes.io~empty-dir/%s/%s.sock", string(podUID), volumeName, containerdisk.HotplugDiskName)
+	}
+
+	configVolumeSourcePath = func(podUID types.UID, volume *v1.Volume) (*safepath.Path, error) {
+		return safepath.JoinAndResolveWithRelativeRoot("/proc/1/root", fmt.Sprintf("/var/lib/kubelet/pods/%s/volumes/%s/%s", string(podUID), configVolumePluginDir(volume), volume.Name))
+	}
+
+	containerDiskImagePath = func(vmi *v1.VirtualMachineInstance, volume *v1.Volume, sourceUID types.UID) (*safepath.Path, error) {
+		res, err := isolationDetector("/path").DetectForSocket(vmi, containerDiskSocketPath(sourceUID, volume.Name))
+		if err != nil {
+			return nil, err
+		}
+		mountPoint, err := isolation.ParentPathForRootMount(nodeIsolationResult(), res)
+		if err != nil {
+			return nil, err
+		}
+		return containerdisk.GetImage(mountPoint, volume.ContainerDisk.Path)
+	}
+
+	createIsoImage = func(sourceDir *safepath.Path, isoDir *safepath.Path, isoName string, volID string) error {
+		return sourceDir.ExecuteNoFollow(func(safeSourceDir string) error {
+			return isoDir.ExecuteNoFollow(func(safeIsoDir string) error {
+				return config.CreateIsoImage(filepath.Join(safeIsoDir, isoName), safeSourceDir, volID)
+			})
+		})
+	}
+
 	statDevice = func(fileName *safepath.Path) (os.FileInfo, error) {
 		info, err := safepath.StatAtNoFollow(fileName)
 		if err != nil {
@@ -106,6 +137,10 @@ var (
 		return virt_chroot.MountChroot(sourcePath, targetPath, false).CombinedOutput()
 	}
 
+	mountReadOnlyCommand = func(sourcePath, targetPath *safepath.Path) ([]byte, error) {
+		return virt_chroot.MountChroot(sourcePath, targetPath, true).CombinedOutput()
+	}
+
 	unmountCommand = func(diskPath *safepath.Path) ([]byte, error) {
 		return virt_chroot.UmountChroot(diskPath).CombinedOutput()
 	}
@@ -142,6 +177,7 @@ type volumeMounter struct {
 	skipSafetyCheck    bool
 	hotplugDiskManager hotplugdisk.HotplugDiskManagerInterface
 	ownershipManager   diskutils.OwnershipManagerInterface
+	kubeletPluginsDir  string
 }
 
 // VolumeMounter is the interface used to mount and unmount volumes to/from a running virtlauncher pod.
@@ -155,6 +191,8 @@ type VolumeMounter interface {
 	UnmountAll(vmi *v1.VirtualMachineInstance) error
 	//IsMounted returns if the volume is mounted or not.
 	IsMounted(vmi *v1.VirtualMachineInstance, volume string, sourceUID types.UID) (bool, error)
+	//IsCSIVolumePublished returns if the volume staged through its CSI driver is still published or not.
+	IsCSIVolumePublished(vmi *v1.VirtualMachineInstance, volume string) (bool, error)
 }
 
 type vmiMountTargetEntry struct {
@@ -164,6 +202,7 @@ type vmiMountTargetEntry struct {
 type vmiMountTargetRecord struct {
 	MountTargetEntries []vmiMountTargetEntry `json:"mountTargetEntries"`
 	UsesSafePaths      bool                  `json:"usesSafePaths"`
+	CSIVolumeEntries   []vmiCSIVolumeEntry   `json:"csiVolumeEntries,omitempty"`
 }
 
 // NewVolumeMounter creates a new VolumeMounter
@@ -173,6 +212,7 @@ func NewVolumeMounter(mountStateDir string, kubeletPodsDir string) VolumeMounter
 		mountStateDir:      mountStateDir,
 		hotplugDiskManager: hotplugdisk.NewHotplugDiskManager(kubeletPodsDir),
 		ownershipManager:   diskutils.DefaultOwnershipManager,
+		kubeletPluginsDir:  filepath.Join(filepath.Dir(kubeletPodsDir), "plugins"),
 	}
 }
 
@@ -310,11 +350,22 @@ func (m *volumeMounter) mountHotplugVolume(vmi *v1.VirtualMachineInstance, volum
 	logger := log.DefaultLogger()
 	logger.V(4).Infof("Hotplug check volume name: %s", volumeName)
 	if sourceUID != types.UID("") {
+		volume := getSpecVolume(vmi, volumeName)
 		if m.isBlockVolume(&vmi.Status, volumeName) {
 			logger.V(4).Infof("Mounting block volume: %s", volumeName)
 			if err := m.mountBlockHotplugVolume(vmi, volumeName, sourceUID, record); err != nil {
 				return fmt.Errorf("failed to mount block hotplug volume %s: %v", volumeName, err)
 			}
+		} else if volume != nil && util.IsHotplugConfigVolume(volume) {
+			logger.V(4).Infof("Mounting config volume: %s", volumeName)
+			if err := m.mountConfigHotplugVolume(vmi, volume, sourceUID, record); err != nil {
+				return fmt.Errorf("failed to mount config hotplug volume %s: %v", volumeName, err)
+			}
+		} else if volume != nil && util.IsHotplugContainerDiskVolume(volume) {
+			logger.V(4).Infof("Mounting containerDisk volume: %s", volumeName)
+			if err := m.mountContainerDiskHotplugVolume(vmi, volume, sourceUID, record); err != nil {
+				return fmt.Errorf("failed to mount containerDisk hotplug volume %s: %v", volumeName, err)
+			}
 		} else {
 			logger.V(4).Infof("Mounting file system volume: %s", volumeName)
 			if err := m.mountFileSystemHotplugVolume(vmi, volumeName, sourceUID, record, mountDirectory); err != nil {
@@ -343,9 +394,16 @@ func (m *volumeMounter) mountFromPod(vmi *v1.VirtualMachineInstance, sourceUID t
 			// Skip non hotplug volumes
 			continue
 		}
-		mountDirectory := false
-		if volumeStatus.MemoryDumpVolume != nil {
-			mountDirectory = true
+		if IsCSIVolumeDetaching(vmi, &volumeStatus) {
+			// Skip volumes which are unplugged, they are unpublished by Unmount
+			continue
+		}
+		mountDirectory := m.isDirectoryMounted(vmi, volumeStatus.Name)
+		if csiVolume := volumeStatus.HotplugVolume.CSIVolume; csiVolume != nil {
+			if err := m.mountCSIHotplugVolume(vmi, volumeStatus.Name, csiVolume, record, mountDirectory); err != nil {
+				return err
+			}
+			continue
 		}
 		if sourceUID == types.UID("") {
 			sourceUID = volumeStatus.HotplugVolume.AttachPodUID
@@ -357,8 +415,13 @@ func (m *volumeMounter) mountFromPod(vmi *v1.VirtualMachineInstance, sourceUID t
 	return nil
 }
 
-func (m *volumeMounter) isDirectoryMounted(vmiStatus *v1.VirtualMachineInstanceStatus, volumeName string) bool {
-	for _, status := range vmiStatus.VolumeStatus {
+// isDirectoryMounted checks if the volume is mounted as a directory, this is the case for memory dumps
+// and for volumes shared with the guest through virtiofs
+func (m *volumeMounter) isDirectoryMounted(vmi *v1.VirtualMachineInstance, volumeName string) bool {
+	if util.IsFilesystemVolume(vmi, volumeName) {
+		return true
+	}
+	for _, status := range vmi.Status.VolumeStatus {
 		if status.Name == volumeName {
 			return status.MemoryDumpVolume != nil
 		}
@@ -379,6 +442,14 @@ func (m *volumeMounter) isBlockVolume(vmiStatus *v1.VirtualMachineInstanceStatus
 }
 
 func (m *volumeMounter) mountBlockHotplugVolume(vmi *v1.VirtualMachineInstance, volume string, sourceUID types.UID, record *vmiMountTargetRecord) error {
+	return m.mountBlockHotplugVolumeFromSource(vmi, volume, record, func() (uint64, os.FileMode, error) {
+		return m.getSourceMajorMinor(sourceUID, volume)
+	})
+}
+
+// mountBlockHotplugVolumeFromSource creates the block device of a hotplugged volume in the virt-launcher pod, sourceMajorMinor
+// looks up the device number and permissions of the source device on the node.
+func (m *volumeMounter) mountBlockHotplugVolumeFromSource(vmi *v1.VirtualMachineInstance, volume string, record *vmiMountTargetRecord, sourceMajorMinor func() (uint64, os.FileMode, error)) error {
 	virtlauncherUID := m.findVirtlauncherUID(vmi)
 	if virtlauncherUID == "" {
 		// This is not the node the pod is running on.
@@ -395,7 +466,7 @@ func (m *volumeMounter) mountBlockHotplugVolume(vmi *v1.VirtualMachineInstance,
 	}
 
 	if _, err := safepath.JoinNoFollow(targetPath, volume); errors.Is(err, os.ErrNotExist) {
-		dev, permissions, err := m.getSourceMajorMinor(sourceUID, volume)
+		dev, permissions, err := sourceMajorMinor()
 		if err != nil {
 			return err
 		}
@@ -426,7 +497,7 @@ func (m *volumeMounter) mountBlockHotplugVolume(vmi *v1.VirtualMachineInstance,
 	volumeNotReady := !m.volumeStatusReady(volume, vmi)
 
 	if isMigrationInProgress || volumeNotReady {
-		dev, _, err := m.getSourceMajorMinor(sourceUID, volume)
+		dev, _, err := sourceMajorMinor()
 		if err != nil {
 			return err
 		}
@@ -514,6 +585,21 @@ func (m *volumeMounter) createBlockDeviceFile(basePath *safepath.Path, deviceNam
 }
 
 func (m *volumeMounter) mountFileSystemHotplugVolume(vmi *v1.VirtualMachineInstance, volume string, sourceUID types.UID, record *vmiMountTargetRecord, mountDirectory bool) error {
+	return m.mountFileSystemHotplugVolumeFromSource(vmi, volume, record, mountDirectory, func() (*safepath.Path, error) {
+		sourcePath, err := m.getSourcePodFilePath(sourceUID, vmi, volume)
+		if err != nil {
+			log.DefaultLogger().V(3).Infof("Error getting source path: %v", err)
+			// We are eating the error to avoid spamming the log with errors, it might take a while for the volume
+			// to get mounted on the node, and this will error until the volume is mounted.
+			return nil, nil
+		}
+		return sourcePath, nil
+	})
+}
+
+// mountFileSystemHotplugVolumeFromSource bind mounts a hotplugged filesystem volume into the virt-launcher pod, sourcePath
+// looks up the directory of the volume on the node, it returns nil if the volume is not available yet.
+func (m *volumeMounter) mountFileSystemHotplugVolumeFromSource(vmi *v1.VirtualMachineInstance, volume string, record *vmiMountTargetRecord, mountDirectory bool, sourcePath func() (*safepath.Path, error)) error {
 	virtlauncherUID := m.findVirtlauncherUID(vmi)
 	if virtlauncherUID == "" {
 		// This is not the node the pod is running on.
@@ -535,12 +621,9 @@ func (m *volumeMounter) mountFileSystemHotplugVolume(vmi *v1.VirtualMachineInsta
 		return fmt.Errorf("failed to determine if %s is already mounted: %v", target, err)
 	}
 	if !isMounted {
-		sourcePath, err := m.getSourcePodFilePath(sourceUID, vmi, volume)
-		if err != nil {
-			log.DefaultLogger().V(3).Infof("Error getting source path: %v", err)
-			// We are eating the error to avoid spamming the log with errors, it might take a while for the volume
-			// to get mounted on the node, and this will error until the volume is mounted.
-			return nil
+		sourcePath, err := sourcePath()
+		if err != nil || sourcePath == nil {
+			return err
 		}
 		if err := m.writePathToMountRecord(unsafepath.UnsafeAbsolute(target.Raw()), vmi, record); err != nil {
 			return err
@@ -560,6 +643,125 @@ func (m *volumeMounter) mountFileSystemHotplugVolume(vmi *v1.VirtualMachineInsta
 	return m.ownershipManager.SetFileOwnership(target)
 }
 
+func getSpecVolume(vmi *v1.VirtualMachineInstance, volumeName string) *v1.Volume {
+	for i := range vmi.Spec.Volumes {
+		if vmi.Spec.Volumes[i].Name == volumeName {
+			return &vmi.Spec.Volumes[i]
+		}
+	}
+	return nil
+}
+
+// configVolumePluginDir returns the directory in which the kubelet populates the pod volume of a config volume
+func configVolumePluginDir(volume *v1.Volume) string {
+	switch {
+	case volume.ConfigMap != nil:
+		return "kubernetes.io~configmap"
+	case volume.Secret != nil:
+		return "kubernetes.io~secret"
+	default:
+		return "kubernetes.io~projected"
+	}
+}
+
+func configVolumeLabel(volume *v1.Volume) string {
+	switch {
+	case volume.ConfigMap != nil:
+		return volume.ConfigMap.VolumeLabel
+	case volume.Secret != nil:
+		return volume.Secret.VolumeLabel
+	default:
+		return ""
+	}
+}
+
+// mountConfigHotplugVolume builds an iso image from the ConfigMap, Secret or ServiceAccount volume populated
+// in the attachment pod and bind mounts it read-only into the virt-launcher pod
+func (m *volumeMounter) mountConfigHotplugVolume(vmi *v1.VirtualMachineInstance, volume *v1.Volume, sourceUID types.UID, record *vmiMountTargetRecord) error {
+	virtlauncherUID := m.findVirtlauncherUID(vmi)
+	if virtlauncherUID == "" {
+		// This is not the node the pod is running on.
+		return nil
+	}
+	target, err := m.hotplugDiskManager.GetFileSystemDiskTargetPathFromHostView(virtlauncherUID, volume.Name, true)
+	if err != nil {
+		return err
+	}
+
+	isMounted, err := isMounted(target)
+	if err != nil {
+		return fmt.Errorf("failed to determine if %s is already mounted: %v", target, err)
+	}
+	if isMounted {
+		return nil
+	}
+	sourceDir, err := configVolumeSourcePath(sourceUID, volume)
+	if err != nil {
+		log.DefaultLogger().V(3).Infof("Error getting source path: %v", err)
+		// The kubelet might not have populated the volume yet, try again later.
+		return nil
+	}
+	isoDir, err := deviceBasePath(sourceUID)
+	if err != nil {
+		return err
+	}
+	isoName := fmt.Sprintf("%s.iso", volume.Name)
+	if err := createIsoImage(sourceDir, isoDir, isoName, configVolumeLabel(volume)); err != nil {
+		return fmt.Errorf("failed to create iso image for %s: %v", volume.Name, err)
+	}
+	isoFile, err := safepath.JoinNoFollow(isoDir, isoName)
+	if err != nil {
+		return err
+	}
+	if err := m.ownershipManager.SetFileOwnership(isoFile); err != nil {
+		return err
+	}
+	if err := m.writePathToMountRecord(unsafepath.UnsafeAbsolute(target.Raw()), vmi, record); err != nil {
+		return err
+	}
+	if out, err := mountReadOnlyCommand(isoFile, target); err != nil {
+		return fmt.Errorf("failed to bindmount hotplug volume source from %v to %v: %v : %v", isoFile, target, string(out), err)
+	}
+	log.DefaultLogger().V(1).Infof("successfully mounted %v", volume.Name)
+	return nil
+}
+
+// mountContainerDiskHotplugVolume bind mounts the disk image of a containerDisk served by the attachment pod
+// read-only into the virt-launcher pod
+func (m *volumeMounter) mountContainerDiskHotplugVolume(vmi *v1.VirtualMachineInstance, volume *v1.Volume, sourceUID types.UID, record *vmiMountTargetRecord) error {
+	virtlauncherUID := m.findVirtlauncherUID(vmi)
+	if virtlauncherUID == "" {
+		// This is not the node the pod is running on.
+		return nil
+	}
+	target, err := m.hotplugDiskManager.GetFileSystemDiskTargetPathFromHostView(virtlauncherUID, volume.Name, true)
+	if err != nil {
+		return err
+	}
+
+	isMounted, err := isMounted(target)
+	if err != nil {
+		return fmt.Errorf("failed to determine if %s is already mounted: %v", target, err)
+	}
+	if isMounted {
+		return nil
+	}
+	sourceFile, err := containerDiskImagePath(vmi, volume, sourceUID)
+	if err != nil {
+		log.DefaultLogger().V(3).Infof("Error getting source path: %v", err)
+		// The containerDisk container might not be running yet, try again later.
+		return nil
+	}
+	if err := m.writePathToMountRecord(unsafepath.UnsafeAbsolute(target.Raw()), vmi, record); err != nil {
+		return err
+	}
+	if out, err := mountReadOnlyCommand(sourceFile, target); err != nil {
+		return fmt.Errorf("failed to bindmount hotplug volume source from %v to %v: %v : %v", sourceFile, target, string(out), err)
+	}
+	log.DefaultLogger().V(1).Infof("successfully mounted %v", volume.Name)
+	return nil
+}
+
 func (m *volumeMounter) findVirtlauncherUID(vmi *v1.VirtualMachineInstance) (uid types.UID) {
 	cnt := 0
 	for podUID := range vmi.Status.ActivePods {
@@ -641,7 +843,7 @@ func (m *volumeMounter) Unmount(vmi *v1.VirtualMachineInstance) error {
 			// no entries to unmount
 			return nil
 		}
-		if len(record.MountTargetEntries) == 0 {
+		if len(record.MountTargetEntries) == 0 && len(record.CSIVolumeEntries) == 0 {
 			return nil
 		}
 
@@ -652,6 +854,9 @@ func (m *volumeMounter) Unmount(vmi *v1.VirtualMachineInstance) error {
 		if err != nil {
 			if errors.Is(err, os.ErrNotExist) {
 				// no mounts left, the base path does not even exist anymore
+				if err := m.unpublishAllCSIVolumes(vmi, record); err != nil {
+					return err
+				}
 				if err := m.deleteMountTargetRecord(vmi); err != nil {
 					return fmt.Errorf("failed to delete mount target records: %v", err)
 				}
@@ -660,14 +865,14 @@ func (m *volumeMounter) Unmount(vmi *v1.VirtualMachineInstance) error {
 			return err
 		}
 		for _, volumeStatus := range vmi.Status.VolumeStatus {
-			if volumeStatus.HotplugVolume == nil {
+			if volumeStatus.HotplugVolume == nil || IsCSIVolumeDetaching(vmi, &volumeStatus) {
 				continue
 			}
 			var path *safepath.Path
 			var err error
 			if m.isBlockVolume(&vmi.Status, volumeStatus.Name) {
 				path, err = safepath.JoinNoFollow(basePath, volumeStatus.Name)
-			} else if m.isDirectoryMounted(&vmi.Status, volumeStatus.Name) {
+			} else if m.isDirectoryMounted(vmi, volumeStatus.Name) {
 				path, err = m.hotplugDiskManager.GetFileSystemDirectoryTargetPathFromHostView(virtlauncherUID, volumeStatus.Name, false)
 				if os.IsExist(err) {
 					// already unmounted or never mounted
@@ -712,7 +917,18 @@ func (m *volumeMounter) Unmount(vmi *v1.VirtualMachineInstance) error {
 				})
 			}
 		}
-		if len(newRecord.MountTargetEntries) > 0 {
+		// Volumes staged through their CSI driver are released once they are unmounted from the virt-launcher pod
+		currentCSIVolumes := make(map[string]bool)
+		for _, volumeStatus := range vmi.Status.VolumeStatus {
+			if volumeStatus.HotplugVolume != nil && volumeStatus.HotplugVolume.CSIVolume != nil && !IsCSIVolumeDetaching(vmi, &volumeStatus) {
+				currentCSIVolumes[volumeStatus.Name] = true
+			}
+		}
+		var unpublishErr error
+		newRecord.CSIVolumeEntries, unpublishErr = m.unpublishCSIVolumes(record.CSIVolumeEntries, func(entry vmiCSIVolumeEntry) bool {
+			return currentCSIVolumes[entry.VolumeName]
+		})
+		if len(newRecord.MountTargetEntries) > 0 || len(newRecord.CSIVolumeEntries) > 0 {
 			err = m.setMountTargetRecord(vmi, &newRecord)
 		} else {
 			err = m.deleteMountTargetRecord(vmi)
@@ -720,10 +936,31 @@ func (m *volumeMounter) Unmount(vmi *v1.VirtualMachineInstance) error {
 		if err != nil {
 			return err
 		}
+		if unpublishErr != nil {
+			return unpublishErr
+		}
 	}
 	return nil
 }
 
+// unpublishAllCSIVolumes releases all volumes of the VMI staged through their CSI driver, the ones which fail stay in
+// the record, so they are retried.
+func (m *volumeMounter) unpublishAllCSIVolumes(vmi *v1.VirtualMachineInstance, record *vmiMountTargetRecord) error {
+	if len(record.CSIVolumeEntries) == 0 {
+		return nil
+	}
+	remaining, err := m.unpublishCSIVolumes(record.CSIVolumeEntries, func(vmiCSIVolumeEntry) bool {
+		return false
+	})
+	if err != nil {
+		record.CSIVolumeEntries = remaining
+		if setErr := m.setMountTargetRecord(vmi, record); setErr != nil {
+			return setErr
+		}
+	}
+	return err
+}
+
 func (m *volumeMounter) unmountFileSystemHotplugVolumes(diskPath *safepath.Path) error {
 	if mounted, err := isMounted(diskPath); err != nil {
 		return fmt.Errorf("failed to check mount point for hotplug disk %v: %v", diskPath, err)
@@ -801,6 +1038,9 @@ func (m *volumeMounter) UnmountAll(vmi *v1.VirtualMachineInstance) error {
 				}
 			}
 		}
+		if err := m.unpublishAllCSIVolumes(vmi, record); err != nil {
+			return err
+		}
 		err = m.deleteMountTargetRecord(vmi)
 		if err != nil {
 			return err
@@ -833,7 +1073,7 @@ func (m *volumeMounter) IsMounted(vmi *v1.VirtualMachineInstance, volume string,
 		isBlockExists, _ := isBlockDevice(deviceName)
 		return isBlockExists, nil
 	}
-	if m.isDirectoryMounted(&vmi.Status, volume) {
+	if m.isDirectoryMounted(vmi, volume) {
 		path, err := safepath.JoinNoFollow(targetPath, volume)
 		if err != nil {
 			if errors.Is(err, os.ErrNotExist) {
@@ -852,3 +1092,31 @@ func (m *volumeMounter) IsMounted(vmi *v1.VirtualMachineInstance, volume string,
 	}
 	return isMounted(path)
 }
+
+// IsCSIVolumePublished checks if the mount record still holds the volume staged through its CSI driver
+func (m *volumeMounter) IsCSIVolumePublished(vmi *v1.VirtualMachineInstance, volume string) (bool, error) {
+	record, err := m.getMountTargetRecord(vmi)
+	if err != nil {
+		return false, err
+	}
+	for _, entry := range record.CSIVolumeEntries {
+		if entry.VolumeName == volume {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// IsCSIVolumeDetaching checks if the volume status belongs to a volume staged through its CSI driver which is no
+// longer part of the VMI spec
+func IsCSIVolumeDetaching(vmi *v1.VirtualMachineInstance, volumeStatus *v1.VolumeStatus) bool {
+	if volumeStatus.HotplugVolume == nil || volumeStatus.HotplugVolume.CSIVolume == nil {
+		return false
+	}
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name == volumeStatus.Name {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,289 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package hotplug_volume
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/safepath"
+	"kubevirt.io/kubevirt/pkg/virt-handler/hotplug-disk/csi"
+)
+
+const (
+	// csiHotplugDir is the directory in the kubelet plugins directory, where virt-handler stages and publishes
+	// hotplugged volumes directly through their CSI driver. The CSI drivers have access to the plugins directory.
+	csiHotplugDir       = "kubevirt.io/hotplug-volumes"
+	csiStagingDir       = "staging"
+	csiPublishTarget    = "publish"
+	csiOperationTimeout = time.Minute
+)
+
+var (
+	// csiHostRoot is where the root filesystem of the node is visible to virt-handler
+	csiHostRoot = "/proc/1/root"
+
+	csiPluginSocketPath = func(pluginsDir, driver string) string {
+		return filepath.Join(csiHostRoot, pluginsDir, driver, "csi.sock")
+	}
+
+	newCSINodeClient = func(socketPath string) (csi.NodeClient, error) {
+		return csi.NewNodeClient(socketPath)
+	}
+)
+
+// vmiCSIVolumeEntry records a volume staged and published through its CSI driver, so it can be cleaned up even once
+// the volume is gone from the VMI.
+type vmiCSIVolumeEntry struct {
+	VolumeName   string `json:"volumeName"`
+	Driver       string `json:"driver"`
+	VolumeHandle string `json:"volumeHandle"`
+	// StagingTargetPath is empty if the driver does not stage volumes
+	StagingTargetPath string `json:"stagingTargetPath,omitempty"`
+	TargetPath        string `json:"targetPath"`
+}
+
+// mountCSIHotplugVolume stages and publishes a hotplugged volume through its CSI driver on the node, and then
+// mounts it into the virt-launcher pod, like a volume from an attachment pod.
+func (m *volumeMounter) mountCSIHotplugVolume(vmi *v1.VirtualMachineInstance, volumeName string, csiVolume *v1.HotplugCSIVolumeSource, record *vmiMountTargetRecord, mountDirectory bool) error {
+	if m.findVirtlauncherUID(vmi) == "" {
+		// This is not the node the pod is running on.
+		return nil
+	}
+	isBlock := m.isBlockVolume(&vmi.Status, volumeName)
+	publish := func() (*safepath.Path, error) {
+		targetPath, err := m.publishCSIVolume(vmi, volumeName, csiVolume, isBlock, record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish volume %s through the CSI driver %s: %v", volumeName, csiVolume.Driver, err)
+		}
+		return safepath.JoinAndResolveWithRelativeRoot(csiHostRoot, targetPath)
+	}
+
+	if isBlock {
+		return m.mountBlockHotplugVolumeFromSource(vmi, volumeName, record, func() (uint64, os.FileMode, error) {
+			devicePath, err := publish()
+			if err != nil {
+				return 0, 0, err
+			}
+			return m.getBlockFileMajorMinor(devicePath, statDevice)
+		})
+	}
+	return m.mountFileSystemHotplugVolumeFromSource(vmi, volumeName, record, mountDirectory, publish)
+}
+
+// publishCSIVolume stages and publishes a volume on the node, and returns the path the driver published it to. Both
+// operations are idempotent, so volumes which are already published are just published again.
+func (m *volumeMounter) publishCSIVolume(vmi *v1.VirtualMachineInstance, volumeName string, csiVolume *v1.HotplugCSIVolumeSource, isBlock bool, record *vmiMountTargetRecord) (string, error) {
+	client, err := newCSINodeClient(csiPluginSocketPath(m.kubeletPluginsDir, csiVolume.Driver))
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), csiOperationTimeout)
+	defer cancel()
+
+	stage, err := client.StageUnstageSupported(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	volumeDir := filepath.Join(m.kubeletPluginsDir, csiHotplugDir, string(vmi.UID), volumeName)
+	entry := vmiCSIVolumeEntry{
+		VolumeName:   volumeName,
+		Driver:       csiVolume.Driver,
+		VolumeHandle: csiVolume.VolumeHandle,
+		TargetPath:   filepath.Join(volumeDir, csiPublishTarget),
+	}
+	if stage {
+		entry.StagingTargetPath = filepath.Join(volumeDir, csiStagingDir)
+	}
+	if err := m.writeCSIVolumeToMountRecord(entry, vmi, record); err != nil {
+		return "", err
+	}
+
+	// The staging directory and the parent of the target are created by the CO, the target itself by the driver
+	if err := mkdirAllOnHost(volumeDir); err != nil {
+		return "", err
+	}
+	capability := csiVolumeCapability(vmi, volumeName, csiVolume, isBlock)
+	if stage {
+		if err := mkdirAllOnHost(entry.StagingTargetPath); err != nil {
+			return "", err
+		}
+		if err := client.NodeStageVolume(ctx, &csi.NodeStageVolumeRequest{
+			VolumeId:          entry.VolumeHandle,
+			StagingTargetPath: entry.StagingTargetPath,
+			VolumeCapability:  capability,
+			VolumeContext:     csiVolume.VolumeAttributes,
+		}); err != nil {
+			return "", err
+		}
+	}
+	if err := client.NodePublishVolume(ctx, &csi.NodePublishVolumeRequest{
+		VolumeId:          entry.VolumeHandle,
+		StagingTargetPath: entry.StagingTargetPath,
+		TargetPath:        entry.TargetPath,
+		VolumeCapability:  capability,
+		Readonly:          capability.AccessMode.Mode == csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY,
+		VolumeContext:     csiVolume.VolumeAttributes,
+	}); err != nil {
+		return "", err
+	}
+	log.Log.Object(vmi).V(1).Infof("published volume %s through the CSI driver %s", volumeName, csiVolume.Driver)
+	return entry.TargetPath, nil
+}
+
+func csiVolumeCapability(vmi *v1.VirtualMachineInstance, volumeName string, csiVolume *v1.HotplugCSIVolumeSource, isBlock bool) *csi.VolumeCapability {
+	capability := &csi.VolumeCapability{
+		AccessMode: &csi.VolumeCapability_AccessMode{
+			Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER,
+		},
+	}
+	if isBlock {
+		capability.Block = &csi.VolumeCapability_BlockVolume{}
+	} else {
+		capability.Mount = &csi.VolumeCapability_MountVolume{
+			FsType:     csiVolume.FSType,
+			MountFlags: csiVolume.MountOptions,
+		}
+	}
+	for _, status := range vmi.Status.VolumeStatus {
+		if status.Name != volumeName || status.PersistentVolumeClaimInfo == nil {
+			continue
+		}
+		for _, accessMode := range status.PersistentVolumeClaimInfo.AccessModes {
+			switch accessMode {
+			case k8sv1.ReadWriteMany:
+				capability.AccessMode.Mode = csi.VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER
+				return capability
+			case k8sv1.ReadOnlyMany:
+				capability.AccessMode.Mode = csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY
+			}
+		}
+	}
+	return capability
+}
+
+func (m *volumeMounter) writeCSIVolumeToMountRecord(entry vmiCSIVolumeEntry, vmi *v1.VirtualMachineInstance, record *vmiMountTargetRecord) error {
+	for _, existing := range record.CSIVolumeEntries {
+		if existing == entry {
+			return nil
+		}
+	}
+	record.CSIVolumeEntries = append(record.CSIVolumeEntries, entry)
+	return m.setMountTargetRecord(vmi, record)
+}
+
+// unpublishCSIVolumes unpublishes and unstages the recorded CSI volumes which are not kept, it returns the entries
+// which are kept or failed to be cleaned up.
+func (m *volumeMounter) unpublishCSIVolumes(entries []vmiCSIVolumeEntry, keep func(entry vmiCSIVolumeEntry) bool) ([]vmiCSIVolumeEntry, error) {
+	var remaining []vmiCSIVolumeEntry
+	var errs []error
+	for _, entry := range entries {
+		if keep(entry) {
+			remaining = append(remaining, entry)
+			continue
+		}
+		if err := m.unpublishCSIVolume(entry); err != nil {
+			errs = append(errs, fmt.Errorf("failed to unpublish volume %s through the CSI driver %s: %v", entry.VolumeName, entry.Driver, err))
+			remaining = append(remaining, entry)
+		}
+	}
+	return remaining, utilerrors.NewAggregate(errs)
+}
+
+func (m *volumeMounter) unpublishCSIVolume(entry vmiCSIVolumeEntry) error {
+	client, err := newCSINodeClient(csiPluginSocketPath(m.kubeletPluginsDir, entry.Driver))
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), csiOperationTimeout)
+	defer cancel()
+
+	if err := client.NodeUnpublishVolume(ctx, &csi.NodeUnpublishVolumeRequest{
+		VolumeId:   entry.VolumeHandle,
+		TargetPath: entry.TargetPath,
+	}); err != nil {
+		return err
+	}
+	if entry.StagingTargetPath != "" {
+		if err := client.NodeUnstageVolume(ctx, &csi.NodeUnstageVolumeRequest{
+			VolumeId:          entry.VolumeHandle,
+			StagingTargetPath: entry.StagingTargetPath,
+		}); err != nil {
+			return err
+		}
+		removeFromHost(entry.StagingTargetPath)
+	}
+	volumeDir := filepath.Dir(entry.TargetPath)
+	removeFromHost(volumeDir)
+	// Only succeeds once the last volume of the VMI is gone
+	removeFromHost(filepath.Dir(volumeDir))
+	log.Log.V(1).Infof("unpublished volume %s through the CSI driver %s", entry.VolumeName, entry.Driver)
+	return nil
+}
+
+// mkdirAllOnHost creates a directory and its parents on the node, without following symlinks
+func mkdirAllOnHost(dir string) error {
+	path, err := safepath.JoinAndResolveWithRelativeRoot(csiHostRoot)
+	if err != nil {
+		return err
+	}
+	for _, elem := range splitPath(dir) {
+		err := safepath.MkdirAtNoFollow(path, elem, 0750)
+		if err != nil && !errors.Is(err, os.ErrExist) {
+			return err
+		}
+		if path, err = safepath.JoinNoFollow(path, elem); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeFromHost removes an empty directory on the node, failures are only logged, as the directory is not needed anymore
+func removeFromHost(dir string) {
+	path, err := safepath.JoinAndResolveWithRelativeRoot(csiHostRoot, dir)
+	if err == nil {
+		err = safepath.UnlinkAtNoFollow(path)
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Log.V(3).Infof("failed to remove %s: %v", dir, err)
+	}
+}
+
+func splitPath(path string) []string {
+	var elems []string
+	for path = filepath.Clean(path); path != "/" && path != "."; path = filepath.Dir(path) {
+		elems = append([]string{filepath.Base(path)}, elems...)
+	}
+	return elems
+}
@@ -0,0 +1,326 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package hotplug_volume
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/api"
+
+	diskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
+	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
+	"kubevirt.io/kubevirt/pkg/safepath"
+	"kubevirt.io/kubevirt/pkg/unsafepath"
+	"kubevirt.io/kubevirt/pkg/virt-handler/hotplug-disk/csi"
+)
+
+type fakeCSINodeClient struct {
+	hostRoot     string
+	stage        bool
+	unpublishErr error
+	socketPaths  []string
+	calls        []string
+	stageReq     *csi.NodeStageVolumeRequest
+	publishReq   *csi.NodePublishVolumeRequest
+}
+
+func (c *fakeCSINodeClient) StageUnstageSupported(_ context.Context) (bool, error) {
+	return c.stage, nil
+}
+
+func (c *fakeCSINodeClient) NodeStageVolume(_ context.Context, req *csi.NodeStageVolumeRequest) error {
+	c.calls = append(c.calls, "stage")
+	c.stageReq = req
+	return nil
+}
+
+func (c *fakeCSINodeClient) NodeUnstageVolume(_ context.Context, _ *csi.NodeUnstageVolumeRequest) error {
+	c.calls = append(c.calls, "unstage")
+	return nil
+}
+
+func (c *fakeCSINodeClient) NodePublishVolume(_ context.Context, req *csi.NodePublishVolumeRequest) error {
+	c.calls = append(c.calls, "publish")
+	c.publishReq = req
+	// Like a driver, create the target and put a disk image into it
+	_, err := newFile(filepath.Join(c.hostRoot, req.TargetPath), "disk.img")
+	return err
+}
+
+func (c *fakeCSINodeClient) NodeUnpublishVolume(_ context.Context, req *csi.NodeUnpublishVolumeRequest) error {
+	c.calls = append(c.calls, "unpublish")
+	if c.unpublishErr != nil {
+		return c.unpublishErr
+	}
+	return os.RemoveAll(filepath.Join(c.hostRoot, req.TargetPath))
+}
+
+func (c *fakeCSINodeClient) Close() error {
+	return nil
+}
+
+var _ = Describe("HotplugVolume through CSI drivers", func() {
+	const (
+		pluginsDir = "/var/lib/kubelet/plugins"
+		volumeName = "csivolume"
+		volumeDir  = pluginsDir + "/" + csiHotplugDir + "/1234/" + volumeName
+	)
+
+	var (
+		orgCSIHostRoot      = csiHostRoot
+		orgNewCSINodeClient = newCSINodeClient
+
+		m                *volumeMounter
+		vmi              *v1.VirtualMachineInstance
+		client           *fakeCSINodeClient
+		hostRoot         string
+		targetPodPath    string
+		ownershipManager *diskutils.MockOwnershipManagerInterface
+	)
+
+	csiVolumeStatus := func(accessModes ...k8sv1.PersistentVolumeAccessMode) v1.VolumeStatus {
+		fs := k8sv1.PersistentVolumeFilesystem
+		return v1.VolumeStatus{
+			Name: volumeName,
+			PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{
+				VolumeMode:  &fs,
+				AccessModes: accessModes,
+			},
+			HotplugVolume: &v1.HotplugVolumeStatus{
+				CSIVolume: &v1.HotplugCSIVolumeSource{
+					Driver:           "csi.example.com",
+					VolumeHandle:     "handle",
+					FSType:           "ext4",
+					MountOptions:     []string{"noatime"},
+					VolumeAttributes: map[string]string{"key": "value"},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp("", "hotplug-volume-test")
+		Expect(err).ToNot(HaveOccurred())
+		hostRoot = filepath.Join(tempDir, "host")
+		Expect(os.MkdirAll(hostRoot, 0755)).To(Succeed())
+		csiHostRoot = hostRoot
+
+		client = &fakeCSINodeClient{hostRoot: hostRoot, stage: true}
+		newCSINodeClient = func(socketPath string) (csi.NodeClient, error) {
+			client.socketPaths = append(client.socketPaths, socketPath)
+			return client, nil
+		}
+
+		vmi = api.NewMinimalVMI("fake-vmi")
+		vmi.UID = "1234"
+		vmi.Status.ActivePods = map[types.UID]string{"abcd": "host"}
+
+		targetPodPath = filepath.Join(tempDir, "abcd/volumes/kubernetes.io~empty-dir/hotplug-disks")
+		Expect(os.MkdirAll(targetPodPath, 0755)).To(Succeed())
+
+		ownershipManager = diskutils.NewMockOwnershipManagerInterface(gomock.NewController(GinkgoT()))
+		m = &volumeMounter{
+			mountRecords:       make(map[types.UID]*vmiMountTargetRecord),
+			mountStateDir:      tempDir,
+			skipSafetyCheck:    true,
+			hotplugDiskManager: hotplugdisk.NewHotplugDiskWithOptions(tempDir),
+			ownershipManager:   ownershipManager,
+			kubeletPluginsDir:  pluginsDir,
+		}
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(tempDir)
+		csiHostRoot = orgCSIHostRoot
+		newCSINodeClient = orgNewCSINodeClient
+		mountCommand = orgMountCommand
+		unmountCommand = orgUnMountCommand
+		isMounted = orgIsMounted
+	})
+
+	mountCSIVolume := func() {
+		vmi.Spec.Volumes = []v1.Volume{{
+			Name: volumeName,
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "claim"},
+					Hotpluggable:                      true,
+				},
+			},
+		}}
+		vmi.Status.VolumeStatus = []v1.VolumeStatus{csiVolumeStatus()}
+		targetFilePath := filepath.Join(targetPodPath, volumeName+".img")
+		isMounted = func(_ *safepath.Path) (bool, error) {
+			return false, nil
+		}
+		mountCommand = func(sourcePath, targetPath *safepath.Path) ([]byte, error) {
+			Expect(unsafepath.UnsafeAbsolute(sourcePath.Raw())).To(Equal(filepath.Join(hostRoot, volumeDir, csiPublishTarget, "disk.img")))
+			Expect(unsafepath.UnsafeAbsolute(targetPath.Raw())).To(Equal(targetFilePath))
+			return []byte("Success"), nil
+		}
+		ownershipManager.EXPECT().SetFileOwnership(gomock.Any()).Return(nil)
+
+		Expect(m.Mount(vmi)).To(Succeed())
+	}
+
+	It("should stage, publish and mount a filesystem volume", func() {
+		mountCSIVolume()
+
+		Expect(client.socketPaths).To(ConsistOf(filepath.Join(hostRoot, pluginsDir, "csi.example.com", "csi.sock")))
+		Expect(client.calls).To(Equal([]string{"stage", "publish"}))
+		Expect(client.stageReq.VolumeId).To(Equal("handle"))
+		Expect(client.stageReq.StagingTargetPath).To(Equal(filepath.Join(volumeDir, csiStagingDir)))
+		Expect(client.stageReq.VolumeContext).To(HaveKeyWithValue("key", "value"))
+		Expect(client.stageReq.VolumeCapability.Mount.FsType).To(Equal("ext4"))
+		Expect(client.stageReq.VolumeCapability.Mount.MountFlags).To(ConsistOf("noatime"))
+		Expect(client.publishReq.TargetPath).To(Equal(filepath.Join(volumeDir, csiPublishTarget)))
+		Expect(client.publishReq.StagingTargetPath).To(Equal(client.stageReq.StagingTargetPath))
+		Expect(client.publishReq.Readonly).To(BeFalse())
+		Expect(filepath.Join(hostRoot, volumeDir, csiStagingDir)).To(BeADirectory())
+
+		record, err := m.getMountTargetRecord(vmi)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(record.MountTargetEntries).To(HaveLen(1))
+		Expect(record.CSIVolumeEntries).To(ConsistOf(vmiCSIVolumeEntry{
+			VolumeName:        volumeName,
+			Driver:            "csi.example.com",
+			VolumeHandle:      "handle",
+			StagingTargetPath: filepath.Join(volumeDir, csiStagingDir),
+			TargetPath:        filepath.Join(volumeDir, csiPublishTarget),
+		}))
+	})
+
+	It("should not stage volumes if the driver does not support it", func() {
+		client.stage = false
+		mountCSIVolume()
+
+		Expect(client.calls).To(Equal([]string{"publish"}))
+		Expect(client.publishReq.StagingTargetPath).To(BeEmpty())
+		record, err := m.getMountTargetRecord(vmi)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(record.CSIVolumeEntries).To(HaveLen(1))
+		Expect(record.CSIVolumeEntries[0].StagingTargetPath).To(BeEmpty())
+	})
+
+	It("should unpublish and unstage a volume once it is gone from the VMI", func() {
+		mountCSIVolume()
+		client.calls = nil
+
+		By("Keeping the volume while it is in the status")
+		isMounted = func(_ *safepath.Path) (bool, error) {
+			return true, nil
+		}
+		Expect(m.Unmount(vmi)).To(Succeed())
+		Expect(client.calls).To(BeEmpty())
+
+		By("Releasing the volume once it is removed")
+		vmi.Status.VolumeStatus = nil
+		unmountCommand = func(_ *safepath.Path) ([]byte, error) {
+			return []byte("Success"), nil
+		}
+		Expect(m.Unmount(vmi)).To(Succeed())
+		Expect(client.calls).To(Equal([]string{"unpublish", "unstage"}))
+		Expect(filepath.Join(hostRoot, pluginsDir, csiHotplugDir, "1234")).ToNot(BeAnExistingFile())
+		Expect(filepath.Join(tempDir, "1234")).ToNot(BeAnExistingFile())
+	})
+
+	It("should unpublish a volume removed from the spec while its status is kept", func() {
+		mountCSIVolume()
+		client.calls = nil
+		published, err := m.IsCSIVolumePublished(vmi, volumeName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(published).To(BeTrue())
+
+		vmi.Spec.Volumes = nil
+		isMounted = func(_ *safepath.Path) (bool, error) {
+			return true, nil
+		}
+		unmountCommand = func(_ *safepath.Path) ([]byte, error) {
+			return []byte("Success"), nil
+		}
+		By("Not publishing the volume again")
+		Expect(m.Mount(vmi)).To(Succeed())
+		Expect(client.calls).To(BeEmpty())
+
+		Expect(m.Unmount(vmi)).To(Succeed())
+		Expect(client.calls).To(Equal([]string{"unpublish", "unstage"}))
+		published, err = m.IsCSIVolumePublished(vmi, volumeName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(published).To(BeFalse())
+	})
+
+	It("should keep the volume in the record if it fails to be unpublished", func() {
+		mountCSIVolume()
+		client.unpublishErr = fmt.Errorf("unpublish error")
+
+		vmi.Status.VolumeStatus = nil
+		isMounted = func(_ *safepath.Path) (bool, error) {
+			return false, nil
+		}
+		err := m.Unmount(vmi)
+		Expect(err).To(MatchError(ContainSubstring("unpublish error")))
+
+		record, err := m.getMountTargetRecord(vmi)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(record.MountTargetEntries).To(BeEmpty())
+		Expect(record.CSIVolumeEntries).To(HaveLen(1))
+	})
+
+	It("unmountAll should unpublish all volumes", func() {
+		mountCSIVolume()
+		client.calls = nil
+
+		isMounted = func(_ *safepath.Path) (bool, error) {
+			return false, nil
+		}
+		Expect(m.UnmountAll(vmi)).To(Succeed())
+		Expect(client.calls).To(Equal([]string{"unpublish", "unstage"}))
+		Expect(filepath.Join(tempDir, "1234")).ToNot(BeAnExistingFile())
+	})
+
+	DescribeTable("should request the access mode of the PVC", func(expected csi.VolumeCapability_AccessMode_Mode, accessModes ...k8sv1.PersistentVolumeAccessMode) {
+		vmi.Status.VolumeStatus = []v1.VolumeStatus{csiVolumeStatus(accessModes...)}
+		capability := csiVolumeCapability(vmi, volumeName, vmi.Status.VolumeStatus[0].HotplugVolume.CSIVolume, false)
+		Expect(capability.AccessMode.Mode).To(Equal(expected))
+	},
+		Entry("ReadWriteOnce", csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER, k8sv1.ReadWriteOnce),
+		Entry("ReadWriteMany", csi.VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER, k8sv1.ReadWriteOnce, k8sv1.ReadWriteMany),
+		Entry("ReadOnlyMany", csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY, k8sv1.ReadOnlyMany),
+	)
+
+	It("should request a block volume without mount options", func() {
+		vmi.Status.VolumeStatus = []v1.VolumeStatus{csiVolumeStatus()}
+		capability := csiVolumeCapability(vmi, volumeName, vmi.Status.VolumeStatus[0].HotplugVolume.CSIVolume, true)
+		Expect(capability.Block).ToNot(BeNil())
+		Expect(capability.Mount).To(BeNil())
+	})
+})
@@ -78,6 +78,9 @@ var (
 	orgFindMntByDevice     = findMntByDevice
 	orgNodeIsolationResult = nodeIsolationResult
 	orgParentPathForMount  = parentPathForMount
+	orgConfigVolumeSource  = configVolumeSourcePath
+	orgCreateIsoImage      = createIsoImage
+	orgMountReadOnly       = mountReadOnlyCommand
 )
 
 var _ = Describe("HotplugVolume", func() {
@@ -574,6 +577,9 @@ var _ = Describe("HotplugVolume", func() {
 			unmountCommand = orgUnMountCommand
 			isMounted = orgIsMounted
 			isolationDetector = orgIsoDetector
+			configVolumeSourcePath = orgConfigVolumeSource
+			createIsoImage = orgCreateIsoImage
+			mountReadOnlyCommand = orgMountReadOnly
 		})
 
 		It("getSourcePodFile should find the disk.img file, if it exists", func() {
@@ -693,6 +699,78 @@ var _ = Describe("HotplugVolume", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		DescribeTable("should mount as a directory", func(setup func(vmi *v1.VirtualMachineInstance), expected bool) {
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{{Name: "testvolume", HotplugVolume: &v1.HotplugVolumeStatus{}}}
+			setup(vmi)
+			Expect(m.isDirectoryMounted(vmi, "testvolume")).To(Equal(expected))
+		},
+			Entry("a memory dump volume", func(vmi *v1.VirtualMachineInstance) {
+				vmi.Status.VolumeStatus[0].MemoryDumpVolume = &v1.DomainMemoryDumpInfo{}
+			}, true),
+			Entry("a volume shared through virtiofs", func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Domain.Devices.Filesystems = []v1.Filesystem{{Name: "testvolume", Virtiofs: &v1.FilesystemVirtiofs{}}}
+			}, true),
+			Entry("but not a disk volume", func(vmi *v1.VirtualMachineInstance) {}, false),
+		)
+
+		It("should build an iso image for a hotplugged configMap and mount it read-only", func() {
+			sourcePodUID := "ghfjk"
+			sourceDir, err := newDir(tempDir, sourcePodUID, "configmap")
+			Expect(err).ToNot(HaveOccurred())
+			configVolumeSourcePath = func(podUID types.UID, volume *v1.Volume) (*safepath.Path, error) {
+				Expect(podUID).To(Equal(types.UID(sourcePodUID)))
+				return sourceDir, nil
+			}
+			createIsoImage = func(source *safepath.Path, isoDir *safepath.Path, isoName string, volID string) error {
+				Expect(source).To(Equal(sourceDir))
+				Expect(isoName).To(Equal("testvolume.iso"))
+				Expect(volID).To(Equal("cfgdata"))
+				_, err := newFile(unsafepath.UnsafeAbsolute(isoDir.Raw()), isoName)
+				return err
+			}
+			targetFilePath, err := newFile(unsafepath.UnsafeAbsolute(targetPodPath.Raw()), "testvolume.img")
+			Expect(err).ToNot(HaveOccurred())
+			mountReadOnlyCommand = func(sourcePath, targetPath *safepath.Path) ([]byte, error) {
+				Expect(filepath.Base(unsafepath.UnsafeRelative(sourcePath.Raw()))).To(Equal("testvolume.iso"))
+				Expect(targetPath).To(Equal(targetFilePath))
+				return []byte("Success"), nil
+			}
+			ownershipManager.EXPECT().SetFileOwnership(gomock.Any())
+
+			volume := &v1.Volume{
+				Name: "testvolume",
+				VolumeSource: v1.VolumeSource{
+					ConfigMap: &v1.ConfigMapVolumeSource{
+						LocalObjectReference: k8sv1.LocalObjectReference{Name: "test-config"},
+						VolumeLabel:          "cfgdata",
+						Hotpluggable:         true,
+					},
+				},
+			}
+			err = m.mountConfigHotplugVolume(vmi, volume, types.UID(sourcePodUID), record)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(record.MountTargetEntries).To(HaveLen(1))
+			Expect(record.MountTargetEntries[0].TargetFile).To(Equal(unsafepath.UnsafeAbsolute(targetFilePath.Raw())))
+		})
+
+		It("should not fail mounting a hotplugged configMap that is not populated yet", func() {
+			configVolumeSourcePath = func(podUID types.UID, volume *v1.Volume) (*safepath.Path, error) {
+				return nil, fmt.Errorf("not found")
+			}
+			_, err := newFile(unsafepath.UnsafeAbsolute(targetPodPath.Raw()), "testvolume.img")
+			Expect(err).ToNot(HaveOccurred())
+
+			volume := &v1.Volume{
+				Name: "testvolume",
+				VolumeSource: v1.VolumeSource{
+					ConfigMap: &v1.ConfigMapVolumeSource{Hotpluggable: true},
+				},
+			}
+			err = m.mountConfigHotplugVolume(vmi, volume, "ghfjk", record)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(record.MountTargetEntries).To(BeEmpty())
+		})
+
 		It("unmountFileSystemHotplugVolumes should return error if isMounted returns error", func() {
 			testPath, err := newFile(tempDir, "test")
 			Expect(err).ToNot(HaveOccurred())
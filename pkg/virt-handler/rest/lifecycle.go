@@ -34,6 +34,8 @@ import (
 	"kubevirt.io/client-go/log"
 
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+	"kubevirt.io/kubevirt/pkg/virt-handler/fstrim"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
 
 const (
@@ -43,16 +45,18 @@ const (
 )
 
 type LifecycleHandler struct {
-	recorder     record.EventRecorder
-	vmiInformer  cache.SharedIndexInformer
-	virtShareDir string
+	recorder        record.EventRecorder
+	vmiInformer     cache.SharedIndexInformer
+	virtShareDir    string
+	fsTrimScheduler *fstrim.Scheduler
 }
 
-func NewLifecycleHandler(recorder record.EventRecorder, vmiInformer cache.SharedIndexInformer, virtShareDir string) *LifecycleHandler {
+func NewLifecycleHandler(recorder record.EventRecorder, vmiInformer cache.SharedIndexInformer, virtShareDir string, fsTrimScheduler *fstrim.Scheduler) *LifecycleHandler {
 	return &LifecycleHandler{
-		recorder:     recorder,
-		vmiInformer:  vmiInformer,
-		virtShareDir: virtShareDir,
+		recorder:        recorder,
+		vmiInformer:     vmiInformer,
+		virtShareDir:    virtShareDir,
+		fsTrimScheduler: fsTrimScheduler,
 	}
 }
 
@@ -162,6 +166,60 @@ func (lh *LifecycleHandler) SoftRebootHandler(request *restful.Request, response
 	response.WriteHeader(http.StatusAccepted)
 }
 
+// FSTrimHandler queues a trim of the guest filesystems, it doesn't wait for the trim
+// which may take longer than the request from virt-api is allowed to
+func (lh *LifecycleHandler) FSTrimHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, lh.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error(failedRetrieveVMI)
+		response.WriteError(code, err)
+		return
+	}
+
+	if err := lh.fsTrimScheduler.TrimNow(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to trim the guest filesystems")
+		response.WriteError(http.StatusConflict, err)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+// GuestExecHandler executes a command in the guest through the guest agent
+// and waits for it to exit
+func (lh *LifecycleHandler) GuestExecHandler(request *restful.Request, response *restful.Response) {
+	vmi, client, err := lh.getVMILauncherClient(request, response)
+	if err != nil {
+		return
+	}
+
+	options := &v1.GuestExecOptions{}
+	if request.Request.Body == nil {
+		log.Log.Object(vmi).Error("No command in guest exec request")
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("failed to retrieve the command to execute"))
+		return
+	}
+
+	defer request.Request.Body.Close()
+	if err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(options); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to unmarshal guest exec request")
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("failed to unmarshal the command to execute"))
+		return
+	}
+
+	exitCode, stdOut, err := client.Exec(api.VMINamespaceKeyFunc(vmi), options.Command, options.Args, options.TimeoutSeconds)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to execute %s in the guest", options.Command)
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteEntity(&v1.GuestExecResult{
+		ExitCode: int32(exitCode),
+		StdOut:   stdOut,
+	})
+}
+
 func (lh *LifecycleHandler) GetGuestInfo(request *restful.Request, response *restful.Response) {
 	log.Log.Info("Retreiving guestinfo")
 	vmi, client, err := lh.getVMILauncherClient(request, response)
@@ -87,6 +87,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/executor"
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
 	neterrors "kubevirt.io/kubevirt/pkg/network/errors"
+	networkvolume "kubevirt.io/kubevirt/pkg/storage/network-volume"
 	"kubevirt.io/kubevirt/pkg/storage/reservation"
 	pvctypes "kubevirt.io/kubevirt/pkg/storage/types"
 	virtutil "kubevirt.io/kubevirt/pkg/util"
@@ -96,12 +97,16 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
 	migrationproxy "kubevirt.io/kubevirt/pkg/virt-handler/migration-proxy"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter"
 	"kubevirt.io/kubevirt/pkg/watchdog"
 )
 
 type netconf interface {
 	Setup(vmi *v1.VirtualMachineInstance, networks []v1.Network, launcherPid int, preSetup func() error) error
 	Teardown(vmi *v1.VirtualMachineInstance) error
+	Reattach(vmi *v1.VirtualMachineInstance, launcherPid int) error
+	StartRouterAdvertisement(vmi *v1.VirtualMachineInstance, launcherPid int) error
+	RouterAdvertisementPending(vmi *v1.VirtualMachineInstance) bool
 }
 
 type netstat interface {
@@ -109,6 +114,9 @@ type netstat interface {
 	Teardown(vmi *v1.VirtualMachineInstance)
 	PodInterfaceVolatileDataIsCached(vmi *v1.VirtualMachineInstance, ifaceName string) bool
 	CachePodInterfaceVolatileData(vmi *v1.VirtualMachineInstance, ifaceName string, data *netcache.PodIfaceCacheData)
+	StartGuestIPLearning(vmi *v1.VirtualMachineInstance, launcherPID int) error
+	StopGuestIPLearning(vmi *v1.VirtualMachineInstance)
+	GuestIPLearningPending(vmi *v1.VirtualMachineInstance) bool
 }
 
 const (
@@ -541,6 +549,56 @@ func (d *VirtualMachineController) teardownNetwork(vmi *v1.VirtualMachineInstanc
 	d.netStat.Teardown(vmi)
 }
 
+// reattachNetworks wires back to the guest the pod interfaces replaced following a change of the network they are attached to.
+func (d *VirtualMachineController) reattachNetworks(vmi *v1.VirtualMachineInstance) error {
+	bridgedIfaces := netvmispec.FilterInterfacesSpec(vmi.Spec.Domain.Devices.Interfaces, func(iface v1.Interface) bool {
+		return iface.Bridge != nil && iface.State != v1.InterfaceStateAbsent
+	})
+	if len(bridgedIfaces) == 0 {
+		return nil
+	}
+
+	isolationRes, err := d.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		return fmt.Errorf(failedDetectIsolationFmt, err)
+	}
+	return d.netConf.Reattach(vmi, isolationRes.Pid())
+}
+
+// startGuestIPLearning learns the guest IP addresses of bridged interfaces, to report them while the guest agent is not connected.
+// Learning stops once the guest agent connects, as it reports the guest addresses from then on.
+func (d *VirtualMachineController) startGuestIPLearning(vmi *v1.VirtualMachineInstance) error {
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if condManager.HasCondition(vmi, v1.VirtualMachineInstanceAgentConnected) {
+		d.netStat.StopGuestIPLearning(vmi)
+		return nil
+	}
+	// The launcher pod is only looked up once an interface is left to learn
+	if !d.netStat.GuestIPLearningPending(vmi) {
+		return nil
+	}
+
+	isolationRes, err := d.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		return fmt.Errorf(failedDetectIsolationFmt, err)
+	}
+	return d.netStat.StartGuestIPLearning(vmi, isolationRes.Pid())
+}
+
+// startRouterAdvertisement advertises the IPv6 default route to the guest of masquerade interfaces setting router advertisement options.
+func (d *VirtualMachineController) startRouterAdvertisement(vmi *v1.VirtualMachineInstance) error {
+	// The launcher pod is only looked up once an interface is left to advertise
+	if !d.netConf.RouterAdvertisementPending(vmi) {
+		return nil
+	}
+
+	isolationRes, err := d.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		return fmt.Errorf(failedDetectIsolationFmt, err)
+	}
+	return d.netConf.StartRouterAdvertisement(vmi, isolationRes.Pid())
+}
+
 func (d *VirtualMachineController) setupNetwork(vmi *v1.VirtualMachineInstance, networks []v1.Network) error {
 	if len(networks) == 0 {
 		return nil
@@ -895,6 +953,7 @@ func (d *VirtualMachineController) updateVolumeStatusesFromDomain(vmi *v1.Virtua
 		for _, volume := range vmi.Spec.Volumes {
 			specVolumeMap[volume.Name] = volume
 		}
+		guestUsage := guestUsageFromDomain(domain)
 		newStatusMap := make(map[string]v1.VolumeStatus)
 		newStatuses := make([]v1.VolumeStatus, 0)
 		needsRefresh := false
@@ -903,8 +962,21 @@ func (d *VirtualMachineController) updateVolumeStatusesFromDomain(vmi *v1.Virtua
 			if _, ok := diskDeviceMap[volumeStatus.Name]; ok {
 				volumeStatus.Target = diskDeviceMap[volumeStatus.Name]
 			}
+			if guestUsage != nil {
+				volumeStatus.GuestUsage = guestUsage[volumeStatus.Name]
+			}
 			if volumeStatus.HotplugVolume != nil {
 				hasHotplug = true
+				if hotplug_volume.IsCSIVolumeDetaching(vmi, &volumeStatus) {
+					// Removing the status tells virt-controller it can release the claim of the volume
+					published, err := d.hotplugVolumeMounter.IsCSIVolumePublished(vmi, volumeStatus.Name)
+					if err != nil {
+						log.Log.Object(vmi).Errorf("error occurred while checking if volume %s is published: %v", volumeStatus.Name, err)
+					} else if !published {
+						log.Log.Object(vmi).Infof("Volume %s has been unpublished, removing its status", volumeStatus.Name)
+						continue
+					}
+				}
 				volumeStatus, tmpNeedsRefresh = d.updateHotplugVolumeStatus(vmi, volumeStatus, specVolumeMap)
 				needsRefresh = needsRefresh || tmpNeedsRefresh
 			}
@@ -1140,6 +1212,23 @@ func (d *VirtualMachineController) updateMemoryDumpInfo(vmi *v1.VirtualMachineIn
 	return volumeStatus, needsRefresh
 }
 
+// guestUsageFromDomain returns the usage of the guest filesystems per volume, or nil if the guest filesystems are
+// not known, like before the guest agent reported them.
+func guestUsageFromDomain(domain *api.Domain) map[string]*v1.VolumeGuestUsage {
+	if domain.Status.VolumeUsage == nil {
+		return nil
+	}
+	guestUsage := make(map[string]*v1.VolumeGuestUsage, len(domain.Status.VolumeUsage))
+	for _, volumeUsage := range domain.Status.VolumeUsage {
+		guestUsage[volumeUsage.Name] = &v1.VolumeGuestUsage{
+			UsedBytes:   volumeUsage.UsedBytes,
+			TotalBytes:  volumeUsage.TotalBytes,
+			MountPoints: volumeUsage.MountPoints,
+		}
+	}
+	return guestUsage
+}
+
 func (d *VirtualMachineController) updateFSFreezeStatus(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
 
 	if domain == nil || domain.Status.FSFreezeStatus.Status == "" {
@@ -1157,6 +1246,11 @@ func (d *VirtualMachineController) updateFSFreezeStatus(vmi *v1.VirtualMachineIn
 func IsoGuestVolumePath(vmi *v1.VirtualMachineInstance, volume *v1.Volume) (string, bool) {
 	var volPath string
 
+	// Hotplugged config volumes are not generated by virt-launcher
+	if virtutil.IsHotplugVolume(volume) {
+		return "", false
+	}
+
 	basepath := "/var/run"
 	if volume.CloudInitNoCloud != nil {
 		volPath = filepath.Join(basepath, "kubevirt-ephemeral-disks", "cloud-init-data", vmi.Namespace, vmi.Name, "noCloud.iso")
@@ -2408,14 +2502,10 @@ func (d *VirtualMachineController) checkVolumesForMigration(vmi *v1.VirtualMachi
 	// A relevant error will be returned in this case.
 	for _, volume := range vmi.Spec.Volumes {
 		volSrc := volume.VolumeSource
-		if volSrc.PersistentVolumeClaim != nil || volSrc.DataVolume != nil {
+		if volSrc.PersistentVolumeClaim != nil || volSrc.DataVolume != nil || volSrc.Overlay != nil {
 
-			var claimName string
-			if volSrc.PersistentVolumeClaim != nil {
-				claimName = volSrc.PersistentVolumeClaim.ClaimName
-			} else {
-				claimName = volSrc.DataVolume.Name
-			}
+			// the overlay is written on the overlay claim, its base claim is only ever read
+			claimName := pvctypes.PVCNameFromVirtVolume(&volume)
 
 			volumeStatus, ok := volumeStatusMap[volume.Name]
 
@@ -2430,6 +2520,12 @@ func (d *VirtualMachineController) checkVolumesForMigration(vmi *v1.VirtualMachi
 			if !shared {
 				return true, fmt.Errorf("cannot migrate VMI with non-shared HostDisk")
 			}
+		} else if virtutil.IsHotplugConfigVolume(&volume) || virtutil.IsHotplugContainerDiskVolume(&volume) {
+			// Hotplugged read-only volumes are provided to the migration target by its own attachment pod
+			continue
+		} else if networkvolume.IsNetworkVolume(&volume) {
+			// the migration target connects to the same network block device
+			continue
 		} else {
 			isVolumeUsedByReadOnlyDisk := false
 			for _, disk := range vmi.Spec.Domain.Devices.Disks {
@@ -2856,11 +2952,13 @@ func (d *VirtualMachineController) vmUpdateHelperDefault(origVMI *v1.VirtualMach
 	}
 
 	vmi := origVMI.DeepCopy()
-	// Find preallocated volumes
+	// Find preallocated volumes, they keep discard=unmap if the filesystem trim policy asks for it
 	var preallocatedVolumes []string
-	for _, volumeStatus := range vmi.Status.VolumeStatus {
-		if volumeStatus.PersistentVolumeClaimInfo != nil && volumeStatus.PersistentVolumeClaimInfo.Preallocated {
-			preallocatedVolumes = append(preallocatedVolumes, volumeStatus.Name)
+	if vmi.Spec.FilesystemTrim == nil || !vmi.Spec.FilesystemTrim.DiscardPreallocated {
+		for _, volumeStatus := range vmi.Status.VolumeStatus {
+			if volumeStatus.PersistentVolumeClaimInfo != nil && volumeStatus.PersistentVolumeClaimInfo.Preallocated {
+				preallocatedVolumes = append(preallocatedVolumes, volumeStatus.Name)
+			}
 		}
 	}
 
@@ -2999,6 +3097,20 @@ func (d *VirtualMachineController) vmUpdateHelperDefault(origVMI *v1.VirtualMach
 				d.recorder.Event(vmi, k8sv1.EventTypeWarning, "NicHotplug", err.Error())
 				errorTolerantFeaturesError = append(errorTolerantFeaturesError, err)
 			}
+
+			if err := d.reattachNetworks(vmi); err != nil {
+				log.Log.Object(vmi).Error(err.Error())
+				d.recorder.Event(vmi, k8sv1.EventTypeWarning, "NicReattach", err.Error())
+				errorTolerantFeaturesError = append(errorTolerantFeaturesError, err)
+			}
+		}
+
+		if err := d.startGuestIPLearning(vmi); err != nil {
+			log.Log.Object(vmi).Reason(err).Warning("failed to start learning the guest IP addresses")
+		}
+
+		if err := d.startRouterAdvertisement(vmi); err != nil {
+			log.Log.Object(vmi).Reason(err).Warning("failed to start the IPv6 router advertisement")
 		}
 	}
 
@@ -3043,10 +3155,53 @@ func (d *VirtualMachineController) vmUpdateHelperDefault(origVMI *v1.VirtualMach
 		if err := d.hotplugVolumeMounter.Unmount(vmi); err != nil {
 			return err
 		}
+
+		if err := d.syncIOTune(vmi, client); err != nil {
+			log.Log.Object(vmi).Error(err.Error())
+			d.recorder.Event(vmi, k8sv1.EventTypeWarning, "IOTuneChange", err.Error())
+			errorTolerantFeaturesError = append(errorTolerantFeaturesError, err)
+		}
 	}
 	return errors.NewAggregate(errorTolerantFeaturesError)
 }
 
+// syncIOTune updates the I/O throttling of the running domain disks when it differs from the VMI spec.
+func (d *VirtualMachineController) syncIOTune(vmi *v1.VirtualMachineInstance, client cmdclient.LauncherClient) error {
+	if migrations.IsMigrating(vmi) {
+		return nil
+	}
+	domain, exists, _, err := d.getDomainFromCache(controller.VirtualMachineInstanceKey(vmi))
+	if err != nil || !exists {
+		return err
+	}
+	if !isIOTuneChangeRequired(vmi, domain) {
+		return nil
+	}
+	if err := client.SyncVirtualMachineIOTune(vmi); err != nil {
+		return fmt.Errorf("failed to update disks I/O throttling: %v", err)
+	}
+	return nil
+}
+
+func isIOTuneChangeRequired(vmi *v1.VirtualMachineInstance, domain *api.Domain) bool {
+	domainDisksByName := map[string]api.Disk{}
+	for _, disk := range domain.Spec.Devices.Disks {
+		if disk.Alias != nil {
+			domainDisksByName[disk.Alias.GetName()] = disk
+		}
+	}
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		domainDisk, exists := domainDisksByName[disk.Name]
+		if !exists {
+			continue
+		}
+		if !converter.IOTuneEqual(converter.Convert_v1_DiskIOTune_To_api_IOTune(disk.IOTune), domainDisk.IOTune) {
+			return true
+		}
+	}
+	return false
+}
+
 func (d *VirtualMachineController) hotplugSriovInterfaces(vmi *v1.VirtualMachineInstance) error {
 	sriovSpecInterfaces := netvmispec.FilterSRIOVInterfaces(vmi.Spec.Domain.Devices.Interfaces)
 	sriovStatusInterfaces := netvmispec.FilterStatusInterfacesByNames(vmi.Status.Interfaces, netvmispec.InterfacesNames(sriovSpecInterfaces))
@@ -677,6 +677,62 @@ var _ = Describe("VirtualMachineInstance", func() {
 			testutils.ExpectEvent(recorder, VMIDefined)
 		})
 
+		DescribeTable("should pass preallocated volumes to keep them from being discarded", func(filesystemTrim *v1.FilesystemTrim, expectedVolumes []string) {
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+			vmi.Spec.FilesystemTrim = filesystemTrim
+			blockMode := k8sv1.PersistentVolumeBlock
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+			}
+			for _, name := range []string{"preallocated", "thin"} {
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: name,
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+							PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: name},
+						},
+					},
+				})
+			}
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{
+				{
+					Name: "preallocated",
+					PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{
+						Preallocated: true,
+						VolumeMode:   &blockMode,
+						AccessModes:  []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteMany},
+					},
+				},
+				{
+					Name: "thin",
+					PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{
+						VolumeMode:  &blockMode,
+						AccessModes: []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteMany},
+					},
+				},
+			}
+			vmi = addActivePods(vmi, podTestUUID, host)
+
+			mockWatchdog.CreateFile(vmi)
+			vmiFeeder.Add(vmi)
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) {
+				Expect(options.PreallocatedVolumes).To(Equal(expectedVolumes))
+			})
+			mockHotplugVolumeMounter.EXPECT().Mount(gomock.Any()).Return(nil)
+			controller.Execute()
+			testutils.ExpectEvent(recorder, VMIDefined)
+		},
+			Entry("without filesystem trim", nil, []string{"preallocated"}),
+			Entry("with filesystem trim", &v1.FilesystemTrim{}, []string{"preallocated"}),
+			Entry("unless the filesystem trim policy discards them", &v1.FilesystemTrim{DiscardPreallocated: true}, nil),
+		)
+
 		It("should update the qemu machine type on the VMI status", func() {
 			vmi := api2.NewMinimalVMI("testvmi")
 			vmi.UID = vmiTestUUID
@@ -1363,6 +1419,37 @@ var _ = Describe("VirtualMachineInstance", func() {
 				Expect(hasHotplug).To(BeFalse())
 			})
 
+			It("should update the guest usage of the volumes only once it is known", func() {
+				vmi := api2.NewMinimalVMI("testvmi")
+				vmi.UID = vmiTestUUID
+				vmi.Status.Phase = v1.Running
+				oldUsage := &v1.VolumeGuestUsage{UsedBytes: 1, TotalBytes: 100}
+				vmi.Status.VolumeStatus = []v1.VolumeStatus{
+					{Name: "rootdisk", GuestUsage: oldUsage},
+					{Name: "datadisk", GuestUsage: oldUsage},
+				}
+				domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+				domain.Status.Status = api.Running
+
+				By("keeping the usage while the guest filesystems are unknown")
+				controller.updateVolumeStatusesFromDomain(vmi, domain)
+				Expect(vmi.Status.VolumeStatus[0].GuestUsage).To(Equal(oldUsage))
+				Expect(vmi.Status.VolumeStatus[1].GuestUsage).To(Equal(oldUsage))
+
+				By("updating the usage once the guest filesystems are known")
+				domain.Status.VolumeUsage = []api.VolumeUsage{
+					{Name: "rootdisk", UsedBytes: 10, TotalBytes: 100, MountPoints: []string{"/"}},
+				}
+				controller.updateVolumeStatusesFromDomain(vmi, domain)
+				Expect(vmi.Status.VolumeStatus[0].Name).To(Equal("datadisk"))
+				Expect(vmi.Status.VolumeStatus[0].GuestUsage).To(BeNil())
+				Expect(vmi.Status.VolumeStatus[1].GuestUsage).To(Equal(&v1.VolumeGuestUsage{
+					UsedBytes:   10,
+					TotalBytes:  100,
+					MountPoints: []string{"/"},
+				}))
+			})
+
 			It("should have hashotplug true with hotplugged volumes", func() {
 				vmi := api2.NewMinimalVMI("testvmi")
 				vmi.UID = vmiTestUUID
@@ -1459,6 +1546,39 @@ var _ = Describe("VirtualMachineInstance", func() {
 				Entry("When current phase is bound for hotplug volume", v1.HotplugVolumeAttachedToNode),
 			)
 
+			It("should keep the status of an unplugged CSI volume until it is unpublished", func() {
+				vmi := api2.NewMinimalVMI("testvmi")
+				vmi.UID = vmiTestUUID
+				vmi.Status.Phase = v1.Running
+				vmi.Status.VolumeStatus = append(vmi.Status.VolumeStatus, v1.VolumeStatus{
+					Name:    "test",
+					Phase:   v1.HotplugVolumeDetaching,
+					Reason:  "reason",
+					Message: "message",
+					HotplugVolume: &v1.HotplugVolumeStatus{
+						CSIVolume: &v1.HotplugCSIVolumeSource{
+							Driver:       "csi.example.com",
+							VolumeHandle: "handle",
+						},
+					},
+				})
+				domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+				domain.Status.Status = api.Running
+				vmiFeeder.Add(vmi)
+				domainFeeder.Add(domain)
+				mockHotplugVolumeMounter.EXPECT().IsCSIVolumePublished(vmi, "test").Return(true, nil)
+				mockHotplugVolumeMounter.EXPECT().IsMounted(vmi, "test", gomock.Any()).Return(false, nil)
+				hasHotplug := controller.updateVolumeStatusesFromDomain(vmi, domain)
+				Expect(hasHotplug).To(BeTrue())
+				Expect(vmi.Status.VolumeStatus).To(HaveLen(1))
+				Expect(vmi.Status.VolumeStatus[0].Phase).To(Equal(v1.HotplugVolumeDetaching))
+
+				By("Removing the status once the volume is unpublished")
+				mockHotplugVolumeMounter.EXPECT().IsCSIVolumePublished(vmi, "test").Return(false, nil)
+				controller.updateVolumeStatusesFromDomain(vmi, domain)
+				Expect(vmi.Status.VolumeStatus).To(BeEmpty())
+			})
+
 			It("Should generate a ready event when target is assigned", func() {
 				vmi := api2.NewMinimalVMI("testvmi")
 				vmi.UID = vmiTestUUID
@@ -2558,6 +2678,61 @@ var _ = Describe("VirtualMachineInstance", func() {
 			Expect(blockMigrate).To(BeTrue())
 			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI with non-shared HostDisk")))
 		})
+		DescribeTable("should classify overlay volumes by the access mode of their overlay claim", func(accessMode k8sv1.PersistentVolumeAccessMode, expectedErr error) {
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{Name: "overlay", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusVirtio}}},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{Name: "overlay", VolumeSource: v1.VolumeSource{Overlay: &v1.OverlayVolumeSource{ClaimName: "overlay-pvc", BaseClaimName: "base-pvc"}}},
+			}
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{
+				{
+					Name: "overlay",
+					PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{
+						AccessModes: []k8sv1.PersistentVolumeAccessMode{accessMode},
+					},
+				},
+			}
+
+			blockMigrate, err := controller.checkVolumesForMigration(vmi)
+			if expectedErr == nil {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(blockMigrate).To(BeFalse())
+			} else {
+				Expect(err).To(Equal(expectedErr))
+				Expect(blockMigrate).To(BeTrue())
+			}
+		},
+			Entry("shared overlay claim", k8sv1.ReadWriteMany, nil),
+			Entry("non-shared overlay claim", k8sv1.ReadWriteOnce,
+				fmt.Errorf("cannot migrate VMI: PVC overlay-pvc is not shared, live migration requires that all PVCs must be shared (using ReadWriteMany access mode)")),
+		)
+		It("should fail migration of overlay volumes with an unknown overlay claim", func() {
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{
+				{Name: "overlay", VolumeSource: v1.VolumeSource{Overlay: &v1.OverlayVolumeSource{ClaimName: "overlay-pvc", BaseClaimName: "base-pvc"}}},
+			}
+
+			blockMigrate, err := controller.checkVolumesForMigration(vmi)
+			Expect(blockMigrate).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("Unable to determine if PVC overlay-pvc is shared")))
+		})
+		It("should be allowed to live-migrate network volumes without block migration", func() {
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{Name: "nbd", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusVirtio}}},
+				{Name: "rbd", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusVirtio}}},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{Name: "nbd", VolumeSource: v1.VolumeSource{NBD: &v1.NBDVolumeSource{URI: "nbd://nbd.example.com/disk0"}}},
+				{Name: "rbd", VolumeSource: v1.VolumeSource{RBD: &v1.RBDVolumeSource{Monitors: []string{"ceph-mon"}, Image: "vm-disk"}}},
+			}
+
+			blockMigrate, err := controller.checkVolumesForMigration(vmi)
+			Expect(blockMigrate).To(BeFalse())
+			Expect(err).ToNot(HaveOccurred())
+		})
 		DescribeTable("with host model", func(hostCpuModel string) {
 			vmi := api2.NewMinimalVMI("testvmi")
 			vmi.Spec.Domain.CPU = &v1.CPU{Model: v1.CPUModeHostModel}
@@ -3188,6 +3363,29 @@ var _ = Describe("VirtualMachineInstance", func() {
 
 })
 
+var _ = Describe("I/O throttling", func() {
+	newDomainWithDisk := func(name string, ioTune *api.IOTune) *api.Domain {
+		domain := api.NewMinimalDomain("testvmi")
+		domain.Spec.Devices.Disks = []api.Disk{{Alias: api.NewUserDefinedAlias(name), IOTune: ioTune}}
+		return domain
+	}
+
+	DescribeTable("should detect a required change", func(ioTune *v1.DiskIOTune, domain *api.Domain, expected bool) {
+		vmi := api2.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "rootdisk", IOTune: ioTune}}
+
+		Expect(isIOTuneChangeRequired(vmi, domain)).To(Equal(expected))
+	},
+		Entry("when no throttling is set", nil, newDomainWithDisk("rootdisk", nil), false),
+		Entry("when an empty throttling is set", &v1.DiskIOTune{}, newDomainWithDisk("rootdisk", nil), false),
+		Entry("when the throttling matches", &v1.DiskIOTune{ReadBytesSec: 1024}, newDomainWithDisk("rootdisk", &api.IOTune{ReadBytesSec: 1024}), false),
+		Entry("when the throttling is added", &v1.DiskIOTune{ReadBytesSec: 1024}, newDomainWithDisk("rootdisk", nil), true),
+		Entry("when the throttling is changed", &v1.DiskIOTune{ReadBytesSec: 2048}, newDomainWithDisk("rootdisk", &api.IOTune{ReadBytesSec: 1024}), true),
+		Entry("when the throttling is removed", nil, newDomainWithDisk("rootdisk", &api.IOTune{ReadBytesSec: 1024}), true),
+		Entry("when the disk is not in the domain", &v1.DiskIOTune{ReadBytesSec: 1024}, newDomainWithDisk("otherdisk", nil), false),
+	)
+})
+
 var _ = Describe("DomainNotifyServerRestarts", func() {
 	Context("should establish a notify server pipe", func() {
 		var shareDir string
@@ -3491,6 +3689,18 @@ func (nc *netConfStub) Teardown(vmi *v1.VirtualMachineInstance) error {
 	return nil
 }
 
+func (nc *netConfStub) Reattach(vmi *v1.VirtualMachineInstance, launcherPid int) error {
+	return nil
+}
+
+func (nc *netConfStub) StartRouterAdvertisement(vmi *v1.VirtualMachineInstance, launcherPid int) error {
+	return nil
+}
+
+func (nc *netConfStub) RouterAdvertisementPending(vmi *v1.VirtualMachineInstance) bool {
+	return false
+}
+
 func (nc *netConfStub) HotUnplugInterfaces(vmi *v1.VirtualMachineInstance) error {
 	return nil
 }
@@ -3523,3 +3733,10 @@ func (ns *netStatStub) PodInterfaceVolatileDataIsCached(vmi *v1.VirtualMachineIn
 }
 func (ns *netStatStub) CachePodInterfaceVolatileData(vmi *v1.VirtualMachineInstance, ifaceName string, data *netcache.PodIfaceCacheData) {
 }
+func (ns *netStatStub) StartGuestIPLearning(vmi *v1.VirtualMachineInstance, launcherPID int) error {
+	return nil
+}
+func (ns *netStatStub) StopGuestIPLearning(vmi *v1.VirtualMachineInstance) {}
+func (ns *netStatStub) GuestIPLearningPending(vmi *v1.VirtualMachineInstance) bool {
+	return false
+}
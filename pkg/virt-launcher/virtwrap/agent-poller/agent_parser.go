@@ -81,11 +81,30 @@ type User struct {
 
 // Filesystem of the host
 type Filesystem struct {
-	Name       string `json:"name"`
-	Mountpoint string `json:"mountpoint"`
-	Type       string `json:"type"`
-	UsedBytes  int    `json:"used-bytes,omitempty"`
-	TotalBytes int    `json:"total-bytes,omitempty"`
+	Name       string   `json:"name"`
+	Mountpoint string   `json:"mountpoint"`
+	Type       string   `json:"type"`
+	UsedBytes  int      `json:"used-bytes,omitempty"`
+	TotalBytes int      `json:"total-bytes,omitempty"`
+	Disk       []FSDisk `json:"disk,omitempty"`
+}
+
+// FSDisk a filesystem of the host is located on
+type FSDisk struct {
+	Serial        string        `json:"serial,omitempty"`
+	BusType       string        `json:"bus-type"`
+	Bus           int           `json:"bus"`
+	Target        int           `json:"target"`
+	Unit          int           `json:"unit"`
+	PCIController PCIController `json:"pci-controller"`
+}
+
+// PCIController of a disk
+type PCIController struct {
+	Domain   int `json:"domain"`
+	Bus      int `json:"bus"`
+	Slot     int `json:"slot"`
+	Function int `json:"function"`
 }
 
 // AgentInfo from the guest VM serves the purpose
@@ -196,12 +215,33 @@ func parseFilesystem(agentReply string) ([]api.Filesystem, error) {
 			Type:       fs.Type,
 			TotalBytes: fs.TotalBytes,
 			UsedBytes:  fs.UsedBytes,
+			Disk:       convertFSDisks(fs.Disk),
 		})
 	}
 
 	return convertedResult, nil
 }
 
+func convertFSDisks(disks []FSDisk) []api.FSDisk {
+	var convertedDisks []api.FSDisk
+	for _, disk := range disks {
+		convertedDisks = append(convertedDisks, api.FSDisk{
+			Serial:  disk.Serial,
+			BusType: disk.BusType,
+			Bus:     disk.Bus,
+			Target:  disk.Target,
+			Unit:    disk.Unit,
+			PCIController: api.PCIAddress{
+				Domain:   disk.PCIController.Domain,
+				Bus:      disk.PCIController.Bus,
+				Slot:     disk.PCIController.Slot,
+				Function: disk.PCIController.Function,
+			},
+		})
+	}
+	return convertedDisks
+}
+
 // parseUsers from the agent response
 func parseUsers(agentReply string) ([]api.User, error) {
 	result := []User{}
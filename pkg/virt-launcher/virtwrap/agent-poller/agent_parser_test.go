@@ -285,6 +285,50 @@ var _ = Describe("Qemu agent poller", func() {
 			Expect(parseFilesystem(jsonInput)).To(Equal(expectedFilesystem))
 		})
 
+		It("should parse the disks of a Filesystem", func() {
+
+			jsonInput := `{
+                "return":[
+                    {
+                        "name":"vda1",
+                        "mountpoint":"/",
+                        "type":"xfs",
+                        "total-bytes":99999,
+                        "used-bytes":33333,
+                        "disk":[
+                            {
+                                "serial":"rootdisk",
+                                "bus-type":"virtio",
+                                "bus":0,
+                                "unit":0,
+                                "target":0,
+                                "pci-controller":{"domain":0,"bus":7,"slot":0,"function":0},
+                                "dev":"/dev/vda1"
+                            }
+                        ]
+                    }
+                ]
+            }`
+
+			expectedFilesystem := []api.Filesystem{
+				{
+					Name:       "vda1",
+					Mountpoint: "/",
+					Type:       "xfs",
+					TotalBytes: 99999,
+					UsedBytes:  33333,
+					Disk: []api.FSDisk{
+						{
+							Serial:        "rootdisk",
+							BusType:       "virtio",
+							PCIController: api.PCIAddress{Bus: 7},
+						},
+					},
+				},
+			}
+			Expect(parseFilesystem(jsonInput)).To(Equal(expectedFilesystem))
+		})
+
 		It("should parse Users", func() {
 
 			jsonInput := `{
@@ -95,6 +95,8 @@ func (s *AsyncAgentStore) Store(key AgentCommand, value interface{}) {
 		case GET_FSFREEZE_STATUS:
 			status := value.(api.FSFreeze)
 			domainInfo.FSFreezeStatus = &status
+		case GET_FILESYSTEM:
+			domainInfo.Filesystems = value.([]api.Filesystem)
 		}
 
 		s.AgentUpdated <- AgentUpdatedEvent{
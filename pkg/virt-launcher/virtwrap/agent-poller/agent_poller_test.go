@@ -70,6 +70,27 @@ var _ = Describe("Qemu agent poller", func() {
 			Expect(agentStore.AgentUpdated).ToNot(Receive())
 		})
 
+		It("should fire an event for new filesystems", func() {
+			var agentStore = NewAsyncAgentStore()
+			filesystems := []api.Filesystem{
+				{
+					Name:       "vda1",
+					Mountpoint: "/",
+					UsedBytes:  10,
+					TotalBytes: 100,
+				},
+			}
+			agentStore.Store(GET_FILESYSTEM, filesystems)
+
+			Expect(agentStore.AgentUpdated).To(Receive(Equal(AgentUpdatedEvent{
+				Type:       GET_FILESYSTEM,
+				DomainInfo: api.DomainGuestInfo{Filesystems: filesystems},
+			})))
+
+			agentStore.Store(GET_FILESYSTEM, filesystems)
+			Expect(agentStore.AgentUpdated).ToNot(Receive())
+		})
+
 		It("should fire an event for new sysinfo data", func() {
 			var agentStore = NewAsyncAgentStore()
 
@@ -0,0 +1,145 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package agentpoller
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+const (
+	busTypeVirtio = "virtio"
+	busTypeSCSI   = "scsi"
+)
+
+// VolumeUsage correlates the guest filesystems to the disks of the domain, by the serial or the bus address of the
+// disks, and sums up the usage of the filesystems per volume. Filesystems which span several volumes, like LVM
+// volumes, are not accounted to any of them. The result is never nil, so that it can be told apart from unknown usage.
+func VolumeUsage(filesystems []api.Filesystem, devices *api.Devices) []api.VolumeUsage {
+	usage := map[string]*api.VolumeUsage{}
+	// A filesystem can be mounted several times, it is only accounted once per volume
+	accounted := map[string]map[string]bool{}
+	for _, fs := range filesystems {
+		volumeName := volumeOfFilesystem(fs, devices)
+		if volumeName == "" {
+			continue
+		}
+		volumeUsage, exists := usage[volumeName]
+		if !exists {
+			volumeUsage = &api.VolumeUsage{Name: volumeName}
+			usage[volumeName] = volumeUsage
+			accounted[volumeName] = map[string]bool{}
+		}
+		volumeUsage.MountPoints = append(volumeUsage.MountPoints, fs.Mountpoint)
+		if !accounted[volumeName][fs.Name] {
+			accounted[volumeName][fs.Name] = true
+			volumeUsage.UsedBytes += int64(fs.UsedBytes)
+			volumeUsage.TotalBytes += int64(fs.TotalBytes)
+		}
+	}
+
+	result := make([]api.VolumeUsage, 0, len(usage))
+	for _, volumeUsage := range usage {
+		sort.Strings(volumeUsage.MountPoints)
+		result = append(result, *volumeUsage)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// volumeOfFilesystem returns the volume all disks of the filesystem belong to, or an empty string
+func volumeOfFilesystem(fs api.Filesystem, devices *api.Devices) string {
+	volumeName := ""
+	for _, fsDisk := range fs.Disk {
+		name := volumeOfDisk(fsDisk, devices)
+		if name == "" || (volumeName != "" && volumeName != name) {
+			return ""
+		}
+		volumeName = name
+	}
+	return volumeName
+}
+
+func volumeOfDisk(fsDisk api.FSDisk, devices *api.Devices) string {
+	for _, disk := range devices.Disks {
+		if disk.Alias == nil || !disk.Alias.IsUserDefined() {
+			continue
+		}
+		if matchesSerial(fsDisk, disk) || matchesAddress(fsDisk, disk, devices.Controllers) {
+			return disk.Alias.GetName()
+		}
+	}
+	return ""
+}
+
+// matchesSerial compares the serials, some guests prefix the serial of SCSI and SATA disks with vendor and model
+func matchesSerial(fsDisk api.FSDisk, disk api.Disk) bool {
+	if disk.Serial == "" || fsDisk.Serial == "" {
+		return false
+	}
+	return fsDisk.Serial == disk.Serial || strings.HasSuffix(fsDisk.Serial, "_"+disk.Serial)
+}
+
+// matchesAddress compares the PCI address of virtio disks, and the SCSI address of SCSI disks together with the PCI
+// address of their controller
+func matchesAddress(fsDisk api.FSDisk, disk api.Disk, controllers []api.Controller) bool {
+	if disk.Address == nil {
+		return false
+	}
+	switch fsDisk.BusType {
+	case busTypeVirtio:
+		return disk.Target.Bus == busTypeVirtio && matchesPCIAddress(fsDisk.PCIController, disk.Address)
+	case busTypeSCSI:
+		if disk.Target.Bus != busTypeSCSI || disk.Address.Type != "drive" ||
+			!matchesNumber(fsDisk.Bus, disk.Address.Bus) ||
+			!matchesNumber(fsDisk.Target, disk.Address.Target) ||
+			!matchesNumber(fsDisk.Unit, disk.Address.Unit) {
+			return false
+		}
+		for _, controller := range controllers {
+			if controller.Type == busTypeSCSI && controller.Index == disk.Address.Controller && controller.Address != nil {
+				return matchesPCIAddress(fsDisk.PCIController, controller.Address)
+			}
+		}
+	}
+	return false
+}
+
+func matchesPCIAddress(pciAddress api.PCIAddress, address *api.Address) bool {
+	return address.Type == api.AddressPCI &&
+		matchesNumber(pciAddress.Domain, address.Domain) &&
+		matchesNumber(pciAddress.Bus, address.Bus) &&
+		matchesNumber(pciAddress.Slot, address.Slot) &&
+		matchesNumber(pciAddress.Function, address.Function)
+}
+
+// matchesNumber compares a number reported by the guest to a number of the domain, which may be hexadecimal
+func matchesNumber(guest int, domain string) bool {
+	if domain == "" {
+		return guest == 0
+	}
+	number, err := strconv.ParseInt(domain, 0, 64)
+	return err == nil && number == int64(guest)
+}
@@ -0,0 +1,146 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package agentpoller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+var _ = Describe("Volume usage", func() {
+	var devices *api.Devices
+
+	BeforeEach(func() {
+		devices = &api.Devices{
+			Disks: []api.Disk{
+				{
+					Alias:  api.NewUserDefinedAlias("rootdisk"),
+					Target: api.DiskTarget{Bus: "virtio", Device: "vda"},
+					Serial: "root-serial",
+					Address: &api.Address{
+						Type: api.AddressPCI, Domain: "0x0000", Bus: "0x07", Slot: "0x00", Function: "0x0",
+					},
+				},
+				{
+					Alias:  api.NewUserDefinedAlias("datadisk"),
+					Target: api.DiskTarget{Bus: "virtio", Device: "vdb"},
+					Address: &api.Address{
+						Type: api.AddressPCI, Domain: "0x0000", Bus: "0x08", Slot: "0x00", Function: "0x0",
+					},
+				},
+				{
+					Alias:  api.NewUserDefinedAlias("scsidisk"),
+					Target: api.DiskTarget{Bus: "scsi", Device: "sda"},
+					Address: &api.Address{
+						Type: "drive", Controller: "0", Bus: "0", Target: "0", Unit: "1",
+					},
+				},
+			},
+			Controllers: []api.Controller{
+				{
+					Type:  "scsi",
+					Index: "0",
+					Address: &api.Address{
+						Type: api.AddressPCI, Domain: "0x0000", Bus: "0x05", Slot: "0x00", Function: "0x0",
+					},
+				},
+			},
+		}
+	})
+
+	filesystem := func(name, mountpoint string, used, total int, disks ...api.FSDisk) api.Filesystem {
+		return api.Filesystem{
+			Name:       name,
+			Mountpoint: mountpoint,
+			UsedBytes:  used,
+			TotalBytes: total,
+			Disk:       disks,
+		}
+	}
+
+	virtioDisk := func(serial string, bus int) api.FSDisk {
+		return api.FSDisk{Serial: serial, BusType: "virtio", PCIController: api.PCIAddress{Bus: bus}}
+	}
+
+	It("should report no usage if the filesystems are unknown", func() {
+		usage := VolumeUsage(nil, devices)
+		Expect(usage).ToNot(BeNil())
+		Expect(usage).To(BeEmpty())
+	})
+
+	It("should correlate filesystems by the serial of the disk", func() {
+		usage := VolumeUsage([]api.Filesystem{
+			filesystem("vda1", "/", 10, 100, virtioDisk("root-serial", 42)),
+			filesystem("sda1", "/data", 1, 2, api.FSDisk{Serial: "0QEMU_QEMU_HARDDISK_root-serial", BusType: "scsi"}),
+		}, devices)
+		Expect(usage).To(Equal([]api.VolumeUsage{
+			{Name: "rootdisk", UsedBytes: 11, TotalBytes: 102, MountPoints: []string{"/", "/data"}},
+		}))
+	})
+
+	It("should correlate filesystems by the PCI address of virtio disks", func() {
+		usage := VolumeUsage([]api.Filesystem{
+			filesystem("vdb1", "/data", 20, 200, virtioDisk("", 8)),
+		}, devices)
+		Expect(usage).To(Equal([]api.VolumeUsage{
+			{Name: "datadisk", UsedBytes: 20, TotalBytes: 200, MountPoints: []string{"/data"}},
+		}))
+	})
+
+	It("should correlate filesystems by the SCSI address and the controller of SCSI disks", func() {
+		usage := VolumeUsage([]api.Filesystem{
+			filesystem("sda1", "/scsi", 30, 300, api.FSDisk{BusType: "scsi", Unit: 1, PCIController: api.PCIAddress{Bus: 5}}),
+			filesystem("sdb1", "/other", 40, 400, api.FSDisk{BusType: "scsi", Unit: 1, PCIController: api.PCIAddress{Bus: 6}}),
+		}, devices)
+		Expect(usage).To(Equal([]api.VolumeUsage{
+			{Name: "scsidisk", UsedBytes: 30, TotalBytes: 300, MountPoints: []string{"/scsi"}},
+		}))
+	})
+
+	It("should account a filesystem mounted several times only once", func() {
+		usage := VolumeUsage([]api.Filesystem{
+			filesystem("vda2", "/var", 10, 100, virtioDisk("", 7)),
+			filesystem("vda2", "/home", 10, 100, virtioDisk("", 7)),
+		}, devices)
+		Expect(usage).To(Equal([]api.VolumeUsage{
+			{Name: "rootdisk", UsedBytes: 10, TotalBytes: 100, MountPoints: []string{"/home", "/var"}},
+		}))
+	})
+
+	It("should not account filesystems spanning several volumes", func() {
+		usage := VolumeUsage([]api.Filesystem{
+			filesystem("dm-0", "/", 10, 100, virtioDisk("", 7), virtioDisk("", 8)),
+			filesystem("vdb1", "/data", 20, 200, virtioDisk("", 8)),
+		}, devices)
+		Expect(usage).To(Equal([]api.VolumeUsage{
+			{Name: "datadisk", UsedBytes: 20, TotalBytes: 200, MountPoints: []string{"/data"}},
+		}))
+	})
+
+	It("should ignore filesystems without known disks", func() {
+		usage := VolumeUsage([]api.Filesystem{
+			filesystem("tmpfs", "/tmp", 10, 100),
+			filesystem("vdz1", "/unknown", 10, 100, virtioDisk("", 9)),
+		}, devices)
+		Expect(usage).To(BeEmpty())
+	})
+})
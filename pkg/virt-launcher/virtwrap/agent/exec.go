@@ -38,16 +38,30 @@ func (e ExecExitCode) Error() string {
 // The resulting stdout will be returned as a string
 func GuestExec(virConn cli.Connection, domName string, command string, args []string, timeoutSeconds int32) (string, error) {
 	stdOut := ""
+	if timeoutSeconds <= 0 {
+		return "", fmt.Errorf("invalid timeout %d for command [%s]", timeoutSeconds, command)
+	}
+
+	// the command and its arguments may come from users, quote them
+	// so they can't break out of the JSON strings
 	argsStr := ""
 	for _, arg := range args {
+		quotedArg, err := json.Marshal(arg)
+		if err != nil {
+			return "", err
+		}
 		if argsStr == "" {
-			argsStr = fmt.Sprintf("\"%s\"", arg)
+			argsStr = string(quotedArg)
 		} else {
-			argsStr = argsStr + fmt.Sprintf(", \"%s\"", arg)
+			argsStr = argsStr + ", " + string(quotedArg)
 		}
 	}
+	quotedCommand, err := json.Marshal(command)
+	if err != nil {
+		return "", err
+	}
 
-	cmdExec := fmt.Sprintf(`{"execute": "guest-exec", "arguments": { "path": "%s", "arg": [ %s ], "capture-output":true } }`, command, argsStr)
+	cmdExec := fmt.Sprintf(`{"execute": "guest-exec", "arguments": { "path": %s, "arg": [ %s ], "capture-output":true } }`, quotedCommand, argsStr)
 	output, err := virConn.QemuAgentCommand(cmdExec, domName)
 	if err != nil {
 		return "", err
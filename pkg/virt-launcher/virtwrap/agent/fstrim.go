@@ -0,0 +1,43 @@
+package agent
+
+import (
+	"encoding/json"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+)
+
+type fstrimReturn struct {
+	Return fstrimReturnData `json:"return"`
+}
+type fstrimReturnData struct {
+	Paths []fstrimPath `json:"paths"`
+}
+type fstrimPath struct {
+	Path    string `json:"path"`
+	Trimmed int64  `json:"trimmed"`
+	Error   string `json:"error"`
+}
+
+// GuestFSTrim discards the unused blocks of all mounted guest filesystems and returns the result per filesystem.
+// Filesystems which can't be trimmed, e.g. because the disk doesn't support discard, are reported with an error
+func GuestFSTrim(virConn cli.Connection, domName string) ([]api.FSTrimmed, error) {
+	output, err := virConn.QemuAgentCommand(`{"execute":"guest-fstrim"}`, domName)
+	if err != nil {
+		return nil, err
+	}
+	res := &fstrimReturn{}
+	if err := json.Unmarshal([]byte(output), res); err != nil {
+		return nil, err
+	}
+
+	trimmed := make([]api.FSTrimmed, 0, len(res.Return.Paths))
+	for _, path := range res.Return.Paths {
+		trimmed = append(trimmed, api.FSTrimmed{
+			Mountpoint:   path.Path,
+			TrimmedBytes: path.Trimmed,
+			Error:        path.Error,
+		})
+	}
+	return trimmed, nil
+}
@@ -40,6 +40,7 @@ var _ = ginkgo.Describe("Generated deepcopy functions", func() {
 			&DiskTarget{},
 			&DiskDriver{},
 			&DiskSourceHost{},
+			&DiskSourceInitiator{},
 			&Serial{},
 			&SerialTarget{},
 			&Console{},
@@ -116,6 +116,8 @@ type DomainStatus struct {
 	Interfaces     []InterfaceStatus
 	OSInfo         GuestOSInfo
 	FSFreezeStatus FSFreeze
+	// VolumeUsage is nil as long as the guest filesystems are unknown
+	VolumeUsage []VolumeUsage
 }
 
 type DomainSysInfo struct {
@@ -162,6 +164,40 @@ type Filesystem struct {
 	Type       string
 	UsedBytes  int
 	TotalBytes int
+	Disk       []FSDisk
+}
+
+// FSDisk is a disk a guest filesystem is located on, as seen by the guest
+type FSDisk struct {
+	Serial        string
+	BusType       string
+	Bus           int
+	Target        int
+	Unit          int
+	PCIController PCIAddress
+}
+
+// PCIAddress is the PCI address of a device, as seen by the guest
+type PCIAddress struct {
+	Domain   int
+	Bus      int
+	Slot     int
+	Function int
+}
+
+// VolumeUsage is the usage of the guest filesystems located on a volume
+type VolumeUsage struct {
+	Name        string
+	UsedBytes   int64
+	TotalBytes  int64
+	MountPoints []string
+}
+
+// FSTrimmed is the result of trimming one guest filesystem
+type FSTrimmed struct {
+	Mountpoint   string
+	TrimmedBytes int64
+	Error        string
 }
 
 type User struct {
@@ -175,6 +211,7 @@ type DomainGuestInfo struct {
 	Interfaces     []InterfaceStatus
 	OSInfo         *GuestOSInfo
 	FSFreezeStatus *FSFreeze
+	Filesystems    []Filesystem
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -588,6 +625,7 @@ type Controller struct {
 	Index   string            `xml:"index,attr"`
 	Model   string            `xml:"model,attr,omitempty"`
 	Driver  *ControllerDriver `xml:"driver,omitempty"`
+	Serial  string            `xml:"serial,omitempty"`
 	Alias   *Alias            `xml:"alias,omitempty"`
 	Address *Address          `xml:"address,omitempty"`
 }
@@ -606,25 +644,33 @@ type ControllerDriver struct {
 // BEGIN Disk -----------------------------
 
 type Disk struct {
-	Device             string         `xml:"device,attr"`
-	Snapshot           string         `xml:"snapshot,attr,omitempty"`
-	Type               string         `xml:"type,attr"`
-	Source             DiskSource     `xml:"source"`
-	Target             DiskTarget     `xml:"target"`
-	Serial             string         `xml:"serial,omitempty"`
-	Driver             *DiskDriver    `xml:"driver,omitempty"`
-	ReadOnly           *ReadOnly      `xml:"readonly,omitempty"`
-	Auth               *DiskAuth      `xml:"auth,omitempty"`
-	Alias              *Alias         `xml:"alias,omitempty"`
-	BackingStore       *BackingStore  `xml:"backingStore,omitempty"`
-	BootOrder          *BootOrder     `xml:"boot,omitempty"`
-	Address            *Address       `xml:"address,omitempty"`
-	Model              string         `xml:"model,attr,omitempty"`
-	BlockIO            *BlockIO       `xml:"blockio,omitempty"`
-	FilesystemOverhead *cdiv1.Percent `xml:"filesystemOverhead,omitempty"`
-	Capacity           *int64         `xml:"capacity,omitempty"`
-	ExpandDisksEnabled bool           `xml:"expandDisksEnabled,omitempty"`
-	Shareable          *Shareable     `xml:"shareable,omitempty"`
+	Device             string          `xml:"device,attr"`
+	Snapshot           string          `xml:"snapshot,attr,omitempty"`
+	Type               string          `xml:"type,attr"`
+	Source             DiskSource      `xml:"source"`
+	Target             DiskTarget      `xml:"target"`
+	Serial             string          `xml:"serial,omitempty"`
+	WWN                string          `xml:"wwn,omitempty"`
+	Driver             *DiskDriver     `xml:"driver,omitempty"`
+	ReadOnly           *ReadOnly       `xml:"readonly,omitempty"`
+	Auth               *DiskAuth       `xml:"auth,omitempty"`
+	Alias              *Alias          `xml:"alias,omitempty"`
+	BackingStore       *BackingStore   `xml:"backingStore,omitempty"`
+	BootOrder          *BootOrder      `xml:"boot,omitempty"`
+	Address            *Address        `xml:"address,omitempty"`
+	Model              string          `xml:"model,attr,omitempty"`
+	BlockIO            *BlockIO        `xml:"blockio,omitempty"`
+	FilesystemOverhead *cdiv1.Percent  `xml:"filesystemOverhead,omitempty"`
+	Capacity           *int64          `xml:"capacity,omitempty"`
+	ExpandDisksEnabled bool            `xml:"expandDisksEnabled,omitempty"`
+	Shareable          *Shareable      `xml:"shareable,omitempty"`
+	IOTune             *IOTune         `xml:"iotune,omitempty"`
+	Encryption         *DiskEncryption `xml:"encryption,omitempty"`
+}
+
+type DiskEncryption struct {
+	Format string      `xml:"format,attr"`
+	Secret *DiskSecret `xml:"secret,omitempty"`
 }
 
 type DiskAuth struct {
@@ -641,13 +687,14 @@ type DiskSecret struct {
 type ReadOnly struct{}
 
 type DiskSource struct {
-	Dev           string          `xml:"dev,attr,omitempty"`
-	File          string          `xml:"file,attr,omitempty"`
-	StartupPolicy string          `xml:"startupPolicy,attr,omitempty"`
-	Protocol      string          `xml:"protocol,attr,omitempty"`
-	Name          string          `xml:"name,attr,omitempty"`
-	Host          *DiskSourceHost `xml:"host,omitempty"`
-	Reservations  *Reservations   `xml:"reservations,omitempty"`
+	Dev           string               `xml:"dev,attr,omitempty"`
+	File          string               `xml:"file,attr,omitempty"`
+	StartupPolicy string               `xml:"startupPolicy,attr,omitempty"`
+	Protocol      string               `xml:"protocol,attr,omitempty"`
+	Name          string               `xml:"name,attr,omitempty"`
+	Hosts         []DiskSourceHost     `xml:"host,omitempty"`
+	Initiator     *DiskSourceInitiator `xml:"initiator,omitempty"`
+	Reservations  *Reservations        `xml:"reservations,omitempty"`
 }
 
 type DiskTarget struct {
@@ -673,6 +720,14 @@ type DiskSourceHost struct {
 	Port string `xml:"port,attr,omitempty"`
 }
 
+type DiskSourceInitiator struct {
+	IQN DiskSourceInitiatorIQN `xml:"iqn"`
+}
+
+type DiskSourceInitiatorIQN struct {
+	Name string `xml:"name,attr"`
+}
+
 type BackingStore struct {
 	Type   string              `xml:"type,attr,omitempty"`
 	Format *BackingStoreFormat `xml:"format,omitempty"`
@@ -688,6 +743,22 @@ type BlockIO struct {
 	PhysicalBlockSize uint `xml:"physical_block_size,attr,omitempty"`
 }
 
+type IOTune struct {
+	TotalBytesSec    uint64 `xml:"total_bytes_sec,omitempty"`
+	ReadBytesSec     uint64 `xml:"read_bytes_sec,omitempty"`
+	WriteBytesSec    uint64 `xml:"write_bytes_sec,omitempty"`
+	TotalIopsSec     uint64 `xml:"total_iops_sec,omitempty"`
+	ReadIopsSec      uint64 `xml:"read_iops_sec,omitempty"`
+	WriteIopsSec     uint64 `xml:"write_iops_sec,omitempty"`
+	TotalBytesSecMax uint64 `xml:"total_bytes_sec_max,omitempty"`
+	ReadBytesSecMax  uint64 `xml:"read_bytes_sec_max,omitempty"`
+	WriteBytesSecMax uint64 `xml:"write_bytes_sec_max,omitempty"`
+	TotalIopsSecMax  uint64 `xml:"total_iops_sec_max,omitempty"`
+	ReadIopsSecMax   uint64 `xml:"read_iops_sec_max,omitempty"`
+	WriteIopsSecMax  uint64 `xml:"write_iops_sec_max,omitempty"`
+	GroupName        string `xml:"group_name,omitempty"`
+}
+
 type Reservations struct {
 	Managed            string              `xml:"managed,attr,omitempty"`
 	SourceReservations *SourceReservations `xml:"source,omitempty"`
@@ -797,6 +868,8 @@ type FilterRef struct {
 }
 
 type InterfaceSource struct {
+	Type    string   `xml:"type,attr,omitempty"`
+	Path    string   `xml:"path,attr,omitempty"`
 	Network string   `xml:"network,attr,omitempty"`
 	Device  string   `xml:"dev,attr,omitempty"`
 	Bridge  string   `xml:"bridge,attr,omitempty"`
@@ -1129,11 +1202,12 @@ type SecretUsage struct {
 }
 
 type SecretSpec struct {
-	XMLName     xml.Name    `xml:"secret"`
-	Ephemeral   string      `xml:"ephemeral,attr"`
-	Private     string      `xml:"private,attr"`
-	Description string      `xml:"description,omitempty"`
-	Usage       SecretUsage `xml:"usage,omitempty"`
+	XMLName     xml.Name     `xml:"secret"`
+	Ephemeral   string       `xml:"ephemeral,attr"`
+	Private     string       `xml:"private,attr"`
+	UUID        string       `xml:"uuid,omitempty"`
+	Description string       `xml:"description,omitempty"`
+	Usage       *SecretUsage `xml:"usage,omitempty"`
 }
 
 func NewMinimalDomainSpec(vmiName string) *DomainSpec {
@@ -305,8 +305,8 @@ var _ = ginkgo.Describe("Schema", func() {
 				Driver: &DiskDriver{Name: "qemu",
 					Type: "raw"},
 				Source: DiskSource{Protocol: "iscsi",
-					Name: "iqn.2013-07.com.example:iscsi-nopool/2",
-					Host: &DiskSourceHost{Name: "example.com", Port: "3260"}},
+					Name:  "iqn.2013-07.com.example:iscsi-nopool/2",
+					Hosts: []DiskSourceHost{{Name: "example.com", Port: "3260"}}},
 				Target: DiskTarget{Device: "vda"},
 				Alias:  NewUserDefinedAlias("mydisk"),
 			},
@@ -211,6 +211,17 @@ func (_mr *_MockConnectionRecorder) GetSEVInfo() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetSEVInfo")
 }
 
+func (_m *MockConnection) SecretDefineXML(xml string) (VirSecret, error) {
+	ret := _m.ctrl.Call(_m, "SecretDefineXML", xml)
+	ret0, _ := ret[0].(VirSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockConnectionRecorder) SecretDefineXML(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SecretDefineXML", arg0)
+}
+
 // Mock of Stream interface
 type MockStream struct {
 	ctrl     *gomock.Controller
@@ -408,6 +419,16 @@ func (_mr *_MockVirDomainRecorder) DetachDeviceFlags(arg0, arg1 interface{}) *go
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "DetachDeviceFlags", arg0, arg1)
 }
 
+func (_m *MockVirDomain) UpdateDeviceFlags(xml string, flags libvirt.DomainDeviceModifyFlags) error {
+	ret := _m.ctrl.Call(_m, "UpdateDeviceFlags", xml, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirDomainRecorder) UpdateDeviceFlags(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UpdateDeviceFlags", arg0, arg1)
+}
+
 func (_m *MockVirDomain) DestroyFlags(flags libvirt.DomainDestroyFlags) error {
 	ret := _m.ctrl.Call(_m, "DestroyFlags", flags)
 	ret0, _ := ret[0].(error)
@@ -657,6 +678,16 @@ func (_mr *_MockVirDomainRecorder) SetVcpusFlags(arg0, arg1 interface{}) *gomock
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetVcpusFlags", arg0, arg1)
 }
 
+func (_m *MockVirDomain) SetBlockIoTune(disk string, params *libvirt.DomainBlockIoTuneParameters, flags libvirt.DomainModificationImpact) error {
+	ret := _m.ctrl.Call(_m, "SetBlockIoTune", disk, params, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirDomainRecorder) SetBlockIoTune(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetBlockIoTune", arg0, arg1, arg2)
+}
+
 func (_m *MockVirDomain) GetLaunchSecurityInfo(flags uint32) (*libvirt.DomainLaunchSecurityParameters, error) {
 	ret := _m.ctrl.Call(_m, "GetLaunchSecurityInfo", flags)
 	ret0, _ := ret[0].(*libvirt.DomainLaunchSecurityParameters)
@@ -677,3 +708,54 @@ func (_m *MockVirDomain) SetLaunchSecurityState(params *libvirt.DomainLaunchSecu
 func (_mr *_MockVirDomainRecorder) SetLaunchSecurityState(arg0, arg1 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetLaunchSecurityState", arg0, arg1)
 }
+
+// Mock of VirSecret interface
+type MockVirSecret struct {
+	ctrl     *gomock.Controller
+	recorder *_MockVirSecretRecorder
+}
+
+// Recorder for MockVirSecret (not exported)
+type _MockVirSecretRecorder struct {
+	mock *MockVirSecret
+}
+
+func NewMockVirSecret(ctrl *gomock.Controller) *MockVirSecret {
+	mock := &MockVirSecret{ctrl: ctrl}
+	mock.recorder = &_MockVirSecretRecorder{mock}
+	return mock
+}
+
+func (_m *MockVirSecret) EXPECT() *_MockVirSecretRecorder {
+	return _m.recorder
+}
+
+func (_m *MockVirSecret) SetValue(value []byte, flags uint32) error {
+	ret := _m.ctrl.Call(_m, "SetValue", value, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirSecretRecorder) SetValue(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetValue", arg0, arg1)
+}
+
+func (_m *MockVirSecret) Undefine() error {
+	ret := _m.ctrl.Call(_m, "Undefine")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirSecretRecorder) Undefine() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Undefine")
+}
+
+func (_m *MockVirSecret) Free() error {
+	ret := _m.ctrl.Call(_m, "Free")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirSecretRecorder) Free() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Free")
+}
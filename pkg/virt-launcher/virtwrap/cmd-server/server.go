@@ -191,6 +191,23 @@ func (l *Launcher) SyncVirtualMachineCPUs(_ context.Context, request *cmdv1.VMIR
 	return response, nil
 }
 
+func (l *Launcher) SyncVirtualMachineIOTune(_ context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.UpdateIOTune(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to update VMI disks I/O throttling")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("VMI disks I/O throttling has been updated")
+	return response, nil
+}
+
 func (l *Launcher) SyncVirtualMachine(_ context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
 
 	vmi, response := getVMIFromRequest(request.Vmi)
@@ -293,6 +310,34 @@ func (l *Launcher) UnfreezeVirtualMachine(_ context.Context, request *cmdv1.VMIR
 	return response, nil
 }
 
+func (l *Launcher) GuestFSTrim(_ context.Context, request *cmdv1.VMIRequest) (*cmdv1.GuestFSTrimResponse, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	fstrimResponse := &cmdv1.GuestFSTrimResponse{Response: response}
+	if !response.Success {
+		return fstrimResponse, nil
+	}
+
+	trimmed, err := l.domainManager.FSTrimVMI(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to trim the filesystems of vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return fstrimResponse, nil
+	}
+
+	jTrimmed, err := json.Marshal(trimmed)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to marshal the filesystem trim result")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return fstrimResponse, nil
+	}
+	fstrimResponse.GuestFSTrimResponse = string(jTrimmed)
+
+	log.Log.Object(vmi).Info("Trimmed the filesystems of vmi")
+	return fstrimResponse, nil
+}
+
 func (l *Launcher) SoftRebootVirtualMachine(_ context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
 	vmi, response := getVMIFromRequest(request.Vmi)
 	if !response.Success {
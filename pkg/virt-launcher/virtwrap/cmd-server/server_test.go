@@ -114,6 +114,27 @@ var _ = Describe("Virt remote commands", func() {
 			Expect(client.UnfreezeVirtualMachine(vmi)).To(Succeed())
 		})
 
+		It("should trim the guest filesystems of a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			trimmed := []api.FSTrimmed{
+				{Mountpoint: "/", TrimmedBytes: 1048576},
+				{Mountpoint: "/boot", Error: "discard operation is not supported"},
+			}
+			domainManager.EXPECT().FSTrimVMI(vmi).Return(trimmed, nil)
+
+			result, err := client.GuestFSTrim(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(trimmed))
+		})
+
+		It("should fail to trim the guest filesystems of a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().FSTrimVMI(vmi).Return(nil, errors.New("guest agent is not connected"))
+
+			_, err := client.GuestFSTrim(vmi)
+			Expect(err).To(MatchError(ContainSubstring("guest agent is not connected")))
+		})
+
 		It("should soft reboot a vmi", func() {
 			vmi := v1.NewVMIReferenceFromName("testvmi")
 			domainManager.EXPECT().SoftRebootVMI(vmi)
@@ -273,6 +294,20 @@ var _ = Describe("Virt remote commands", func() {
 			Expect(client.FinalizeVirtualMachineMigration(vmi)).ToNot(Succeed())
 		})
 
+		It("should update the disks I/O throttling", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().UpdateIOTune(vmi).Return(nil)
+
+			Expect(client.SyncVirtualMachineIOTune(vmi)).Should(Succeed())
+		})
+
+		It("should fail to update the disks I/O throttling", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().UpdateIOTune(vmi).Return(errors.New("error"))
+
+			Expect(client.SyncVirtualMachineIOTune(vmi)).ToNot(Succeed())
+		})
+
 		It("should get the qemu version", func() {
 			server := &Launcher{
 				domainManager: domainManager,
@@ -59,12 +59,15 @@ import (
 	"kubevirt.io/kubevirt/pkg/config"
 
 	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
+	diskencryption "kubevirt.io/kubevirt/pkg/disk-encryption"
 	"kubevirt.io/kubevirt/pkg/emptydisk"
 	ephemeraldisk "kubevirt.io/kubevirt/pkg/ephemeral-disk"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
 	"kubevirt.io/kubevirt/pkg/ignition"
+	netvmispec "kubevirt.io/kubevirt/pkg/network/vmispec"
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/efi"
 )
 
 const deviceTypeNotCompatibleFmt = "device %s is of type lun. Not compatible with a file based disk"
@@ -93,6 +96,8 @@ type EFIConfiguration struct {
 	EFICode      string
 	EFIVars      string
 	SecureLoader bool
+	// PersistentNVRAM keeps the NVRAM in the backend storage
+	PersistentNVRAM bool
 }
 
 type ConverterContext struct {
@@ -163,7 +168,16 @@ func Convert_v1_Disk_To_api_Disk(c *ConverterContext, diskDevice *v1.Disk, disk
 		if diskDevice.Disk.Bus == "scsi" {
 			disk.Address.Unit = strconv.Itoa(unit)
 		}
-		if diskDevice.Disk.PciAddress != "" {
+		if diskDevice.Disk.Bus == v1.DiskBusNVMe {
+			// The disk is the only namespace of its own nvme controller
+			disk.Address = &api.Address{
+				Type:       "drive",
+				Controller: strconv.Itoa(unit),
+				Bus:        "0",
+				Unit:       "0",
+			}
+		}
+		if diskDevice.Disk.PciAddress != "" && diskDevice.Disk.Bus != v1.DiskBusNVMe {
 			if diskDevice.Disk.Bus != v1.DiskBusVirtio {
 				return fmt.Errorf("setting a pci address is not allowed for non-virtio bus types, for disk %s", diskDevice.Name)
 			}
@@ -177,7 +191,10 @@ func Convert_v1_Disk_To_api_Disk(c *ConverterContext, diskDevice *v1.Disk, disk
 			disk.Model = InterpretTransitionalModelType(&c.UseVirtioTransitional)
 		}
 		disk.ReadOnly = toApiReadOnly(diskDevice.Disk.ReadOnly)
-		disk.Serial = diskDevice.Serial
+		// the serial of nvme disks is set on their controller
+		if diskDevice.Disk.Bus != v1.DiskBusNVMe {
+			disk.Serial = diskDevice.Serial
+		}
 		if diskDevice.Shareable != nil {
 			if *diskDevice.Shareable {
 				if diskDevice.Cache == "" {
@@ -228,6 +245,7 @@ func Convert_v1_Disk_To_api_Disk(c *ConverterContext, diskDevice *v1.Disk, disk
 	if numQueues != nil && disk.Target.Bus == v1.DiskBusVirtio {
 		disk.Driver.Queues = numQueues
 	}
+	disk.WWN = diskDevice.WWN
 	disk.Alias = api.NewUserDefinedAlias(diskDevice.Name)
 	if diskDevice.BootOrder != nil {
 		disk.BootOrder = &api.BootOrder{Order: *diskDevice.BootOrder}
@@ -235,10 +253,54 @@ func Convert_v1_Disk_To_api_Disk(c *ConverterContext, diskDevice *v1.Disk, disk
 	if c.UseLaunchSecurity && disk.Target.Bus == v1.DiskBusVirtio {
 		disk.Driver.IOMMU = "on"
 	}
+	disk.IOTune = Convert_v1_DiskIOTune_To_api_IOTune(diskDevice.IOTune)
 
 	return nil
 }
 
+// Convert_v1_DiskEncryption_To_api_DiskEncryption unlocks the LUKS volume with the libvirt secret virt-launcher defines for the disk
+func Convert_v1_DiskEncryption_To_api_DiskEncryption(vmi *v1.VirtualMachineInstance, diskName string) *api.DiskEncryption {
+	return &api.DiskEncryption{
+		Format: "luks",
+		Secret: &api.DiskSecret{
+			Type: "passphrase",
+			UUID: diskencryption.SecretUUID(vmi.UID, diskName),
+		},
+	}
+}
+
+func Convert_v1_DiskIOTune_To_api_IOTune(ioTune *v1.DiskIOTune) *api.IOTune {
+	if ioTune == nil {
+		return nil
+	}
+	return &api.IOTune{
+		TotalBytesSec:    uint64(ioTune.TotalBytesSec),
+		ReadBytesSec:     uint64(ioTune.ReadBytesSec),
+		WriteBytesSec:    uint64(ioTune.WriteBytesSec),
+		TotalIopsSec:     uint64(ioTune.TotalIOPSSec),
+		ReadIopsSec:      uint64(ioTune.ReadIOPSSec),
+		WriteIopsSec:     uint64(ioTune.WriteIOPSSec),
+		TotalBytesSecMax: uint64(ioTune.TotalBytesSecMax),
+		ReadBytesSecMax:  uint64(ioTune.ReadBytesSecMax),
+		WriteBytesSecMax: uint64(ioTune.WriteBytesSecMax),
+		TotalIopsSecMax:  uint64(ioTune.TotalIOPSSecMax),
+		ReadIopsSecMax:   uint64(ioTune.ReadIOPSSecMax),
+		WriteIopsSecMax:  uint64(ioTune.WriteIOPSSecMax),
+		GroupName:        ioTune.GroupName,
+	}
+}
+
+// IOTuneEqual compares I/O throttling settings, treating absent settings as no limit.
+func IOTuneEqual(a, b *api.IOTune) bool {
+	if a == nil {
+		a = &api.IOTune{}
+	}
+	if b == nil {
+		b = &api.IOTune{}
+	}
+	return *a == *b
+}
+
 // Get expected disk capacity - a minimum between the request and the PVC capacity.
 // Returns nil when we have insufficient data to calculate this minimum.
 func getDiskCapacity(pvcInfo *v1.PersistentVolumeClaimInfo) *int64 {
@@ -417,6 +479,14 @@ func SetDriverCacheMode(disk *api.Disk, directIOChecker DirectIOChecker) error {
 	mode := v1.DriverCache(disk.Driver.Cache)
 	isBlockDev := false
 
+	// qemu connects to network disks on its own, there is no local file system to check for direct I/O
+	if disk.Type == "network" {
+		if mode == "" {
+			disk.Driver.Cache = string(v1.CacheNone)
+		}
+		return nil
+	}
+
 	if disk.Source.File != "" {
 		path = disk.Source.File
 	} else if disk.Source.Dev != "" {
@@ -559,6 +629,9 @@ func makeDeviceName(diskName string, bus v1.DiskBus, prefixMap map[string]device
 
 // port of http://elixir.free-electrons.com/linux/v4.15/source/drivers/scsi/sd.c#L3211
 func FormatDeviceName(prefix string, index int) string {
+	if prefix == nvmeDevicePrefix {
+		return formatNVMeDeviceName(index)
+	}
 	base := int('z' - 'a' + 1)
 	name := ""
 
@@ -618,6 +691,9 @@ func Convert_v1_Volume_To_api_Disk(source *v1.Volume, disk *api.Disk, c *Convert
 	if source.Ephemeral != nil {
 		return Convert_v1_EphemeralVolumeSource_To_api_Disk(source.Name, disk, c)
 	}
+	if source.Overlay != nil {
+		return Convert_v1_OverlayVolumeSource_To_api_Disk(source.Name, disk, c)
+	}
 	if source.EmptyDisk != nil {
 		return Convert_v1_EmptyDiskSource_To_api_Disk(source.Name, source.EmptyDisk, disk)
 	}
@@ -636,6 +712,15 @@ func Convert_v1_Volume_To_api_Disk(source *v1.Volume, disk *api.Disk, c *Convert
 	if source.DownwardMetrics != nil {
 		return Convert_v1_DownwardMetricSource_To_api_Disk(disk, c)
 	}
+	if source.NBD != nil {
+		return Convert_v1_NBDVolumeSource_To_api_Disk(source.Name, source.NBD, disk)
+	}
+	if source.ISCSI != nil {
+		return Convert_v1_ISCSIVolumeSource_To_api_Disk(source.Name, source.ISCSI, disk, c)
+	}
+	if source.RBD != nil {
+		return Convert_v1_RBDVolumeSource_To_api_Disk(source.Name, source.RBD, disk, c)
+	}
 
 	return fmt.Errorf("disk %s references an unsupported source", disk.Alias.GetName())
 }
@@ -655,9 +740,38 @@ func Convert_v1_Hotplug_Volume_To_api_Disk(source *v1.Volume, disk *api.Disk, c
 	if source.DataVolume != nil {
 		return Convert_v1_Hotplug_DataVolume_To_api_Disk(source.Name, disk, c)
 	}
+
+	if source.ContainerDisk != nil || source.ConfigMap != nil || source.Secret != nil || source.ServiceAccount != nil {
+		return Convert_v1_Hotplug_ReadOnlyVolumeSource_To_api_Disk(source.Name, disk)
+	}
 	return fmt.Errorf("hotplug disk %s references an unsupported source", disk.Alias.GetName())
 }
 
+// Convert_v1_Hotplug_ReadOnlyVolumeSource_To_api_Disk converts a hotplugged containerDisk, ConfigMap, Secret or
+// ServiceAccount to a read-only api disk. The iso images of config volumes and the images of containerDisks
+// are attached as raw images.
+func Convert_v1_Hotplug_ReadOnlyVolumeSource_To_api_Disk(volumeName string, disk *api.Disk) error {
+	disk.Type = "file"
+	disk.Driver.Type = "raw"
+	disk.Driver.ErrorPolicy = "stop"
+	disk.Source.File = GetHotplugFilesystemVolumePath(volumeName)
+	disk.ReadOnly = toApiReadOnly(true)
+	return nil
+}
+
+// isReadOnlyHotplugVolume checks if the volume is only ever provided through a hotplug attachment pod
+func isReadOnlyHotplugVolume(volume *v1.Volume) bool {
+	return util.IsHotplugConfigVolume(volume) || util.IsHotplugContainerDiskVolume(volume)
+}
+
+// Convert_v1_EmptyCDRom_To_api_Disk builds a cdrom disk without media
+func Convert_v1_EmptyCDRom_To_api_Disk(disk *api.Disk) {
+	disk.Type = "file"
+	disk.Driver.Type = "raw"
+	disk.Driver.ErrorPolicy = "stop"
+	disk.Source = api.DiskSource{}
+}
+
 func Convert_v1_Config_To_api_Disk(volumeName string, disk *api.Disk, configType config.Type) error {
 	disk.Type = "file"
 	disk.Driver.Type = "raw"
@@ -901,6 +1015,39 @@ func Convert_v1_EphemeralVolumeSource_To_api_Disk(volumeName string, disk *api.D
 	return nil
 }
 
+// Convert_v1_OverlayVolumeSource_To_api_Disk attaches the persistent qcow2 overlay with the base image as backing store
+func Convert_v1_OverlayVolumeSource_To_api_Disk(volumeName string, disk *api.Disk, c *ConverterContext) error {
+	if disk.Type == "lun" {
+		return fmt.Errorf(deviceTypeNotCompatibleFmt, disk.Alias.GetName())
+	}
+	disk.Type = "file"
+	disk.Driver.Type = "qcow2"
+	disk.Driver.ErrorPolicy = "stop"
+	disk.Driver.Discard = "unmap"
+	disk.Source.File = ephemeraldisk.GetOverlayFilePath(volumeName)
+	disk.BackingStore = &api.BackingStore{
+		Format: &api.BackingStoreFormat{},
+		Source: &api.DiskSource{},
+	}
+
+	baseVolumeName := ephemeraldisk.OverlayBaseVolumeName(volumeName)
+	backingDisk := &api.Disk{Driver: &api.DiskDriver{}}
+	if c.IsBlockPVC[baseVolumeName] {
+		if err := Convert_v1_BlockVolumeSource_To_api_Disk(baseVolumeName, backingDisk, c.VolumesDiscardIgnore); err != nil {
+			return err
+		}
+	} else {
+		if err := Convert_v1_FilesystemVolumeSource_To_api_Disk(baseVolumeName, backingDisk, c.VolumesDiscardIgnore); err != nil {
+			return err
+		}
+	}
+	disk.BackingStore.Format.Type = backingDisk.Driver.Type
+	disk.BackingStore.Source = &backingDisk.Source
+	disk.BackingStore.Type = backingDisk.Type
+
+	return nil
+}
+
 func Convert_v1_Watchdog_To_api_Watchdog(source *v1.Watchdog, watchdog *api.Watchdog, _ *ConverterContext) error {
 	watchdog.Alias = api.NewUserDefinedAlias(source.Name)
 	if source.I6300ESB != nil {
@@ -1280,7 +1427,7 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 			}
 
 			domain.Spec.OS.NVRam = &api.NVRam{
-				NVRam:    filepath.Join("/tmp", domain.Spec.Name),
+				NVRam:    efi.NVRAMPath(domain.Spec.Name, c.EFIConfiguration.PersistentNVRAM),
 				Template: c.EFIConfiguration.EFIVars,
 			}
 		}
@@ -1409,8 +1556,8 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 			isMemfdRequired = true
 		}
 	}
-	// virtiofs require shared access
-	if util.IsVMIVirtiofsEnabled(vmi) {
+	// virtiofs and vhost-user require shared access, filesystems may also be hotplugged later on
+	if util.IsVMIVirtiofsEnabled(vmi) || vmi.Spec.Domain.Devices.FilesystemHotplug || netvmispec.VhostUserInterfaceExist(vmi.Spec.Domain.Devices.Interfaces) {
 		if domain.Spec.MemoryBacking == nil {
 			domain.Spec.MemoryBacking = &api.MemoryBacking{}
 		}
@@ -1527,11 +1674,16 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 			return err
 		}
 		volume := volumes[disk.Name]
-		if volume == nil {
+		hpStatus, hpOk := c.HotplugVolumes[disk.Name]
+		hpAttached := hpOk && (hpStatus.Phase == v1.HotplugVolumeMounted || hpStatus.Phase == v1.VolumeReady)
+		if volume == nil && disk.CDRom == nil {
 			return fmt.Errorf("No matching volume with name %s found", disk.Name)
 		}
 
-		if _, ok := c.HotplugVolumes[disk.Name]; !ok {
+		if volume == nil || (disk.CDRom != nil && hpOk && !hpAttached) {
+			// a cdrom without a volume, or whose media is still being hotplugged, has an empty tray
+			Convert_v1_EmptyCDRom_To_api_Disk(&newDisk)
+		} else if !hpOk && !isReadOnlyHotplugVolume(volume) {
 			err = Convert_v1_Volume_To_api_Disk(volume, &newDisk, c, volumeIndices[disk.Name])
 		} else {
 			err = Convert_v1_Hotplug_Volume_To_api_Disk(volume, &newDisk, c)
@@ -1544,7 +1696,12 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 			return err
 		}
 
-		if useIOThreads {
+		if disk.Encryption != nil {
+			newDisk.Encryption = Convert_v1_DiskEncryption_To_api_DiskEncryption(vmi, disk.Name)
+		}
+
+		// nvme disks are served by their controller, which has no iothread support
+		if useIOThreads && newDisk.Target.Bus != v1.DiskBusNVMe {
 			if _, ok := c.HotplugVolumes[disk.Name]; !ok {
 				ioThreadId := defaultIOThread
 				dedicatedThread := false
@@ -1567,14 +1724,14 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 			}
 		}
 
-		hpStatus, hpOk := c.HotplugVolumes[disk.Name]
 		// if len(c.PermanentVolumes) == 0, it means the vmi is not ready yet, add all disks
-		if _, ok := c.PermanentVolumes[disk.Name]; ok || len(c.PermanentVolumes) == 0 || (hpOk && (hpStatus.Phase == v1.HotplugVolumeMounted || hpStatus.Phase == v1.VolumeReady)) {
+		// cdroms are always present, their media is changed in place
+		if _, ok := c.PermanentVolumes[disk.Name]; ok || len(c.PermanentVolumes) == 0 || hpAttached || disk.CDRom != nil {
 			domain.Spec.Devices.Disks = append(domain.Spec.Devices.Disks, newDisk)
 		}
 	}
 	// Handle virtioFS
-	domain.Spec.Devices.Filesystems = append(domain.Spec.Devices.Filesystems, convertFileSystems(vmi.Spec.Domain.Devices.Filesystems)...)
+	domain.Spec.Devices.Filesystems = append(domain.Spec.Devices.Filesystems, convertFileSystems(vmi)...)
 
 	Convert_v1_Sound_To_api_Sound(vmi, &domain.Spec.Devices, c)
 
@@ -1649,6 +1806,12 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		domain.Spec.Devices.Controllers = append(domain.Spec.Devices.Controllers, scsiController)
 	}
 
+	nvmeControllers, err := convertNVMeControllers(vmi, domain.Spec.Devices.Disks)
+	if err != nil {
+		return err
+	}
+	domain.Spec.Devices.Controllers = append(domain.Spec.Devices.Controllers, nvmeControllers...)
+
 	if vmi.Spec.Domain.Clock != nil {
 		clock := vmi.Spec.Domain.Clock
 		newClock := &api.Clock{}
@@ -1934,6 +2097,8 @@ func getPrefixFromBus(bus v1.DiskBus) string {
 		return "vd"
 	case v1.DiskBusSATA, v1.DiskBusSCSI, v1.DiskBusUSB:
 		return "sd"
+	case v1.DiskBusNVMe:
+		return nvmeDevicePrefix
 	default:
 		log.Log.Errorf("Unrecognized bus '%s'", bus)
 		return ""
@@ -44,9 +44,12 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 
+	diskencryption "kubevirt.io/kubevirt/pkg/disk-encryption"
 	"kubevirt.io/kubevirt/pkg/ephemeral-disk/fake"
+	networkvolume "kubevirt.io/kubevirt/pkg/storage/network-volume"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virtiofs"
 
 	v1 "kubevirt.io/api/core/v1"
 	kvapi "kubevirt.io/client-go/api"
@@ -242,6 +245,40 @@ var _ = Describe("Converter", func() {
   <driver cache="none" error_policy="stop" name="qemu" type="" discard="unmap"></driver>
   <alias name="ua-mydisk"></alias>
   <shareable></shareable>
+</Disk>`
+			xml := diskToDiskXML(v1Disk)
+			Expect(xml).To(Equal(expectedXML))
+		})
+		It("should set the I/O throttling if requested", func() {
+			v1Disk := &v1.Disk{
+				Name: "mydisk",
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{
+						Bus: v1.VirtIO,
+					},
+				},
+				IOTune: &v1.DiskIOTune{
+					GroupName:        "shared",
+					ReadBytesSec:     1048576,
+					WriteBytesSec:    524288,
+					TotalIOPSSec:     100,
+					TotalIOPSSecMax:  200,
+					WriteBytesSecMax: 1048576,
+				},
+			}
+			var expectedXML = `<Disk device="disk" type="" model="virtio-non-transitional">
+  <source></source>
+  <target bus="virtio" dev="vda"></target>
+  <driver error_policy="stop" name="qemu" type="" discard="unmap"></driver>
+  <alias name="ua-mydisk"></alias>
+  <iotune>
+    <read_bytes_sec>1048576</read_bytes_sec>
+    <write_bytes_sec>524288</write_bytes_sec>
+    <total_iops_sec>100</total_iops_sec>
+    <write_bytes_sec_max>1048576</write_bytes_sec_max>
+    <total_iops_sec_max>200</total_iops_sec_max>
+    <group_name>shared</group_name>
+  </iotune>
 </Disk>`
 			xml := diskToDiskXML(v1Disk)
 			Expect(xml).To(Equal(expectedXML))
@@ -2355,6 +2392,53 @@ var _ = Describe("Converter", func() {
 			domain := &api.Domain{}
 			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c)).To(HaveOccurred(), "conversion should fail because a macvtap interface requires a multus network attachment")
 		})
+		It("Should create network configuration for vhostuser interface and a multus network", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			networkName := "net1"
+
+			iface1 := v1.Interface{
+				Name:                   networkName,
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{VhostUser: &v1.InterfaceVhostUser{}},
+				MacAddress:             "de:ad:00:00:be:af",
+			}
+
+			multusNetwork := v1.Network{
+				Name: networkName,
+				NetworkSource: v1.NetworkSource{
+					Multus: &v1.MultusNetwork{NetworkName: "multusNet"},
+				},
+			}
+			vmi.Spec.Networks = []v1.Network{multusNetwork}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{iface1}
+
+			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(domainSpec.Devices.Interfaces).To(HaveLen(1), "should have a single interface")
+			Expect(domainSpec.Devices.Interfaces[0].Type).To(Equal("vhostuser"))
+			Expect(domainSpec.Devices.Interfaces[0].Source).To(Equal(api.InterfaceSource{
+				Type: "unix",
+				Path: "/var/run/kubevirt/vhostuser/pod6c270ef2f25",
+				Mode: "server",
+			}))
+			Expect(domainSpec.Devices.Interfaces[0].MAC).To(Equal(&api.MAC{MAC: "de:ad:00:00:be:af"}))
+			Expect(domainSpec.Devices.Interfaces[0].Rom).To(Equal(&api.Rom{Enabled: "no"}))
+
+			By("sharing the guest memory with the userspace switch")
+			Expect(domainSpec.MemoryBacking.Access).To(Equal(&api.MemoryBackingAccess{Mode: "shared"}))
+			Expect(domainSpec.MemoryBacking.Source).To(Equal(&api.MemoryBackingSource{Type: "memfd"}))
+			Expect(domainSpec.CPU.NUMA.Cells).To(HaveLen(1))
+		})
+		Specify("vhostuser interface binding must be used on a multus network", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			name1 := "net1"
+
+			iface1 := v1.Interface{Name: name1, InterfaceBindingMethod: v1.InterfaceBindingMethod{VhostUser: &v1.InterfaceVhostUser{}}}
+
+			vmi.Spec.Networks = []v1.Network{{Name: name1, NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}}}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{iface1}
+
+			domain := &api.Domain{}
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c)).To(HaveOccurred(), "conversion should fail because a vhostuser interface requires a multus network attachment")
+		})
 		It("creates SRIOV hostdev", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			domain := &api.Domain{}
@@ -2414,6 +2498,281 @@ var _ = Describe("Converter", func() {
 		)
 	})
 
+	Context("with nvme disks", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		nvmeDisk := func(name string) v1.Disk {
+			return v1.Disk{
+				Name:       name,
+				DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusNVMe}},
+			}
+		}
+
+		BeforeEach(func() {
+			vmi = &v1.VirtualMachineInstance{
+				ObjectMeta: k8smeta.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "default",
+					UID:       "1234",
+				},
+			}
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			for _, name := range []string{"boot", "a-disk-with-a-rather-long-name"} {
+				vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, nvmeDisk(name))
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name:         name,
+					VolumeSource: v1.VolumeSource{EmptyDisk: &v1.EmptyDiskSource{Capacity: resource.MustParse("1Gi")}},
+				})
+			}
+		})
+
+		It("should attach every disk as the namespace of its own nvme controller", func() {
+			vmi.Spec.Domain.Devices.Disks[1].WWN = "5000c50015ea71ac"
+			domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true, Architecture: "amd64"})
+
+			Expect(domain.Spec.Devices.Disks).To(HaveLen(2))
+			for i, disk := range domain.Spec.Devices.Disks {
+				Expect(disk.Target.Bus).To(Equal(v1.DiskBusNVMe))
+				Expect(disk.Target.Device).To(Equal(fmt.Sprintf("nvme%dn1", i)))
+				Expect(disk.Address).To(Equal(&api.Address{Type: "drive", Controller: strconv.Itoa(i), Bus: "0", Unit: "0"}))
+				Expect(disk.Serial).To(BeEmpty())
+			}
+			Expect(domain.Spec.Devices.Disks[1].WWN).To(Equal("5000c50015ea71ac"))
+
+			var nvmeControllers []api.Controller
+			for _, controller := range domain.Spec.Devices.Controllers {
+				if controller.Type == "nvme" {
+					nvmeControllers = append(nvmeControllers, controller)
+				}
+			}
+			Expect(nvmeControllers).To(ConsistOf(
+				api.Controller{Type: "nvme", Index: "0", Serial: "boot"},
+				api.Controller{Type: "nvme", Index: "1", Serial: nvmeSerial(&vmi.Spec.Domain.Devices.Disks[1])},
+			))
+		})
+
+		It("should default the serial to at most 20 characters", func() {
+			serial := nvmeSerial(&vmi.Spec.Domain.Devices.Disks[1])
+			Expect(serial).To(HaveLen(20))
+			Expect(serial).To(Equal(nvmeSerial(&vmi.Spec.Domain.Devices.Disks[1])))
+
+			vmi.Spec.Domain.Devices.Disks[1].Serial = "my-serial"
+			Expect(nvmeSerial(&vmi.Spec.Domain.Devices.Disks[1])).To(Equal("my-serial"))
+		})
+
+		It("should place the nvme controller on the requested PCI address", func() {
+			vmi.Spec.Domain.Devices.Disks[0].Disk.PciAddress = "0000:81:01.0"
+			domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true, Architecture: "amd64"})
+
+			Expect(domain.Spec.Devices.Disks[0].Address.Type).To(Equal("drive"))
+			Expect(domain.Spec.Devices.Controllers).To(ContainElement(api.Controller{
+				Type:    "nvme",
+				Index:   "0",
+				Serial:  "boot",
+				Address: &api.Address{Type: api.AddressPCI, Domain: "0x0000", Bus: "0x81", Slot: "0x01", Function: "0x0"},
+			}))
+		})
+
+		It("should not assign IOThreads to nvme disks", func() {
+			policy := v1.IOThreadsPolicyShared
+			vmi.Spec.Domain.IOThreadsPolicy = &policy
+			domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true, Architecture: "amd64"})
+
+			for _, disk := range domain.Spec.Devices.Disks {
+				Expect(disk.Driver.IOThread).To(BeNil())
+			}
+		})
+
+		It("should place the nvme controllers on the root complex", func() {
+			spec := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true, Architecture: "amd64"}).Spec.DeepCopy()
+			Expect(PlacePCIDevicesOnRootComplex(spec)).To(Succeed())
+
+			for _, controller := range spec.Devices.Controllers {
+				if controller.Type == "nvme" {
+					Expect(controller.Address.Type).To(Equal(api.AddressPCI))
+					Expect(controller.Address.Bus).To(Equal("0x00"))
+				}
+			}
+			for _, disk := range spec.Devices.Disks {
+				Expect(disk.Address.Type).To(Equal("drive"))
+			}
+		})
+	})
+
+	Context("with encrypted disks", func() {
+		It("should unlock the LUKS volume with the libvirt secret of the disk", func() {
+			vmi := &v1.VirtualMachineInstance{
+				ObjectMeta: k8smeta.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "default",
+					UID:       "1234",
+				},
+			}
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{Name: "clear", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusVirtio}}},
+				{
+					Name:       "encrypted",
+					DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusVirtio}},
+					Encryption: &v1.DiskEncryption{SecretRef: k8sv1.LocalObjectReference{Name: "tenant-key"}},
+				},
+			}
+			for _, name := range []string{"clear", "encrypted"} {
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: name,
+					VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+						PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: name},
+					}},
+				})
+			}
+
+			domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true, Architecture: "amd64"})
+			Expect(domain.Spec.Devices.Disks).To(HaveLen(2))
+			Expect(domain.Spec.Devices.Disks[0].Encryption).To(BeNil())
+			Expect(domain.Spec.Devices.Disks[1].Driver.Type).To(Equal("raw"))
+			Expect(domain.Spec.Devices.Disks[1].Encryption).To(Equal(&api.DiskEncryption{
+				Format: "luks",
+				Secret: &api.DiskSecret{Type: "passphrase", UUID: diskencryption.SecretUUID("1234", "encrypted")},
+			}))
+		})
+	})
+
+	Context("with persistent overlays", func() {
+		DescribeTable("should attach the overlay backed by the base image", func(isBlockBase bool, expectedBackingStore *api.BackingStore) {
+			vmi := &v1.VirtualMachineInstance{
+				ObjectMeta: k8smeta.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "default",
+					UID:       "1234",
+				},
+			}
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{Name: "vdi", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusVirtio}}},
+			}
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "vdi",
+				VolumeSource: v1.VolumeSource{Overlay: &v1.OverlayVolumeSource{
+					ClaimName:     "vdi-overlay",
+					BaseClaimName: "golden",
+				}},
+			}}
+
+			domain := vmiToDomain(vmi, &ConverterContext{
+				AllowEmulation: true,
+				Architecture:   "amd64",
+				IsBlockPVC:     map[string]bool{"vdi-base": isBlockBase},
+			})
+			Expect(domain.Spec.Devices.Disks).To(HaveLen(1))
+			disk := domain.Spec.Devices.Disks[0]
+			Expect(disk.Type).To(Equal("file"))
+			Expect(disk.Driver.Type).To(Equal("qcow2"))
+			Expect(disk.Source.File).To(Equal("/var/run/kubevirt-private/vmi-disks/vdi/disk.qcow2"))
+			Expect(disk.BackingStore).To(Equal(expectedBackingStore))
+		},
+			Entry("on a filesystem PVC", false, &api.BackingStore{
+				Type:   "file",
+				Format: &api.BackingStoreFormat{Type: "raw"},
+				Source: &api.DiskSource{File: "/var/run/kubevirt-private/vmi-disks/vdi-base/disk.img"},
+			}),
+			Entry("on a block PVC", true, &api.BackingStore{
+				Type:   "block",
+				Format: &api.BackingStoreFormat{Type: "raw"},
+				Source: &api.DiskSource{Dev: "/dev/vdi-base", Name: "vdi-base"},
+			}),
+		)
+	})
+
+	Context("with network volumes", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = &v1.VirtualMachineInstance{
+				ObjectMeta: k8smeta.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "default",
+					UID:       "1234",
+				},
+			}
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{Name: "network", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusVirtio}}},
+			}
+		})
+
+		DescribeTable("should let qemu connect to the volume", func(source v1.VolumeSource, expectedSource api.DiskSource, expectedAuth *api.DiskAuth) {
+			vmi.Spec.Volumes = []v1.Volume{{Name: "network", VolumeSource: source}}
+
+			domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true, Architecture: "amd64", VirtualMachine: vmi})
+			Expect(domain.Spec.Devices.Disks).To(HaveLen(1))
+			disk := domain.Spec.Devices.Disks[0]
+			Expect(disk.Type).To(Equal("network"))
+			Expect(disk.Driver.Type).To(Equal("raw"))
+			Expect(disk.Driver.ErrorPolicy).To(Equal("stop"))
+			Expect(disk.Source).To(Equal(expectedSource))
+			Expect(disk.Auth).To(Equal(expectedAuth))
+		},
+			Entry("with NBD",
+				v1.VolumeSource{NBD: &v1.NBDVolumeSource{URI: "nbd://nbd.example.com/disk0"}},
+				api.DiskSource{Protocol: "nbd", Name: "disk0", Hosts: []api.DiskSourceHost{{Name: "nbd.example.com", Port: "10809"}}},
+				nil,
+			),
+			Entry("with iSCSI and CHAP",
+				v1.VolumeSource{ISCSI: &v1.ISCSIVolumeSource{
+					TargetPortal:  "10.0.0.1",
+					IQN:           "iqn.2024-01.com.example:storage",
+					Lun:           1,
+					InitiatorName: "iqn.2024-01.com.example:initiator",
+					CHAPSecretRef: &k8sv1.LocalObjectReference{Name: "chap"},
+				}},
+				api.DiskSource{
+					Protocol:  "iscsi",
+					Name:      "iqn.2024-01.com.example:storage/1",
+					Hosts:     []api.DiskSourceHost{{Name: "10.0.0.1", Port: "3260"}},
+					Initiator: &api.DiskSourceInitiator{IQN: api.DiskSourceInitiatorIQN{Name: "iqn.2024-01.com.example:initiator"}},
+				},
+				&api.DiskAuth{Secret: &api.DiskSecret{Type: "iscsi", UUID: networkvolume.SecretUUID("1234", "network")}},
+			),
+			Entry("with RBD and a keyring",
+				v1.VolumeSource{RBD: &v1.RBDVolumeSource{
+					Monitors:         []string{"10.0.0.1", "10.0.0.2:6790"},
+					Image:            "vm-disk",
+					User:             "kubevirt",
+					KeyringSecretRef: &k8sv1.LocalObjectReference{Name: "ceph"},
+				}},
+				api.DiskSource{
+					Protocol: "rbd",
+					Name:     "rbd/vm-disk",
+					Hosts:    []api.DiskSourceHost{{Name: "10.0.0.1", Port: "6789"}, {Name: "10.0.0.2", Port: "6790"}},
+				},
+				&api.DiskAuth{Username: "kubevirt", Secret: &api.DiskSecret{Type: "ceph", UUID: networkvolume.SecretUUID("1234", "network")}},
+			),
+			Entry("with RBD without cephx",
+				v1.VolumeSource{RBD: &v1.RBDVolumeSource{Monitors: []string{"ceph-mon"}, Pool: "vms", Image: "vm-disk"}},
+				api.DiskSource{Protocol: "rbd", Name: "vms/vm-disk", Hosts: []api.DiskSourceHost{{Name: "ceph-mon", Port: "6789"}}},
+				nil,
+			),
+		)
+
+		It("should not use NBD volumes as lun", func() {
+			vmi.Spec.Domain.Devices.Disks[0].DiskDevice = v1.DiskDevice{LUN: &v1.LunTarget{Bus: v1.DiskBusSCSI}}
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name:         "network",
+				VolumeSource: v1.VolumeSource{NBD: &v1.NBDVolumeSource{URI: "nbd://nbd.example.com"}},
+			}}
+
+			domain := &api.Domain{}
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, &ConverterContext{AllowEmulation: true, Architecture: "amd64", VirtualMachine: vmi})).
+				To(MatchError(ContainSubstring("Only iSCSI volumes can be used as lun")))
+		})
+
+		It("should default the cache mode of network disks without checking for direct I/O", func() {
+			disk := &api.Disk{Type: "network", Driver: &api.DiskDriver{}, Source: api.DiskSource{Protocol: "nbd"}}
+			Expect(SetDriverCacheMode(disk, nil)).To(Succeed())
+			Expect(disk.Driver.Cache).To(Equal(string(v1.CacheNone)))
+		})
+	})
+
 	Context("HyperV features", func() {
 		DescribeTable("should convert hyperv features", func(hyperV *v1.FeatureHyperv, result *api.FeatureHyperv) {
 			vmi := v1.VirtualMachineInstance{
@@ -3048,6 +3407,22 @@ var _ = Describe("Converter", func() {
 			Entry("should not use SecureBoot", False(), "OVMF_CODE.fd", "OVMF_VARS.fd"),
 			Entry("should not use SecureBoot when OVMF_CODE.fd not present", True(), "OVMF_CODE.secboot.fd", "OVMF_VARS.fd"),
 		)
+
+		It("should keep persistent NVRAM in the backend storage", func() {
+			c.EFIConfiguration = &EFIConfiguration{
+				EFICode:         "OVMF_CODE.secboot.fd",
+				EFIVars:         "OVMF_VARS.secboot.fd",
+				SecureLoader:    true,
+				PersistentNVRAM: true,
+			}
+			vmi.Spec.Domain.Firmware = &v1.Firmware{
+				Bootloader: &v1.Bootloader{
+					EFI: &v1.EFI{Persistent: True()},
+				},
+			}
+			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(domainSpec.OS.NVRam.NVRam).To(Equal("/var/run/kubevirt-private/nvram/mynamespace_testvmi_VARS.fd"))
+		})
 	})
 
 	Context("Kernel Boot", func() {
@@ -3184,6 +3559,116 @@ var _ = Describe("Converter", func() {
 			Entry("block mode DV", Convert_v1_Hotplug_DataVolume_To_api_Disk, "test-block-dv", true, false),
 			Entry("'discard ignore' DV", Convert_v1_Hotplug_DataVolume_To_api_Disk, "test-discard-ignore", false, true),
 		)
+
+		It("should convert hotplugged read-only volumes to read-only raw file disks", func() {
+			disk := &api.Disk{
+				Driver: &api.DiskDriver{},
+			}
+			volume := &v1.Volume{
+				Name: "test-configmap",
+				VolumeSource: v1.VolumeSource{
+					ConfigMap: &v1.ConfigMapVolumeSource{Hotpluggable: true},
+				},
+			}
+			Expect(Convert_v1_Hotplug_Volume_To_api_Disk(volume, disk, c)).To(Succeed())
+			Expect(disk.Type).To(Equal("file"))
+			Expect(disk.Driver.Type).To(Equal("raw"))
+			Expect(disk.Source.File).To(Equal(filepath.Join(v1.HotplugDiskDir, "test-configmap.img")))
+			Expect(disk.ReadOnly).ToNot(BeNil())
+		})
+
+		It("should serve hotplugged filesystems from the launcher virtiofsd socket", func() {
+			vmi.Spec.Domain.Devices.FilesystemHotplug = true
+			vmi.Spec.Domain.Devices.Filesystems = []v1.Filesystem{
+				{Name: "static", Virtiofs: &v1.FilesystemVirtiofs{}},
+				{Name: "dataset", Virtiofs: &v1.FilesystemVirtiofs{}},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "static",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{},
+					},
+				},
+				{
+					Name: "dataset",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{Hotpluggable: true},
+					},
+				},
+			}
+
+			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(domainSpec.Devices.Filesystems).To(HaveLen(2))
+			Expect(domainSpec.Devices.Filesystems[0].Source.Socket).To(Equal(virtiofs.VirtioFSSocketPath("static")))
+			Expect(domainSpec.Devices.Filesystems[1].Source.Socket).To(Equal(virtiofs.HotplugVirtioFSSocketPath("dataset")))
+			Expect(domainSpec.Devices.Filesystems[1].Target.Dir).To(Equal("dataset"))
+		})
+
+		It("should share the guest memory when filesystem hotplug is enabled", func() {
+			vmi.Spec.Domain.Devices.FilesystemHotplug = true
+
+			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(domainSpec.MemoryBacking.Access).To(Equal(&api.MemoryBackingAccess{Mode: "shared"}))
+			Expect(domainSpec.MemoryBacking.Source).To(Equal(&api.MemoryBackingSource{Type: "memfd"}))
+		})
+
+		Context("cdrom media", func() {
+			BeforeEach(func() {
+				vmi.Spec.Domain.Devices.Disks = []v1.Disk{{
+					Name: "cdrom1",
+					DiskDevice: v1.DiskDevice{
+						CDRom: &v1.CDRomTarget{Bus: v1.DiskBusSATA},
+					},
+				}}
+			})
+
+			expectEmptyCDRom := func(domain *api.Domain) {
+				Expect(domain.Spec.Devices.Disks).To(HaveLen(1))
+				disk := domain.Spec.Devices.Disks[0]
+				Expect(disk.Device).To(Equal("cdrom"))
+				Expect(disk.Type).To(Equal("file"))
+				Expect(disk.Source).To(Equal(api.DiskSource{}))
+			}
+
+			It("should render a cdrom without a volume as an empty tray", func() {
+				expectEmptyCDRom(vmiToDomain(vmi, c))
+			})
+
+			It("should fail for a non cdrom disk without a volume", func() {
+				vmi.Spec.Domain.Devices.Disks[0].CDRom = nil
+				vmi.Spec.Domain.Devices.Disks[0].Disk = &v1.DiskTarget{Bus: v1.DiskBusVirtio}
+				domain := &api.Domain{}
+				Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c)).To(MatchError(ContainSubstring("No matching volume")))
+			})
+
+			DescribeTable("with hotplugged media", func(phase v1.VolumePhase, expectMedia bool) {
+				vmi.Spec.Volumes = []v1.Volume{{
+					Name: "cdrom1",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+							PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "iso"},
+							Hotpluggable:                      true,
+						},
+					},
+				}}
+				c.PermanentVolumes = map[string]v1.VolumeStatus{"other": {Name: "other"}}
+				c.HotplugVolumes = map[string]v1.VolumeStatus{"cdrom1": {Name: "cdrom1", Phase: phase}}
+
+				domain := vmiToDomain(vmi, c)
+				if !expectMedia {
+					expectEmptyCDRom(domain)
+					return
+				}
+				Expect(domain.Spec.Devices.Disks).To(HaveLen(1))
+				Expect(domain.Spec.Devices.Disks[0].Source.File).To(Equal(filepath.Join(v1.HotplugDiskDir, "cdrom1.img")))
+			},
+				Entry("that is pending", v1.VolumePending, false),
+				Entry("that is attached to the pod", v1.HotplugVolumeAttachedToNode, false),
+				Entry("that is mounted", v1.HotplugVolumeMounted, true),
+				Entry("that is ready", v1.VolumeReady, true),
+			)
+		})
 	})
 
 	Context("with AMD SEV LaunchSecurity", func() {
@@ -3411,6 +3896,10 @@ var _ = Describe("disk device naming", func() {
 		Expect(res).To(Equal("sdaz"))
 		res = FormatDeviceName("sd", 26*26-1)
 		Expect(res).To(Equal("sdyz"))
+		res = FormatDeviceName("nvme", 0)
+		Expect(res).To(Equal("nvme0n1"))
+		res = FormatDeviceName("nvme", 27)
+		Expect(res).To(Equal("nvme27n1"))
 	})
 
 	It("makeDeviceName should generate proper name", func() {
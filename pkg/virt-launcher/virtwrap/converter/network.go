@@ -32,6 +32,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter/vcpu"
 
 	"kubevirt.io/kubevirt/pkg/network/dns"
+	"kubevirt.io/kubevirt/pkg/network/vhostuser"
 	netvmispec "kubevirt.io/kubevirt/pkg/network/vmispec"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device"
@@ -130,6 +131,26 @@ func CreateDomainInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain,
 			}
 		} else if iface.Passt != nil {
 			domain.Spec.Devices.Emulator = "/usr/bin/qrap"
+		} else if iface.VhostUser != nil {
+			if net.Multus == nil {
+				return nil, fmt.Errorf("vhostuser interface %s requires Multus meta-cni", iface.Name)
+			}
+
+			// QEMU serves the socket, the userspace switch connects to it once the CNI plugin has set up the port
+			domainIface.Type = "vhostuser"
+			domainIface.Source = api.InterfaceSource{
+				Type: "unix",
+				Path: vhostuser.SocketPath(*net),
+				Mode: "server",
+			}
+			if iface.MacAddress != "" {
+				domainIface.MAC = &api.MAC{MAC: iface.MacAddress}
+			}
+			if iface.BootOrder != nil {
+				domainIface.BootOrder = &api.BootOrder{Order: *iface.BootOrder}
+			} else {
+				domainIface.Rom = &api.Rom{Enabled: "no"}
+			}
 		}
 
 		if c.UseLaunchSecurity {
@@ -0,0 +1,102 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package converter
+
+import (
+	"fmt"
+	"strconv"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	networkvolume "kubevirt.io/kubevirt/pkg/storage/network-volume"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+const networkDeviceTypeNotCompatibleFmt = "device %s is of type lun. Only iSCSI volumes can be used as lun"
+
+// convertNetworkDisk sets up the disk as a network disk qemu connects to on its own
+func convertNetworkDisk(protocol string, disk *api.Disk) {
+	disk.Type = "network"
+	disk.Driver.Type = "raw"
+	disk.Driver.ErrorPolicy = "stop"
+	disk.Source.Protocol = protocol
+}
+
+// convertNetworkDiskAuth authenticates the disk with the libvirt secret virt-launcher defines for the volume
+func convertNetworkDiskAuth(volumeName, username, secretType string, disk *api.Disk, c *ConverterContext) {
+	disk.Auth = &api.DiskAuth{
+		Username: username,
+		Secret: &api.DiskSecret{
+			Type: secretType,
+			UUID: networkvolume.SecretUUID(c.VirtualMachine.UID, volumeName),
+		},
+	}
+}
+
+func Convert_v1_NBDVolumeSource_To_api_Disk(volumeName string, source *v1.NBDVolumeSource, disk *api.Disk) error {
+	if disk.Device == "lun" {
+		return fmt.Errorf(networkDeviceTypeNotCompatibleFmt, disk.Alias.GetName())
+	}
+	host, port, export, err := networkvolume.ParseNBDURI(source.URI)
+	if err != nil {
+		return fmt.Errorf("volume %s: %v", volumeName, err)
+	}
+	convertNetworkDisk("nbd", disk)
+	disk.Source.Name = export
+	disk.Source.Hosts = []api.DiskSourceHost{{Name: host, Port: port}}
+	return nil
+}
+
+func Convert_v1_ISCSIVolumeSource_To_api_Disk(volumeName string, source *v1.ISCSIVolumeSource, disk *api.Disk, c *ConverterContext) error {
+	host, port, err := networkvolume.SplitHostPort(source.TargetPortal, networkvolume.DefaultISCSIPort)
+	if err != nil {
+		return fmt.Errorf("volume %s: invalid target portal: %v", volumeName, err)
+	}
+	convertNetworkDisk("iscsi", disk)
+	disk.Source.Name = source.IQN + "/" + strconv.Itoa(int(source.Lun))
+	disk.Source.Hosts = []api.DiskSourceHost{{Name: host, Port: port}}
+	if source.InitiatorName != "" {
+		disk.Source.Initiator = &api.DiskSourceInitiator{IQN: api.DiskSourceInitiatorIQN{Name: source.InitiatorName}}
+	}
+	if source.CHAPSecretRef != nil {
+		// the username is read from the Secret when virt-launcher defines the libvirt secret
+		convertNetworkDiskAuth(volumeName, "", "iscsi", disk, c)
+	}
+	return nil
+}
+
+func Convert_v1_RBDVolumeSource_To_api_Disk(volumeName string, source *v1.RBDVolumeSource, disk *api.Disk, c *ConverterContext) error {
+	if disk.Device == "lun" {
+		return fmt.Errorf(networkDeviceTypeNotCompatibleFmt, disk.Alias.GetName())
+	}
+	convertNetworkDisk("rbd", disk)
+	disk.Source.Name = networkvolume.RBDPool(source) + "/" + source.Image
+	for _, monitor := range source.Monitors {
+		host, port, err := networkvolume.SplitHostPort(monitor, networkvolume.DefaultRBDPort)
+		if err != nil {
+			return fmt.Errorf("volume %s: invalid monitor: %v", volumeName, err)
+		}
+		disk.Source.Hosts = append(disk.Source.Hosts, api.DiskSourceHost{Name: host, Port: port})
+	}
+	if source.KeyringSecretRef != nil {
+		convertNetworkDiskAuth(volumeName, networkvolume.RBDUser(source), "ceph", disk, c)
+	}
+	return nil
+}
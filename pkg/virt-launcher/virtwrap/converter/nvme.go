@@ -0,0 +1,69 @@
+package converter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device"
+)
+
+const nvmeDevicePrefix = "nvme"
+
+// formatNVMeDeviceName names the disk after its controller, every nvme disk is the first namespace of its own controller
+func formatNVMeDeviceName(index int) string {
+	return fmt.Sprintf("%s%dn1", nvmeDevicePrefix, index)
+}
+
+// nvmeSerial returns the serial of the disk's NVMe controller. QEMU requires one, so
+// disks without a serial get a stable one derived from their name.
+func nvmeSerial(disk *v1.Disk) string {
+	if disk.Serial != "" {
+		return disk.Serial
+	}
+	if len(disk.Name) <= util.NVMeMaxSerialLength {
+		return disk.Name
+	}
+	sum := sha256.Sum256([]byte(disk.Name))
+	return hex.EncodeToString(sum[:])[:util.NVMeMaxSerialLength]
+}
+
+// convertNVMeControllers creates a dedicated NVMe controller for every nvme disk of the domain.
+// The controller carries the serial of the disk and, if requested, its PCI address.
+func convertNVMeControllers(vmi *v1.VirtualMachineInstance, disks []api.Disk) ([]api.Controller, error) {
+	nvmeDisks := make(map[string]*v1.Disk)
+	for i, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.Disk != nil && disk.Disk.Bus == v1.DiskBusNVMe {
+			nvmeDisks[disk.Name] = &vmi.Spec.Domain.Devices.Disks[i]
+		}
+	}
+
+	var controllers []api.Controller
+	for _, disk := range disks {
+		if disk.Target.Bus != v1.DiskBusNVMe || disk.Alias == nil {
+			continue
+		}
+		nvmeDisk, ok := nvmeDisks[disk.Alias.GetName()]
+		if !ok {
+			continue
+		}
+		controller := api.Controller{
+			Type:   "nvme",
+			Index:  disk.Address.Controller,
+			Serial: nvmeSerial(nvmeDisk),
+		}
+		if nvmeDisk.Disk.PciAddress != "" {
+			addr, err := device.NewPciAddressField(nvmeDisk.Disk.PciAddress)
+			if err != nil {
+				return nil, fmt.Errorf("failed to configure disk %s: %v", nvmeDisk.Name, err)
+			}
+			controller.Address = addr
+		}
+		controllers = append(controllers, controller)
+	}
+	return controllers, nil
+}
@@ -3,33 +3,49 @@ package converter
 import (
 	v1 "kubevirt.io/api/core/v1"
 
+	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virtiofs"
 )
 
-func convertFileSystems(fileSystems []v1.Filesystem) []api.FilesystemDevice {
+func convertFileSystems(vmi *v1.VirtualMachineInstance) []api.FilesystemDevice {
+	hotplugVolumes := make(map[string]struct{})
+	for _, volume := range vmi.Spec.Volumes {
+		if util.IsHotplugVolume(&volume) {
+			hotplugVolumes[volume.Name] = struct{}{}
+		}
+	}
+
 	domainFileSystems := []api.FilesystemDevice{}
-	for _, fs := range fileSystems {
+	for _, fs := range vmi.Spec.Domain.Devices.Filesystems {
 		if fs.Virtiofs == nil {
 			continue
 		}
 
-		domainFileSystems = append(domainFileSystems,
-			api.FilesystemDevice{
-				Type:       "mount",
-				AccessMode: "passthrough",
-				Driver: &api.FilesystemDriver{
-					Type:  "virtiofs",
-					Queue: "1024",
-				},
-				Source: &api.FilesystemSource{
-					Socket: virtiofs.VirtioFSSocketPath(fs.Name),
-				},
-				Target: &api.FilesystemTarget{
-					Dir: fs.Name,
-				},
-			})
+		socketPath := virtiofs.VirtioFSSocketPath(fs.Name)
+		if _, isHotplug := hotplugVolumes[fs.Name]; isHotplug {
+			socketPath = virtiofs.HotplugVirtioFSSocketPath(fs.Name)
+		}
+		domainFileSystems = append(domainFileSystems, ConvertFileSystem(fs.Name, socketPath))
 	}
 
 	return domainFileSystems
 }
+
+// ConvertFileSystem returns the virtiofs filesystem device served by the virtiofsd socket
+func ConvertFileSystem(name, socketPath string) api.FilesystemDevice {
+	return api.FilesystemDevice{
+		Type:       "mount",
+		AccessMode: "passthrough",
+		Driver: &api.FilesystemDriver{
+			Type:  "virtiofs",
+			Queue: "1024",
+		},
+		Source: &api.FilesystemSource{
+			Socket: socketPath,
+		},
+		Target: &api.FilesystemTarget{
+			Dir: name,
+		},
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package virtwrap
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	diskencryption "kubevirt.io/kubevirt/pkg/disk-encryption"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+// encryptedVolumePreparer gives access to the passphrases of encrypted disks and formats blank volumes
+type encryptedVolumePreparer interface {
+	ReadPassphrase(diskName string) ([]byte, error)
+	PrepareVolume(diskName, path string, format bool) error
+}
+
+// prepareEncryptedVolumes makes sure the volumes of encrypted disks are LUKS volumes before the domain is started,
+// formatting the blank ones of disks allowing it
+func (l *LibvirtDomainManager) prepareEncryptedVolumes(vmi *v1.VirtualMachineInstance, domain *api.Domain) error {
+	encryptionByDisk := map[string]*v1.DiskEncryption{}
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.Encryption != nil {
+			encryptionByDisk[disk.Name] = disk.Encryption
+		}
+	}
+	for _, disk := range domain.Spec.Devices.Disks {
+		if disk.Encryption == nil || disk.Alias == nil {
+			continue
+		}
+		encryption, exists := encryptionByDisk[disk.Alias.GetName()]
+		if !exists {
+			continue
+		}
+		path := disk.Source.File
+		if path == "" {
+			path = disk.Source.Dev
+		}
+		if err := l.volumePreparer.PrepareVolume(disk.Alias.GetName(), path, encryption.Format); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// defineDiskEncryptionSecrets hands the passphrases of the encrypted disks over to libvirt.
+// The secrets are ephemeral and private, libvirt keeps them in memory only and never reveals them.
+func (l *LibvirtDomainManager) defineDiskEncryptionSecrets(vmi *v1.VirtualMachineInstance) error {
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.Encryption == nil {
+			continue
+		}
+		passphrase, err := l.volumePreparer.ReadPassphrase(disk.Name)
+		if err != nil {
+			return err
+		}
+		uuid := diskencryption.SecretUUID(vmi.UID, disk.Name)
+		description := fmt.Sprintf("passphrase of disk %s of %s/%s", disk.Name, vmi.Namespace, vmi.Name)
+		if err := l.defineSecret(vmi, uuid, description, passphrase); err != nil {
+			return fmt.Errorf("failed to define the encryption secret of disk %s: %v", disk.Name, err)
+		}
+	}
+	return nil
+}
+
+func (l *LibvirtDomainManager) defineSecret(vmi *v1.VirtualMachineInstance, uuid, description string, value []byte) error {
+	secretXML, err := xml.Marshal(api.SecretSpec{
+		Ephemeral:   "yes",
+		Private:     "yes",
+		UUID:        uuid,
+		Description: description,
+	})
+	if err != nil {
+		return err
+	}
+	secret, err := l.virConn.SecretDefineXML(string(secretXML))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := secret.Free(); err != nil {
+			log.Log.Object(vmi).Reason(err).Warning("failed to free the libvirt secret")
+		}
+	}()
+	return secret.SetValue(value, 0)
+}
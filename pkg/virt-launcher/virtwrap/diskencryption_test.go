@@ -0,0 +1,164 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package virtwrap
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+	api2 "kubevirt.io/client-go/api"
+
+	diskencryption "kubevirt.io/kubevirt/pkg/disk-encryption"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+)
+
+type fakeVolumePreparer struct {
+	passphrases map[string]string
+	prepared    map[string]string
+	formattable map[string]bool
+}
+
+func (f *fakeVolumePreparer) ReadPassphrase(diskName string) ([]byte, error) {
+	passphrase, ok := f.passphrases[diskName]
+	if !ok {
+		return nil, fmt.Errorf("no passphrase for disk %s", diskName)
+	}
+	return []byte(passphrase), nil
+}
+
+func (f *fakeVolumePreparer) PrepareVolume(diskName, path string, format bool) error {
+	f.prepared[diskName] = path
+	f.formattable[diskName] = format
+	return nil
+}
+
+var _ = Describe("disk encryption on virt-launcher", func() {
+	var (
+		mockConn   *cli.MockConnection
+		mockSecret *cli.MockVirSecret
+		preparer   *fakeVolumePreparer
+		manager    *LibvirtDomainManager
+		vmi        *v1.VirtualMachineInstance
+	)
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		mockConn = cli.NewMockConnection(ctrl)
+		mockSecret = cli.NewMockVirSecret(ctrl)
+		preparer = &fakeVolumePreparer{
+			passphrases: map[string]string{"encrypted": "secret-passphrase"},
+			prepared:    map[string]string{},
+			formattable: map[string]bool{},
+		}
+		manager = &LibvirtDomainManager{virConn: mockConn, volumePreparer: preparer}
+
+		vmi = api2.NewMinimalVMI("testvmi")
+		vmi.UID = "1234"
+		vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+			{Name: "plain"},
+			{
+				Name: "encrypted",
+				Encryption: &v1.DiskEncryption{
+					SecretRef: k8sv1.LocalObjectReference{Name: "tenant-key"},
+				},
+			},
+		}
+	})
+
+	It("should define an ephemeral private secret per encrypted disk", func() {
+		mockConn.EXPECT().SecretDefineXML(gomock.Any()).DoAndReturn(func(secretXML string) (cli.VirSecret, error) {
+			var spec api.SecretSpec
+			Expect(xml.Unmarshal([]byte(secretXML), &spec)).To(Succeed())
+			Expect(spec.Ephemeral).To(Equal("yes"))
+			Expect(spec.Private).To(Equal("yes"))
+			Expect(spec.UUID).To(Equal(diskencryption.SecretUUID(vmi.UID, "encrypted")))
+			Expect(secretXML).ToNot(ContainSubstring("secret-passphrase"))
+			return mockSecret, nil
+		})
+		mockSecret.EXPECT().SetValue([]byte("secret-passphrase"), uint32(0)).Return(nil)
+		mockSecret.EXPECT().Free().Return(nil)
+
+		Expect(manager.defineDiskEncryptionSecrets(vmi)).To(Succeed())
+	})
+
+	It("should fail if the passphrase can't be read", func() {
+		preparer.passphrases = map[string]string{}
+
+		Expect(manager.defineDiskEncryptionSecrets(vmi)).ToNot(Succeed())
+	})
+
+	It("should fail if the secret value can't be set", func() {
+		mockConn.EXPECT().SecretDefineXML(gomock.Any()).Return(mockSecret, nil)
+		mockSecret.EXPECT().SetValue(gomock.Any(), uint32(0)).Return(fmt.Errorf("set value failed"))
+		mockSecret.EXPECT().Free().Return(nil)
+
+		Expect(manager.defineDiskEncryptionSecrets(vmi)).To(MatchError(ContainSubstring("set value failed")))
+	})
+
+	It("should only prepare the volumes of encrypted disks", func() {
+		domain := &api.Domain{}
+		domain.Spec.Devices.Disks = []api.Disk{
+			{
+				Alias:  api.NewUserDefinedAlias("plain"),
+				Source: api.DiskSource{File: "/var/run/kubevirt-private/vmi-disks/plain/disk.img"},
+			},
+			{
+				Alias:      api.NewUserDefinedAlias("encrypted"),
+				Source:     api.DiskSource{Dev: "/dev/encrypted"},
+				Encryption: &api.DiskEncryption{Format: "luks"},
+			},
+		}
+
+		Expect(manager.prepareEncryptedVolumes(vmi, domain)).To(Succeed())
+		Expect(preparer.prepared).To(Equal(map[string]string{"encrypted": "/dev/encrypted"}))
+		Expect(preparer.formattable).To(Equal(map[string]bool{"encrypted": false}))
+	})
+
+	It("should only allow formatting the volumes of disks setting format", func() {
+		vmi.Spec.Domain.Devices.Disks[1].Encryption.Format = true
+		domain := &api.Domain{}
+		domain.Spec.Devices.Disks = []api.Disk{
+			{
+				Alias:      api.NewUserDefinedAlias("encrypted"),
+				Source:     api.DiskSource{Dev: "/dev/encrypted"},
+				Encryption: &api.DiskEncryption{Format: "luks"},
+			},
+		}
+
+		Expect(manager.prepareEncryptedVolumes(vmi, domain)).To(Succeed())
+		Expect(preparer.formattable).To(Equal(map[string]bool{"encrypted": true}))
+	})
+
+	It("should not expand encrypted disks offline", func() {
+		disk := api.Disk{
+			ExpandDisksEnabled: true,
+			Source:             api.DiskSource{File: "/var/run/kubevirt-private/vmi-disks/encrypted/disk.img"},
+			Encryption:         &api.DiskEncryption{Format: "luks"},
+		}
+		Expect(shouldExpandOffline(disk)).To(BeFalse())
+	})
+})
@@ -33,6 +33,13 @@ const (
 	EFIVarsSecureBoot = "OVMF_VARS.secboot.fd"
 	EFICodeSEV        = "OVMF_CODE.cc.fd"
 	EFIVarsSEV        = EFIVars
+
+	// PersistentNVRAMDir is where the backend storage keeps the NVRAM of VMIs with persistent EFI
+	PersistentNVRAMDir = "/var/run/kubevirt-private/nvram"
+	// PersistentNVRAMSubPath is the directory of the backend storage holding the NVRAM
+	PersistentNVRAMSubPath = "nvram"
+
+	ephemeralNVRAMDir = "/tmp"
 )
 
 type EFIEnvironment struct {
@@ -119,3 +126,19 @@ func getEFIBinaryIfExists(path, binary string) string {
 	}
 	return ""
 }
+
+// NVRAMPath returns the path of the NVRAM file of the domain. Persistent NVRAM is kept
+// in the backend storage, so that it outlives the virt-launcher pod.
+func NVRAMPath(domainName string, persistent bool) string {
+	if persistent {
+		return filepath.Join(PersistentNVRAMDir, domainName+"_VARS.fd")
+	}
+	return filepath.Join(ephemeralNVRAMDir, domainName)
+}
+
+// HasPersistentNVRAM returns true if the domain keeps its NVRAM in the backend storage.
+// The NVRAM file must then be kept when the domain is undefined.
+func HasPersistentNVRAM(domainName string) bool {
+	_, err := os.Stat(NVRAMPath(domainName, true))
+	return err == nil
+}
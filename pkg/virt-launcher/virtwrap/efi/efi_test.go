@@ -106,3 +106,17 @@ var _ = Describe("EFI environment detection", func() {
 		Expect(efiEnv.EFIVars(!secureBootEnabled, !sevEnabled)).To(Equal(varsSEV)) // same as EFIVars
 	})
 })
+
+var _ = Describe("EFI NVRAM", func() {
+	It("should keep ephemeral NVRAM in the virt-launcher container", func() {
+		Expect(NVRAMPath("default_testvmi", false)).To(Equal("/tmp/default_testvmi"))
+	})
+
+	It("should keep persistent NVRAM in the backend storage", func() {
+		Expect(NVRAMPath("default_testvmi", true)).To(Equal("/var/run/kubevirt-private/nvram/default_testvmi_VARS.fd"))
+	})
+
+	It("should not report persistent NVRAM which does not exist", func() {
+		Expect(HasPersistentNVRAM("default_testvmi")).To(BeFalse())
+	})
+})
@@ -0,0 +1,239 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package virtwrap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+	"kubevirt.io/kubevirt/pkg/virtiofs"
+)
+
+const virtiofsdSocketTimeout = 10 * time.Second
+
+// virtiofsdProcess is a virtiofsd serving a hotplugged filesystem
+type virtiofsdProcess interface {
+	Stop() error
+}
+
+type virtiofsdCmd struct {
+	cmd *exec.Cmd
+}
+
+func (v *virtiofsdCmd) Stop() error {
+	if err := v.cmd.Process.Kill(); err != nil && err != os.ErrProcessDone {
+		return err
+	}
+	return nil
+}
+
+var startVirtiofsd = startVirtiofsdFunc
+
+// startVirtiofsdFunc starts virtiofsd sharing the hotplugged volume directory and waits for its socket
+func startVirtiofsdFunc(socketPath, sharedDir string) (virtiofsdProcess, error) {
+	if err := os.MkdirAll(filepath.Dir(socketPath), 0750); err != nil {
+		return nil, err
+	}
+	cmd := exec.Command("/usr/libexec/virtiofsd",
+		fmt.Sprintf("--socket-path=%s", socketPath),
+		fmt.Sprintf("--shared-dir=%s", sharedDir),
+		"--cache=auto",
+		"--sandbox=none",
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start virtiofsd for %s: %v", sharedDir, err)
+	}
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			log.Log.Reason(err).Warningf("virtiofsd for %s exited", sharedDir)
+		}
+	}()
+
+	err := wait.PollImmediate(100*time.Millisecond, virtiofsdSocketTimeout, func() (bool, error) {
+		_, err := os.Stat(socketPath)
+		return err == nil, nil
+	})
+	if err != nil {
+		_ = cmd.Process.Kill()
+		return nil, fmt.Errorf("virtiofsd socket %s did not appear: %v", socketPath, err)
+	}
+	return &virtiofsdCmd{cmd: cmd}, nil
+}
+
+func isHotplugFilesystem(fs api.FilesystemDevice) bool {
+	return fs.Source != nil && filepath.Dir(fs.Source.Socket) == virtiofs.HotplugVirtioFSSocketDir
+}
+
+// removeHotplugFilesystems drops the hotplugged filesystems from the spec of a domain about to be defined and
+// returns them, they are attached once the domain runs and their volume is mounted.
+func removeHotplugFilesystems(spec *api.DomainSpec) []api.FilesystemDevice {
+	var hotplugFilesystems []api.FilesystemDevice
+	filesystems := spec.Devices.Filesystems[:0]
+	for _, fs := range spec.Devices.Filesystems {
+		if isHotplugFilesystem(fs) {
+			hotplugFilesystems = append(hotplugFilesystems, fs)
+		} else {
+			filesystems = append(filesystems, fs)
+		}
+	}
+	spec.Devices.Filesystems = filesystems
+	return hotplugFilesystems
+}
+
+func getDetachedFilesystems(oldFilesystems, newFilesystems []api.FilesystemDevice) []api.FilesystemDevice {
+	newFilesystemMap := make(map[string]struct{})
+	for _, fs := range newFilesystems {
+		if fs.Source != nil {
+			newFilesystemMap[fs.Source.Socket] = struct{}{}
+		}
+	}
+	res := make([]api.FilesystemDevice, 0)
+	for _, oldFs := range oldFilesystems {
+		if !isHotplugFilesystem(oldFs) {
+			continue
+		}
+		if _, ok := newFilesystemMap[oldFs.Source.Socket]; !ok {
+			res = append(res, oldFs)
+		}
+	}
+	return res
+}
+
+func getAttachedFilesystems(oldFilesystems, newFilesystems []api.FilesystemDevice) []api.FilesystemDevice {
+	oldFilesystemMap := make(map[string]struct{})
+	for _, fs := range oldFilesystems {
+		if fs.Source != nil {
+			oldFilesystemMap[fs.Source.Socket] = struct{}{}
+		}
+	}
+	res := make([]api.FilesystemDevice, 0)
+	for _, newFs := range newFilesystems {
+		if !isHotplugFilesystem(newFs) {
+			continue
+		}
+		if _, ok := oldFilesystemMap[newFs.Source.Socket]; !ok {
+			res = append(res, newFs)
+		}
+	}
+	return res
+}
+
+func isHotplugVolumeMounted(vmi *v1.VirtualMachineInstance, volumeName string) bool {
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		if volumeStatus.Name == volumeName {
+			return volumeStatus.HotplugVolume != nil &&
+				(volumeStatus.Phase == v1.HotplugVolumeMounted || volumeStatus.Phase == v1.VolumeReady)
+		}
+	}
+	return false
+}
+
+// syncHotplugFilesystems detaches the removed hotplugged filesystems and attaches the new ones once their
+// volume is mounted into the pod. Each hotplugged filesystem is served by its own virtiofsd process.
+func (l *LibvirtDomainManager) syncHotplugFilesystems(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, oldSpec, newSpec *api.DomainSpec) error {
+	logger := log.Log.Object(vmi)
+
+	for _, detachFs := range getDetachedFilesystems(oldSpec.Devices.Filesystems, newSpec.Devices.Filesystems) {
+		logger.V(1).Infof("Detaching filesystem %s", detachFs.Target.Dir)
+		detachBytes, err := xml.Marshal(detachFs)
+		if err != nil {
+			logger.Reason(err).Error("marshalling detached filesystem failed")
+			return err
+		}
+		if err := dom.DetachDeviceFlags(string(detachBytes), affectDeviceLiveAndConfigLibvirtFlags); err != nil {
+			logger.Reason(err).Error("detaching filesystem")
+			return err
+		}
+	}
+
+	// Stop virtiofsd of all the filesystems which are gone, including the ones removed before they got attached
+	if err := l.stopUnusedVirtiofsd(newSpec); err != nil {
+		return err
+	}
+
+	for _, attachFs := range getAttachedFilesystems(oldSpec.Devices.Filesystems, newSpec.Devices.Filesystems) {
+		name := attachFs.Target.Dir
+		if !isHotplugVolumeMounted(vmi, name) {
+			continue
+		}
+		if _, running := l.hotplugVirtiofsd[name]; !running {
+			process, err := startVirtiofsd(attachFs.Source.Socket, hotplugdisk.GetVolumeMountDir(name))
+			if err != nil {
+				return err
+			}
+			l.hotplugVirtiofsd[name] = process
+		}
+		logger.V(1).Infof("Attaching filesystem %s", name)
+		attachBytes, err := xml.Marshal(attachFs)
+		if err != nil {
+			logger.Reason(err).Error("marshalling attached filesystem failed")
+			return err
+		}
+		if err := dom.AttachDeviceFlags(string(attachBytes), affectDeviceLiveAndConfigLibvirtFlags); err != nil {
+			logger.Reason(err).Error("attaching filesystem")
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *LibvirtDomainManager) stopUnusedVirtiofsd(spec *api.DomainSpec) error {
+	inUse := make(map[string]struct{})
+	for _, fs := range spec.Devices.Filesystems {
+		if isHotplugFilesystem(fs) {
+			inUse[fs.Target.Dir] = struct{}{}
+		}
+	}
+	for name := range l.hotplugVirtiofsd {
+		if _, ok := inUse[name]; !ok {
+			if err := l.stopVirtiofsd(name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// stopVirtiofsd forgets the process even if stopping it fails, a later hotplug of the same name starts a new one
+func (l *LibvirtDomainManager) stopVirtiofsd(name string) error {
+	process, running := l.hotplugVirtiofsd[name]
+	if !running {
+		return nil
+	}
+	delete(l.hotplugVirtiofsd, name)
+	if err := process.Stop(); err != nil {
+		return fmt.Errorf("failed to stop virtiofsd for filesystem %s: %v", name, err)
+	}
+	return nil
+}
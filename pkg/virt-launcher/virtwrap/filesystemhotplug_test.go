@@ -0,0 +1,155 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package virtwrap
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter"
+	"kubevirt.io/kubevirt/pkg/virtiofs"
+)
+
+type fakeVirtiofsd struct {
+	stopped bool
+	stopErr error
+}
+
+func (f *fakeVirtiofsd) Stop() error {
+	f.stopped = true
+	return f.stopErr
+}
+
+var _ = Describe("filesystem hotplug on virt-launcher", func() {
+	var (
+		mockDomain     *cli.MockVirDomain
+		manager        *LibvirtDomainManager
+		vmi            *v1.VirtualMachineInstance
+		startedSockets map[string]string
+	)
+
+	staticFs := converter.ConvertFileSystem("static", virtiofs.VirtioFSSocketPath("static"))
+	hotplugFs := converter.ConvertFileSystem("dataset", virtiofs.HotplugVirtioFSSocketPath("dataset"))
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		mockDomain = cli.NewMockVirDomain(ctrl)
+		manager = &LibvirtDomainManager{hotplugVirtiofsd: map[string]virtiofsdProcess{}}
+		vmi = &v1.VirtualMachineInstance{}
+
+		startedSockets = map[string]string{}
+		origStartVirtiofsd := startVirtiofsd
+		startVirtiofsd = func(socketPath, sharedDir string) (virtiofsdProcess, error) {
+			startedSockets[socketPath] = sharedDir
+			return &fakeVirtiofsd{}, nil
+		}
+		DeferCleanup(func() {
+			startVirtiofsd = origStartVirtiofsd
+		})
+	})
+
+	setVolumePhase := func(name string, phase v1.VolumePhase) {
+		vmi.Status.VolumeStatus = []v1.VolumeStatus{{
+			Name:          name,
+			Phase:         phase,
+			HotplugVolume: &v1.HotplugVolumeStatus{},
+		}}
+	}
+
+	domainSpec := func(filesystems ...api.FilesystemDevice) *api.DomainSpec {
+		spec := &api.DomainSpec{}
+		spec.Devices.Filesystems = filesystems
+		return spec
+	}
+
+	It("should only consider filesystems served by the launcher", func() {
+		Expect(getAttachedFilesystems(nil, []api.FilesystemDevice{staticFs, hotplugFs})).To(ConsistOf(hotplugFs))
+		Expect(getAttachedFilesystems([]api.FilesystemDevice{staticFs, hotplugFs}, []api.FilesystemDevice{staticFs, hotplugFs})).To(BeEmpty())
+		Expect(getDetachedFilesystems([]api.FilesystemDevice{staticFs, hotplugFs}, nil)).To(ConsistOf(hotplugFs))
+		Expect(getDetachedFilesystems([]api.FilesystemDevice{staticFs, hotplugFs}, []api.FilesystemDevice{staticFs, hotplugFs})).To(BeEmpty())
+	})
+
+	It("should tolerate filesystems without a source", func() {
+		Expect(getAttachedFilesystems([]api.FilesystemDevice{{}}, []api.FilesystemDevice{{}, hotplugFs})).To(ConsistOf(hotplugFs))
+		Expect(getDetachedFilesystems([]api.FilesystemDevice{{}, hotplugFs}, []api.FilesystemDevice{{}})).To(ConsistOf(hotplugFs))
+	})
+
+	It("should leave the hotplugged filesystems out of a domain about to be defined", func() {
+		spec := domainSpec(staticFs, hotplugFs)
+		Expect(removeHotplugFilesystems(spec)).To(ConsistOf(hotplugFs))
+		Expect(spec.Devices.Filesystems).To(ConsistOf(staticFs))
+	})
+
+	It("should not attach a filesystem before its volume is mounted", func() {
+		setVolumePhase("dataset", v1.HotplugVolumeAttachedToNode)
+
+		Expect(manager.syncHotplugFilesystems(vmi, mockDomain, domainSpec(staticFs), domainSpec(staticFs, hotplugFs))).To(Succeed())
+		Expect(startedSockets).To(BeEmpty())
+	})
+
+	It("should start virtiofsd and attach the filesystem once its volume is mounted", func() {
+		setVolumePhase("dataset", v1.HotplugVolumeMounted)
+		fsXML, err := xml.Marshal(hotplugFs)
+		Expect(err).ToNot(HaveOccurred())
+		mockDomain.EXPECT().AttachDeviceFlags(string(fsXML), affectDeviceLiveAndConfigLibvirtFlags).Return(nil)
+
+		Expect(manager.syncHotplugFilesystems(vmi, mockDomain, domainSpec(staticFs), domainSpec(staticFs, hotplugFs))).To(Succeed())
+		Expect(startedSockets).To(HaveKeyWithValue(virtiofs.HotplugVirtioFSSocketPath("dataset"), hotplugdisk.GetVolumeMountDir("dataset")))
+		Expect(manager.hotplugVirtiofsd).To(HaveKey("dataset"))
+	})
+
+	It("should detach the filesystem and stop virtiofsd", func() {
+		process := &fakeVirtiofsd{}
+		manager.hotplugVirtiofsd["dataset"] = process
+		fsXML, err := xml.Marshal(hotplugFs)
+		Expect(err).ToNot(HaveOccurred())
+		mockDomain.EXPECT().DetachDeviceFlags(string(fsXML), affectDeviceLiveAndConfigLibvirtFlags).Return(nil)
+
+		Expect(manager.syncHotplugFilesystems(vmi, mockDomain, domainSpec(staticFs, hotplugFs), domainSpec(staticFs))).To(Succeed())
+		Expect(process.stopped).To(BeTrue())
+		Expect(manager.hotplugVirtiofsd).To(BeEmpty())
+	})
+
+	It("should stop virtiofsd of a filesystem removed before it got attached", func() {
+		process := &fakeVirtiofsd{}
+		manager.hotplugVirtiofsd["dataset"] = process
+
+		Expect(manager.syncHotplugFilesystems(vmi, mockDomain, domainSpec(staticFs), domainSpec(staticFs))).To(Succeed())
+		Expect(process.stopped).To(BeTrue())
+		Expect(manager.hotplugVirtiofsd).To(BeEmpty())
+	})
+
+	It("should forget virtiofsd even if stopping it fails", func() {
+		process := &fakeVirtiofsd{stopErr: fmt.Errorf("boom")}
+		manager.hotplugVirtiofsd["dataset"] = process
+
+		Expect(manager.syncHotplugFilesystems(vmi, mockDomain, domainSpec(staticFs), domainSpec(staticFs))).To(MatchError(ContainSubstring("boom")))
+		Expect(manager.hotplugVirtiofsd).To(BeEmpty())
+	})
+})
@@ -85,6 +85,17 @@ func (_mr *_MockDomainManagerRecorder) UnfreezeVMI(arg0 interface{}) *gomock.Cal
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "UnfreezeVMI", arg0)
 }
 
+func (_m *MockDomainManager) FSTrimVMI(_param0 *v1.VirtualMachineInstance) ([]api.FSTrimmed, error) {
+	ret := _m.ctrl.Call(_m, "FSTrimVMI", _param0)
+	ret0, _ := ret[0].([]api.FSTrimmed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockDomainManagerRecorder) FSTrimVMI(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "FSTrimVMI", arg0)
+}
+
 func (_m *MockDomainManager) SoftRebootVMI(_param0 *v1.VirtualMachineInstance) error {
 	ret := _m.ctrl.Call(_m, "SoftRebootVMI", _param0)
 	ret0, _ := ret[0].(error)
@@ -307,6 +318,16 @@ func (_mr *_MockDomainManagerRecorder) UpdateVCPUs(arg0, arg1 interface{}) *gomo
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "UpdateVCPUs", arg0, arg1)
 }
 
+func (_m *MockDomainManager) UpdateIOTune(vmi *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "UpdateIOTune", vmi)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) UpdateIOTune(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UpdateIOTune", arg0)
+}
+
 func (_m *MockDomainManager) GetSEVInfo() (*v1.SEVPlatformInfo, error) {
 	ret := _m.ctrl.Call(_m, "GetSEVInfo")
 	ret0, _ := ret[0].(*v1.SEVPlatformInfo)
@@ -0,0 +1,115 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"fmt"
+
+	"libvirt.org/go/libvirt"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter"
+)
+
+// UpdateIOTune applies the I/O throttling of the VMI disks to the running domain.
+// Disks whose throttling already matches the VMI spec are left untouched.
+func (l *LibvirtDomainManager) UpdateIOTune(vmi *v1.VirtualMachineInstance) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	const errMsgPrefix = "failed to update disks I/O throttling"
+
+	domainName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domainName)
+	if err != nil {
+		return fmt.Errorf("%s: %v", errMsgPrefix, err)
+	}
+	defer dom.Free()
+
+	spec, err := getDomainSpec(dom)
+	if err != nil {
+		return fmt.Errorf("%s: %v", errMsgPrefix, err)
+	}
+
+	domainDisksByName := map[string]api.Disk{}
+	for _, disk := range spec.Devices.Disks {
+		if disk.Alias != nil {
+			domainDisksByName[disk.Alias.GetName()] = disk
+		}
+	}
+
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		domainDisk, exists := domainDisksByName[disk.Name]
+		if !exists {
+			continue
+		}
+		ioTune := converter.Convert_v1_DiskIOTune_To_api_IOTune(disk.IOTune)
+		if converter.IOTuneEqual(ioTune, domainDisk.IOTune) {
+			continue
+		}
+		if err := dom.SetBlockIoTune(domainDisk.Target.Device, toBlockIoTuneParameters(ioTune, domainDisk.IOTune), libvirt.DOMAIN_AFFECT_LIVE); err != nil {
+			return fmt.Errorf("%s of disk %s: %v", errMsgPrefix, disk.Name, err)
+		}
+		log.Log.Object(vmi).Infof("updated I/O throttling of disk %s", disk.Name)
+	}
+	return nil
+}
+
+// toBlockIoTuneParameters sets all the limits, clearing the ones which are not requested.
+// The group name is only passed on when it changes, which also allows leaving a group.
+func toBlockIoTuneParameters(ioTune, current *api.IOTune) *libvirt.DomainBlockIoTuneParameters {
+	if ioTune == nil {
+		ioTune = &api.IOTune{}
+	}
+	if current == nil {
+		current = &api.IOTune{}
+	}
+	return &libvirt.DomainBlockIoTuneParameters{
+		TotalBytesSecSet:    true,
+		TotalBytesSec:       ioTune.TotalBytesSec,
+		ReadBytesSecSet:     true,
+		ReadBytesSec:        ioTune.ReadBytesSec,
+		WriteBytesSecSet:    true,
+		WriteBytesSec:       ioTune.WriteBytesSec,
+		TotalIopsSecSet:     true,
+		TotalIopsSec:        ioTune.TotalIopsSec,
+		ReadIopsSecSet:      true,
+		ReadIopsSec:         ioTune.ReadIopsSec,
+		WriteIopsSecSet:     true,
+		WriteIopsSec:        ioTune.WriteIopsSec,
+		TotalBytesSecMaxSet: true,
+		TotalBytesSecMax:    ioTune.TotalBytesSecMax,
+		ReadBytesSecMaxSet:  true,
+		ReadBytesSecMax:     ioTune.ReadBytesSecMax,
+		WriteBytesSecMaxSet: true,
+		WriteBytesSecMax:    ioTune.WriteBytesSecMax,
+		TotalIopsSecMaxSet:  true,
+		TotalIopsSecMax:     ioTune.TotalIopsSecMax,
+		ReadIopsSecMaxSet:   true,
+		ReadIopsSecMax:      ioTune.ReadIopsSecMax,
+		WriteIopsSecMaxSet:  true,
+		WriteIopsSecMax:     ioTune.WriteIopsSecMax,
+		GroupNameSet:        ioTune.GroupName != current.GroupName,
+		GroupName:           ioTune.GroupName,
+	}
+}
@@ -36,12 +36,14 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter/vcpu"
 
+	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"libvirt.org/go/libvirt"
 
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/log"
 
+	networkvolume "kubevirt.io/kubevirt/pkg/storage/network-volume"
 	virtutil "kubevirt.io/kubevirt/pkg/util"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	migrationproxy "kubevirt.io/kubevirt/pkg/virt-handler/migration-proxy"
@@ -370,8 +372,17 @@ func classifyVolumesForMigration(vmi *v1.VirtualMachineInstance) *migrationDisks
 	}
 	for _, volume := range vmi.Spec.Volumes {
 		volSrc := volume.VolumeSource
+		// Hotplugged read-only volumes are provided to the target by its own attachment pod
+		if virtutil.IsHotplugConfigVolume(&volume) || virtutil.IsHotplugContainerDiskVolume(&volume) {
+			disks.shared[volume.Name] = true
+			continue
+		}
 		if volSrc.PersistentVolumeClaim != nil || volSrc.DataVolume != nil ||
-			(volSrc.HostDisk != nil && *volSrc.HostDisk.Shared) {
+			(volSrc.HostDisk != nil && *volSrc.HostDisk.Shared) || networkvolume.IsNetworkVolume(&volume) {
+			disks.shared[volume.Name] = true
+		}
+		// An overlay on a claim which is not shared must never be copied onto the claim the source still uses
+		if volSrc.Overlay != nil && hasSharedClaim(vmi, volume.Name) {
 			disks.shared[volume.Name] = true
 		}
 		if volSrc.ConfigMap != nil || volSrc.Secret != nil || volSrc.DownwardAPI != nil ||
@@ -383,6 +394,21 @@ func classifyVolumesForMigration(vmi *v1.VirtualMachineInstance) *migrationDisks
 	return disks
 }
 
+// hasSharedClaim checks if the claim backing the volume can be accessed by both the source and the target
+func hasSharedClaim(vmi *v1.VirtualMachineInstance, volumeName string) bool {
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		if volumeStatus.Name != volumeName || volumeStatus.PersistentVolumeClaimInfo == nil {
+			continue
+		}
+		for _, accessMode := range volumeStatus.PersistentVolumeClaimInfo.AccessModes {
+			if accessMode == k8sv1.ReadWriteMany {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func getDiskTargetsForMigration(dom cli.VirDomain, vmi *v1.VirtualMachineInstance) []string {
 	// This method collects all VMI disks that needs to be copied during live migration
 	// and returns a list of its target device names.
@@ -46,8 +46,11 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/hostdevice/generic"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/hostdevice/gpu"
 
+	diskencryption "kubevirt.io/kubevirt/pkg/disk-encryption"
 	"kubevirt.io/kubevirt/pkg/downwardmetrics"
 	"kubevirt.io/kubevirt/pkg/network/cache"
+	backendstorage "kubevirt.io/kubevirt/pkg/storage/backend-storage"
+	networkvolume "kubevirt.io/kubevirt/pkg/storage/network-volume"
 	"kubevirt.io/kubevirt/pkg/util/hardware"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/agent"
@@ -73,6 +76,7 @@ import (
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
 	"kubevirt.io/kubevirt/pkg/hooks"
 	"kubevirt.io/kubevirt/pkg/ignition"
+	netdriver "kubevirt.io/kubevirt/pkg/network/driver"
 	netsetup "kubevirt.io/kubevirt/pkg/network/setup"
 	netsriov "kubevirt.io/kubevirt/pkg/network/sriov"
 	netvmispec "kubevirt.io/kubevirt/pkg/network/vmispec"
@@ -113,6 +117,7 @@ type DomainManager interface {
 	UnpauseVMI(*v1.VirtualMachineInstance) error
 	FreezeVMI(*v1.VirtualMachineInstance, int32) error
 	UnfreezeVMI(*v1.VirtualMachineInstance) error
+	FSTrimVMI(*v1.VirtualMachineInstance) ([]api.FSTrimmed, error)
 	SoftRebootVMI(*v1.VirtualMachineInstance) error
 	KillVMI(*v1.VirtualMachineInstance) error
 	DeleteVMI(*v1.VirtualMachineInstance) error
@@ -135,6 +140,7 @@ type DomainManager interface {
 	MemoryDump(vmi *v1.VirtualMachineInstance, dumpPath string) error
 	GetQemuVersion() (string, error)
 	UpdateVCPUs(vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) error
+	UpdateIOTune(vmi *v1.VirtualMachineInstance) error
 	GetSEVInfo() (*v1.SEVPlatformInfo, error)
 	GetLaunchMeasurement(*v1.VirtualMachineInstance) (*v1.SEVMeasurementInfo, error)
 	InjectLaunchSecret(*v1.VirtualMachineInstance, *v1.SEVSecretOptions) error
@@ -168,6 +174,15 @@ type LibvirtDomainManager struct {
 	migrateInfoStats         *stats.DomainJobInfo
 
 	metadataCache *metadata.Cache
+
+	podLinkStates map[string]podLinkState
+
+	// virtiofsd processes of the hotplugged filesystems, implicitly locked by domainModifyLock
+	hotplugVirtiofsd map[string]virtiofsdProcess
+
+	volumePreparer encryptedVolumePreparer
+
+	readNetworkVolumeCredentials func(volume *v1.Volume) (*networkvolume.Credentials, error)
 }
 
 type pausedVMIs struct {
@@ -214,6 +229,11 @@ func newLibvirtDomainManager(connection cli.Connection, virtShareDir, ephemeralD
 		cancelSafetyUnfreezeChan: make(chan struct{}),
 		migrateInfoStats:         &stats.DomainJobInfo{},
 		metadataCache:            metadataCache,
+		podLinkStates:            map[string]podLinkState{},
+		hotplugVirtiofsd:         map[string]virtiofsdProcess{},
+		volumePreparer:           diskencryption.NewVolumePreparer(),
+
+		readNetworkVolumeCredentials: networkvolume.ReadVolumeCredentials,
 	}
 
 	manager.hotplugHostDevicesInProgress = make(chan struct{}, maxConcurrentHotplugHostDevices)
@@ -724,6 +744,17 @@ func (l *LibvirtDomainManager) preStartHook(vmi *v1.VirtualMachineInstance, doma
 		return domain, fmt.Errorf("failed to craete downwardMetric disk: %v", err)
 	}
 
+	// check or format encrypted volumes and hand their passphrases over to libvirt
+	if err := l.prepareEncryptedVolumes(vmi, domain); err != nil {
+		return domain, fmt.Errorf("preparing encrypted volumes failed: %v", err)
+	}
+	if err := l.defineDiskEncryptionSecrets(vmi); err != nil {
+		return domain, err
+	}
+	if err := l.defineNetworkVolumeSecrets(vmi, domain); err != nil {
+		return domain, err
+	}
+
 	// set drivers cache mode
 	for i := range domain.Spec.Devices.Disks {
 		err := converter.SetDriverCacheMode(&domain.Spec.Devices.Disks[i], l.directIOChecker)
@@ -811,6 +842,10 @@ func shouldExpandOffline(disk api.Disk) bool {
 		// Block devices don't need to be expanded
 		return false
 	}
+	if disk.Encryption != nil {
+		// qemu-img can't resize LUKS volumes without their passphrase
+		return false
+	}
 	diskInfo, err := converter.GetImageInfo(getSourceFile(disk))
 	if err != nil {
 		log.DefaultLogger().Reason(err).Warning("Failed to get image info")
@@ -863,6 +898,9 @@ func (l *LibvirtDomainManager) generateConverterContext(vmi *v1.VirtualMachineIn
 				isBlockDV, _ = isBlockDeviceVolume(volume.Name)
 			}
 			isBlockDVMap[volume.Name] = isBlockDV
+		} else if volume.VolumeSource.Overlay != nil {
+			baseVolumeName := ephemeraldisk.OverlayBaseVolumeName(volume.Name)
+			isBlockPVCMap[baseVolumeName], _ = isBlockDeviceVolume(baseVolumeName)
 		}
 	}
 
@@ -877,9 +915,10 @@ func (l *LibvirtDomainManager) generateConverterContext(vmi *v1.VirtualMachineIn
 		}
 
 		efiConf = &converter.EFIConfiguration{
-			EFICode:      l.efiEnvironment.EFICode(secureBoot, sev),
-			EFIVars:      l.efiEnvironment.EFIVars(secureBoot, sev),
-			SecureLoader: secureBoot,
+			EFICode:         l.efiEnvironment.EFICode(secureBoot, sev),
+			EFIVars:         l.efiEnvironment.EFIVars(secureBoot, sev),
+			SecureLoader:    secureBoot,
+			PersistentNVRAM: backendstorage.HasPersistentEFI(&vmi.Spec),
 		}
 	}
 
@@ -990,6 +1029,8 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, allowEmul
 				return nil, err
 			}
 
+			// virtiofsd of the hotplugged filesystems is not running yet, they are attached further down
+			hotplugFilesystems := removeHotplugFilesystems(&domain.Spec)
 			dom, err = withNetworkIfacesResources(
 				vmi, &domain.Spec,
 				func(v *v1.VirtualMachineInstance, s *api.DomainSpec) (cli.VirDomain, error) {
@@ -999,6 +1040,7 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, allowEmul
 			if err != nil {
 				return nil, err
 			}
+			domain.Spec.Devices.Filesystems = append(domain.Spec.Devices.Filesystems, hotplugFilesystems...)
 
 			l.metadataCache.UID.Set(vmi.UID)
 			l.metadataCache.GracePeriod.Set(
@@ -1106,6 +1148,38 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, allowEmul
 		}
 	}
 
+	// Change the media of cdroms in place
+	for _, cdrom := range getChangedCDRomMedia(oldSpec.Devices.Disks, domain.Spec.Devices.Disks) {
+		if file := getSourceFile(cdrom); file != "" {
+			mediaReady, err := checkIfDiskReadyToUse(file)
+			if err != nil {
+				return nil, err
+			}
+			if !mediaReady {
+				continue
+			}
+			if err := converter.SetDriverCacheMode(&cdrom, l.directIOChecker); err != nil {
+				return nil, err
+			}
+		}
+		logger.V(1).Infof("Changing media of cdrom %s, target %s", cdrom.Alias.GetName(), cdrom.Target.Device)
+
+		cdromBytes, err := xml.Marshal(cdrom)
+		if err != nil {
+			logger.Reason(err).Error("marshalling cdrom failed")
+			return nil, err
+		}
+		err = dom.UpdateDeviceFlags(strings.ToLower(string(cdromBytes)), affectDeviceLiveAndConfigLibvirtFlags)
+		if err != nil {
+			logger.Reason(err).Error("changing cdrom media")
+			return nil, err
+		}
+	}
+
+	if err := l.syncHotplugFilesystems(vmi, dom, &oldSpec, &domain.Spec); err != nil {
+		return nil, err
+	}
+
 	// Resize and notify the VM about changed disks
 	for _, disk := range domain.Spec.Devices.Disks {
 		if shouldExpandOnline(dom, disk) {
@@ -1130,6 +1204,9 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, allowEmul
 		if err := networkInterfaceManager.hotUnplugVirtioInterface(vmi, &api.Domain{Spec: oldSpec}); err != nil {
 			return nil, err
 		}
+		if err := networkInterfaceManager.syncReattachedInterfaces(vmi, &api.Domain{Spec: oldSpec}, &netdriver.NetworkUtilsHandler{}, l.podLinkStates); err != nil {
+			return nil, err
+		}
 	}
 
 	// TODO: check if VirtualMachineInstance Spec and Domain Spec are equal or if we have to sync
@@ -1191,7 +1268,7 @@ func getDetachedDisks(oldDisks, newDisks []api.Disk) []api.Disk {
 	}
 	res := make([]api.Disk, 0)
 	for _, oldDisk := range oldDisks {
-		if !isHotplugDisk(oldDisk) {
+		if !isHotplugDisk(oldDisk) || isCDRom(oldDisk) {
 			continue
 		}
 		if _, ok := newDiskMap[getSourceFile(oldDisk)]; !ok {
@@ -1212,7 +1289,7 @@ func getAttachedDisks(oldDisks, newDisks []api.Disk) []api.Disk {
 	}
 	res := make([]api.Disk, 0)
 	for _, newDisk := range newDisks {
-		if !isHotplugDisk(newDisk) {
+		if !isHotplugDisk(newDisk) || isCDRom(newDisk) {
 			continue
 		}
 		if _, ok := oldDiskMap[getSourceFile(newDisk)]; !ok {
@@ -1223,6 +1300,36 @@ func getAttachedDisks(oldDisks, newDisks []api.Disk) []api.Disk {
 	return res
 }
 
+func isCDRom(disk api.Disk) bool {
+	return disk.Device == "cdrom"
+}
+
+// getChangedCDRomMedia returns the new cdroms whose media differs from the
+// cdrom with the same target in the old disks. Cdroms are never attached or
+// detached, only their media is swapped.
+func getChangedCDRomMedia(oldDisks, newDisks []api.Disk) []api.Disk {
+	oldCDRomMap := make(map[string]api.Disk)
+	for _, disk := range oldDisks {
+		if isCDRom(disk) {
+			oldCDRomMap[disk.Target.Device] = disk
+		}
+	}
+	res := make([]api.Disk, 0)
+	for _, newDisk := range newDisks {
+		if !isCDRom(newDisk) {
+			continue
+		}
+		oldDisk, ok := oldCDRomMap[newDisk.Target.Device]
+		if !ok {
+			continue
+		}
+		if getSourceFile(oldDisk) != getSourceFile(newDisk) {
+			res = append(res, newDisk)
+		}
+	}
+	return res
+}
+
 var isHotplugBlockDeviceVolume = isHotplugBlockDeviceVolumeFunc
 
 func isHotplugBlockDeviceVolumeFunc(volumeName string) bool {
@@ -1574,6 +1681,26 @@ func (l *LibvirtDomainManager) UnfreezeVMI(vmi *v1.VirtualMachineInstance) error
 	return nil
 }
 
+// FSTrimVMI trims the mounted guest filesystems, the storage gets the unused blocks back through the discard=unmap disks
+func (l *LibvirtDomainManager) FSTrimVMI(vmi *v1.VirtualMachineInstance) ([]api.FSTrimmed, error) {
+	if l.migrationInProgress() {
+		return nil, fmt.Errorf("Failed to trim the filesystems of VMI, VMI is currently during migration")
+	}
+	domainName := api.VMINamespaceKeyFunc(vmi)
+
+	// trimming a frozen filesystem blocks until it is thawed
+	fsfreezeStatus, err := l.getParsedFSStatus(domainName)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to get fs status before trimming the filesystems")
+		return nil, err
+	}
+	if fsfreezeStatus == api.FSFrozen {
+		return nil, fmt.Errorf("Failed to trim the filesystems of VMI, the filesystems are frozen")
+	}
+
+	return agent.GuestFSTrim(l.virConn, domainName)
+}
+
 func (l *LibvirtDomainManager) SoftRebootVMI(vmi *v1.VirtualMachineInstance) error {
 	domainRebootFlagValues := libvirt.DOMAIN_REBOOT_GUEST_AGENT
 	condManager := controller.NewVirtualMachineInstanceConditionManager()
@@ -1709,7 +1836,12 @@ func (l *LibvirtDomainManager) DeleteVMI(vmi *v1.VirtualMachineInstance) error {
 	}
 	defer dom.Free()
 
-	err = dom.UndefineFlags(libvirt.DOMAIN_UNDEFINE_NVRAM)
+	// persistent NVRAM lives in the backend storage and must outlive the domain
+	undefineFlags := libvirt.DOMAIN_UNDEFINE_NVRAM
+	if efi.HasPersistentNVRAM(domName) {
+		undefineFlags = libvirt.DOMAIN_UNDEFINE_KEEP_NVRAM
+	}
+	err = dom.UndefineFlags(undefineFlags)
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Error("Undefining the domain failed.")
 		return err
@@ -632,6 +632,30 @@ var _ = Describe("Manager", func() {
 			// wait for the unfreeze timeout
 			time.Sleep(unfreezeTimeout + 2*time.Second)
 		})
+		It("should trim the filesystems of a VirtualMachineInstance", func() {
+			vmi := newVMI(testNamespace, testVmName)
+
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"`+string(agentpoller.GET_FSFREEZE_STATUS)+`"}`, testDomainName).Return(expectedThawedOutput, nil)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fstrim"}`, testDomainName).
+				Return(`{"return":{"paths":[{"path":"/","trimmed":4096,"minimum":0},{"path":"/mnt","error":"discard operation is not supported"}]}}`, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+			trimmed, err := manager.FSTrimVMI(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(trimmed).To(Equal([]api.FSTrimmed{
+				{Mountpoint: "/", TrimmedBytes: 4096},
+				{Mountpoint: "/mnt", Error: "discard operation is not supported"},
+			}))
+		})
+		It("should fail to trim the filesystems of a frozen VirtualMachineInstance", func() {
+			vmi := newVMI(testNamespace, testVmName)
+
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"`+string(agentpoller.GET_FSFREEZE_STATUS)+`"}`, testDomainName).Return(expectedFrozenOutput, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+			_, err := manager.FSTrimVMI(vmi)
+			Expect(err).To(MatchError(ContainSubstring("the filesystems are frozen")))
+		})
 		It("should update domain with memory dump info when completed successfully", func() {
 			mockConn.EXPECT().LookupDomainByName(testDomainName).DoAndReturn(mockDomainWithFreeExpectation)
 			mockDomain.EXPECT().CoreDumpWithFormat(testDumpPath, libvirt.DOMAIN_CORE_DUMP_FORMAT_RAW, libvirt.DUMP_MEMORY_ONLY).Return(nil)
@@ -1883,6 +1907,41 @@ var _ = Describe("Manager", func() {
 			copyDisks := getDiskTargetsForMigration(mockDomain, vmi)
 			Expect(copyDisks).Should(ConsistOf("vdb", "vdd"))
 		})
+		DescribeTable("should copy overlay volumes only when their overlay claim is not shared", func(accessMode k8sv1.PersistentVolumeAccessMode, expectedCopyDisks []string) {
+			var convertedDomain = `<domain type="kvm" xmlns:qemu="http://libvirt.org/schemas/domain/qemu/1.0">
+  <devices>
+    <disk device="disk" type="file">
+      <source file="/var/run/kubevirt-private/vmi-disks/overlay/disk.qcow2"></source>
+      <target bus="virtio" dev="vda"></target>
+      <driver cache="none" name="qemu" type="qcow2"></driver>
+      <alias name="ua-overlay"></alias>
+      <backingStore type="file">
+        <format type="raw"></format>
+        <source file="/var/run/kubevirt-private/vmi-disks/overlay-base/disk.img"></source>
+      </backingStore>
+    </disk>
+  </devices>
+</domain>`
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Volumes = []v1.Volume{
+				{Name: "overlay", VolumeSource: v1.VolumeSource{Overlay: &v1.OverlayVolumeSource{ClaimName: "overlay-pvc", BaseClaimName: "base-pvc"}}},
+			}
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{
+				{
+					Name: "overlay",
+					PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{
+						AccessModes: []k8sv1.PersistentVolumeAccessMode{accessMode},
+					},
+				},
+			}
+
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(convertedDomain, nil)
+
+			Expect(getDiskTargetsForMigration(mockDomain, vmi)).To(ConsistOf(expectedCopyDisks))
+		},
+			Entry("with a shared overlay claim", k8sv1.ReadWriteMany, []string{}),
+			Entry("with a non-shared overlay claim", k8sv1.ReadWriteOnce, []string{"vda"}),
+		)
 		AfterEach(func() {
 			ip.GetLoopbackAddress = funcPreviousValue
 		})
@@ -1912,6 +1971,94 @@ var _ = Describe("Manager", func() {
 			Entry("paused", libvirt.DOMAIN_PAUSED),
 		)
 	})
+	Context("on I/O throttling update", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		newDomainDisk := func(name, dev string, ioTune *api.IOTune) api.Disk {
+			return api.Disk{
+				Alias:  api.NewUserDefinedAlias(name),
+				Target: api.DiskTarget{Device: dev},
+				IOTune: ioTune,
+			}
+		}
+
+		expectDomainDisks := func(disks ...api.Disk) {
+			domainSpec := &api.DomainSpec{}
+			domainSpec.Devices.Disks = disks
+			domainXML, err := xml.Marshal(domainSpec)
+			Expect(err).ToNot(HaveOccurred())
+			mockConn.EXPECT().LookupDomainByName(testDomainName).DoAndReturn(mockDomainWithFreeExpectation)
+			mockDomain.EXPECT().GetXMLDesc(gomock.Any()).Return(string(domainXML), nil)
+		}
+
+		BeforeEach(func() {
+			vmi = newVMI(testNamespace, testVmName)
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{Name: "rootdisk", IOTune: &v1.DiskIOTune{TotalIOPSSec: 100}},
+				{Name: "datadisk"},
+			}
+		})
+
+		It("should set the throttling of the changed disks only", func() {
+			expectDomainDisks(
+				newDomainDisk("rootdisk", "vda", &api.IOTune{TotalIopsSec: 50}),
+				newDomainDisk("datadisk", "vdb", nil),
+			)
+			mockDomain.EXPECT().SetBlockIoTune("vda", gomock.Any(), libvirt.DOMAIN_AFFECT_LIVE).DoAndReturn(
+				func(_ string, params *libvirt.DomainBlockIoTuneParameters, _ libvirt.DomainModificationImpact) error {
+					Expect(params.TotalIopsSecSet).To(BeTrue())
+					Expect(params.TotalIopsSec).To(BeEquivalentTo(100))
+					Expect(params.ReadBytesSecSet).To(BeTrue())
+					Expect(params.ReadBytesSec).To(BeZero())
+					Expect(params.GroupNameSet).To(BeFalse())
+					return nil
+				})
+
+			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+			Expect(manager.UpdateIOTune(vmi)).To(Succeed())
+		})
+
+		It("should clear the throttling of a disk no longer limited", func() {
+			vmi.Spec.Domain.Devices.Disks[0].IOTune = nil
+			expectDomainDisks(
+				newDomainDisk("rootdisk", "vda", &api.IOTune{TotalIopsSec: 50}),
+				newDomainDisk("datadisk", "vdb", nil),
+			)
+			mockDomain.EXPECT().SetBlockIoTune("vda", &libvirt.DomainBlockIoTuneParameters{
+				TotalBytesSecSet: true, ReadBytesSecSet: true, WriteBytesSecSet: true,
+				TotalIopsSecSet: true, ReadIopsSecSet: true, WriteIopsSecSet: true,
+				TotalBytesSecMaxSet: true, ReadBytesSecMaxSet: true, WriteBytesSecMaxSet: true,
+				TotalIopsSecMaxSet: true, ReadIopsSecMaxSet: true, WriteIopsSecMaxSet: true,
+			}, libvirt.DOMAIN_AFFECT_LIVE).Return(nil)
+
+			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+			Expect(manager.UpdateIOTune(vmi)).To(Succeed())
+		})
+
+		It("should remove a disk from its throttling group", func() {
+			expectDomainDisks(
+				newDomainDisk("rootdisk", "vda", &api.IOTune{TotalIopsSec: 100, GroupName: "group"}),
+				newDomainDisk("datadisk", "vdb", nil),
+			)
+			mockDomain.EXPECT().SetBlockIoTune("vda", gomock.Any(), libvirt.DOMAIN_AFFECT_LIVE).DoAndReturn(
+				func(_ string, params *libvirt.DomainBlockIoTuneParameters, _ libvirt.DomainModificationImpact) error {
+					Expect(params.GroupNameSet).To(BeTrue())
+					Expect(params.GroupName).To(BeEmpty())
+					return nil
+				})
+
+			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+			Expect(manager.UpdateIOTune(vmi)).To(Succeed())
+		})
+
+		It("should fail when libvirt rejects the throttling", func() {
+			expectDomainDisks(newDomainDisk("rootdisk", "vda", nil))
+			mockDomain.EXPECT().SetBlockIoTune("vda", gomock.Any(), libvirt.DOMAIN_AFFECT_LIVE).Return(fmt.Errorf("boom"))
+
+			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+			Expect(manager.UpdateIOTune(vmi)).To(MatchError(ContainSubstring("boom")))
+		})
+	})
 	DescribeTable("check migration flags",
 		func(migrationType string) {
 			isBlockMigration := migrationType == "block"
@@ -2381,6 +2528,23 @@ var _ = Describe("getAttachedDisks", func() {
 				},
 			},
 			[]api.Disk{}),
+		Entry("be empty if media is inserted into a cdrom",
+			[]api.Disk{
+				{
+					Device: "cdrom",
+					Target: api.DiskTarget{Device: "sda"},
+				},
+			},
+			[]api.Disk{
+				{
+					Device: "cdrom",
+					Target: api.DiskTarget{Device: "sda"},
+					Source: api.DiskSource{
+						File: filepath.Join(v1.HotplugDiskDir, "cdrom.img"),
+					},
+				},
+			},
+			[]api.Disk{}),
 	)
 })
 
@@ -2460,6 +2624,68 @@ var _ = Describe("getDetachedDisks", func() {
 				},
 			},
 			[]api.Disk{}),
+		Entry("be empty if media is ejected from a cdrom",
+			[]api.Disk{
+				{
+					Device: "cdrom",
+					Target: api.DiskTarget{Device: "sda"},
+					Source: api.DiskSource{
+						File: filepath.Join(v1.HotplugDiskDir, "cdrom.img"),
+					},
+				},
+			},
+			[]api.Disk{
+				{
+					Device: "cdrom",
+					Target: api.DiskTarget{Device: "sda"},
+				},
+			},
+			[]api.Disk{}),
+	)
+})
+
+var _ = Describe("getChangedCDRomMedia", func() {
+	emptyCDRom := api.Disk{
+		Device: "cdrom",
+		Target: api.DiskTarget{Device: "sda"},
+	}
+	cdromWithMedia := func(file string) api.Disk {
+		disk := emptyCDRom
+		disk.Source = api.DiskSource{File: filepath.Join(v1.HotplugDiskDir, file)}
+		return disk
+	}
+
+	DescribeTable("should return the correct values", func(oldDisks, newDisks, expected []api.Disk) {
+		Expect(getChangedCDRomMedia(oldDisks, newDisks)).To(Equal(expected))
+	},
+		Entry("be empty with empty old and new",
+			[]api.Disk{},
+			[]api.Disk{},
+			[]api.Disk{}),
+		Entry("be empty if the media did not change",
+			[]api.Disk{cdromWithMedia("iso1.img")},
+			[]api.Disk{cdromWithMedia("iso1.img")},
+			[]api.Disk{}),
+		Entry("contain the cdrom if media is inserted",
+			[]api.Disk{emptyCDRom},
+			[]api.Disk{cdromWithMedia("iso1.img")},
+			[]api.Disk{cdromWithMedia("iso1.img")}),
+		Entry("contain the cdrom if media is ejected",
+			[]api.Disk{cdromWithMedia("iso1.img")},
+			[]api.Disk{emptyCDRom},
+			[]api.Disk{emptyCDRom}),
+		Entry("contain the cdrom if media is swapped",
+			[]api.Disk{cdromWithMedia("iso1.img")},
+			[]api.Disk{cdromWithMedia("iso2.img")},
+			[]api.Disk{cdromWithMedia("iso2.img")}),
+		Entry("be empty if the cdrom is new",
+			[]api.Disk{},
+			[]api.Disk{cdromWithMedia("iso1.img")},
+			[]api.Disk{}),
+		Entry("be empty for changed disks",
+			[]api.Disk{{Device: "disk", Target: api.DiskTarget{Device: "sda"}}},
+			[]api.Disk{{Device: "disk", Target: api.DiskTarget{Device: "sda"}, Source: api.DiskSource{File: "file"}}},
+			[]api.Disk{}),
 	)
 })
 
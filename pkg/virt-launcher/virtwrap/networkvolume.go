@@ -0,0 +1,56 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package virtwrap
+
+import (
+	"fmt"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	networkvolume "kubevirt.io/kubevirt/pkg/storage/network-volume"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+// defineNetworkVolumeSecrets hands the credentials of the network volumes over to libvirt and
+// sets the username the disks authenticate with. As for encrypted disks the secrets are ephemeral and private.
+func (l *LibvirtDomainManager) defineNetworkVolumeSecrets(vmi *v1.VirtualMachineInstance, domain *api.Domain) error {
+	for i := range vmi.Spec.Volumes {
+		volume := &vmi.Spec.Volumes[i]
+		if !networkvolume.IsNetworkVolume(volume) || networkvolume.SecretRef(volume) == nil {
+			continue
+		}
+		credentials, err := l.readNetworkVolumeCredentials(volume)
+		if err != nil {
+			return err
+		}
+		uuid := networkvolume.SecretUUID(vmi.UID, volume.Name)
+		description := fmt.Sprintf("credentials of volume %s of %s/%s", volume.Name, vmi.Namespace, vmi.Name)
+		if err := l.defineSecret(vmi, uuid, description, credentials.Secret); err != nil {
+			return fmt.Errorf("failed to define the secret of volume %s: %v", volume.Name, err)
+		}
+		for j := range domain.Spec.Devices.Disks {
+			disk := &domain.Spec.Devices.Disks[j]
+			if disk.Auth != nil && disk.Alias != nil && disk.Alias.GetName() == volume.Name {
+				disk.Auth.Username = credentials.Username
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package virtwrap
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+	api2 "kubevirt.io/client-go/api"
+
+	networkvolume "kubevirt.io/kubevirt/pkg/storage/network-volume"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+)
+
+var _ = Describe("network volumes on virt-launcher", func() {
+	var (
+		mockConn    *cli.MockConnection
+		mockSecret  *cli.MockVirSecret
+		credentials map[string]*networkvolume.Credentials
+		manager     *LibvirtDomainManager
+		vmi         *v1.VirtualMachineInstance
+		domain      *api.Domain
+	)
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		mockConn = cli.NewMockConnection(ctrl)
+		mockSecret = cli.NewMockVirSecret(ctrl)
+		credentials = map[string]*networkvolume.Credentials{
+			"iscsi": {Username: "chap-user", Secret: []byte("chap-password")},
+		}
+		manager = &LibvirtDomainManager{
+			virConn: mockConn,
+			readNetworkVolumeCredentials: func(volume *v1.Volume) (*networkvolume.Credentials, error) {
+				c, ok := credentials[volume.Name]
+				if !ok {
+					return nil, fmt.Errorf("no credentials for volume %s", volume.Name)
+				}
+				return c, nil
+			},
+		}
+
+		vmi = api2.NewMinimalVMI("testvmi")
+		vmi.UID = "1234"
+		vmi.Spec.Volumes = []v1.Volume{
+			{Name: "nbd", VolumeSource: v1.VolumeSource{NBD: &v1.NBDVolumeSource{URI: "nbd://nbd.example.com"}}},
+			{Name: "iscsi", VolumeSource: v1.VolumeSource{ISCSI: &v1.ISCSIVolumeSource{
+				TargetPortal:  "10.0.0.1",
+				IQN:           "iqn.2024-01.com.example:storage",
+				CHAPSecretRef: &k8sv1.LocalObjectReference{Name: "chap"},
+			}}},
+		}
+		domain = &api.Domain{}
+		domain.Spec.Devices.Disks = []api.Disk{
+			{Alias: api.NewUserDefinedAlias("nbd")},
+			{
+				Alias: api.NewUserDefinedAlias("iscsi"),
+				Auth:  &api.DiskAuth{Secret: &api.DiskSecret{Type: "iscsi", UUID: networkvolume.SecretUUID("1234", "iscsi")}},
+			},
+		}
+	})
+
+	It("should define a secret per authenticated volume and set the username of its disk", func() {
+		mockConn.EXPECT().SecretDefineXML(gomock.Any()).DoAndReturn(func(secretXML string) (cli.VirSecret, error) {
+			var spec api.SecretSpec
+			Expect(xml.Unmarshal([]byte(secretXML), &spec)).To(Succeed())
+			Expect(spec.Ephemeral).To(Equal("yes"))
+			Expect(spec.Private).To(Equal("yes"))
+			Expect(spec.UUID).To(Equal(networkvolume.SecretUUID("1234", "iscsi")))
+			return mockSecret, nil
+		})
+		mockSecret.EXPECT().SetValue([]byte("chap-password"), uint32(0)).Return(nil)
+		mockSecret.EXPECT().Free().Return(nil)
+
+		Expect(manager.defineNetworkVolumeSecrets(vmi, domain)).To(Succeed())
+		Expect(domain.Spec.Devices.Disks[0].Auth).To(BeNil())
+		Expect(domain.Spec.Devices.Disks[1].Auth.Username).To(Equal("chap-user"))
+	})
+
+	It("should fail if the credentials can't be read", func() {
+		credentials = map[string]*networkvolume.Credentials{}
+
+		Expect(manager.defineNetworkVolumeSecrets(vmi, domain)).To(MatchError(ContainSubstring("no credentials for volume iscsi")))
+	})
+})
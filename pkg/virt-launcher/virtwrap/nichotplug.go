@@ -31,6 +31,7 @@ import (
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/log"
 
+	netdriver "kubevirt.io/kubevirt/pkg/network/driver"
 	virtnetlink "kubevirt.io/kubevirt/pkg/network/link"
 	netvmispec "kubevirt.io/kubevirt/pkg/network/vmispec"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
@@ -112,6 +113,82 @@ func (vim *virtIOInterfaceManager) hotUnplugVirtioInterface(vmi *v1.VirtualMachi
 	return nil
 }
 
+// podLinkState tracks the pod link serving a guest interface,
+// allowing to detect it was replaced following a change of the network it is attached to.
+type podLinkState struct {
+	index         int
+	guestLinkDown bool
+}
+
+// syncReattachedInterfaces reflects the re-attachment of a pod interface to a different network as a link flap in the guest:
+// the guest link is set down while the pod interface is detached, and set up again once it is connected back.
+func (vim *virtIOInterfaceManager) syncReattachedInterfaces(vmi *v1.VirtualMachineInstance, currentDomain *api.Domain, handler netdriver.NetworkHandler, podLinkStates map[string]podLinkState) error {
+	for _, network := range vmi.Spec.Networks {
+		if !netvmispec.IsSecondaryMultusNetwork(network) {
+			continue
+		}
+		vmiSpecIface := netvmispec.LookupInterfaceByName(vmi.Spec.Domain.Devices.Interfaces, network.Name)
+		if vmiSpecIface == nil || vmiSpecIface.Bridge == nil || vmiSpecIface.State == v1.InterfaceStateAbsent {
+			delete(podLinkStates, network.Name)
+			continue
+		}
+		domainIface := lookupDomainInterfaceByName(currentDomain.Spec.Devices.Interfaces, network.Name)
+		if domainIface == nil {
+			continue
+		}
+
+		podLink, err := virtnetlink.DiscoverByNetwork(handler, vmi.Spec.Networks, network)
+		if err != nil {
+			return err
+		}
+		state, seen := podLinkStates[network.Name]
+		switch {
+		case podLink == nil || podLink.Attrs().MasterIndex == 0:
+			if seen && !state.guestLinkDown {
+				if err := vim.setGuestLinkState(*domainIface, linkStateDown); err != nil {
+					return err
+				}
+				state.guestLinkDown = true
+				podLinkStates[network.Name] = state
+			}
+		case !seen:
+			podLinkStates[network.Name] = podLinkState{index: podLink.Attrs().Index}
+		case state.guestLinkDown || state.index != podLink.Attrs().Index:
+			if !state.guestLinkDown {
+				// The pod interface was replaced between two syncs
+				if err := vim.setGuestLinkState(*domainIface, linkStateDown); err != nil {
+					return err
+				}
+			}
+			if err := vim.setGuestLinkState(*domainIface, linkStateUp); err != nil {
+				return err
+			}
+			podLinkStates[network.Name] = podLinkState{index: podLink.Attrs().Index}
+		}
+	}
+	return nil
+}
+
+const (
+	linkStateUp   = "up"
+	linkStateDown = "down"
+)
+
+func (vim *virtIOInterfaceManager) setGuestLinkState(domainIface api.Interface, state string) error {
+	log.Log.Infof("setting the link of interface %s %s", domainIface.Alias.GetName(), state)
+
+	domainIface.LinkState = &api.LinkState{State: state}
+	ifaceXML, err := xml.Marshal(domainIface)
+	if err != nil {
+		return err
+	}
+	if err := vim.dom.UpdateDeviceFlags(string(ifaceXML), libvirt.DOMAIN_DEVICE_MODIFY_LIVE); err != nil {
+		log.Log.Reason(err).Errorf("libvirt failed to set the link of interface %s %s", domainIface.Alias.GetName(), state)
+		return err
+	}
+	return nil
+}
+
 func interfacesToHotUnplug(vmiSpecInterfaces []v1.Interface, domainSpecInterfaces []api.Interface) []api.Interface {
 	ifaces2remove := netvmispec.FilterInterfacesSpec(vmiSpecInterfaces, func(i v1.Interface) bool {
 		return i.State == v1.InterfaceStateAbsent
@@ -28,11 +28,13 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
 
 	"libvirt.org/go/libvirt"
 
 	v1 "kubevirt.io/api/core/v1"
 
+	netdriver "kubevirt.io/kubevirt/pkg/network/driver"
 	"kubevirt.io/kubevirt/pkg/network/vmispec"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
@@ -210,6 +212,82 @@ var _ = Describe("nic hot-unplug on virt-launcher", func() {
 	)
 })
 
+var _ = Describe("nic re-attachment on virt-launcher", func() {
+	const (
+		nadName     = "n1n"
+		networkName = "n1"
+	)
+
+	var (
+		mockDomain            *cli.MockVirDomain
+		mockHandler           *netdriver.MockNetworkHandler
+		networkIfaceManager   *virtIOInterfaceManager
+		podLinkStates         map[string]podLinkState
+		vmi                   *v1.VirtualMachineInstance
+		hashedPodIfaceName    string
+		expectGuestLinkChange func(state string)
+	)
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		mockDomain = cli.NewMockVirDomain(ctrl)
+		mockHandler = netdriver.NewMockNetworkHandler(ctrl)
+		networkIfaceManager = newVirtIOInterfaceManager(mockDomain, &fakeVMConfigurator{})
+		podLinkStates = map[string]podLinkState{}
+		vmi = vmiWithSingleBridgeInterfaceWithPodInterfaceReady(networkName, nadName)
+		hashedPodIfaceName = namescheme.GenerateHashedInterfaceName(networkName)
+		expectGuestLinkChange = func(state string) {
+			mockDomain.EXPECT().UpdateDeviceFlags(gomock.Any(), libvirt.DOMAIN_DEVICE_MODIFY_LIVE).
+				Do(func(ifaceXML string, _ libvirt.DomainDeviceModifyFlags) {
+					Expect(ifaceXML).To(ContainSubstring(fmt.Sprintf(`<link state="%s"></link>`, state)))
+				}).Return(nil)
+		}
+	})
+
+	syncWithPodLink := func(podLink netlink.Link) error {
+		if podLink == nil {
+			mockHandler.EXPECT().LinkByName(gomock.Any()).Return(nil, netlink.LinkNotFoundError{}).Times(2)
+		} else {
+			mockHandler.EXPECT().LinkByName(hashedPodIfaceName).Return(podLink, nil)
+		}
+		return networkIfaceManager.syncReattachedInterfaces(vmi, dummyDomain(networkName), mockHandler, podLinkStates)
+	}
+
+	attachedPodLink := func(index int) netlink.Link {
+		return &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: hashedPodIfaceName, Index: index, MasterIndex: 1}}
+	}
+
+	It("does not change the guest link when the pod interface is left untouched", func() {
+		Expect(syncWithPodLink(attachedPodLink(10))).To(Succeed())
+		Expect(syncWithPodLink(attachedPodLink(10))).To(Succeed())
+	})
+
+	It("sets the guest link down while the pod interface is detached and up once it is connected back", func() {
+		Expect(syncWithPodLink(attachedPodLink(10))).To(Succeed())
+
+		expectGuestLinkChange(linkStateDown)
+		Expect(syncWithPodLink(nil)).To(Succeed())
+		Expect(syncWithPodLink(nil)).To(Succeed())
+
+		expectGuestLinkChange(linkStateUp)
+		Expect(syncWithPodLink(attachedPodLink(11))).To(Succeed())
+	})
+
+	It("flaps the guest link when the pod interface was replaced between two syncs", func() {
+		Expect(syncWithPodLink(attachedPodLink(10))).To(Succeed())
+
+		var guestLinkStates []string
+		mockDomain.EXPECT().UpdateDeviceFlags(gomock.Any(), libvirt.DOMAIN_DEVICE_MODIFY_LIVE).
+			Do(func(ifaceXML string, _ libvirt.DomainDeviceModifyFlags) {
+				var domainIface api.Interface
+				Expect(xml.Unmarshal([]byte(ifaceXML), &domainIface)).To(Succeed())
+				guestLinkStates = append(guestLinkStates, domainIface.LinkState.State)
+			}).Return(nil).Times(2)
+		Expect(syncWithPodLink(attachedPodLink(11))).To(Succeed())
+		Expect(guestLinkStates).To(Equal([]string{linkStateDown, linkStateUp}))
+	})
+})
+
 var _ = Describe("domain network interfaces resources", func() {
 
 	DescribeTable("are ignored when",
@@ -829,6 +829,22 @@ var CRDsValidation map[string]string = map[string]string{
                     are ANDed.
                   type: object
               type: object
+            containerDiskVerification:
+              description: ContainerDiskVerification, when set, requires containerDisk
+                and kernel boot images to carry a valid cosign signature of one of
+                the given public keys before a VMI using them is started.
+              properties:
+                publicKeys:
+                  description: PublicKeys is a list of PEM encoded ECDSA, RSA or Ed25519
+                    public keys. An image is accepted if it carries a cosign signature
+                    of any of them.
+                  items:
+                    type: string
+                  type: array
+                  x-kubernetes-list-type: atomic
+              required:
+              - publicKeys
+              type: object
             controllerConfiguration:
               description: ReloadableComponentConfiguration holds all generic k8s
                 configuration options which can be reloaded by components without
@@ -5610,22 +5626,120 @@ var CRDsValidation map[string]string = map[string]string{
                                   bus:
                                     description: 'Bus indicates the type of disk device
                                       to emulate. supported values: virtio, sata,
-                                      scsi, usb.'
+                                      scsi, usb, nvme.'
                                     type: string
                                   pciAddress:
                                     description: 'If specified, the virtual disk will
                                       be placed on the guests pci address with the
-                                      specified PCI address. For example: 0000:81:01.10'
+                                      specified PCI address. For example: 0000:81:01.10
+                                      For nvme disks the address is assigned to the
+                                      disk''s NVMe controller.'
                                     type: string
                                   readonly:
                                     description: ReadOnly. Defaults to false.
                                     type: boolean
                                 type: object
+                              encryption:
+                                description: If specified, the disk image is a qemu-native
+                                  LUKS volume unlocked with the passphrase of the
+                                  referenced Secret. A blank volume is only formatted
+                                  as LUKS volume on first boot if format is set.
+                                properties:
+                                  format:
+                                    description: Format allows formatting the volume
+                                      as LUKS volume on first boot if it is blank,
+                                      i.e. its first MiB only contains zeros. Without
+                                      it, the volume has to be a LUKS volume already.
+                                    type: boolean
+                                  secretRef:
+                                    description: SecretRef references a Secret in
+                                      the namespace of the VMI which holds the LUKS
+                                      passphrase under the "passphrase" key.
+                                    properties:
+                                      name:
+                                        description: 'Name of the referent. More info:
+                                          https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                          TODO: Add other useful fields. apiVersion,
+                                          kind, uid?'
+                                        type: string
+                                    type: object
+                                required:
+                                - secretRef
+                                type: object
                               io:
                                 description: 'IO specifies which QEMU disk IO mode
                                   should be used. Supported values are: native, default,
                                   threads.'
                                 type: string
+                              ioTune:
+                                description: DiskIOTune represents the I/O throttling
+                                  applied to a disk. A value of zero, or an omitted
+                                  value, means no limit. Total limits cannot be combined
+                                  with the corresponding read or write limits.
+                                properties:
+                                  groupName:
+                                    description: Disks sharing a group name share
+                                      their limits, the limits of the last disk in
+                                      the group apply.
+                                    type: string
+                                  readBytesSec:
+                                    description: Read throughput limit in bytes per
+                                      second.
+                                    format: int64
+                                    type: integer
+                                  readBytesSecMax:
+                                    description: Read throughput allowed in bytes
+                                      per second during bursts.
+                                    format: int64
+                                    type: integer
+                                  readIOPSSec:
+                                    description: Read I/O operations per second limit.
+                                    format: int64
+                                    type: integer
+                                  readIOPSSecMax:
+                                    description: Read I/O operations per second allowed
+                                      during bursts.
+                                    format: int64
+                                    type: integer
+                                  totalBytesSec:
+                                    description: Total throughput limit in bytes per
+                                      second.
+                                    format: int64
+                                    type: integer
+                                  totalBytesSecMax:
+                                    description: Total throughput allowed in bytes
+                                      per second during bursts.
+                                    format: int64
+                                    type: integer
+                                  totalIOPSSec:
+                                    description: Total I/O operations per second limit.
+                                    format: int64
+                                    type: integer
+                                  totalIOPSSecMax:
+                                    description: Total I/O operations per second allowed
+                                      during bursts.
+                                    format: int64
+                                    type: integer
+                                  writeBytesSec:
+                                    description: Write throughput limit in bytes per
+                                      second.
+                                    format: int64
+                                    type: integer
+                                  writeBytesSecMax:
+                                    description: Write throughput allowed in bytes
+                                      per second during bursts.
+                                    format: int64
+                                    type: integer
+                                  writeIOPSSec:
+                                    description: Write I/O operations per second limit.
+                                    format: int64
+                                    type: integer
+                                  writeIOPSSecMax:
+                                    description: Write I/O operations per second allowed
+                                      during bursts.
+                                    format: int64
+                                    type: integer
+                                type: object
                               lun:
                                 description: Attach a volume as a LUN to the vmi.
                                 properties:
@@ -5648,7 +5762,9 @@ var CRDsValidation map[string]string = map[string]string{
                                 type: string
                               serial:
                                 description: Serial provides the ability to specify
-                                  a serial number for the disk device.
+                                  a serial number for the disk device. NVMe disks
+                                  are limited to 20 characters and default to a serial
+                                  derived from the disk name.
                                 type: string
                               shareable:
                                 description: If specified the disk is made sharable
@@ -5658,10 +5774,23 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: If specified, disk address and its tag
                                   will be provided to the guest via config drive metadata
                                 type: string
+                              wwn:
+                                description: WWN provides the ability to specify a
+                                  World Wide Name for the disk device, as 16 hexadecimal
+                                  digits. Only supported on the scsi, sata and nvme
+                                  buses.
+                                type: string
                             required:
                             - name
                             type: object
                           type: array
+                        filesystemHotplug:
+                          description: |-
+                            FilesystemHotplug enables the ability to hotplug virtiofs filesystems.
+                            It must be set before the VMI starts, on the VM template for filesystems persisted in the VM.
+                            The guest memory is backed by shared memory, as required by virtiofs.
+                            Defaults to false.
+                          type: boolean
                         filesystems:
                           description: Filesystems describes filesystem which is connected
                             to the vmi.
@@ -5837,6 +5966,107 @@ var CRDsValidation map[string]string = map[string]string{
                               masquerade:
                                 description: InterfaceMasquerade connects to a given
                                   network using netfilter rules to nat the traffic.
+                                properties:
+                                  firewall:
+                                    description: Firewall restricts the traffic reaching
+                                      and leaving the virtual machine through the
+                                      interface.
+                                    properties:
+                                      egress:
+                                        description: Egress policy, applied to the
+                                          traffic originating from the virtual machine.
+                                        properties:
+                                          defaultAction:
+                                            description: DefaultAction is applied
+                                              to the traffic no rule matched. Defaults
+                                              to Allow.
+                                            type: string
+                                          rules:
+                                            items:
+                                              description: FirewallRule matches traffic
+                                                by its remote peer, protocol and port.
+                                                Unset fields match any traffic.
+                                              properties:
+                                                action:
+                                                  description: Action applied to the
+                                                    matching traffic.
+                                                  type: string
+                                                ports:
+                                                  description: Ports or port ranges
+                                                    (e.g. 8000-9000) the traffic is
+                                                    destined to. Only valid along
+                                                    with the TCP, UDP or SCTP protocols.
+                                                  items:
+                                                    type: string
+                                                  type: array
+                                                  x-kubernetes-list-type: atomic
+                                                protocol:
+                                                  description: Protocol of the traffic.
+                                                    Must be TCP, UDP, SCTP or ICMP.
+                                                  type: string
+                                                remoteCIDRs:
+                                                  description: RemoteCIDRs match the
+                                                    remote peer, the source of ingress
+                                                    traffic and the destination of
+                                                    egress traffic.
+                                                  items:
+                                                    type: string
+                                                  type: array
+                                                  x-kubernetes-list-type: atomic
+                                              required:
+                                              - action
+                                              type: object
+                                            type: array
+                                            x-kubernetes-list-type: atomic
+                                        type: object
+                                      ingress:
+                                        description: Ingress policy, applied to the
+                                          traffic towards the virtual machine.
+                                        properties:
+                                          defaultAction:
+                                            description: DefaultAction is applied
+                                              to the traffic no rule matched. Defaults
+                                              to Allow.
+                                            type: string
+                                          rules:
+                                            items:
+                                              description: FirewallRule matches traffic
+                                                by its remote peer, protocol and port.
+                                                Unset fields match any traffic.
+                                              properties:
+                                                action:
+                                                  description: Action applied to the
+                                                    matching traffic.
+                                                  type: string
+                                                ports:
+                                                  description: Ports or port ranges
+                                                    (e.g. 8000-9000) the traffic is
+                                                    destined to. Only valid along
+                                                    with the TCP, UDP or SCTP protocols.
+                                                  items:
+                                                    type: string
+                                                  type: array
+                                                  x-kubernetes-list-type: atomic
+                                                protocol:
+                                                  description: Protocol of the traffic.
+                                                    Must be TCP, UDP, SCTP or ICMP.
+                                                  type: string
+                                                remoteCIDRs:
+                                                  description: RemoteCIDRs match the
+                                                    remote peer, the source of ingress
+                                                    traffic and the destination of
+                                                    egress traffic.
+                                                  items:
+                                                    type: string
+                                                  type: array
+                                                  x-kubernetes-list-type: atomic
+                                              required:
+                                              - action
+                                              type: object
+                                            type: array
+                                            x-kubernetes-list-type: atomic
+                                        type: object
+                                    type: object
                                 type: object
                               model:
                                 description: 'Interface model. One of: e1000, e1000e,
@@ -5885,6 +6115,37 @@ var CRDsValidation map[string]string = map[string]string{
                                   - port
                                   type: object
                                 type: array
+                              routerAdvertisement:
+                                description: If specified, enables and tunes the IPv6
+                                  router advertisements sent to the guest. Router
+                                  advertisements are sent on masquerade interfaces
+                                  setting it, once the pod has IPv6 connectivity,
+                                  passt interfaces accept the DNS servers only.
+                                properties:
+                                  dnsServers:
+                                    description: DNSServers are the IPv6 addresses
+                                      advertised as recursive DNS servers (RDNSS).
+                                    items:
+                                      type: string
+                                    type: array
+                                    x-kubernetes-list-type: atomic
+                                  managedAddressConfiguration:
+                                    description: ManagedAddressConfiguration sets
+                                      the managed (M) flag, telling the guest to obtain
+                                      its address through DHCPv6. Defaults to true.
+                                    type: boolean
+                                  otherConfiguration:
+                                    description: OtherConfiguration sets the other
+                                      configuration (O) flag, telling the guest to
+                                      obtain further configuration through DHCPv6.
+                                    type: boolean
+                                  prefix:
+                                    description: Prefix advertised as on-link, in
+                                      CIDR notation. Guests autoconfigure an address
+                                      (SLAAC) only from a /64 prefix. Defaults to
+                                      the IPv6 CIDR of the masquerade network.
+                                    type: string
+                                type: object
                               slirp:
                                 description: InterfaceSlirp connects to a given network
                                   using QEMU user networking mode.
@@ -5904,6 +6165,11 @@ var CRDsValidation map[string]string = map[string]string{
                                   address and its tag will be provided to the guest
                                   via config drive
                                 type: string
+                              vhostUser:
+                                description: InterfaceVhostUser connects to a given
+                                  network through a vhost-user socket served by a
+                                  userspace datapath, such as OVS-DPDK.
+                                type: object
                             required:
                             - name
                             type: object
@@ -6208,6 +6474,13 @@ var CRDsValidation map[string]string = map[string]string{
                             efi:
                               description: If set, EFI will be used instead of BIOS.
                               properties:
+                                persistent:
+                                  description: If set to true, the EFI NVRAM variables
+                                    are kept in the backend storage of the VM, so
+                                    that enrolled Secure Boot keys and boot entries
+                                    survive restarts. Requires the VMPersistentState
+                                    feature gate. Defaults to false
+                                  type: boolean
                                 secureBoot:
                                   description: If set, SecureBoot will be enabled
                                     and the OVMF roms will be swapped for SecureBoot-enabled
@@ -6369,6 +6642,22 @@ var CRDsValidation map[string]string = map[string]string{
                     VirtualMachineInstance should be migrated instead of shut-off
                     in case of a node drain.
                   type: string
+                filesystemTrim:
+                  description: FilesystemTrim periodically trims the guest filesystems
+                    through the qemu guest agent, which returns their unused blocks
+                    to thin-provisioned storage.
+                  properties:
+                    discardPreallocated:
+                      description: DiscardPreallocated passes the disks of preallocated
+                        or thick-provisioned volumes with discard=unmap as well, so
+                        that the trims reach their storage. By default these disks
+                        are passed with discard=ignore.
+                      type: boolean
+                    interval:
+                      description: Interval between two trims of the guest filesystems,
+                        it must be at least 1h. Defaults to 24h.
+                      type: string
+                  type: object
                 hostname:
                   description: Specifies the hostname of the vmi If not specified,
                     the hostname will be set to the name of the vmi, if dhcp or cloud-init
@@ -7011,6 +7300,10 @@ var CRDsValidation map[string]string = map[string]string{
                         description: 'ConfigMapSource represents a reference to a
                           ConfigMap in the same namespace. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-pod-configmap/'
                         properties:
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
                           name:
                             description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
                               TODO: Add other useful fields. apiVersion, kind, uid?'
@@ -7030,6 +7323,10 @@ var CRDsValidation map[string]string = map[string]string{
                         description: 'ContainerDisk references a docker image, embedding
                           a qcow or raw disk. More info: https://kubevirt.gitbooks.io/user-guide/registry-disk.html'
                         properties:
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
                           image:
                             description: Image is the name of the image with the embedded
                               disk.
@@ -7219,6 +7516,41 @@ var CRDsValidation map[string]string = map[string]string{
                         - path
                         - type
                         type: object
+                      iscsi:
+                        description: ISCSI represents a LUN of an iSCSI target, which
+                          qemu connects to directly.
+                        properties:
+                          chapSecretRef:
+                            description: CHAPSecretRef references a Secret in the
+                              namespace of the VMI which holds the CHAP credentials
+                              under the "node.session.auth.username" and "node.session.auth.password"
+                              keys, as the Secrets of type kubernetes.io/iscsi-chap.
+                            properties:
+                              name:
+                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
+                                type: string
+                            type: object
+                          initiatorName:
+                            description: InitiatorName is the iSCSI qualified name
+                              of the initiator. Defaults to the initiator name of
+                              qemu.
+                            type: string
+                          iqn:
+                            description: IQN is the iSCSI qualified name of the target.
+                            type: string
+                          lun:
+                            description: Lun is the number of the LUN on the target.
+                              Defaults to 0.
+                            format: int32
+                            type: integer
+                          targetPortal:
+                            description: TargetPortal is the address of the target,
+                              host or host:port. The port defaults to 3260.
+                            type: string
+                        required:
+                        - targetPortal
+                        - iqn
+                        type: object
                       memoryDump:
                         description: MemoryDump is attached to the virt launcher and
                           is populated with a memory dump of the vmi
@@ -7243,6 +7575,35 @@ var CRDsValidation map[string]string = map[string]string{
                         description: 'Volume''s name. Must be a DNS_LABEL and unique
                           within the vmi. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
                         type: string
+                      nbd:
+                        description: NBD represents an export of a network block device
+                          server, which qemu connects to directly.
+                        properties:
+                          uri:
+                            description: URI of the export, in the form nbd://host[:port][/export].
+                              The port defaults to 10809.
+                            type: string
+                        required:
+                        - uri
+                        type: object
+                      overlay:
+                        description: Overlay is a persistent copy-on-write image on
+                          a PVC, backed by a shared read-only base image on another
+                          PVC.
+                        properties:
+                          baseClaimName:
+                            description: BaseClaimName is the name of a PVC in the
+                              same namespace which holds the raw base image. It is
+                              attached read-only and can be shared by many vmis.
+                            type: string
+                          claimName:
+                            description: ClaimName is the name of a filesystem PVC
+                              in the same namespace which stores the qcow2 overlay.
+                            type: string
+                        required:
+                        - claimName
+                        - baseClaimName
+                        type: object
                       persistentVolumeClaim:
                         description: 'PersistentVolumeClaimVolumeSource represents
                           a reference to a PersistentVolumeClaim in the same namespace.
@@ -7264,10 +7625,50 @@ var CRDsValidation map[string]string = map[string]string{
                         required:
                         - claimName
                         type: object
+                      rbd:
+                        description: RBD represents an image of a Ceph cluster, which
+                          qemu connects to directly.
+                        properties:
+                          image:
+                            description: Image is the name of the image.
+                            type: string
+                          keyringSecretRef:
+                            description: KeyringSecretRef references a Secret in the
+                              namespace of the VMI which holds the cephx key of the
+                              user under the "key" key, as the Secrets of type kubernetes.io/rbd.
+                              Without it, cephx authentication is disabled.
+                            properties:
+                              name:
+                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
+                                type: string
+                            type: object
+                          monitors:
+                            description: Monitors are the addresses of the Ceph monitors,
+                              host or host:port. The port defaults to 6789.
+                            items:
+                              type: string
+                            type: array
+                            x-kubernetes-list-type: atomic
+                          pool:
+                            description: Pool is the name of the pool holding the
+                              image. Defaults to rbd.
+                            type: string
+                          user:
+                            description: User is the Ceph user to authenticate as.
+                              Defaults to admin.
+                            type: string
+                        required:
+                        - monitors
+                        - image
+                        type: object
                       secret:
                         description: 'SecretVolumeSource represents a reference to
                           a secret data in the same namespace. More info: https://kubernetes.io/docs/concepts/configuration/secret/'
                         properties:
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
                           optional:
                             description: Specify whether the Secret or it's keys must
                               be defined
@@ -7288,6 +7689,10 @@ var CRDsValidation map[string]string = map[string]string{
                           to a service account. There can only be one volume of this
                           type! More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
                         properties:
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
                           serviceAccountName:
                             description: 'Name of the service account in the pod''s
                               namespace to use. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
@@ -7507,92 +7912,348 @@ var CRDsValidation map[string]string = map[string]string{
             the VMI template and hotplug on an active running VMI.
           items:
             properties:
-              addVolumeOptions:
-                description: AddVolumeOptions when set indicates a volume should be
-                  added. The details within this field specify how to add the volume
+              addFilesystemOptions:
+                description: AddFilesystemOptions when set indicates a virtiofs
+                  filesystem should be added. The details within this field
+                  specify how to add the filesystem
                 properties:
-                  disk:
-                    description: Disk represents the hotplug disk that will be plugged
-                      into the running VMI
+                  dryRun:
+                    description: 'When present, indicates that modifications should
+                      not be persisted. An invalid or unrecognized dryRun directive
+                      will result in an error response and no further processing of
+                      the request. Valid values are: - All: all dry run stages will
+                      be processed'
+                    items:
+                      type: string
+                    type: array
+                    x-kubernetes-list-type: atomic
+                  name:
+                    description: Name represents the name that will be used to
+                      map the filesystem to the corresponding volume. It is also
+                      used as the mount tag inside the guest.
+                    type: string
+                  volumeSource:
+                    description: VolumeSource represents the source of the
+                      volume shared with the guest.
                     properties:
-                      blockSize:
-                        description: If specified, the virtual disk will be presented
-                          with the given block sizes.
-                        properties:
-                          custom:
-                            description: CustomBlockSize represents the desired logical
-                              and physical block size for a VM disk.
-                            properties:
-                              logical:
-                                type: integer
-                              physical:
-                                type: integer
-                            required:
-                            - logical
-                            - physical
-                            type: object
-                          matchVolume:
-                            description: Represents if a feature is enabled or disabled.
-                            properties:
-                              enabled:
-                                description: Enabled determines if the feature should
-                                  be enabled or disabled on the guest. Defaults to
-                                  true.
-                                type: boolean
-                            type: object
-                        type: object
-                      bootOrder:
-                        description: BootOrder is an integer value > 0, used to determine
-                          ordering of boot devices. Lower values take precedence.
-                          Each disk or interface that has a boot order must have a
-                          unique value. Disks without a boot order are not tried if
-                          a disk with a boot order exists.
-                        type: integer
-                      cache:
-                        description: 'Cache specifies which kvm disk cache mode should
-                          be used. Supported values are: CacheNone, CacheWriteThrough.'
-                        type: string
-                      cdrom:
-                        description: Attach a volume as a cdrom to the vmi.
+                      configMap:
+                        description: ConfigMapSource represents a reference to a ConfigMap
+                          in the same namespace. Hotplugged ConfigMaps are attached
+                          as read-only iso images.
                         properties:
-                          bus:
-                            description: 'Bus indicates the type of disk device to
-                              emulate. supported values: virtio, sata, scsi.'
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          name:
+                            description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
                             type: string
-                          readonly:
-                            description: ReadOnly. Defaults to true.
+                          optional:
+                            description: Specify whether the ConfigMap or it's keys
+                              must be defined
                             type: boolean
-                          tray:
-                            description: Tray indicates if the tray of the device
-                              is open or closed. Allowed values are "open" and "closed".
-                              Defaults to closed.
+                          volumeLabel:
+                            description: The volume label of the resulting disk inside
+                              the VMI. Different bootstrapping mechanisms require
+                              different values. Typical values are "cidata" (cloud-init),
+                              "config-2" (cloud-init) or "OEMDRV" (kickstart).
                             type: string
                         type: object
-                      dedicatedIOThread:
-                        description: dedicatedIOThread indicates this disk should
-                          have an exclusive IO Thread. Enabling this implies useIOThreads
-                          = true. Defaults to false.
-                        type: boolean
-                      disk:
-                        description: Attach a volume as a disk to the vmi.
+                      containerDisk:
+                        description: ContainerDisk references a docker image, embedding
+                          a qcow or raw disk. Hotplugged container disks are attached
+                          read-only.
                         properties:
-                          bus:
-                            description: 'Bus indicates the type of disk device to
-                              emulate. supported values: virtio, sata, scsi, usb.'
-                            type: string
-                          pciAddress:
-                            description: 'If specified, the virtual disk will be placed
-                              on the guests pci address with the specified PCI address.
-                              For example: 0000:81:01.10'
-                            type: string
-                          readonly:
-                            description: ReadOnly. Defaults to false.
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
                             type: boolean
-                        type: object
+                          image:
+                            description: Image is the name of the image with the embedded
+                              disk.
+                            type: string
+                          imagePullPolicy:
+                            description: 'Image pull policy. One of Always, Never,
+                              IfNotPresent. Defaults to Always if :latest tag is specified,
+                              or IfNotPresent otherwise. Cannot be updated. More info:
+                              https://kubernetes.io/docs/concepts/containers/images#updating-images'
+                            enum:
+                            - Always
+                            - IfNotPresent
+                            - Never
+                            type: string
+                          imagePullSecret:
+                            description: ImagePullSecret is the name of the Docker
+                              registry secret required to pull the image. The secret
+                              must already exist.
+                            type: string
+                          path:
+                            description: Path defines the path to disk file in the
+                              container
+                            type: string
+                        required:
+                        - image
+                        type: object
+                      dataVolume:
+                        description: DataVolume represents the dynamic creation a
+                          PVC for this volume as well as the process of populating
+                          that PVC with a disk image.
+                        properties:
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          name:
+                            description: Name of both the DataVolume and the PVC in
+                              the same namespace. After PVC population the DataVolume
+                              is garbage collected by default.
+                            type: string
+                        required:
+                        - name
+                        type: object
+                      persistentVolumeClaim:
+                        description: 'PersistentVolumeClaimVolumeSource represents
+                          a reference to a PersistentVolumeClaim in the same namespace.
+                          Directly attached to the vmi via qemu. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                        properties:
+                          claimName:
+                            description: 'claimName is the name of a PersistentVolumeClaim
+                              in the same namespace as the pod using this volume.
+                              More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                            type: string
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          readOnly:
+                            description: readOnly Will force the ReadOnly setting
+                              in VolumeMounts. Default false.
+                            type: boolean
+                        required:
+                        - claimName
+                        type: object
+                      secret:
+                        description: SecretVolumeSource represents a reference to
+                          a secret data in the same namespace. Hotplugged Secrets
+                          are attached as read-only iso images.
+                        properties:
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          optional:
+                            description: Specify whether the Secret or it's keys must
+                              be defined
+                            type: boolean
+                          secretName:
+                            description: 'Name of the secret in the pod''s namespace
+                              to use. More info: https://kubernetes.io/docs/concepts/storage/volumes#secret'
+                            type: string
+                          volumeLabel:
+                            description: The volume label of the resulting disk inside
+                              the VMI. Different bootstrapping mechanisms require
+                              different values. Typical values are "cidata" (cloud-init),
+                              "config-2" (cloud-init) or "OEMDRV" (kickstart).
+                            type: string
+                        type: object
+                      serviceAccount:
+                        description: ServiceAccountVolumeSource represents a reference
+                          to a service account. Hotplugged ServiceAccounts are attached
+                          as read-only iso images.
+                        properties:
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          serviceAccountName:
+                            description: 'Name of the service account in the pod''s
+                              namespace to use. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
+                            type: string
+                        type: object
+                    type: object
+                required:
+                - name
+                - volumeSource
+                type: object
+              addVolumeOptions:
+                description: AddVolumeOptions when set indicates a volume should be
+                  added. The details within this field specify how to add the volume
+                properties:
+                  disk:
+                    description: Disk represents the hotplug disk that will be plugged
+                      into the running VMI
+                    properties:
+                      blockSize:
+                        description: If specified, the virtual disk will be presented
+                          with the given block sizes.
+                        properties:
+                          custom:
+                            description: CustomBlockSize represents the desired logical
+                              and physical block size for a VM disk.
+                            properties:
+                              logical:
+                                type: integer
+                              physical:
+                                type: integer
+                            required:
+                            - logical
+                            - physical
+                            type: object
+                          matchVolume:
+                            description: Represents if a feature is enabled or disabled.
+                            properties:
+                              enabled:
+                                description: Enabled determines if the feature should
+                                  be enabled or disabled on the guest. Defaults to
+                                  true.
+                                type: boolean
+                            type: object
+                        type: object
+                      bootOrder:
+                        description: BootOrder is an integer value > 0, used to determine
+                          ordering of boot devices. Lower values take precedence.
+                          Each disk or interface that has a boot order must have a
+                          unique value. Disks without a boot order are not tried if
+                          a disk with a boot order exists.
+                        type: integer
+                      cache:
+                        description: 'Cache specifies which kvm disk cache mode should
+                          be used. Supported values are: CacheNone, CacheWriteThrough.'
+                        type: string
+                      cdrom:
+                        description: Attach a volume as a cdrom to the vmi.
+                        properties:
+                          bus:
+                            description: 'Bus indicates the type of disk device to
+                              emulate. supported values: virtio, sata, scsi.'
+                            type: string
+                          readonly:
+                            description: ReadOnly. Defaults to true.
+                            type: boolean
+                          tray:
+                            description: Tray indicates if the tray of the device
+                              is open or closed. Allowed values are "open" and "closed".
+                              Defaults to closed.
+                            type: string
+                        type: object
+                      dedicatedIOThread:
+                        description: dedicatedIOThread indicates this disk should
+                          have an exclusive IO Thread. Enabling this implies useIOThreads
+                          = true. Defaults to false.
+                        type: boolean
+                      disk:
+                        description: Attach a volume as a disk to the vmi.
+                        properties:
+                          bus:
+                            description: 'Bus indicates the type of disk device to
+                              emulate. supported values: virtio, sata, scsi, usb,
+                              nvme.'
+                            type: string
+                          pciAddress:
+                            description: 'If specified, the virtual disk will be placed
+                              on the guests pci address with the specified PCI address.
+                              For example: 0000:81:01.10 For nvme disks the address
+                              is assigned to the disk''s NVMe controller.'
+                            type: string
+                          readonly:
+                            description: ReadOnly. Defaults to false.
+                            type: boolean
+                        type: object
+                      encryption:
+                        description: If specified, the disk image is a qemu-native
+                          LUKS volume unlocked with the passphrase of the referenced
+                          Secret. A blank volume is only formatted as LUKS volume
+                          on first boot if format is set.
+                        properties:
+                          format:
+                            description: Format allows formatting the volume as LUKS
+                              volume on first boot if it is blank, i.e. its first
+                              MiB only contains zeros. Without it, the volume has
+                              to be a LUKS volume already.
+                            type: boolean
+                          secretRef:
+                            description: SecretRef references a Secret in the namespace
+                              of the VMI which holds the LUKS passphrase under the
+                              "passphrase" key.
+                            properties:
+                              name:
+                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                  TODO: Add other useful fields. apiVersion, kind,
+                                  uid?'
+                                type: string
+                            type: object
+                        required:
+                        - secretRef
+                        type: object
                       io:
                         description: 'IO specifies which QEMU disk IO mode should
                           be used. Supported values are: native, default, threads.'
                         type: string
+                      ioTune:
+                        description: DiskIOTune represents the I/O throttling applied
+                          to a disk. A value of zero, or an omitted value, means no
+                          limit. Total limits cannot be combined with the corresponding
+                          read or write limits.
+                        properties:
+                          groupName:
+                            description: Disks sharing a group name share their limits,
+                              the limits of the last disk in the group apply.
+                            type: string
+                          readBytesSec:
+                            description: Read throughput limit in bytes per second.
+                            format: int64
+                            type: integer
+                          readBytesSecMax:
+                            description: Read throughput allowed in bytes per second
+                              during bursts.
+                            format: int64
+                            type: integer
+                          readIOPSSec:
+                            description: Read I/O operations per second limit.
+                            format: int64
+                            type: integer
+                          readIOPSSecMax:
+                            description: Read I/O operations per second allowed during
+                              bursts.
+                            format: int64
+                            type: integer
+                          totalBytesSec:
+                            description: Total throughput limit in bytes per second.
+                            format: int64
+                            type: integer
+                          totalBytesSecMax:
+                            description: Total throughput allowed in bytes per second
+                              during bursts.
+                            format: int64
+                            type: integer
+                          totalIOPSSec:
+                            description: Total I/O operations per second limit.
+                            format: int64
+                            type: integer
+                          totalIOPSSecMax:
+                            description: Total I/O operations per second allowed during
+                              bursts.
+                            format: int64
+                            type: integer
+                          writeBytesSec:
+                            description: Write throughput limit in bytes per second.
+                            format: int64
+                            type: integer
+                          writeBytesSecMax:
+                            description: Write throughput allowed in bytes per second
+                              during bursts.
+                            format: int64
+                            type: integer
+                          writeIOPSSec:
+                            description: Write I/O operations per second limit.
+                            format: int64
+                            type: integer
+                          writeIOPSSecMax:
+                            description: Write I/O operations per second allowed during
+                              bursts.
+                            format: int64
+                            type: integer
+                        type: object
                       lun:
                         description: Attach a volume as a LUN to the vmi.
                         properties:
@@ -7613,7 +8274,9 @@ var CRDsValidation map[string]string = map[string]string{
                         type: string
                       serial:
                         description: Serial provides the ability to specify a serial
-                          number for the disk device.
+                          number for the disk device. NVMe disks are limited to 20
+                          characters and default to a serial derived from the disk
+                          name.
                         type: string
                       shareable:
                         description: If specified the disk is made sharable and multiple
@@ -7623,6 +8286,11 @@ var CRDsValidation map[string]string = map[string]string{
                         description: If specified, disk address and its tag will be
                           provided to the guest via config drive metadata
                         type: string
+                      wwn:
+                        description: WWN provides the ability to specify a World Wide
+                          Name for the disk device, as 16 hexadecimal digits. Only
+                          supported on the scsi, sata and nvme buses.
+                        type: string
                     required:
                     - name
                     type: object
@@ -7645,6 +8313,64 @@ var CRDsValidation map[string]string = map[string]string{
                     description: VolumeSource represents the source of the volume
                       to map to the disk.
                     properties:
+                      configMap:
+                        description: ConfigMapSource represents a reference to a ConfigMap
+                          in the same namespace. Hotplugged ConfigMaps are attached
+                          as read-only iso images.
+                        properties:
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          name:
+                            description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
+                            type: string
+                          optional:
+                            description: Specify whether the ConfigMap or it's keys
+                              must be defined
+                            type: boolean
+                          volumeLabel:
+                            description: The volume label of the resulting disk inside
+                              the VMI. Different bootstrapping mechanisms require
+                              different values. Typical values are "cidata" (cloud-init),
+                              "config-2" (cloud-init) or "OEMDRV" (kickstart).
+                            type: string
+                        type: object
+                      containerDisk:
+                        description: ContainerDisk references a docker image, embedding
+                          a qcow or raw disk. Hotplugged container disks are attached
+                          read-only.
+                        properties:
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          image:
+                            description: Image is the name of the image with the embedded
+                              disk.
+                            type: string
+                          imagePullPolicy:
+                            description: 'Image pull policy. One of Always, Never,
+                              IfNotPresent. Defaults to Always if :latest tag is specified,
+                              or IfNotPresent otherwise. Cannot be updated. More info:
+                              https://kubernetes.io/docs/concepts/containers/images#updating-images'
+                            enum:
+                            - Always
+                            - IfNotPresent
+                            - Never
+                            type: string
+                          imagePullSecret:
+                            description: ImagePullSecret is the name of the Docker
+                              registry secret required to pull the image. The secret
+                              must already exist.
+                            type: string
+                          path:
+                            description: Path defines the path to disk file in the
+                              container
+                            type: string
+                        required:
+                        - image
+                        type: object
                       dataVolume:
                         description: DataVolume represents the dynamic creation a
                           PVC for this volume as well as the process of populating
@@ -7683,20 +8409,264 @@ var CRDsValidation map[string]string = map[string]string{
                         required:
                         - claimName
                         type: object
-                    type: object
-                required:
-                - disk
-                - name
-                - volumeSource
-                type: object
-              removeVolumeOptions:
-                description: RemoveVolumeOptions when set indicates a volume should
-                  be removed. The details within this field specify how to add the
-                  volume
-                properties:
-                  dryRun:
-                    description: 'When present, indicates that modifications should
-                      not be persisted. An invalid or unrecognized dryRun directive
+                      secret:
+                        description: SecretVolumeSource represents a reference to
+                          a secret data in the same namespace. Hotplugged Secrets
+                          are attached as read-only iso images.
+                        properties:
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          optional:
+                            description: Specify whether the Secret or it's keys must
+                              be defined
+                            type: boolean
+                          secretName:
+                            description: 'Name of the secret in the pod''s namespace
+                              to use. More info: https://kubernetes.io/docs/concepts/storage/volumes#secret'
+                            type: string
+                          volumeLabel:
+                            description: The volume label of the resulting disk inside
+                              the VMI. Different bootstrapping mechanisms require
+                              different values. Typical values are "cidata" (cloud-init),
+                              "config-2" (cloud-init) or "OEMDRV" (kickstart).
+                            type: string
+                        type: object
+                      serviceAccount:
+                        description: ServiceAccountVolumeSource represents a reference
+                          to a service account. Hotplugged ServiceAccounts are attached
+                          as read-only iso images.
+                        properties:
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          serviceAccountName:
+                            description: 'Name of the service account in the pod''s
+                              namespace to use. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
+                            type: string
+                        type: object
+                    type: object
+                required:
+                - disk
+                - name
+                - volumeSource
+                type: object
+              ejectMediaOptions:
+                description: EjectMediaOptions when set indicates the media of a cdrom
+                  should be ejected. The details within this field specify which cdrom
+                  to eject
+                properties:
+                  dryRun:
+                    description: 'When present, indicates that modifications should
+                      not be persisted. An invalid or unrecognized dryRun directive
+                      will result in an error response and no further processing of
+                      the request. Valid values are: - All: all dry run stages will
+                      be processed'
+                    items:
+                      type: string
+                    type: array
+                    x-kubernetes-list-type: atomic
+                  name:
+                    description: Name represents the name of the cdrom disk whose
+                      media is ejected
+                    type: string
+                required:
+                - name
+                type: object
+              insertMediaOptions:
+                description: InsertMediaOptions when set indicates a media should
+                  be inserted into an empty cdrom. The details within this field specify
+                  which media to insert
+                properties:
+                  dryRun:
+                    description: 'When present, indicates that modifications should
+                      not be persisted. An invalid or unrecognized dryRun directive
+                      will result in an error response and no further processing of
+                      the request. Valid values are: - All: all dry run stages will
+                      be processed'
+                    items:
+                      type: string
+                    type: array
+                    x-kubernetes-list-type: atomic
+                  name:
+                    description: Name represents the name of the cdrom disk the media
+                      is inserted into. The volume backing the media is given the
+                      same name.
+                    type: string
+                  volumeSource:
+                    description: VolumeSource represents the source of the media.
+                    properties:
+                      configMap:
+                        description: ConfigMapSource represents a reference to a ConfigMap
+                          in the same namespace. Hotplugged ConfigMaps are attached
+                          as read-only iso images.
+                        properties:
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          name:
+                            description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
+                            type: string
+                          optional:
+                            description: Specify whether the ConfigMap or it's keys
+                              must be defined
+                            type: boolean
+                          volumeLabel:
+                            description: The volume label of the resulting disk inside
+                              the VMI. Different bootstrapping mechanisms require
+                              different values. Typical values are "cidata" (cloud-init),
+                              "config-2" (cloud-init) or "OEMDRV" (kickstart).
+                            type: string
+                        type: object
+                      containerDisk:
+                        description: ContainerDisk references a docker image, embedding
+                          a qcow or raw disk. Hotplugged container disks are attached
+                          read-only.
+                        properties:
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          image:
+                            description: Image is the name of the image with the embedded
+                              disk.
+                            type: string
+                          imagePullPolicy:
+                            description: 'Image pull policy. One of Always, Never,
+                              IfNotPresent. Defaults to Always if :latest tag is specified,
+                              or IfNotPresent otherwise. Cannot be updated. More info:
+                              https://kubernetes.io/docs/concepts/containers/images#updating-images'
+                            enum:
+                            - Always
+                            - IfNotPresent
+                            - Never
+                            type: string
+                          imagePullSecret:
+                            description: ImagePullSecret is the name of the Docker
+                              registry secret required to pull the image. The secret
+                              must already exist.
+                            type: string
+                          path:
+                            description: Path defines the path to disk file in the
+                              container
+                            type: string
+                        required:
+                        - image
+                        type: object
+                      dataVolume:
+                        description: DataVolume represents the dynamic creation a
+                          PVC for this volume as well as the process of populating
+                          that PVC with a disk image.
+                        properties:
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          name:
+                            description: Name of both the DataVolume and the PVC in
+                              the same namespace. After PVC population the DataVolume
+                              is garbage collected by default.
+                            type: string
+                        required:
+                        - name
+                        type: object
+                      persistentVolumeClaim:
+                        description: 'PersistentVolumeClaimVolumeSource represents
+                          a reference to a PersistentVolumeClaim in the same namespace.
+                          Directly attached to the vmi via qemu. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                        properties:
+                          claimName:
+                            description: 'claimName is the name of a PersistentVolumeClaim
+                              in the same namespace as the pod using this volume.
+                              More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                            type: string
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          readOnly:
+                            description: readOnly Will force the ReadOnly setting
+                              in VolumeMounts. Default false.
+                            type: boolean
+                        required:
+                        - claimName
+                        type: object
+                      secret:
+                        description: SecretVolumeSource represents a reference to
+                          a secret data in the same namespace. Hotplugged Secrets
+                          are attached as read-only iso images.
+                        properties:
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          optional:
+                            description: Specify whether the Secret or it's keys must
+                              be defined
+                            type: boolean
+                          secretName:
+                            description: 'Name of the secret in the pod''s namespace
+                              to use. More info: https://kubernetes.io/docs/concepts/storage/volumes#secret'
+                            type: string
+                          volumeLabel:
+                            description: The volume label of the resulting disk inside
+                              the VMI. Different bootstrapping mechanisms require
+                              different values. Typical values are "cidata" (cloud-init),
+                              "config-2" (cloud-init) or "OEMDRV" (kickstart).
+                            type: string
+                        type: object
+                      serviceAccount:
+                        description: ServiceAccountVolumeSource represents a reference
+                          to a service account. Hotplugged ServiceAccounts are attached
+                          as read-only iso images.
+                        properties:
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          serviceAccountName:
+                            description: 'Name of the service account in the pod''s
+                              namespace to use. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
+                            type: string
+                        type: object
+                    type: object
+                required:
+                - name
+                - volumeSource
+                type: object
+              removeFilesystemOptions:
+                description: RemoveFilesystemOptions when set indicates a
+                  virtiofs filesystem should be removed. The details within this
+                  field specify which filesystem to remove
+                properties:
+                  dryRun:
+                    description: 'When present, indicates that modifications should
+                      not be persisted. An invalid or unrecognized dryRun directive
+                      will result in an error response and no further processing of
+                      the request. Valid values are: - All: all dry run stages will
+                      be processed'
+                    items:
+                      type: string
+                    type: array
+                    x-kubernetes-list-type: atomic
+                  name:
+                    description: Name represents the name that maps to both the
+                      filesystem and volume that should be removed
+                    type: string
+                required:
+                - name
+                type: object
+              removeVolumeOptions:
+                description: RemoveVolumeOptions when set indicates a volume should
+                  be removed. The details within this field specify how to add the
+                  volume
+                properties:
+                  dryRun:
+                    description: 'When present, indicates that modifications should
+                      not be persisted. An invalid or unrecognized dryRun directive
                       will result in an error response and no further processing of
                       the request. Valid values are: - All: all dry run stages will
                       be processed'
@@ -8310,48 +9280,138 @@ var CRDsValidation map[string]string = map[string]string{
             preferredInterfaceMasquerade:
               description: PreferredInterfaceMasquerade optionally defines the preferred
                 masquerade configuration to use with each network interface.
-              type: object
-            preferredInterfaceModel:
-              description: PreferredInterfaceModel optionally defines the preferred
-                model to be used by Interface devices.
-              type: string
-            preferredLunBus:
-              description: PreferredLunBus optionally defines the preferred bus for
-                Lun Disk devices.
-              type: string
-            preferredNetworkInterfaceMultiQueue:
-              description: PreferredNetworkInterfaceMultiQueue optionally enables
-                the vhost multiqueue feature for virtio interfaces.
-              type: boolean
-            preferredRng:
-              description: PreferredRng optionally defines the preferred rng device
-                to be used.
-              type: object
-            preferredSoundModel:
-              description: PreferredSoundModel optionally defines the preferred model
-                for Sound devices.
-              type: string
-            preferredTPM:
-              description: PreferredTPM optionally defines the preferred TPM device
-                to be used.
               properties:
-                persistent:
-                  description: Persistent indicates the state of the TPM device should
-                    be kept accross reboots Defaults to false
-                  type: boolean
-              type: object
-            preferredUseVirtioTransitional:
-              description: PreferredUseVirtioTransitional optionally defines the preferred
-                value of UseVirtioTransitional
-              type: boolean
-            preferredVirtualGPUOptions:
-              description: PreferredVirtualGPUOptions optionally defines the preferred
-                value of VirtualGPUOptions
-              properties:
-                display:
+                firewall:
+                  description: Firewall restricts the traffic reaching and leaving
+                    the virtual machine through the interface.
                   properties:
-                    enabled:
-                      description: Enabled determines if a display addapter backed
+                    egress:
+                      description: Egress policy, applied to the traffic originating
+                        from the virtual machine.
+                      properties:
+                        defaultAction:
+                          description: DefaultAction is applied to the traffic no
+                            rule matched. Defaults to Allow.
+                          type: string
+                        rules:
+                          items:
+                            description: FirewallRule matches traffic by its remote
+                              peer, protocol and port. Unset fields match any traffic.
+                            properties:
+                              action:
+                                description: Action applied to the matching traffic.
+                                type: string
+                              ports:
+                                description: Ports or port ranges (e.g. 8000-9000)
+                                  the traffic is destined to. Only valid along with
+                                  the TCP, UDP or SCTP protocols.
+                                items:
+                                  type: string
+                                type: array
+                                x-kubernetes-list-type: atomic
+                              protocol:
+                                description: Protocol of the traffic. Must be TCP,
+                                  UDP, SCTP or ICMP.
+                                type: string
+                              remoteCIDRs:
+                                description: RemoteCIDRs match the remote peer, the
+                                  source of ingress traffic and the destination of
+                                  egress traffic.
+                                items:
+                                  type: string
+                                type: array
+                                x-kubernetes-list-type: atomic
+                            required:
+                            - action
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
+                      type: object
+                    ingress:
+                      description: Ingress policy, applied to the traffic towards
+                        the virtual machine.
+                      properties:
+                        defaultAction:
+                          description: DefaultAction is applied to the traffic no
+                            rule matched. Defaults to Allow.
+                          type: string
+                        rules:
+                          items:
+                            description: FirewallRule matches traffic by its remote
+                              peer, protocol and port. Unset fields match any traffic.
+                            properties:
+                              action:
+                                description: Action applied to the matching traffic.
+                                type: string
+                              ports:
+                                description: Ports or port ranges (e.g. 8000-9000)
+                                  the traffic is destined to. Only valid along with
+                                  the TCP, UDP or SCTP protocols.
+                                items:
+                                  type: string
+                                type: array
+                                x-kubernetes-list-type: atomic
+                              protocol:
+                                description: Protocol of the traffic. Must be TCP,
+                                  UDP, SCTP or ICMP.
+                                type: string
+                              remoteCIDRs:
+                                description: RemoteCIDRs match the remote peer, the
+                                  source of ingress traffic and the destination of
+                                  egress traffic.
+                                items:
+                                  type: string
+                                type: array
+                                x-kubernetes-list-type: atomic
+                            required:
+                            - action
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
+                      type: object
+                  type: object
+              type: object
+            preferredInterfaceModel:
+              description: PreferredInterfaceModel optionally defines the preferred
+                model to be used by Interface devices.
+              type: string
+            preferredLunBus:
+              description: PreferredLunBus optionally defines the preferred bus for
+                Lun Disk devices.
+              type: string
+            preferredNetworkInterfaceMultiQueue:
+              description: PreferredNetworkInterfaceMultiQueue optionally enables
+                the vhost multiqueue feature for virtio interfaces.
+              type: boolean
+            preferredRng:
+              description: PreferredRng optionally defines the preferred rng device
+                to be used.
+              type: object
+            preferredSoundModel:
+              description: PreferredSoundModel optionally defines the preferred model
+                for Sound devices.
+              type: string
+            preferredTPM:
+              description: PreferredTPM optionally defines the preferred TPM device
+                to be used.
+              properties:
+                persistent:
+                  description: Persistent indicates the state of the TPM device should
+                    be kept accross reboots Defaults to false
+                  type: boolean
+              type: object
+            preferredUseVirtioTransitional:
+              description: PreferredUseVirtioTransitional optionally defines the preferred
+                value of UseVirtioTransitional
+              type: boolean
+            preferredVirtualGPUOptions:
+              description: PreferredVirtualGPUOptions optionally defines the preferred
+                value of VirtualGPUOptions
+              properties:
+                display:
+                  properties:
+                    enabled:
+                      description: Enabled determines if a display addapter backed
                         by a vGPU should be enabled or disabled on the guest. Defaults
                         to true.
                       type: boolean
@@ -8661,6 +9721,50 @@ var CRDsValidation map[string]string = map[string]string{
       description: VirtualMachineExportSpec is the spec for a VirtualMachineExport
         resource
       properties:
+        destination:
+          description: Destination, if set, makes the export server push the exported
+            volumes and manifests to the given location, in addition to serving them
+            for download
+          properties:
+            format:
+              description: Format is the format the volumes are pushed in, either
+                raw or gzip. Volumes which do not contain a disk image are always
+                pushed as tar.gz archive. Defaults to gzip
+              type: string
+            s3:
+              description: S3 pushes the export to a bucket of an S3 compatible object
+                storage
+              properties:
+                bucket:
+                  description: Bucket is the name of the bucket the objects are stored
+                    in
+                  type: string
+                credentialsSecretRef:
+                  description: CredentialsSecretRef is the name of the secret holding
+                    the accessKeyId and the secretKey used to access the bucket, the
+                    same keys as used by CDI for S3 sources
+                  type: string
+                endpoint:
+                  description: Endpoint is the URL of the S3 API, e.g. https://s3.us-east-1.amazonaws.com
+                  type: string
+                insecureSkipTLSVerify:
+                  description: InsecureSkipTLSVerify disables the verification of
+                    the certificate of the endpoint
+                  type: boolean
+                prefix:
+                  description: Prefix is prepended to the keys of all pushed objects.
+                    Defaults to <namespace>/<export name>
+                  type: string
+                region:
+                  description: Region is the region used to sign the requests. Defaults
+                    to us-east-1
+                  type: string
+              required:
+              - endpoint
+              - bucket
+              - credentialsSecretRef
+              type: object
+          type: object
         source:
           description: TypedLocalObjectReference contains enough information to let
             you locate the typed referenced object inside the same namespace.
@@ -8772,6 +9876,12 @@ var CRDsValidation map[string]string = map[string]string{
                               description: Format is the format of the image at the
                                 specified URL
                               type: string
+                            sha256:
+                              description: Sha256 is the hex encoded sha256 checksum
+                                of the volume in the format specified, once calculated
+                                by the export server. It is only available for the
+                                raw format.
+                              type: string
                             url:
                               description: Url is the url that contains the volume
                                 in the format specified
@@ -8841,6 +9951,12 @@ var CRDsValidation map[string]string = map[string]string{
                               description: Format is the format of the image at the
                                 specified URL
                               type: string
+                            sha256:
+                              description: Sha256 is the hex encoded sha256 checksum
+                                of the volume in the format specified, once calculated
+                                by the export server. It is only available for the
+                                raw format.
+                              type: string
                             url:
                               description: Url is the url that contains the volume
                                 in the format specified
@@ -8870,6 +9986,82 @@ var CRDsValidation map[string]string = map[string]string{
         phase:
           description: VirtualMachineExportPhase is the current phase of the VirtualMachineExport
           type: string
+        push:
+          description: Push shows the progress of pushing the export to its destination
+          properties:
+            completionTime:
+              format: date-time
+              type: string
+            manifests:
+              description: Manifests is the list of pushed manifests
+              items:
+                description: VirtualMachineExportPushedObject shows the progress of
+                  pushing a single object
+                properties:
+                  bytesPushed:
+                    description: BytesPushed is the number of bytes pushed so far
+                    format: int64
+                    type: integer
+                  format:
+                    type: string
+                  message:
+                    type: string
+                  name:
+                    description: Name is the name of the volume or manifest
+                    type: string
+                  phase:
+                    type: string
+                  sha256:
+                    description: Sha256 is the hex encoded sha256 checksum of the
+                      object, set once it is pushed
+                    type: string
+                  url:
+                    description: URL is the url of the object in the destination
+                    type: string
+                required:
+                - name
+                type: object
+              type: array
+              x-kubernetes-list-type: map
+            message:
+              type: string
+            phase:
+              type: string
+            startTime:
+              format: date-time
+              type: string
+            volumes:
+              description: Volumes is the list of pushed volumes
+              items:
+                description: VirtualMachineExportPushedObject shows the progress of
+                  pushing a single object
+                properties:
+                  bytesPushed:
+                    description: BytesPushed is the number of bytes pushed so far
+                    format: int64
+                    type: integer
+                  format:
+                    type: string
+                  message:
+                    type: string
+                  name:
+                    description: Name is the name of the volume or manifest
+                    type: string
+                  phase:
+                    type: string
+                  sha256:
+                    description: Sha256 is the hex encoded sha256 checksum of the
+                      object, set once it is pushed
+                    type: string
+                  url:
+                    description: URL is the url of the object in the destination
+                    type: string
+                required:
+                - name
+                type: object
+              type: array
+              x-kubernetes-list-type: map
+          type: object
         serviceName:
           description: ServiceName is the name of the service created associated with
             the Virtual Machine export. It will be used to create the internal URLs
@@ -10123,21 +11315,114 @@ var CRDsValidation map[string]string = map[string]string{
                         properties:
                           bus:
                             description: 'Bus indicates the type of disk device to
-                              emulate. supported values: virtio, sata, scsi, usb.'
+                              emulate. supported values: virtio, sata, scsi, usb,
+                              nvme.'
                             type: string
                           pciAddress:
                             description: 'If specified, the virtual disk will be placed
                               on the guests pci address with the specified PCI address.
-                              For example: 0000:81:01.10'
+                              For example: 0000:81:01.10 For nvme disks the address
+                              is assigned to the disk''s NVMe controller.'
                             type: string
                           readonly:
                             description: ReadOnly. Defaults to false.
                             type: boolean
                         type: object
+                      encryption:
+                        description: If specified, the disk image is a qemu-native
+                          LUKS volume unlocked with the passphrase of the referenced
+                          Secret. A blank volume is only formatted as LUKS volume
+                          on first boot if format is set.
+                        properties:
+                          format:
+                            description: Format allows formatting the volume as LUKS
+                              volume on first boot if it is blank, i.e. its first
+                              MiB only contains zeros. Without it, the volume has
+                              to be a LUKS volume already.
+                            type: boolean
+                          secretRef:
+                            description: SecretRef references a Secret in the namespace
+                              of the VMI which holds the LUKS passphrase under the
+                              "passphrase" key.
+                            properties:
+                              name:
+                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                  TODO: Add other useful fields. apiVersion, kind,
+                                  uid?'
+                                type: string
+                            type: object
+                        required:
+                        - secretRef
+                        type: object
                       io:
                         description: 'IO specifies which QEMU disk IO mode should
                           be used. Supported values are: native, default, threads.'
                         type: string
+                      ioTune:
+                        description: DiskIOTune represents the I/O throttling applied
+                          to a disk. A value of zero, or an omitted value, means no
+                          limit. Total limits cannot be combined with the corresponding
+                          read or write limits.
+                        properties:
+                          groupName:
+                            description: Disks sharing a group name share their limits,
+                              the limits of the last disk in the group apply.
+                            type: string
+                          readBytesSec:
+                            description: Read throughput limit in bytes per second.
+                            format: int64
+                            type: integer
+                          readBytesSecMax:
+                            description: Read throughput allowed in bytes per second
+                              during bursts.
+                            format: int64
+                            type: integer
+                          readIOPSSec:
+                            description: Read I/O operations per second limit.
+                            format: int64
+                            type: integer
+                          readIOPSSecMax:
+                            description: Read I/O operations per second allowed during
+                              bursts.
+                            format: int64
+                            type: integer
+                          totalBytesSec:
+                            description: Total throughput limit in bytes per second.
+                            format: int64
+                            type: integer
+                          totalBytesSecMax:
+                            description: Total throughput allowed in bytes per second
+                              during bursts.
+                            format: int64
+                            type: integer
+                          totalIOPSSec:
+                            description: Total I/O operations per second limit.
+                            format: int64
+                            type: integer
+                          totalIOPSSecMax:
+                            description: Total I/O operations per second allowed during
+                              bursts.
+                            format: int64
+                            type: integer
+                          writeBytesSec:
+                            description: Write throughput limit in bytes per second.
+                            format: int64
+                            type: integer
+                          writeBytesSecMax:
+                            description: Write throughput allowed in bytes per second
+                              during bursts.
+                            format: int64
+                            type: integer
+                          writeIOPSSec:
+                            description: Write I/O operations per second limit.
+                            format: int64
+                            type: integer
+                          writeIOPSSecMax:
+                            description: Write I/O operations per second allowed during
+                              bursts.
+                            format: int64
+                            type: integer
+                        type: object
                       lun:
                         description: Attach a volume as a LUN to the vmi.
                         properties:
@@ -10158,7 +11443,9 @@ var CRDsValidation map[string]string = map[string]string{
                         type: string
                       serial:
                         description: Serial provides the ability to specify a serial
-                          number for the disk device.
+                          number for the disk device. NVMe disks are limited to 20
+                          characters and default to a serial derived from the disk
+                          name.
                         type: string
                       shareable:
                         description: If specified the disk is made sharable and multiple
@@ -10168,10 +11455,22 @@ var CRDsValidation map[string]string = map[string]string{
                         description: If specified, disk address and its tag will be
                           provided to the guest via config drive metadata
                         type: string
+                      wwn:
+                        description: WWN provides the ability to specify a World Wide
+                          Name for the disk device, as 16 hexadecimal digits. Only
+                          supported on the scsi, sata and nvme buses.
+                        type: string
                     required:
                     - name
                     type: object
                   type: array
+                filesystemHotplug:
+                  description: |-
+                    FilesystemHotplug enables the ability to hotplug virtiofs filesystems.
+                    It must be set before the VMI starts, on the VM template for filesystems persisted in the VM.
+                    The guest memory is backed by shared memory, as required by virtiofs.
+                    Defaults to false.
+                  type: boolean
                 filesystems:
                   description: Filesystems describes filesystem which is connected
                     to the vmi.
@@ -10342,6 +11641,102 @@ var CRDsValidation map[string]string = map[string]string{
                       masquerade:
                         description: InterfaceMasquerade connects to a given network
                           using netfilter rules to nat the traffic.
+                        properties:
+                          firewall:
+                            description: Firewall restricts the traffic reaching and
+                              leaving the virtual machine through the interface.
+                            properties:
+                              egress:
+                                description: Egress policy, applied to the traffic
+                                  originating from the virtual machine.
+                                properties:
+                                  defaultAction:
+                                    description: DefaultAction is applied to the traffic
+                                      no rule matched. Defaults to Allow.
+                                    type: string
+                                  rules:
+                                    items:
+                                      description: FirewallRule matches traffic by
+                                        its remote peer, protocol and port. Unset
+                                        fields match any traffic.
+                                      properties:
+                                        action:
+                                          description: Action applied to the matching
+                                            traffic.
+                                          type: string
+                                        ports:
+                                          description: Ports or port ranges (e.g.
+                                            8000-9000) the traffic is destined to.
+                                            Only valid along with the TCP, UDP or
+                                            SCTP protocols.
+                                          items:
+                                            type: string
+                                          type: array
+                                          x-kubernetes-list-type: atomic
+                                        protocol:
+                                          description: Protocol of the traffic. Must
+                                            be TCP, UDP, SCTP or ICMP.
+                                          type: string
+                                        remoteCIDRs:
+                                          description: RemoteCIDRs match the remote
+                                            peer, the source of ingress traffic and
+                                            the destination of egress traffic.
+                                          items:
+                                            type: string
+                                          type: array
+                                          x-kubernetes-list-type: atomic
+                                      required:
+                                      - action
+                                      type: object
+                                    type: array
+                                    x-kubernetes-list-type: atomic
+                                type: object
+                              ingress:
+                                description: Ingress policy, applied to the traffic
+                                  towards the virtual machine.
+                                properties:
+                                  defaultAction:
+                                    description: DefaultAction is applied to the traffic
+                                      no rule matched. Defaults to Allow.
+                                    type: string
+                                  rules:
+                                    items:
+                                      description: FirewallRule matches traffic by
+                                        its remote peer, protocol and port. Unset
+                                        fields match any traffic.
+                                      properties:
+                                        action:
+                                          description: Action applied to the matching
+                                            traffic.
+                                          type: string
+                                        ports:
+                                          description: Ports or port ranges (e.g.
+                                            8000-9000) the traffic is destined to.
+                                            Only valid along with the TCP, UDP or
+                                            SCTP protocols.
+                                          items:
+                                            type: string
+                                          type: array
+                                          x-kubernetes-list-type: atomic
+                                        protocol:
+                                          description: Protocol of the traffic. Must
+                                            be TCP, UDP, SCTP or ICMP.
+                                          type: string
+                                        remoteCIDRs:
+                                          description: RemoteCIDRs match the remote
+                                            peer, the source of ingress traffic and
+                                            the destination of egress traffic.
+                                          items:
+                                            type: string
+                                          type: array
+                                          x-kubernetes-list-type: atomic
+                                      required:
+                                      - action
+                                      type: object
+                                    type: array
+                                    x-kubernetes-list-type: atomic
+                                type: object
+                            type: object
                         type: object
                       model:
                         description: 'Interface model. One of: e1000, e1000e, ne2k_pci,
@@ -10387,6 +11782,37 @@ var CRDsValidation map[string]string = map[string]string{
                           - port
                           type: object
                         type: array
+                      routerAdvertisement:
+                        description: If specified, enables and tunes the IPv6 router
+                          advertisements sent to the guest. Router advertisements
+                          are sent on masquerade interfaces setting it, once the pod
+                          has IPv6 connectivity, passt interfaces accept the DNS servers
+                          only.
+                        properties:
+                          dnsServers:
+                            description: DNSServers are the IPv6 addresses advertised
+                              as recursive DNS servers (RDNSS).
+                            items:
+                              type: string
+                            type: array
+                            x-kubernetes-list-type: atomic
+                          managedAddressConfiguration:
+                            description: ManagedAddressConfiguration sets the managed
+                              (M) flag, telling the guest to obtain its address through
+                              DHCPv6. Defaults to true.
+                            type: boolean
+                          otherConfiguration:
+                            description: OtherConfiguration sets the other configuration
+                              (O) flag, telling the guest to obtain further configuration
+                              through DHCPv6.
+                            type: boolean
+                          prefix:
+                            description: Prefix advertised as on-link, in CIDR notation.
+                              Guests autoconfigure an address (SLAAC) only from a
+                              /64 prefix. Defaults to the IPv6 CIDR of the masquerade
+                              network.
+                            type: string
+                        type: object
                       slirp:
                         description: InterfaceSlirp connects to a given network using
                           QEMU user networking mode.
@@ -10404,6 +11830,11 @@ var CRDsValidation map[string]string = map[string]string{
                         description: If specified, the virtual network interface address
                           and its tag will be provided to the guest via config drive
                         type: string
+                      vhostUser:
+                        description: InterfaceVhostUser connects to a given network
+                          through a vhost-user socket served by a userspace datapath,
+                          such as OVS-DPDK.
+                        type: object
                     required:
                     - name
                     type: object
@@ -10682,6 +12113,12 @@ var CRDsValidation map[string]string = map[string]string{
                     efi:
                       description: If set, EFI will be used instead of BIOS.
                       properties:
+                        persistent:
+                          description: If set to true, the EFI NVRAM variables are
+                            kept in the backend storage of the VM, so that enrolled
+                            Secure Boot keys and boot entries survive restarts. Requires
+                            the VMPersistentState feature gate. Defaults to false
+                          type: boolean
                         secureBoot:
                           description: If set, SecureBoot will be enabled and the
                             OVMF roms will be swapped for SecureBoot-enabled ones.
@@ -10832,6 +12269,22 @@ var CRDsValidation map[string]string = map[string]string{
           description: EvictionStrategy can be set to "LiveMigrate" if the VirtualMachineInstance
             should be migrated instead of shut-off in case of a node drain.
           type: string
+        filesystemTrim:
+          description: FilesystemTrim periodically trims the guest filesystems through
+            the qemu guest agent, which returns their unused blocks to thin-provisioned
+            storage.
+          properties:
+            discardPreallocated:
+              description: DiscardPreallocated passes the disks of preallocated or
+                thick-provisioned volumes with discard=unmap as well, so that the
+                trims reach their storage. By default these disks are passed with
+                discard=ignore.
+              type: boolean
+            interval:
+              description: Interval between two trims of the guest filesystems, it
+                must be at least 1h. Defaults to 24h.
+              type: string
+          type: object
         hostname:
           description: Specifies the hostname of the vmi If not specified, the hostname
             will be set to the name of the vmi, if dhcp or cloud-init is configured
@@ -11438,6 +12891,10 @@ var CRDsValidation map[string]string = map[string]string{
                 description: 'ConfigMapSource represents a reference to a ConfigMap
                   in the same namespace. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-pod-configmap/'
                 properties:
+                  hotpluggable:
+                    description: Hotpluggable indicates whether the volume can be
+                      hotplugged and hotunplugged.
+                    type: boolean
                   name:
                     description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
                       TODO: Add other useful fields. apiVersion, kind, uid?'
@@ -11457,6 +12914,10 @@ var CRDsValidation map[string]string = map[string]string{
                 description: 'ContainerDisk references a docker image, embedding a
                   qcow or raw disk. More info: https://kubevirt.gitbooks.io/user-guide/registry-disk.html'
                 properties:
+                  hotpluggable:
+                    description: Hotpluggable indicates whether the volume can be
+                      hotplugged and hotunplugged.
+                    type: boolean
                   image:
                     description: Image is the name of the image with the embedded
                       disk.
@@ -11636,6 +13097,40 @@ var CRDsValidation map[string]string = map[string]string{
                 - path
                 - type
                 type: object
+              iscsi:
+                description: ISCSI represents a LUN of an iSCSI target, which qemu
+                  connects to directly.
+                properties:
+                  chapSecretRef:
+                    description: CHAPSecretRef references a Secret in the namespace
+                      of the VMI which holds the CHAP credentials under the "node.session.auth.username"
+                      and "node.session.auth.password" keys, as the Secrets of type
+                      kubernetes.io/iscsi-chap.
+                    properties:
+                      name:
+                        description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
+                        type: string
+                    type: object
+                  initiatorName:
+                    description: InitiatorName is the iSCSI qualified name of the
+                      initiator. Defaults to the initiator name of qemu.
+                    type: string
+                  iqn:
+                    description: IQN is the iSCSI qualified name of the target.
+                    type: string
+                  lun:
+                    description: Lun is the number of the LUN on the target. Defaults
+                      to 0.
+                    format: int32
+                    type: integer
+                  targetPortal:
+                    description: TargetPortal is the address of the target, host or
+                      host:port. The port defaults to 3260.
+                    type: string
+                required:
+                - targetPortal
+                - iqn
+                type: object
               memoryDump:
                 description: MemoryDump is attached to the virt launcher and is populated
                   with a memory dump of the vmi
@@ -11660,6 +13155,34 @@ var CRDsValidation map[string]string = map[string]string{
                 description: 'Volume''s name. Must be a DNS_LABEL and unique within
                   the vmi. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
                 type: string
+              nbd:
+                description: NBD represents an export of a network block device server,
+                  which qemu connects to directly.
+                properties:
+                  uri:
+                    description: URI of the export, in the form nbd://host[:port][/export].
+                      The port defaults to 10809.
+                    type: string
+                required:
+                - uri
+                type: object
+              overlay:
+                description: Overlay is a persistent copy-on-write image on a PVC,
+                  backed by a shared read-only base image on another PVC.
+                properties:
+                  baseClaimName:
+                    description: BaseClaimName is the name of a PVC in the same namespace
+                      which holds the raw base image. It is attached read-only and
+                      can be shared by many vmis.
+                    type: string
+                  claimName:
+                    description: ClaimName is the name of a filesystem PVC in the
+                      same namespace which stores the qcow2 overlay.
+                    type: string
+                required:
+                - claimName
+                - baseClaimName
+                type: object
               persistentVolumeClaim:
                 description: 'PersistentVolumeClaimVolumeSource represents a reference
                   to a PersistentVolumeClaim in the same namespace. Directly attached
@@ -11681,13 +13204,53 @@ var CRDsValidation map[string]string = map[string]string{
                 required:
                 - claimName
                 type: object
+              rbd:
+                description: RBD represents an image of a Ceph cluster, which qemu
+                  connects to directly.
+                properties:
+                  image:
+                    description: Image is the name of the image.
+                    type: string
+                  keyringSecretRef:
+                    description: KeyringSecretRef references a Secret in the namespace
+                      of the VMI which holds the cephx key of the user under the "key"
+                      key, as the Secrets of type kubernetes.io/rbd. Without it, cephx
+                      authentication is disabled.
+                    properties:
+                      name:
+                        description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
+                        type: string
+                    type: object
+                  monitors:
+                    description: Monitors are the addresses of the Ceph monitors,
+                      host or host:port. The port defaults to 6789.
+                    items:
+                      type: string
+                    type: array
+                    x-kubernetes-list-type: atomic
+                  pool:
+                    description: Pool is the name of the pool holding the image. Defaults
+                      to rbd.
+                    type: string
+                  user:
+                    description: User is the Ceph user to authenticate as. Defaults
+                      to admin.
+                    type: string
+                required:
+                - monitors
+                - image
+                type: object
               secret:
                 description: 'SecretVolumeSource represents a reference to a secret
                   data in the same namespace. More info: https://kubernetes.io/docs/concepts/configuration/secret/'
                 properties:
-                  optional:
-                    description: Specify whether the Secret or it's keys must be defined
-                    type: boolean
+                  hotpluggable:
+                    description: Hotpluggable indicates whether the volume can be
+                      hotplugged and hotunplugged.
+                    type: boolean
+                  optional:
+                    description: Specify whether the Secret or it's keys must be defined
+                    type: boolean
                   secretName:
                     description: 'Name of the secret in the pod''s namespace to use.
                       More info: https://kubernetes.io/docs/concepts/storage/volumes#secret'
@@ -11704,6 +13267,10 @@ var CRDsValidation map[string]string = map[string]string{
                   a service account. There can only be one volume of this type! More
                   info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
                 properties:
+                  hotpluggable:
+                    description: Hotpluggable indicates whether the volume can be
+                      hotplugged and hotunplugged.
+                    type: boolean
                   serviceAccountName:
                     description: 'Name of the service account in the pod''s namespace
                       to use. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
@@ -11859,6 +13426,24 @@ var CRDsValidation map[string]string = map[string]string{
                 items:
                   type: string
                 type: array
+              ipSources:
+                description: Specifies the origin of each of the IP addresses, when
+                  they were learned without the guest agent.
+                items:
+                  properties:
+                    ip:
+                      description: IP address of a Virtual Machine interface
+                      type: string
+                    source:
+                      description: 'Specifies the origin of the IP address. values:
+                        pod-interface, dhcp-lease, neighbor-snooping.'
+                      type: string
+                  required:
+                  - ip
+                  - source
+                  type: object
+                type: array
+                x-kubernetes-list-type: atomic
               mac:
                 description: Hardware address of a Virtual Machine interface
                 type: string
@@ -12784,21 +14369,114 @@ var CRDsValidation map[string]string = map[string]string{
                         properties:
                           bus:
                             description: 'Bus indicates the type of disk device to
-                              emulate. supported values: virtio, sata, scsi, usb.'
+                              emulate. supported values: virtio, sata, scsi, usb,
+                              nvme.'
                             type: string
                           pciAddress:
                             description: 'If specified, the virtual disk will be placed
                               on the guests pci address with the specified PCI address.
-                              For example: 0000:81:01.10'
+                              For example: 0000:81:01.10 For nvme disks the address
+                              is assigned to the disk''s NVMe controller.'
                             type: string
                           readonly:
                             description: ReadOnly. Defaults to false.
                             type: boolean
                         type: object
+                      encryption:
+                        description: If specified, the disk image is a qemu-native
+                          LUKS volume unlocked with the passphrase of the referenced
+                          Secret. A blank volume is only formatted as LUKS volume
+                          on first boot if format is set.
+                        properties:
+                          format:
+                            description: Format allows formatting the volume as LUKS
+                              volume on first boot if it is blank, i.e. its first
+                              MiB only contains zeros. Without it, the volume has
+                              to be a LUKS volume already.
+                            type: boolean
+                          secretRef:
+                            description: SecretRef references a Secret in the namespace
+                              of the VMI which holds the LUKS passphrase under the
+                              "passphrase" key.
+                            properties:
+                              name:
+                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                  TODO: Add other useful fields. apiVersion, kind,
+                                  uid?'
+                                type: string
+                            type: object
+                        required:
+                        - secretRef
+                        type: object
                       io:
                         description: 'IO specifies which QEMU disk IO mode should
                           be used. Supported values are: native, default, threads.'
                         type: string
+                      ioTune:
+                        description: DiskIOTune represents the I/O throttling applied
+                          to a disk. A value of zero, or an omitted value, means no
+                          limit. Total limits cannot be combined with the corresponding
+                          read or write limits.
+                        properties:
+                          groupName:
+                            description: Disks sharing a group name share their limits,
+                              the limits of the last disk in the group apply.
+                            type: string
+                          readBytesSec:
+                            description: Read throughput limit in bytes per second.
+                            format: int64
+                            type: integer
+                          readBytesSecMax:
+                            description: Read throughput allowed in bytes per second
+                              during bursts.
+                            format: int64
+                            type: integer
+                          readIOPSSec:
+                            description: Read I/O operations per second limit.
+                            format: int64
+                            type: integer
+                          readIOPSSecMax:
+                            description: Read I/O operations per second allowed during
+                              bursts.
+                            format: int64
+                            type: integer
+                          totalBytesSec:
+                            description: Total throughput limit in bytes per second.
+                            format: int64
+                            type: integer
+                          totalBytesSecMax:
+                            description: Total throughput allowed in bytes per second
+                              during bursts.
+                            format: int64
+                            type: integer
+                          totalIOPSSec:
+                            description: Total I/O operations per second limit.
+                            format: int64
+                            type: integer
+                          totalIOPSSecMax:
+                            description: Total I/O operations per second allowed during
+                              bursts.
+                            format: int64
+                            type: integer
+                          writeBytesSec:
+                            description: Write throughput limit in bytes per second.
+                            format: int64
+                            type: integer
+                          writeBytesSecMax:
+                            description: Write throughput allowed in bytes per second
+                              during bursts.
+                            format: int64
+                            type: integer
+                          writeIOPSSec:
+                            description: Write I/O operations per second limit.
+                            format: int64
+                            type: integer
+                          writeIOPSSecMax:
+                            description: Write I/O operations per second allowed during
+                              bursts.
+                            format: int64
+                            type: integer
+                        type: object
                       lun:
                         description: Attach a volume as a LUN to the vmi.
                         properties:
@@ -12819,7 +14497,9 @@ var CRDsValidation map[string]string = map[string]string{
                         type: string
                       serial:
                         description: Serial provides the ability to specify a serial
-                          number for the disk device.
+                          number for the disk device. NVMe disks are limited to 20
+                          characters and default to a serial derived from the disk
+                          name.
                         type: string
                       shareable:
                         description: If specified the disk is made sharable and multiple
@@ -12829,10 +14509,22 @@ var CRDsValidation map[string]string = map[string]string{
                         description: If specified, disk address and its tag will be
                           provided to the guest via config drive metadata
                         type: string
+                      wwn:
+                        description: WWN provides the ability to specify a World Wide
+                          Name for the disk device, as 16 hexadecimal digits. Only
+                          supported on the scsi, sata and nvme buses.
+                        type: string
                     required:
                     - name
                     type: object
                   type: array
+                filesystemHotplug:
+                  description: |-
+                    FilesystemHotplug enables the ability to hotplug virtiofs filesystems.
+                    It must be set before the VMI starts, on the VM template for filesystems persisted in the VM.
+                    The guest memory is backed by shared memory, as required by virtiofs.
+                    Defaults to false.
+                  type: boolean
                 filesystems:
                   description: Filesystems describes filesystem which is connected
                     to the vmi.
@@ -13003,6 +14695,102 @@ var CRDsValidation map[string]string = map[string]string{
                       masquerade:
                         description: InterfaceMasquerade connects to a given network
                           using netfilter rules to nat the traffic.
+                        properties:
+                          firewall:
+                            description: Firewall restricts the traffic reaching and
+                              leaving the virtual machine through the interface.
+                            properties:
+                              egress:
+                                description: Egress policy, applied to the traffic
+                                  originating from the virtual machine.
+                                properties:
+                                  defaultAction:
+                                    description: DefaultAction is applied to the traffic
+                                      no rule matched. Defaults to Allow.
+                                    type: string
+                                  rules:
+                                    items:
+                                      description: FirewallRule matches traffic by
+                                        its remote peer, protocol and port. Unset
+                                        fields match any traffic.
+                                      properties:
+                                        action:
+                                          description: Action applied to the matching
+                                            traffic.
+                                          type: string
+                                        ports:
+                                          description: Ports or port ranges (e.g.
+                                            8000-9000) the traffic is destined to.
+                                            Only valid along with the TCP, UDP or
+                                            SCTP protocols.
+                                          items:
+                                            type: string
+                                          type: array
+                                          x-kubernetes-list-type: atomic
+                                        protocol:
+                                          description: Protocol of the traffic. Must
+                                            be TCP, UDP, SCTP or ICMP.
+                                          type: string
+                                        remoteCIDRs:
+                                          description: RemoteCIDRs match the remote
+                                            peer, the source of ingress traffic and
+                                            the destination of egress traffic.
+                                          items:
+                                            type: string
+                                          type: array
+                                          x-kubernetes-list-type: atomic
+                                      required:
+                                      - action
+                                      type: object
+                                    type: array
+                                    x-kubernetes-list-type: atomic
+                                type: object
+                              ingress:
+                                description: Ingress policy, applied to the traffic
+                                  towards the virtual machine.
+                                properties:
+                                  defaultAction:
+                                    description: DefaultAction is applied to the traffic
+                                      no rule matched. Defaults to Allow.
+                                    type: string
+                                  rules:
+                                    items:
+                                      description: FirewallRule matches traffic by
+                                        its remote peer, protocol and port. Unset
+                                        fields match any traffic.
+                                      properties:
+                                        action:
+                                          description: Action applied to the matching
+                                            traffic.
+                                          type: string
+                                        ports:
+                                          description: Ports or port ranges (e.g.
+                                            8000-9000) the traffic is destined to.
+                                            Only valid along with the TCP, UDP or
+                                            SCTP protocols.
+                                          items:
+                                            type: string
+                                          type: array
+                                          x-kubernetes-list-type: atomic
+                                        protocol:
+                                          description: Protocol of the traffic. Must
+                                            be TCP, UDP, SCTP or ICMP.
+                                          type: string
+                                        remoteCIDRs:
+                                          description: RemoteCIDRs match the remote
+                                            peer, the source of ingress traffic and
+                                            the destination of egress traffic.
+                                          items:
+                                            type: string
+                                          type: array
+                                          x-kubernetes-list-type: atomic
+                                      required:
+                                      - action
+                                      type: object
+                                    type: array
+                                    x-kubernetes-list-type: atomic
+                                type: object
+                            type: object
                         type: object
                       model:
                         description: 'Interface model. One of: e1000, e1000e, ne2k_pci,
@@ -13048,6 +14836,37 @@ var CRDsValidation map[string]string = map[string]string{
                           - port
                           type: object
                         type: array
+                      routerAdvertisement:
+                        description: If specified, enables and tunes the IPv6 router
+                          advertisements sent to the guest. Router advertisements
+                          are sent on masquerade interfaces setting it, once the pod
+                          has IPv6 connectivity, passt interfaces accept the DNS servers
+                          only.
+                        properties:
+                          dnsServers:
+                            description: DNSServers are the IPv6 addresses advertised
+                              as recursive DNS servers (RDNSS).
+                            items:
+                              type: string
+                            type: array
+                            x-kubernetes-list-type: atomic
+                          managedAddressConfiguration:
+                            description: ManagedAddressConfiguration sets the managed
+                              (M) flag, telling the guest to obtain its address through
+                              DHCPv6. Defaults to true.
+                            type: boolean
+                          otherConfiguration:
+                            description: OtherConfiguration sets the other configuration
+                              (O) flag, telling the guest to obtain further configuration
+                              through DHCPv6.
+                            type: boolean
+                          prefix:
+                            description: Prefix advertised as on-link, in CIDR notation.
+                              Guests autoconfigure an address (SLAAC) only from a
+                              /64 prefix. Defaults to the IPv6 CIDR of the masquerade
+                              network.
+                            type: string
+                        type: object
                       slirp:
                         description: InterfaceSlirp connects to a given network using
                           QEMU user networking mode.
@@ -13065,6 +14884,11 @@ var CRDsValidation map[string]string = map[string]string{
                         description: If specified, the virtual network interface address
                           and its tag will be provided to the guest via config drive
                         type: string
+                      vhostUser:
+                        description: InterfaceVhostUser connects to a given network
+                          through a vhost-user socket served by a userspace datapath,
+                          such as OVS-DPDK.
+                        type: object
                     required:
                     - name
                     type: object
@@ -13343,6 +15167,12 @@ var CRDsValidation map[string]string = map[string]string{
                     efi:
                       description: If set, EFI will be used instead of BIOS.
                       properties:
+                        persistent:
+                          description: If set to true, the EFI NVRAM variables are
+                            kept in the backend storage of the VM, so that enrolled
+                            Secure Boot keys and boot entries survive restarts. Requires
+                            the VMPersistentState feature gate. Defaults to false
+                          type: boolean
                         secureBoot:
                           description: If set, SecureBoot will be enabled and the
                             OVMF roms will be swapped for SecureBoot-enabled ones.
@@ -14914,22 +16744,120 @@ var CRDsValidation map[string]string = map[string]string{
                                   bus:
                                     description: 'Bus indicates the type of disk device
                                       to emulate. supported values: virtio, sata,
-                                      scsi, usb.'
+                                      scsi, usb, nvme.'
                                     type: string
                                   pciAddress:
                                     description: 'If specified, the virtual disk will
                                       be placed on the guests pci address with the
-                                      specified PCI address. For example: 0000:81:01.10'
+                                      specified PCI address. For example: 0000:81:01.10
+                                      For nvme disks the address is assigned to the
+                                      disk''s NVMe controller.'
                                     type: string
                                   readonly:
                                     description: ReadOnly. Defaults to false.
                                     type: boolean
                                 type: object
+                              encryption:
+                                description: If specified, the disk image is a qemu-native
+                                  LUKS volume unlocked with the passphrase of the
+                                  referenced Secret. A blank volume is only formatted
+                                  as LUKS volume on first boot if format is set.
+                                properties:
+                                  format:
+                                    description: Format allows formatting the volume
+                                      as LUKS volume on first boot if it is blank,
+                                      i.e. its first MiB only contains zeros. Without
+                                      it, the volume has to be a LUKS volume already.
+                                    type: boolean
+                                  secretRef:
+                                    description: SecretRef references a Secret in
+                                      the namespace of the VMI which holds the LUKS
+                                      passphrase under the "passphrase" key.
+                                    properties:
+                                      name:
+                                        description: 'Name of the referent. More info:
+                                          https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                          TODO: Add other useful fields. apiVersion,
+                                          kind, uid?'
+                                        type: string
+                                    type: object
+                                required:
+                                - secretRef
+                                type: object
                               io:
                                 description: 'IO specifies which QEMU disk IO mode
                                   should be used. Supported values are: native, default,
                                   threads.'
                                 type: string
+                              ioTune:
+                                description: DiskIOTune represents the I/O throttling
+                                  applied to a disk. A value of zero, or an omitted
+                                  value, means no limit. Total limits cannot be combined
+                                  with the corresponding read or write limits.
+                                properties:
+                                  groupName:
+                                    description: Disks sharing a group name share
+                                      their limits, the limits of the last disk in
+                                      the group apply.
+                                    type: string
+                                  readBytesSec:
+                                    description: Read throughput limit in bytes per
+                                      second.
+                                    format: int64
+                                    type: integer
+                                  readBytesSecMax:
+                                    description: Read throughput allowed in bytes
+                                      per second during bursts.
+                                    format: int64
+                                    type: integer
+                                  readIOPSSec:
+                                    description: Read I/O operations per second limit.
+                                    format: int64
+                                    type: integer
+                                  readIOPSSecMax:
+                                    description: Read I/O operations per second allowed
+                                      during bursts.
+                                    format: int64
+                                    type: integer
+                                  totalBytesSec:
+                                    description: Total throughput limit in bytes per
+                                      second.
+                                    format: int64
+                                    type: integer
+                                  totalBytesSecMax:
+                                    description: Total throughput allowed in bytes
+                                      per second during bursts.
+                                    format: int64
+                                    type: integer
+                                  totalIOPSSec:
+                                    description: Total I/O operations per second limit.
+                                    format: int64
+                                    type: integer
+                                  totalIOPSSecMax:
+                                    description: Total I/O operations per second allowed
+                                      during bursts.
+                                    format: int64
+                                    type: integer
+                                  writeBytesSec:
+                                    description: Write throughput limit in bytes per
+                                      second.
+                                    format: int64
+                                    type: integer
+                                  writeBytesSecMax:
+                                    description: Write throughput allowed in bytes
+                                      per second during bursts.
+                                    format: int64
+                                    type: integer
+                                  writeIOPSSec:
+                                    description: Write I/O operations per second limit.
+                                    format: int64
+                                    type: integer
+                                  writeIOPSSecMax:
+                                    description: Write I/O operations per second allowed
+                                      during bursts.
+                                    format: int64
+                                    type: integer
+                                type: object
                               lun:
                                 description: Attach a volume as a LUN to the vmi.
                                 properties:
@@ -14952,7 +16880,9 @@ var CRDsValidation map[string]string = map[string]string{
                                 type: string
                               serial:
                                 description: Serial provides the ability to specify
-                                  a serial number for the disk device.
+                                  a serial number for the disk device. NVMe disks
+                                  are limited to 20 characters and default to a serial
+                                  derived from the disk name.
                                 type: string
                               shareable:
                                 description: If specified the disk is made sharable
@@ -14962,10 +16892,23 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: If specified, disk address and its tag
                                   will be provided to the guest via config drive metadata
                                 type: string
+                              wwn:
+                                description: WWN provides the ability to specify a
+                                  World Wide Name for the disk device, as 16 hexadecimal
+                                  digits. Only supported on the scsi, sata and nvme
+                                  buses.
+                                type: string
                             required:
                             - name
                             type: object
                           type: array
+                        filesystemHotplug:
+                          description: |-
+                            FilesystemHotplug enables the ability to hotplug virtiofs filesystems.
+                            It must be set before the VMI starts, on the VM template for filesystems persisted in the VM.
+                            The guest memory is backed by shared memory, as required by virtiofs.
+                            Defaults to false.
+                          type: boolean
                         filesystems:
                           description: Filesystems describes filesystem which is connected
                             to the vmi.
@@ -15141,6 +17084,107 @@ var CRDsValidation map[string]string = map[string]string{
                               masquerade:
                                 description: InterfaceMasquerade connects to a given
                                   network using netfilter rules to nat the traffic.
+                                properties:
+                                  firewall:
+                                    description: Firewall restricts the traffic reaching
+                                      and leaving the virtual machine through the
+                                      interface.
+                                    properties:
+                                      egress:
+                                        description: Egress policy, applied to the
+                                          traffic originating from the virtual machine.
+                                        properties:
+                                          defaultAction:
+                                            description: DefaultAction is applied
+                                              to the traffic no rule matched. Defaults
+                                              to Allow.
+                                            type: string
+                                          rules:
+                                            items:
+                                              description: FirewallRule matches traffic
+                                                by its remote peer, protocol and port.
+                                                Unset fields match any traffic.
+                                              properties:
+                                                action:
+                                                  description: Action applied to the
+                                                    matching traffic.
+                                                  type: string
+                                                ports:
+                                                  description: Ports or port ranges
+                                                    (e.g. 8000-9000) the traffic is
+                                                    destined to. Only valid along
+                                                    with the TCP, UDP or SCTP protocols.
+                                                  items:
+                                                    type: string
+                                                  type: array
+                                                  x-kubernetes-list-type: atomic
+                                                protocol:
+                                                  description: Protocol of the traffic.
+                                                    Must be TCP, UDP, SCTP or ICMP.
+                                                  type: string
+                                                remoteCIDRs:
+                                                  description: RemoteCIDRs match the
+                                                    remote peer, the source of ingress
+                                                    traffic and the destination of
+                                                    egress traffic.
+                                                  items:
+                                                    type: string
+                                                  type: array
+                                                  x-kubernetes-list-type: atomic
+                                              required:
+                                              - action
+                                              type: object
+                                            type: array
+                                            x-kubernetes-list-type: atomic
+                                        type: object
+                                      ingress:
+                                        description: Ingress policy, applied to the
+                                          traffic towards the virtual machine.
+                                        properties:
+                                          defaultAction:
+                                            description: DefaultAction is applied
+                                              to the traffic no rule matched. Defaults
+                                              to Allow.
+                                            type: string
+                                          rules:
+                                            items:
+                                              description: FirewallRule matches traffic
+                                                by its remote peer, protocol and port.
+                                                Unset fields match any traffic.
+                                              properties:
+                                                action:
+                                                  description: Action applied to the
+                                                    matching traffic.
+                                                  type: string
+                                                ports:
+                                                  description: Ports or port ranges
+                                                    (e.g. 8000-9000) the traffic is
+                                                    destined to. Only valid along
+                                                    with the TCP, UDP or SCTP protocols.
+                                                  items:
+                                                    type: string
+                                                  type: array
+                                                  x-kubernetes-list-type: atomic
+                                                protocol:
+                                                  description: Protocol of the traffic.
+                                                    Must be TCP, UDP, SCTP or ICMP.
+                                                  type: string
+                                                remoteCIDRs:
+                                                  description: RemoteCIDRs match the
+                                                    remote peer, the source of ingress
+                                                    traffic and the destination of
+                                                    egress traffic.
+                                                  items:
+                                                    type: string
+                                                  type: array
+                                                  x-kubernetes-list-type: atomic
+                                              required:
+                                              - action
+                                              type: object
+                                            type: array
+                                            x-kubernetes-list-type: atomic
+                                        type: object
+                                    type: object
                                 type: object
                               model:
                                 description: 'Interface model. One of: e1000, e1000e,
@@ -15189,6 +17233,37 @@ var CRDsValidation map[string]string = map[string]string{
                                   - port
                                   type: object
                                 type: array
+                              routerAdvertisement:
+                                description: If specified, enables and tunes the IPv6
+                                  router advertisements sent to the guest. Router
+                                  advertisements are sent on masquerade interfaces
+                                  setting it, once the pod has IPv6 connectivity,
+                                  passt interfaces accept the DNS servers only.
+                                properties:
+                                  dnsServers:
+                                    description: DNSServers are the IPv6 addresses
+                                      advertised as recursive DNS servers (RDNSS).
+                                    items:
+                                      type: string
+                                    type: array
+                                    x-kubernetes-list-type: atomic
+                                  managedAddressConfiguration:
+                                    description: ManagedAddressConfiguration sets
+                                      the managed (M) flag, telling the guest to obtain
+                                      its address through DHCPv6. Defaults to true.
+                                    type: boolean
+                                  otherConfiguration:
+                                    description: OtherConfiguration sets the other
+                                      configuration (O) flag, telling the guest to
+                                      obtain further configuration through DHCPv6.
+                                    type: boolean
+                                  prefix:
+                                    description: Prefix advertised as on-link, in
+                                      CIDR notation. Guests autoconfigure an address
+                                      (SLAAC) only from a /64 prefix. Defaults to
+                                      the IPv6 CIDR of the masquerade network.
+                                    type: string
+                                type: object
                               slirp:
                                 description: InterfaceSlirp connects to a given network
                                   using QEMU user networking mode.
@@ -15208,6 +17283,11 @@ var CRDsValidation map[string]string = map[string]string{
                                   address and its tag will be provided to the guest
                                   via config drive
                                 type: string
+                              vhostUser:
+                                description: InterfaceVhostUser connects to a given
+                                  network through a vhost-user socket served by a
+                                  userspace datapath, such as OVS-DPDK.
+                                type: object
                             required:
                             - name
                             type: object
@@ -15512,6 +17592,13 @@ var CRDsValidation map[string]string = map[string]string{
                             efi:
                               description: If set, EFI will be used instead of BIOS.
                               properties:
+                                persistent:
+                                  description: If set to true, the EFI NVRAM variables
+                                    are kept in the backend storage of the VM, so
+                                    that enrolled Secure Boot keys and boot entries
+                                    survive restarts. Requires the VMPersistentState
+                                    feature gate. Defaults to false
+                                  type: boolean
                                 secureBoot:
                                   description: If set, SecureBoot will be enabled
                                     and the OVMF roms will be swapped for SecureBoot-enabled
@@ -15673,6 +17760,22 @@ var CRDsValidation map[string]string = map[string]string{
                     VirtualMachineInstance should be migrated instead of shut-off
                     in case of a node drain.
                   type: string
+                filesystemTrim:
+                  description: FilesystemTrim periodically trims the guest filesystems
+                    through the qemu guest agent, which returns their unused blocks
+                    to thin-provisioned storage.
+                  properties:
+                    discardPreallocated:
+                      description: DiscardPreallocated passes the disks of preallocated
+                        or thick-provisioned volumes with discard=unmap as well, so
+                        that the trims reach their storage. By default these disks
+                        are passed with discard=ignore.
+                      type: boolean
+                    interval:
+                      description: Interval between two trims of the guest filesystems,
+                        it must be at least 1h. Defaults to 24h.
+                      type: string
+                  type: object
                 hostname:
                   description: Specifies the hostname of the vmi If not specified,
                     the hostname will be set to the name of the vmi, if dhcp or cloud-init
@@ -16315,6 +18418,10 @@ var CRDsValidation map[string]string = map[string]string{
                         description: 'ConfigMapSource represents a reference to a
                           ConfigMap in the same namespace. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-pod-configmap/'
                         properties:
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
                           name:
                             description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
                               TODO: Add other useful fields. apiVersion, kind, uid?'
@@ -16334,6 +18441,10 @@ var CRDsValidation map[string]string = map[string]string{
                         description: 'ContainerDisk references a docker image, embedding
                           a qcow or raw disk. More info: https://kubevirt.gitbooks.io/user-guide/registry-disk.html'
                         properties:
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
                           image:
                             description: Image is the name of the image with the embedded
                               disk.
@@ -16523,6 +18634,41 @@ var CRDsValidation map[string]string = map[string]string{
                         - path
                         - type
                         type: object
+                      iscsi:
+                        description: ISCSI represents a LUN of an iSCSI target, which
+                          qemu connects to directly.
+                        properties:
+                          chapSecretRef:
+                            description: CHAPSecretRef references a Secret in the
+                              namespace of the VMI which holds the CHAP credentials
+                              under the "node.session.auth.username" and "node.session.auth.password"
+                              keys, as the Secrets of type kubernetes.io/iscsi-chap.
+                            properties:
+                              name:
+                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
+                                type: string
+                            type: object
+                          initiatorName:
+                            description: InitiatorName is the iSCSI qualified name
+                              of the initiator. Defaults to the initiator name of
+                              qemu.
+                            type: string
+                          iqn:
+                            description: IQN is the iSCSI qualified name of the target.
+                            type: string
+                          lun:
+                            description: Lun is the number of the LUN on the target.
+                              Defaults to 0.
+                            format: int32
+                            type: integer
+                          targetPortal:
+                            description: TargetPortal is the address of the target,
+                              host or host:port. The port defaults to 3260.
+                            type: string
+                        required:
+                        - targetPortal
+                        - iqn
+                        type: object
                       memoryDump:
                         description: MemoryDump is attached to the virt launcher and
                           is populated with a memory dump of the vmi
@@ -16547,6 +18693,35 @@ var CRDsValidation map[string]string = map[string]string{
                         description: 'Volume''s name. Must be a DNS_LABEL and unique
                           within the vmi. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
                         type: string
+                      nbd:
+                        description: NBD represents an export of a network block device
+                          server, which qemu connects to directly.
+                        properties:
+                          uri:
+                            description: URI of the export, in the form nbd://host[:port][/export].
+                              The port defaults to 10809.
+                            type: string
+                        required:
+                        - uri
+                        type: object
+                      overlay:
+                        description: Overlay is a persistent copy-on-write image on
+                          a PVC, backed by a shared read-only base image on another
+                          PVC.
+                        properties:
+                          baseClaimName:
+                            description: BaseClaimName is the name of a PVC in the
+                              same namespace which holds the raw base image. It is
+                              attached read-only and can be shared by many vmis.
+                            type: string
+                          claimName:
+                            description: ClaimName is the name of a filesystem PVC
+                              in the same namespace which stores the qcow2 overlay.
+                            type: string
+                        required:
+                        - claimName
+                        - baseClaimName
+                        type: object
                       persistentVolumeClaim:
                         description: 'PersistentVolumeClaimVolumeSource represents
                           a reference to a PersistentVolumeClaim in the same namespace.
@@ -16568,10 +18743,50 @@ var CRDsValidation map[string]string = map[string]string{
                         required:
                         - claimName
                         type: object
+                      rbd:
+                        description: RBD represents an image of a Ceph cluster, which
+                          qemu connects to directly.
+                        properties:
+                          image:
+                            description: Image is the name of the image.
+                            type: string
+                          keyringSecretRef:
+                            description: KeyringSecretRef references a Secret in the
+                              namespace of the VMI which holds the cephx key of the
+                              user under the "key" key, as the Secrets of type kubernetes.io/rbd.
+                              Without it, cephx authentication is disabled.
+                            properties:
+                              name:
+                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
+                                type: string
+                            type: object
+                          monitors:
+                            description: Monitors are the addresses of the Ceph monitors,
+                              host or host:port. The port defaults to 6789.
+                            items:
+                              type: string
+                            type: array
+                            x-kubernetes-list-type: atomic
+                          pool:
+                            description: Pool is the name of the pool holding the
+                              image. Defaults to rbd.
+                            type: string
+                          user:
+                            description: User is the Ceph user to authenticate as.
+                              Defaults to admin.
+                            type: string
+                        required:
+                        - monitors
+                        - image
+                        type: object
                       secret:
                         description: 'SecretVolumeSource represents a reference to
                           a secret data in the same namespace. More info: https://kubernetes.io/docs/concepts/configuration/secret/'
                         properties:
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
                           optional:
                             description: Specify whether the Secret or it's keys must
                               be defined
@@ -16592,6 +18807,10 @@ var CRDsValidation map[string]string = map[string]string{
                           to a service account. There can only be one volume of this
                           type! More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
                         properties:
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
                           serviceAccountName:
                             description: 'Name of the service account in the pod''s
                               namespace to use. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
@@ -19198,23 +21417,128 @@ var CRDsValidation map[string]string = map[string]string{
                                           bus:
                                             description: 'Bus indicates the type of
                                               disk device to emulate. supported values:
-                                              virtio, sata, scsi, usb.'
+                                              virtio, sata, scsi, usb, nvme.'
                                             type: string
                                           pciAddress:
                                             description: 'If specified, the virtual
                                               disk will be placed on the guests pci
                                               address with the specified PCI address.
-                                              For example: 0000:81:01.10'
+                                              For example: 0000:81:01.10 For nvme
+                                              disks the address is assigned to the
+                                              disk''s NVMe controller.'
                                             type: string
                                           readonly:
                                             description: ReadOnly. Defaults to false.
                                             type: boolean
                                         type: object
+                                      encryption:
+                                        description: If specified, the disk image
+                                          is a qemu-native LUKS volume unlocked with
+                                          the passphrase of the referenced Secret.
+                                          A blank volume is only formatted as LUKS
+                                          volume on first boot if format is set.
+                                        properties:
+                                          format:
+                                            description: Format allows formatting
+                                              the volume as LUKS volume on first boot
+                                              if it is blank, i.e. its first MiB only
+                                              contains zeros. Without it, the volume
+                                              has to be a LUKS volume already.
+                                            type: boolean
+                                          secretRef:
+                                            description: SecretRef references a Secret
+                                              in the namespace of the VMI which holds
+                                              the LUKS passphrase under the "passphrase"
+                                              key.
+                                            properties:
+                                              name:
+                                                description: 'Name of the referent.
+                                                  More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                                  TODO: Add other useful fields. apiVersion,
+                                                  kind, uid?'
+                                                type: string
+                                            type: object
+                                        required:
+                                        - secretRef
+                                        type: object
                                       io:
                                         description: 'IO specifies which QEMU disk
                                           IO mode should be used. Supported values
                                           are: native, default, threads.'
                                         type: string
+                                      ioTune:
+                                        description: DiskIOTune represents the I/O
+                                          throttling applied to a disk. A value of
+                                          zero, or an omitted value, means no limit.
+                                          Total limits cannot be combined with the
+                                          corresponding read or write limits.
+                                        properties:
+                                          groupName:
+                                            description: Disks sharing a group name
+                                              share their limits, the limits of the
+                                              last disk in the group apply.
+                                            type: string
+                                          readBytesSec:
+                                            description: Read throughput limit in
+                                              bytes per second.
+                                            format: int64
+                                            type: integer
+                                          readBytesSecMax:
+                                            description: Read throughput allowed in
+                                              bytes per second during bursts.
+                                            format: int64
+                                            type: integer
+                                          readIOPSSec:
+                                            description: Read I/O operations per second
+                                              limit.
+                                            format: int64
+                                            type: integer
+                                          readIOPSSecMax:
+                                            description: Read I/O operations per second
+                                              allowed during bursts.
+                                            format: int64
+                                            type: integer
+                                          totalBytesSec:
+                                            description: Total throughput limit in
+                                              bytes per second.
+                                            format: int64
+                                            type: integer
+                                          totalBytesSecMax:
+                                            description: Total throughput allowed
+                                              in bytes per second during bursts.
+                                            format: int64
+                                            type: integer
+                                          totalIOPSSec:
+                                            description: Total I/O operations per
+                                              second limit.
+                                            format: int64
+                                            type: integer
+                                          totalIOPSSecMax:
+                                            description: Total I/O operations per
+                                              second allowed during bursts.
+                                            format: int64
+                                            type: integer
+                                          writeBytesSec:
+                                            description: Write throughput limit in
+                                              bytes per second.
+                                            format: int64
+                                            type: integer
+                                          writeBytesSecMax:
+                                            description: Write throughput allowed
+                                              in bytes per second during bursts.
+                                            format: int64
+                                            type: integer
+                                          writeIOPSSec:
+                                            description: Write I/O operations per
+                                              second limit.
+                                            format: int64
+                                            type: integer
+                                          writeIOPSSecMax:
+                                            description: Write I/O operations per
+                                              second allowed during bursts.
+                                            format: int64
+                                            type: integer
+                                        type: object
                                       lun:
                                         description: Attach a volume as a LUN to the
                                           vmi.
@@ -19239,6 +21563,9 @@ var CRDsValidation map[string]string = map[string]string{
                                       serial:
                                         description: Serial provides the ability to
                                           specify a serial number for the disk device.
+                                          NVMe disks are limited to 20 characters
+                                          and default to a serial derived from the
+                                          disk name.
                                         type: string
                                       shareable:
                                         description: If specified the disk is made
@@ -19250,11 +21577,24 @@ var CRDsValidation map[string]string = map[string]string{
                                           its tag will be provided to the guest via
                                           config drive metadata
                                         type: string
+                                      wwn:
+                                        description: WWN provides the ability to specify
+                                          a World Wide Name for the disk device, as
+                                          16 hexadecimal digits. Only supported on
+                                          the scsi, sata and nvme buses.
+                                        type: string
                                     required:
                                     - name
                                     type: object
                                   type: array
-                                filesystems:
+                                filesystemHotplug:
+                                  description: |-
+                                    FilesystemHotplug enables the ability to hotplug virtiofs filesystems.
+                                    It must be set before the VMI starts, on the VM template for filesystems persisted in the VM.
+                                    The guest memory is backed by shared memory, as required by virtiofs.
+                                    Defaults to false.
+                                  type: boolean
+                                filesystems:
                                   description: Filesystems describes filesystem which
                                     is connected to the vmi.
                                   items:
@@ -19440,6 +21780,117 @@ var CRDsValidation map[string]string = map[string]string{
                                         description: InterfaceMasquerade connects
                                           to a given network using netfilter rules
                                           to nat the traffic.
+                                        properties:
+                                          firewall:
+                                            description: Firewall restricts the traffic
+                                              reaching and leaving the virtual machine
+                                              through the interface.
+                                            properties:
+                                              egress:
+                                                description: Egress policy, applied
+                                                  to the traffic originating from
+                                                  the virtual machine.
+                                                properties:
+                                                  defaultAction:
+                                                    description: DefaultAction is
+                                                      applied to the traffic no rule
+                                                      matched. Defaults to Allow.
+                                                    type: string
+                                                  rules:
+                                                    items:
+                                                      description: FirewallRule matches
+                                                        traffic by its remote peer,
+                                                        protocol and port. Unset fields
+                                                        match any traffic.
+                                                      properties:
+                                                        action:
+                                                          description: Action applied
+                                                            to the matching traffic.
+                                                          type: string
+                                                        ports:
+                                                          description: Ports or port
+                                                            ranges (e.g. 8000-9000)
+                                                            the traffic is destined
+                                                            to. Only valid along with
+                                                            the TCP, UDP or SCTP protocols.
+                                                          items:
+                                                            type: string
+                                                          type: array
+                                                          x-kubernetes-list-type: atomic
+                                                        protocol:
+                                                          description: Protocol of
+                                                            the traffic. Must be TCP,
+                                                            UDP, SCTP or ICMP.
+                                                          type: string
+                                                        remoteCIDRs:
+                                                          description: RemoteCIDRs
+                                                            match the remote peer,
+                                                            the source of ingress
+                                                            traffic and the destination
+                                                            of egress traffic.
+                                                          items:
+                                                            type: string
+                                                          type: array
+                                                          x-kubernetes-list-type: atomic
+                                                      required:
+                                                      - action
+                                                      type: object
+                                                    type: array
+                                                    x-kubernetes-list-type: atomic
+                                                type: object
+                                              ingress:
+                                                description: Ingress policy, applied
+                                                  to the traffic towards the virtual
+                                                  machine.
+                                                properties:
+                                                  defaultAction:
+                                                    description: DefaultAction is
+                                                      applied to the traffic no rule
+                                                      matched. Defaults to Allow.
+                                                    type: string
+                                                  rules:
+                                                    items:
+                                                      description: FirewallRule matches
+                                                        traffic by its remote peer,
+                                                        protocol and port. Unset fields
+                                                        match any traffic.
+                                                      properties:
+                                                        action:
+                                                          description: Action applied
+                                                            to the matching traffic.
+                                                          type: string
+                                                        ports:
+                                                          description: Ports or port
+                                                            ranges (e.g. 8000-9000)
+                                                            the traffic is destined
+                                                            to. Only valid along with
+                                                            the TCP, UDP or SCTP protocols.
+                                                          items:
+                                                            type: string
+                                                          type: array
+                                                          x-kubernetes-list-type: atomic
+                                                        protocol:
+                                                          description: Protocol of
+                                                            the traffic. Must be TCP,
+                                                            UDP, SCTP or ICMP.
+                                                          type: string
+                                                        remoteCIDRs:
+                                                          description: RemoteCIDRs
+                                                            match the remote peer,
+                                                            the source of ingress
+                                                            traffic and the destination
+                                                            of egress traffic.
+                                                          items:
+                                                            type: string
+                                                          type: array
+                                                          x-kubernetes-list-type: atomic
+                                                      required:
+                                                      - action
+                                                      type: object
+                                                    type: array
+                                                    x-kubernetes-list-type: atomic
+                                                type: object
+                                            type: object
                                         type: object
                                       model:
                                         description: 'Interface model. One of: e1000,
@@ -19493,6 +21944,42 @@ var CRDsValidation map[string]string = map[string]string{
                                           - port
                                           type: object
                                         type: array
+                                      routerAdvertisement:
+                                        description: If specified, enables and tunes
+                                          the IPv6 router advertisements sent to the
+                                          guest. Router advertisements are sent on
+                                          masquerade interfaces setting it, once the
+                                          pod has IPv6 connectivity, passt interfaces
+                                          accept the DNS servers only.
+                                        properties:
+                                          dnsServers:
+                                            description: DNSServers are the IPv6 addresses
+                                              advertised as recursive DNS servers
+                                              (RDNSS).
+                                            items:
+                                              type: string
+                                            type: array
+                                            x-kubernetes-list-type: atomic
+                                          managedAddressConfiguration:
+                                            description: ManagedAddressConfiguration
+                                              sets the managed (M) flag, telling the
+                                              guest to obtain its address through
+                                              DHCPv6. Defaults to true.
+                                            type: boolean
+                                          otherConfiguration:
+                                            description: OtherConfiguration sets the
+                                              other configuration (O) flag, telling
+                                              the guest to obtain further configuration
+                                              through DHCPv6.
+                                            type: boolean
+                                          prefix:
+                                            description: Prefix advertised as on-link,
+                                              in CIDR notation. Guests autoconfigure
+                                              an address (SLAAC) only from a /64 prefix.
+                                              Defaults to the IPv6 CIDR of the masquerade
+                                              network.
+                                            type: string
+                                        type: object
                                       slirp:
                                         description: InterfaceSlirp connects to a
                                           given network using QEMU user networking
@@ -19514,6 +22001,12 @@ var CRDsValidation map[string]string = map[string]string{
                                           interface address and its tag will be provided
                                           to the guest via config drive
                                         type: string
+                                      vhostUser:
+                                        description: InterfaceVhostUser connects to
+                                          a given network through a vhost-user socket
+                                          served by a userspace datapath, such as
+                                          OVS-DPDK.
+                                        type: object
                                     required:
                                     - name
                                     type: object
@@ -19833,6 +22326,14 @@ var CRDsValidation map[string]string = map[string]string{
                                       description: If set, EFI will be used instead
                                         of BIOS.
                                       properties:
+                                        persistent:
+                                          description: If set to true, the EFI NVRAM
+                                            variables are kept in the backend storage
+                                            of the VM, so that enrolled Secure Boot
+                                            keys and boot entries survive restarts.
+                                            Requires the VMPersistentState feature
+                                            gate. Defaults to false
+                                          type: boolean
                                         secureBoot:
                                           description: If set, SecureBoot will be
                                             enabled and the OVMF roms will be swapped
@@ -20003,6 +22504,22 @@ var CRDsValidation map[string]string = map[string]string{
                             if the VirtualMachineInstance should be migrated instead
                             of shut-off in case of a node drain.
                           type: string
+                        filesystemTrim:
+                          description: FilesystemTrim periodically trims the guest
+                            filesystems through the qemu guest agent, which returns
+                            their unused blocks to thin-provisioned storage.
+                          properties:
+                            discardPreallocated:
+                              description: DiscardPreallocated passes the disks of
+                                preallocated or thick-provisioned volumes with discard=unmap
+                                as well, so that the trims reach their storage. By
+                                default these disks are passed with discard=ignore.
+                              type: boolean
+                            interval:
+                              description: Interval between two trims of the guest
+                                filesystems, it must be at least 1h. Defaults to 24h.
+                              type: string
+                          type: object
                         hostname:
                           description: Specifies the hostname of the vmi If not specified,
                             the hostname will be set to the name of the vmi, if dhcp
@@ -20689,6 +23206,10 @@ var CRDsValidation map[string]string = map[string]string{
                                   to a ConfigMap in the same namespace. More info:
                                   https://kubernetes.io/docs/tasks/configure-pod-container/configure-pod-configmap/'
                                 properties:
+                                  hotpluggable:
+                                    description: Hotpluggable indicates whether the
+                                      volume can be hotplugged and hotunplugged.
+                                    type: boolean
                                   name:
                                     description: 'Name of the referent. More info:
                                       https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
@@ -20711,6 +23232,10 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: 'ContainerDisk references a docker image,
                                   embedding a qcow or raw disk. More info: https://kubevirt.gitbooks.io/user-guide/registry-disk.html'
                                 properties:
+                                  hotpluggable:
+                                    description: Hotpluggable indicates whether the
+                                      volume can be hotplugged and hotunplugged.
+                                    type: boolean
                                   image:
                                     description: Image is the name of the image with
                                       the embedded disk.
@@ -20912,6 +23437,45 @@ var CRDsValidation map[string]string = map[string]string{
                                 - path
                                 - type
                                 type: object
+                              iscsi:
+                                description: ISCSI represents a LUN of an iSCSI target,
+                                  which qemu connects to directly.
+                                properties:
+                                  chapSecretRef:
+                                    description: CHAPSecretRef references a Secret
+                                      in the namespace of the VMI which holds the
+                                      CHAP credentials under the "node.session.auth.username"
+                                      and "node.session.auth.password" keys, as the
+                                      Secrets of type kubernetes.io/iscsi-chap.
+                                    properties:
+                                      name:
+                                        description: 'Name of the referent. More info:
+                                          https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
+                                        type: string
+                                    type: object
+                                  initiatorName:
+                                    description: InitiatorName is the iSCSI qualified
+                                      name of the initiator. Defaults to the initiator
+                                      name of qemu.
+                                    type: string
+                                  iqn:
+                                    description: IQN is the iSCSI qualified name of
+                                      the target.
+                                    type: string
+                                  lun:
+                                    description: Lun is the number of the LUN on the
+                                      target. Defaults to 0.
+                                    format: int32
+                                    type: integer
+                                  targetPortal:
+                                    description: TargetPortal is the address of the
+                                      target, host or host:port. The port defaults
+                                      to 3260.
+                                    type: string
+                                required:
+                                - targetPortal
+                                - iqn
+                                type: object
                               memoryDump:
                                 description: MemoryDump is attached to the virt launcher
                                   and is populated with a memory dump of the vmi
@@ -20936,6 +23500,37 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: 'Volume''s name. Must be a DNS_LABEL
                                   and unique within the vmi. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
                                 type: string
+                              nbd:
+                                description: NBD represents an export of a network
+                                  block device server, which qemu connects to directly.
+                                properties:
+                                  uri:
+                                    description: URI of the export, in the form nbd://host[:port][/export].
+                                      The port defaults to 10809.
+                                    type: string
+                                required:
+                                - uri
+                                type: object
+                              overlay:
+                                description: Overlay is a persistent copy-on-write
+                                  image on a PVC, backed by a shared read-only base
+                                  image on another PVC.
+                                properties:
+                                  baseClaimName:
+                                    description: BaseClaimName is the name of a PVC
+                                      in the same namespace which holds the raw base
+                                      image. It is attached read-only and can be shared
+                                      by many vmis.
+                                    type: string
+                                  claimName:
+                                    description: ClaimName is the name of a filesystem
+                                      PVC in the same namespace which stores the qcow2
+                                      overlay.
+                                    type: string
+                                required:
+                                - claimName
+                                - baseClaimName
+                                type: object
                               persistentVolumeClaim:
                                 description: 'PersistentVolumeClaimVolumeSource represents
                                   a reference to a PersistentVolumeClaim in the same
@@ -20958,11 +23553,54 @@ var CRDsValidation map[string]string = map[string]string{
                                 required:
                                 - claimName
                                 type: object
+                              rbd:
+                                description: RBD represents an image of a Ceph cluster,
+                                  which qemu connects to directly.
+                                properties:
+                                  image:
+                                    description: Image is the name of the image.
+                                    type: string
+                                  keyringSecretRef:
+                                    description: KeyringSecretRef references a Secret
+                                      in the namespace of the VMI which holds the
+                                      cephx key of the user under the "key" key, as
+                                      the Secrets of type kubernetes.io/rbd. Without
+                                      it, cephx authentication is disabled.
+                                    properties:
+                                      name:
+                                        description: 'Name of the referent. More info:
+                                          https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
+                                        type: string
+                                    type: object
+                                  monitors:
+                                    description: Monitors are the addresses of the
+                                      Ceph monitors, host or host:port. The port defaults
+                                      to 6789.
+                                    items:
+                                      type: string
+                                    type: array
+                                    x-kubernetes-list-type: atomic
+                                  pool:
+                                    description: Pool is the name of the pool holding
+                                      the image. Defaults to rbd.
+                                    type: string
+                                  user:
+                                    description: User is the Ceph user to authenticate
+                                      as. Defaults to admin.
+                                    type: string
+                                required:
+                                - monitors
+                                - image
+                                type: object
                               secret:
                                 description: 'SecretVolumeSource represents a reference
                                   to a secret data in the same namespace. More info:
                                   https://kubernetes.io/docs/concepts/configuration/secret/'
                                 properties:
+                                  hotpluggable:
+                                    description: Hotpluggable indicates whether the
+                                      volume can be hotplugged and hotunplugged.
+                                    type: boolean
                                   optional:
                                     description: Specify whether the Secret or it's
                                       keys must be defined
@@ -20984,6 +23622,10 @@ var CRDsValidation map[string]string = map[string]string{
                                   a reference to a service account. There can only
                                   be one volume of this type! More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
                                 properties:
+                                  hotpluggable:
+                                    description: Hotpluggable indicates whether the
+                                      volume can be hotplugged and hotunplugged.
+                                    type: boolean
                                   serviceAccountName:
                                     description: 'Name of the service account in the
                                       pod''s namespace to use. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
@@ -21308,6 +23950,96 @@ var CRDsValidation map[string]string = map[string]string{
             preferredInterfaceMasquerade:
               description: PreferredInterfaceMasquerade optionally defines the preferred
                 masquerade configuration to use with each network interface.
+              properties:
+                firewall:
+                  description: Firewall restricts the traffic reaching and leaving
+                    the virtual machine through the interface.
+                  properties:
+                    egress:
+                      description: Egress policy, applied to the traffic originating
+                        from the virtual machine.
+                      properties:
+                        defaultAction:
+                          description: DefaultAction is applied to the traffic no
+                            rule matched. Defaults to Allow.
+                          type: string
+                        rules:
+                          items:
+                            description: FirewallRule matches traffic by its remote
+                              peer, protocol and port. Unset fields match any traffic.
+                            properties:
+                              action:
+                                description: Action applied to the matching traffic.
+                                type: string
+                              ports:
+                                description: Ports or port ranges (e.g. 8000-9000)
+                                  the traffic is destined to. Only valid along with
+                                  the TCP, UDP or SCTP protocols.
+                                items:
+                                  type: string
+                                type: array
+                                x-kubernetes-list-type: atomic
+                              protocol:
+                                description: Protocol of the traffic. Must be TCP,
+                                  UDP, SCTP or ICMP.
+                                type: string
+                              remoteCIDRs:
+                                description: RemoteCIDRs match the remote peer, the
+                                  source of ingress traffic and the destination of
+                                  egress traffic.
+                                items:
+                                  type: string
+                                type: array
+                                x-kubernetes-list-type: atomic
+                            required:
+                            - action
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
+                      type: object
+                    ingress:
+                      description: Ingress policy, applied to the traffic towards
+                        the virtual machine.
+                      properties:
+                        defaultAction:
+                          description: DefaultAction is applied to the traffic no
+                            rule matched. Defaults to Allow.
+                          type: string
+                        rules:
+                          items:
+                            description: FirewallRule matches traffic by its remote
+                              peer, protocol and port. Unset fields match any traffic.
+                            properties:
+                              action:
+                                description: Action applied to the matching traffic.
+                                type: string
+                              ports:
+                                description: Ports or port ranges (e.g. 8000-9000)
+                                  the traffic is destined to. Only valid along with
+                                  the TCP, UDP or SCTP protocols.
+                                items:
+                                  type: string
+                                type: array
+                                x-kubernetes-list-type: atomic
+                              protocol:
+                                description: Protocol of the traffic. Must be TCP,
+                                  UDP, SCTP or ICMP.
+                                type: string
+                              remoteCIDRs:
+                                description: RemoteCIDRs match the remote peer, the
+                                  source of ingress traffic and the destination of
+                                  egress traffic.
+                                items:
+                                  type: string
+                                type: array
+                                x-kubernetes-list-type: atomic
+                            required:
+                            - action
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
+                      type: object
+                  type: object
               type: object
             preferredInterfaceModel:
               description: PreferredInterfaceModel optionally defines the preferred
@@ -21658,6 +24390,21 @@ var CRDsValidation map[string]string = map[string]string{
     spec:
       description: VirtualMachineRestoreSpec is the spec for a VirtualMachineRestoreresource
       properties:
+        excludedVolumes:
+          description: ExcludedVolumes are volumes of the snapshot which are not restored,
+            the target keeps its current volumes instead
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: set
+        includedVolumes:
+          description: IncludedVolumes limits the restore to the listed volumes of
+            the snapshot, the other volumes of the target are left untouched. All
+            the volumes of the snapshot are restored when empty.
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: set
         patches:
           description: "If the target for the restore does not exist, it will be created.
             Patches holds JSON patches that would be applied to the target manifest
@@ -21753,8 +24500,9 @@ var CRDsValidation map[string]string = map[string]string{
   - spec
   type: object
 `,
-	"virtualmachinesnapshot": `openAPIV3Schema:
-  description: VirtualMachineSnapshot defines the operation of snapshotting a VM
+	"virtualmachinerestoregroup": `openAPIV3Schema:
+  description: VirtualMachineRestoreGroup defines the operation of restoring all VMs
+    of a VirtualMachineSnapshotGroup
   properties:
     apiVersion:
       description: 'APIVersion defines the versioned schema of this representation
@@ -21769,55 +24517,31 @@ var CRDsValidation map[string]string = map[string]string{
     metadata:
       type: object
     spec:
-      description: VirtualMachineSnapshotSpec is the spec for a VirtualMachineSnapshot
+      description: VirtualMachineRestoreGroupSpec is the spec for a VirtualMachineRestoreGroup
         resource
       properties:
-        deletionPolicy:
-          description: DeletionPolicy defines that to do with VirtualMachineSnapshot
-            when VirtualMachineSnapshot is deleted
+        virtualMachineSnapshotGroupName:
+          description: The VMs of the group are restored to the VMs they were taken
+            from, the restore only starts once none of them is running.
           type: string
-        failureDeadline:
-          description: This time represents the number of seconds we permit the vm
-            snapshot to take. In case we pass this deadline we mark this snapshot
-            as failed. Defaults to DefaultFailureDeadline - 5min
-          type: string
-        source:
-          description: TypedLocalObjectReference contains enough information to let
-            you locate the typed referenced object inside the same namespace.
-          properties:
-            apiGroup:
-              description: APIGroup is the group for the resource being referenced.
-                If APIGroup is not specified, the specified Kind must be in the core
-                API group. For any other third-party types, APIGroup is required.
-              type: string
-            kind:
-              description: Kind is the type of resource being referenced
-              type: string
-            name:
-              description: Name is the name of resource being referenced
-              type: string
-          required:
-          - kind
-          - name
-          type: object
       required:
-      - source
+      - virtualMachineSnapshotGroupName
       type: object
     status:
-      description: VirtualMachineSnapshotStatus is the status for a VirtualMachineSnapshot
+      description: VirtualMachineRestoreGroupStatus is the status for a VirtualMachineRestoreGroup
         resource
       properties:
+        complete:
+          type: boolean
         conditions:
           items:
             description: Condition defines conditions
             properties:
               lastProbeTime:
                 format: date-time
-                nullable: true
                 type: string
               lastTransitionTime:
                 format: date-time
-                nullable: true
                 type: string
               message:
                 type: string
@@ -21826,67 +24550,41 @@ var CRDsValidation map[string]string = map[string]string{
               status:
                 type: string
               type:
-                description: ConditionType is the const type for Conditions
                 type: string
             required:
-            - status
             - type
+            - status
             type: object
           type: array
-        creationTime:
+          x-kubernetes-list-type: atomic
+        restoreTime:
           format: date-time
           nullable: true
           type: string
-        error:
-          description: Error is the last error encountered during the snapshot/restore
-          properties:
-            message:
-              type: string
-            time:
-              format: date-time
-              type: string
-          type: object
-        indications:
+        virtualMachineRestores:
+          description: VirtualMachineRestores lists the restore of every VM of the
+            group
           items:
-            description: Indication is a way to indicate the state of the vm when
-              taking the snapshot
-            type: string
-          type: array
-          x-kubernetes-list-type: set
-        phase:
-          description: VirtualMachineSnapshotPhase is the current phase of the VirtualMachineSnapshot
-          type: string
-        readyToUse:
-          type: boolean
-        snapshotVolumes:
-          description: SnapshotVolumesLists includes the list of volumes which were
-            included in the snapshot and volumes which were excluded from the snapshot
-          properties:
-            excludedVolumes:
-              items:
+            description: VirtualMachineRestoreGroupMember is the restore of a single
+              VM of a VirtualMachineRestoreGroup
+            properties:
+              virtualMachineName:
                 type: string
-              type: array
-              x-kubernetes-list-type: set
-            includedVolumes:
-              items:
+              virtualMachineRestoreName:
                 type: string
-              type: array
-              x-kubernetes-list-type: set
-          type: object
-        sourceUID:
-          description: UID is a type that holds unique ID values, including UUIDs.  Because
-            we don't ONLY use UUIDs, this is an alias to string.  Being a type captures
-            intent and helps make sure that UIDs and names do not get conflated.
-          type: string
-        virtualMachineSnapshotContentName:
-          type: string
+            required:
+            - virtualMachineName
+            - virtualMachineRestoreName
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
       type: object
   required:
   - spec
   type: object
 `,
-	"virtualmachinesnapshotcontent": `openAPIV3Schema:
-  description: VirtualMachineSnapshotContent contains the snapshot data
+	"virtualmachinesnapshot": `openAPIV3Schema:
+  description: VirtualMachineSnapshot defines the operation of snapshotting a VM
   properties:
     apiVersion:
       description: 'APIVersion defines the versioned schema of this representation
@@ -21901,10 +24599,256 @@ var CRDsValidation map[string]string = map[string]string{
     metadata:
       type: object
     spec:
-      description: VirtualMachineSnapshotContentSpec is the spec for a VirtualMachineSnapshotContent
+      description: VirtualMachineSnapshotSpec is the spec for a VirtualMachineSnapshot
         resource
       properties:
-        source:
+        deletionPolicy:
+          description: DeletionPolicy defines that to do with VirtualMachineSnapshot
+            when VirtualMachineSnapshot is deleted
+          type: string
+        excludedVolumes:
+          description: ExcludedVolumes are volumes of the source which are not snapshotted
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: set
+        failureDeadline:
+          description: This time represents the number of seconds we permit the vm
+            snapshot to take. In case we pass this deadline we mark this snapshot
+            as failed. Defaults to DefaultFailureDeadline - 5min
+          type: string
+        hooks:
+          description: SnapshotHooks are the commands executed in the guest during
+            an online snapshot
+          properties:
+            postThaw:
+              description: PostThaw hooks are executed in order after the guest filesystems
+                are thawed
+              items:
+                description: SnapshotHook is a command executed in the guest
+                properties:
+                  command:
+                    description: Command is the path of the executable in the guest
+                      followed by its arguments
+                    items:
+                      type: string
+                    type: array
+                    x-kubernetes-list-type: atomic
+                  failurePolicy:
+                    description: FailurePolicy defines what happens when the command
+                      fails, exits with a non-zero code or times out. Defaults to
+                      Abort.
+                    type: string
+                  name:
+                    description: Name of the hook, unique among the hooks of the same
+                      type
+                    type: string
+                  timeoutSeconds:
+                    description: TimeoutSeconds is how long to wait for the command
+                      to exit. Defaults to 30, at most 50.
+                    format: int32
+                    type: integer
+                required:
+                - name
+                - command
+                type: object
+              type: array
+              x-kubernetes-list-type: atomic
+            preFreeze:
+              description: PreFreeze hooks are executed in order before the guest
+                filesystems are frozen
+              items:
+                description: SnapshotHook is a command executed in the guest
+                properties:
+                  command:
+                    description: Command is the path of the executable in the guest
+                      followed by its arguments
+                    items:
+                      type: string
+                    type: array
+                    x-kubernetes-list-type: atomic
+                  failurePolicy:
+                    description: FailurePolicy defines what happens when the command
+                      fails, exits with a non-zero code or times out. Defaults to
+                      Abort.
+                    type: string
+                  name:
+                    description: Name of the hook, unique among the hooks of the same
+                      type
+                    type: string
+                  timeoutSeconds:
+                    description: TimeoutSeconds is how long to wait for the command
+                      to exit. Defaults to 30, at most 50.
+                    format: int32
+                    type: integer
+                required:
+                - name
+                - command
+                type: object
+              type: array
+              x-kubernetes-list-type: atomic
+          type: object
+        includedVolumes:
+          description: IncludedVolumes limits the snapshot to the listed volumes of
+            the source. All the volumes of the source are snapshotted when empty.
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: set
+        source:
+          description: TypedLocalObjectReference contains enough information to let
+            you locate the typed referenced object inside the same namespace.
+          properties:
+            apiGroup:
+              description: APIGroup is the group for the resource being referenced.
+                If APIGroup is not specified, the specified Kind must be in the core
+                API group. For any other third-party types, APIGroup is required.
+              type: string
+            kind:
+              description: Kind is the type of resource being referenced
+              type: string
+            name:
+              description: Name is the name of resource being referenced
+              type: string
+          required:
+          - kind
+          - name
+          type: object
+      required:
+      - source
+      type: object
+    status:
+      description: VirtualMachineSnapshotStatus is the status for a VirtualMachineSnapshot
+        resource
+      properties:
+        conditions:
+          items:
+            description: Condition defines conditions
+            properties:
+              lastProbeTime:
+                format: date-time
+                nullable: true
+                type: string
+              lastTransitionTime:
+                format: date-time
+                nullable: true
+                type: string
+              message:
+                type: string
+              reason:
+                type: string
+              status:
+                type: string
+              type:
+                description: ConditionType is the const type for Conditions
+                type: string
+            required:
+            - status
+            - type
+            type: object
+          type: array
+        creationTime:
+          format: date-time
+          nullable: true
+          type: string
+        error:
+          description: Error is the last error encountered during the snapshot/restore
+          properties:
+            message:
+              type: string
+            time:
+              format: date-time
+              type: string
+          type: object
+        hookResults:
+          items:
+            description: SnapshotHookResult is the outcome of a hook
+            properties:
+              completionTime:
+                format: date-time
+                nullable: true
+                type: string
+              error:
+                type: string
+              exitCode:
+                format: int32
+                type: integer
+              name:
+                type: string
+              output:
+                description: Output is the standard output of the command, truncated
+                  to 4KiB
+                type: string
+              succeeded:
+                type: boolean
+              type:
+                type: string
+            required:
+            - name
+            - type
+            - succeeded
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        indications:
+          items:
+            description: Indication is a way to indicate the state of the vm when
+              taking the snapshot
+            type: string
+          type: array
+          x-kubernetes-list-type: set
+        phase:
+          description: VirtualMachineSnapshotPhase is the current phase of the VirtualMachineSnapshot
+          type: string
+        readyToUse:
+          type: boolean
+        snapshotVolumes:
+          description: SnapshotVolumesLists includes the list of volumes which were
+            included in the snapshot and volumes which were excluded from the snapshot
+          properties:
+            excludedVolumes:
+              items:
+                type: string
+              type: array
+              x-kubernetes-list-type: set
+            includedVolumes:
+              items:
+                type: string
+              type: array
+              x-kubernetes-list-type: set
+          type: object
+        sourceUID:
+          description: UID is a type that holds unique ID values, including UUIDs.  Because
+            we don't ONLY use UUIDs, this is an alias to string.  Being a type captures
+            intent and helps make sure that UIDs and names do not get conflated.
+          type: string
+        virtualMachineSnapshotContentName:
+          type: string
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachinesnapshotcontent": `openAPIV3Schema:
+  description: VirtualMachineSnapshotContent contains the snapshot data
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation
+        of an object. Servers should convert recognized schemas to the latest internal
+        value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object
+        represents. Servers may infer this from the endpoint the client submits requests
+        to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineSnapshotContentSpec is the spec for a VirtualMachineSnapshotContent
+        resource
+      properties:
+        source:
           description: SourceSpec contains the appropriate spec for the resource being
             snapshotted
           properties:
@@ -24264,24 +27208,133 @@ var CRDsValidation map[string]string = map[string]string{
                                               bus:
                                                 description: 'Bus indicates the type
                                                   of disk device to emulate. supported
-                                                  values: virtio, sata, scsi, usb.'
+                                                  values: virtio, sata, scsi, usb,
+                                                  nvme.'
                                                 type: string
                                               pciAddress:
                                                 description: 'If specified, the virtual
                                                   disk will be placed on the guests
                                                   pci address with the specified PCI
-                                                  address. For example: 0000:81:01.10'
+                                                  address. For example: 0000:81:01.10
+                                                  For nvme disks the address is assigned
+                                                  to the disk''s NVMe controller.'
                                                 type: string
                                               readonly:
                                                 description: ReadOnly. Defaults to
                                                   false.
                                                 type: boolean
                                             type: object
+                                          encryption:
+                                            description: If specified, the disk image
+                                              is a qemu-native LUKS volume unlocked
+                                              with the passphrase of the referenced
+                                              Secret. A blank volume is only formatted
+                                              as LUKS volume on first boot if format
+                                              is set.
+                                            properties:
+                                              format:
+                                                description: Format allows formatting
+                                                  the volume as LUKS volume on first
+                                                  boot if it is blank, i.e. its first
+                                                  MiB only contains zeros. Without
+                                                  it, the volume has to be a LUKS
+                                                  volume already.
+                                                type: boolean
+                                              secretRef:
+                                                description: SecretRef references
+                                                  a Secret in the namespace of the
+                                                  VMI which holds the LUKS passphrase
+                                                  under the "passphrase" key.
+                                                properties:
+                                                  name:
+                                                    description: 'Name of the referent.
+                                                      More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                                      TODO: Add other useful fields.
+                                                      apiVersion, kind, uid?'
+                                                    type: string
+                                                type: object
+                                            required:
+                                            - secretRef
+                                            type: object
                                           io:
                                             description: 'IO specifies which QEMU
                                               disk IO mode should be used. Supported
                                               values are: native, default, threads.'
                                             type: string
+                                          ioTune:
+                                            description: DiskIOTune represents the
+                                              I/O throttling applied to a disk. A
+                                              value of zero, or an omitted value,
+                                              means no limit. Total limits cannot
+                                              be combined with the corresponding read
+                                              or write limits.
+                                            properties:
+                                              groupName:
+                                                description: Disks sharing a group
+                                                  name share their limits, the limits
+                                                  of the last disk in the group apply.
+                                                type: string
+                                              readBytesSec:
+                                                description: Read throughput limit
+                                                  in bytes per second.
+                                                format: int64
+                                                type: integer
+                                              readBytesSecMax:
+                                                description: Read throughput allowed
+                                                  in bytes per second during bursts.
+                                                format: int64
+                                                type: integer
+                                              readIOPSSec:
+                                                description: Read I/O operations per
+                                                  second limit.
+                                                format: int64
+                                                type: integer
+                                              readIOPSSecMax:
+                                                description: Read I/O operations per
+                                                  second allowed during bursts.
+                                                format: int64
+                                                type: integer
+                                              totalBytesSec:
+                                                description: Total throughput limit
+                                                  in bytes per second.
+                                                format: int64
+                                                type: integer
+                                              totalBytesSecMax:
+                                                description: Total throughput allowed
+                                                  in bytes per second during bursts.
+                                                format: int64
+                                                type: integer
+                                              totalIOPSSec:
+                                                description: Total I/O operations
+                                                  per second limit.
+                                                format: int64
+                                                type: integer
+                                              totalIOPSSecMax:
+                                                description: Total I/O operations
+                                                  per second allowed during bursts.
+                                                format: int64
+                                                type: integer
+                                              writeBytesSec:
+                                                description: Write throughput limit
+                                                  in bytes per second.
+                                                format: int64
+                                                type: integer
+                                              writeBytesSecMax:
+                                                description: Write throughput allowed
+                                                  in bytes per second during bursts.
+                                                format: int64
+                                                type: integer
+                                              writeIOPSSec:
+                                                description: Write I/O operations
+                                                  per second limit.
+                                                format: int64
+                                                type: integer
+                                              writeIOPSSecMax:
+                                                description: Write I/O operations
+                                                  per second allowed during bursts.
+                                                format: int64
+                                                type: integer
+                                            type: object
                                           lun:
                                             description: Attach a volume as a LUN
                                               to the vmi.
@@ -24308,7 +27361,9 @@ var CRDsValidation map[string]string = map[string]string{
                                           serial:
                                             description: Serial provides the ability
                                               to specify a serial number for the disk
-                                              device.
+                                              device. NVMe disks are limited to 20
+                                              characters and default to a serial derived
+                                              from the disk name.
                                             type: string
                                           shareable:
                                             description: If specified the disk is
@@ -24320,10 +27375,24 @@ var CRDsValidation map[string]string = map[string]string{
                                               and its tag will be provided to the
                                               guest via config drive metadata
                                             type: string
+                                          wwn:
+                                            description: WWN provides the ability
+                                              to specify a World Wide Name for the
+                                              disk device, as 16 hexadecimal digits.
+                                              Only supported on the scsi, sata and
+                                              nvme buses.
+                                            type: string
                                         required:
                                         - name
                                         type: object
                                       type: array
+                                    filesystemHotplug:
+                                      description: |-
+                                        FilesystemHotplug enables the ability to hotplug virtiofs filesystems.
+                                        It must be set before the VMI starts, on the VM template for filesystems persisted in the VM.
+                                        The guest memory is backed by shared memory, as required by virtiofs.
+                                        Defaults to false.
+                                      type: boolean
                                     filesystems:
                                       description: Filesystems describes filesystem
                                         which is connected to the vmi.
@@ -24517,6 +27586,127 @@ var CRDsValidation map[string]string = map[string]string{
                                             description: InterfaceMasquerade connects
                                               to a given network using netfilter rules
                                               to nat the traffic.
+                                            properties:
+                                              firewall:
+                                                description: Firewall restricts the
+                                                  traffic reaching and leaving the
+                                                  virtual machine through the interface.
+                                                properties:
+                                                  egress:
+                                                    description: Egress policy, applied
+                                                      to the traffic originating from
+                                                      the virtual machine.
+                                                    properties:
+                                                      defaultAction:
+                                                        description: DefaultAction
+                                                          is applied to the traffic
+                                                          no rule matched. Defaults
+                                                          to Allow.
+                                                        type: string
+                                                      rules:
+                                                        items:
+                                                          description: FirewallRule
+                                                            matches traffic by its
+                                                            remote peer, protocol
+                                                            and port. Unset fields
+                                                            match any traffic.
+                                                          properties:
+                                                            action:
+                                                              description: Action
+                                                                applied to the matching
+                                                                traffic.
+                                                              type: string
+                                                            ports:
+                                                              description: Ports or
+                                                                port ranges (e.g.
+                                                                8000-9000) the traffic
+                                                                is destined to. Only
+                                                                valid along with the
+                                                                TCP, UDP or SCTP protocols.
+                                                              items:
+                                                                type: string
+                                                              type: array
+                                                              x-kubernetes-list-type: atomic
+                                                            protocol:
+                                                              description: Protocol
+                                                                of the traffic. Must
+                                                                be TCP, UDP, SCTP
+                                                                or ICMP.
+                                                              type: string
+                                                            remoteCIDRs:
+                                                              description: RemoteCIDRs
+                                                                match the remote peer,
+                                                                the source of ingress
+                                                                traffic and the destination
+                                                                of egress traffic.
+                                                              items:
+                                                                type: string
+                                                              type: array
+                                                              x-kubernetes-list-type: atomic
+                                                          required:
+                                                          - action
+                                                          type: object
+                                                        type: array
+                                                        x-kubernetes-list-type: atomic
+                                                    type: object
+                                                  ingress:
+                                                    description: Ingress policy, applied
+                                                      to the traffic towards the virtual
+                                                      machine.
+                                                    properties:
+                                                      defaultAction:
+                                                        description: DefaultAction
+                                                          is applied to the traffic
+                                                          no rule matched. Defaults
+                                                          to Allow.
+                                                        type: string
+                                                      rules:
+                                                        items:
+                                                          description: FirewallRule
+                                                            matches traffic by its
+                                                            remote peer, protocol
+                                                            and port. Unset fields
+                                                            match any traffic.
+                                                          properties:
+                                                            action:
+                                                              description: Action
+                                                                applied to the matching
+                                                                traffic.
+                                                              type: string
+                                                            ports:
+                                                              description: Ports or
+                                                                port ranges (e.g.
+                                                                8000-9000) the traffic
+                                                                is destined to. Only
+                                                                valid along with the
+                                                                TCP, UDP or SCTP protocols.
+                                                              items:
+                                                                type: string
+                                                              type: array
+                                                              x-kubernetes-list-type: atomic
+                                                            protocol:
+                                                              description: Protocol
+                                                                of the traffic. Must
+                                                                be TCP, UDP, SCTP
+                                                                or ICMP.
+                                                              type: string
+                                                            remoteCIDRs:
+                                                              description: RemoteCIDRs
+                                                                match the remote peer,
+                                                                the source of ingress
+                                                                traffic and the destination
+                                                                of egress traffic.
+                                                              items:
+                                                                type: string
+                                                              type: array
+                                                              x-kubernetes-list-type: atomic
+                                                          required:
+                                                          - action
+                                                          type: object
+                                                        type: array
+                                                        x-kubernetes-list-type: atomic
+                                                    type: object
+                                                type: object
                                             type: object
                                           model:
                                             description: 'Interface model. One of:
@@ -24573,6 +27763,44 @@ var CRDsValidation map[string]string = map[string]string{
                                               - port
                                               type: object
                                             type: array
+                                          routerAdvertisement:
+                                            description: If specified, enables and
+                                              tunes the IPv6 router advertisements
+                                              sent to the guest. Router advertisements
+                                              are sent on masquerade interfaces setting
+                                              it, once the pod has IPv6 connectivity,
+                                              passt interfaces accept the DNS servers
+                                              only.
+                                            properties:
+                                              dnsServers:
+                                                description: DNSServers are the IPv6
+                                                  addresses advertised as recursive
+                                                  DNS servers (RDNSS).
+                                                items:
+                                                  type: string
+                                                type: array
+                                                x-kubernetes-list-type: atomic
+                                              managedAddressConfiguration:
+                                                description: ManagedAddressConfiguration
+                                                  sets the managed (M) flag, telling
+                                                  the guest to obtain its address
+                                                  through DHCPv6. Defaults to true.
+                                                type: boolean
+                                              otherConfiguration:
+                                                description: OtherConfiguration sets
+                                                  the other configuration (O) flag,
+                                                  telling the guest to obtain further
+                                                  configuration through DHCPv6.
+                                                type: boolean
+                                              prefix:
+                                                description: Prefix advertised as
+                                                  on-link, in CIDR notation. Guests
+                                                  autoconfigure an address (SLAAC)
+                                                  only from a /64 prefix. Defaults
+                                                  to the IPv6 CIDR of the masquerade
+                                                  network.
+                                                type: string
+                                            type: object
                                           slirp:
                                             description: InterfaceSlirp connects to
                                               a given network using QEMU user networking
@@ -24595,6 +27823,12 @@ var CRDsValidation map[string]string = map[string]string{
                                               will be provided to the guest via config
                                               drive
                                             type: string
+                                          vhostUser:
+                                            description: InterfaceVhostUser connects
+                                              to a given network through a vhost-user
+                                              socket served by a userspace datapath,
+                                              such as OVS-DPDK.
+                                            type: object
                                         required:
                                         - name
                                         type: object
@@ -24923,6 +28157,14 @@ var CRDsValidation map[string]string = map[string]string{
                                           description: If set, EFI will be used instead
                                             of BIOS.
                                           properties:
+                                            persistent:
+                                              description: If set to true, the EFI
+                                                NVRAM variables are kept in the backend
+                                                storage of the VM, so that enrolled
+                                                Secure Boot keys and boot entries
+                                                survive restarts. Requires the VMPersistentState
+                                                feature gate. Defaults to false
+                                              type: boolean
                                             secureBoot:
                                               description: If set, SecureBoot will
                                                 be enabled and the OVMF roms will
@@ -25098,6 +28340,24 @@ var CRDsValidation map[string]string = map[string]string{
                                 if the VirtualMachineInstance should be migrated instead
                                 of shut-off in case of a node drain.
                               type: string
+                            filesystemTrim:
+                              description: FilesystemTrim periodically trims the guest
+                                filesystems through the qemu guest agent, which returns
+                                their unused blocks to thin-provisioned storage.
+                              properties:
+                                discardPreallocated:
+                                  description: DiscardPreallocated passes the disks
+                                    of preallocated or thick-provisioned volumes with
+                                    discard=unmap as well, so that the trims reach
+                                    their storage. By default these disks are passed
+                                    with discard=ignore.
+                                  type: boolean
+                                interval:
+                                  description: Interval between two trims of the guest
+                                    filesystems, it must be at least 1h. Defaults
+                                    to 24h.
+                                  type: string
+                              type: object
                             hostname:
                               description: Specifies the hostname of the vmi If not
                                 specified, the hostname will be set to the name of
@@ -25813,6 +29073,10 @@ var CRDsValidation map[string]string = map[string]string{
                                       to a ConfigMap in the same namespace. More info:
                                       https://kubernetes.io/docs/tasks/configure-pod-container/configure-pod-configmap/'
                                     properties:
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
                                       name:
                                         description: 'Name of the referent. More info:
                                           https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
@@ -25836,6 +29100,10 @@ var CRDsValidation map[string]string = map[string]string{
                                       image, embedding a qcow or raw disk. More info:
                                       https://kubevirt.gitbooks.io/user-guide/registry-disk.html'
                                     properties:
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
                                       image:
                                         description: Image is the name of the image
                                           with the embedded disk.
@@ -26044,15 +29312,54 @@ var CRDsValidation map[string]string = map[string]string{
                                     - path
                                     - type
                                     type: object
-                                  memoryDump:
-                                    description: MemoryDump is attached to the virt
-                                      launcher and is populated with a memory dump
-                                      of the vmi
+                                  iscsi:
+                                    description: ISCSI represents a LUN of an iSCSI
+                                      target, which qemu connects to directly.
                                     properties:
-                                      claimName:
-                                        description: 'claimName is the name of a PersistentVolumeClaim
-                                          in the same namespace as the pod using this
-                                          volume. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                                      chapSecretRef:
+                                        description: CHAPSecretRef references a Secret
+                                          in the namespace of the VMI which holds
+                                          the CHAP credentials under the "node.session.auth.username"
+                                          and "node.session.auth.password" keys, as
+                                          the Secrets of type kubernetes.io/iscsi-chap.
+                                        properties:
+                                          name:
+                                            description: 'Name of the referent. More
+                                              info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
+                                            type: string
+                                        type: object
+                                      initiatorName:
+                                        description: InitiatorName is the iSCSI qualified
+                                          name of the initiator. Defaults to the initiator
+                                          name of qemu.
+                                        type: string
+                                      iqn:
+                                        description: IQN is the iSCSI qualified name
+                                          of the target.
+                                        type: string
+                                      lun:
+                                        description: Lun is the number of the LUN
+                                          on the target. Defaults to 0.
+                                        format: int32
+                                        type: integer
+                                      targetPortal:
+                                        description: TargetPortal is the address of
+                                          the target, host or host:port. The port
+                                          defaults to 3260.
+                                        type: string
+                                    required:
+                                    - targetPortal
+                                    - iqn
+                                    type: object
+                                  memoryDump:
+                                    description: MemoryDump is attached to the virt
+                                      launcher and is populated with a memory dump
+                                      of the vmi
+                                    properties:
+                                      claimName:
+                                        description: 'claimName is the name of a PersistentVolumeClaim
+                                          in the same namespace as the pod using this
+                                          volume. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
                                         type: string
                                       hotpluggable:
                                         description: Hotpluggable indicates whether
@@ -26069,6 +29376,39 @@ var CRDsValidation map[string]string = map[string]string{
                                     description: 'Volume''s name. Must be a DNS_LABEL
                                       and unique within the vmi. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
                                     type: string
+                                  nbd:
+                                    description: NBD represents an export of a network
+                                      block device server, which qemu connects to
+                                      directly.
+                                    properties:
+                                      uri:
+                                        description: URI of the export, in the form
+                                          nbd://host[:port][/export]. The port defaults
+                                          to 10809.
+                                        type: string
+                                    required:
+                                    - uri
+                                    type: object
+                                  overlay:
+                                    description: Overlay is a persistent copy-on-write
+                                      image on a PVC, backed by a shared read-only
+                                      base image on another PVC.
+                                    properties:
+                                      baseClaimName:
+                                        description: BaseClaimName is the name of
+                                          a PVC in the same namespace which holds
+                                          the raw base image. It is attached read-only
+                                          and can be shared by many vmis.
+                                        type: string
+                                      claimName:
+                                        description: ClaimName is the name of a filesystem
+                                          PVC in the same namespace which stores the
+                                          qcow2 overlay.
+                                        type: string
+                                    required:
+                                    - claimName
+                                    - baseClaimName
+                                    type: object
                                   persistentVolumeClaim:
                                     description: 'PersistentVolumeClaimVolumeSource
                                       represents a reference to a PersistentVolumeClaim
@@ -26091,11 +29431,54 @@ var CRDsValidation map[string]string = map[string]string{
                                     required:
                                     - claimName
                                     type: object
+                                  rbd:
+                                    description: RBD represents an image of a Ceph
+                                      cluster, which qemu connects to directly.
+                                    properties:
+                                      image:
+                                        description: Image is the name of the image.
+                                        type: string
+                                      keyringSecretRef:
+                                        description: KeyringSecretRef references a
+                                          Secret in the namespace of the VMI which
+                                          holds the cephx key of the user under the
+                                          "key" key, as the Secrets of type kubernetes.io/rbd.
+                                          Without it, cephx authentication is disabled.
+                                        properties:
+                                          name:
+                                            description: 'Name of the referent. More
+                                              info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
+                                            type: string
+                                        type: object
+                                      monitors:
+                                        description: Monitors are the addresses of
+                                          the Ceph monitors, host or host:port. The
+                                          port defaults to 6789.
+                                        items:
+                                          type: string
+                                        type: array
+                                        x-kubernetes-list-type: atomic
+                                      pool:
+                                        description: Pool is the name of the pool
+                                          holding the image. Defaults to rbd.
+                                        type: string
+                                      user:
+                                        description: User is the Ceph user to authenticate
+                                          as. Defaults to admin.
+                                        type: string
+                                    required:
+                                    - monitors
+                                    - image
+                                    type: object
                                   secret:
                                     description: 'SecretVolumeSource represents a
                                       reference to a secret data in the same namespace.
                                       More info: https://kubernetes.io/docs/concepts/configuration/secret/'
                                     properties:
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
                                       optional:
                                         description: Specify whether the Secret or
                                           it's keys must be defined
@@ -26118,6 +29501,10 @@ var CRDsValidation map[string]string = map[string]string{
                                       only be one volume of this type! More info:
                                       https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
                                     properties:
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
                                       serviceAccountName:
                                         description: 'Name of the service account
                                           in the pod''s namespace to use. More info:
@@ -26358,6 +29745,179 @@ var CRDsValidation map[string]string = map[string]string{
                         VMI.
                       items:
                         properties:
+                          addFilesystemOptions:
+                            description: AddFilesystemOptions when set indicates
+                              a virtiofs filesystem should be added. The details
+                              within this field specify how to add the
+                              filesystem
+                            properties:
+                              dryRun:
+                                description: 'When present, indicates that modifications
+                                  should not be persisted. An invalid or unrecognized
+                                  dryRun directive will result in an error response
+                                  and no further processing of the request. Valid
+                                  values are: - All: all dry run stages will be processed'
+                                items:
+                                  type: string
+                                type: array
+                                x-kubernetes-list-type: atomic
+                              name:
+                                description: Name represents the name that will
+                                  be used to map the filesystem to the
+                                  corresponding volume. It is also used as the
+                                  mount tag inside the guest.
+                                type: string
+                              volumeSource:
+                                description: VolumeSource represents the source
+                                  of the volume shared with the guest.
+                                properties:
+                                  configMap:
+                                    description: ConfigMapSource represents a reference
+                                      to a ConfigMap in the same namespace. Hotplugged
+                                      ConfigMaps are attached as read-only iso images.
+                                    properties:
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
+                                      name:
+                                        description: 'Name of the referent. More info:
+                                          https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
+                                        type: string
+                                      optional:
+                                        description: Specify whether the ConfigMap
+                                          or it's keys must be defined
+                                        type: boolean
+                                      volumeLabel:
+                                        description: The volume label of the resulting
+                                          disk inside the VMI. Different bootstrapping
+                                          mechanisms require different values. Typical
+                                          values are "cidata" (cloud-init), "config-2"
+                                          (cloud-init) or "OEMDRV" (kickstart).
+                                        type: string
+                                    type: object
+                                  containerDisk:
+                                    description: ContainerDisk references a docker
+                                      image, embedding a qcow or raw disk. Hotplugged
+                                      container disks are attached read-only.
+                                    properties:
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
+                                      image:
+                                        description: Image is the name of the image
+                                          with the embedded disk.
+                                        type: string
+                                      imagePullPolicy:
+                                        description: 'Image pull policy. One of Always,
+                                          Never, IfNotPresent. Defaults to Always
+                                          if :latest tag is specified, or IfNotPresent
+                                          otherwise. Cannot be updated. More info:
+                                          https://kubernetes.io/docs/concepts/containers/images#updating-images'
+                                        enum:
+                                        - Always
+                                        - IfNotPresent
+                                        - Never
+                                        type: string
+                                      imagePullSecret:
+                                        description: ImagePullSecret is the name of
+                                          the Docker registry secret required to pull
+                                          the image. The secret must already exist.
+                                        type: string
+                                      path:
+                                        description: Path defines the path to disk
+                                          file in the container
+                                        type: string
+                                    required:
+                                    - image
+                                    type: object
+                                  dataVolume:
+                                    description: DataVolume represents the dynamic
+                                      creation a PVC for this volume as well as the
+                                      process of populating that PVC with a disk image.
+                                    properties:
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
+                                      name:
+                                        description: Name of both the DataVolume and
+                                          the PVC in the same namespace. After PVC
+                                          population the DataVolume is garbage collected
+                                          by default.
+                                        type: string
+                                    required:
+                                    - name
+                                    type: object
+                                  persistentVolumeClaim:
+                                    description: 'PersistentVolumeClaimVolumeSource
+                                      represents a reference to a PersistentVolumeClaim
+                                      in the same namespace. Directly attached to
+                                      the vmi via qemu. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                                    properties:
+                                      claimName:
+                                        description: 'claimName is the name of a PersistentVolumeClaim
+                                          in the same namespace as the pod using this
+                                          volume. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                                        type: string
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
+                                      readOnly:
+                                        description: readOnly Will force the ReadOnly
+                                          setting in VolumeMounts. Default false.
+                                        type: boolean
+                                    required:
+                                    - claimName
+                                    type: object
+                                  secret:
+                                    description: SecretVolumeSource represents a reference
+                                      to a secret data in the same namespace. Hotplugged
+                                      Secrets are attached as read-only iso images.
+                                    properties:
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
+                                      optional:
+                                        description: Specify whether the Secret or
+                                          it's keys must be defined
+                                        type: boolean
+                                      secretName:
+                                        description: 'Name of the secret in the pod''s
+                                          namespace to use. More info: https://kubernetes.io/docs/concepts/storage/volumes#secret'
+                                        type: string
+                                      volumeLabel:
+                                        description: The volume label of the resulting
+                                          disk inside the VMI. Different bootstrapping
+                                          mechanisms require different values. Typical
+                                          values are "cidata" (cloud-init), "config-2"
+                                          (cloud-init) or "OEMDRV" (kickstart).
+                                        type: string
+                                    type: object
+                                  serviceAccount:
+                                    description: ServiceAccountVolumeSource represents
+                                      a reference to a service account. Hotplugged
+                                      ServiceAccounts are attached as read-only iso
+                                      images.
+                                    properties:
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
+                                      serviceAccountName:
+                                        description: 'Name of the service account
+                                          in the pod''s namespace to use. More info:
+                                          https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
+                                        type: string
+                                    type: object
+                                type: object
+                            required:
+                            - name
+                            - volumeSource
+                            type: object
                           addVolumeOptions:
                             description: AddVolumeOptions when set indicates a volume
                               should be added. The details within this field specify
@@ -26439,23 +29999,119 @@ var CRDsValidation map[string]string = map[string]string{
                                       bus:
                                         description: 'Bus indicates the type of disk
                                           device to emulate. supported values: virtio,
-                                          sata, scsi, usb.'
+                                          sata, scsi, usb, nvme.'
                                         type: string
                                       pciAddress:
                                         description: 'If specified, the virtual disk
                                           will be placed on the guests pci address
                                           with the specified PCI address. For example:
-                                          0000:81:01.10'
+                                          0000:81:01.10 For nvme disks the address
+                                          is assigned to the disk''s NVMe controller.'
                                         type: string
                                       readonly:
                                         description: ReadOnly. Defaults to false.
                                         type: boolean
                                     type: object
+                                  encryption:
+                                    description: If specified, the disk image is a
+                                      qemu-native LUKS volume unlocked with the passphrase
+                                      of the referenced Secret. A blank volume is
+                                      formatted as LUKS volume on first boot.
+                                    properties:
+                                      secretRef:
+                                        description: SecretRef references a Secret
+                                          in the namespace of the VMI which holds
+                                          the LUKS passphrase under the "passphrase"
+                                          key.
+                                        properties:
+                                          name:
+                                            description: 'Name of the referent. More
+                                              info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                              TODO: Add other useful fields. apiVersion,
+                                              kind, uid?'
+                                            type: string
+                                        type: object
+                                    required:
+                                    - secretRef
+                                    type: object
                                   io:
                                     description: 'IO specifies which QEMU disk IO
                                       mode should be used. Supported values are: native,
                                       default, threads.'
                                     type: string
+                                  ioTune:
+                                    description: DiskIOTune represents the I/O throttling
+                                      applied to a disk. A value of zero, or an omitted
+                                      value, means no limit. Total limits cannot be
+                                      combined with the corresponding read or write
+                                      limits.
+                                    properties:
+                                      groupName:
+                                        description: Disks sharing a group name share
+                                          their limits, the limits of the last disk
+                                          in the group apply.
+                                        type: string
+                                      readBytesSec:
+                                        description: Read throughput limit in bytes
+                                          per second.
+                                        format: int64
+                                        type: integer
+                                      readBytesSecMax:
+                                        description: Read throughput allowed in bytes
+                                          per second during bursts.
+                                        format: int64
+                                        type: integer
+                                      readIOPSSec:
+                                        description: Read I/O operations per second
+                                          limit.
+                                        format: int64
+                                        type: integer
+                                      readIOPSSecMax:
+                                        description: Read I/O operations per second
+                                          allowed during bursts.
+                                        format: int64
+                                        type: integer
+                                      totalBytesSec:
+                                        description: Total throughput limit in bytes
+                                          per second.
+                                        format: int64
+                                        type: integer
+                                      totalBytesSecMax:
+                                        description: Total throughput allowed in bytes
+                                          per second during bursts.
+                                        format: int64
+                                        type: integer
+                                      totalIOPSSec:
+                                        description: Total I/O operations per second
+                                          limit.
+                                        format: int64
+                                        type: integer
+                                      totalIOPSSecMax:
+                                        description: Total I/O operations per second
+                                          allowed during bursts.
+                                        format: int64
+                                        type: integer
+                                      writeBytesSec:
+                                        description: Write throughput limit in bytes
+                                          per second.
+                                        format: int64
+                                        type: integer
+                                      writeBytesSecMax:
+                                        description: Write throughput allowed in bytes
+                                          per second during bursts.
+                                        format: int64
+                                        type: integer
+                                      writeIOPSSec:
+                                        description: Write I/O operations per second
+                                          limit.
+                                        format: int64
+                                        type: integer
+                                      writeIOPSSecMax:
+                                        description: Write I/O operations per second
+                                          allowed during bursts.
+                                        format: int64
+                                        type: integer
+                                    type: object
                                   lun:
                                     description: Attach a volume as a LUN to the vmi.
                                     properties:
@@ -26478,7 +30134,9 @@ var CRDsValidation map[string]string = map[string]string{
                                     type: string
                                   serial:
                                     description: Serial provides the ability to specify
-                                      a serial number for the disk device.
+                                      a serial number for the disk device. NVMe disks
+                                      are limited to 20 characters and default to
+                                      a serial derived from the disk name.
                                     type: string
                                   shareable:
                                     description: If specified the disk is made sharable
@@ -26489,6 +30147,12 @@ var CRDsValidation map[string]string = map[string]string{
                                       tag will be provided to the guest via config
                                       drive metadata
                                     type: string
+                                  wwn:
+                                    description: WWN provides the ability to specify
+                                      a World Wide Name for the disk device, as 16
+                                      hexadecimal digits. Only supported on the scsi,
+                                      sata and nvme buses.
+                                    type: string
                                 required:
                                 - name
                                 type: object
@@ -26512,6 +30176,261 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: VolumeSource represents the source of
                                   the volume to map to the disk.
                                 properties:
+                                  configMap:
+                                    description: ConfigMapSource represents a reference
+                                      to a ConfigMap in the same namespace. Hotplugged
+                                      ConfigMaps are attached as read-only iso images.
+                                    properties:
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
+                                      name:
+                                        description: 'Name of the referent. More info:
+                                          https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
+                                        type: string
+                                      optional:
+                                        description: Specify whether the ConfigMap
+                                          or it's keys must be defined
+                                        type: boolean
+                                      volumeLabel:
+                                        description: The volume label of the resulting
+                                          disk inside the VMI. Different bootstrapping
+                                          mechanisms require different values. Typical
+                                          values are "cidata" (cloud-init), "config-2"
+                                          (cloud-init) or "OEMDRV" (kickstart).
+                                        type: string
+                                    type: object
+                                  containerDisk:
+                                    description: ContainerDisk references a docker
+                                      image, embedding a qcow or raw disk. Hotplugged
+                                      container disks are attached read-only.
+                                    properties:
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
+                                      image:
+                                        description: Image is the name of the image
+                                          with the embedded disk.
+                                        type: string
+                                      imagePullPolicy:
+                                        description: 'Image pull policy. One of Always,
+                                          Never, IfNotPresent. Defaults to Always
+                                          if :latest tag is specified, or IfNotPresent
+                                          otherwise. Cannot be updated. More info:
+                                          https://kubernetes.io/docs/concepts/containers/images#updating-images'
+                                        enum:
+                                        - Always
+                                        - IfNotPresent
+                                        - Never
+                                        type: string
+                                      imagePullSecret:
+                                        description: ImagePullSecret is the name of
+                                          the Docker registry secret required to pull
+                                          the image. The secret must already exist.
+                                        type: string
+                                      path:
+                                        description: Path defines the path to disk
+                                          file in the container
+                                        type: string
+                                    required:
+                                    - image
+                                    type: object
+                                  dataVolume:
+                                    description: DataVolume represents the dynamic
+                                      creation a PVC for this volume as well as the
+                                      process of populating that PVC with a disk image.
+                                    properties:
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
+                                      name:
+                                        description: Name of both the DataVolume and
+                                          the PVC in the same namespace. After PVC
+                                          population the DataVolume is garbage collected
+                                          by default.
+                                        type: string
+                                    required:
+                                    - name
+                                    type: object
+                                  persistentVolumeClaim:
+                                    description: 'PersistentVolumeClaimVolumeSource
+                                      represents a reference to a PersistentVolumeClaim
+                                      in the same namespace. Directly attached to
+                                      the vmi via qemu. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                                    properties:
+                                      claimName:
+                                        description: 'claimName is the name of a PersistentVolumeClaim
+                                          in the same namespace as the pod using this
+                                          volume. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                                        type: string
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
+                                      readOnly:
+                                        description: readOnly Will force the ReadOnly
+                                          setting in VolumeMounts. Default false.
+                                        type: boolean
+                                    required:
+                                    - claimName
+                                    type: object
+                                  secret:
+                                    description: SecretVolumeSource represents a reference
+                                      to a secret data in the same namespace. Hotplugged
+                                      Secrets are attached as read-only iso images.
+                                    properties:
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
+                                      optional:
+                                        description: Specify whether the Secret or
+                                          it's keys must be defined
+                                        type: boolean
+                                      secretName:
+                                        description: 'Name of the secret in the pod''s
+                                          namespace to use. More info: https://kubernetes.io/docs/concepts/storage/volumes#secret'
+                                        type: string
+                                      volumeLabel:
+                                        description: The volume label of the resulting
+                                          disk inside the VMI. Different bootstrapping
+                                          mechanisms require different values. Typical
+                                          values are "cidata" (cloud-init), "config-2"
+                                          (cloud-init) or "OEMDRV" (kickstart).
+                                        type: string
+                                    type: object
+                                  serviceAccount:
+                                    description: ServiceAccountVolumeSource represents
+                                      a reference to a service account. Hotplugged
+                                      ServiceAccounts are attached as read-only iso
+                                      images.
+                                    properties:
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
+                                      serviceAccountName:
+                                        description: 'Name of the service account
+                                          in the pod''s namespace to use. More info:
+                                          https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
+                                        type: string
+                                    type: object
+                                type: object
+                            required:
+                            - disk
+                            - name
+                            - volumeSource
+                            type: object
+                          ejectMediaOptions:
+                            description: EjectMediaOptions when set indicates the
+                              media of a cdrom should be ejected. The details within
+                              this field specify which cdrom to eject
+                            properties:
+                              dryRun:
+                                description: 'When present, indicates that modifications
+                                  should not be persisted. An invalid or unrecognized
+                                  dryRun directive will result in an error response
+                                  and no further processing of the request. Valid
+                                  values are: - All: all dry run stages will be processed'
+                                items:
+                                  type: string
+                                type: array
+                                x-kubernetes-list-type: atomic
+                              name:
+                                description: Name represents the name of the cdrom
+                                  disk whose media is ejected
+                                type: string
+                            required:
+                            - name
+                            type: object
+                          insertMediaOptions:
+                            description: InsertMediaOptions when set indicates a media
+                              should be inserted into an empty cdrom. The details
+                              within this field specify which media to insert
+                            properties:
+                              dryRun:
+                                description: 'When present, indicates that modifications
+                                  should not be persisted. An invalid or unrecognized
+                                  dryRun directive will result in an error response
+                                  and no further processing of the request. Valid
+                                  values are: - All: all dry run stages will be processed'
+                                items:
+                                  type: string
+                                type: array
+                                x-kubernetes-list-type: atomic
+                              name:
+                                description: Name represents the name of the cdrom
+                                  disk the media is inserted into. The volume backing
+                                  the media is given the same name.
+                                type: string
+                              volumeSource:
+                                description: VolumeSource represents the source of
+                                  the media.
+                                properties:
+                                  configMap:
+                                    description: ConfigMapSource represents a reference
+                                      to a ConfigMap in the same namespace. Hotplugged
+                                      ConfigMaps are attached as read-only iso images.
+                                    properties:
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
+                                      name:
+                                        description: 'Name of the referent. More info:
+                                          https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
+                                        type: string
+                                      optional:
+                                        description: Specify whether the ConfigMap
+                                          or it's keys must be defined
+                                        type: boolean
+                                      volumeLabel:
+                                        description: The volume label of the resulting
+                                          disk inside the VMI. Different bootstrapping
+                                          mechanisms require different values. Typical
+                                          values are "cidata" (cloud-init), "config-2"
+                                          (cloud-init) or "OEMDRV" (kickstart).
+                                        type: string
+                                    type: object
+                                  containerDisk:
+                                    description: ContainerDisk references a docker
+                                      image, embedding a qcow or raw disk. Hotplugged
+                                      container disks are attached read-only.
+                                    properties:
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
+                                      image:
+                                        description: Image is the name of the image
+                                          with the embedded disk.
+                                        type: string
+                                      imagePullPolicy:
+                                        description: 'Image pull policy. One of Always,
+                                          Never, IfNotPresent. Defaults to Always
+                                          if :latest tag is specified, or IfNotPresent
+                                          otherwise. Cannot be updated. More info:
+                                          https://kubernetes.io/docs/concepts/containers/images#updating-images'
+                                        enum:
+                                        - Always
+                                        - IfNotPresent
+                                        - Never
+                                        type: string
+                                      imagePullSecret:
+                                        description: ImagePullSecret is the name of
+                                          the Docker registry secret required to pull
+                                          the image. The secret must already exist.
+                                        type: string
+                                      path:
+                                        description: Path defines the path to disk
+                                          file in the container
+                                        type: string
+                                    required:
+                                    - image
+                                    type: object
                                   dataVolume:
                                     description: DataVolume represents the dynamic
                                       creation a PVC for this volume as well as the
@@ -26527,37 +30446,101 @@ var CRDsValidation map[string]string = map[string]string{
                                           population the DataVolume is garbage collected
                                           by default.
                                         type: string
-                                    required:
-                                    - name
+                                    required:
+                                    - name
+                                    type: object
+                                  persistentVolumeClaim:
+                                    description: 'PersistentVolumeClaimVolumeSource
+                                      represents a reference to a PersistentVolumeClaim
+                                      in the same namespace. Directly attached to
+                                      the vmi via qemu. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                                    properties:
+                                      claimName:
+                                        description: 'claimName is the name of a PersistentVolumeClaim
+                                          in the same namespace as the pod using this
+                                          volume. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                                        type: string
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
+                                      readOnly:
+                                        description: readOnly Will force the ReadOnly
+                                          setting in VolumeMounts. Default false.
+                                        type: boolean
+                                    required:
+                                    - claimName
+                                    type: object
+                                  secret:
+                                    description: SecretVolumeSource represents a reference
+                                      to a secret data in the same namespace. Hotplugged
+                                      Secrets are attached as read-only iso images.
+                                    properties:
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
+                                      optional:
+                                        description: Specify whether the Secret or
+                                          it's keys must be defined
+                                        type: boolean
+                                      secretName:
+                                        description: 'Name of the secret in the pod''s
+                                          namespace to use. More info: https://kubernetes.io/docs/concepts/storage/volumes#secret'
+                                        type: string
+                                      volumeLabel:
+                                        description: The volume label of the resulting
+                                          disk inside the VMI. Different bootstrapping
+                                          mechanisms require different values. Typical
+                                          values are "cidata" (cloud-init), "config-2"
+                                          (cloud-init) or "OEMDRV" (kickstart).
+                                        type: string
                                     type: object
-                                  persistentVolumeClaim:
-                                    description: 'PersistentVolumeClaimVolumeSource
-                                      represents a reference to a PersistentVolumeClaim
-                                      in the same namespace. Directly attached to
-                                      the vmi via qemu. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                                  serviceAccount:
+                                    description: ServiceAccountVolumeSource represents
+                                      a reference to a service account. Hotplugged
+                                      ServiceAccounts are attached as read-only iso
+                                      images.
                                     properties:
-                                      claimName:
-                                        description: 'claimName is the name of a PersistentVolumeClaim
-                                          in the same namespace as the pod using this
-                                          volume. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
-                                        type: string
                                       hotpluggable:
                                         description: Hotpluggable indicates whether
                                           the volume can be hotplugged and hotunplugged.
                                         type: boolean
-                                      readOnly:
-                                        description: readOnly Will force the ReadOnly
-                                          setting in VolumeMounts. Default false.
-                                        type: boolean
-                                    required:
-                                    - claimName
+                                      serviceAccountName:
+                                        description: 'Name of the service account
+                                          in the pod''s namespace to use. More info:
+                                          https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
+                                        type: string
                                     type: object
                                 type: object
                             required:
-                            - disk
                             - name
                             - volumeSource
                             type: object
+                          removeFilesystemOptions:
+                            description: RemoveFilesystemOptions when set
+                              indicates a virtiofs filesystem should be removed.
+                              The details within this field specify which
+                              filesystem to remove
+                            properties:
+                              dryRun:
+                                description: 'When present, indicates that modifications
+                                  should not be persisted. An invalid or unrecognized
+                                  dryRun directive will result in an error response
+                                  and no further processing of the request. Valid
+                                  values are: - All: all dry run stages will be processed'
+                                items:
+                                  type: string
+                                type: array
+                                x-kubernetes-list-type: atomic
+                              name:
+                                description: Name represents the name that maps
+                                  to both the filesystem and volume that should
+                                  be removed
+                                type: string
+                            required:
+                            - name
+                            type: object
                           removeVolumeOptions:
                             description: RemoveVolumeOptions when set indicates a
                               volume should be removed. The details within this field
@@ -26886,5 +30869,253 @@ var CRDsValidation map[string]string = map[string]string{
   required:
   - spec
   type: object
+`,
+	"virtualmachinesnapshotgroup": `openAPIV3Schema:
+  description: VirtualMachineSnapshotGroup defines the operation of snapshotting several
+    VMs at the same point in time
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation
+        of an object. Servers should convert recognized schemas to the latest internal
+        value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object
+        represents. Servers may infer this from the endpoint the client submits requests
+        to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineSnapshotGroupSpec is the spec for a VirtualMachineSnapshotGroup
+        resource
+      properties:
+        deletionPolicy:
+          type: string
+        failureDeadline:
+          description: This time represents the number of seconds we permit freezing
+            all VMs of the group and taking all their snapshots. The deadline is shared
+            by the VirtualMachineSnapshots of the group, in case we pass it we mark
+            the group as failed. Defaults to DefaultFailureDeadline - 5min
+          type: string
+        selector:
+          description: Selector selects the VirtualMachines in the namespace of the
+            group. The selected VMs are frozen together and all their volumes are
+            snapshotted before any of them is thawed again.
+          properties:
+            matchExpressions:
+              description: matchExpressions is a list of label selector requirements.
+                The requirements are ANDed.
+              items:
+                description: A label selector requirement is a selector that contains
+                  values, a key, and an operator that relates the key and values.
+                properties:
+                  key:
+                    description: key is the label key that the selector applies to.
+                    type: string
+                  operator:
+                    description: operator represents a key's relationship to a set
+                      of values. Valid operators are In, NotIn, Exists and DoesNotExist.
+                    type: string
+                  values:
+                    description: values is an array of string values. If the operator
+                      is In or NotIn, the values array must be non-empty. If the operator
+                      is Exists or DoesNotExist, the values array must be empty. This
+                      array is replaced during a strategic merge patch.
+                    items:
+                      type: string
+                    type: array
+                required:
+                - key
+                - operator
+                type: object
+              type: array
+            matchLabels:
+              additionalProperties:
+                type: string
+              description: matchLabels is a map of {key,value} pairs. A single {key,value}
+                in the matchLabels map is equivalent to an element of matchExpressions,
+                whose key field is "key", the operator is "In", and the values array
+                contains only "value". The requirements are ANDed.
+              type: object
+          type: object
+      required:
+      - selector
+      type: object
+    status:
+      description: VirtualMachineSnapshotGroupStatus is the status for a VirtualMachineSnapshotGroup
+        resource
+      properties:
+        conditions:
+          items:
+            description: Condition defines conditions
+            properties:
+              lastProbeTime:
+                format: date-time
+                type: string
+              lastTransitionTime:
+                format: date-time
+                type: string
+              message:
+                type: string
+              reason:
+                type: string
+              status:
+                type: string
+              type:
+                type: string
+            required:
+            - type
+            - status
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        creationTime:
+          format: date-time
+          nullable: true
+          type: string
+        error:
+          description: Error is the last error encountered during the snapshot/restore
+          properties:
+            message:
+              type: string
+            time:
+              format: date-time
+              type: string
+          type: object
+        phase:
+          type: string
+        readyToUse:
+          type: boolean
+        virtualMachineSnapshots:
+          description: VirtualMachineSnapshots lists the snapshot of every VM selected
+            when the group was created
+          items:
+            description: VirtualMachineSnapshotGroupMember is the snapshot of a single
+              VM of a VirtualMachineSnapshotGroup
+            properties:
+              virtualMachineName:
+                type: string
+              virtualMachineSnapshotName:
+                type: string
+            required:
+            - virtualMachineName
+            - virtualMachineSnapshotName
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachinesnapshotmount": `openAPIV3Schema:
+  description: VirtualMachineSnapshotMount defines the operation of exposing a single
+    volume of a VirtualMachineSnapshot as a temporary PVC, optionally hotplugged read-only
+    into a running VM, to recover files from it
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation
+        of an object. Servers should convert recognized schemas to the latest internal
+        value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object
+        represents. Servers may infer this from the endpoint the client submits requests
+        to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineSnapshotMountSpec is the spec for a VirtualMachineSnapshotMount
+        resource
+      properties:
+        target:
+          description: Target is the running VirtualMachine the volume is hotplugged
+            into as a read-only disk. If omitted the volume is only exposed as a PVC,
+            which can be inspected with virtctl guestfs.
+          properties:
+            apiGroup:
+              description: APIGroup is the group for the resource being referenced.
+                If APIGroup is not specified, the specified Kind must be in the core
+                API group. For any other third-party types, APIGroup is required.
+              type: string
+            kind:
+              description: Kind is the type of resource being referenced
+              type: string
+            name:
+              description: Name is the name of resource being referenced
+              type: string
+          required:
+          - kind
+          - name
+          type: object
+        ttlDuration:
+          description: ttlDuration limits the lifetime of the mount After this duration
+            has passed from counting from CreationTimestamp, the volume is unplugged
+            and the mount is deleted together with its PVC. Defaults to DefaultMountDurationTTL
+            - 2h
+          type: string
+        virtualMachineSnapshotName:
+          type: string
+        volumeName:
+          description: VolumeName is the name of the snapshotted volume to expose
+          type: string
+      required:
+      - virtualMachineSnapshotName
+      - volumeName
+      type: object
+    status:
+      description: VirtualMachineSnapshotMountStatus is the status for a VirtualMachineSnapshotMount
+        resource
+      properties:
+        conditions:
+          items:
+            description: Condition defines conditions
+            properties:
+              lastProbeTime:
+                format: date-time
+                type: string
+              lastTransitionTime:
+                format: date-time
+                type: string
+              message:
+                type: string
+              reason:
+                type: string
+              status:
+                type: string
+              type:
+                type: string
+            required:
+            - type
+            - status
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        error:
+          description: Error is the last error encountered during the snapshot/restore
+          properties:
+            message:
+              type: string
+            time:
+              format: date-time
+              type: string
+          type: object
+        persistentVolumeClaimName:
+          description: PersistentVolumeClaimName is the name of the temporary PVC
+            restored from the volume snapshot
+          type: string
+        phase:
+          type: string
+        ttlExpirationTime:
+          description: The time at which the mount will be removed according to the
+            specified TTL Formula is CreationTimestamp + TTL
+          format: date-time
+          type: string
+      type: object
+  required:
+  - spec
+  type: object
 `,
 }
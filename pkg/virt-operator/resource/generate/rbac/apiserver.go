@@ -201,6 +201,9 @@ func newApiServerClusterRole() *rbacv1.ClusterRole {
 					"virtualmachinesnapshots",
 					"virtualmachinerestores",
 					"virtualmachinesnapshotcontents",
+					"virtualmachinesnapshotgroups",
+					"virtualmachinerestoregroups",
+					"virtualmachinesnapshotmounts",
 				},
 				Verbs: []string{
 					"get", "list", "watch",
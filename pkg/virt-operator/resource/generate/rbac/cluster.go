@@ -168,9 +168,14 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"virtualmachineinstances/unpause",
 					"virtualmachineinstances/addvolume",
 					"virtualmachineinstances/removevolume",
+					"virtualmachineinstances/insertmedia",
+					"virtualmachineinstances/ejectmedia",
+					"virtualmachineinstances/addfilesystem",
+					"virtualmachineinstances/removefilesystem",
 					"virtualmachineinstances/freeze",
 					"virtualmachineinstances/unfreeze",
 					"virtualmachineinstances/softreboot",
+					"virtualmachineinstances/fstrim",
 					VMInstancesSEVSetupSession,
 					VMInstancesSEVInjectLaunchSecret,
 				},
@@ -200,6 +205,10 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"virtualmachines/restart",
 					"virtualmachines/addvolume",
 					"virtualmachines/removevolume",
+					"virtualmachines/insertmedia",
+					"virtualmachines/ejectmedia",
+					"virtualmachines/addfilesystem",
+					"virtualmachines/removefilesystem",
 					"virtualmachines/migrate",
 					"virtualmachines/memorydump",
 					"virtualmachines/addinterface",
@@ -242,6 +251,9 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"virtualmachinesnapshots",
 					"virtualmachinesnapshotcontents",
 					"virtualmachinerestores",
+					"virtualmachinesnapshotgroups",
+					"virtualmachinerestoregroups",
+					"virtualmachinesnapshotmounts",
 				},
 				Verbs: []string{
 					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
@@ -351,9 +363,14 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"virtualmachineinstances/unpause",
 					"virtualmachineinstances/addvolume",
 					"virtualmachineinstances/removevolume",
+					"virtualmachineinstances/insertmedia",
+					"virtualmachineinstances/ejectmedia",
+					"virtualmachineinstances/addfilesystem",
+					"virtualmachineinstances/removefilesystem",
 					"virtualmachineinstances/freeze",
 					"virtualmachineinstances/unfreeze",
 					"virtualmachineinstances/softreboot",
+					"virtualmachineinstances/fstrim",
 					VMInstancesSEVSetupSession,
 					VMInstancesSEVInjectLaunchSecret,
 				},
@@ -383,6 +400,10 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"virtualmachines/restart",
 					"virtualmachines/addvolume",
 					"virtualmachines/removevolume",
+					"virtualmachines/insertmedia",
+					"virtualmachines/ejectmedia",
+					"virtualmachines/addfilesystem",
+					"virtualmachines/removefilesystem",
 					"virtualmachines/migrate",
 					"virtualmachines/memorydump",
 					"virtualmachines/addinterface",
@@ -425,6 +446,9 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"virtualmachinesnapshots",
 					"virtualmachinesnapshotcontents",
 					"virtualmachinerestores",
+					"virtualmachinesnapshotgroups",
+					"virtualmachinerestoregroups",
+					"virtualmachinesnapshotmounts",
 				},
 				Verbs: []string{
 					"get", "delete", "create", "update", "patch", "list", "watch",
@@ -567,6 +591,9 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 					"virtualmachinesnapshots",
 					"virtualmachinesnapshotcontents",
 					"virtualmachinerestores",
+					"virtualmachinesnapshotgroups",
+					"virtualmachinerestoregroups",
+					"virtualmachinesnapshotmounts",
 				},
 				Verbs: []string{
 					"get", "list", "watch",
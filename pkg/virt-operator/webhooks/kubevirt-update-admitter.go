@@ -43,6 +43,7 @@ import (
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
 
+	"kubevirt.io/kubevirt/pkg/container-disk/signature"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	validating_webhooks "kubevirt.io/kubevirt/pkg/util/webhooks/validating-webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/apply"
@@ -106,6 +107,11 @@ func (admitter *KubeVirtUpdateAdmitter) Admit(ar *admissionv1.AdmissionReview) *
 
 	}
 
+	if !equality.Semantic.DeepEqual(currKV.Spec.Configuration.ContainerDiskVerification, newKV.Spec.Configuration.ContainerDiskVerification) {
+		results = append(results,
+			validateContainerDiskVerification(field.NewPath("spec").Child("configuration", "containerDiskVerification"), newKV.Spec.Configuration.ContainerDiskVerification)...)
+	}
+
 	if newKV.Spec.Infra != nil {
 		results = append(results, validateInfraReplicas(newKV.Spec.Infra.Replicas)...)
 	}
@@ -308,6 +314,33 @@ func validateSeccompConfiguration(field *field.Path, seccompConf *v1.SeccompConf
 
 }
 
+func validateContainerDiskVerification(field *field.Path, verification *v1.ContainerDiskVerification) []metav1.StatusCause {
+	statuses := []metav1.StatusCause{}
+	if verification == nil {
+		return statuses
+	}
+
+	publicKeysField := field.Child("publicKeys")
+	if len(verification.PublicKeys) == 0 {
+		statuses = append(statuses, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Field:   publicKeysField.String(),
+			Message: fmt.Sprintf("%s needs at least one public key", publicKeysField.String()),
+		})
+	}
+	for i, publicKey := range verification.PublicKeys {
+		if _, err := signature.ParsePublicKeys([]string{publicKey}); err != nil {
+			statuses = append(statuses, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   publicKeysField.Index(i).String(),
+				Message: fmt.Sprintf("%s is not a valid public key", publicKeysField.Index(i).String()),
+			})
+		}
+	}
+
+	return statuses
+}
+
 func validateWorkloadPlacement(namespace string, placementConfig *v1.NodePlacement, client kubecli.KubevirtClient) []metav1.StatusCause {
 	statuses := []metav1.StatusCause{}
 
@@ -37,6 +37,10 @@ import (
 	"kubevirt.io/kubevirt/pkg/testutils"
 )
 
+const testPublicKey = `-----BEGIN PUBLIC KEY-----
+MCowBQYDK2VwAyEAXJRSKksv9iR9r6Yh1n4Z3QvXro/SuhTrOyT9qQzAnPc=
+-----END PUBLIC KEY-----`
+
 var _ = Describe("Validating KubeVirtUpdate Admitter", func() {
 
 	test := field.NewPath("test")
@@ -66,6 +70,23 @@ var _ = Describe("Validating KubeVirtUpdate Admitter", func() {
 		}, []string{vmProfileField.Child("customProfile", "runtimeDefaultProfile").String(), vmProfileField.Child("customProfile", "localhostProfile").String()}),
 	)
 
+	DescribeTable("validateContainerDiskVerification", func(verification *v1.ContainerDiskVerification, expectedFields []string) {
+		causes := validateContainerDiskVerification(test, verification)
+		Expect(causes).To(HaveLen(len(expectedFields)))
+		for _, cause := range causes {
+			Expect(cause.Field).To(BeElementOf(expectedFields))
+		}
+	},
+		Entry("without verification", nil, nil),
+		Entry("with a valid public key", &v1.ContainerDiskVerification{
+			PublicKeys: []string{testPublicKey},
+		}, nil),
+		Entry("without public keys", &v1.ContainerDiskVerification{}, []string{test.Child("publicKeys").String()}),
+		Entry("with an invalid public key", &v1.ContainerDiskVerification{
+			PublicKeys: []string{testPublicKey, "not a key"},
+		}, []string{test.Child("publicKeys").Index(1).String()}),
+	)
+
 	DescribeTable("test validateCustomizeComponents", func(cc v1.CustomizeComponents, expectedCauses int) {
 		causes := validateCustomizeComponents(cc)
 		Expect(causes).To(HaveLen(expectedCauses))
@@ -0,0 +1,285 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package flatten
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	batchv1 "k8s.io/api/batch/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/clientcmd"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	"kubevirt.io/kubevirt/pkg/virtctl/guestfs"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_FLATTEN = "flatten-overlay"
+
+	// The paths below have to match the ones in pkg/ephemeral-disk, the qcow2 overlay refers to its base image by
+	// absolute path. The package is not imported to avoid compile conflicts when the os is windows
+	pvcBaseDir              = "/var/run/kubevirt-private/vmi-disks"
+	blockDevBaseDir         = "/dev"
+	overlayFileName         = "disk.qcow2"
+	diskImageFileName       = "disk.img"
+	overlayBaseVolumeSuffix = "-base"
+
+	jobNamePrefix     = "flatten-"
+	containerName     = "flatten"
+	volumeArg         = "volume"
+	imageArg          = "image"
+	pullPolicyDefault = k8sv1.PullIfNotPresent
+	pollInterval      = 5 * time.Second
+	timeoutDefault    = 2 * time.Hour
+)
+
+var (
+	volumeName string
+	image      string
+	pullPolicy string
+	timeout    time.Duration
+)
+
+type command struct {
+	clientConfig clientcmd.ClientConfig
+}
+
+// NewFlattenCommand returns a cobra.Command to merge a persistent overlay with its base image
+func NewFlattenCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   COMMAND_FLATTEN + " (VM)",
+		Short: "Merge a persistent overlay volume of a stopped VM with its base image",
+		Long: `Create a job which converts the qcow2 overlay and its base image into a standalone raw disk image on the overlay pvc.
+Once the job completed, the volume of the VM is changed to a persistentVolumeClaim volume of the overlay pvc and the base pvc isn't needed anymore.
+The VM must be stopped and the overlay pvc must be large enough to hold the full disk image.`,
+		Example: usage(),
+		Args:    templates.ExactArgs(COMMAND_FLATTEN, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := command{clientConfig: clientConfig}
+			return c.run(cmd, args)
+		},
+	}
+	cmd.Flags().StringVar(&volumeName, volumeArg, "", "name of the overlay volume of the VM")
+	cmd.Flags().StringVar(&image, imageArg, "", "libguestfs-tools container image used to run the job")
+	cmd.Flags().StringVar(&pullPolicy, "pull-policy", string(pullPolicyDefault), "pull policy for the libguestfs image")
+	cmd.Flags().DurationVar(&timeout, "timeout", timeoutDefault, "time to wait for the job to complete")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	return `  # Flatten the overlay volume 'rootdisk' of VM 'vdi-042':
+  {{ProgramName}} flatten-overlay vdi-042 --volume=rootdisk`
+}
+
+func (c *command) run(cmd *cobra.Command, args []string) error {
+	vmName := args[0]
+	if volumeName == "" {
+		return fmt.Errorf("--%s is required", volumeArg)
+	}
+	if err := guestfs.ValidatePullPolicy(pullPolicy); err != nil {
+		return err
+	}
+
+	namespace, _, err := c.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return fmt.Errorf("cannot obtain KubeVirt client: %v", err)
+	}
+
+	vm, err := virtClient.VirtualMachine(namespace).Get(context.Background(), vmName, &metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	volumeIndex, volume, err := findOverlayVolume(vm, volumeName)
+	if err != nil {
+		return err
+	}
+	_, err = virtClient.VirtualMachineInstance(namespace).Get(context.Background(), vmName, &metav1.GetOptions{})
+	if err == nil {
+		return fmt.Errorf("VM %s is running, stop it first", vmName)
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	job, err := c.ensureFlattenJob(virtClient, namespace, volume)
+	if err != nil {
+		return err
+	}
+	cmd.Printf("Waiting for job %s to flatten volume %s\n", job.Name, volumeName)
+	if err := waitForJob(virtClient, namespace, job.Name, pollInterval, timeout); err != nil {
+		return err
+	}
+
+	if err := replaceOverlayVolume(virtClient, vm, volumeIndex, volume); err != nil {
+		return fmt.Errorf("flattened the overlay, but failed to update VM %s, re-run the command to retry: %v", vmName, err)
+	}
+	cmd.Printf("Volume %s of VM %s now uses pvc %s, pvc %s is not needed by the VM anymore\n",
+		volumeName, vmName, volume.Overlay.ClaimName, volume.Overlay.BaseClaimName)
+	return nil
+}
+
+func findOverlayVolume(vm *v1.VirtualMachine, name string) (int, *v1.Volume, error) {
+	if vm.Spec.Template == nil {
+		return 0, nil, fmt.Errorf("VM %s has no template", vm.Name)
+	}
+	for i, volume := range vm.Spec.Template.Spec.Volumes {
+		if volume.Name != name {
+			continue
+		}
+		if volume.Overlay == nil {
+			return 0, nil, fmt.Errorf("volume %s of VM %s is not an overlay volume", name, vm.Name)
+		}
+		return i, volume.DeepCopy(), nil
+	}
+	return 0, nil, fmt.Errorf("VM %s has no volume %s", vm.Name, name)
+}
+
+// ensureFlattenJob creates the flatten job, or returns the existing one so an interrupted flatten can be resumed
+func (c *command) ensureFlattenJob(virtClient kubecli.KubevirtClient, namespace string, volume *v1.Volume) (*batchv1.Job, error) {
+	jobName := jobNamePrefix + volume.Overlay.ClaimName
+	job, err := virtClient.BatchV1().Jobs(namespace).Get(context.Background(), jobName, metav1.GetOptions{})
+	if err == nil {
+		return job, nil
+	} else if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	// the overlay must only be flattened while the disk is offline
+	if err := guestfs.CheckPVCNotInUse(virtClient, namespace, volume.Overlay.ClaimName); err != nil {
+		return nil, err
+	}
+	basePVC, err := virtClient.CoreV1().PersistentVolumeClaims(namespace).Get(context.Background(), volume.Overlay.BaseClaimName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	jobImage, err := guestfs.JobImage(virtClient, image)
+	if err != nil {
+		return nil, err
+	}
+
+	isBlock := basePVC.Spec.VolumeMode != nil && *basePVC.Spec.VolumeMode == k8sv1.PersistentVolumeBlock
+	return virtClient.BatchV1().Jobs(namespace).Create(context.Background(), newFlattenJob(jobName, volume, jobImage, isBlock), metav1.CreateOptions{})
+}
+
+func waitForJob(virtClient kubecli.KubevirtClient, namespace, jobName string, interval, timeout time.Duration) error {
+	return wait.PollImmediate(interval, timeout, func() (bool, error) {
+		job, err := virtClient.BatchV1().Jobs(namespace).Get(context.Background(), jobName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		if job.Status.Failed > 0 {
+			return false, fmt.Errorf("job %s failed, check its logs and delete it before retrying", jobName)
+		}
+		return job.Status.Succeeded > 0, nil
+	})
+}
+
+// replaceOverlayVolume points the volume to the flattened image, which is a regular disk image on the overlay pvc now
+func replaceOverlayVolume(virtClient kubecli.KubevirtClient, vm *v1.VirtualMachine, volumeIndex int, volume *v1.Volume) error {
+	newVolume := v1.Volume{
+		Name: volume.Name,
+		VolumeSource: v1.VolumeSource{
+			PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+				PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+					ClaimName: volume.Overlay.ClaimName,
+				},
+			},
+		},
+	}
+	payload, err := patch.GenerateTestReplacePatch(fmt.Sprintf("/spec/template/spec/volumes/%d", volumeIndex), volume, newVolume)
+	if err != nil {
+		return err
+	}
+	_, err = virtClient.VirtualMachine(vm.Namespace).Patch(context.Background(), vm.Name, types.JSONPatchType, payload, &metav1.PatchOptions{})
+	return err
+}
+
+// flattenScript writes the merged image next to the overlay and only replaces the overlay once the conversion succeeded.
+// Until the VM is updated, a repeated run finds the finished job and doesn't touch the image again.
+func flattenScript(volumeDir string) string {
+	overlayPath := filepath.Join(volumeDir, overlayFileName)
+	imagePath := filepath.Join(volumeDir, diskImageFileName)
+	return strings.Join([]string{
+		"set -e",
+		fmt.Sprintf("qemu-img convert -O raw %s %s.flatten", overlayPath, imagePath),
+		fmt.Sprintf("mv %s.flatten %s", imagePath, imagePath),
+		fmt.Sprintf("rm %s", overlayPath),
+	}, "\n")
+}
+
+func newFlattenJob(jobName string, volume *v1.Volume, jobImage string, isBlock bool) *batchv1.Job {
+	baseVolumeName := volume.Name + overlayBaseVolumeSuffix
+	volumeDir := filepath.Join(pvcBaseDir, volume.Name)
+	container := k8sv1.Container{
+		Name:            containerName,
+		Image:           jobImage,
+		ImagePullPolicy: k8sv1.PullPolicy(pullPolicy),
+		Command:         []string{"/bin/sh", "-c", flattenScript(volumeDir)},
+		VolumeMounts: []k8sv1.VolumeMount{
+			{Name: volume.Name, MountPath: volumeDir},
+		},
+	}
+	// The base is attached where virt-launcher attaches it, the overlay refers to it by this path
+	if isBlock {
+		container.VolumeDevices = []k8sv1.VolumeDevice{{Name: baseVolumeName, DevicePath: filepath.Join(blockDevBaseDir, baseVolumeName)}}
+	} else {
+		container.VolumeMounts = append(container.VolumeMounts, k8sv1.VolumeMount{
+			Name:      baseVolumeName,
+			MountPath: filepath.Join(pvcBaseDir, baseVolumeName),
+			ReadOnly:  true,
+		})
+	}
+
+	return guestfs.NewJob(jobName, container, []k8sv1.Volume{
+		{
+			Name: volume.Name,
+			VolumeSource: k8sv1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: volume.Overlay.ClaimName},
+			},
+		},
+		{
+			Name: baseVolumeName,
+			VolumeSource: k8sv1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+					ClaimName: volume.Overlay.BaseClaimName,
+					ReadOnly:  true,
+				},
+			},
+		},
+	})
+}
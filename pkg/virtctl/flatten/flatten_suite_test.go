@@ -0,0 +1,11 @@
+package flatten_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestFlatten(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
@@ -0,0 +1,226 @@
+package flatten_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	testing "k8s.io/client-go/testing"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	"kubevirt.io/kubevirt/pkg/virtctl/guestfs"
+	"kubevirt.io/kubevirt/tests/clientcmd"
+)
+
+var _ = Describe("Flatten overlay", func() {
+	const (
+		vmName    = "vdi-042"
+		namespace = "default"
+		jobName   = "flatten-vdi-042-root"
+	)
+
+	var (
+		kubeClient   *fake.Clientset
+		vmInterface  *kubecli.MockVirtualMachineInterface
+		vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+		vm           *v1.VirtualMachine
+	)
+
+	newPVC := func(name string, volumeMode k8sv1.PersistentVolumeMode) *k8sv1.PersistentVolumeClaim {
+		return &k8sv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       k8sv1.PersistentVolumeClaimSpec{VolumeMode: &volumeMode},
+		}
+	}
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+
+		kubeClient = fake.NewSimpleClientset()
+		// Jobs complete right away, there is no job controller behind the fake client
+		kubeClient.PrependReactor("create", "jobs", func(action testing.Action) (bool, runtime.Object, error) {
+			action.(testing.CreateAction).GetObject().(*batchv1.Job).Status.Succeeded = 1
+			return false, nil, nil
+		})
+		kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().BatchV1().Return(kubeClient.BatchV1()).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(namespace).Return(vmInterface).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(namespace).Return(vmiInterface).AnyTimes()
+
+		guestfs.SetImageInfoGetFunc(func(_ kubecli.KubevirtClient) (*kubecli.GuestfsInfo, error) {
+			return &kubecli.GuestfsInfo{Registry: "someregistry.io/kubevirt", Tag: "v1.0.0"}, nil
+		})
+		DeferCleanup(guestfs.SetDefaultImageInfoGetFunc)
+
+		vm = &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: vmName, Namespace: namespace},
+			Spec: v1.VirtualMachineSpec{
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: v1.VirtualMachineInstanceSpec{
+						Volumes: []v1.Volume{
+							{
+								Name: "cloudinit",
+								VolumeSource: v1.VolumeSource{
+									CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: "#cloud-config"},
+								},
+							},
+							{
+								Name: "root",
+								VolumeSource: v1.VolumeSource{
+									Overlay: &v1.OverlayVolumeSource{ClaimName: "vdi-042-root", BaseClaimName: "golden"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		vmInterface.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(vm, nil).AnyTimes()
+	})
+
+	runFlatten := func(args ...string) error {
+		cmd := clientcmd.NewRepeatableVirtctlCommand(append([]string{"flatten-overlay", vmName}, args...)...)
+		return cmd()
+	}
+
+	expectVMStopped := func() {
+		vmiInterface.EXPECT().Get(context.Background(), vmName, gomock.Any()).
+			Return(nil, errors.NewNotFound(schema.GroupResource{Group: "kubevirt.io", Resource: "virtualmachineinstances"}, vmName))
+	}
+
+	expectVolumeReplaced := func() {
+		expectedPatch, err := patch.GenerateTestReplacePatch("/spec/template/spec/volumes/1", vm.Spec.Template.Spec.Volumes[1], v1.Volume{
+			Name: "root",
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "vdi-042-root"},
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		vmInterface.EXPECT().Patch(context.Background(), vmName, types.JSONPatchType, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ types.PatchType, data []byte, _ *metav1.PatchOptions, _ ...string) (*v1.VirtualMachine, error) {
+				Expect(json.RawMessage(data)).To(MatchJSON(expectedPatch))
+				return vm, nil
+			})
+	}
+
+	getJobPodSpec := func() *k8sv1.PodSpec {
+		job, err := kubeClient.BatchV1().Jobs(namespace).Get(context.Background(), jobName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return &job.Spec.Template.Spec
+	}
+
+	It("should flatten an overlay on a filesystem base and update the VM", func() {
+		_, err := kubeClient.CoreV1().PersistentVolumeClaims(namespace).Create(context.Background(), newPVC("golden", k8sv1.PersistentVolumeFilesystem), metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		expectVMStopped()
+		expectVolumeReplaced()
+
+		Expect(runFlatten("--volume=root")).To(Succeed())
+
+		spec := getJobPodSpec()
+		Expect(spec.RestartPolicy).To(Equal(k8sv1.RestartPolicyNever))
+		container := spec.Containers[0]
+		Expect(container.Image).To(Equal("someregistry.io/kubevirt/libguestfs-tools:v1.0.0"))
+		Expect(container.Command[2]).To(ContainSubstring("qemu-img convert -O raw /var/run/kubevirt-private/vmi-disks/root/disk.qcow2 /var/run/kubevirt-private/vmi-disks/root/disk.img.flatten"))
+		Expect(container.VolumeMounts).To(ConsistOf(
+			k8sv1.VolumeMount{Name: "root", MountPath: "/var/run/kubevirt-private/vmi-disks/root"},
+			k8sv1.VolumeMount{Name: "root-base", MountPath: "/var/run/kubevirt-private/vmi-disks/root-base", ReadOnly: true},
+		))
+		Expect(container.VolumeDevices).To(BeEmpty())
+		Expect(spec.Volumes).To(ContainElement(HaveField("VolumeSource.PersistentVolumeClaim", &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "golden", ReadOnly: true})))
+	})
+
+	It("should attach a block base where virt-launcher attaches it", func() {
+		_, err := kubeClient.CoreV1().PersistentVolumeClaims(namespace).Create(context.Background(), newPVC("golden", k8sv1.PersistentVolumeBlock), metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		expectVMStopped()
+		expectVolumeReplaced()
+
+		Expect(runFlatten("--volume=root", "--image=custom:latest")).To(Succeed())
+
+		container := getJobPodSpec().Containers[0]
+		Expect(container.Image).To(Equal("custom:latest"))
+		Expect(container.VolumeDevices).To(ConsistOf(k8sv1.VolumeDevice{Name: "root-base", DevicePath: "/dev/root-base"}))
+	})
+
+	It("should resume with an already completed job", func() {
+		_, err := kubeClient.BatchV1().Jobs(namespace).Create(context.Background(), &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: jobName, Namespace: namespace},
+		}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		expectVMStopped()
+		expectVolumeReplaced()
+
+		Expect(runFlatten("--volume=root")).To(Succeed())
+	})
+
+	It("should not update the VM if the job failed", func() {
+		_, err := kubeClient.BatchV1().Jobs(namespace).Create(context.Background(), &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: jobName, Namespace: namespace},
+		}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		kubeClient.PrependReactor("get", "jobs", func(action testing.Action) (bool, runtime.Object, error) {
+			return true, &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: jobName, Namespace: namespace},
+				Status:     batchv1.JobStatus{Failed: 1},
+			}, nil
+		})
+		expectVMStopped()
+
+		Expect(runFlatten("--volume=root")).To(MatchError(ContainSubstring("job flatten-vdi-042-root failed")))
+	})
+
+	It("should refuse to flatten while the VM is running", func() {
+		vmiInterface.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(&v1.VirtualMachineInstance{}, nil)
+
+		Expect(runFlatten("--volume=root")).To(MatchError(ContainSubstring("is running")))
+	})
+
+	It("should refuse to flatten a pvc in use", func() {
+		expectVMStopped()
+		pod := &k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "virt-launcher-other", Namespace: namespace},
+			Spec: k8sv1.PodSpec{Volumes: []k8sv1.Volume{{
+				Name: "root",
+				VolumeSource: k8sv1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "vdi-042-root"},
+				},
+			}}},
+			Status: k8sv1.PodStatus{Phase: k8sv1.PodRunning},
+		}
+		_, err := kubeClient.CoreV1().Pods(namespace).Create(context.Background(), pod, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(runFlatten("--volume=root")).To(MatchError(ContainSubstring("is used by pod virt-launcher-other")))
+	})
+
+	DescribeTable("should reject invalid arguments", func(expectedErr string, args ...string) {
+		Expect(runFlatten(args...)).To(MatchError(ContainSubstring(expectedErr)))
+	},
+		Entry("missing volume", "--volume is required"),
+		Entry("unknown volume", fmt.Sprintf("VM %s has no volume data", vmName), "--volume=data"),
+		Entry("not an overlay volume", "is not an overlay volume", "--volume=cloudinit"),
+		Entry("invalid pull policy", "Invalid pull policy", "--volume=root", "--pull-policy=Sometimes"),
+	)
+})
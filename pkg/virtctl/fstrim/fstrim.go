@@ -0,0 +1,86 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package fstrim
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_FSTRIM = "fstrim"
+)
+
+func NewFSTrimCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fstrim (VMI)",
+		Short: "Trim the guest filesystems of a virtual machine instance",
+		Long: `Trim the guest filesystems of a virtual machine instance through the guest agent, to return their unused blocks to the storage.
+The trim runs in the background, its result is reported as events on the virtual machine instance.`,
+		Args:    templates.ExactArgs(COMMAND_FSTRIM, 1),
+		Example: usage(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := FSTrim{
+				clientConfig: clientConfig,
+			}
+			return c.Run(args)
+		},
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	usage := "  # Trim the guest filesystems of a virtualmachineinstance called 'myvmi':\n"
+	usage += fmt.Sprintf("  {{ProgramName}} %s myvmi", COMMAND_FSTRIM)
+	return usage
+}
+
+type FSTrim struct {
+	clientConfig clientcmd.ClientConfig
+}
+
+func (o *FSTrim) Run(args []string) error {
+	vmi := args[0]
+
+	namespace, _, err := o.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(o.clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+
+	if err = virtClient.VirtualMachineInstance(namespace).FSTrim(context.Background(), vmi); err != nil {
+		return fmt.Errorf("Error trimming the filesystems of VirtualMachineInstance %s: %v", vmi, err)
+	}
+
+	fmt.Printf("VMI %s was scheduled to %s\n", vmi, COMMAND_FSTRIM)
+	return nil
+}
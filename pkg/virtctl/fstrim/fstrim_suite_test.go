@@ -0,0 +1,11 @@
+package fstrim_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestFSTrim(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
@@ -0,0 +1,50 @@
+package fstrim_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/fstrim"
+	"kubevirt.io/kubevirt/tests/clientcmd"
+)
+
+var _ = Describe("Trimming the guest filesystems", func() {
+
+	const vmiName = "testvmi"
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+	})
+
+	It("should fail with missing input parameters", func() {
+		cmd := clientcmd.NewRepeatableVirtctlCommand(fstrim.COMMAND_FSTRIM)
+		Expect(cmd()).To(HaveOccurred())
+	})
+
+	It("should trim the guest filesystems of the VMI", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).Return(vmiInterface)
+		vmiInterface.EXPECT().FSTrim(context.Background(), vmiName).Return(nil)
+
+		cmd := clientcmd.NewRepeatableVirtctlCommand(fstrim.COMMAND_FSTRIM, vmiName)
+		Expect(cmd()).To(Succeed())
+	})
+
+	It("should report the error of the subresource", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).Return(vmiInterface)
+		vmiInterface.EXPECT().FSTrim(context.Background(), vmiName).Return(errors.New("VMI does not have guest agent connected"))
+
+		cmd := clientcmd.NewRepeatableVirtctlCommand(fstrim.COMMAND_FSTRIM, vmiName)
+		Expect(cmd()).To(MatchError(ContainSubstring("VMI does not have guest agent connected")))
+	})
+})
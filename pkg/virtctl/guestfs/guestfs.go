@@ -217,34 +217,36 @@ type K8sClient struct {
 
 // setImage sets the image name based on the information retrieved by the KubeVirt server.
 func setImage(virtClient kubecli.KubevirtClient) error {
-	var imageName string
 	info, err := ImageInfoGetFunc(virtClient)
 	if err != nil {
 		return fmt.Errorf("could not get guestfs image info: %v", err)
 	}
+	image, err = ImageFromInfo(info)
+	return err
+}
+
+// ImageFromInfo assembles the libguestfs-tools image name from the information retrieved by the KubeVirt server.
+func ImageFromInfo(info *kubecli.GuestfsInfo) (string, error) {
 	if info.GsImage != "" {
 		// custom image set, no need to assemble url
-		image = info.GsImage
-		return nil
+		return info.GsImage, nil
 	}
 	// Set image name including prefix if available
-	imageName = fmt.Sprintf("%s%s", info.ImagePrefix, defaultImageName)
+	imageName := fmt.Sprintf("%s%s", info.ImagePrefix, defaultImageName)
 	// Set the image version.
 	if info.Digest != "" {
 		imageName = fmt.Sprintf("%s@%s", imageName, info.Digest)
 	} else if info.Tag != "" {
 		imageName = fmt.Sprintf("%s:%s", imageName, info.Tag)
 	} else {
-		return fmt.Errorf("Neither the digest nor the tag for the image has been specified")
+		return "", fmt.Errorf("Neither the digest nor the tag for the image has been specified")
 	}
 
 	// Set the registry
-	image = imageName
 	if info.Registry != "" {
-		image = fmt.Sprintf("%s/%s", info.Registry, imageName)
+		return fmt.Sprintf("%s/%s", info.Registry, imageName), nil
 	}
-
-	return nil
+	return imageName, nil
 }
 
 // getImageInfo gets the image info based on the information on KubeVirt CR
@@ -0,0 +1,111 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package guestfs
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"kubevirt.io/client-go/kubecli"
+)
+
+const (
+	jobQemuUserID   = int64(107)
+	jobBackoffLimit = int32(0)
+)
+
+// ValidatePullPolicy checks the value of a --pull-policy flag
+func ValidatePullPolicy(pullPolicy string) error {
+	if pullPolicy != string(corev1.PullAlways) &&
+		pullPolicy != string(corev1.PullNever) &&
+		pullPolicy != string(corev1.PullIfNotPresent) {
+		return fmt.Errorf("Invalid pull policy: %s", pullPolicy)
+	}
+	return nil
+}
+
+// JobImage returns the given image, or the libguestfs-tools image of the cluster if none was given
+func JobImage(virtClient kubecli.KubevirtClient, image string) (string, error) {
+	if image != "" {
+		return image, nil
+	}
+	info, err := ImageInfoGetFunc(virtClient)
+	if err != nil {
+		return "", fmt.Errorf("could not get guestfs image info: %v", err)
+	}
+	return ImageFromInfo(info)
+}
+
+// CheckPVCNotInUse makes sure no running pod uses the pvc, jobs modifying a disk image must only run while the disk is offline
+func CheckPVCNotInUse(virtClient kubecli.KubevirtClient, namespace, pvcName string) error {
+	pods, err := virtClient.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == pvcName {
+				return fmt.Errorf("PVC %s is used by pod %s, stop the VM first", pvcName, pod.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// NewJob wraps the container into a job which runs it once, unprivileged and as the qemu user
+func NewJob(name string, container corev1.Container, volumes []corev1.Volume) *batchv1.Job {
+	container.SecurityContext = &corev1.SecurityContext{
+		AllowPrivilegeEscalation: pointer.Bool(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: pointer.Int32(jobBackoffLimit),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: pointer.Bool(true),
+						RunAsUser:    pointer.Int64(jobQemuUserID),
+						FSGroup:      pointer.Int64(jobQemuUserID),
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeRuntimeDefault,
+						},
+					},
+					Containers: []corev1.Container{container},
+					Volumes:    volumes,
+				},
+			},
+		},
+	}
+}
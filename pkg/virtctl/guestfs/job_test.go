@@ -0,0 +1,66 @@
+package guestfs_test
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/guestfs"
+)
+
+var _ = Describe("Guestfs jobs", func() {
+	newPod := func(name string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{{
+					Name: "disk",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvcName},
+					},
+				}},
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	DescribeTable("should check that the pvc is not in use", func(pod *corev1.Pod, expectedErr string) {
+		virtClient := kubecli.NewMockKubevirtClient(gomock.NewController(GinkgoT()))
+		virtClient.EXPECT().CoreV1().Return(fake.NewSimpleClientset(pod).CoreV1()).AnyTimes()
+
+		err := guestfs.CheckPVCNotInUse(virtClient, testNamespace, pvcName)
+		if expectedErr == "" {
+			Expect(err).ToNot(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(expectedErr))
+		}
+	},
+		Entry("with a running pod", newPod("virt-launcher", corev1.PodRunning), "PVC test-pvc is used by pod virt-launcher, stop the VM first"),
+		Entry("with a completed pod", newPod("virt-launcher", corev1.PodSucceeded), ""),
+		Entry("with a failed pod", newPod("virt-launcher", corev1.PodFailed), ""),
+	)
+
+	It("should reject an unknown pull policy", func() {
+		Expect(guestfs.ValidatePullPolicy(string(corev1.PullNever))).To(Succeed())
+		Expect(guestfs.ValidatePullPolicy("Sometimes")).To(MatchError("Invalid pull policy: Sometimes"))
+	})
+
+	It("should run the job container once and unprivileged", func() {
+		job := guestfs.NewJob("job", corev1.Container{Name: "container"}, []corev1.Volume{{Name: "disk"}})
+
+		Expect(*job.Spec.BackoffLimit).To(BeZero())
+		spec := job.Spec.Template.Spec
+		Expect(spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+		Expect(*spec.SecurityContext.RunAsNonRoot).To(BeTrue())
+		Expect(*spec.SecurityContext.RunAsUser).To(Equal(int64(107)))
+		Expect(spec.Volumes).To(ConsistOf(HaveField("Name", "disk")))
+		Expect(spec.Containers).To(HaveLen(1))
+		Expect(*spec.Containers[0].SecurityContext.AllowPrivilegeEscalation).To(BeFalse())
+		Expect(spec.Containers[0].SecurityContext.Capabilities.Drop).To(ConsistOf(corev1.Capability("ALL")))
+	})
+})
@@ -307,7 +307,9 @@ func (c *command) run(args []string) error {
 				return err
 			}
 		} else {
-			obj, err = createUploadDataVolume(virtClient, namespace, name, size, storageClass, accessMode, blockVolume, archiveUpload)
+			labels := make(map[string]string)
+			setDefaultInstancetypeLabels(labels)
+			obj, err = createUploadDataVolume(virtClient, namespace, name, size, storageClass, accessMode, blockVolume, archiveUpload, forceBind, labels)
 			if err != nil {
 				return err
 			}
@@ -329,30 +331,16 @@ func (c *command) run(args []string) error {
 			return err
 		}
 	} else {
-		err = waitDvUploadScheduled(virtClient, namespace, name, uploadReadyWaitInterval, time.Duration(uploadPodWaitSecs)*time.Second)
+		err = waitDvUploadScheduled(virtClient, namespace, name, forceBind, uploadReadyWaitInterval, time.Duration(uploadPodWaitSecs)*time.Second)
 		if err != nil {
 			return err
 		}
 	}
-	if uploadProxyURL == "" {
-		uploadProxyURL, err = getUploadProxyURL(virtClient.CdiClient())
-		if err != nil {
-			return err
-		}
-		if uploadProxyURL == "" {
-			return fmt.Errorf("uploadproxy URL not found")
-		}
-	}
-
-	u, err := url.Parse(uploadProxyURL)
+	uploadProxyURL, err = resolveUploadProxyURL(virtClient, uploadProxyURL)
 	if err != nil {
 		return err
 	}
 
-	if u.Scheme == "" {
-		uploadProxyURL = fmt.Sprintf("https://%s", uploadProxyURL)
-	}
-
 	fmt.Printf("Uploading data to %s\n", uploadProxyURL)
 
 	token, err := getUploadToken(virtClient.CdiClient(), namespace, name)
@@ -360,7 +348,12 @@ func (c *command) run(args []string) error {
 		return err
 	}
 
-	err = uploadData(uploadProxyURL, token, file, insecure)
+	fi, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	err = uploadData(uploadProxyURL, token, file, fi.Size(), insecure)
 	if err != nil {
 		return err
 	}
@@ -376,6 +369,78 @@ func (c *command) run(args []string) error {
 	return err
 }
 
+// DataVolumeUpload describes the upload of an image into a new DataVolume
+type DataVolumeUpload struct {
+	Namespace    string
+	Name         string
+	Size         string
+	StorageClass string
+	AccessMode   string
+	BlockVolume  bool
+	ForceBind    bool
+	Labels       map[string]string
+
+	// UploadProxyURL is looked up in the CDI config when empty
+	UploadProxyURL string
+	Insecure       bool
+	UploadPodWait  time.Duration
+}
+
+// UploadToNewDataVolume creates a DataVolume with an upload source and streams the image
+// through the upload proxy. CDI converts the image to raw once it was received, this function
+// does not wait for that to complete.
+func UploadToNewDataVolume(client kubecli.KubevirtClient, upload *DataVolumeUpload, image io.Reader, imageSize int64) error {
+	dv, err := createUploadDataVolume(client, upload.Namespace, upload.Name, upload.Size, upload.StorageClass, upload.AccessMode, upload.BlockVolume, false, upload.ForceBind, upload.Labels)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("DataVolume %s/%s created\n", dv.Namespace, dv.Name)
+
+	err = waitDvUploadScheduled(client, upload.Namespace, upload.Name, upload.ForceBind, uploadReadyWaitInterval, upload.UploadPodWait)
+	if err != nil {
+		return err
+	}
+
+	proxyURL, err := resolveUploadProxyURL(client, upload.UploadProxyURL)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Uploading data to %s\n", proxyURL)
+
+	token, err := getUploadToken(client.CdiClient(), upload.Namespace, upload.Name)
+	if err != nil {
+		return err
+	}
+
+	return uploadData(proxyURL, token, image, imageSize, upload.Insecure)
+}
+
+// resolveUploadProxyURL looks up the upload proxy URL in the CDI config if it was not specified and makes sure it has a scheme
+func resolveUploadProxyURL(client kubecli.KubevirtClient, uploadProxyURL string) (string, error) {
+	var err error
+	if uploadProxyURL == "" {
+		uploadProxyURL, err = getUploadProxyURL(client.CdiClient())
+		if err != nil {
+			return "", err
+		}
+		if uploadProxyURL == "" {
+			return "", fmt.Errorf("uploadproxy URL not found")
+		}
+	}
+
+	u, err := url.Parse(uploadProxyURL)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Scheme == "" {
+		uploadProxyURL = fmt.Sprintf("https://%s", uploadProxyURL)
+	}
+
+	return uploadProxyURL, nil
+}
+
 func getHTTPClient(insecure bool) *http.Client {
 	client := &http.Client{}
 
@@ -428,26 +493,21 @@ func ConstructUploadProxyPathAsync(uploadProxyURL, token string, insecure bool)
 	return u.String(), nil
 }
 
-func uploadData(uploadProxyURL, token string, file *os.File, insecure bool) error {
+func uploadData(uploadProxyURL, token string, image io.Reader, size int64, insecure bool) error {
 	url, err := ConstructUploadProxyPathAsync(uploadProxyURL, token, insecure)
 	if err != nil {
 		return err
 	}
 
-	fi, err := file.Stat()
-	if err != nil {
-		return err
-	}
-
-	bar := pb.New64(fi.Size()).SetUnits(pb.U_BYTES)
-	reader := bar.NewProxyReader(file)
+	bar := pb.New64(size).SetUnits(pb.U_BYTES)
+	reader := bar.NewProxyReader(image)
 
 	client := httpClientCreatorFunc(insecure)
 	req, _ := http.NewRequest("POST", url, io.NopCloser(reader))
 
 	req.Header.Add("Authorization", "Bearer "+token)
 	req.Header.Add("Content-Type", "application/octet-stream")
-	req.ContentLength = fi.Size()
+	req.ContentLength = size
 
 	fmt.Println()
 	bar.Start()
@@ -490,7 +550,7 @@ func getUploadToken(client cdiClientset.Interface, namespace, name string) (stri
 	return response.Status.Token, nil
 }
 
-func waitDvUploadScheduled(client kubecli.KubevirtClient, namespace, name string, interval, timeout time.Duration) error {
+func waitDvUploadScheduled(client kubecli.KubevirtClient, namespace, name string, forceBind bool, interval, timeout time.Duration) error {
 	loggedStatus := false
 	//
 	err := wait.PollImmediate(interval, timeout, func() (bool, error) {
@@ -605,7 +665,7 @@ func setDefaultInstancetypeLabels(labels map[string]string) {
 	}
 }
 
-func createUploadDataVolume(client kubecli.KubevirtClient, namespace, name, size, storageClass, accessMode string, blockVolume, archiveUpload bool) (*cdiv1.DataVolume, error) {
+func createUploadDataVolume(client kubecli.KubevirtClient, namespace, name, size, storageClass, accessMode string, blockVolume, archiveUpload, forceBind bool, labels map[string]string) (*cdiv1.DataVolume, error) {
 	pvcSpec, err := createStorageSpec(client, size, storageClass, accessMode, blockVolume)
 	if err != nil {
 		return nil, err
@@ -624,9 +684,6 @@ func createUploadDataVolume(client kubecli.KubevirtClient, namespace, name, size
 		annotations[forceImmediateBindingAnnotation] = ""
 	}
 
-	labels := make(map[string]string)
-	setDefaultInstancetypeLabels(labels)
-
 	contentType := cdiv1.DataVolumeKubeVirt
 	if archiveUpload {
 		contentType = cdiv1.DataVolumeArchive
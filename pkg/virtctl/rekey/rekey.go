@@ -0,0 +1,208 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rekey
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	batchv1 "k8s.io/api/batch/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/guestfs"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_REKEY = "rekey-disk"
+
+	// passphraseKey is the key of the Secret holding the LUKS passphrase, as in pkg/disk-encryption.
+	// The package is not imported to avoid compile conflicts when the os is windows
+	passphraseKey = "passphrase"
+
+	jobNamePrefix     = "rekey-"
+	containerName     = "rekey"
+	diskVolumeName    = "disk"
+	oldSecretVolume   = "old-passphrase"
+	newSecretVolume   = "new-passphrase"
+	diskDir           = "/disk"
+	diskImagePath     = diskDir + "/disk.img"
+	diskDevicePath    = "/dev/vda"
+	oldPassphraseDir  = "/var/run/secrets/old"
+	newPassphraseDir  = "/var/run/secrets/new"
+	oldSecretArg      = "old-secret"
+	newSecretArg      = "new-secret"
+	imageArg          = "image"
+	pullPolicyDefault = k8sv1.PullIfNotPresent
+)
+
+var (
+	oldSecret  string
+	newSecret  string
+	image      string
+	pullPolicy string
+)
+
+type command struct {
+	clientConfig clientcmd.ClientConfig
+}
+
+// NewRekeyCommand returns a cobra.Command to rotate the passphrase of an encrypted disk
+func NewRekeyCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   COMMAND_REKEY + " (PVC)",
+		Short: "Rotate the passphrase of an encrypted disk",
+		Long: `Create a job which re-encrypts the LUKS key slots of the disk image on the pvc.
+The disk is unlocked with the passphrase of the old Secret, which is replaced with the passphrase of the new Secret.
+The pvc must not be in use, the VM has to be stopped and pointed to the new Secret before it is started again.`,
+		Example: usage(),
+		Args:    templates.ExactArgs(COMMAND_REKEY, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := command{clientConfig: clientConfig}
+			return c.run(cmd, args)
+		},
+	}
+	cmd.Flags().StringVar(&oldSecret, oldSecretArg, "", "Secret holding the current passphrase of the disk")
+	cmd.Flags().StringVar(&newSecret, newSecretArg, "", "Secret holding the new passphrase of the disk")
+	cmd.Flags().StringVar(&image, imageArg, "", "libguestfs-tools container image used to run the job")
+	cmd.Flags().StringVar(&pullPolicy, "pull-policy", string(pullPolicyDefault), "pull policy for the libguestfs image")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	return `  # Replace the passphrase in Secret 'key-v1' of the disk on pvc 'mydisk' with the one in Secret 'key-v2':
+  {{ProgramName}} rekey-disk mydisk --old-secret=key-v1 --new-secret=key-v2`
+}
+
+func (c *command) run(cmd *cobra.Command, args []string) error {
+	pvcName := args[0]
+	if oldSecret == "" || newSecret == "" {
+		return fmt.Errorf("both --%s and --%s are required", oldSecretArg, newSecretArg)
+	}
+	if oldSecret == newSecret {
+		return fmt.Errorf("the old and the new Secret must differ")
+	}
+	if err := guestfs.ValidatePullPolicy(pullPolicy); err != nil {
+		return err
+	}
+
+	namespace, _, err := c.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return fmt.Errorf("cannot obtain KubeVirt client: %v", err)
+	}
+
+	pvc, err := virtClient.CoreV1().PersistentVolumeClaims(namespace).Get(context.Background(), pvcName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	// LUKS key slots must only be changed while the disk is offline
+	if err := guestfs.CheckPVCNotInUse(virtClient, namespace, pvcName); err != nil {
+		return err
+	}
+	for _, secret := range []string{oldSecret, newSecret} {
+		if _, err := virtClient.CoreV1().Secrets(namespace).Get(context.Background(), secret, metav1.GetOptions{}); err != nil {
+			return err
+		}
+	}
+
+	jobImage, err := guestfs.JobImage(virtClient, image)
+	if err != nil {
+		return err
+	}
+
+	isBlock := pvc.Spec.VolumeMode != nil && *pvc.Spec.VolumeMode == k8sv1.PersistentVolumeBlock
+	job, err := virtClient.BatchV1().Jobs(namespace).Create(context.Background(), newRekeyJob(pvcName, jobImage, isBlock), metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	cmd.Printf("Created job %s to rekey the disk on pvc %s, point the VM to Secret %s once it completed\n", job.Name, pvcName, newSecret)
+	return nil
+}
+
+// rekeyScript adds the new passphrase to a free key slot and removes the key slots of the old one afterwards.
+// If the second step fails, both passphrases unlock the disk and the job can be repeated.
+func rekeyScript(isBlock bool) string {
+	path, driver := diskImagePath, "file"
+	if isBlock {
+		path, driver = diskDevicePath, "host_device"
+	}
+	secrets := fmt.Sprintf("--object secret,id=old,file=%s/%s --object secret,id=new,file=%s/%s",
+		oldPassphraseDir, passphraseKey, newPassphraseDir, passphraseKey)
+	imageOpts := func(keySecret string) string {
+		return fmt.Sprintf("--image-opts driver=luks,key-secret=%s,file.driver=%s,file.filename=%s", keySecret, driver, path)
+	}
+	return strings.Join([]string{
+		"set -e",
+		fmt.Sprintf("qemu-img amend %s %s -o state=active,new-secret=new", secrets, imageOpts("old")),
+		fmt.Sprintf("qemu-img amend %s %s -o state=inactive,old-secret=old", secrets, imageOpts("new")),
+	}, "\n")
+}
+
+func secretVolume(name, secretName string) k8sv1.Volume {
+	return k8sv1.Volume{
+		Name: name,
+		VolumeSource: k8sv1.VolumeSource{
+			Secret: &k8sv1.SecretVolumeSource{
+				SecretName: secretName,
+				Items:      []k8sv1.KeyToPath{{Key: passphraseKey, Path: passphraseKey}},
+			},
+		},
+	}
+}
+
+func newRekeyJob(pvcName, jobImage string, isBlock bool) *batchv1.Job {
+	container := k8sv1.Container{
+		Name:            containerName,
+		Image:           jobImage,
+		ImagePullPolicy: k8sv1.PullPolicy(pullPolicy),
+		Command:         []string{"/bin/sh", "-c", rekeyScript(isBlock)},
+		VolumeMounts: []k8sv1.VolumeMount{
+			{Name: oldSecretVolume, MountPath: oldPassphraseDir, ReadOnly: true},
+			{Name: newSecretVolume, MountPath: newPassphraseDir, ReadOnly: true},
+		},
+	}
+	if isBlock {
+		container.VolumeDevices = []k8sv1.VolumeDevice{{Name: diskVolumeName, DevicePath: diskDevicePath}}
+	} else {
+		container.VolumeMounts = append(container.VolumeMounts, k8sv1.VolumeMount{Name: diskVolumeName, MountPath: diskDir})
+	}
+
+	return guestfs.NewJob(jobNamePrefix+pvcName, container, []k8sv1.Volume{
+		{
+			Name: diskVolumeName,
+			VolumeSource: k8sv1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: pvcName},
+			},
+		},
+		secretVolume(oldSecretVolume, oldSecret),
+		secretVolume(newSecretVolume, newSecret),
+	})
+}
@@ -0,0 +1,11 @@
+package rekey_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestRekey(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
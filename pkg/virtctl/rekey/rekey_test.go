@@ -0,0 +1,144 @@
+package rekey_test
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/guestfs"
+	"kubevirt.io/kubevirt/tests/clientcmd"
+)
+
+var _ = Describe("Rekey disk", func() {
+	const (
+		pvcName   = "encrypted"
+		namespace = "default"
+		jobName   = "rekey-" + pvcName
+	)
+
+	var kubeClient *fake.Clientset
+
+	newSecret := func(name string) *k8sv1.Secret {
+		return &k8sv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Data:       map[string][]byte{"passphrase": []byte(name)},
+		}
+	}
+
+	newPVC := func(volumeMode k8sv1.PersistentVolumeMode) *k8sv1.PersistentVolumeClaim {
+		return &k8sv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: pvcName, Namespace: namespace},
+			Spec:       k8sv1.PersistentVolumeClaimSpec{VolumeMode: &volumeMode},
+		}
+	}
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+
+		kubeClient = fake.NewSimpleClientset(newSecret("key-v1"), newSecret("key-v2"))
+		kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().BatchV1().Return(kubeClient.BatchV1()).AnyTimes()
+
+		guestfs.SetImageInfoGetFunc(func(_ kubecli.KubevirtClient) (*kubecli.GuestfsInfo, error) {
+			return &kubecli.GuestfsInfo{Registry: "someregistry.io/kubevirt", Tag: "v1.0.0"}, nil
+		})
+		DeferCleanup(guestfs.SetDefaultImageInfoGetFunc)
+	})
+
+	runRekey := func(args ...string) error {
+		cmd := clientcmd.NewRepeatableVirtctlCommand(append([]string{"rekey-disk", pvcName}, args...)...)
+		return cmd()
+	}
+
+	getJobPodSpec := func() *k8sv1.PodSpec {
+		job, err := kubeClient.BatchV1().Jobs(namespace).Get(context.Background(), jobName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return &job.Spec.Template.Spec
+	}
+
+	It("should create a job rekeying a filesystem pvc", func() {
+		_, err := kubeClient.CoreV1().PersistentVolumeClaims(namespace).Create(context.Background(), newPVC(k8sv1.PersistentVolumeFilesystem), metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(runRekey("--old-secret=key-v1", "--new-secret=key-v2")).To(Succeed())
+
+		spec := getJobPodSpec()
+		Expect(spec.RestartPolicy).To(Equal(k8sv1.RestartPolicyNever))
+		Expect(spec.Containers).To(HaveLen(1))
+		container := spec.Containers[0]
+		Expect(container.Image).To(Equal("someregistry.io/kubevirt/libguestfs-tools:v1.0.0"))
+		Expect(container.Command[2]).To(ContainSubstring("key-secret=old,file.driver=file,file.filename=/disk/disk.img -o state=active,new-secret=new"))
+		Expect(container.Command[2]).To(ContainSubstring("key-secret=new,file.driver=file,file.filename=/disk/disk.img -o state=inactive,old-secret=old"))
+		Expect(container.VolumeMounts).To(ContainElement(HaveField("MountPath", "/disk")))
+		Expect(container.VolumeDevices).To(BeEmpty())
+
+		var secretNames []string
+		for _, volume := range spec.Volumes {
+			if volume.Secret != nil {
+				secretNames = append(secretNames, volume.Secret.SecretName)
+			}
+		}
+		Expect(secretNames).To(ConsistOf("key-v1", "key-v2"))
+	})
+
+	It("should create a job rekeying a block pvc", func() {
+		_, err := kubeClient.CoreV1().PersistentVolumeClaims(namespace).Create(context.Background(), newPVC(k8sv1.PersistentVolumeBlock), metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(runRekey("--old-secret=key-v1", "--new-secret=key-v2", "--image=custom:latest")).To(Succeed())
+
+		container := getJobPodSpec().Containers[0]
+		Expect(container.Image).To(Equal("custom:latest"))
+		Expect(container.Command[2]).To(ContainSubstring("file.driver=host_device,file.filename=/dev/vda"))
+		Expect(container.VolumeDevices).To(ConsistOf(k8sv1.VolumeDevice{Name: "disk", DevicePath: "/dev/vda"}))
+	})
+
+	It("should refuse to rekey a pvc in use", func() {
+		_, err := kubeClient.CoreV1().PersistentVolumeClaims(namespace).Create(context.Background(), newPVC(k8sv1.PersistentVolumeFilesystem), metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		pod := &k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "virt-launcher-testvm", Namespace: namespace},
+			Spec: k8sv1.PodSpec{Volumes: []k8sv1.Volume{{
+				Name: "disk",
+				VolumeSource: k8sv1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: pvcName},
+				},
+			}}},
+			Status: k8sv1.PodStatus{Phase: k8sv1.PodRunning},
+		}
+		_, err = kubeClient.CoreV1().Pods(namespace).Create(context.Background(), pod, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(runRekey("--old-secret=key-v1", "--new-secret=key-v2")).To(MatchError(ContainSubstring("is used by pod virt-launcher-testvm")))
+	})
+
+	DescribeTable("should reject invalid arguments", func(expectedErr string, args ...string) {
+		Expect(runRekey(args...)).To(MatchError(ContainSubstring(expectedErr)))
+	},
+		Entry("missing old secret", "both --old-secret and --new-secret are required", "--new-secret=key-v2"),
+		Entry("missing new secret", "both --old-secret and --new-secret are required", "--old-secret=key-v1"),
+		Entry("same secrets", "must differ", "--old-secret=key-v1", "--new-secret=key-v1"),
+		Entry("invalid pull policy", "Invalid pull policy", "--old-secret=key-v1", "--new-secret=key-v2", "--pull-policy=Sometimes"),
+	)
+
+	It("should fail if the pvc doesn't exist", func() {
+		Expect(runRekey("--old-secret=key-v1", "--new-secret=key-v2")).To(MatchError(ContainSubstring("not found")))
+	})
+
+	It("should fail if a secret doesn't exist", func() {
+		_, err := kubeClient.CoreV1().PersistentVolumeClaims(namespace).Create(context.Background(), newPVC(k8sv1.PersistentVolumeFilesystem), metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(runRekey("--old-secret=key-v1", "--new-secret=key-v3")).To(MatchError(ContainSubstring("key-v3")))
+	})
+})
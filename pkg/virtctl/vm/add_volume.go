@@ -37,11 +37,19 @@ const (
 	COMMAND_ADDVOLUME = "addvolume"
 	serialArg         = "serial"
 	cacheArg          = "cache"
+	containerDiskArg  = "container-disk"
+	configMapArg      = "config-map"
+	secretArg         = "secret"
+	serviceAccountArg = "service-account"
 )
 
 var (
-	serial string
-	cache  string
+	serial             string
+	cache              string
+	containerDiskImage string
+	configMapName      string
+	secretName         string
+	serviceAccountName string
 )
 
 func NewAddVolumeCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
@@ -60,6 +68,11 @@ func NewAddVolumeCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd.MarkFlagRequired(volumeNameArg)
 	cmd.Flags().StringVar(&serial, serialArg, "", "serial number you want to assign to the disk")
 	cmd.Flags().StringVar(&cache, cacheArg, "", "caching options attribute control the cache mechanism")
+	cmd.Flags().StringVar(&containerDiskImage, containerDiskArg, "", "if set, the volume is a read-only containerDisk pulled from the given image")
+	cmd.Flags().StringVar(&configMapName, configMapArg, "", "if set, the volume is a read-only disk built from the given ConfigMap")
+	cmd.Flags().StringVar(&secretName, secretArg, "", "if set, the volume is a read-only disk built from the given Secret")
+	cmd.Flags().StringVar(&serviceAccountName, serviceAccountArg, "", "if set, the volume is a read-only disk built from the given ServiceAccount")
+	cmd.MarkFlagsMutuallyExclusive(containerDiskArg, configMapArg, secretArg, serviceAccountArg)
 	cmd.Flags().BoolVar(&persist, persistArg, false, "if set, the added volume will be persisted in the VM spec (if it exists)")
 	cmd.Flags().BoolVar(&dryRun, dryRunArg, false, dryRunCommandUsage)
 
@@ -78,6 +91,12 @@ func usageAddVolume() string {
 
   #Dynamically attach a volume with 'none' cache attribute to a running VM.
   {{ProgramName}} addvolume fedora-dv --volume-name=example-dv --cache=none
+
+  #Dynamically attach a driver ISO packaged as a container image to a running VM.
+  {{ProgramName}} addvolume fedora-dv --volume-name=drivers --container-disk=quay.io/containerdisks/virtio-win:latest
+
+  #Dynamically attach a read-only disk built from a Secret to a running VM.
+  {{ProgramName}} addvolume fedora-dv --volume-name=credentials --secret=my-secret
   `
 }
 
@@ -124,8 +143,48 @@ func getVolumeSourceFromVolume(volumeName, namespace string, virtClient kubecli.
 	return nil, fmt.Errorf("Volume %s is not a DataVolume or PersistentVolumeClaim", volumeName)
 }
 
+// getReadOnlyVolumeSourceFromFlags returns the containerDisk, ConfigMap, Secret or ServiceAccount volume source
+// requested on the command line, or nil if none was requested
+func getReadOnlyVolumeSourceFromFlags() *v1.HotplugVolumeSource {
+	switch {
+	case containerDiskImage != "":
+		return &v1.HotplugVolumeSource{
+			ContainerDisk: &v1.ContainerDiskSource{
+				Image:        containerDiskImage,
+				Hotpluggable: true,
+			},
+		}
+	case configMapName != "":
+		return &v1.HotplugVolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: k8sv1.LocalObjectReference{Name: configMapName},
+				Hotpluggable:         true,
+			},
+		}
+	case secretName != "":
+		return &v1.HotplugVolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName:   secretName,
+				Hotpluggable: true,
+			},
+		}
+	case serviceAccountName != "":
+		return &v1.HotplugVolumeSource{
+			ServiceAccount: &v1.ServiceAccountVolumeSource{
+				ServiceAccountName: serviceAccountName,
+				Hotpluggable:       true,
+			},
+		}
+	}
+	return nil
+}
+
 func addVolume(vmiName, volumeName, namespace string, virtClient kubecli.KubevirtClient, dryRunOption *[]string) error {
-	volumeSource, err := getVolumeSourceFromVolume(volumeName, namespace, virtClient)
+	volumeSource := getReadOnlyVolumeSourceFromFlags()
+	var err error
+	if volumeSource == nil {
+		volumeSource, err = getVolumeSourceFromVolume(volumeName, namespace, virtClient)
+	}
 	if err != nil {
 		return fmt.Errorf("error adding volume, %v", err)
 	}
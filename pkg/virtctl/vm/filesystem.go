@@ -0,0 +1,169 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package vm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_ADDFILESYSTEM    = "addfilesystem"
+	COMMAND_REMOVEFILESYSTEM = "removefilesystem"
+	filesystemNameArg        = "filesystem-name"
+)
+
+var filesystemName string
+
+func NewAddFilesystemCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "addfilesystem VMI",
+		Short:   "add a virtiofs filesystem to a running VM",
+		Example: usageAddFilesystem(),
+		Args:    templates.ExactArgs("addfilesystem", 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_ADDFILESYSTEM, clientConfig: clientConfig}
+			return c.addFilesystemRun(args)
+		},
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	cmd.Flags().StringVar(&volumeName, volumeNameArg, "", "name of the DataVolume or PersistentVolumeClaim to share with the guest")
+	cmd.MarkFlagRequired(volumeNameArg)
+	cmd.Flags().StringVar(&filesystemName, filesystemNameArg, "", "name of the filesystem, used as mount tag in the guest. Defaults to the volume name")
+	cmd.Flags().BoolVar(&persist, persistArg, false, "if set, the added filesystem will be persisted in the VM spec (if it exists)")
+	cmd.Flags().BoolVar(&dryRun, dryRunArg, false, dryRunCommandUsage)
+	return cmd
+}
+
+func NewRemoveFilesystemCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "removefilesystem VMI",
+		Short:   "remove a hotplugged virtiofs filesystem from a running VM",
+		Example: usageRemoveFilesystem(),
+		Args:    templates.ExactArgs("removefilesystem", 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_REMOVEFILESYSTEM, clientConfig: clientConfig}
+			return c.removeFilesystemRun(args)
+		},
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	cmd.Flags().StringVar(&filesystemName, filesystemNameArg, "", "name of the filesystem to remove")
+	cmd.MarkFlagRequired(filesystemNameArg)
+	cmd.Flags().BoolVar(&persist, persistArg, false, "if set, the removed filesystem will be removed from the VM spec (if it exists)")
+	cmd.Flags().BoolVar(&dryRun, dryRunArg, false, dryRunCommandUsage)
+	return cmd
+}
+
+func usageAddFilesystem() string {
+	return `  #Share the PVC 'dataset' with the running VM 'fedora-vm', the guest mounts it with the tag 'dataset'.
+  {{ProgramName}} addfilesystem fedora-vm --volume-name=dataset
+
+  #Share the PVC 'dataset' with the mount tag 'data'.
+  {{ProgramName}} addfilesystem fedora-vm --volume-name=dataset --filesystem-name=data
+
+  #Share the PVC 'dataset' and persist it in the VM spec. At next VM restart the filesystem will be shared like any other filesystem.
+  {{ProgramName}} addfilesystem fedora-vm --volume-name=dataset --persist
+  `
+}
+
+func usageRemoveFilesystem() string {
+	return `  #Remove the hotplugged filesystem 'dataset' from the running VM 'fedora-vm'.
+  {{ProgramName}} removefilesystem fedora-vm --filesystem-name=dataset
+
+  #Remove the hotplugged filesystem 'dataset' and remove it from the VM spec.
+  {{ProgramName}} removefilesystem fedora-vm --filesystem-name=dataset --persist
+  `
+}
+
+func (o *Command) addFilesystemRun(args []string) error {
+	var dryRunOption []string
+	vmiName := args[0]
+
+	virtClient, namespace, err := GetNamespaceAndClient(o.clientConfig)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		dryRunOption = []string{metav1.DryRunAll}
+		fmt.Printf("Dry Run execution\n")
+	}
+
+	volumeSource, err := getVolumeSourceFromVolume(volumeName, namespace, virtClient)
+	if err != nil {
+		return fmt.Errorf("error adding filesystem, %v", err)
+	}
+	name := filesystemName
+	if name == "" {
+		name = volumeName
+	}
+	addRequest := &v1.AddFilesystemOptions{
+		Name:         name,
+		VolumeSource: volumeSource,
+		DryRun:       dryRunOption,
+	}
+	if !persist {
+		err = virtClient.VirtualMachineInstance(namespace).AddFilesystem(context.Background(), vmiName, addRequest)
+	} else {
+		err = virtClient.VirtualMachine(namespace).AddFilesystem(context.Background(), vmiName, addRequest)
+	}
+	if err != nil {
+		return fmt.Errorf("error adding filesystem, %v", err)
+	}
+	fmt.Printf("Successfully submitted add filesystem request to VM %s for filesystem %s\n", vmiName, name)
+	return nil
+}
+
+func (o *Command) removeFilesystemRun(args []string) error {
+	var dryRunOption []string
+	vmiName := args[0]
+
+	virtClient, namespace, err := GetNamespaceAndClient(o.clientConfig)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		dryRunOption = []string{metav1.DryRunAll}
+		fmt.Printf("Dry Run execution\n")
+	}
+
+	removeRequest := &v1.RemoveFilesystemOptions{
+		Name:   filesystemName,
+		DryRun: dryRunOption,
+	}
+	if !persist {
+		err = virtClient.VirtualMachineInstance(namespace).RemoveFilesystem(context.Background(), vmiName, removeRequest)
+	} else {
+		err = virtClient.VirtualMachine(namespace).RemoveFilesystem(context.Background(), vmiName, removeRequest)
+	}
+	if err != nil {
+		return fmt.Errorf("error removing filesystem, %v", err)
+	}
+	fmt.Printf("Successfully submitted remove filesystem request to VM %s for filesystem %s\n", vmiName, filesystemName)
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package vm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_INSERTMEDIA = "insertmedia"
+	COMMAND_EJECTMEDIA  = "ejectmedia"
+	diskNameArg         = "disk-name"
+)
+
+var diskName string
+
+func NewInsertMediaCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "insertmedia VMI",
+		Short:   "insert media into an empty cdrom of a running VM",
+		Example: usageInsertMedia(),
+		Args:    templates.ExactArgs("insertmedia", 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_INSERTMEDIA, clientConfig: clientConfig}
+			return c.insertMediaRun(args)
+		},
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	cmd.Flags().StringVar(&diskName, diskNameArg, "", "name of the cdrom disk in the disks section of spec")
+	cmd.MarkFlagRequired(diskNameArg)
+	cmd.Flags().StringVar(&volumeName, volumeNameArg, "", "name of the DataVolume or PersistentVolumeClaim holding the media")
+	cmd.MarkFlagRequired(volumeNameArg)
+	cmd.Flags().BoolVar(&persist, persistArg, false, "if set, the inserted media will be persisted in the VM spec (if it exists)")
+	cmd.Flags().BoolVar(&dryRun, dryRunArg, false, dryRunCommandUsage)
+	return cmd
+}
+
+func NewEjectMediaCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ejectmedia VMI",
+		Short:   "eject the media of a cdrom of a running VM",
+		Example: usageEjectMedia(),
+		Args:    templates.ExactArgs("ejectmedia", 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_EJECTMEDIA, clientConfig: clientConfig}
+			return c.ejectMediaRun(args)
+		},
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	cmd.Flags().StringVar(&diskName, diskNameArg, "", "name of the cdrom disk in the disks section of spec")
+	cmd.MarkFlagRequired(diskNameArg)
+	cmd.Flags().BoolVar(&persist, persistArg, false, "if set, the ejected media will be removed from the VM spec (if it exists)")
+	cmd.Flags().BoolVar(&dryRun, dryRunArg, false, dryRunCommandUsage)
+	return cmd
+}
+
+func usageInsertMedia() string {
+	return `  #Insert the media of a DataVolume or PVC into the empty cdrom 'cdrom1' of a running VM.
+  {{ProgramName}} insertmedia fedora-vm --disk-name=cdrom1 --volume-name=virtio-win-iso
+
+  #Insert media and persist it in the VM spec. At next VM restart the cdrom will still hold the media.
+  {{ProgramName}} insertmedia fedora-vm --disk-name=cdrom1 --volume-name=virtio-win-iso --persist
+  `
+}
+
+func usageEjectMedia() string {
+	return `  #Eject the media of the cdrom 'cdrom1' of a running VM.
+  {{ProgramName}} ejectmedia fedora-vm --disk-name=cdrom1
+
+  #Eject the media and remove it from the VM spec.
+  {{ProgramName}} ejectmedia fedora-vm --disk-name=cdrom1 --persist
+  `
+}
+
+func (o *Command) insertMediaRun(args []string) error {
+	var dryRunOption []string
+	vmiName := args[0]
+
+	virtClient, namespace, err := GetNamespaceAndClient(o.clientConfig)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		dryRunOption = []string{metav1.DryRunAll}
+		fmt.Printf("Dry Run execution\n")
+	}
+
+	volumeSource, err := getVolumeSourceFromVolume(volumeName, namespace, virtClient)
+	if err != nil {
+		return fmt.Errorf("error inserting media, %v", err)
+	}
+	insertRequest := &v1.InsertMediaOptions{
+		Name:         diskName,
+		VolumeSource: volumeSource,
+		DryRun:       dryRunOption,
+	}
+	if !persist {
+		err = virtClient.VirtualMachineInstance(namespace).InsertMedia(context.Background(), vmiName, insertRequest)
+	} else {
+		err = virtClient.VirtualMachine(namespace).InsertMedia(context.Background(), vmiName, insertRequest)
+	}
+	if err != nil {
+		return fmt.Errorf("error inserting media, %v", err)
+	}
+	fmt.Printf("Successfully submitted insert media request to VM %s for cdrom %s\n", vmiName, diskName)
+	return nil
+}
+
+func (o *Command) ejectMediaRun(args []string) error {
+	var dryRunOption []string
+	vmiName := args[0]
+
+	virtClient, namespace, err := GetNamespaceAndClient(o.clientConfig)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		dryRunOption = []string{metav1.DryRunAll}
+		fmt.Printf("Dry Run execution\n")
+	}
+
+	ejectRequest := &v1.EjectMediaOptions{
+		Name:   diskName,
+		DryRun: dryRunOption,
+	}
+	if !persist {
+		err = virtClient.VirtualMachineInstance(namespace).EjectMedia(context.Background(), vmiName, ejectRequest)
+	} else {
+		err = virtClient.VirtualMachine(namespace).EjectMedia(context.Background(), vmiName, ejectRequest)
+	}
+	if err != nil {
+		return fmt.Errorf("error ejecting media, %v", err)
+	}
+	fmt.Printf("Successfully submitted eject media request to VM %s for cdrom %s\n", vmiName, diskName)
+	return nil
+}
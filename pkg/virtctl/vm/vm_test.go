@@ -570,6 +570,49 @@ var _ = Describe("VirtualMachine", func() {
 			Entry("removevolume pvc, with persist with dry-run should call VM endpoint", "removevolume", "testvmi", "testvolume", false, expectVMEndpointRemoveVolume, "--persist", "--dry-run"),
 		)
 
+		DescribeTable("addvolume should hotplug read-only volume sources", func(arg string, verify func(*v1.HotplugVolumeSource)) {
+			kubecli.MockKubevirtClientInstance.
+				EXPECT().
+				VirtualMachineInstance(k8smetav1.NamespaceDefault).
+				Return(vmiInterface).
+				Times(1)
+			vmiInterface.EXPECT().AddVolume(context.Background(), "testvmi", gomock.Any()).DoAndReturn(func(ctx context.Context, arg0, arg1 interface{}) interface{} {
+				Expect(arg1.(*v1.AddVolumeOptions).Name).To(Equal("testvolume"))
+				verify(arg1.(*v1.AddVolumeOptions).VolumeSource)
+				return nil
+			})
+			cmd := clientcmd.NewVirtctlCommand("addvolume", "testvmi", "--volume-name=testvolume", arg)
+			Expect(cmd.Execute()).To(Succeed())
+		},
+			Entry("with a containerDisk", "--container-disk=registry:5000/drivers:latest", func(source *v1.HotplugVolumeSource) {
+				Expect(source.ContainerDisk).ToNot(BeNil())
+				Expect(source.ContainerDisk.Image).To(Equal("registry:5000/drivers:latest"))
+				Expect(source.ContainerDisk.Hotpluggable).To(BeTrue())
+			}),
+			Entry("with a ConfigMap", "--config-map=test-config", func(source *v1.HotplugVolumeSource) {
+				Expect(source.ConfigMap).ToNot(BeNil())
+				Expect(source.ConfigMap.Name).To(Equal("test-config"))
+				Expect(source.ConfigMap.Hotpluggable).To(BeTrue())
+			}),
+			Entry("with a Secret", "--secret=test-secret", func(source *v1.HotplugVolumeSource) {
+				Expect(source.Secret).ToNot(BeNil())
+				Expect(source.Secret.SecretName).To(Equal("test-secret"))
+				Expect(source.Secret.Hotpluggable).To(BeTrue())
+			}),
+			Entry("with a ServiceAccount", "--service-account=test-sa", func(source *v1.HotplugVolumeSource) {
+				Expect(source.ServiceAccount).ToNot(BeNil())
+				Expect(source.ServiceAccount.ServiceAccountName).To(Equal("test-sa"))
+				Expect(source.ServiceAccount.Hotpluggable).To(BeTrue())
+			}),
+		)
+
+		It("addvolume should reject multiple read-only volume sources", func() {
+			cmd := clientcmd.NewRepeatableVirtctlCommand("addvolume", "testvmi", "--volume-name=testvolume", "--config-map=test-config", "--secret=test-secret")
+			res := cmd()
+			Expect(res).To(HaveOccurred())
+			Expect(res.Error()).To(ContainSubstring("if any flags in the group"))
+		})
+
 		DescribeTable("removevolume should report error if call returns error according to option", func(isDryRun bool) {
 			expectVMIEndpointRemoveVolumeError("testvmi", "testvolume")
 			commandAndArgs := []string{"removevolume", "testvmi", "--volume-name=testvolume"}
@@ -584,6 +627,147 @@ var _ = Describe("VirtualMachine", func() {
 			Entry("with default", false),
 			Entry("with dry-run arg", true),
 		)
+
+		DescribeTable("should fail media commands with missing required or invalid parameters", func(commandName, errorString string, args ...string) {
+			commandAndArgs := []string{commandName}
+			commandAndArgs = append(commandAndArgs, args...)
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandAndArgs...)
+			res := cmd()
+			Expect(res).To(HaveOccurred())
+			Expect(res.Error()).To(ContainSubstring(errorString))
+		},
+			Entry("insertmedia no args", "insertmedia", "argument validation failed"),
+			Entry("insertmedia name, missing required disk-name", "insertmedia", "required flag(s)", "testvmi", "--volume-name=blah"),
+			Entry("insertmedia name, missing required volume-name", "insertmedia", "required flag(s)", "testvmi", "--disk-name=cdrom"),
+			Entry("ejectmedia no args", "ejectmedia", "argument validation failed"),
+			Entry("ejectmedia name, missing required disk-name", "ejectmedia", "required flag(s)", "testvmi"),
+			Entry("ejectmedia name, invalid extra parameter", "ejectmedia", "unknown flag", "testvmi", "--disk-name=cdrom", "--invalid=test"),
+		)
+
+		It("should fail insertmedia when no source is found", func() {
+			kubecli.MockKubevirtClientInstance.EXPECT().CdiClient().Return(cdiClient)
+			kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(coreClient.CoreV1())
+			cmd := clientcmd.NewRepeatableVirtctlCommand("insertmedia", "testvmi", "--disk-name=cdrom", "--volume-name=testvolume")
+			res := cmd()
+			Expect(res).To(HaveOccurred())
+			Expect(res.Error()).To(ContainSubstring("Volume testvolume is not a DataVolume or PersistentVolumeClaim"))
+		})
+
+		DescribeTable("should call correct insertmedia endpoint", func(useDv, usePersist bool) {
+			kubecli.MockKubevirtClientInstance.EXPECT().CdiClient().Return(cdiClient)
+			if useDv {
+				cdiClient.CdiV1beta1().DataVolumes(k8smetav1.NamespaceDefault).Create(context.Background(), createTestDataVolume(), k8smetav1.CreateOptions{})
+			} else {
+				kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(coreClient.CoreV1())
+				coreClient.CoreV1().PersistentVolumeClaims(k8smetav1.NamespaceDefault).Create(context.Background(), createTestPVC(), k8smetav1.CreateOptions{})
+			}
+			verifyInsert := func(ctx context.Context, name string, opts *v1.InsertMediaOptions) error {
+				Expect(opts.Name).To(Equal("cdrom"))
+				if useDv {
+					Expect(opts.VolumeSource.DataVolume.Name).To(Equal("testvolume"))
+				} else {
+					Expect(opts.VolumeSource.PersistentVolumeClaim.ClaimName).To(Equal("testvolume"))
+				}
+				return nil
+			}
+			commandAndArgs := []string{"insertmedia", "testvmi", "--disk-name=cdrom", "--volume-name=testvolume"}
+			if usePersist {
+				kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface)
+				vmInterface.EXPECT().InsertMedia(context.Background(), "testvmi", gomock.Any()).DoAndReturn(verifyInsert)
+				commandAndArgs = append(commandAndArgs, "--persist")
+			} else {
+				kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface)
+				vmiInterface.EXPECT().InsertMedia(context.Background(), "testvmi", gomock.Any()).DoAndReturn(verifyInsert)
+			}
+			cmd := clientcmd.NewVirtctlCommand(commandAndArgs...)
+			Expect(cmd.Execute()).To(Succeed())
+		},
+			Entry("dv, no persist should call VMI endpoint", true, false),
+			Entry("pvc, no persist should call VMI endpoint", false, false),
+			Entry("dv, with persist should call VM endpoint", true, true),
+			Entry("pvc, with persist should call VM endpoint", false, true),
+		)
+
+		DescribeTable("should call correct ejectmedia endpoint", func(usePersist bool) {
+			verifyEject := func(ctx context.Context, name string, opts *v1.EjectMediaOptions) error {
+				Expect(opts.Name).To(Equal("cdrom"))
+				return nil
+			}
+			commandAndArgs := []string{"ejectmedia", "testvmi", "--disk-name=cdrom"}
+			if usePersist {
+				kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface)
+				vmInterface.EXPECT().EjectMedia(context.Background(), "testvmi", gomock.Any()).DoAndReturn(verifyEject)
+				commandAndArgs = append(commandAndArgs, "--persist")
+			} else {
+				kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface)
+				vmiInterface.EXPECT().EjectMedia(context.Background(), "testvmi", gomock.Any()).DoAndReturn(verifyEject)
+			}
+			cmd := clientcmd.NewVirtctlCommand(commandAndArgs...)
+			Expect(cmd.Execute()).To(Succeed())
+		},
+			Entry("no persist should call VMI endpoint", false),
+			Entry("with persist should call VM endpoint", true),
+		)
+
+		DescribeTable("should fail filesystem commands with missing required parameters", func(commandName, errorString string, args ...string) {
+			commandAndArgs := []string{commandName}
+			commandAndArgs = append(commandAndArgs, args...)
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandAndArgs...)
+			res := cmd()
+			Expect(res).To(HaveOccurred())
+			Expect(res.Error()).To(ContainSubstring(errorString))
+		},
+			Entry("addfilesystem no args", "addfilesystem", "argument validation failed"),
+			Entry("addfilesystem name, missing required volume-name", "addfilesystem", "required flag(s)", "testvmi"),
+			Entry("removefilesystem no args", "removefilesystem", "argument validation failed"),
+			Entry("removefilesystem name, missing required filesystem-name", "removefilesystem", "required flag(s)", "testvmi"),
+		)
+
+		DescribeTable("should call correct addfilesystem endpoint", func(filesystemNameArg, expectedName string, usePersist bool) {
+			kubecli.MockKubevirtClientInstance.EXPECT().CdiClient().Return(cdiClient)
+			kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(coreClient.CoreV1())
+			coreClient.CoreV1().PersistentVolumeClaims(k8smetav1.NamespaceDefault).Create(context.Background(), createTestPVC(), k8smetav1.CreateOptions{})
+			verifyAdd := func(ctx context.Context, name string, opts *v1.AddFilesystemOptions) error {
+				Expect(opts.Name).To(Equal(expectedName))
+				Expect(opts.VolumeSource.PersistentVolumeClaim.ClaimName).To(Equal("testvolume"))
+				return nil
+			}
+			commandAndArgs := []string{"addfilesystem", "testvmi", "--volume-name=testvolume"}
+			if filesystemNameArg != "" {
+				commandAndArgs = append(commandAndArgs, "--filesystem-name="+filesystemNameArg)
+			}
+			if usePersist {
+				kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface)
+				vmInterface.EXPECT().AddFilesystem(context.Background(), "testvmi", gomock.Any()).DoAndReturn(verifyAdd)
+				commandAndArgs = append(commandAndArgs, "--persist")
+			} else {
+				kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface)
+				vmiInterface.EXPECT().AddFilesystem(context.Background(), "testvmi", gomock.Any()).DoAndReturn(verifyAdd)
+			}
+			cmd := clientcmd.NewVirtctlCommand(commandAndArgs...)
+			Expect(cmd.Execute()).To(Succeed())
+		},
+			Entry("defaulting the filesystem name to the volume name, no persist should call VMI endpoint", "", "testvolume", false),
+			Entry("with an explicit filesystem name, no persist should call VMI endpoint", "dataset", "dataset", false),
+			Entry("with persist should call VM endpoint", "dataset", "dataset", true),
+		)
+
+		DescribeTable("should call correct removefilesystem endpoint", func(usePersist bool) {
+			commandAndArgs := []string{"removefilesystem", "testvmi", "--filesystem-name=dataset"}
+			if usePersist {
+				kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface)
+				vmInterface.EXPECT().RemoveFilesystem(context.Background(), "testvmi", &v1.RemoveFilesystemOptions{Name: "dataset"}).Return(nil)
+				commandAndArgs = append(commandAndArgs, "--persist")
+			} else {
+				kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface)
+				vmiInterface.EXPECT().RemoveFilesystem(context.Background(), "testvmi", &v1.RemoveFilesystemOptions{Name: "dataset"}).Return(nil)
+			}
+			cmd := clientcmd.NewVirtctlCommand(commandAndArgs...)
+			Expect(cmd.Execute()).To(Succeed())
+		},
+			Entry("no persist should call VMI endpoint", false),
+			Entry("with persist should call VM endpoint", true),
+		)
 	})
 
 	Context("Expand command", func() {
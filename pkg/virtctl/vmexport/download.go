@@ -0,0 +1,407 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package vmexport
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/cheggaaa/pb/v3"
+
+	exportv1 "kubevirt.io/api/export/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+)
+
+const (
+	// downloadRetries is the number of times an interrupted download is resumed
+	downloadRetries = 5
+
+	// reprDigestHeader is used by the export server to publish the digest of
+	// the complete volume, see RFC 9530
+	reprDigestHeader = "Repr-Digest"
+)
+
+// DownloadRetryInterval is the time to wait before resuming an interrupted download.
+// Useful for unit tests.
+var DownloadRetryInterval = 2 * time.Second
+
+// notResumableError marks errors which cannot be solved by resuming the download
+type notResumableError struct {
+	error
+}
+
+func notResumable(format string, a ...interface{}) error {
+	return &notResumableError{fmt.Errorf(format, a...)}
+}
+
+func isNotResumable(err error) bool {
+	var notResumableErr *notResumableError
+	return errors.As(err, &notResumableErr)
+}
+
+// errRestarted is returned if the server sent the whole volume instead of the
+// requested range, because its content changed or ranges are not supported
+var errRestarted = errors.New("the server sent the whole volume instead of the requested range")
+
+// rawDownloader downloads a raw volume with range requests, so interrupted
+// downloads can be resumed
+type rawDownloader struct {
+	client   kubecli.KubevirtClient
+	vmexport *exportv1.VirtualMachineExport
+	vmeInfo  *VMExportInfo
+	url      string
+
+	lock sync.Mutex
+	// etag identifies the content of the volume, it ensures that resumed
+	// downloads continue with the same content
+	etag string
+	// digest is the checksum of the volume published by the export server
+	digest string
+}
+
+// contentRange is the parsed Content-Range header of a partial response
+type contentRange struct {
+	start, end, size int64
+}
+
+func parseContentRange(value string) (*contentRange, error) {
+	var cr contentRange
+	if _, err := fmt.Sscanf(value, "bytes %d-%d/%d", &cr.start, &cr.end, &cr.size); err != nil {
+		return nil, fmt.Errorf("invalid Content-Range %q: %v", value, err)
+	}
+	return &cr, nil
+}
+
+// parseReprDigest extracts the hex encoded sha256 digest from a Repr-Digest header
+func parseReprDigest(value string) string {
+	for _, digest := range strings.Split(value, ",") {
+		algorithm, encoded, found := strings.Cut(strings.TrimSpace(digest), "=")
+		if !found || algorithm != "sha-256" {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(strings.Trim(encoded, ":"))
+		if err != nil {
+			continue
+		}
+		return hex.EncodeToString(decoded)
+	}
+	return ""
+}
+
+// get requests the bytes of the volume from start to end, inclusive. A
+// negative end requests everything from start on.
+func (d *rawDownloader) get(start, end int64) (*http.Response, error) {
+	headers := map[string]string{}
+	if start > 0 || end >= 0 {
+		byteRange := fmt.Sprintf("bytes=%d-", start)
+		if end >= 0 {
+			byteRange += strconv.FormatInt(end, 10)
+		}
+		headers["Range"] = byteRange
+		d.lock.Lock()
+		if d.etag != "" {
+			headers["If-Range"] = d.etag
+		}
+		d.lock.Unlock()
+	}
+	resp, err := HandleHTTPRequest(d.client, d.vmexport, d.url, d.vmeInfo.Insecure, d.vmeInfo.ServiceURL, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.etag == "" {
+		d.etag = resp.Header.Get("ETag")
+	}
+	if d.digest == "" {
+		d.digest = parseReprDigest(resp.Header.Get(reprDigestHeader))
+	}
+	return resp, nil
+}
+
+func newProgressBar(total, current int64) *pb.ProgressBar {
+	barTemplate := fmt.Sprintf(`{{ "Downloading file:" }} {{counters . }} {{ cycle . %s }} {{speed . }}`, progressBarCycle)
+	bar := pb.ProgressBarTemplate(barTemplate).New(0)
+	bar.SetTotal(total)
+	bar.SetCurrent(current)
+	return bar.Start()
+}
+
+// download downloads the volume sequentially. Downloads to a file continue
+// after the content already in the file, interrupted downloads are resumed.
+func (d *rawDownloader) download(output io.Writer) (hash.Hash, error) {
+	checksum := sha256.New()
+	var offset int64
+
+	file, isFile := output.(*os.File)
+	if isFile && d.vmeInfo.Resume {
+		var err error
+		if offset, err = file.Seek(0, io.SeekEnd); err != nil {
+			return nil, err
+		}
+		// The checksum covers the content already downloaded as well
+		if _, err := io.Copy(checksum, io.NewSectionReader(file, 0, offset)); err != nil {
+			return nil, err
+		}
+		if offset > 0 {
+			fmt.Fprintf(os.Stderr, "Resuming download of %s at %d bytes\n", d.vmeInfo.OutputFile, offset)
+		}
+	}
+
+	bar := newProgressBar(0, offset)
+	defer bar.Finish()
+
+	for attempt := 0; ; attempt++ {
+		n, err := d.downloadFrom(offset, output, checksum, bar)
+		offset += n
+		if err == nil {
+			return checksum, nil
+		}
+		if isNotResumable(err) || attempt == downloadRetries {
+			return nil, err
+		}
+		if errors.Is(err, errRestarted) {
+			if !isFile {
+				return nil, err
+			}
+			fmt.Fprintln(os.Stderr, "The content of the volume changed, restarting the download")
+			if err := file.Truncate(0); err != nil {
+				return nil, err
+			}
+			if _, err := file.Seek(0, io.SeekStart); err != nil {
+				return nil, err
+			}
+			checksum.Reset()
+			bar.SetCurrent(0)
+			offset = 0
+			d.lock.Lock()
+			d.etag = ""
+			d.lock.Unlock()
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Download interrupted at %d bytes, resuming: %v\n", offset, err)
+		time.Sleep(DownloadRetryInterval)
+	}
+}
+
+// downloadFrom downloads the volume from offset on and returns the number of
+// bytes written to output
+func (d *rawDownloader) downloadFrom(offset int64, output io.Writer, checksum hash.Hash, bar *pb.ProgressBar) (int64, error) {
+	resp, err := d.get(offset, -1)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if offset > 0 {
+			return 0, errRestarted
+		}
+		bar.SetTotal(resp.ContentLength)
+	case http.StatusPartialContent:
+		cr, err := parseContentRange(resp.Header.Get("Content-Range"))
+		if err != nil {
+			return 0, &notResumableError{err}
+		}
+		if cr.start != offset {
+			return 0, notResumable("got range starting at %d instead of %d", cr.start, offset)
+		}
+		bar.SetTotal(cr.size)
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The file is already complete if the server has nothing left to send
+		if value := resp.Header.Get("Content-Range"); value == fmt.Sprintf("bytes */%d", offset) {
+			bar.SetTotal(offset)
+			return 0, nil
+		}
+		return 0, notResumable("bad status: %s", resp.Status)
+	default:
+		return 0, notResumable("bad status: %s", resp.Status)
+	}
+
+	n, err := io.Copy(io.MultiWriter(output, checksum), bar.NewProxyReader(resp.Body))
+	if err == nil && resp.ContentLength >= 0 && n != resp.ContentLength {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+// offsetWriter writes to a file sequentially, starting at an offset
+type offsetWriter struct {
+	file   *os.File
+	offset int64
+}
+
+func (w *offsetWriter) Write(p []byte) (int, error) {
+	n, err := w.file.WriteAt(p, w.offset)
+	w.offset += int64(n)
+	return n, err
+}
+
+// downloadParallel downloads chunks of the volume in parallel. Each chunk is
+// resumed on its own if interrupted.
+func (d *rawDownloader) downloadParallel(file *os.File, parallel int) (hash.Hash, error) {
+	// Get the size of the volume and the etag with a request for the first byte
+	resp, err := d.get(0, 0)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent {
+		if resp.StatusCode == http.StatusOK {
+			// Ranges are not supported by the server
+			return d.download(file)
+		}
+		return nil, fmt.Errorf("bad status: %s", resp.Status)
+	}
+	cr, err := parseContentRange(resp.Header.Get("Content-Range"))
+	if err != nil {
+		return nil, err
+	}
+	size := cr.size
+	if err := file.Truncate(size); err != nil {
+		return nil, err
+	}
+
+	bar := newProgressBar(size, 0)
+	defer bar.Finish()
+
+	chunkSize := (size + int64(parallel) - 1) / int64(parallel)
+	var (
+		wg       sync.WaitGroup
+		errsLock sync.Mutex
+		errs     []error
+	)
+	for start := int64(0); start < size; start += chunkSize {
+		start, end := start, start+chunkSize-1
+		if end >= size {
+			end = size - 1
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := d.downloadChunk(file, start, end, bar); err != nil {
+				errsLock.Lock()
+				errs = append(errs, err)
+				errsLock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	checksum := sha256.New()
+	if _, err := io.Copy(checksum, io.NewSectionReader(file, 0, size)); err != nil {
+		return nil, err
+	}
+	return checksum, nil
+}
+
+func (d *rawDownloader) downloadChunk(file *os.File, start, end int64, bar *pb.ProgressBar) error {
+	offset := start
+	for attempt := 0; offset <= end; attempt++ {
+		n, err := d.downloadChunkFrom(file, offset, end, bar)
+		offset += n
+		if err == nil {
+			continue
+		}
+		if isNotResumable(err) || attempt == downloadRetries {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Download of bytes %d-%d interrupted at %d bytes, resuming: %v\n", start, end, offset, err)
+		time.Sleep(DownloadRetryInterval)
+	}
+	return nil
+}
+
+func (d *rawDownloader) downloadChunkFrom(file *os.File, offset, end int64, bar *pb.ProgressBar) (int64, error) {
+	resp, err := d.get(offset, end)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusPartialContent {
+		// The whole volume is sent if its content changed
+		return 0, notResumable("bad status: %s", resp.Status)
+	}
+	cr, err := parseContentRange(resp.Header.Get("Content-Range"))
+	if err != nil {
+		return 0, &notResumableError{err}
+	}
+	if cr.start != offset || cr.end != end {
+		return 0, notResumable("got range %d-%d instead of %d-%d", cr.start, cr.end, offset, end)
+	}
+
+	n, err := io.Copy(&offsetWriter{file: file, offset: offset}, bar.NewProxyReader(resp.Body))
+	if err == nil && n != end-offset+1 {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+// downloadRawVolume downloads a volume in raw format and verifies it against
+// the checksum published by the export server, if available
+func downloadRawVolume(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo, downloadUrl, digest string) error {
+	d := &rawDownloader{
+		client:   client,
+		vmexport: vmexport,
+		vmeInfo:  vmeInfo,
+		url:      downloadUrl,
+		digest:   digest,
+	}
+
+	var (
+		checksum hash.Hash
+		err      error
+	)
+	if file, ok := vmeInfo.OutputWriter.(*os.File); ok && vmeInfo.Parallel > 1 {
+		checksum, err = d.downloadParallel(file, vmeInfo.Parallel)
+	} else {
+		checksum, err = d.download(vmeInfo.OutputWriter)
+	}
+	if err != nil {
+		return err
+	}
+
+	if d.digest == "" {
+		fmt.Fprintln(os.Stderr, "The checksum of the volume is not available yet, skipping verification")
+		return nil
+	}
+	if actual := hex.EncodeToString(checksum.Sum(nil)); actual != d.digest {
+		return fmt.Errorf("checksum mismatch: expected sha256 %s, got %s", d.digest, actual)
+	}
+	fmt.Fprintln(os.Stderr, "Checksum verified successfully")
+	return nil
+}
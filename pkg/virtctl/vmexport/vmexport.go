@@ -70,11 +70,18 @@ const (
 	OUTPUT_FORMAT_FLAG  = "--manifest-output-format"
 	SERVICE_URL_FLAG    = "--service-url"
 	INCLUDE_SECRET_FLAG = "--include-secret"
+	FORMAT_FLAG         = "--format"
+	RESUME_FLAG         = "--resume"
+	PARALLEL_FLAG       = "--parallel"
 
 	// Possible output format for manifests
 	OUTPUT_FORMAT_JSON = "json"
 	OUTPUT_FORMAT_YAML = "yaml"
 
+	// Possible formats of the downloaded volume
+	FORMAT_GZIP = "gzip"
+	FORMAT_RAW  = "raw"
+
 	ACCEPT           = "Accept"
 	APPLICATION_YAML = "application/yaml"
 	APPLICATION_JSON = "application/json"
@@ -103,6 +110,8 @@ const (
 	ErrIncompatibleExportTypeManifest = "cannot get manifest for PVC export"
 	// ErrInvalidValue ensures that the value provided in a flag is one of the acceptable values
 	ErrInvalidValue = "%s is not a valid value, acceptable values are %s"
+	// ErrRequiredRawFormat serves as error message when a flag is only supported when downloading the raw format
+	ErrRequiredRawFormat = "the '%s' flag is only supported with '--format=raw'"
 
 	// progressBarCycle is a const used to store the cycle displayed in the progress bar when downloading the exported volume
 	progressBarCycle = `"[___________________]" "[==>________________]" "[====>______________]" "[======>____________]" "[========>__________]" "[==========>________]" "[============>______]" "[==============>____]" "[================>__]" "[==================>]"`
@@ -123,6 +132,9 @@ var (
 	volumeName           string
 	ttl                  string
 	manifestOutputFormat string
+	format               string
+	resume               bool
+	parallel             int
 )
 
 type exportFunc func(client kubecli.KubevirtClient, vmeInfo *VMExportInfo) error
@@ -150,6 +162,9 @@ type VMExportInfo struct {
 	ServiceURL     string
 	ExportSource   k8sv1.TypedLocalObjectReference
 	TTL            metav1.Duration
+	Format         string
+	Resume         bool
+	Parallel       int
 }
 
 type command struct {
@@ -195,6 +210,12 @@ func usage() string {
 	# Create a VirtualMachineExport and download the requested volume from it
 	{{ProgramName}} vmexport download vm1-export --vm=vm1 --volume=volume1 --output=disk.img.gz
 
+	# Download a volume in raw format with 4 parallel connections, the download is verified against the checksum published by the export
+	{{ProgramName}} vmexport download vm1-export --volume=volume1 --output=disk.img --format=raw --parallel=4
+
+	# Resume an interrupted download of a volume in raw format
+	{{ProgramName}} vmexport download vm1-export --volume=volume1 --output=disk.img --format=raw --resume
+
 	# Create a VirtualMachineExport and get the VirtualMachine manifest in Yaml format
 	{{ProgramName}} vmexport download vm1-export --vm=vm1 --manifest
 
@@ -229,6 +250,9 @@ func NewVirtualMachineExportCommand(clientConfig clientcmd.ClientConfig) *cobra.
 	cmd.Flags().StringVar(&serviceUrl, "service-url", "", "Specify service url to use in the returned manifest, instead of the external URL in the Virtual Machine export status. This is useful for NodePorts or if you don't have an external URL configured")
 	cmd.Flags().BoolVar(&includeSecret, "include-secret", false, "When used with manifest and set to true include a secret that contains proper headers for CDI to import using the manifest")
 	cmd.Flags().BoolVar(&exportManifest, "manifest", false, "Instead of downloading a volume, retrieve the VM manifest")
+	cmd.Flags().StringVar(&format, "format", "", "Format of the downloaded volume, defaults to gzip. Valid options are gzip or raw. Raw downloads are resumed when interrupted and verified against the checksum published by the export")
+	cmd.Flags().BoolVar(&resume, "resume", false, "When used with '--format=raw', continue a previous download into the file specified by '--output' instead of overwriting it")
+	cmd.Flags().IntVar(&parallel, "parallel", 1, "When used with '--format=raw', the number of parallel connections used to download the volume into the file specified by '--output'")
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 
 	return cmd
@@ -297,9 +321,13 @@ func (c *command) parseExportArguments(args []string, vmeInfo *VMExportInfo) err
 	// We store the flags in a struct to avoid relying on global variables
 	vmeInfo.ExportSource = getExportSource()
 	vmeInfo.OutputFile = outputFile
-	// User wants the output in a file, create
+	// User wants the output in a file, create it unless resuming a download
 	if outputFile != "" {
-		output, err := os.Create(vmeInfo.OutputFile)
+		flags := os.O_RDWR | os.O_CREATE
+		if !resume {
+			flags |= os.O_TRUNC
+		}
+		output, err := os.OpenFile(vmeInfo.OutputFile, flags, 0666)
 		if err != nil {
 			return err
 		}
@@ -315,6 +343,9 @@ func (c *command) parseExportArguments(args []string, vmeInfo *VMExportInfo) err
 	vmeInfo.OutputFormat = manifestOutputFormat
 	vmeInfo.IncludeSecret = includeSecret
 	vmeInfo.ExportManifest = exportManifest
+	vmeInfo.Format = format
+	vmeInfo.Resume = resume
+	vmeInfo.Parallel = parallel
 	vmeInfo.TTL = metav1.Duration{}
 	if ttl != "" {
 		duration, err := time.ParseDuration(ttl)
@@ -478,10 +509,24 @@ func getVirtualMachineManifest(client kubecli.KubevirtClient, vmexport *exportv1
 // downloadVolume handles the process of downloading the requested volume from a VirtualMachineExport
 func downloadVolume(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo) error {
 	// Extract the URL from the vmexport
-	downloadUrl, err := GetUrlFromVirtualMachineExport(vmexport, vmeInfo)
+	volumeFormat, err := getVolumeFormatFromVirtualMachineExport(vmexport, vmeInfo)
 	if err != nil {
 		return err
 	}
+	downloadUrl, err := replaceUrlWithServiceUrl(volumeFormat.Url, vmeInfo)
+	if err != nil {
+		return err
+	}
+
+	if volumeFormat.Format == exportv1.KubeVirtRaw {
+		if err := downloadRawVolume(client, vmexport, vmeInfo, downloadUrl, volumeFormat.Sha256); err != nil {
+			return err
+		}
+		if vmeInfo.OutputFile != "" {
+			fmt.Println("Download finished succesfully")
+		}
+		return nil
+	}
 
 	resp, err := HandleHTTPRequest(client, vmexport, downloadUrl, vmeInfo.Insecure, vmeInfo.ServiceURL, nil)
 	if err != nil {
@@ -520,10 +565,18 @@ func replaceUrlWithServiceUrl(manifestUrl string, vmeInfo *VMExportInfo) (string
 
 // GetUrlFromVirtualMachineExport inspects the VirtualMachineExport status to fetch the extected URL
 func GetUrlFromVirtualMachineExport(vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo) (string, error) {
+	volumeFormat, err := getVolumeFormatFromVirtualMachineExport(vmexport, vmeInfo)
+	if err != nil {
+		return "", err
+	}
+	return replaceUrlWithServiceUrl(volumeFormat.Url, vmeInfo)
+}
+
+// getVolumeFormatFromVirtualMachineExport inspects the VirtualMachineExport status to fetch the expected volume format
+func getVolumeFormatFromVirtualMachineExport(vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo) (*exportv1.VirtualMachineExportVolumeFormat, error) {
 	var (
-		downloadUrl string
-		err         error
-		links       *exportv1.VirtualMachineExportLink
+		volumeFormat *exportv1.VirtualMachineExportVolumeFormat
+		links        *exportv1.VirtualMachineExportLink
 	)
 
 	if vmeInfo.ServiceURL == "" && vmexport.Status.Links != nil && vmexport.Status.Links.External != nil {
@@ -532,35 +585,38 @@ func GetUrlFromVirtualMachineExport(vmexport *exportv1.VirtualMachineExport, vme
 		links = vmexport.Status.Links.Internal
 	}
 	if links == nil || len(links.Volumes) <= 0 {
-		return "", fmt.Errorf("unable to access the volume info from '%s/%s' VirtualMachineExport", vmexport.Namespace, vmexport.Name)
+		return nil, fmt.Errorf("unable to access the volume info from '%s/%s' VirtualMachineExport", vmexport.Namespace, vmexport.Name)
 	}
 	volumeNumber := len(links.Volumes)
 	if volumeNumber > 1 && vmeInfo.VolumeName == "" {
-		return "", fmt.Errorf("detected more than one downloadable volume in '%s/%s' VirtualMachineExport: Select the expected volume using the --volume flag", vmexport.Namespace, vmexport.Name)
+		return nil, fmt.Errorf("detected more than one downloadable volume in '%s/%s' VirtualMachineExport: Select the expected volume using the --volume flag", vmexport.Namespace, vmexport.Name)
 	}
 	for _, exportVolume := range links.Volumes {
 		// Access the requested volume
 		if volumeNumber == 1 || exportVolume.Name == vmeInfo.VolumeName {
-			for _, format := range exportVolume.Formats {
-				// We always attempt to find and get the compressed file URL, so we only break the loop when one is found
-				if format.Format == exportv1.KubeVirtGz || format.Format == exportv1.ArchiveGz || format.Format == exportv1.KubeVirtRaw {
-					downloadUrl, err = replaceUrlWithServiceUrl(format.Url, vmeInfo)
-					if err != nil {
-						return "", err
+			for i, f := range exportVolume.Formats {
+				if vmeInfo.Format == FORMAT_RAW {
+					if f.Format == exportv1.KubeVirtRaw {
+						volumeFormat = &exportVolume.Formats[i]
 					}
+					continue
+				}
+				// We always attempt to find and get the compressed file URL, so we only break the loop when one is found
+				if f.Format == exportv1.KubeVirtGz || f.Format == exportv1.ArchiveGz || f.Format == exportv1.KubeVirtRaw {
+					volumeFormat = &exportVolume.Formats[i]
 				}
-				if format.Format == exportv1.KubeVirtGz || format.Format == exportv1.ArchiveGz {
+				if f.Format == exportv1.KubeVirtGz || f.Format == exportv1.ArchiveGz {
 					break
 				}
 			}
 		}
 	}
 
-	if downloadUrl == "" {
-		return "", fmt.Errorf("unable to get a valid URL from '%s/%s' VirtualMachineExport", vmexport.Namespace, vmexport.Name)
+	if volumeFormat == nil {
+		return nil, fmt.Errorf("unable to get a valid URL from '%s/%s' VirtualMachineExport", vmexport.Namespace, vmexport.Name)
 	}
 
-	return downloadUrl, nil
+	return volumeFormat, nil
 }
 
 // GetManifestUrlsFromVirtualMachineExport retrieves the manifest URLs from VirtualMachineExport status
@@ -794,6 +850,12 @@ func handleCreateFlags() error {
 	if serviceUrl != "" {
 		return fmt.Errorf(ErrIncompatibleFlag, SERVICE_URL_FLAG, CREATE)
 	}
+	if format != "" {
+		return fmt.Errorf(ErrIncompatibleFlag, FORMAT_FLAG, CREATE)
+	}
+	if resume {
+		return fmt.Errorf(ErrIncompatibleFlag, RESUME_FLAG, CREATE)
+	}
 
 	return nil
 }
@@ -845,6 +907,32 @@ func handleDownloadFlags() error {
 		}
 	}
 
+	format = strings.ToLower(format)
+	if format != FORMAT_GZIP && format != FORMAT_RAW && format != "" {
+		return fmt.Errorf(ErrInvalidValue, FORMAT_FLAG, "gzip/raw")
+	}
+	if parallel < 1 {
+		return fmt.Errorf(ErrInvalidValue, PARALLEL_FLAG, "positive numbers")
+	}
+	if resume || parallel > 1 {
+		flag := RESUME_FLAG
+		if !resume {
+			flag = PARALLEL_FLAG
+		}
+		if exportManifest {
+			return fmt.Errorf(ErrIncompatibleFlag, flag, MANIFEST_FLAG)
+		}
+		if format != FORMAT_RAW {
+			return fmt.Errorf(ErrRequiredRawFormat, flag)
+		}
+		if outputFile == "" {
+			return fmt.Errorf(ErrRequiredFlag, OUTPUT_FLAG, flag)
+		}
+	}
+	if resume && parallel > 1 {
+		return fmt.Errorf(ErrIncompatibleFlag, PARALLEL_FLAG, RESUME_FLAG)
+	}
+
 	return nil
 }
 
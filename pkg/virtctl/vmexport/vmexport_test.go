@@ -1,11 +1,19 @@
 package vmexport_test
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
+	"testing/iotest"
 	"time"
 
 	"github.com/golang/mock/gomock"
@@ -281,6 +289,13 @@ var _ = Describe("vmexport", func() {
 			Entry("Using 'manifest' with pvc flag", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.PVC_FLAG, virtctlvmexport.MANIFEST_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.MANIFEST_FLAG, setflag(virtctlvmexport.PVC_FLAG, "test")),
 			Entry("Using 'manifest' with volume type", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.VOLUME_FLAG, virtctlvmexport.MANIFEST_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.MANIFEST_FLAG, setflag(virtctlvmexport.VM_FLAG, "test"), setflag(virtctlvmexport.VOLUME_FLAG, "volume")),
 			Entry("Using 'manifest' with invalid output_format_flag", fmt.Sprintf(virtctlvmexport.ErrInvalidValue, virtctlvmexport.OUTPUT_FORMAT_FLAG, "json/yaml"), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.MANIFEST_FLAG, setflag(virtctlvmexport.OUTPUT_FORMAT_FLAG, "invalid")),
+			Entry("Using 'download' with invalid format", fmt.Sprintf(virtctlvmexport.ErrInvalidValue, virtctlvmexport.FORMAT_FLAG, "gzip/raw"), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.FORMAT_FLAG, "qcow2")),
+			Entry("Using 'download' with invalid parallel", fmt.Sprintf(virtctlvmexport.ErrInvalidValue, virtctlvmexport.PARALLEL_FLAG, "positive numbers"), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.PARALLEL_FLAG, "0")),
+			Entry("Using 'resume' without raw format", fmt.Sprintf(virtctlvmexport.ErrRequiredRawFormat, virtctlvmexport.RESUME_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.OUTPUT_FLAG, "disk.img"), virtctlvmexport.RESUME_FLAG),
+			Entry("Using 'resume' without output", fmt.Sprintf(virtctlvmexport.ErrRequiredFlag, virtctlvmexport.OUTPUT_FLAG, virtctlvmexport.RESUME_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.FORMAT_RAW), virtctlvmexport.RESUME_FLAG),
+			Entry("Using 'parallel' without raw format", fmt.Sprintf(virtctlvmexport.ErrRequiredRawFormat, virtctlvmexport.PARALLEL_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.OUTPUT_FLAG, "disk.img"), setflag(virtctlvmexport.PARALLEL_FLAG, "4")),
+			Entry("Using 'parallel' with 'resume'", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.PARALLEL_FLAG, virtctlvmexport.RESUME_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.OUTPUT_FLAG, "disk.img"), setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.FORMAT_RAW), setflag(virtctlvmexport.PARALLEL_FLAG, "4"), virtctlvmexport.RESUME_FLAG),
+			Entry("Using 'create' with format", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.FORMAT_FLAG, virtctlvmexport.CREATE), virtctlvmexport.CREATE, vmexportName, setflag(virtctlvmexport.PVC_FLAG, "test"), setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.FORMAT_RAW)),
 		)
 
 		AfterEach(func() {
@@ -642,6 +657,146 @@ var _ = Describe("vmexport", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+	Context("Raw download", func() {
+		const rawUrl = "https://test.something.somewhere/volumes/test-pvc/disk.img"
+
+		var (
+			orgHttpFunc         virtctlvmexport.HandleHTTPRequestFunc
+			orgRetryInterval    time.Duration
+			diskContent         []byte
+			diskChecksum        string
+			outputPath          string
+			requestedRanges     []string
+			requestedRangesLock sync.Mutex
+			failAfter           int
+			digestHeader        string
+		)
+
+		serveDisk := func(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, downloadUrl string, insecure bool, exportURL string, headers map[string]string) (*http.Response, error) {
+			Expect(downloadUrl).To(Equal(rawUrl))
+			req := httptest.NewRequest(http.MethodGet, downloadUrl, nil)
+			for k, v := range headers {
+				req.Header.Set(k, v)
+			}
+			requestedRangesLock.Lock()
+			requestedRanges = append(requestedRanges, req.Header.Get("Range"))
+			requestedRangesLock.Unlock()
+
+			recorder := httptest.NewRecorder()
+			recorder.Header().Set("ETag", `"disk"`)
+			if digestHeader != "" {
+				recorder.Header().Set("Repr-Digest", digestHeader)
+			}
+			http.ServeContent(recorder, req, "disk.img", time.Time{}, bytes.NewReader(diskContent))
+			resp := recorder.Result()
+			if failAfter > 0 {
+				// Interrupt the first response
+				body, err := io.ReadAll(resp.Body)
+				Expect(err).ToNot(HaveOccurred())
+				resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body[:failAfter]), iotest.ErrReader(fmt.Errorf("connection reset"))))
+				failAfter = 0
+			}
+			return resp, nil
+		}
+
+		setupExport := func(checksum string) {
+			vmexport := utils.VMExportSpecPVC(vmexportName, metav1.NamespaceDefault, "test-pvc", secretName)
+			vmexport.Status = utils.GetVMEStatus([]exportv1.VirtualMachineExportVolume{
+				{
+					Name: volumeName,
+					Formats: []exportv1.VirtualMachineExportVolumeFormat{
+						{Format: exportv1.KubeVirtRaw, Url: rawUrl, Sha256: checksum},
+						{Format: exportv1.KubeVirtGz, Url: rawUrl + ".gz"},
+					},
+				},
+			}, secretName)
+			utils.HandleVMExportGet(vmExportClient, vmexport, vmexportName)
+			utils.HandleSecretGet(kubeClient, secretName)
+		}
+
+		download := func(args ...string) error {
+			args = append([]string{commandName, virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.OUTPUT_FLAG, outputPath), setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.FORMAT_RAW), virtctlvmexport.KEEP_FLAG}, args...)
+			return clientcmd.NewRepeatableVirtctlCommand(args...)()
+		}
+
+		BeforeEach(func() {
+			orgHttpFunc = virtctlvmexport.HandleHTTPRequest
+			orgRetryInterval = virtctlvmexport.DownloadRetryInterval
+			virtctlvmexport.HandleHTTPRequest = serveDisk
+			virtctlvmexport.DownloadRetryInterval = 0
+			testInit(http.StatusOK)
+
+			diskContent = bytes.Repeat([]byte("0123456789abcdef"), 1024)
+			sum := sha256.Sum256(diskContent)
+			diskChecksum = hex.EncodeToString(sum[:])
+			outputPath = filepath.Join(GinkgoT().TempDir(), "disk.img")
+			requestedRanges = nil
+			failAfter = 0
+			digestHeader = ""
+		})
+
+		AfterEach(func() {
+			virtctlvmexport.HandleHTTPRequest = orgHttpFunc
+			virtctlvmexport.DownloadRetryInterval = orgRetryInterval
+			testDone()
+		})
+
+		expectDownloaded := func() {
+			content, err := os.ReadFile(outputPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(content).To(Equal(diskContent))
+		}
+
+		It("should download and verify the volume", func() {
+			setupExport(diskChecksum)
+			Expect(download()).To(Succeed())
+			expectDownloaded()
+			Expect(requestedRanges).To(Equal([]string{""}))
+		})
+
+		It("should fail if the checksum does not match", func() {
+			setupExport(strings.Repeat("0", 64))
+			err := download()
+			Expect(err).To(MatchError(ContainSubstring("checksum mismatch")))
+		})
+
+		It("should verify the volume with the digest sent by the server", func() {
+			setupExport("")
+			digestHeader = "sha-256=:" + base64.StdEncoding.EncodeToString([]byte("not the checksum")) + ":"
+			err := download()
+			Expect(err).To(MatchError(ContainSubstring("checksum mismatch")))
+		})
+
+		It("should resume an interrupted download", func() {
+			setupExport(diskChecksum)
+			failAfter = 1000
+			Expect(download()).To(Succeed())
+			expectDownloaded()
+			Expect(requestedRanges).To(Equal([]string{"", "bytes=1000-"}))
+		})
+
+		It("should continue a previous download with --resume", func() {
+			setupExport(diskChecksum)
+			Expect(os.WriteFile(outputPath, diskContent[:4096], 0644)).To(Succeed())
+			Expect(download(virtctlvmexport.RESUME_FLAG)).To(Succeed())
+			expectDownloaded()
+			Expect(requestedRanges).To(Equal([]string{"bytes=4096-"}))
+		})
+
+		It("should accept an already complete download with --resume", func() {
+			setupExport(diskChecksum)
+			Expect(os.WriteFile(outputPath, diskContent, 0644)).To(Succeed())
+			Expect(download(virtctlvmexport.RESUME_FLAG)).To(Succeed())
+			expectDownloaded()
+		})
+
+		It("should download chunks in parallel", func() {
+			setupExport(diskChecksum)
+			Expect(download(setflag(virtctlvmexport.PARALLEL_FLAG, "4"))).To(Succeed())
+			expectDownloaded()
+			Expect(requestedRanges).To(ConsistOf("bytes=0-0", "bytes=0-4095", "bytes=4096-8191", "bytes=8192-12287", "bytes=12288-16383"))
+		})
+	})
 })
 
 func handleVMExportDelete(client *kubevirtfake.Clientset, name string) {
@@ -0,0 +1,353 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package vmimport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CIM resource types used in the virtual hardware section of an OVF descriptor
+const (
+	resourceTypeProcessor       = 3
+	resourceTypeMemory          = 4
+	resourceTypeIDEController   = 5
+	resourceTypeSCSIController  = 6
+	resourceTypeEthernetAdapter = 10
+	resourceTypeDisk            = 17
+	resourceTypeSATAController  = 20
+)
+
+const diskHostResourcePrefix = "ovf:/disk/"
+
+type envelope struct {
+	References     []fileReference `xml:"References>File"`
+	Disks          []diskSection   `xml:"DiskSection>Disk"`
+	VirtualSystems []virtualSystem `xml:"VirtualSystem"`
+}
+
+type fileReference struct {
+	ID   string `xml:"id,attr"`
+	Href string `xml:"href,attr"`
+	Size int64  `xml:"size,attr"`
+}
+
+type diskSection struct {
+	DiskID                  string `xml:"diskId,attr"`
+	FileRef                 string `xml:"fileRef,attr"`
+	Capacity                string `xml:"capacity,attr"`
+	CapacityAllocationUnits string `xml:"capacityAllocationUnits,attr"`
+}
+
+type virtualSystem struct {
+	ID              string                 `xml:"id,attr"`
+	Name            string                 `xml:"Name"`
+	OperatingSystem operatingSystemSection `xml:"OperatingSystemSection"`
+	Hardware        virtualHardwareSection `xml:"VirtualHardwareSection"`
+}
+
+type operatingSystemSection struct {
+	OSType      string `xml:"osType,attr"`
+	Description string `xml:"Description"`
+}
+
+type virtualHardwareSection struct {
+	Items          []hardwareItem `xml:"Item"`
+	StorageItems   []hardwareItem `xml:"StorageItem"`
+	EthernetItems  []hardwareItem `xml:"EthernetPortItem"`
+	VendorSettings []vendorConfig `xml:"Config"`
+}
+
+// hardwareItem covers the CIM_ResourceAllocationSettingData elements as well as
+// the storage and ethernet port variants introduced with OVF 2.0, they share
+// the element names used here.
+type hardwareItem struct {
+	InstanceID      string `xml:"InstanceID"`
+	ElementName     string `xml:"ElementName"`
+	ResourceType    int    `xml:"ResourceType"`
+	ResourceSubType string `xml:"ResourceSubType"`
+	VirtualQuantity int64  `xml:"VirtualQuantity"`
+	AllocationUnits string `xml:"AllocationUnits"`
+	HostResource    string `xml:"HostResource"`
+	Parent          string `xml:"Parent"`
+	AddressOnParent string `xml:"AddressOnParent"`
+	Connection      string `xml:"Connection"`
+	Address         string `xml:"Address"`
+	CoresPerSocket  int64  `xml:"CoresPerSocket"`
+}
+
+type vendorConfig struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type busType string
+
+const (
+	busIDE   busType = "ide"
+	busSCSI  busType = "scsi"
+	busSATA  busType = "sata"
+	busOther busType = ""
+)
+
+const (
+	biosBoot = "bios"
+	efiBoot  = "efi"
+)
+
+// machine is the subset of an OVF virtual system which is needed to create a VirtualMachine
+type machine struct {
+	Name           string
+	OSType         string
+	CPUs           int64
+	CoresPerSocket int64
+	MemoryBytes    int64
+	Firmware       string
+	SecureBoot     bool
+	// Disks are in the order the guest sees them, the first disk is the boot disk
+	Disks []disk
+	NICs  []nic
+}
+
+type disk struct {
+	ID            string
+	File          string
+	CapacityBytes int64
+	Bus           busType
+}
+
+type nic struct {
+	Name       string
+	Network    string
+	Model      string
+	MacAddress string
+}
+
+// parseOVF parses an OVF descriptor and returns the first virtual system it describes
+func parseOVF(r io.Reader) (*machine, error) {
+	env := &envelope{}
+	if err := xml.NewDecoder(r).Decode(env); err != nil {
+		return nil, fmt.Errorf("failed to parse OVF descriptor: %v", err)
+	}
+	if len(env.VirtualSystems) == 0 {
+		return nil, fmt.Errorf("OVF descriptor does not contain a virtual system")
+	}
+	if len(env.VirtualSystems) > 1 {
+		return nil, fmt.Errorf("OVF descriptors with more than one virtual system are not supported")
+	}
+	vs := env.VirtualSystems[0]
+
+	m := &machine{
+		Name:     vs.Name,
+		OSType:   vs.OperatingSystem.OSType,
+		Firmware: biosBoot,
+	}
+	if m.Name == "" {
+		m.Name = vs.ID
+	}
+
+	for _, config := range vs.Hardware.VendorSettings {
+		switch config.Key {
+		case "firmware":
+			m.Firmware = strings.ToLower(config.Value)
+		case "uefi.secureBoot.enabled":
+			m.SecureBoot, _ = strconv.ParseBool(config.Value)
+		}
+	}
+	if m.Firmware != biosBoot && m.Firmware != efiBoot {
+		return nil, fmt.Errorf("unsupported firmware %q", m.Firmware)
+	}
+
+	items := append(append(append([]hardwareItem{}, vs.Hardware.Items...), vs.Hardware.StorageItems...), vs.Hardware.EthernetItems...)
+	controllers := map[string]hardwareItem{}
+	for _, item := range items {
+		switch item.ResourceType {
+		case resourceTypeIDEController, resourceTypeSCSIController, resourceTypeSATAController:
+			controllers[item.InstanceID] = item
+		}
+	}
+
+	controllerOrder := map[string]int{}
+	var diskItems []hardwareItem
+	for _, item := range items {
+		switch item.ResourceType {
+		case resourceTypeProcessor:
+			m.CPUs = item.VirtualQuantity
+			m.CoresPerSocket = item.CoresPerSocket
+		case resourceTypeMemory:
+			multiplier, err := parseAllocationUnits(item.AllocationUnits, 1<<20)
+			if err != nil {
+				return nil, err
+			}
+			m.MemoryBytes = item.VirtualQuantity * multiplier
+		case resourceTypeIDEController, resourceTypeSCSIController, resourceTypeSATAController:
+			controllerOrder[item.InstanceID] = len(controllerOrder)
+		case resourceTypeDisk:
+			diskItems = append(diskItems, item)
+		case resourceTypeEthernetAdapter:
+			m.NICs = append(m.NICs, nic{
+				Name:       item.ElementName,
+				Network:    item.Connection,
+				Model:      nicModel(item.ResourceSubType),
+				MacAddress: item.Address,
+			})
+		}
+	}
+	if m.CPUs <= 0 {
+		return nil, fmt.Errorf("OVF descriptor does not define the number of CPUs")
+	}
+	if m.MemoryBytes <= 0 {
+		return nil, fmt.Errorf("OVF descriptor does not define the amount of memory")
+	}
+
+	// Disks are ordered by their controller and by their address on the controller,
+	// which matches the order in which the original hypervisor enumerated them.
+	sort.SliceStable(diskItems, func(i, j int) bool {
+		ci, cj := controllerOrder[diskItems[i].Parent], controllerOrder[diskItems[j].Parent]
+		if ci != cj {
+			return ci < cj
+		}
+		ai, _ := strconv.Atoi(diskItems[i].AddressOnParent)
+		aj, _ := strconv.Atoi(diskItems[j].AddressOnParent)
+		return ai < aj
+	})
+
+	files := map[string]fileReference{}
+	for _, f := range env.References {
+		files[f.ID] = f
+	}
+	disks := map[string]diskSection{}
+	for _, d := range env.Disks {
+		disks[d.DiskID] = d
+	}
+
+	for _, item := range diskItems {
+		diskID := strings.TrimPrefix(item.HostResource, diskHostResourcePrefix)
+		diskID = strings.TrimPrefix(diskID, "/disk/")
+		d, ok := disks[diskID]
+		if !ok {
+			return nil, fmt.Errorf("disk %q of %q is not defined in the disk section", diskID, item.ElementName)
+		}
+		f, ok := files[d.FileRef]
+		if !ok {
+			return nil, fmt.Errorf("file %q of disk %q is not defined in the references section", d.FileRef, diskID)
+		}
+		capacity, err := parseCapacity(d.Capacity, d.CapacityAllocationUnits)
+		if err != nil {
+			return nil, fmt.Errorf("invalid capacity of disk %q: %v", diskID, err)
+		}
+		m.Disks = append(m.Disks, disk{
+			ID:            diskID,
+			File:          f.Href,
+			CapacityBytes: capacity,
+			Bus:           controllerBus(controllers[item.Parent]),
+		})
+	}
+	if len(m.Disks) == 0 {
+		return nil, fmt.Errorf("OVF descriptor does not contain any disks")
+	}
+
+	return m, nil
+}
+
+func controllerBus(controller hardwareItem) busType {
+	switch controller.ResourceType {
+	case resourceTypeIDEController:
+		return busIDE
+	case resourceTypeSCSIController:
+		return busSCSI
+	case resourceTypeSATAController:
+		return busSATA
+	}
+	return busOther
+}
+
+// nicModel maps the OVF adapter type to a model understood by KubeVirt, adapters
+// without an emulated counterpart are left to the cluster default.
+func nicModel(subType string) string {
+	switch strings.ToLower(subType) {
+	case "e1000":
+		return "e1000"
+	case "e1000e":
+		return "e1000e"
+	case "pcnet32":
+		return "pcnet"
+	case "rtl8139":
+		return "rtl8139"
+	case "virtio":
+		return "virtio"
+	}
+	return ""
+}
+
+var programmaticUnitsRegex = regexp.MustCompile(`^byte(?:\*(\d+)\^(\d+))?$`)
+
+// parseAllocationUnits returns the multiplier of DSP0004 programmatic units
+// like "byte * 2^20" and of the common abbreviations used by some tools.
+func parseAllocationUnits(units string, defaultMultiplier int64) (int64, error) {
+	normalized := strings.ToLower(strings.ReplaceAll(units, " ", ""))
+	switch normalized {
+	case "":
+		return defaultMultiplier, nil
+	case "kb", "kilobytes":
+		return 1 << 10, nil
+	case "mb", "megabytes":
+		return 1 << 20, nil
+	case "gb", "gigabytes":
+		return 1 << 30, nil
+	case "tb", "terabytes":
+		return 1 << 40, nil
+	}
+
+	match := programmaticUnitsRegex.FindStringSubmatch(normalized)
+	if match == nil {
+		return 0, fmt.Errorf("unsupported allocation units %q", units)
+	}
+	if match[1] == "" {
+		return 1, nil
+	}
+	base, _ := strconv.ParseInt(match[1], 10, 64)
+	exponent, _ := strconv.ParseInt(match[2], 10, 64)
+	multiplier := int64(1)
+	for i := int64(0); i < exponent; i++ {
+		multiplier *= base
+		if multiplier <= 0 || multiplier > 1<<50 {
+			return 0, fmt.Errorf("unsupported allocation units %q", units)
+		}
+	}
+	return multiplier, nil
+}
+
+func parseCapacity(capacity, units string) (int64, error) {
+	value, err := strconv.ParseInt(capacity, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	multiplier, err := parseAllocationUnits(units, 1)
+	if err != nil {
+		return 0, err
+	}
+	return value * multiplier, nil
+}
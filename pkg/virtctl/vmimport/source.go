@@ -0,0 +1,181 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package vmimport
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const ovfExtension = ".ovf"
+
+// source gives access to the descriptor and the disk images of an OVA archive or of an OVF directory
+type source interface {
+	// descriptor returns the contents of the OVF descriptor
+	descriptor() io.Reader
+	// openDisk returns the disk image referenced by href and its size
+	openDisk(href string) (io.ReadCloser, int64, error)
+	close() error
+}
+
+// openSource opens an OVA archive, an OVF descriptor or a directory containing an OVF descriptor
+func openSource(sourcePath string) (source, error) {
+	fi, err := os.Stat(sourcePath)
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		descriptors, err := filepath.Glob(filepath.Join(sourcePath, "*"+ovfExtension))
+		if err != nil {
+			return nil, err
+		}
+		if len(descriptors) != 1 {
+			return nil, fmt.Errorf("expected exactly one OVF descriptor in %s, found %d", sourcePath, len(descriptors))
+		}
+		return openOVF(descriptors[0])
+	}
+	if strings.EqualFold(filepath.Ext(sourcePath), ovfExtension) {
+		return openOVF(sourcePath)
+	}
+	return openOVA(sourcePath)
+}
+
+type ovfSource struct {
+	dir  string
+	data []byte
+}
+
+func openOVF(descriptorPath string) (source, error) {
+	// #nosec G304 No risk for path injection as this function executes with
+	// the same privileges as those of virtctl user who supplies the path
+	data, err := os.ReadFile(descriptorPath)
+	if err != nil {
+		return nil, err
+	}
+	return &ovfSource{dir: filepath.Dir(descriptorPath), data: data}, nil
+}
+
+func (s *ovfSource) descriptor() io.Reader {
+	return bytes.NewReader(s.data)
+}
+
+func (s *ovfSource) openDisk(href string) (io.ReadCloser, int64, error) {
+	if filepath.IsAbs(href) || strings.Contains(href, "://") {
+		return nil, 0, fmt.Errorf("disk %s must be relative to the OVF descriptor", href)
+	}
+	// #nosec G304 see above
+	f, err := os.Open(filepath.Join(s.dir, filepath.FromSlash(path.Clean("/"+href))))
+	if err != nil {
+		return nil, 0, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	return f, fi.Size(), nil
+}
+
+func (s *ovfSource) close() error {
+	return nil
+}
+
+type tarEntry struct {
+	offset int64
+	size   int64
+}
+
+// ovaSource reads the disk images straight out of the archive, OVA archives
+// are uncompressed tar files so every file is a contiguous section of it.
+type ovaSource struct {
+	file    *os.File
+	data    []byte
+	entries map[string]tarEntry
+}
+
+func openOVA(ovaPath string) (source, error) {
+	// #nosec G304 see above
+	f, err := os.Open(ovaPath)
+	if err != nil {
+		return nil, err
+	}
+	s := &ovaSource{file: f, entries: map[string]tarEntry{}}
+
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("failed to read OVA %s: %v", ovaPath, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean("/" + hdr.Name)
+		if strings.EqualFold(path.Ext(name), ovfExtension) {
+			if s.data != nil {
+				f.Close()
+				return nil, fmt.Errorf("OVA %s contains more than one OVF descriptor", ovaPath)
+			}
+			if s.data, err = io.ReadAll(tr); err != nil {
+				f.Close()
+				return nil, err
+			}
+			continue
+		}
+		offset, err := f.Seek(0, io.SeekCurrent)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		s.entries[name] = tarEntry{offset: offset, size: hdr.Size}
+	}
+	if s.data == nil {
+		f.Close()
+		return nil, fmt.Errorf("OVA %s does not contain an OVF descriptor", ovaPath)
+	}
+
+	return s, nil
+}
+
+func (s *ovaSource) descriptor() io.Reader {
+	return bytes.NewReader(s.data)
+}
+
+func (s *ovaSource) openDisk(href string) (io.ReadCloser, int64, error) {
+	entry, ok := s.entries[path.Clean("/"+href)]
+	if !ok {
+		return nil, 0, fmt.Errorf("disk %s not found in OVA", href)
+	}
+	return io.NopCloser(io.NewSectionReader(s.file, entry.offset, entry.size)), entry.size, nil
+}
+
+func (s *ovaSource) close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,249 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package vmimport
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	v1 "kubevirt.io/api/core/v1"
+	instancetypeapi "kubevirt.io/api/instancetype"
+	"kubevirt.io/client-go/kubecli"
+)
+
+var invalidNameCharsRegex = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// maxDiskNameSuffixLen leaves room for the "-disk-N" suffix of the DataVolume names
+const maxDiskNameSuffixLen = 8
+
+// sanitizeName turns the name of the OVF virtual system into a valid VirtualMachine name
+func sanitizeName(name string) string {
+	name = invalidNameCharsRegex.ReplaceAllString(strings.ToLower(name), "-")
+	if len(name) > validation.DNS1123LabelMaxLength-maxDiskNameSuffixLen {
+		name = name[:validation.DNS1123LabelMaxLength-maxDiskNameSuffixLen]
+	}
+	return strings.Trim(name, "-")
+}
+
+func diskName(index int) string {
+	return fmt.Sprintf("disk-%d", index)
+}
+
+func dataVolumeName(vmName string, index int) string {
+	return fmt.Sprintf("%s-%s", vmName, diskName(index))
+}
+
+func diskBus(bus busType) v1.DiskBus {
+	switch bus {
+	case busIDE, busSATA:
+		// KubeVirt does not emulate IDE controllers, SATA is the closest match
+		return v1.DiskBusSATA
+	case busSCSI:
+		return v1.DiskBusSCSI
+	}
+	return v1.DiskBusVirtio
+}
+
+// newVirtualMachine creates a VirtualMachine matching the OVF virtual system, networkMap maps
+// the OVF network names to Multus networks. NICs on unmapped networks are connected to the pod network.
+func newVirtualMachine(namespace, name string, m *machine, networkMap map[string]string, runStrategy v1.VirtualMachineRunStrategy) (*v1.VirtualMachine, error) {
+	memory := resource.NewQuantity(m.MemoryBytes, resource.BinarySI)
+	cpu := &v1.CPU{
+		Sockets: uint32(m.CPUs),
+		Cores:   1,
+		Threads: 1,
+	}
+	if m.CoresPerSocket > 0 && m.CPUs%m.CoresPerSocket == 0 {
+		cpu.Sockets = uint32(m.CPUs / m.CoresPerSocket)
+		cpu.Cores = uint32(m.CoresPerSocket)
+	}
+
+	vm := &v1.VirtualMachine{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       v1.VirtualMachineGroupVersionKind.Kind,
+			APIVersion: v1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: v1.VirtualMachineSpec{
+			RunStrategy: &runStrategy,
+			Template: &v1.VirtualMachineInstanceTemplateSpec{
+				Spec: v1.VirtualMachineInstanceSpec{
+					Domain: v1.DomainSpec{
+						CPU:    cpu,
+						Memory: &v1.Memory{Guest: memory},
+					},
+				},
+			},
+		},
+	}
+	spec := &vm.Spec.Template.Spec
+
+	if m.Firmware == efiBoot {
+		secureBoot := m.SecureBoot
+		spec.Domain.Firmware = &v1.Firmware{
+			Bootloader: &v1.Bootloader{
+				EFI: &v1.EFI{SecureBoot: &secureBoot},
+			},
+		}
+		if secureBoot {
+			spec.Domain.Features = &v1.Features{
+				SMM: &v1.FeatureState{Enabled: &secureBoot},
+			}
+		}
+	}
+
+	for i, d := range m.Disks {
+		disk := v1.Disk{
+			Name: diskName(i),
+			DiskDevice: v1.DiskDevice{
+				Disk: &v1.DiskTarget{Bus: diskBus(d.Bus)},
+			},
+		}
+		if i == 0 {
+			bootOrder := uint(1)
+			disk.BootOrder = &bootOrder
+		}
+		spec.Domain.Devices.Disks = append(spec.Domain.Devices.Disks, disk)
+		spec.Volumes = append(spec.Volumes, v1.Volume{
+			Name: diskName(i),
+			VolumeSource: v1.VolumeSource{
+				DataVolume: &v1.DataVolumeSource{Name: dataVolumeName(name, i)},
+			},
+		})
+	}
+
+	hasPodNetwork := false
+	for i, n := range m.NICs {
+		iface := v1.Interface{
+			Name:       fmt.Sprintf("nic-%d", i),
+			Model:      n.Model,
+			MacAddress: n.MacAddress,
+		}
+		network := v1.Network{Name: iface.Name}
+		if multusNetwork, ok := networkMap[n.Network]; ok {
+			iface.InterfaceBindingMethod.Bridge = &v1.InterfaceBridge{}
+			network.Multus = &v1.MultusNetwork{NetworkName: multusNetwork}
+		} else {
+			if hasPodNetwork {
+				return nil, fmt.Errorf("NIC %q is connected to network %q which has no mapping, only one NIC can use the pod network", n.Name, n.Network)
+			}
+			hasPodNetwork = true
+			iface.InterfaceBindingMethod.Masquerade = &v1.InterfaceMasquerade{}
+			network.Pod = &v1.PodNetwork{}
+		}
+		spec.Domain.Devices.Interfaces = append(spec.Domain.Devices.Interfaces, iface)
+		spec.Networks = append(spec.Networks, network)
+	}
+	if len(m.NICs) == 0 {
+		autoattach := false
+		spec.Domain.Devices.AutoattachPodInterface = &autoattach
+	}
+
+	return vm, nil
+}
+
+// inferInstancetype replaces the CPU and memory of the VirtualMachine with the first cluster
+// instancetype (by name) providing exactly the same amount of vCPUs and memory.
+func inferInstancetype(client kubecli.KubevirtClient, vm *v1.VirtualMachine, m *machine) (bool, error) {
+	instancetypes, err := client.VirtualMachineClusterInstancetype().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to list cluster instancetypes: %v", err)
+	}
+
+	var matches []string
+	for _, instancetype := range instancetypes.Items {
+		if int64(instancetype.Spec.CPU.Guest) == m.CPUs && instancetype.Spec.Memory.Guest.Value() == m.MemoryBytes {
+			matches = append(matches, instancetype.Name)
+		}
+	}
+	if len(matches) == 0 {
+		return false, nil
+	}
+	sort.Strings(matches)
+
+	vm.Spec.Instancetype = &v1.InstancetypeMatcher{
+		Name: matches[0],
+		Kind: instancetypeapi.ClusterSingularResourceName,
+	}
+	vm.Spec.Template.Spec.Domain.CPU = nil
+	vm.Spec.Template.Spec.Domain.Memory = nil
+	return true, nil
+}
+
+// preferenceRules map the OS types used by VMware and VirtualBox to the names of the common preferences
+var preferenceRules = []struct {
+	osType     *regexp.Regexp
+	preference string
+}{
+	{regexp.MustCompile(`^windows2022`), "windows.2k22"},
+	{regexp.MustCompile(`^windows2019srvnext`), "windows.2k22"},
+	{regexp.MustCompile(`^windows2019`), "windows.2k19"},
+	{regexp.MustCompile(`^windows(9server|2016)`), "windows.2k16"},
+	{regexp.MustCompile(`^windows(8server|2012)`), "windows.2k12"},
+	{regexp.MustCompile(`^windows11`), "windows.11"},
+	{regexp.MustCompile(`^windows(9|10)`), "windows.10"},
+	{regexp.MustCompile(`^rhel(\d+)`), "rhel.$1"},
+	{regexp.MustCompile(`^centos(\d+)`), "centos.stream$1"},
+	{regexp.MustCompile(`^fedora`), "fedora"},
+	{regexp.MustCompile(`^ubuntu`), "ubuntu"},
+}
+
+func preferenceForOSType(osType string) string {
+	osType = strings.ToLower(osType)
+	for _, rule := range preferenceRules {
+		if match := rule.osType.FindStringSubmatchIndex(osType); match != nil {
+			return string(rule.osType.ExpandString(nil, rule.preference, osType, match))
+		}
+	}
+	return ""
+}
+
+// inferPreference sets the cluster preference matching the guest OS of the OVF virtual system if it exists
+func inferPreference(client kubecli.KubevirtClient, vm *v1.VirtualMachine, m *machine) (bool, error) {
+	preference := preferenceForOSType(m.OSType)
+	if preference == "" {
+		return false, nil
+	}
+
+	preferences, err := client.VirtualMachineClusterPreference().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to list cluster preferences: %v", err)
+	}
+	for _, p := range preferences.Items {
+		if p.Name == preference {
+			vm.Spec.Preference = &v1.PreferenceMatcher{
+				Name: preference,
+				Kind: instancetypeapi.ClusterSingularPreferenceResourceName,
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,271 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package vmimport
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/imageupload"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_IMPORT = "import"
+
+	nameFlag              = "name"
+	networkFlag           = "network"
+	inferInstancetypeFlag = "infer-instancetype"
+	inferPreferenceFlag   = "infer-preference"
+	startFlag             = "start"
+	dryRunFlag            = "dry-run"
+)
+
+type command struct {
+	clientConfig clientcmd.ClientConfig
+
+	name              string
+	networks          []string
+	inferInstancetype bool
+	inferPreference   bool
+	start             bool
+	dryRun            bool
+
+	storageClass      string
+	accessMode        string
+	blockVolume       bool
+	forceBind         bool
+	uploadProxyURL    string
+	insecure          bool
+	uploadPodWaitSecs uint
+}
+
+// NewImportCommand returns a cobra.Command to import a VirtualMachine from an OVA archive or an OVF descriptor
+func NewImportCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	c := command{clientConfig: clientConfig}
+	cmd := &cobra.Command{
+		Use:     "import (OVA|OVF|DIRECTORY)",
+		Short:   "Import a virtual machine from an OVA archive or from an OVF descriptor and its VMDK disks.",
+		Long:    "Import a virtual machine from an OVA archive or from an OVF descriptor and its VMDK disks.\n\nEvery disk is uploaded into a new DataVolume through the CDI upload proxy, CDI converts the disks to raw. A VirtualMachine matching the CPU, memory, disks, NICs and firmware of the OVF descriptor is created afterwards and references the DataVolumes.",
+		Example: usage(),
+		Args:    templates.ExactArgs(COMMAND_IMPORT, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, args[0])
+		},
+	}
+
+	cmd.Flags().StringVar(&c.name, nameFlag, "", "The name of the VirtualMachine, defaults to the name of the OVF virtual system.")
+	cmd.Flags().StringArrayVar(&c.networks, networkFlag, nil, "Connect the NICs on an OVF network to a Multus network, in the form OVF_NETWORK:NETWORK_ATTACHMENT_DEFINITION. Can be provided multiple times. A single NIC on an unmapped network is connected to the pod network.")
+	cmd.Flags().BoolVar(&c.inferInstancetype, inferInstancetypeFlag, false, "Use the cluster instancetype matching the CPUs and memory of the OVF virtual system if there is one.")
+	cmd.Flags().BoolVar(&c.inferPreference, inferPreferenceFlag, false, "Use the cluster preference matching the guest OS of the OVF virtual system if there is one.")
+	cmd.Flags().BoolVar(&c.start, startFlag, false, "Start the VirtualMachine once it was created.")
+	cmd.Flags().BoolVar(&c.dryRun, dryRunFlag, false, "Print the VirtualMachine which would be created, without uploading the disks.")
+	cmd.Flags().StringVar(&c.storageClass, "storage-class", "", "The storage class for the DataVolumes.")
+	cmd.Flags().StringVar(&c.accessMode, "access-mode", "", "The access mode for the DataVolumes.")
+	cmd.Flags().BoolVar(&c.blockVolume, "block-volume", false, "Create the DataVolumes with VolumeMode=Block (default is the storageProfile default).")
+	cmd.Flags().BoolVar(&c.forceBind, "force-bind", false, "Force bind the PVCs, ignoring the WaitForFirstConsumer logic.")
+	cmd.Flags().StringVar(&c.uploadProxyURL, "uploadproxy-url", "", "The URL of the cdi-upload proxy service.")
+	cmd.Flags().BoolVar(&c.insecure, "insecure", false, "Allow insecure server connections when using HTTPS.")
+	cmd.Flags().UintVar(&c.uploadPodWaitSecs, "wait-secs", 300, "Seconds to wait for each upload pod to start.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+
+	return cmd
+}
+
+func usage() string {
+	return `  # Import the virtual machine of an OVA archive:
+  {{ProgramName}} vm import myvm.ova
+
+  # Import a virtual machine from an OVF descriptor and the VMDK disks next to it, using a custom name:
+  {{ProgramName}} vm import --name myvm exported/myvm.ovf
+
+  # Import a virtual machine connecting the NICs on "VM Network" to a Multus network and use a matching instancetype and preference:
+  {{ProgramName}} vm import --network "VM Network:default/vlan10" --infer-instancetype --infer-preference myvm.ova`
+}
+
+func parseNetworkMap(networks []string) (map[string]string, error) {
+	networkMap := map[string]string{}
+	for _, network := range networks {
+		i := strings.LastIndex(network, ":")
+		if i <= 0 || i == len(network)-1 {
+			return nil, fmt.Errorf("invalid network mapping %q, expected OVF_NETWORK:NETWORK_ATTACHMENT_DEFINITION", network)
+		}
+		networkMap[network[:i]] = network[i+1:]
+	}
+	return networkMap, nil
+}
+
+func (c *command) run(cmd *cobra.Command, sourcePath string) error {
+	networkMap, err := parseNetworkMap(c.networks)
+	if err != nil {
+		return err
+	}
+
+	src, err := openSource(sourcePath)
+	if err != nil {
+		return err
+	}
+	defer src.close()
+
+	m, err := parseOVF(src.descriptor())
+	if err != nil {
+		return err
+	}
+
+	name := c.name
+	if name == "" {
+		if name = sanitizeName(m.Name); name == "" {
+			return fmt.Errorf("cannot derive a VirtualMachine name from %q, use --%s", m.Name, nameFlag)
+		}
+	}
+
+	namespace, _, err := c.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	runStrategy := v1.RunStrategyHalted
+	if c.start {
+		runStrategy = v1.RunStrategyAlways
+	}
+	vm, err := newVirtualMachine(namespace, name, m, networkMap, runStrategy)
+	if err != nil {
+		return err
+	}
+
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return fmt.Errorf("cannot obtain KubeVirt client: %v", err)
+	}
+
+	if c.inferInstancetype {
+		found, err := inferInstancetype(virtClient, vm, m)
+		if err != nil {
+			return err
+		}
+		if !found {
+			cmd.PrintErrf("No cluster instancetype with %d CPUs and %s memory found, keeping the resources of the OVF descriptor\n", m.CPUs, resource.NewQuantity(m.MemoryBytes, resource.BinarySI))
+		}
+	}
+	if c.inferPreference {
+		found, err := inferPreference(virtClient, vm, m)
+		if err != nil {
+			return err
+		}
+		if !found {
+			cmd.PrintErrf("No cluster preference found for OS type %q\n", m.OSType)
+		}
+	}
+
+	if c.dryRun {
+		out, err := yaml.Marshal(vm)
+		if err != nil {
+			return err
+		}
+		cmd.Print(string(out))
+		return nil
+	}
+
+	dataVolumes := make([]string, len(m.Disks))
+	for i := range m.Disks {
+		dataVolumes[i] = dataVolumeName(name, i)
+	}
+	if err := checkDataVolumesAbsent(virtClient, namespace, dataVolumes); err != nil {
+		return err
+	}
+
+	// the DataVolumes did not exist before, so they are removed again if the import fails
+	// and running the import another time starts over
+	for i, d := range m.Disks {
+		if err := c.uploadDisk(virtClient, src, namespace, dataVolumes[i], d); err != nil {
+			deleteDataVolumes(cmd, virtClient, namespace, dataVolumes[:i+1])
+			return err
+		}
+	}
+
+	if _, err := virtClient.VirtualMachine(namespace).Create(context.Background(), vm); err != nil {
+		deleteDataVolumes(cmd, virtClient, namespace, dataVolumes)
+		return err
+	}
+	cmd.Printf("VirtualMachine %s/%s created, its disks become available once CDI finished converting them\n", namespace, name)
+
+	return nil
+}
+
+func (c *command) uploadDisk(virtClient kubecli.KubevirtClient, src source, namespace, dvName string, d disk) error {
+	image, size, err := src.openDisk(d.File)
+	if err != nil {
+		return err
+	}
+	defer image.Close()
+
+	upload := &imageupload.DataVolumeUpload{
+		Namespace:      namespace,
+		Name:           dvName,
+		Size:           resource.NewQuantity(d.CapacityBytes, resource.BinarySI).String(),
+		StorageClass:   c.storageClass,
+		AccessMode:     c.accessMode,
+		BlockVolume:    c.blockVolume,
+		ForceBind:      c.forceBind,
+		UploadProxyURL: c.uploadProxyURL,
+		Insecure:       c.insecure,
+		UploadPodWait:  time.Duration(c.uploadPodWaitSecs) * time.Second,
+	}
+	if err := imageupload.UploadToNewDataVolume(virtClient, upload, image, size); err != nil {
+		return fmt.Errorf("failed to upload disk %s: %v", d.File, err)
+	}
+	return nil
+}
+
+func checkDataVolumesAbsent(virtClient kubecli.KubevirtClient, namespace string, names []string) error {
+	for _, name := range names {
+		_, err := virtClient.CdiClient().CdiV1beta1().DataVolumes(namespace).Get(context.Background(), name, metav1.GetOptions{})
+		if err == nil {
+			return fmt.Errorf("DataVolume %s/%s already exists, use --%s to import with another name", namespace, name, nameFlag)
+		}
+		if !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteDataVolumes(cmd *cobra.Command, virtClient kubecli.KubevirtClient, namespace string, names []string) {
+	for _, name := range names {
+		err := virtClient.CdiClient().CdiV1beta1().DataVolumes(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			cmd.PrintErrf("Failed to delete DataVolume %s/%s: %v\n", namespace, name, err)
+			continue
+		}
+		cmd.PrintErrf("DataVolume %s/%s deleted\n", namespace, name)
+	}
+}
@@ -0,0 +1,11 @@
+package vmimport_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestVMImport(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
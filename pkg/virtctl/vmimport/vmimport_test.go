@@ -0,0 +1,390 @@
+package vmimport_test
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/testing"
+	"sigs.k8s.io/yaml"
+
+	v1 "kubevirt.io/api/core/v1"
+	instancetypeapi "kubevirt.io/api/instancetype"
+	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+	fakecdiclient "kubevirt.io/client-go/generated/containerized-data-importer/clientset/versioned/fake"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	uploadcdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/upload/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/imageupload"
+	"kubevirt.io/kubevirt/tests/clientcmd"
+)
+
+const ovfDescriptor = `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope vmw:buildId="build-1" xmlns="http://schemas.dmtf.org/ovf/envelope/1" xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1" xmlns:rasd="http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData" xmlns:vmw="http://www.vmware.com/schema/ovf">
+  <References>
+    <File ovf:href="testvm-disk1.vmdk" ovf:id="file1" ovf:size="10"/>
+    <File ovf:href="testvm-disk2.vmdk" ovf:id="file2" ovf:size="12"/>
+  </References>
+  <DiskSection>
+    <Info>Virtual disk information</Info>
+    <Disk ovf:capacity="2" ovf:capacityAllocationUnits="byte * 2^30" ovf:diskId="vmdisk1" ovf:fileRef="file1" ovf:format="http://www.vmware.com/interfaces/specifications/vmdk.html#streamOptimized"/>
+    <Disk ovf:capacity="1073741824" ovf:diskId="vmdisk2" ovf:fileRef="file2" ovf:format="http://www.vmware.com/interfaces/specifications/vmdk.html#streamOptimized"/>
+  </DiskSection>
+  <NetworkSection>
+    <Network ovf:name="VM Network"/>
+    <Network ovf:name="Storage"/>
+  </NetworkSection>
+  <VirtualSystem ovf:id="Test_VM">
+    <Name>Test_VM</Name>
+    <OperatingSystemSection ovf:id="80" vmw:osType="rhel9_64Guest">
+      <Description>Red Hat Enterprise Linux 9 (64-bit)</Description>
+    </OperatingSystemSection>
+    <VirtualHardwareSection>
+      <Item>
+        <rasd:AllocationUnits>hertz * 10^6</rasd:AllocationUnits>
+        <rasd:ElementName>4 virtual CPU(s)</rasd:ElementName>
+        <rasd:InstanceID>1</rasd:InstanceID>
+        <rasd:ResourceType>3</rasd:ResourceType>
+        <rasd:VirtualQuantity>4</rasd:VirtualQuantity>
+        <vmw:CoresPerSocket ovf:required="false">2</vmw:CoresPerSocket>
+      </Item>
+      <Item>
+        <rasd:AllocationUnits>byte * 2^20</rasd:AllocationUnits>
+        <rasd:ElementName>4096MB of memory</rasd:ElementName>
+        <rasd:InstanceID>2</rasd:InstanceID>
+        <rasd:ResourceType>4</rasd:ResourceType>
+        <rasd:VirtualQuantity>4096</rasd:VirtualQuantity>
+      </Item>
+      <Item>
+        <rasd:ElementName>SCSI controller 0</rasd:ElementName>
+        <rasd:InstanceID>3</rasd:InstanceID>
+        <rasd:ResourceSubType>VirtualSCSI</rasd:ResourceSubType>
+        <rasd:ResourceType>6</rasd:ResourceType>
+      </Item>
+      <Item>
+        <rasd:Address>0</rasd:Address>
+        <rasd:ElementName>IDE 0</rasd:ElementName>
+        <rasd:InstanceID>4</rasd:InstanceID>
+        <rasd:ResourceType>5</rasd:ResourceType>
+      </Item>
+      <Item>
+        <rasd:AddressOnParent>0</rasd:AddressOnParent>
+        <rasd:ElementName>Hard disk 2</rasd:ElementName>
+        <rasd:HostResource>ovf:/disk/vmdisk2</rasd:HostResource>
+        <rasd:InstanceID>6</rasd:InstanceID>
+        <rasd:Parent>4</rasd:Parent>
+        <rasd:ResourceType>17</rasd:ResourceType>
+      </Item>
+      <Item>
+        <rasd:AddressOnParent>0</rasd:AddressOnParent>
+        <rasd:ElementName>Hard disk 1</rasd:ElementName>
+        <rasd:HostResource>ovf:/disk/vmdisk1</rasd:HostResource>
+        <rasd:InstanceID>5</rasd:InstanceID>
+        <rasd:Parent>3</rasd:Parent>
+        <rasd:ResourceType>17</rasd:ResourceType>
+      </Item>
+      <Item>
+        <rasd:Address>00:50:56:aa:bb:cc</rasd:Address>
+        <rasd:AddressOnParent>7</rasd:AddressOnParent>
+        <rasd:Connection>VM Network</rasd:Connection>
+        <rasd:ElementName>Network adapter 1</rasd:ElementName>
+        <rasd:InstanceID>7</rasd:InstanceID>
+        <rasd:ResourceSubType>E1000e</rasd:ResourceSubType>
+        <rasd:ResourceType>10</rasd:ResourceType>
+      </Item>
+      <Item>
+        <rasd:AddressOnParent>8</rasd:AddressOnParent>
+        <rasd:Connection>Storage</rasd:Connection>
+        <rasd:ElementName>Network adapter 2</rasd:ElementName>
+        <rasd:InstanceID>8</rasd:InstanceID>
+        <rasd:ResourceSubType>VmxNet3</rasd:ResourceSubType>
+        <rasd:ResourceType>10</rasd:ResourceType>
+      </Item>
+      <vmw:Config ovf:required="false" vmw:key="firmware" vmw:value="efi"/>
+      <vmw:Config ovf:required="false" vmw:key="uefi.secureBoot.enabled" vmw:value="true"/>
+    </VirtualHardwareSection>
+  </VirtualSystem>
+</Envelope>
+`
+
+var disks = map[string][]byte{
+	"testvm-disk1.vmdk": []byte("disk1-data"),
+	"testvm-disk2.vmdk": []byte("disk2-data-2"),
+}
+
+const (
+	commandName     = "vm"
+	targetNamespace = "default"
+	storageNetwork  = "Storage:default/storage"
+)
+
+var _ = Describe("vm import", func() {
+
+	var (
+		ctrl       *gomock.Controller
+		virtClient *kubecli.MockKubevirtClient
+		tmpDir     string
+		ovaPath    string
+	)
+
+	writeOVA := func(path string) {
+		f, err := os.Create(path)
+		Expect(err).ToNot(HaveOccurred())
+		defer f.Close()
+		tw := tar.NewWriter(f)
+		defer tw.Close()
+
+		writeEntry := func(name string, data []byte) {
+			Expect(tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), Typeflag: tar.TypeReg})).To(Succeed())
+			_, err := tw.Write(data)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		writeEntry("testvm.ovf", []byte(ovfDescriptor))
+		writeEntry("testvm-disk1.vmdk", disks["testvm-disk1.vmdk"])
+		writeEntry("testvm-disk2.vmdk", disks["testvm-disk2.vmdk"])
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		virtClient = kubecli.NewMockKubevirtClient(ctrl)
+		kubecli.MockKubevirtClientInstance = virtClient
+
+		tmpDir = GinkgoT().TempDir()
+		ovaPath = filepath.Join(tmpDir, "testvm.ova")
+		writeOVA(ovaPath)
+	})
+
+	dryRun := func(args ...string) *v1.VirtualMachine {
+		out, err := clientcmd.NewRepeatableVirtctlCommandWithOut(append([]string{commandName, "import", "--dry-run"}, args...)...)()
+		Expect(err).ToNot(HaveOccurred())
+		vm := &v1.VirtualMachine{}
+		Expect(yaml.Unmarshal(out, vm)).To(Succeed())
+		return vm
+	}
+
+	Context("VirtualMachine", func() {
+		It("should match the OVF descriptor of an OVA", func() {
+			vm := dryRun("--network", storageNetwork, ovaPath)
+
+			Expect(vm.Name).To(Equal("test-vm"))
+			Expect(vm.Namespace).To(Equal(targetNamespace))
+			Expect(*vm.Spec.RunStrategy).To(Equal(v1.RunStrategyHalted))
+
+			domain := vm.Spec.Template.Spec.Domain
+			Expect(domain.CPU.Sockets).To(BeEquivalentTo(2))
+			Expect(domain.CPU.Cores).To(BeEquivalentTo(2))
+			Expect(domain.Memory.Guest.Cmp(resource.MustParse("4Gi"))).To(BeZero())
+			Expect(*domain.Firmware.Bootloader.EFI.SecureBoot).To(BeTrue())
+			Expect(*domain.Features.SMM.Enabled).To(BeTrue())
+
+			By("ordering the disks by controller and address")
+			Expect(domain.Devices.Disks).To(HaveLen(2))
+			Expect(domain.Devices.Disks[0].Name).To(Equal("disk-0"))
+			Expect(domain.Devices.Disks[0].Disk.Bus).To(Equal(v1.DiskBusSCSI))
+			Expect(*domain.Devices.Disks[0].BootOrder).To(BeEquivalentTo(1))
+			Expect(domain.Devices.Disks[1].Disk.Bus).To(Equal(v1.DiskBusSATA))
+			Expect(domain.Devices.Disks[1].BootOrder).To(BeNil())
+			Expect(vm.Spec.Template.Spec.Volumes).To(HaveLen(2))
+			Expect(vm.Spec.Template.Spec.Volumes[0].DataVolume.Name).To(Equal("test-vm-disk-0"))
+			Expect(vm.Spec.Template.Spec.Volumes[1].DataVolume.Name).To(Equal("test-vm-disk-1"))
+
+			By("connecting the NICs")
+			Expect(domain.Devices.Interfaces).To(HaveLen(2))
+			Expect(domain.Devices.Interfaces[0].Model).To(Equal("e1000e"))
+			Expect(domain.Devices.Interfaces[0].MacAddress).To(Equal("00:50:56:aa:bb:cc"))
+			Expect(domain.Devices.Interfaces[0].Masquerade).ToNot(BeNil())
+			Expect(domain.Devices.Interfaces[1].Model).To(BeEmpty())
+			Expect(domain.Devices.Interfaces[1].Bridge).ToNot(BeNil())
+			Expect(vm.Spec.Template.Spec.Networks).To(ConsistOf(
+				v1.Network{Name: "nic-0", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}},
+				v1.Network{Name: "nic-1", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "default/storage"}}},
+			))
+		})
+
+		It("should read an OVF descriptor from a directory", func() {
+			Expect(os.WriteFile(filepath.Join(tmpDir, "testvm.ovf"), []byte(ovfDescriptor), 0600)).To(Succeed())
+			vm := dryRun("--network", storageNetwork, "--name", "myvm", "--start", tmpDir)
+
+			Expect(vm.Name).To(Equal("myvm"))
+			Expect(*vm.Spec.RunStrategy).To(Equal(v1.RunStrategyAlways))
+			Expect(vm.Spec.Template.Spec.Volumes[0].DataVolume.Name).To(Equal("myvm-disk-0"))
+		})
+
+		It("should use the matching cluster instancetype and preference", func() {
+			kvClient := kubevirtfake.NewSimpleClientset(
+				&instancetypev1beta1.VirtualMachineClusterInstancetype{
+					ObjectMeta: metav1.ObjectMeta{Name: "u1.large"},
+					Spec: instancetypev1beta1.VirtualMachineInstancetypeSpec{
+						CPU:    instancetypev1beta1.CPUInstancetype{Guest: 2},
+						Memory: instancetypev1beta1.MemoryInstancetype{Guest: resource.MustParse("8Gi")},
+					},
+				},
+				&instancetypev1beta1.VirtualMachineClusterInstancetype{
+					ObjectMeta: metav1.ObjectMeta{Name: "u1.medium"},
+					Spec: instancetypev1beta1.VirtualMachineInstancetypeSpec{
+						CPU:    instancetypev1beta1.CPUInstancetype{Guest: 4},
+						Memory: instancetypev1beta1.MemoryInstancetype{Guest: resource.MustParse("4Gi")},
+					},
+				},
+				&instancetypev1beta1.VirtualMachineClusterPreference{
+					ObjectMeta: metav1.ObjectMeta{Name: "rhel.9"},
+				},
+			)
+			virtClient.EXPECT().VirtualMachineClusterInstancetype().Return(kvClient.InstancetypeV1beta1().VirtualMachineClusterInstancetypes()).AnyTimes()
+			virtClient.EXPECT().VirtualMachineClusterPreference().Return(kvClient.InstancetypeV1beta1().VirtualMachineClusterPreferences()).AnyTimes()
+
+			vm := dryRun("--network", storageNetwork, "--infer-instancetype", "--infer-preference", ovaPath)
+
+			Expect(vm.Spec.Instancetype).To(Equal(&v1.InstancetypeMatcher{Name: "u1.medium", Kind: instancetypeapi.ClusterSingularResourceName}))
+			Expect(vm.Spec.Preference).To(Equal(&v1.PreferenceMatcher{Name: "rhel.9", Kind: instancetypeapi.ClusterSingularPreferenceResourceName}))
+			Expect(vm.Spec.Template.Spec.Domain.CPU).To(BeNil())
+			Expect(vm.Spec.Template.Spec.Domain.Memory).To(BeNil())
+		})
+
+		It("should keep the resources when there is no matching instancetype", func() {
+			kvClient := kubevirtfake.NewSimpleClientset()
+			virtClient.EXPECT().VirtualMachineClusterInstancetype().Return(kvClient.InstancetypeV1beta1().VirtualMachineClusterInstancetypes()).AnyTimes()
+
+			vm := dryRun("--network", storageNetwork, "--infer-instancetype", ovaPath)
+
+			Expect(vm.Spec.Instancetype).To(BeNil())
+			Expect(vm.Spec.Template.Spec.Domain.CPU).ToNot(BeNil())
+			Expect(vm.Spec.Template.Spec.Domain.Memory).ToNot(BeNil())
+		})
+
+		DescribeTable("should fail", func(expectedErr string, args ...string) {
+			if args[len(args)-1] == "OVA" {
+				args[len(args)-1] = ovaPath
+			}
+			cmd := clientcmd.NewRepeatableVirtctlCommand(append([]string{commandName, "import", "--dry-run"}, args...)...)
+			err := cmd()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(expectedErr))
+		},
+			Entry("with more than one NIC on the pod network", "only one NIC can use the pod network", "OVA"),
+			Entry("with an invalid network mapping", "invalid network mapping", "--network", "Storage", "OVA"),
+			Entry("with a missing source", "no such file or directory", "missing.ova"),
+		)
+	})
+
+	Context("upload", func() {
+		var (
+			server    *httptest.Server
+			cdiClient *fakecdiclient.Clientset
+			lock      sync.Mutex
+			uploaded  map[string][]byte
+			failing   map[string]bool
+		)
+
+		BeforeEach(func() {
+			uploaded = map[string][]byte{}
+			failing = map[string]bool{}
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodHead {
+					w.WriteHeader(http.StatusOK)
+					return
+				}
+				if failing[r.Header.Get("Authorization")] {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				data, err := io.ReadAll(r.Body)
+				Expect(err).ToNot(HaveOccurred())
+				lock.Lock()
+				defer lock.Unlock()
+				uploaded[r.Header.Get("Authorization")] = data
+				w.WriteHeader(http.StatusOK)
+			}))
+			imageupload.SetHTTPClientCreator(func(bool) *http.Client {
+				return server.Client()
+			})
+
+			cdiClient = fakecdiclient.NewSimpleClientset(&cdiv1.CDIConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "config"},
+				Status:     cdiv1.CDIConfigStatus{UploadProxyURL: &server.URL},
+			})
+			cdiClient.Fake.PrependReactor("create", "datavolumes", func(action testing.Action) (bool, runtime.Object, error) {
+				dv := action.(testing.CreateAction).GetObject().(*cdiv1.DataVolume)
+				dv.Status.Phase = cdiv1.UploadReady
+				return false, nil, nil
+			})
+			cdiClient.Fake.PrependReactor("create", "uploadtokenrequests", func(action testing.Action) (bool, runtime.Object, error) {
+				request := action.(testing.CreateAction).GetObject().(*uploadcdiv1.UploadTokenRequest)
+				request.Status.Token = request.Spec.PvcName
+				return true, request, nil
+			})
+			virtClient.EXPECT().CdiClient().Return(cdiClient).AnyTimes()
+		})
+
+		AfterEach(func() {
+			imageupload.SetDefaultHTTPClientCreator()
+			server.Close()
+		})
+
+		It("should upload every disk and create the VirtualMachine", func() {
+			vmInterface := kubecli.NewMockVirtualMachineInterface(ctrl)
+			virtClient.EXPECT().VirtualMachine(targetNamespace).Return(vmInterface)
+			vmInterface.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+				Expect(vm.Name).To(Equal("test-vm"))
+				return vm, nil
+			})
+
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, "import", "--network", storageNetwork, "--insecure", ovaPath)
+			Expect(cmd()).To(Succeed())
+
+			for i, size := range []string{"2Gi", "1Gi"} {
+				name := fmt.Sprintf("test-vm-disk-%d", i)
+				dv, err := cdiClient.CdiV1beta1().DataVolumes(targetNamespace).Get(context.Background(), name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dv.Spec.Source.Upload).ToNot(BeNil())
+				Expect(dv.Spec.ContentType).To(Equal(cdiv1.DataVolumeKubeVirt))
+				Expect(dv.Spec.Storage.Resources.Requests.Storage().Cmp(resource.MustParse(size))).To(BeZero())
+			}
+			Expect(uploaded).To(HaveKeyWithValue("Bearer test-vm-disk-0", disks["testvm-disk1.vmdk"]))
+			Expect(uploaded).To(HaveKeyWithValue("Bearer test-vm-disk-1", disks["testvm-disk2.vmdk"]))
+		})
+
+		It("should delete the created DataVolumes if a disk fails to upload", func() {
+			failing["Bearer test-vm-disk-1"] = true
+
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, "import", "--network", storageNetwork, "--insecure", ovaPath)
+			Expect(cmd()).To(MatchError(ContainSubstring("failed to upload disk testvm-disk2.vmdk")))
+
+			for _, name := range []string{"test-vm-disk-0", "test-vm-disk-1"} {
+				_, err := cdiClient.CdiV1beta1().DataVolumes(targetNamespace).Get(context.Background(), name, metav1.GetOptions{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			}
+		})
+
+		It("should not upload any disk if a DataVolume already exists", func() {
+			existing := &cdiv1.DataVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-vm-disk-1", Namespace: targetNamespace},
+			}
+			_, err := cdiClient.CdiV1beta1().DataVolumes(targetNamespace).Create(context.Background(), existing, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, "import", "--network", storageNetwork, "--insecure", ovaPath)
+			Expect(cmd()).To(MatchError(ContainSubstring("DataVolume default/test-vm-disk-1 already exists")))
+
+			Expect(uploaded).To(BeEmpty())
+			_, err = cdiClient.CdiV1beta1().DataVolumes(targetNamespace).Get(context.Background(), "test-vm-disk-1", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+})
@@ -18,6 +18,15 @@ func VirtioFSSocketPath(volumeName string) string {
 	return filepath.Join(VirtioFSContainersMountBaseDir, socketName)
 }
 
+// HotplugVirtioFSSocketDir contains the sockets of the virtiofsd processes started by virt-launcher
+// for hotplugged filesystems
+var HotplugVirtioFSSocketDir = filepath.Join(util.VirtPrivateDir, "virtiofs-hotplug")
+
+func HotplugVirtioFSSocketPath(volumeName string) string {
+	socketName := fmt.Sprintf("%s.sock", volumeName)
+	return filepath.Join(HotplugVirtioFSSocketDir, socketName)
+}
+
 // RequiresRootPrivileges Returns true if the volume requires the virtiofs
 // container to run as user root
 func RequiresRootPrivileges(volume *v1.Volume) bool {
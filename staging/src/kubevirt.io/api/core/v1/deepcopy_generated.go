@@ -71,6 +71,32 @@ func (in *AccessCredentialSecretSource) DeepCopy() *AccessCredentialSecretSource
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddFilesystemOptions) DeepCopyInto(out *AddFilesystemOptions) {
+	*out = *in
+	if in.VolumeSource != nil {
+		in, out := &in.VolumeSource, &out.VolumeSource
+		*out = new(HotplugVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddFilesystemOptions.
+func (in *AddFilesystemOptions) DeepCopy() *AddFilesystemOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(AddFilesystemOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddInterfaceOptions) DeepCopyInto(out *AddInterfaceOptions) {
 	*out = *in
@@ -641,6 +667,27 @@ func (in *ContainerDiskSource) DeepCopy() *ContainerDiskSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerDiskVerification) DeepCopyInto(out *ContainerDiskVerification) {
+	*out = *in
+	if in.PublicKeys != nil {
+		in, out := &in.PublicKeys, &out.PublicKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerDiskVerification.
+func (in *ContainerDiskVerification) DeepCopy() *ContainerDiskVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerDiskVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomBlockSize) DeepCopyInto(out *CustomBlockSize) {
 	*out = *in
@@ -1025,6 +1072,16 @@ func (in *Disk) DeepCopyInto(out *Disk) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.IOTune != nil {
+		in, out := &in.IOTune, &out.IOTune
+		*out = new(DiskIOTune)
+		**out = **in
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(DiskEncryption)
+		**out = **in
+	}
 	return
 }
 
@@ -1069,6 +1126,39 @@ func (in *DiskDevice) DeepCopy() *DiskDevice {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskEncryption) DeepCopyInto(out *DiskEncryption) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskEncryption.
+func (in *DiskEncryption) DeepCopy() *DiskEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskIOTune) DeepCopyInto(out *DiskIOTune) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskIOTune.
+func (in *DiskIOTune) DeepCopy() *DiskIOTune {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskIOTune)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiskTarget) DeepCopyInto(out *DiskTarget) {
 	*out = *in
@@ -1240,6 +1330,11 @@ func (in *EFI) DeepCopyInto(out *EFI) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Persistent != nil {
+		in, out := &in.Persistent, &out.Persistent
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -1253,6 +1348,27 @@ func (in *EFI) DeepCopy() *EFI {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EjectMediaOptions) DeepCopyInto(out *EjectMediaOptions) {
+	*out = *in
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EjectMediaOptions.
+func (in *EjectMediaOptions) DeepCopy() *EjectMediaOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(EjectMediaOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmptyDiskSource) DeepCopyInto(out *EmptyDiskSource) {
 	*out = *in
@@ -1545,6 +1661,47 @@ func (in *Filesystem) DeepCopy() *Filesystem {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemTrim) DeepCopyInto(out *FilesystemTrim) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemTrim.
+func (in *FilesystemTrim) DeepCopy() *FilesystemTrim {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemTrim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemTrimStatus) DeepCopyInto(out *FilesystemTrimStatus) {
+	*out = *in
+	if in.LastTrimTime != nil {
+		in, out := &in.LastTrimTime, &out.LastTrimTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemTrimStatus.
+func (in *FilesystemTrimStatus) DeepCopy() *FilesystemTrimStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemTrimStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FilesystemVirtiofs) DeepCopyInto(out *FilesystemVirtiofs) {
 	*out = *in
@@ -1561,6 +1718,55 @@ func (in *FilesystemVirtiofs) DeepCopy() *FilesystemVirtiofs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallPolicy) DeepCopyInto(out *FirewallPolicy) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]FirewallRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallPolicy.
+func (in *FirewallPolicy) DeepCopy() *FirewallPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallRule) DeepCopyInto(out *FirewallRule) {
+	*out = *in
+	if in.RemoteCIDRs != nil {
+		in, out := &in.RemoteCIDRs, &out.RemoteCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallRule.
+func (in *FirewallRule) DeepCopy() *FirewallRule {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Firmware) DeepCopyInto(out *Firmware) {
 	*out = *in
@@ -1714,6 +1920,43 @@ func (in *GuestAgentPing) DeepCopy() *GuestAgentPing {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestExecOptions) DeepCopyInto(out *GuestExecOptions) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestExecOptions.
+func (in *GuestExecOptions) DeepCopy() *GuestExecOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestExecOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestExecResult) DeepCopyInto(out *GuestExecResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestExecResult.
+func (in *GuestExecResult) DeepCopy() *GuestExecResult {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestExecResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HPETTimer) DeepCopyInto(out *HPETTimer) {
 	*out = *in
@@ -1809,6 +2052,34 @@ func (in *HostDisk) DeepCopy() *HostDisk {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HotplugCSIVolumeSource) DeepCopyInto(out *HotplugCSIVolumeSource) {
+	*out = *in
+	if in.MountOptions != nil {
+		in, out := &in.MountOptions, &out.MountOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VolumeAttributes != nil {
+		in, out := &in.VolumeAttributes, &out.VolumeAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HotplugCSIVolumeSource.
+func (in *HotplugCSIVolumeSource) DeepCopy() *HotplugCSIVolumeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(HotplugCSIVolumeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HotplugVolumeSource) DeepCopyInto(out *HotplugVolumeSource) {
 	*out = *in
@@ -1822,6 +2093,26 @@ func (in *HotplugVolumeSource) DeepCopyInto(out *HotplugVolumeSource) {
 		*out = new(DataVolumeSource)
 		**out = **in
 	}
+	if in.ContainerDisk != nil {
+		in, out := &in.ContainerDisk, &out.ContainerDisk
+		*out = new(ContainerDiskSource)
+		**out = **in
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(SecretVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccountVolumeSource)
+		**out = **in
+	}
 	return
 }
 
@@ -1838,6 +2129,11 @@ func (in *HotplugVolumeSource) DeepCopy() *HotplugVolumeSource {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HotplugVolumeStatus) DeepCopyInto(out *HotplugVolumeStatus) {
 	*out = *in
+	if in.CSIVolume != nil {
+		in, out := &in.CSIVolume, &out.CSIVolume
+		*out = new(HotplugCSIVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1904,6 +2200,27 @@ func (in *I6300ESBWatchdog) DeepCopy() *I6300ESBWatchdog {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ISCSIVolumeSource) DeepCopyInto(out *ISCSIVolumeSource) {
+	*out = *in
+	if in.CHAPSecretRef != nil {
+		in, out := &in.CHAPSecretRef, &out.CHAPSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ISCSIVolumeSource.
+func (in *ISCSIVolumeSource) DeepCopy() *ISCSIVolumeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ISCSIVolumeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Input) DeepCopyInto(out *Input) {
 	*out = *in
@@ -1920,6 +2237,32 @@ func (in *Input) DeepCopy() *Input {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InsertMediaOptions) DeepCopyInto(out *InsertMediaOptions) {
+	*out = *in
+	if in.VolumeSource != nil {
+		in, out := &in.VolumeSource, &out.VolumeSource
+		*out = new(HotplugVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InsertMediaOptions.
+func (in *InsertMediaOptions) DeepCopy() *InsertMediaOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(InsertMediaOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstancetypeMatcher) DeepCopyInto(out *InstancetypeMatcher) {
 	*out = *in
@@ -1955,6 +2298,11 @@ func (in *Interface) DeepCopyInto(out *Interface) {
 		*out = new(DHCPOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RouterAdvertisement != nil {
+		in, out := &in.RouterAdvertisement, &out.RouterAdvertisement
+		*out = new(RouterAdvertisementOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1984,7 +2332,7 @@ func (in *InterfaceBindingMethod) DeepCopyInto(out *InterfaceBindingMethod) {
 	if in.Masquerade != nil {
 		in, out := &in.Masquerade, &out.Masquerade
 		*out = new(InterfaceMasquerade)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.SRIOV != nil {
 		in, out := &in.SRIOV, &out.SRIOV
@@ -2001,6 +2349,11 @@ func (in *InterfaceBindingMethod) DeepCopyInto(out *InterfaceBindingMethod) {
 		*out = new(InterfacePasst)
 		**out = **in
 	}
+	if in.VhostUser != nil {
+		in, out := &in.VhostUser, &out.VhostUser
+		*out = new(InterfaceVhostUser)
+		**out = **in
+	}
 	return
 }
 
@@ -2030,6 +2383,32 @@ func (in *InterfaceBridge) DeepCopy() *InterfaceBridge {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceFirewall) DeepCopyInto(out *InterfaceFirewall) {
+	*out = *in
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(FirewallPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = new(FirewallPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceFirewall.
+func (in *InterfaceFirewall) DeepCopy() *InterfaceFirewall {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceFirewall)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceMacvtap) DeepCopyInto(out *InterfaceMacvtap) {
 	*out = *in
@@ -2049,6 +2428,11 @@ func (in *InterfaceMacvtap) DeepCopy() *InterfaceMacvtap {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceMasquerade) DeepCopyInto(out *InterfaceMasquerade) {
 	*out = *in
+	if in.Firewall != nil {
+		in, out := &in.Firewall, &out.Firewall
+		*out = new(InterfaceFirewall)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2110,6 +2494,22 @@ func (in *InterfaceSlirp) DeepCopy() *InterfaceSlirp {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceVhostUser) DeepCopyInto(out *InterfaceVhostUser) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceVhostUser.
+func (in *InterfaceVhostUser) DeepCopy() *InterfaceVhostUser {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceVhostUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KSMConfiguration) DeepCopyInto(out *KSMConfiguration) {
 	*out = *in
@@ -2393,6 +2793,11 @@ func (in *KubeVirtConfiguration) DeepCopyInto(out *KubeVirtConfiguration) {
 		*out = new(LiveUpdateConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ContainerDiskVerification != nil {
+		in, out := &in.ContainerDiskVerification, &out.ContainerDiskVerification
+		*out = new(ContainerDiskVerification)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2929,6 +3334,22 @@ func (in *MultusNetwork) DeepCopy() *MultusNetwork {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NBDVolumeSource) DeepCopyInto(out *NBDVolumeSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NBDVolumeSource.
+func (in *NBDVolumeSource) DeepCopy() *NBDVolumeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(NBDVolumeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NUMA) DeepCopyInto(out *NUMA) {
 	*out = *in
@@ -3103,6 +3524,22 @@ func (in *NodePlacement) DeepCopy() *NodePlacement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OverlayVolumeSource) DeepCopyInto(out *OverlayVolumeSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OverlayVolumeSource.
+func (in *OverlayVolumeSource) DeepCopy() *OverlayVolumeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(OverlayVolumeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PITTimer) DeepCopyInto(out *PITTimer) {
 	*out = *in
@@ -3383,6 +3820,32 @@ func (in *QemuGuestAgentUserPasswordAccessCredentialPropagation) DeepCopy() *Qem
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBDVolumeSource) DeepCopyInto(out *RBDVolumeSource) {
+	*out = *in
+	if in.Monitors != nil {
+		in, out := &in.Monitors, &out.Monitors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.KeyringSecretRef != nil {
+		in, out := &in.KeyringSecretRef, &out.KeyringSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBDVolumeSource.
+func (in *RBDVolumeSource) DeepCopy() *RBDVolumeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RBDVolumeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RESTClientConfiguration) DeepCopyInto(out *RESTClientConfiguration) {
 	*out = *in
@@ -3483,6 +3946,27 @@ func (in *ReloadableComponentConfiguration) DeepCopy() *ReloadableComponentConfi
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoveFilesystemOptions) DeepCopyInto(out *RemoveFilesystemOptions) {
+	*out = *in
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoveFilesystemOptions.
+func (in *RemoveFilesystemOptions) DeepCopy() *RemoveFilesystemOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoveFilesystemOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoveInterfaceOptions) DeepCopyInto(out *RemoveInterfaceOptions) {
 	*out = *in
@@ -3593,6 +4077,32 @@ func (in *Rng) DeepCopy() *Rng {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterAdvertisementOptions) DeepCopyInto(out *RouterAdvertisementOptions) {
+	*out = *in
+	if in.DNSServers != nil {
+		in, out := &in.DNSServers, &out.DNSServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedAddressConfiguration != nil {
+		in, out := &in.ManagedAddressConfiguration, &out.ManagedAddressConfiguration
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterAdvertisementOptions.
+func (in *RouterAdvertisementOptions) DeepCopy() *RouterAdvertisementOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterAdvertisementOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SEV) DeepCopyInto(out *SEV) {
 	*out = *in
@@ -4811,6 +5321,11 @@ func (in *VirtualMachineInstanceNetworkInterface) DeepCopyInto(out *VirtualMachi
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IPSources != nil {
+		in, out := &in.IPSources, &out.IPSources
+		*out = make([]VirtualMachineInstanceNetworkInterfaceIPSource, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -4824,6 +5339,22 @@ func (in *VirtualMachineInstanceNetworkInterface) DeepCopy() *VirtualMachineInst
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceNetworkInterfaceIPSource) DeepCopyInto(out *VirtualMachineInstanceNetworkInterfaceIPSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceNetworkInterfaceIPSource.
+func (in *VirtualMachineInstanceNetworkInterfaceIPSource) DeepCopy() *VirtualMachineInstanceNetworkInterfaceIPSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceNetworkInterfaceIPSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstancePhaseTransitionTimestamp) DeepCopyInto(out *VirtualMachineInstancePhaseTransitionTimestamp) {
 	*out = *in
@@ -5158,6 +5689,11 @@ func (in *VirtualMachineInstanceSpec) DeepCopyInto(out *VirtualMachineInstanceSp
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FilesystemTrim != nil {
+		in, out := &in.FilesystemTrim, &out.FilesystemTrim
+		*out = new(FilesystemTrim)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5240,6 +5776,11 @@ func (in *VirtualMachineInstanceStatus) DeepCopyInto(out *VirtualMachineInstance
 		*out = new(CPUTopology)
 		**out = **in
 	}
+	if in.FilesystemTrim != nil {
+		in, out := &in.FilesystemTrim, &out.FilesystemTrim
+		*out = new(FilesystemTrimStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5563,6 +6104,26 @@ func (in *VirtualMachineVolumeRequest) DeepCopyInto(out *VirtualMachineVolumeReq
 		*out = new(RemoveVolumeOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.InsertMediaOptions != nil {
+		in, out := &in.InsertMediaOptions, &out.InsertMediaOptions
+		*out = new(InsertMediaOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EjectMediaOptions != nil {
+		in, out := &in.EjectMediaOptions, &out.EjectMediaOptions
+		*out = new(EjectMediaOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AddFilesystemOptions != nil {
+		in, out := &in.AddFilesystemOptions, &out.AddFilesystemOptions
+		*out = new(AddFilesystemOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemoveFilesystemOptions != nil {
+		in, out := &in.RemoveFilesystemOptions, &out.RemoveFilesystemOptions
+		*out = new(RemoveFilesystemOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5593,6 +6154,27 @@ func (in *Volume) DeepCopy() *Volume {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGuestUsage) DeepCopyInto(out *VolumeGuestUsage) {
+	*out = *in
+	if in.MountPoints != nil {
+		in, out := &in.MountPoints, &out.MountPoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGuestUsage.
+func (in *VolumeGuestUsage) DeepCopy() *VolumeGuestUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGuestUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeSnapshotStatus) DeepCopyInto(out *VolumeSnapshotStatus) {
 	*out = *in
@@ -5647,6 +6229,11 @@ func (in *VolumeSource) DeepCopyInto(out *VolumeSource) {
 		*out = new(EphemeralVolumeSource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Overlay != nil {
+		in, out := &in.Overlay, &out.Overlay
+		*out = new(OverlayVolumeSource)
+		**out = **in
+	}
 	if in.EmptyDisk != nil {
 		in, out := &in.EmptyDisk, &out.EmptyDisk
 		*out = new(EmptyDiskSource)
@@ -5687,6 +6274,21 @@ func (in *VolumeSource) DeepCopyInto(out *VolumeSource) {
 		*out = new(MemoryDumpVolumeSource)
 		**out = **in
 	}
+	if in.NBD != nil {
+		in, out := &in.NBD, &out.NBD
+		*out = new(NBDVolumeSource)
+		**out = **in
+	}
+	if in.ISCSI != nil {
+		in, out := &in.ISCSI, &out.ISCSI
+		*out = new(ISCSIVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RBD != nil {
+		in, out := &in.RBD, &out.RBD
+		*out = new(RBDVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5711,13 +6313,18 @@ func (in *VolumeStatus) DeepCopyInto(out *VolumeStatus) {
 	if in.HotplugVolume != nil {
 		in, out := &in.HotplugVolume, &out.HotplugVolume
 		*out = new(HotplugVolumeStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.MemoryDumpVolume != nil {
 		in, out := &in.MemoryDumpVolume, &out.MemoryDumpVolume
 		*out = new(DomainMemoryDumpInfo)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GuestUsage != nil {
+		in, out := &in.GuestUsage, &out.GuestUsage
+		*out = new(VolumeGuestUsage)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
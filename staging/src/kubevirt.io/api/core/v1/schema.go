@@ -69,6 +69,9 @@ type ConfigMapVolumeSource struct {
 	// Typical values are "cidata" (cloud-init), "config-2" (cloud-init) or "OEMDRV" (kickstart).
 	// +optional
 	VolumeLabel string `json:"volumeLabel,omitempty"`
+	// Hotpluggable indicates whether the volume can be hotplugged and hotunplugged.
+	// +optional
+	Hotpluggable bool `json:"hotpluggable,omitempty"`
 }
 
 // SecretVolumeSource adapts a Secret into a volume.
@@ -84,6 +87,9 @@ type SecretVolumeSource struct {
 	// Typical values are "cidata" (cloud-init), "config-2" (cloud-init) or "OEMDRV" (kickstart).
 	// +optional
 	VolumeLabel string `json:"volumeLabel,omitempty"`
+	// Hotpluggable indicates whether the volume can be hotplugged and hotunplugged.
+	// +optional
+	Hotpluggable bool `json:"hotpluggable,omitempty"`
 }
 
 // DownwardAPIVolumeSource represents a volume containing downward API info.
@@ -103,6 +109,9 @@ type ServiceAccountVolumeSource struct {
 	// Name of the service account in the pod's namespace to use.
 	// More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Hotpluggable indicates whether the volume can be hotplugged and hotunplugged.
+	// +optional
+	Hotpluggable bool `json:"hotpluggable,omitempty"`
 }
 
 // DownwardMetricsVolumeSource adds a very small disk to VMIs which contains a limited view of host and guest
@@ -237,6 +246,12 @@ type EFI struct {
 	// Defaults to true
 	// +optional
 	SecureBoot *bool `json:"secureBoot,omitempty"`
+	// If set to true, the EFI NVRAM variables are kept in the backend storage of the VM,
+	// so that enrolled Secure Boot keys and boot entries survive restarts.
+	// Requires the VMPersistentState feature gate.
+	// Defaults to false
+	// +optional
+	Persistent *bool `json:"persistent,omitempty"`
 }
 
 // If set, the VM will be booted from the defined kernel / initrd.
@@ -408,6 +423,12 @@ type Devices struct {
 	UseVirtioTransitional *bool `json:"useVirtioTransitional,omitempty"`
 	// DisableHotplug disabled the ability to hotplug disks.
 	DisableHotplug bool `json:"disableHotplug,omitempty"`
+	// FilesystemHotplug enables the ability to hotplug virtiofs filesystems.
+	// It must be set before the VMI starts, on the VM template for filesystems persisted in the VM.
+	// The guest memory is backed by shared memory, as required by virtiofs.
+	// Defaults to false.
+	// +optional
+	FilesystemHotplug bool `json:"filesystemHotplug,omitempty"`
 	// Disks describes disks, cdroms and luns which are connected to the vmi.
 	Disks []Disk `json:"disks,omitempty"`
 	// Watchdog describes a watchdog device which can be added to the vmi.
@@ -582,8 +603,13 @@ type Disk struct {
 	// +optional
 	BootOrder *uint `json:"bootOrder,omitempty"`
 	// Serial provides the ability to specify a serial number for the disk device.
+	// NVMe disks are limited to 20 characters and default to a serial derived from the disk name.
 	// +optional
 	Serial string `json:"serial,omitempty"`
+	// WWN provides the ability to specify a World Wide Name for the disk device, as 16 hexadecimal digits.
+	// Only supported on the scsi, sata and nvme buses.
+	// +optional
+	WWN string `json:"wwn,omitempty"`
 	// dedicatedIOThread indicates this disk should have an exclusive IO Thread.
 	// Enabling this implies useIOThreads = true.
 	// Defaults to false.
@@ -606,6 +632,69 @@ type Disk struct {
 	// If specified the disk is made sharable and multiple write from different VMs are permitted
 	// +optional
 	Shareable *bool `json:"shareable,omitempty"`
+	// If specified, limits the I/O throughput of the disk.
+	// The limits can be changed while the VMI is running.
+	// +optional
+	IOTune *DiskIOTune `json:"ioTune,omitempty"`
+	// If specified, the disk image is a qemu-native LUKS volume unlocked with the passphrase of the referenced Secret.
+	// A blank volume is only formatted as LUKS volume on first boot if format is set.
+	// +optional
+	Encryption *DiskEncryption `json:"encryption,omitempty"`
+}
+
+// DiskEncryption references the passphrase of a LUKS encrypted disk image.
+type DiskEncryption struct {
+	// SecretRef references a Secret in the namespace of the VMI which holds the LUKS passphrase under the "passphrase" key.
+	SecretRef v1.LocalObjectReference `json:"secretRef"`
+	// Format allows formatting the volume as LUKS volume on first boot if it is blank, i.e. its first MiB only contains zeros.
+	// Without it, the volume has to be a LUKS volume already.
+	// +optional
+	Format bool `json:"format,omitempty"`
+}
+
+// DiskIOTune represents the I/O throttling applied to a disk.
+// A value of zero, or an omitted value, means no limit. Negative values are invalid.
+// Total limits cannot be combined with the corresponding read or write limits.
+type DiskIOTune struct {
+	// Disks sharing a group name share their limits, the limits of the last disk in the group apply.
+	// +optional
+	GroupName string `json:"groupName,omitempty"`
+	// Total throughput limit in bytes per second.
+	// +optional
+	TotalBytesSec int64 `json:"totalBytesSec,omitempty"`
+	// Read throughput limit in bytes per second.
+	// +optional
+	ReadBytesSec int64 `json:"readBytesSec,omitempty"`
+	// Write throughput limit in bytes per second.
+	// +optional
+	WriteBytesSec int64 `json:"writeBytesSec,omitempty"`
+	// Total I/O operations per second limit.
+	// +optional
+	TotalIOPSSec int64 `json:"totalIOPSSec,omitempty"`
+	// Read I/O operations per second limit.
+	// +optional
+	ReadIOPSSec int64 `json:"readIOPSSec,omitempty"`
+	// Write I/O operations per second limit.
+	// +optional
+	WriteIOPSSec int64 `json:"writeIOPSSec,omitempty"`
+	// Total throughput allowed in bytes per second during bursts.
+	// +optional
+	TotalBytesSecMax int64 `json:"totalBytesSecMax,omitempty"`
+	// Read throughput allowed in bytes per second during bursts.
+	// +optional
+	ReadBytesSecMax int64 `json:"readBytesSecMax,omitempty"`
+	// Write throughput allowed in bytes per second during bursts.
+	// +optional
+	WriteBytesSecMax int64 `json:"writeBytesSecMax,omitempty"`
+	// Total I/O operations per second allowed during bursts.
+	// +optional
+	TotalIOPSSecMax int64 `json:"totalIOPSSecMax,omitempty"`
+	// Read I/O operations per second allowed during bursts.
+	// +optional
+	ReadIOPSSecMax int64 `json:"readIOPSSecMax,omitempty"`
+	// Write I/O operations per second allowed during bursts.
+	// +optional
+	WriteIOPSSecMax int64 `json:"writeIOPSSecMax,omitempty"`
 }
 
 // CustomBlockSize represents the desired logical and physical block size for a VM disk.
@@ -639,16 +728,18 @@ const (
 	DiskBusSATA   DiskBus = "sata"
 	DiskBusVirtio DiskBus = VirtIO
 	DiskBusUSB    DiskBus = "usb"
+	DiskBusNVMe   DiskBus = "nvme"
 )
 
 type DiskTarget struct {
 	// Bus indicates the type of disk device to emulate.
-	// supported values: virtio, sata, scsi, usb.
+	// supported values: virtio, sata, scsi, usb, nvme.
 	Bus DiskBus `json:"bus,omitempty"`
 	// ReadOnly.
 	// Defaults to false.
 	ReadOnly bool `json:"readonly,omitempty"`
 	// If specified, the virtual disk will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10
+	// For nvme disks the address is assigned to the disk's NVMe controller.
 	// +optional
 	PciAddress string `json:"pciAddress,omitempty"`
 }
@@ -758,6 +849,9 @@ type VolumeSource struct {
 	// Ephemeral is a special volume source that "wraps" specified source and provides copy-on-write image on top of it.
 	// +optional
 	Ephemeral *EphemeralVolumeSource `json:"ephemeral,omitempty"`
+	// Overlay is a persistent copy-on-write image on a PVC, backed by a shared read-only base image on another PVC.
+	// +optional
+	Overlay *OverlayVolumeSource `json:"overlay,omitempty"`
 	// EmptyDisk represents a temporary disk which shares the vmis lifecycle.
 	// More info: https://kubevirt.gitbooks.io/user-guide/disks-and-volumes.html
 	// +optional
@@ -787,6 +881,15 @@ type VolumeSource struct {
 	DownwardMetrics *DownwardMetricsVolumeSource `json:"downwardMetrics,omitempty"`
 	// MemoryDump is attached to the virt launcher and is populated with a memory dump of the vmi
 	MemoryDump *MemoryDumpVolumeSource `json:"memoryDump,omitempty"`
+	// NBD represents an export of a network block device server, which qemu connects to directly.
+	// +optional
+	NBD *NBDVolumeSource `json:"nbd,omitempty"`
+	// ISCSI represents a LUN of an iSCSI target, which qemu connects to directly.
+	// +optional
+	ISCSI *ISCSIVolumeSource `json:"iscsi,omitempty"`
+	// RBD represents an image of a Ceph cluster, which qemu connects to directly.
+	// +optional
+	RBD *RBDVolumeSource `json:"rbd,omitempty"`
 }
 
 // HotplugVolumeSource Represents the source of a volume to mount which are capable
@@ -802,6 +905,22 @@ type HotplugVolumeSource struct {
 	// the process of populating that PVC with a disk image.
 	// +optional
 	DataVolume *DataVolumeSource `json:"dataVolume,omitempty"`
+	// ContainerDisk references a docker image, embedding a qcow or raw disk.
+	// Hotplugged container disks are attached read-only.
+	// +optional
+	ContainerDisk *ContainerDiskSource `json:"containerDisk,omitempty"`
+	// ConfigMapSource represents a reference to a ConfigMap in the same namespace.
+	// Hotplugged ConfigMaps are attached as read-only iso images.
+	// +optional
+	ConfigMap *ConfigMapVolumeSource `json:"configMap,omitempty"`
+	// SecretVolumeSource represents a reference to a secret data in the same namespace.
+	// Hotplugged Secrets are attached as read-only iso images.
+	// +optional
+	Secret *SecretVolumeSource `json:"secret,omitempty"`
+	// ServiceAccountVolumeSource represents a reference to a service account.
+	// Hotplugged ServiceAccounts are attached as read-only iso images.
+	// +optional
+	ServiceAccount *ServiceAccountVolumeSource `json:"serviceAccount,omitempty"`
 }
 
 type DataVolumeSource struct {
@@ -838,6 +957,65 @@ type EphemeralVolumeSource struct {
 	PersistentVolumeClaim *v1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
 }
 
+// OverlayVolumeSource represents a persistent qcow2 overlay backed by a shared base image.
+// The overlay is created on first boot and keeps all writes of the vmi, the base image is never written.
+type OverlayVolumeSource struct {
+	// ClaimName is the name of a filesystem PVC in the same namespace which stores the qcow2 overlay.
+	ClaimName string `json:"claimName"`
+	// BaseClaimName is the name of a PVC in the same namespace which holds the raw base image.
+	// It is attached read-only and can be shared by many vmis.
+	BaseClaimName string `json:"baseClaimName"`
+}
+
+// NBDVolumeSource represents an export of a network block device server.
+type NBDVolumeSource struct {
+	// URI of the export, in the form nbd://host[:port][/export].
+	// The port defaults to 10809.
+	URI string `json:"uri"`
+}
+
+// ISCSIVolumeSource represents a LUN of an iSCSI target.
+type ISCSIVolumeSource struct {
+	// TargetPortal is the address of the target, host or host:port.
+	// The port defaults to 3260.
+	TargetPortal string `json:"targetPortal"`
+	// IQN is the iSCSI qualified name of the target.
+	IQN string `json:"iqn"`
+	// Lun is the number of the LUN on the target. Defaults to 0.
+	// +optional
+	Lun int32 `json:"lun,omitempty"`
+	// InitiatorName is the iSCSI qualified name of the initiator.
+	// Defaults to the initiator name of qemu.
+	// +optional
+	InitiatorName string `json:"initiatorName,omitempty"`
+	// CHAPSecretRef references a Secret in the namespace of the VMI which holds the CHAP credentials
+	// under the "node.session.auth.username" and "node.session.auth.password" keys,
+	// as the Secrets of type kubernetes.io/iscsi-chap.
+	// +optional
+	CHAPSecretRef *v1.LocalObjectReference `json:"chapSecretRef,omitempty"`
+}
+
+// RBDVolumeSource represents an image of a Ceph cluster.
+type RBDVolumeSource struct {
+	// Monitors are the addresses of the Ceph monitors, host or host:port.
+	// The port defaults to 6789.
+	// +listType=atomic
+	Monitors []string `json:"monitors"`
+	// Pool is the name of the pool holding the image. Defaults to rbd.
+	// +optional
+	Pool string `json:"pool,omitempty"`
+	// Image is the name of the image.
+	Image string `json:"image"`
+	// User is the Ceph user to authenticate as. Defaults to admin.
+	// +optional
+	User string `json:"user,omitempty"`
+	// KeyringSecretRef references a Secret in the namespace of the VMI which holds the cephx key of the user
+	// under the "key" key, as the Secrets of type kubernetes.io/rbd.
+	// Without it, cephx authentication is disabled.
+	// +optional
+	KeyringSecretRef *v1.LocalObjectReference `json:"keyringSecretRef,omitempty"`
+}
+
 // EmptyDisk represents a temporary disk which shares the vmis lifecycle.
 type EmptyDiskSource struct {
 	// Capacity of the sparse disk.
@@ -859,6 +1037,9 @@ type ContainerDiskSource struct {
 	// More info: https://kubernetes.io/docs/concepts/containers/images#updating-images
 	// +optional
 	ImagePullPolicy v1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// Hotpluggable indicates whether the volume can be hotplugged and hotunplugged.
+	// +optional
+	Hotpluggable bool `json:"hotpluggable,omitempty"`
 }
 
 // Exactly one of its members must be set.
@@ -1201,6 +1382,11 @@ type Interface struct {
 	// If specified the network interface will pass additional DHCP options to the VMI
 	// +optional
 	DHCPOptions *DHCPOptions `json:"dhcpOptions,omitempty"`
+	// If specified, enables and tunes the IPv6 router advertisements sent to the guest.
+	// Router advertisements are sent on masquerade interfaces setting it, once the pod has IPv6 connectivity,
+	// passt interfaces accept the DNS servers only.
+	// +optional
+	RouterAdvertisement *RouterAdvertisementOptions `json:"routerAdvertisement,omitempty"`
 	// If specified, the virtual network interface address and its tag will be provided to the guest via config drive
 	// +optional
 	Tag string `json:"tag,omitempty"`
@@ -1255,6 +1441,26 @@ func (d *DHCPOptions) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// RouterAdvertisementOptions defines the content of the IPv6 router advertisements sent to the guest.
+type RouterAdvertisementOptions struct {
+	// Prefix advertised as on-link, in CIDR notation.
+	// Guests autoconfigure an address (SLAAC) only from a /64 prefix.
+	// Defaults to the IPv6 CIDR of the masquerade network.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// DNSServers are the IPv6 addresses advertised as recursive DNS servers (RDNSS).
+	// +optional
+	// +listType=atomic
+	DNSServers []string `json:"dnsServers,omitempty"`
+	// ManagedAddressConfiguration sets the managed (M) flag, telling the guest to obtain its address through DHCPv6.
+	// Defaults to true.
+	// +optional
+	ManagedAddressConfiguration *bool `json:"managedAddressConfiguration,omitempty"`
+	// OtherConfiguration sets the other configuration (O) flag, telling the guest to obtain further configuration through DHCPv6.
+	// +optional
+	OtherConfiguration bool `json:"otherConfiguration,omitempty"`
+}
+
 // DHCPExtraOptions defines Extra DHCP options for a VM.
 type DHCPPrivateOptions struct {
 	// Option is an Integer value from 224-254
@@ -1274,6 +1480,7 @@ type InterfaceBindingMethod struct {
 	SRIOV      *InterfaceSRIOV      `json:"sriov,omitempty"`
 	Macvtap    *InterfaceMacvtap    `json:"macvtap,omitempty"`
 	Passt      *InterfacePasst      `json:"passt,omitempty"`
+	VhostUser  *InterfaceVhostUser  `json:"vhostUser,omitempty"`
 }
 
 // InterfaceBridge connects to a given network via a linux bridge.
@@ -1283,7 +1490,59 @@ type InterfaceBridge struct{}
 type InterfaceSlirp struct{}
 
 // InterfaceMasquerade connects to a given network using netfilter rules to nat the traffic.
-type InterfaceMasquerade struct{}
+type InterfaceMasquerade struct {
+	// Firewall restricts the traffic reaching and leaving the virtual machine through the interface.
+	// +optional
+	Firewall *InterfaceFirewall `json:"firewall,omitempty"`
+}
+
+// InterfaceFirewall holds the policies applied to the traffic of an interface.
+type InterfaceFirewall struct {
+	// Ingress policy, applied to the traffic towards the virtual machine.
+	// +optional
+	Ingress *FirewallPolicy `json:"ingress,omitempty"`
+	// Egress policy, applied to the traffic originating from the virtual machine.
+	// +optional
+	Egress *FirewallPolicy `json:"egress,omitempty"`
+}
+
+// FirewallPolicy is an ordered list of rules, the first rule matching the traffic decides its fate.
+// Replies to allowed connections are always accepted.
+type FirewallPolicy struct {
+	// DefaultAction is applied to the traffic no rule matched.
+	// Defaults to Allow.
+	// +optional
+	DefaultAction FirewallAction `json:"defaultAction,omitempty"`
+	// +optional
+	// +listType=atomic
+	Rules []FirewallRule `json:"rules,omitempty"`
+}
+
+// FirewallRule matches traffic by its remote peer, protocol and port.
+// Unset fields match any traffic.
+type FirewallRule struct {
+	// Action applied to the matching traffic.
+	Action FirewallAction `json:"action"`
+	// RemoteCIDRs match the remote peer, the source of ingress traffic and the destination of egress traffic.
+	// +optional
+	// +listType=atomic
+	RemoteCIDRs []string `json:"remoteCIDRs,omitempty"`
+	// Protocol of the traffic. Must be TCP, UDP, SCTP or ICMP.
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+	// Ports or port ranges (e.g. 8000-9000) the traffic is destined to.
+	// Only valid along with the TCP, UDP or SCTP protocols.
+	// +optional
+	// +listType=atomic
+	Ports []string `json:"ports,omitempty"`
+}
+
+type FirewallAction string
+
+const (
+	FirewallActionAllow FirewallAction = "Allow"
+	FirewallActionDeny  FirewallAction = "Deny"
+)
 
 // InterfaceSRIOV connects to a given network by passing-through an SR-IOV PCI device via vfio.
 type InterfaceSRIOV struct{}
@@ -1294,6 +1553,9 @@ type InterfaceMacvtap struct{}
 // InterfacePasst connects to a given network.
 type InterfacePasst struct{}
 
+// InterfaceVhostUser connects to a given network through a vhost-user socket served by a userspace datapath, such as OVS-DPDK.
+type InterfaceVhostUser struct{}
+
 // Port represents a port to expose from the virtual machine.
 // Default protocol TCP.
 // The port field is mandatory
@@ -14,18 +14,20 @@ func (HostDisk) SwaggerDoc() map[string]string {
 
 func (ConfigMapVolumeSource) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":            "ConfigMapVolumeSource adapts a ConfigMap into a volume.\nMore info: https://kubernetes.io/docs/concepts/storage/volumes/#configmap",
-		"optional":    "Specify whether the ConfigMap or it's keys must be defined\n+optional",
-		"volumeLabel": "The volume label of the resulting disk inside the VMI.\nDifferent bootstrapping mechanisms require different values.\nTypical values are \"cidata\" (cloud-init), \"config-2\" (cloud-init) or \"OEMDRV\" (kickstart).\n+optional",
+		"":             "ConfigMapVolumeSource adapts a ConfigMap into a volume.\nMore info: https://kubernetes.io/docs/concepts/storage/volumes/#configmap",
+		"optional":     "Specify whether the ConfigMap or it's keys must be defined\n+optional",
+		"volumeLabel":  "The volume label of the resulting disk inside the VMI.\nDifferent bootstrapping mechanisms require different values.\nTypical values are \"cidata\" (cloud-init), \"config-2\" (cloud-init) or \"OEMDRV\" (kickstart).\n+optional",
+		"hotpluggable": "Hotpluggable indicates whether the volume can be hotplugged and hotunplugged.\n+optional",
 	}
 }
 
 func (SecretVolumeSource) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":            "SecretVolumeSource adapts a Secret into a volume.",
-		"secretName":  "Name of the secret in the pod's namespace to use.\nMore info: https://kubernetes.io/docs/concepts/storage/volumes#secret",
-		"optional":    "Specify whether the Secret or it's keys must be defined\n+optional",
-		"volumeLabel": "The volume label of the resulting disk inside the VMI.\nDifferent bootstrapping mechanisms require different values.\nTypical values are \"cidata\" (cloud-init), \"config-2\" (cloud-init) or \"OEMDRV\" (kickstart).\n+optional",
+		"":             "SecretVolumeSource adapts a Secret into a volume.",
+		"secretName":   "Name of the secret in the pod's namespace to use.\nMore info: https://kubernetes.io/docs/concepts/storage/volumes#secret",
+		"optional":     "Specify whether the Secret or it's keys must be defined\n+optional",
+		"volumeLabel":  "The volume label of the resulting disk inside the VMI.\nDifferent bootstrapping mechanisms require different values.\nTypical values are \"cidata\" (cloud-init), \"config-2\" (cloud-init) or \"OEMDRV\" (kickstart).\n+optional",
+		"hotpluggable": "Hotpluggable indicates whether the volume can be hotplugged and hotunplugged.\n+optional",
 	}
 }
 
@@ -41,6 +43,7 @@ func (ServiceAccountVolumeSource) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                   "ServiceAccountVolumeSource adapts a ServiceAccount into a volume.",
 		"serviceAccountName": "Name of the service account in the pod's namespace to use.\nMore info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/",
+		"hotpluggable":       "Hotpluggable indicates whether the volume can be hotplugged and hotunplugged.\n+optional",
 	}
 }
 
@@ -123,6 +126,7 @@ func (EFI) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":           "If set, EFI will be used instead of BIOS.",
 		"secureBoot": "If set, SecureBoot will be enabled and the OVMF roms will be swapped for\nSecureBoot-enabled ones.\nRequires SMM to be enabled.\nDefaults to true\n+optional",
+		"persistent": "If set to true, the EFI NVRAM variables are kept in the backend storage of the VM,\nso that enrolled Secure Boot keys and boot entries survive restarts.\nRequires the VMPersistentState feature gate.\nDefaults to false\n+optional",
 	}
 }
 
@@ -230,6 +234,7 @@ func (Devices) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"useVirtioTransitional":      "Fall back to legacy virtio 0.9 support if virtio bus is selected on devices.\nThis is helpful for old machines like CentOS6 or RHEL6 which\ndo not understand virtio_non_transitional (virtio 1.0).",
 		"disableHotplug":             "DisableHotplug disabled the ability to hotplug disks.",
+		"filesystemHotplug":          "FilesystemHotplug enables the ability to hotplug virtiofs filesystems.\nIt must be set before the VMI starts, on the VM template for filesystems persisted in the VM.\nThe guest memory is backed by shared memory, as required by virtiofs.\nDefaults to false.\n+optional",
 		"disks":                      "Disks describes disks, cdroms and luns which are connected to the vmi.",
 		"watchdog":                   "Watchdog describes a watchdog device which can be added to the vmi.",
 		"interfaces":                 "Interfaces describe network interfaces which are added to the vmi.",
@@ -320,13 +325,43 @@ func (Disk) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"name":              "Name is the device name",
 		"bootOrder":         "BootOrder is an integer value > 0, used to determine ordering of boot devices.\nLower values take precedence.\nEach disk or interface that has a boot order must have a unique value.\nDisks without a boot order are not tried if a disk with a boot order exists.\n+optional",
-		"serial":            "Serial provides the ability to specify a serial number for the disk device.\n+optional",
+		"serial":            "Serial provides the ability to specify a serial number for the disk device.\nNVMe disks are limited to 20 characters and default to a serial derived from the disk name.\n+optional",
+		"wwn":               "WWN provides the ability to specify a World Wide Name for the disk device, as 16 hexadecimal digits.\nOnly supported on the scsi, sata and nvme buses.\n+optional",
 		"dedicatedIOThread": "dedicatedIOThread indicates this disk should have an exclusive IO Thread.\nEnabling this implies useIOThreads = true.\nDefaults to false.\n+optional",
 		"cache":             "Cache specifies which kvm disk cache mode should be used.\nSupported values are: CacheNone, CacheWriteThrough.\n+optional",
 		"io":                "IO specifies which QEMU disk IO mode should be used.\nSupported values are: native, default, threads.\n+optional",
 		"tag":               "If specified, disk address and its tag will be provided to the guest via config drive metadata\n+optional",
 		"blockSize":         "If specified, the virtual disk will be presented with the given block sizes.\n+optional",
 		"shareable":         "If specified the disk is made sharable and multiple write from different VMs are permitted\n+optional",
+		"ioTune":            "If specified, limits the I/O throughput of the disk.\nThe limits can be changed while the VMI is running.\n+optional",
+		"encryption":        "If specified, the disk image is a qemu-native LUKS volume unlocked with the passphrase of the referenced Secret.\nA blank volume is only formatted as LUKS volume on first boot if format is set.\n+optional",
+	}
+}
+
+func (DiskEncryption) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "DiskEncryption references the passphrase of a LUKS encrypted disk image.",
+		"secretRef": "SecretRef references a Secret in the namespace of the VMI which holds the LUKS passphrase under the \"passphrase\" key.",
+		"format":    "Format allows formatting the volume as LUKS volume on first boot if it is blank, i.e. its first MiB only contains zeros.\nWithout it, the volume has to be a LUKS volume already.\n+optional",
+	}
+}
+
+func (DiskIOTune) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "DiskIOTune represents the I/O throttling applied to a disk.\nA value of zero, or an omitted value, means no limit. Negative values are invalid.\nTotal limits cannot be combined with the corresponding read or write limits.",
+		"groupName":        "Disks sharing a group name share their limits, the limits of the last disk in the group apply.\n+optional",
+		"totalBytesSec":    "Total throughput limit in bytes per second.\n+optional",
+		"readBytesSec":     "Read throughput limit in bytes per second.\n+optional",
+		"writeBytesSec":    "Write throughput limit in bytes per second.\n+optional",
+		"totalIOPSSec":     "Total I/O operations per second limit.\n+optional",
+		"readIOPSSec":      "Read I/O operations per second limit.\n+optional",
+		"writeIOPSSec":     "Write I/O operations per second limit.\n+optional",
+		"totalBytesSecMax": "Total throughput allowed in bytes per second during bursts.\n+optional",
+		"readBytesSecMax":  "Read throughput allowed in bytes per second during bursts.\n+optional",
+		"writeBytesSecMax": "Write throughput allowed in bytes per second during bursts.\n+optional",
+		"totalIOPSSecMax":  "Total I/O operations per second allowed during bursts.\n+optional",
+		"readIOPSSecMax":   "Read I/O operations per second allowed during bursts.\n+optional",
+		"writeIOPSSecMax":  "Write I/O operations per second allowed during bursts.\n+optional",
 	}
 }
 
@@ -353,9 +388,9 @@ func (DiskDevice) SwaggerDoc() map[string]string {
 
 func (DiskTarget) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"bus":        "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi, usb.",
+		"bus":        "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi, usb, nvme.",
 		"readonly":   "ReadOnly.\nDefaults to false.",
-		"pciAddress": "If specified, the virtual disk will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10\n+optional",
+		"pciAddress": "If specified, the virtual disk will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10\nFor nvme disks the address is assigned to the disk's NVMe controller.\n+optional",
 	}
 }
 
@@ -417,6 +452,7 @@ func (VolumeSource) SwaggerDoc() map[string]string {
 		"sysprep":               "Represents a Sysprep volume source.\n+optional",
 		"containerDisk":         "ContainerDisk references a docker image, embedding a qcow or raw disk.\nMore info: https://kubevirt.gitbooks.io/user-guide/registry-disk.html\n+optional",
 		"ephemeral":             "Ephemeral is a special volume source that \"wraps\" specified source and provides copy-on-write image on top of it.\n+optional",
+		"overlay":               "Overlay is a persistent copy-on-write image on a PVC, backed by a shared read-only base image on another PVC.\n+optional",
 		"emptyDisk":             "EmptyDisk represents a temporary disk which shares the vmis lifecycle.\nMore info: https://kubevirt.gitbooks.io/user-guide/disks-and-volumes.html\n+optional",
 		"dataVolume":            "DataVolume represents the dynamic creation a PVC for this volume as well as\nthe process of populating that PVC with a disk image.\n+optional",
 		"configMap":             "ConfigMapSource represents a reference to a ConfigMap in the same namespace.\nMore info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-pod-configmap/\n+optional",
@@ -425,6 +461,9 @@ func (VolumeSource) SwaggerDoc() map[string]string {
 		"serviceAccount":        "ServiceAccountVolumeSource represents a reference to a service account.\nThere can only be one volume of this type!\nMore info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/\n+optional",
 		"downwardMetrics":       "DownwardMetrics adds a very small disk to VMIs which contains a limited view of host and guest\nmetrics. The disk content is compatible with vhostmd (https://github.com/vhostmd/vhostmd) and vm-dump-metrics.",
 		"memoryDump":            "MemoryDump is attached to the virt launcher and is populated with a memory dump of the vmi",
+		"nbd":                   "NBD represents an export of a network block device server, which qemu connects to directly.\n+optional",
+		"iscsi":                 "ISCSI represents a LUN of an iSCSI target, which qemu connects to directly.\n+optional",
+		"rbd":                   "RBD represents an image of a Ceph cluster, which qemu connects to directly.\n+optional",
 	}
 }
 
@@ -433,6 +472,10 @@ func (HotplugVolumeSource) SwaggerDoc() map[string]string {
 		"":                      "HotplugVolumeSource Represents the source of a volume to mount which are capable\nof being hotplugged on a live running VMI.\nOnly one of its members may be specified.",
 		"persistentVolumeClaim": "PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.\nDirectly attached to the vmi via qemu.\nMore info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims\n+optional",
 		"dataVolume":            "DataVolume represents the dynamic creation a PVC for this volume as well as\nthe process of populating that PVC with a disk image.\n+optional",
+		"containerDisk":         "ContainerDisk references a docker image, embedding a qcow or raw disk.\nHotplugged container disks are attached read-only.\n+optional",
+		"configMap":             "ConfigMapSource represents a reference to a ConfigMap in the same namespace.\nHotplugged ConfigMaps are attached as read-only iso images.\n+optional",
+		"secret":                "SecretVolumeSource represents a reference to a secret data in the same namespace.\nHotplugged Secrets are attached as read-only iso images.\n+optional",
+		"serviceAccount":        "ServiceAccountVolumeSource represents a reference to a service account.\nHotplugged ServiceAccounts are attached as read-only iso images.\n+optional",
 	}
 }
 
@@ -460,6 +503,43 @@ func (EphemeralVolumeSource) SwaggerDoc() map[string]string {
 	}
 }
 
+func (OverlayVolumeSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "OverlayVolumeSource represents a persistent qcow2 overlay backed by a shared base image.\nThe overlay is created on first boot and keeps all writes of the vmi, the base image is never written.",
+		"claimName":     "ClaimName is the name of a filesystem PVC in the same namespace which stores the qcow2 overlay.",
+		"baseClaimName": "BaseClaimName is the name of a PVC in the same namespace which holds the raw base image.\nIt is attached read-only and can be shared by many vmis.",
+	}
+}
+
+func (NBDVolumeSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":    "NBDVolumeSource represents an export of a network block device server.",
+		"uri": "URI of the export, in the form nbd://host[:port][/export].\nThe port defaults to 10809.",
+	}
+}
+
+func (ISCSIVolumeSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "ISCSIVolumeSource represents a LUN of an iSCSI target.",
+		"targetPortal":  "TargetPortal is the address of the target, host or host:port.\nThe port defaults to 3260.",
+		"iqn":           "IQN is the iSCSI qualified name of the target.",
+		"lun":           "Lun is the number of the LUN on the target. Defaults to 0.\n+optional",
+		"initiatorName": "InitiatorName is the iSCSI qualified name of the initiator.\nDefaults to the initiator name of qemu.\n+optional",
+		"chapSecretRef": "CHAPSecretRef references a Secret in the namespace of the VMI which holds the CHAP credentials\nunder the \"node.session.auth.username\" and \"node.session.auth.password\" keys,\nas the Secrets of type kubernetes.io/iscsi-chap.\n+optional",
+	}
+}
+
+func (RBDVolumeSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "RBDVolumeSource represents an image of a Ceph cluster.",
+		"monitors":         "Monitors are the addresses of the Ceph monitors, host or host:port.\nThe port defaults to 6789.\n+listType=atomic",
+		"pool":             "Pool is the name of the pool holding the image. Defaults to rbd.\n+optional",
+		"image":            "Image is the name of the image.",
+		"user":             "User is the Ceph user to authenticate as. Defaults to admin.\n+optional",
+		"keyringSecretRef": "KeyringSecretRef references a Secret in the namespace of the VMI which holds the cephx key of the user\nunder the \"key\" key, as the Secrets of type kubernetes.io/rbd.\nWithout it, cephx authentication is disabled.\n+optional",
+	}
+}
+
 func (EmptyDiskSource) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":         "EmptyDisk represents a temporary disk which shares the vmis lifecycle.",
@@ -474,6 +554,7 @@ func (ContainerDiskSource) SwaggerDoc() map[string]string {
 		"imagePullSecret": "ImagePullSecret is the name of the Docker registry secret required to pull the image. The secret must already exist.",
 		"path":            "Path defines the path to disk file in the container",
 		"imagePullPolicy": "Image pull policy.\nOne of Always, Never, IfNotPresent.\nDefaults to Always if :latest tag is specified, or IfNotPresent otherwise.\nCannot be updated.\nMore info: https://kubernetes.io/docs/concepts/containers/images#updating-images\n+optional",
+		"hotpluggable":    "Hotpluggable indicates whether the volume can be hotplugged and hotunplugged.\n+optional",
 	}
 }
 
@@ -636,16 +717,17 @@ func (I6300ESBWatchdog) SwaggerDoc() map[string]string {
 
 func (Interface) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"name":        "Logical name of the interface as well as a reference to the associated networks.\nMust match the Name of a Network.",
-		"model":       "Interface model.\nOne of: e1000, e1000e, ne2k_pci, pcnet, rtl8139, virtio.\nDefaults to virtio.",
-		"ports":       "List of ports to be forwarded to the virtual machine.",
-		"macAddress":  "Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.",
-		"bootOrder":   "BootOrder is an integer value > 0, used to determine ordering of boot devices.\nLower values take precedence.\nEach interface or disk that has a boot order must have a unique value.\nInterfaces without a boot order are not tried.\n+optional",
-		"pciAddress":  "If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10\n+optional",
-		"dhcpOptions": "If specified the network interface will pass additional DHCP options to the VMI\n+optional",
-		"tag":         "If specified, the virtual network interface address and its tag will be provided to the guest via config drive\n+optional",
-		"acpiIndex":   "If specified, the ACPI index is used to provide network interface device naming, that is stable across changes\nin PCI addresses assigned to the device.\nThis value is required to be unique across all devices and be between 1 and (16*1024-1).\n+optional",
-		"state":       "State represents the requested operational state of the interface.\nThe (only) value supported is `absent`, expressing a request to remove the interface.\n+optional",
+		"name":                "Logical name of the interface as well as a reference to the associated networks.\nMust match the Name of a Network.",
+		"model":               "Interface model.\nOne of: e1000, e1000e, ne2k_pci, pcnet, rtl8139, virtio.\nDefaults to virtio.",
+		"ports":               "List of ports to be forwarded to the virtual machine.",
+		"macAddress":          "Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.",
+		"bootOrder":           "BootOrder is an integer value > 0, used to determine ordering of boot devices.\nLower values take precedence.\nEach interface or disk that has a boot order must have a unique value.\nInterfaces without a boot order are not tried.\n+optional",
+		"pciAddress":          "If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10\n+optional",
+		"dhcpOptions":         "If specified the network interface will pass additional DHCP options to the VMI\n+optional",
+		"routerAdvertisement": "If specified, enables and tunes the IPv6 router advertisements sent to the guest.\nRouter advertisements are sent on masquerade interfaces setting it, once the pod has IPv6 connectivity,\npasst interfaces accept the DNS servers only.\n+optional",
+		"tag":                 "If specified, the virtual network interface address and its tag will be provided to the guest via config drive\n+optional",
+		"acpiIndex":           "If specified, the ACPI index is used to provide network interface device naming, that is stable across changes\nin PCI addresses assigned to the device.\nThis value is required to be unique across all devices and be between 1 and (16*1024-1).\n+optional",
+		"state":               "State represents the requested operational state of the interface.\nThe (only) value supported is `absent`, expressing a request to remove the interface.\n+optional",
 	}
 }
 
@@ -659,6 +741,16 @@ func (DHCPOptions) SwaggerDoc() map[string]string {
 	}
 }
 
+func (RouterAdvertisementOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                            "RouterAdvertisementOptions defines the content of the IPv6 router advertisements sent to the guest.",
+		"prefix":                      "Prefix advertised as on-link, in CIDR notation.\nGuests autoconfigure an address (SLAAC) only from a /64 prefix.\nDefaults to the IPv6 CIDR of the masquerade network.\n+optional",
+		"dnsServers":                  "DNSServers are the IPv6 addresses advertised as recursive DNS servers (RDNSS).\n+optional\n+listType=atomic",
+		"managedAddressConfiguration": "ManagedAddressConfiguration sets the managed (M) flag, telling the guest to obtain its address through DHCPv6.\nDefaults to true.\n+optional",
+		"otherConfiguration":          "OtherConfiguration sets the other configuration (O) flag, telling the guest to obtain further configuration through DHCPv6.\n+optional",
+	}
+}
+
 func (DHCPPrivateOptions) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":       "DHCPExtraOptions defines Extra DHCP options for a VM.",
@@ -687,7 +779,34 @@ func (InterfaceSlirp) SwaggerDoc() map[string]string {
 
 func (InterfaceMasquerade) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "InterfaceMasquerade connects to a given network using netfilter rules to nat the traffic.",
+		"":         "InterfaceMasquerade connects to a given network using netfilter rules to nat the traffic.",
+		"firewall": "Firewall restricts the traffic reaching and leaving the virtual machine through the interface.\n+optional",
+	}
+}
+
+func (InterfaceFirewall) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "InterfaceFirewall holds the policies applied to the traffic of an interface.",
+		"ingress": "Ingress policy, applied to the traffic towards the virtual machine.\n+optional",
+		"egress":  "Egress policy, applied to the traffic originating from the virtual machine.\n+optional",
+	}
+}
+
+func (FirewallPolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "FirewallPolicy is an ordered list of rules, the first rule matching the traffic decides its fate.\nReplies to allowed connections are always accepted.",
+		"defaultAction": "DefaultAction is applied to the traffic no rule matched.\nDefaults to Allow.\n+optional",
+		"rules":         "+optional\n+listType=atomic",
+	}
+}
+
+func (FirewallRule) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "FirewallRule matches traffic by its remote peer, protocol and port.\nUnset fields match any traffic.",
+		"action":      "Action applied to the matching traffic.",
+		"remoteCIDRs": "RemoteCIDRs match the remote peer, the source of ingress traffic and the destination of egress traffic.\n+optional\n+listType=atomic",
+		"protocol":    "Protocol of the traffic. Must be TCP, UDP, SCTP or ICMP.\n+optional",
+		"ports":       "Ports or port ranges (e.g. 8000-9000) the traffic is destined to.\nOnly valid along with the TCP, UDP or SCTP protocols.\n+optional\n+listType=atomic",
 	}
 }
 
@@ -709,6 +828,12 @@ func (InterfacePasst) SwaggerDoc() map[string]string {
 	}
 }
 
+func (InterfaceVhostUser) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "InterfaceVhostUser connects to a given network through a vhost-user socket served by a userspace datapath, such as OVS-DPDK.",
+	}
+}
+
 func (Port) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":         "Port represents a port to expose from the virtual machine.\nDefault protocol TCP.\nThe port field is mandatory",
@@ -164,6 +164,30 @@ type VirtualMachineInstanceSpec struct {
 	AccessCredentials []AccessCredential `json:"accessCredentials,omitempty"`
 	// Specifies the architecture of the vm guest you are attempting to run. Defaults to the compiled architecture of the KubeVirt components
 	Architecture string `json:"architecture,omitempty"`
+	// FilesystemTrim periodically trims the guest filesystems through the qemu guest agent,
+	// which returns their unused blocks to thin-provisioned storage.
+	// +optional
+	FilesystemTrim *FilesystemTrim `json:"filesystemTrim,omitempty"`
+}
+
+// FilesystemTrim configures the periodic trim of the guest filesystems
+type FilesystemTrim struct {
+	// Interval between two trims of the guest filesystems, it must be at least 1h.
+	// Defaults to 24h.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// DiscardPreallocated passes the disks of preallocated or thick-provisioned volumes with discard=unmap as well,
+	// so that the trims reach their storage. By default these disks are passed with discard=ignore.
+	// +optional
+	DiscardPreallocated bool `json:"discardPreallocated,omitempty"`
+}
+
+// FilesystemTrimStatus reports the trims of the guest filesystems
+type FilesystemTrimStatus struct {
+	// LastTrimTime is the time of the last trim of the guest filesystems, periodic or on demand, whether it succeeded or not.
+	// The next periodic trim is scheduled from it.
+	// +optional
+	LastTrimTime *metav1.Time `json:"lastTrimTime,omitempty"`
 }
 
 func (vmiSpec *VirtualMachineInstanceSpec) UnmarshalJSON(data []byte) error {
@@ -280,6 +304,10 @@ type VirtualMachineInstanceStatus struct {
 	// Current topology may differ from the desired topology in the spec while CPU hotplug
 	// takes place.
 	CurrentCPUTopology *CPUTopology `json:"currentCPUTopology,omitempty"`
+
+	// FilesystemTrim reports the trims of the guest filesystems
+	// +optional
+	FilesystemTrim *FilesystemTrimStatus `json:"filesystemTrim,omitempty"`
 }
 
 // PersistentVolumeClaimInfo contains the relavant information virt-handler needs cached about a PVC
@@ -332,6 +360,21 @@ type VolumeStatus struct {
 	Size int64 `json:"size,omitempty"`
 	// If the volume is memorydump volume, this will contain the memorydump info.
 	MemoryDumpVolume *DomainMemoryDumpInfo `json:"memoryDumpVolume,omitempty"`
+	// GuestUsage is the usage of the guest filesystems located on the volume, as reported by the guest agent.
+	// +optional
+	GuestUsage *VolumeGuestUsage `json:"guestUsage,omitempty"`
+}
+
+// VolumeGuestUsage sums up the guest filesystems located on a volume
+type VolumeGuestUsage struct {
+	// UsedBytes is the amount of bytes used by the guest filesystems on the volume
+	UsedBytes int64 `json:"usedBytes"`
+	// TotalBytes is the capacity of the guest filesystems on the volume
+	TotalBytes int64 `json:"totalBytes"`
+	// MountPoints are the guest mount points of the filesystems on the volume
+	// +optional
+	// +listType=atomic
+	MountPoints []string `json:"mountPoints,omitempty"`
 }
 
 // DomainMemoryDumpInfo represents the memory dump information
@@ -352,6 +395,29 @@ type HotplugVolumeStatus struct {
 	AttachPodName string `json:"attachPodName,omitempty"`
 	// AttachPodUID is the UID of the pod used to attach the volume to the node.
 	AttachPodUID types.UID `json:"attachPodUID,omitempty"`
+	// CSIVolume is set when virt-handler stages the volume on the node directly
+	// through the CSI driver, instead of mounting it from an attachment pod.
+	// +optional
+	CSIVolume *HotplugCSIVolumeSource `json:"csiVolume,omitempty"`
+}
+
+// HotplugCSIVolumeSource holds what virt-handler needs to stage a hotplugged volume
+// through the CSI driver of the node.
+type HotplugCSIVolumeSource struct {
+	// Driver is the name of the CSI driver of the volume.
+	Driver string `json:"driver"`
+	// VolumeHandle identifies the volume to the CSI driver.
+	VolumeHandle string `json:"volumeHandle"`
+	// FSType is the filesystem of the volume, if it is not a block volume.
+	// +optional
+	FSType string `json:"fsType,omitempty"`
+	// MountOptions are the mount options of the persistent volume.
+	// +optional
+	// +listType=atomic
+	MountOptions []string `json:"mountOptions,omitempty"`
+	// VolumeAttributes are passed to the CSI driver as the volume context.
+	// +optional
+	VolumeAttributes map[string]string `json:"volumeAttributes,omitempty"`
 }
 
 // VolumePhase indicates the current phase of the hotplug process.
@@ -607,6 +673,17 @@ type VirtualMachineInstanceNetworkInterface struct {
 	InfoSource string `json:"infoSource,omitempty"`
 	// Specifies how many queues are allocated by MultiQueue
 	QueueCount int32 `json:"queueCount,omitempty"`
+	// Specifies the origin of each of the IP addresses, when they were learned without the guest agent.
+	// +optional
+	// +listType=atomic
+	IPSources []VirtualMachineInstanceNetworkInterfaceIPSource `json:"ipSources,omitempty"`
+}
+
+type VirtualMachineInstanceNetworkInterfaceIPSource struct {
+	// IP address of a Virtual Machine interface
+	IP string `json:"ip"`
+	// Specifies the origin of the IP address. values: pod-interface, dhcp-lease, neighbor-snooping.
+	Source string `json:"source"`
 }
 
 type VirtualMachineInstanceGuestOSInfo struct {
@@ -1554,6 +1631,18 @@ type VirtualMachineVolumeRequest struct {
 	// RemoveVolumeOptions when set indicates a volume should be removed. The details
 	// within this field specify how to add the volume
 	RemoveVolumeOptions *RemoveVolumeOptions `json:"removeVolumeOptions,omitempty" optional:"true"`
+	// InsertMediaOptions when set indicates a media should be inserted into an empty cdrom.
+	// The details within this field specify which media to insert
+	InsertMediaOptions *InsertMediaOptions `json:"insertMediaOptions,omitempty" optional:"true"`
+	// EjectMediaOptions when set indicates the media of a cdrom should be ejected.
+	// The details within this field specify which cdrom to eject
+	EjectMediaOptions *EjectMediaOptions `json:"ejectMediaOptions,omitempty" optional:"true"`
+	// AddFilesystemOptions when set indicates a virtiofs filesystem should be added.
+	// The details within this field specify how to add the filesystem
+	AddFilesystemOptions *AddFilesystemOptions `json:"addFilesystemOptions,omitempty" optional:"true"`
+	// RemoveFilesystemOptions when set indicates a virtiofs filesystem should be removed.
+	// The details within this field specify which filesystem to remove
+	RemoveFilesystemOptions *RemoveFilesystemOptions `json:"removeFilesystemOptions,omitempty" optional:"true"`
 }
 
 type VirtualMachineStateChangeRequest struct {
@@ -2175,6 +2264,31 @@ type FreezeUnfreezeTimeout struct {
 	UnfreezeTimeout *metav1.Duration `json:"unfreezeTimeout"`
 }
 
+// GuestExecMaxTimeoutSeconds is the longest a command executed in the guest through the
+// guestexec subresource may run, so that the request completes within the API server timeout
+const GuestExecMaxTimeoutSeconds = 50
+
+// GuestExecOptions is the command to execute in the guest through the qemu guest agent
+type GuestExecOptions struct {
+	// Command is the path of the executable in the guest
+	Command string `json:"command"`
+	// Args are passed to the command
+	// +optional
+	// +listType=atomic
+	Args []string `json:"args,omitempty"`
+	// TimeoutSeconds is how long to wait for the command to exit, at most GuestExecMaxTimeoutSeconds
+	TimeoutSeconds int32 `json:"timeoutSeconds"`
+}
+
+// GuestExecResult is the outcome of a command executed in the guest
+type GuestExecResult struct {
+	// ExitCode of the command
+	ExitCode int32 `json:"exitCode"`
+	// StdOut is the standard output of the command
+	// +optional
+	StdOut string `json:"stdOut,omitempty"`
+}
+
 // VirtualMachineMemoryDumpRequest represent the memory dump request phase and info
 type VirtualMachineMemoryDumpRequest struct {
 	// ClaimName is the name of the pvc that will contain the memory dump
@@ -2259,6 +2373,70 @@ type RemoveVolumeOptions struct {
 	DryRun []string `json:"dryRun,omitempty"`
 }
 
+// InsertMediaOptions is provided when inserting a media into an empty cdrom
+type InsertMediaOptions struct {
+	// Name represents the name of the cdrom disk the media is inserted into.
+	// The volume backing the media is given the same name.
+	Name string `json:"name"`
+	// VolumeSource represents the source of the media.
+	VolumeSource *HotplugVolumeSource `json:"volumeSource"`
+	// When present, indicates that modifications should not be
+	// persisted. An invalid or unrecognized dryRun directive will
+	// result in an error response and no further processing of the
+	// request. Valid values are:
+	// - All: all dry run stages will be processed
+	// +optional
+	// +listType=atomic
+	DryRun []string `json:"dryRun,omitempty"`
+}
+
+// EjectMediaOptions is provided when ejecting the media of a cdrom
+type EjectMediaOptions struct {
+	// Name represents the name of the cdrom disk whose media is ejected
+	Name string `json:"name"`
+	// When present, indicates that modifications should not be
+	// persisted. An invalid or unrecognized dryRun directive will
+	// result in an error response and no further processing of the
+	// request. Valid values are:
+	// - All: all dry run stages will be processed
+	// +optional
+	// +listType=atomic
+	DryRun []string `json:"dryRun,omitempty"`
+}
+
+// AddFilesystemOptions is provided when dynamically hot plugging a virtiofs filesystem
+type AddFilesystemOptions struct {
+	// Name represents the name that will be used to map the
+	// filesystem to the corresponding volume. It is also used as
+	// the mount tag inside the guest.
+	Name string `json:"name"`
+	// VolumeSource represents the source of the volume shared with the guest.
+	VolumeSource *HotplugVolumeSource `json:"volumeSource"`
+	// When present, indicates that modifications should not be
+	// persisted. An invalid or unrecognized dryRun directive will
+	// result in an error response and no further processing of the
+	// request. Valid values are:
+	// - All: all dry run stages will be processed
+	// +optional
+	// +listType=atomic
+	DryRun []string `json:"dryRun,omitempty"`
+}
+
+// RemoveFilesystemOptions is provided when dynamically hot unplugging a virtiofs filesystem
+type RemoveFilesystemOptions struct {
+	// Name represents the name that maps to both the filesystem and volume that
+	// should be removed
+	Name string `json:"name"`
+	// When present, indicates that modifications should not be
+	// persisted. An invalid or unrecognized dryRun directive will
+	// result in an error response and no further processing of the
+	// request. Valid values are:
+	// - All: all dry run stages will be processed
+	// +optional
+	// +listType=atomic
+	DryRun []string `json:"dryRun,omitempty"`
+}
+
 // AddInterfaceOptions is provided when dynamically hot plugging a network interface
 type AddInterfaceOptions struct {
 	// NetworkAttachmentDefinitionName references a NetworkAttachmentDefinition CRD object. Format:
@@ -2366,6 +2544,11 @@ type KubeVirtConfiguration struct {
 	AutoCPULimitNamespaceLabelSelector *metav1.LabelSelector `json:"autoCPULimitNamespaceLabelSelector,omitempty"`
 	// LiveUpdateConfiguration holds defaults for live update features
 	LiveUpdateConfiguration *LiveUpdateConfiguration `json:"liveUpdateConfiguration,omitempty"`
+
+	// ContainerDiskVerification, when set, requires containerDisk and kernel boot images
+	// to carry a valid cosign signature of one of the given public keys before a VMI using them is started.
+	// +optional
+	ContainerDiskVerification *ContainerDiskVerification `json:"containerDiskVerification,omitempty"`
 }
 
 type ArchConfiguration struct {
@@ -2763,6 +2946,14 @@ type LiveUpdateConfiguration struct {
 	MaxCpuSockets *uint32 `json:"maxCpuSockets,omitempty"`
 }
 
+// ContainerDiskVerification holds the policy for verifying the signatures of containerDisk images
+type ContainerDiskVerification struct {
+	// PublicKeys is a list of PEM encoded ECDSA, RSA or Ed25519 public keys.
+	// An image is accepted if it carries a cosign signature of any of them.
+	// +listType=atomic
+	PublicKeys []string `json:"publicKeys"`
+}
+
 // SEVPlatformInfo contains information about the AMD SEV features for the node.
 //
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
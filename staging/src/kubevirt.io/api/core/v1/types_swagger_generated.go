@@ -39,6 +39,22 @@ func (VirtualMachineInstanceSpec) SwaggerDoc() map[string]string {
 		"dnsConfig":                     "Specifies the DNS parameters of a pod.\nParameters specified here will be merged to the generated DNS\nconfiguration based on DNSPolicy.\n+optional",
 		"accessCredentials":             "Specifies a set of public keys to inject into the vm guest\n+listType=atomic\n+optional",
 		"architecture":                  "Specifies the architecture of the vm guest you are attempting to run. Defaults to the compiled architecture of the KubeVirt components",
+		"filesystemTrim":                "FilesystemTrim periodically trims the guest filesystems through the qemu guest agent,\nwhich returns their unused blocks to thin-provisioned storage.\n+optional",
+	}
+}
+
+func (FilesystemTrim) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                    "FilesystemTrim configures the periodic trim of the guest filesystems",
+		"interval":            "Interval between two trims of the guest filesystems, it must be at least 1h.\nDefaults to 24h.\n+optional",
+		"discardPreallocated": "DiscardPreallocated passes the disks of preallocated or thick-provisioned volumes with discard=unmap as well,\nso that the trims reach their storage. By default these disks are passed with discard=ignore.\n+optional",
+	}
+}
+
+func (FilesystemTrimStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "FilesystemTrimStatus reports the trims of the guest filesystems",
+		"lastTrimTime": "LastTrimTime is the time of the last trim of the guest filesystems, periodic or on demand, whether it succeeded or not.\nThe next periodic trim is scheduled from it.\n+optional",
 	}
 }
 
@@ -80,6 +96,7 @@ func (VirtualMachineInstanceStatus) SwaggerDoc() map[string]string {
 		"selinuxContext":                "SELinuxContext is the actual SELinux context of the virt-launcher pod\n+optional",
 		"machine":                       "Machine shows the final resulting qemu machine type. This can be different\nthan the machine type selected in the spec, due to qemus machine type alias mechanism.\n+optional",
 		"currentCPUTopology":            "CurrentCPUTopology specifies the current CPU topology used by the VM workload.\nCurrent topology may differ from the desired topology in the spec while CPU hotplug\ntakes place.",
+		"filesystemTrim":                "FilesystemTrim reports the trims of the guest filesystems\n+optional",
 	}
 }
 
@@ -107,6 +124,16 @@ func (VolumeStatus) SwaggerDoc() map[string]string {
 		"hotplugVolume":             "If the volume is hotplug, this will contain the hotplug status.",
 		"size":                      "Represents the size of the volume",
 		"memoryDumpVolume":          "If the volume is memorydump volume, this will contain the memorydump info.",
+		"guestUsage":                "GuestUsage is the usage of the guest filesystems located on the volume, as reported by the guest agent.\n+optional",
+	}
+}
+
+func (VolumeGuestUsage) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "VolumeGuestUsage sums up the guest filesystems located on a volume",
+		"usedBytes":   "UsedBytes is the amount of bytes used by the guest filesystems on the volume",
+		"totalBytes":  "TotalBytes is the capacity of the guest filesystems on the volume",
+		"mountPoints": "MountPoints are the guest mount points of the filesystems on the volume\n+optional\n+listType=atomic",
 	}
 }
 
@@ -125,6 +152,18 @@ func (HotplugVolumeStatus) SwaggerDoc() map[string]string {
 		"":              "HotplugVolumeStatus represents the hotplug status of the volume",
 		"attachPodName": "AttachPodName is the name of the pod used to attach the volume to the node.",
 		"attachPodUID":  "AttachPodUID is the UID of the pod used to attach the volume to the node.",
+		"csiVolume":     "CSIVolume is set when virt-handler stages the volume on the node directly\nthrough the CSI driver, instead of mounting it from an attachment pod.\n+optional",
+	}
+}
+
+func (HotplugCSIVolumeSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "HotplugCSIVolumeSource holds what virt-handler needs to stage a hotplugged volume\nthrough the CSI driver of the node.",
+		"driver":           "Driver is the name of the CSI driver of the volume.",
+		"volumeHandle":     "VolumeHandle identifies the volume to the CSI driver.",
+		"fsType":           "FSType is the filesystem of the volume, if it is not a block volume.\n+optional",
+		"mountOptions":     "MountOptions are the mount options of the persistent volume.\n+optional\n+listType=atomic",
+		"volumeAttributes": "VolumeAttributes are passed to the CSI driver as the volume context.\n+optional",
 	}
 }
 
@@ -151,6 +190,14 @@ func (VirtualMachineInstanceNetworkInterface) SwaggerDoc() map[string]string {
 		"interfaceName": "The interface name inside the Virtual Machine",
 		"infoSource":    "Specifies the origin of the interface data collected. values: domain, guest-agent, multus-status.",
 		"queueCount":    "Specifies how many queues are allocated by MultiQueue",
+		"ipSources":     "Specifies the origin of each of the IP addresses, when they were learned without the guest agent.\n+optional\n+listType=atomic",
+	}
+}
+
+func (VirtualMachineInstanceNetworkInterfaceIPSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"ip":     "IP address of a Virtual Machine interface",
+		"source": "Specifies the origin of the IP address. values: pod-interface, dhcp-lease, neighbor-snooping.",
 	}
 }
 
@@ -372,8 +419,12 @@ func (VolumeSnapshotStatus) SwaggerDoc() map[string]string {
 
 func (VirtualMachineVolumeRequest) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"addVolumeOptions":    "AddVolumeOptions when set indicates a volume should be added. The details\nwithin this field specify how to add the volume",
-		"removeVolumeOptions": "RemoveVolumeOptions when set indicates a volume should be removed. The details\nwithin this field specify how to add the volume",
+		"addVolumeOptions":        "AddVolumeOptions when set indicates a volume should be added. The details\nwithin this field specify how to add the volume",
+		"removeVolumeOptions":     "RemoveVolumeOptions when set indicates a volume should be removed. The details\nwithin this field specify how to add the volume",
+		"insertMediaOptions":      "InsertMediaOptions when set indicates a media should be inserted into an empty cdrom.\nThe details within this field specify which media to insert",
+		"ejectMediaOptions":       "EjectMediaOptions when set indicates the media of a cdrom should be ejected.\nThe details within this field specify which cdrom to eject",
+		"addFilesystemOptions":    "AddFilesystemOptions when set indicates a virtiofs filesystem should be added.\nThe details within this field specify how to add the filesystem",
+		"removeFilesystemOptions": "RemoveFilesystemOptions when set indicates a virtiofs filesystem should be removed.\nThe details within this field specify which filesystem to remove",
 	}
 }
 
@@ -632,6 +683,23 @@ func (FreezeUnfreezeTimeout) SwaggerDoc() map[string]string {
 	}
 }
 
+func (GuestExecOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "GuestExecOptions is the command to execute in the guest through the qemu guest agent",
+		"command":        "Command is the path of the executable in the guest",
+		"args":           "Args are passed to the command\n+optional\n+listType=atomic",
+		"timeoutSeconds": "TimeoutSeconds is how long to wait for the command to exit, at most GuestExecMaxTimeoutSeconds",
+	}
+}
+
+func (GuestExecResult) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "GuestExecResult is the outcome of a command executed in the guest",
+		"exitCode": "ExitCode of the command",
+		"stdOut":   "StdOut is the standard output of the command\n+optional",
+	}
+}
+
 func (VirtualMachineMemoryDumpRequest) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":               "VirtualMachineMemoryDumpRequest represent the memory dump request phase and info",
@@ -671,6 +739,40 @@ func (RemoveVolumeOptions) SwaggerDoc() map[string]string {
 	}
 }
 
+func (InsertMediaOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "InsertMediaOptions is provided when inserting a media into an empty cdrom",
+		"name":         "Name represents the name of the cdrom disk the media is inserted into.\nThe volume backing the media is given the same name.",
+		"volumeSource": "VolumeSource represents the source of the media.",
+		"dryRun":       "When present, indicates that modifications should not be\npersisted. An invalid or unrecognized dryRun directive will\nresult in an error response and no further processing of the\nrequest. Valid values are:\n- All: all dry run stages will be processed\n+optional\n+listType=atomic",
+	}
+}
+
+func (EjectMediaOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "EjectMediaOptions is provided when ejecting the media of a cdrom",
+		"name":   "Name represents the name of the cdrom disk whose media is ejected",
+		"dryRun": "When present, indicates that modifications should not be\npersisted. An invalid or unrecognized dryRun directive will\nresult in an error response and no further processing of the\nrequest. Valid values are:\n- All: all dry run stages will be processed\n+optional\n+listType=atomic",
+	}
+}
+
+func (AddFilesystemOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "AddFilesystemOptions is provided when dynamically hot plugging a virtiofs filesystem",
+		"name":         "Name represents the name that will be used to map the\nfilesystem to the corresponding volume. It is also used as\nthe mount tag inside the guest.",
+		"volumeSource": "VolumeSource represents the source of the volume shared with the guest.",
+		"dryRun":       "When present, indicates that modifications should not be\npersisted. An invalid or unrecognized dryRun directive will\nresult in an error response and no further processing of the\nrequest. Valid values are:\n- All: all dry run stages will be processed\n+optional\n+listType=atomic",
+	}
+}
+
+func (RemoveFilesystemOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "RemoveFilesystemOptions is provided when dynamically hot unplugging a virtiofs filesystem",
+		"name":   "Name represents the name that maps to both the filesystem and volume that\nshould be removed",
+		"dryRun": "When present, indicates that modifications should not be\npersisted. An invalid or unrecognized dryRun directive will\nresult in an error response and no further processing of the\nrequest. Valid values are:\n- All: all dry run stages will be processed\n+optional\n+listType=atomic",
+	}
+}
+
 func (AddInterfaceOptions) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                                "AddInterfaceOptions is provided when dynamically hot plugging a network interface",
@@ -722,6 +824,7 @@ func (KubeVirtConfiguration) SwaggerDoc() map[string]string {
 		"ksmConfiguration":                   "KSMConfiguration holds the information regarding the enabling the KSM in the nodes (if available).",
 		"autoCPULimitNamespaceLabelSelector": "When set, AutoCPULimitNamespaceLabelSelector will set a CPU limit on virt-launcher for VMIs running inside\nnamespaces that match the label selector.\nThe CPU limit will equal the number of requested vCPUs.\nThis setting does not apply to VMIs with dedicated CPUs.",
 		"liveUpdateConfiguration":            "LiveUpdateConfiguration holds defaults for live update features",
+		"containerDiskVerification":          "ContainerDiskVerification, when set, requires containerDisk and kernel boot images\nto carry a valid cosign signature of one of the given public keys before a VMI using them is started.\n+optional",
 	}
 }
 
@@ -936,6 +1039,13 @@ func (LiveUpdateConfiguration) SwaggerDoc() map[string]string {
 	}
 }
 
+func (ContainerDiskVerification) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "ContainerDiskVerification holds the policy for verifying the signatures of containerDisk images",
+		"publicKeys": "PublicKeys is a list of PEM encoded ECDSA, RSA or Ed25519 public keys.\nAn image is accepted if it carries a cosign signature of any of them.\n+listType=atomic",
+	}
+}
+
 func (SEVPlatformInfo) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":          "SEVPlatformInfo contains information about the AMD SEV features for the node.\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
@@ -44,6 +44,22 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3ExportDestination) DeepCopyInto(out *S3ExportDestination) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3ExportDestination.
+func (in *S3ExportDestination) DeepCopy() *S3ExportDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(S3ExportDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineExport) DeepCopyInto(out *VirtualMachineExport) {
 	*out = *in
@@ -76,6 +92,27 @@ func (in *VirtualMachineExport) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineExportDestination) DeepCopyInto(out *VirtualMachineExportDestination) {
+	*out = *in
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3ExportDestination)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineExportDestination.
+func (in *VirtualMachineExportDestination) DeepCopy() *VirtualMachineExportDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineExportDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineExportLink) DeepCopyInto(out *VirtualMachineExportLink) {
 	*out = *in
@@ -179,6 +216,56 @@ func (in *VirtualMachineExportManifest) DeepCopy() *VirtualMachineExportManifest
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineExportPushStatus) DeepCopyInto(out *VirtualMachineExportPushStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]VirtualMachineExportPushedObject, len(*in))
+		copy(*out, *in)
+	}
+	if in.Manifests != nil {
+		in, out := &in.Manifests, &out.Manifests
+		*out = make([]VirtualMachineExportPushedObject, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineExportPushStatus.
+func (in *VirtualMachineExportPushStatus) DeepCopy() *VirtualMachineExportPushStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineExportPushStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineExportPushedObject) DeepCopyInto(out *VirtualMachineExportPushedObject) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineExportPushedObject.
+func (in *VirtualMachineExportPushedObject) DeepCopy() *VirtualMachineExportPushedObject {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineExportPushedObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineExportSpec) DeepCopyInto(out *VirtualMachineExportSpec) {
 	*out = *in
@@ -193,6 +280,11 @@ func (in *VirtualMachineExportSpec) DeepCopyInto(out *VirtualMachineExportSpec)
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Destination != nil {
+		in, out := &in.Destination, &out.Destination
+		*out = new(VirtualMachineExportDestination)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -235,6 +327,11 @@ func (in *VirtualMachineExportStatus) DeepCopyInto(out *VirtualMachineExportStat
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Push != nil {
+		in, out := &in.Push, &out.Push
+		*out = new(VirtualMachineExportPushStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -67,6 +67,48 @@ type VirtualMachineExportSpec struct {
 	// If this field is omitted, a reasonable default is applied.
 	// +optional
 	TTLDuration *metav1.Duration `json:"ttlDuration,omitempty"`
+
+	// Destination, if set, makes the export server push the exported volumes and manifests
+	// to the given location, in addition to serving them for download
+	// +optional
+	Destination *VirtualMachineExportDestination `json:"destination,omitempty"`
+}
+
+// VirtualMachineExportDestination defines where the export is pushed to
+type VirtualMachineExportDestination struct {
+	// S3 pushes the export to a bucket of an S3 compatible object storage
+	// +optional
+	S3 *S3ExportDestination `json:"s3,omitempty"`
+
+	// Format is the format the volumes are pushed in, either raw or gzip. Volumes which
+	// do not contain a disk image are always pushed as tar.gz archive. Defaults to gzip
+	// +optional
+	Format ExportVolumeFormat `json:"format,omitempty"`
+}
+
+// S3ExportDestination defines a bucket of an S3 compatible object storage
+type S3ExportDestination struct {
+	// Endpoint is the URL of the S3 API, e.g. https://s3.us-east-1.amazonaws.com
+	Endpoint string `json:"endpoint"`
+
+	// Bucket is the name of the bucket the objects are stored in
+	Bucket string `json:"bucket"`
+
+	// Prefix is prepended to the keys of all pushed objects. Defaults to <namespace>/<export name>
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Region is the region used to sign the requests. Defaults to us-east-1
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// CredentialsSecretRef is the name of the secret holding the accessKeyId and the secretKey
+	// used to access the bucket, the same keys as used by CDI for S3 sources
+	CredentialsSecretRef string `json:"credentialsSecretRef"`
+
+	// InsecureSkipTLSVerify disables the verification of the certificate of the endpoint
+	// +optional
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
 }
 
 // VirtualMachineExportPhase is the current phase of the VirtualMachineExport
@@ -113,6 +155,78 @@ type VirtualMachineExportStatus struct {
 	// +optional
 	// +listType=atomic
 	Conditions []Condition `json:"conditions,omitempty"`
+
+	// +optional
+	// Push shows the progress of pushing the export to its destination
+	Push *VirtualMachineExportPushStatus `json:"push,omitempty"`
+}
+
+// VirtualMachineExportPushPhase is the phase of pushing the export to its destination
+type VirtualMachineExportPushPhase string
+
+const (
+	// PushPending means the push has not started yet
+	PushPending VirtualMachineExportPushPhase = "Pending"
+	// PushInProgress means the objects are being pushed
+	PushInProgress VirtualMachineExportPushPhase = "InProgress"
+	// PushSucceeded means all objects were pushed
+	PushSucceeded VirtualMachineExportPushPhase = "Succeeded"
+	// PushFailed means pushing at least one of the objects failed
+	PushFailed VirtualMachineExportPushPhase = "Failed"
+)
+
+// VirtualMachineExportPushStatus shows the progress of pushing the export to its destination
+type VirtualMachineExportPushStatus struct {
+	// +optional
+	Phase VirtualMachineExportPushPhase `json:"phase,omitempty"`
+
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Volumes is the list of pushed volumes
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Volumes []VirtualMachineExportPushedObject `json:"volumes,omitempty"`
+
+	// Manifests is the list of pushed manifests
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Manifests []VirtualMachineExportPushedObject `json:"manifests,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// VirtualMachineExportPushedObject shows the progress of pushing a single object
+type VirtualMachineExportPushedObject struct {
+	// Name is the name of the volume or manifest
+	Name string `json:"name"`
+
+	// +optional
+	Format ExportVolumeFormat `json:"format,omitempty"`
+
+	// URL is the url of the object in the destination
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// +optional
+	Phase VirtualMachineExportPushPhase `json:"phase,omitempty"`
+
+	// BytesPushed is the number of bytes pushed so far
+	// +optional
+	BytesPushed int64 `json:"bytesPushed,omitempty"`
+
+	// Sha256 is the hex encoded sha256 checksum of the object, set once it is pushed
+	// +optional
+	Sha256 string `json:"sha256,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // VirtualMachineExportLinks contains the links that point the exported VM resources
@@ -188,6 +302,11 @@ type VirtualMachineExportVolumeFormat struct {
 	Format ExportVolumeFormat `json:"format"`
 	// Url is the url that contains the volume in the format specified
 	Url string `json:"url"`
+	// Sha256 is the hex encoded sha256 checksum of the volume in the format
+	// specified, once calculated by the export server. It is only available
+	// for the raw format.
+	// +optional
+	Sha256 string `json:"sha256,omitempty"`
 }
 
 // ConditionType is the const type for Conditions
@@ -200,6 +319,8 @@ const (
 	ConditionPVC ConditionType = "PVCReady"
 	// ConditionVolumesCreated is the condition to see if volumes are created from volume snapshots
 	ConditionVolumesCreated ConditionType = "VolumesCreated"
+	// ConditionPushed is the condition to see if the export was pushed to its destination
+	ConditionPushed ConditionType = "Pushed"
 )
 
 // Condition defines conditions
@@ -21,6 +21,27 @@ func (VirtualMachineExportSpec) SwaggerDoc() map[string]string {
 		"":               "VirtualMachineExportSpec is the spec for a VirtualMachineExport resource",
 		"tokenSecretRef": "+optional\nTokenSecretRef is the name of the custom-defined secret that contains the token used by the export server pod",
 		"ttlDuration":    "ttlDuration limits the lifetime of an export\nIf this field is set, after this duration has passed from counting from CreationTimestamp,\nthe export is eligible to be automatically deleted.\nIf this field is omitted, a reasonable default is applied.\n+optional",
+		"destination":    "Destination, if set, makes the export server push the exported volumes and manifests\nto the given location, in addition to serving them for download\n+optional",
+	}
+}
+
+func (VirtualMachineExportDestination) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "VirtualMachineExportDestination defines where the export is pushed to",
+		"s3":     "S3 pushes the export to a bucket of an S3 compatible object storage\n+optional",
+		"format": "Format is the format the volumes are pushed in, either raw or gzip. Volumes which\ndo not contain a disk image are always pushed as tar.gz archive. Defaults to gzip\n+optional",
+	}
+}
+
+func (S3ExportDestination) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                      "S3ExportDestination defines a bucket of an S3 compatible object storage",
+		"endpoint":              "Endpoint is the URL of the S3 API, e.g. https://s3.us-east-1.amazonaws.com",
+		"bucket":                "Bucket is the name of the bucket the objects are stored in",
+		"prefix":                "Prefix is prepended to the keys of all pushed objects. Defaults to <namespace>/<export name>\n+optional",
+		"region":                "Region is the region used to sign the requests. Defaults to us-east-1\n+optional",
+		"credentialsSecretRef":  "CredentialsSecretRef is the name of the secret holding the accessKeyId and the secretKey\nused to access the bucket, the same keys as used by CDI for S3 sources",
+		"insecureSkipTLSVerify": "InsecureSkipTLSVerify disables the verification of the certificate of the endpoint\n+optional",
 	}
 }
 
@@ -34,6 +55,32 @@ func (VirtualMachineExportStatus) SwaggerDoc() map[string]string {
 		"serviceName":        "+optional\nServiceName is the name of the service created associated with the Virtual Machine export. It will be used to\ncreate the internal URLs for downloading the images",
 		"virtualMachineName": "+optional\nVirtualMachineName shows the name of the source virtual machine if the source is either a VirtualMachine or\na VirtualMachineSnapshot. This is mainly to easily identify the source VirtualMachine in case of a\nVirtualMachineSnapshot",
 		"conditions":         "+optional\n+listType=atomic",
+		"push":               "+optional\nPush shows the progress of pushing the export to its destination",
+	}
+}
+
+func (VirtualMachineExportPushStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "VirtualMachineExportPushStatus shows the progress of pushing the export to its destination",
+		"phase":          "+optional",
+		"startTime":      "+optional",
+		"completionTime": "+optional",
+		"volumes":        "Volumes is the list of pushed volumes\n+listType=map\n+listMapKey=name\n+optional",
+		"manifests":      "Manifests is the list of pushed manifests\n+listType=map\n+listMapKey=name\n+optional",
+		"message":        "+optional",
+	}
+}
+
+func (VirtualMachineExportPushedObject) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "VirtualMachineExportPushedObject shows the progress of pushing a single object",
+		"name":        "Name is the name of the volume or manifest",
+		"format":      "+optional",
+		"url":         "URL is the url of the object in the destination\n+optional",
+		"phase":       "+optional",
+		"bytesPushed": "BytesPushed is the number of bytes pushed so far\n+optional",
+		"sha256":      "Sha256 is the hex encoded sha256 checksum of the object, set once it is pushed\n+optional",
+		"message":     "+optional",
 	}
 }
 
@@ -75,6 +122,7 @@ func (VirtualMachineExportVolumeFormat) SwaggerDoc() map[string]string {
 		"":       "VirtualMachineExportVolumeFormat contains the format type and URL to get the volume in that format",
 		"format": "Format is the format of the image at the specified URL",
 		"url":    "Url is the url that contains the volume in the format specified",
+		"sha256": "Sha256 is the hex encoded sha256 checksum of the volume in the format\nspecified, once calculated by the export server. It is only available\nfor the raw format.\n+optional",
 	}
 }
 
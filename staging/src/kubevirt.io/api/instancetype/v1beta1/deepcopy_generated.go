@@ -211,7 +211,7 @@ func (in *DevicePreferences) DeepCopyInto(out *DevicePreferences) {
 	if in.PreferredInterfaceMasquerade != nil {
 		in, out := &in.PreferredInterfaceMasquerade, &out.PreferredInterfaceMasquerade
 		*out = new(v1.InterfaceMasquerade)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
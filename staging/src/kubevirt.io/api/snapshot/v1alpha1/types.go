@@ -71,6 +71,97 @@ type VirtualMachineSnapshotSpec struct {
 	// Defaults to DefaultFailureDeadline - 5min
 	// +optional
 	FailureDeadline *metav1.Duration `json:"failureDeadline,omitempty"`
+
+	// Hooks are commands executed in the guest through the guest agent
+	// before its filesystems are frozen and after they are thawed
+	// +optional
+	Hooks *SnapshotHooks `json:"hooks,omitempty"`
+
+	// IncludedVolumes limits the snapshot to the listed volumes of the source.
+	// All the volumes of the source are snapshotted when empty.
+	// +optional
+	// +listType=set
+	IncludedVolumes []string `json:"includedVolumes,omitempty"`
+
+	// ExcludedVolumes are volumes of the source which are not snapshotted
+	// +optional
+	// +listType=set
+	ExcludedVolumes []string `json:"excludedVolumes,omitempty"`
+}
+
+// SnapshotHooks are the commands executed in the guest during an online snapshot
+type SnapshotHooks struct {
+	// PreFreeze hooks are executed in order before the guest filesystems are frozen
+	// +optional
+	// +listType=atomic
+	PreFreeze []SnapshotHook `json:"preFreeze,omitempty"`
+
+	// PostThaw hooks are executed in order after the guest filesystems are thawed
+	// +optional
+	// +listType=atomic
+	PostThaw []SnapshotHook `json:"postThaw,omitempty"`
+}
+
+// SnapshotHook is a command executed in the guest
+type SnapshotHook struct {
+	// Name of the hook, unique among the hooks of the same type
+	Name string `json:"name"`
+
+	// Command is the path of the executable in the guest followed by its arguments
+	// +listType=atomic
+	Command []string `json:"command"`
+
+	// TimeoutSeconds is how long to wait for the command to exit.
+	// Defaults to 30, at most 50.
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// FailurePolicy defines what happens when the command fails, exits
+	// with a non-zero code or times out.
+	// Defaults to Abort.
+	// +optional
+	FailurePolicy *SnapshotHookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// SnapshotHookFailurePolicy defines what happens when a hook fails
+type SnapshotHookFailurePolicy string
+
+const (
+	// SnapshotHookFailurePolicyAbort fails the snapshot when the hook fails
+	SnapshotHookFailurePolicyAbort SnapshotHookFailurePolicy = "Abort"
+	// SnapshotHookFailurePolicyContinue continues the snapshot when the hook fails
+	SnapshotHookFailurePolicyContinue SnapshotHookFailurePolicy = "Continue"
+)
+
+// SnapshotHookType is the point of the snapshot a hook is executed at
+type SnapshotHookType string
+
+const (
+	PreFreezeHook SnapshotHookType = "PreFreeze"
+	PostThawHook  SnapshotHookType = "PostThaw"
+)
+
+// SnapshotHookResult is the outcome of a hook
+type SnapshotHookResult struct {
+	Name string `json:"name"`
+
+	Type SnapshotHookType `json:"type"`
+
+	Succeeded bool `json:"succeeded"`
+
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// Output is the standard output of the command, truncated to 4KiB
+	// +optional
+	Output string `json:"output,omitempty"`
+
+	// +optional
+	Error *string `json:"error,omitempty"`
+
+	// +optional
+	// +nullable
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 }
 
 // Indication is a way to indicate the state of the vm when taking the snapshot
@@ -124,6 +215,10 @@ type VirtualMachineSnapshotStatus struct {
 
 	// +optional
 	SnapshotVolumes *SnapshotVolumesLists `json:"snapshotVolumes,omitempty"`
+
+	// +optional
+	// +listType=atomic
+	HookResults []SnapshotHookResult `json:"hookResults,omitempty"`
 }
 
 // SnapshotVolumesLists includes the list of volumes which were included in the snapshot and volumes which were excluded from the snapshot
@@ -321,6 +416,19 @@ type VirtualMachineRestoreSpec struct {
 	// +optional
 	// +listType=atomic
 	Patches []string `json:"patches,omitempty"`
+
+	// IncludedVolumes limits the restore to the listed volumes of the snapshot,
+	// the other volumes of the target are left untouched.
+	// All the volumes of the snapshot are restored when empty.
+	// +optional
+	// +listType=set
+	IncludedVolumes []string `json:"includedVolumes,omitempty"`
+
+	// ExcludedVolumes are volumes of the snapshot which are not restored,
+	// the target keeps its current volumes instead
+	// +optional
+	// +listType=set
+	ExcludedVolumes []string `json:"excludedVolumes,omitempty"`
 }
 
 // VirtualMachineRestoreStatus is the spec for a VirtualMachineRestoreresource
@@ -361,3 +469,208 @@ type VirtualMachineRestoreList struct {
 
 	Items []VirtualMachineRestore `json:"items"`
 }
+
+// VirtualMachineSnapshotGroup defines the operation of snapshotting several VMs at the same point in time
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineSnapshotGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineSnapshotGroupSpec `json:"spec"`
+
+	// +optional
+	Status *VirtualMachineSnapshotGroupStatus `json:"status,omitempty"`
+}
+
+// VirtualMachineSnapshotGroupSpec is the spec for a VirtualMachineSnapshotGroup resource
+type VirtualMachineSnapshotGroupSpec struct {
+	// Selector selects the VirtualMachines in the namespace of the group.
+	// The selected VMs are frozen together and all their volumes are
+	// snapshotted before any of them is thawed again.
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// +optional
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// This time represents the number of seconds we permit freezing
+	// all VMs of the group and taking all their snapshots. The deadline
+	// is shared by the VirtualMachineSnapshots of the group, in case we
+	// pass it we mark the group as failed.
+	// Defaults to DefaultFailureDeadline - 5min
+	// +optional
+	FailureDeadline *metav1.Duration `json:"failureDeadline,omitempty"`
+}
+
+// VirtualMachineSnapshotGroupStatus is the status for a VirtualMachineSnapshotGroup resource
+type VirtualMachineSnapshotGroupStatus struct {
+	// +optional
+	// +nullable
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+
+	// +optional
+	Phase VirtualMachineSnapshotPhase `json:"phase,omitempty"`
+
+	// +optional
+	ReadyToUse *bool `json:"readyToUse,omitempty"`
+
+	// +optional
+	Error *Error `json:"error,omitempty"`
+
+	// +optional
+	// +listType=atomic
+	Conditions []Condition `json:"conditions,omitempty"`
+
+	// VirtualMachineSnapshots lists the snapshot of every VM selected when the group was created
+	// +optional
+	// +listType=atomic
+	VirtualMachineSnapshots []VirtualMachineSnapshotGroupMember `json:"virtualMachineSnapshots,omitempty"`
+}
+
+// VirtualMachineSnapshotGroupMember is the snapshot of a single VM of a VirtualMachineSnapshotGroup
+type VirtualMachineSnapshotGroupMember struct {
+	VirtualMachineName string `json:"virtualMachineName"`
+
+	VirtualMachineSnapshotName string `json:"virtualMachineSnapshotName"`
+}
+
+// VirtualMachineSnapshotGroupList is a list of VirtualMachineSnapshotGroup resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineSnapshotGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachineSnapshotGroup `json:"items"`
+}
+
+// VirtualMachineRestoreGroup defines the operation of restoring all VMs of a VirtualMachineSnapshotGroup
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineRestoreGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineRestoreGroupSpec `json:"spec"`
+
+	// +optional
+	Status *VirtualMachineRestoreGroupStatus `json:"status,omitempty"`
+}
+
+// VirtualMachineRestoreGroupSpec is the spec for a VirtualMachineRestoreGroup resource
+type VirtualMachineRestoreGroupSpec struct {
+	// The VMs of the group are restored to the VMs they were taken from,
+	// the restore only starts once none of them is running.
+	VirtualMachineSnapshotGroupName string `json:"virtualMachineSnapshotGroupName"`
+}
+
+// VirtualMachineRestoreGroupStatus is the status for a VirtualMachineRestoreGroup resource
+type VirtualMachineRestoreGroupStatus struct {
+	// +optional
+	RestoreTime *metav1.Time `json:"restoreTime,omitempty"`
+
+	// +optional
+	Complete *bool `json:"complete,omitempty"`
+
+	// +optional
+	// +listType=atomic
+	Conditions []Condition `json:"conditions,omitempty"`
+
+	// VirtualMachineRestores lists the restore of every VM of the group
+	// +optional
+	// +listType=atomic
+	VirtualMachineRestores []VirtualMachineRestoreGroupMember `json:"virtualMachineRestores,omitempty"`
+}
+
+// VirtualMachineRestoreGroupMember is the restore of a single VM of a VirtualMachineRestoreGroup
+type VirtualMachineRestoreGroupMember struct {
+	VirtualMachineName string `json:"virtualMachineName"`
+
+	VirtualMachineRestoreName string `json:"virtualMachineRestoreName"`
+}
+
+// VirtualMachineRestoreGroupList is a list of VirtualMachineRestoreGroup resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineRestoreGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachineRestoreGroup `json:"items"`
+}
+
+// DefaultMountDurationTTL is the default lifetime of a VirtualMachineSnapshotMount
+const DefaultMountDurationTTL = 2 * time.Hour
+
+// VirtualMachineSnapshotMount defines the operation of exposing a single volume
+// of a VirtualMachineSnapshot as a temporary PVC, optionally hotplugged read-only
+// into a running VM, to recover files from it
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineSnapshotMount struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineSnapshotMountSpec `json:"spec"`
+
+	// +optional
+	Status *VirtualMachineSnapshotMountStatus `json:"status,omitempty"`
+}
+
+// VirtualMachineSnapshotMountSpec is the spec for a VirtualMachineSnapshotMount resource
+type VirtualMachineSnapshotMountSpec struct {
+	VirtualMachineSnapshotName string `json:"virtualMachineSnapshotName"`
+
+	// VolumeName is the name of the snapshotted volume to expose
+	VolumeName string `json:"volumeName"`
+
+	// Target is the running VirtualMachine the volume is hotplugged into as a read-only disk.
+	// If omitted the volume is only exposed as a PVC, which can be inspected with virtctl guestfs.
+	// +optional
+	Target *corev1.TypedLocalObjectReference `json:"target,omitempty"`
+
+	// ttlDuration limits the lifetime of the mount
+	// After this duration has passed from counting from CreationTimestamp,
+	// the volume is unplugged and the mount is deleted together with its PVC.
+	// Defaults to DefaultMountDurationTTL - 2h
+	// +optional
+	TTLDuration *metav1.Duration `json:"ttlDuration,omitempty"`
+}
+
+// VirtualMachineSnapshotMountPhase is the current phase of the VirtualMachineSnapshotMount
+type VirtualMachineSnapshotMountPhase string
+
+const (
+	MountPending VirtualMachineSnapshotMountPhase = "Pending"
+	MountReady   VirtualMachineSnapshotMountPhase = "Ready"
+	MountFailed  VirtualMachineSnapshotMountPhase = "Failed"
+)
+
+// VirtualMachineSnapshotMountStatus is the status for a VirtualMachineSnapshotMount resource
+type VirtualMachineSnapshotMountStatus struct {
+	// +optional
+	Phase VirtualMachineSnapshotMountPhase `json:"phase,omitempty"`
+
+	// PersistentVolumeClaimName is the name of the temporary PVC restored from the volume snapshot
+	// +optional
+	PersistentVolumeClaimName *string `json:"persistentVolumeClaimName,omitempty"`
+
+	// The time at which the mount will be removed according to the specified TTL
+	// Formula is CreationTimestamp + TTL
+	// +optional
+	TTLExpirationTime *metav1.Time `json:"ttlExpirationTime,omitempty"`
+
+	// +optional
+	Error *Error `json:"error,omitempty"`
+
+	// +optional
+	// +listType=atomic
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// VirtualMachineSnapshotMountList is a list of VirtualMachineSnapshotMount resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineSnapshotMountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachineSnapshotMount `json:"items"`
+}
@@ -14,6 +14,37 @@ func (VirtualMachineSnapshotSpec) SwaggerDoc() map[string]string {
 		"":                "VirtualMachineSnapshotSpec is the spec for a VirtualMachineSnapshot resource",
 		"deletionPolicy":  "+optional",
 		"failureDeadline": "This time represents the number of seconds we permit the vm snapshot\nto take. In case we pass this deadline we mark this snapshot\nas failed.\nDefaults to DefaultFailureDeadline - 5min\n+optional",
+		"hooks":           "Hooks are commands executed in the guest through the guest agent\nbefore its filesystems are frozen and after they are thawed\n+optional",
+		"includedVolumes": "IncludedVolumes limits the snapshot to the listed volumes of the source.\nAll the volumes of the source are snapshotted when empty.\n+optional\n+listType=set",
+		"excludedVolumes": "ExcludedVolumes are volumes of the source which are not snapshotted\n+optional\n+listType=set",
+	}
+}
+
+func (SnapshotHooks) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "SnapshotHooks are the commands executed in the guest during an online snapshot",
+		"preFreeze": "PreFreeze hooks are executed in order before the guest filesystems are frozen\n+optional\n+listType=atomic",
+		"postThaw":  "PostThaw hooks are executed in order after the guest filesystems are thawed\n+optional\n+listType=atomic",
+	}
+}
+
+func (SnapshotHook) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "SnapshotHook is a command executed in the guest",
+		"name":           "Name of the hook, unique among the hooks of the same type",
+		"command":        "Command is the path of the executable in the guest followed by its arguments\n+listType=atomic",
+		"timeoutSeconds": "TimeoutSeconds is how long to wait for the command to exit.\nDefaults to 30, at most 50.\n+optional",
+		"failurePolicy":  "FailurePolicy defines what happens when the command fails, exits\nwith a non-zero code or times out.\nDefaults to Abort.\n+optional",
+	}
+}
+
+func (SnapshotHookResult) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "SnapshotHookResult is the outcome of a hook",
+		"exitCode":       "+optional",
+		"output":         "Output is the standard output of the command, truncated to 4KiB\n+optional",
+		"error":          "+optional",
+		"completionTime": "+optional\n+nullable",
 	}
 }
 
@@ -29,6 +60,7 @@ func (VirtualMachineSnapshotStatus) SwaggerDoc() map[string]string {
 		"conditions":                        "+optional",
 		"indications":                       "+optional\n+listType=set",
 		"snapshotVolumes":                   "+optional",
+		"hookResults":                       "+optional\n+listType=atomic",
 	}
 }
 
@@ -139,9 +171,11 @@ func (VirtualMachineRestore) SwaggerDoc() map[string]string {
 
 func (VirtualMachineRestoreSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":        "VirtualMachineRestoreSpec is the spec for a VirtualMachineRestoreresource",
-		"target":  "initially only VirtualMachine type supported",
-		"patches": "If the target for the restore does not exist, it will be created. Patches holds JSON patches that would be\napplied to the target manifest before it's created. Patches should fit the target's Kind.\n\nExample for a patch: {\"op\": \"replace\", \"path\": \"/metadata/name\", \"value\": \"new-vm-name\"}\n\n+optional\n+listType=atomic",
+		"":                "VirtualMachineRestoreSpec is the spec for a VirtualMachineRestoreresource",
+		"target":          "initially only VirtualMachine type supported",
+		"patches":         "If the target for the restore does not exist, it will be created. Patches holds JSON patches that would be\napplied to the target manifest before it's created. Patches should fit the target's Kind.\n\nExample for a patch: {\"op\": \"replace\", \"path\": \"/metadata/name\", \"value\": \"new-vm-name\"}\n\n+optional\n+listType=atomic",
+		"includedVolumes": "IncludedVolumes limits the restore to the listed volumes of the snapshot,\nthe other volumes of the target are left untouched.\nAll the volumes of the snapshot are restored when empty.\n+optional\n+listType=set",
+		"excludedVolumes": "ExcludedVolumes are volumes of the snapshot which are not restored,\nthe target keeps its current volumes instead\n+optional\n+listType=set",
 	}
 }
 
@@ -168,3 +202,112 @@ func (VirtualMachineRestoreList) SwaggerDoc() map[string]string {
 		"": "VirtualMachineRestoreList is a list of VirtualMachineRestore resources\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
 	}
 }
+
+func (VirtualMachineSnapshotGroup) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "VirtualMachineSnapshotGroup defines the operation of snapshotting several VMs at the same point in time\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"status": "+optional",
+	}
+}
+
+func (VirtualMachineSnapshotGroupSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "VirtualMachineSnapshotGroupSpec is the spec for a VirtualMachineSnapshotGroup resource",
+		"selector":        "Selector selects the VirtualMachines in the namespace of the group.\nThe selected VMs are frozen together and all their volumes are\nsnapshotted before any of them is thawed again.",
+		"deletionPolicy":  "+optional",
+		"failureDeadline": "This time represents the number of seconds we permit freezing\nall VMs of the group and taking all their snapshots. The deadline\nis shared by the VirtualMachineSnapshots of the group, in case we\npass it we mark the group as failed.\nDefaults to DefaultFailureDeadline - 5min\n+optional",
+	}
+}
+
+func (VirtualMachineSnapshotGroupStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                        "VirtualMachineSnapshotGroupStatus is the status for a VirtualMachineSnapshotGroup resource",
+		"creationTime":            "+optional\n+nullable",
+		"phase":                   "+optional",
+		"readyToUse":              "+optional",
+		"error":                   "+optional",
+		"conditions":              "+optional\n+listType=atomic",
+		"virtualMachineSnapshots": "VirtualMachineSnapshots lists the snapshot of every VM selected when the group was created\n+optional\n+listType=atomic",
+	}
+}
+
+func (VirtualMachineSnapshotGroupMember) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineSnapshotGroupMember is the snapshot of a single VM of a VirtualMachineSnapshotGroup",
+	}
+}
+
+func (VirtualMachineSnapshotGroupList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineSnapshotGroupList is a list of VirtualMachineSnapshotGroup resources\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+	}
+}
+
+func (VirtualMachineRestoreGroup) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "VirtualMachineRestoreGroup defines the operation of restoring all VMs of a VirtualMachineSnapshotGroup\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"status": "+optional",
+	}
+}
+
+func (VirtualMachineRestoreGroupSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                                "VirtualMachineRestoreGroupSpec is the spec for a VirtualMachineRestoreGroup resource",
+		"virtualMachineSnapshotGroupName": "The VMs of the group are restored to the VMs they were taken from,\nthe restore only starts once none of them is running.",
+	}
+}
+
+func (VirtualMachineRestoreGroupStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                       "VirtualMachineRestoreGroupStatus is the status for a VirtualMachineRestoreGroup resource",
+		"restoreTime":            "+optional",
+		"complete":               "+optional",
+		"conditions":             "+optional\n+listType=atomic",
+		"virtualMachineRestores": "VirtualMachineRestores lists the restore of every VM of the group\n+optional\n+listType=atomic",
+	}
+}
+
+func (VirtualMachineRestoreGroupMember) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineRestoreGroupMember is the restore of a single VM of a VirtualMachineRestoreGroup",
+	}
+}
+
+func (VirtualMachineRestoreGroupList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineRestoreGroupList is a list of VirtualMachineRestoreGroup resources\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+	}
+}
+
+func (VirtualMachineSnapshotMount) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "VirtualMachineSnapshotMount defines the operation of exposing a single volume\nof a VirtualMachineSnapshot as a temporary PVC, optionally hotplugged read-only\ninto a running VM, to recover files from it\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"status": "+optional",
+	}
+}
+
+func (VirtualMachineSnapshotMountSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "VirtualMachineSnapshotMountSpec is the spec for a VirtualMachineSnapshotMount resource",
+		"volumeName":  "VolumeName is the name of the snapshotted volume to expose",
+		"target":      "Target is the running VirtualMachine the volume is hotplugged into as a read-only disk.\nIf omitted the volume is only exposed as a PVC, which can be inspected with virtctl guestfs.\n+optional",
+		"ttlDuration": "ttlDuration limits the lifetime of the mount\nAfter this duration has passed from counting from CreationTimestamp,\nthe volume is unplugged and the mount is deleted together with its PVC.\nDefaults to DefaultMountDurationTTL - 2h\n+optional",
+	}
+}
+
+func (VirtualMachineSnapshotMountStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                          "VirtualMachineSnapshotMountStatus is the status for a VirtualMachineSnapshotMount resource",
+		"phase":                     "+optional",
+		"persistentVolumeClaimName": "PersistentVolumeClaimName is the name of the temporary PVC restored from the volume snapshot\n+optional",
+		"ttlExpirationTime":         "The time at which the mount will be removed according to the specified TTL\nFormula is CreationTimestamp + TTL\n+optional",
+		"error":                     "+optional",
+		"conditions":                "+optional\n+listType=atomic",
+	}
+}
+
+func (VirtualMachineSnapshotMountList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineSnapshotMountList is a list of VirtualMachineSnapshotMount resources\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+	}
+}
@@ -32,6 +32,10 @@ func (c *FakeSnapshotV1alpha1) VirtualMachineRestores(namespace string) v1alpha1
 	return &FakeVirtualMachineRestores{c, namespace}
 }
 
+func (c *FakeSnapshotV1alpha1) VirtualMachineRestoreGroups(namespace string) v1alpha1.VirtualMachineRestoreGroupInterface {
+	return &FakeVirtualMachineRestoreGroups{c, namespace}
+}
+
 func (c *FakeSnapshotV1alpha1) VirtualMachineSnapshots(namespace string) v1alpha1.VirtualMachineSnapshotInterface {
 	return &FakeVirtualMachineSnapshots{c, namespace}
 }
@@ -40,6 +44,14 @@ func (c *FakeSnapshotV1alpha1) VirtualMachineSnapshotContents(namespace string)
 	return &FakeVirtualMachineSnapshotContents{c, namespace}
 }
 
+func (c *FakeSnapshotV1alpha1) VirtualMachineSnapshotGroups(namespace string) v1alpha1.VirtualMachineSnapshotGroupInterface {
+	return &FakeVirtualMachineSnapshotGroups{c, namespace}
+}
+
+func (c *FakeSnapshotV1alpha1) VirtualMachineSnapshotMounts(namespace string) v1alpha1.VirtualMachineSnapshotMountInterface {
+	return &FakeVirtualMachineSnapshotMounts{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeSnapshotV1alpha1) RESTClient() rest.Interface {
@@ -20,6 +20,12 @@ package v1alpha1
 
 type VirtualMachineRestoreExpansion interface{}
 
+type VirtualMachineRestoreGroupExpansion interface{}
+
 type VirtualMachineSnapshotExpansion interface{}
 
 type VirtualMachineSnapshotContentExpansion interface{}
+
+type VirtualMachineSnapshotGroupExpansion interface{}
+
+type VirtualMachineSnapshotMountExpansion interface{}
@@ -27,8 +27,11 @@ import (
 type SnapshotV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	VirtualMachineRestoresGetter
+	VirtualMachineRestoreGroupsGetter
 	VirtualMachineSnapshotsGetter
 	VirtualMachineSnapshotContentsGetter
+	VirtualMachineSnapshotGroupsGetter
+	VirtualMachineSnapshotMountsGetter
 }
 
 // SnapshotV1alpha1Client is used to interact with features provided by the snapshot.kubevirt.io group.
@@ -40,6 +43,10 @@ func (c *SnapshotV1alpha1Client) VirtualMachineRestores(namespace string) Virtua
 	return newVirtualMachineRestores(c, namespace)
 }
 
+func (c *SnapshotV1alpha1Client) VirtualMachineRestoreGroups(namespace string) VirtualMachineRestoreGroupInterface {
+	return newVirtualMachineRestoreGroups(c, namespace)
+}
+
 func (c *SnapshotV1alpha1Client) VirtualMachineSnapshots(namespace string) VirtualMachineSnapshotInterface {
 	return newVirtualMachineSnapshots(c, namespace)
 }
@@ -48,6 +55,14 @@ func (c *SnapshotV1alpha1Client) VirtualMachineSnapshotContents(namespace string
 	return newVirtualMachineSnapshotContents(c, namespace)
 }
 
+func (c *SnapshotV1alpha1Client) VirtualMachineSnapshotGroups(namespace string) VirtualMachineSnapshotGroupInterface {
+	return newVirtualMachineSnapshotGroups(c, namespace)
+}
+
+func (c *SnapshotV1alpha1Client) VirtualMachineSnapshotMounts(namespace string) VirtualMachineSnapshotMountInterface {
+	return newVirtualMachineSnapshotMounts(c, namespace)
+}
+
 // NewForConfig creates a new SnapshotV1alpha1Client for the given config.
 func NewForConfig(c *rest.Config) (*SnapshotV1alpha1Client, error) {
 	config := *c
@@ -205,6 +205,36 @@ func (_mr *_MockKubevirtClientRecorder) VirtualMachineRestore(arg0 interface{})
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineRestore", arg0)
 }
 
+func (_m *MockKubevirtClient) VirtualMachineSnapshotGroup(namespace string) v1alpha113.VirtualMachineSnapshotGroupInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineSnapshotGroup", namespace)
+	ret0, _ := ret[0].(v1alpha113.VirtualMachineSnapshotGroupInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineSnapshotGroup(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineSnapshotGroup", arg0)
+}
+
+func (_m *MockKubevirtClient) VirtualMachineRestoreGroup(namespace string) v1alpha113.VirtualMachineRestoreGroupInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineRestoreGroup", namespace)
+	ret0, _ := ret[0].(v1alpha113.VirtualMachineRestoreGroupInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineRestoreGroup(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineRestoreGroup", arg0)
+}
+
+func (_m *MockKubevirtClient) VirtualMachineSnapshotMount(namespace string) v1alpha113.VirtualMachineSnapshotMountInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineSnapshotMount", namespace)
+	ret0, _ := ret[0].(v1alpha113.VirtualMachineSnapshotMountInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineSnapshotMount(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineSnapshotMount", arg0)
+}
+
 func (_m *MockKubevirtClient) VirtualMachineExport(namespace string) v1alpha110.VirtualMachineExportInterface {
 	ret := _m.ctrl.Call(_m, "VirtualMachineExport", namespace)
 	ret0, _ := ret[0].(v1alpha110.VirtualMachineExportInterface)
@@ -1203,6 +1233,27 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) SoftReboot(arg0, arg1 i
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "SoftReboot", arg0, arg1)
 }
 
+func (_m *MockVirtualMachineInstanceInterface) FSTrim(ctx context.Context, name string) error {
+	ret := _m.ctrl.Call(_m, "FSTrim", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) FSTrim(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "FSTrim", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) GuestExec(ctx context.Context, name string, options *v120.GuestExecOptions) (*v120.GuestExecResult, error) {
+	ret := _m.ctrl.Call(_m, "GuestExec", ctx, name, options)
+	ret0, _ := ret[0].(*v120.GuestExecResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) GuestExec(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GuestExec", arg0, arg1, arg2)
+}
+
 func (_m *MockVirtualMachineInstanceInterface) GuestOsInfo(ctx context.Context, name string) (v120.VirtualMachineInstanceGuestAgentInfo, error) {
 	ret := _m.ctrl.Call(_m, "GuestOsInfo", ctx, name)
 	ret0, _ := ret[0].(v120.VirtualMachineInstanceGuestAgentInfo)
@@ -1256,6 +1307,46 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) RemoveVolume(arg0, arg1
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveVolume", arg0, arg1, arg2)
 }
 
+func (_m *MockVirtualMachineInstanceInterface) InsertMedia(ctx context.Context, name string, insertMediaOptions *v120.InsertMediaOptions) error {
+	ret := _m.ctrl.Call(_m, "InsertMedia", ctx, name, insertMediaOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) InsertMedia(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "InsertMedia", arg0, arg1, arg2)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) EjectMedia(ctx context.Context, name string, ejectMediaOptions *v120.EjectMediaOptions) error {
+	ret := _m.ctrl.Call(_m, "EjectMedia", ctx, name, ejectMediaOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) EjectMedia(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "EjectMedia", arg0, arg1, arg2)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) AddFilesystem(ctx context.Context, name string, addFilesystemOptions *v120.AddFilesystemOptions) error {
+	ret := _m.ctrl.Call(_m, "AddFilesystem", ctx, name, addFilesystemOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) AddFilesystem(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "AddFilesystem", arg0, arg1, arg2)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) RemoveFilesystem(ctx context.Context, name string, removeFilesystemOptions *v120.RemoveFilesystemOptions) error {
+	ret := _m.ctrl.Call(_m, "RemoveFilesystem", ctx, name, removeFilesystemOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) RemoveFilesystem(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveFilesystem", arg0, arg1, arg2)
+}
+
 func (_m *MockVirtualMachineInstanceInterface) VSOCK(name string, options *v120.VSOCKOptions) (StreamInterface, error) {
 	ret := _m.ctrl.Call(_m, "VSOCK", name, options)
 	ret0, _ := ret[0].(StreamInterface)
@@ -1739,6 +1830,46 @@ func (_mr *_MockVirtualMachineInterfaceRecorder) RemoveVolume(arg0, arg1, arg2 i
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveVolume", arg0, arg1, arg2)
 }
 
+func (_m *MockVirtualMachineInterface) InsertMedia(ctx context.Context, name string, insertMediaOptions *v120.InsertMediaOptions) error {
+	ret := _m.ctrl.Call(_m, "InsertMedia", ctx, name, insertMediaOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) InsertMedia(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "InsertMedia", arg0, arg1, arg2)
+}
+
+func (_m *MockVirtualMachineInterface) EjectMedia(ctx context.Context, name string, ejectMediaOptions *v120.EjectMediaOptions) error {
+	ret := _m.ctrl.Call(_m, "EjectMedia", ctx, name, ejectMediaOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) EjectMedia(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "EjectMedia", arg0, arg1, arg2)
+}
+
+func (_m *MockVirtualMachineInterface) AddFilesystem(ctx context.Context, name string, addFilesystemOptions *v120.AddFilesystemOptions) error {
+	ret := _m.ctrl.Call(_m, "AddFilesystem", ctx, name, addFilesystemOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) AddFilesystem(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "AddFilesystem", arg0, arg1, arg2)
+}
+
+func (_m *MockVirtualMachineInterface) RemoveFilesystem(ctx context.Context, name string, removeFilesystemOptions *v120.RemoveFilesystemOptions) error {
+	ret := _m.ctrl.Call(_m, "RemoveFilesystem", ctx, name, removeFilesystemOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) RemoveFilesystem(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveFilesystem", arg0, arg1, arg2)
+}
+
 func (_m *MockVirtualMachineInterface) PortForward(name string, port int, protocol string) (StreamInterface, error) {
 	ret := _m.ctrl.Call(_m, "PortForward", name, port, protocol)
 	ret0, _ := ret[0].(StreamInterface)
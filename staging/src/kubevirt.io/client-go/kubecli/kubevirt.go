@@ -71,6 +71,9 @@ type KubevirtClient interface {
 	VirtualMachineSnapshot(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotInterface
 	VirtualMachineSnapshotContent(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotContentInterface
 	VirtualMachineRestore(namespace string) vmsnapshotv1alpha1.VirtualMachineRestoreInterface
+	VirtualMachineSnapshotGroup(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotGroupInterface
+	VirtualMachineRestoreGroup(namespace string) vmsnapshotv1alpha1.VirtualMachineRestoreGroupInterface
+	VirtualMachineSnapshotMount(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotMountInterface
 	VirtualMachineExport(namespace string) vmexportv1alpha1.VirtualMachineExportInterface
 	VirtualMachineInstancetype(namespace string) instancetypev1beta1.VirtualMachineInstancetypeInterface
 	VirtualMachineClusterInstancetype() instancetypev1beta1.VirtualMachineClusterInstancetypeInterface
@@ -174,6 +177,18 @@ func (k kubevirt) VirtualMachineRestore(namespace string) vmsnapshotv1alpha1.Vir
 	return k.generatedKubeVirtClient.SnapshotV1alpha1().VirtualMachineRestores(namespace)
 }
 
+func (k kubevirt) VirtualMachineSnapshotGroup(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotGroupInterface {
+	return k.generatedKubeVirtClient.SnapshotV1alpha1().VirtualMachineSnapshotGroups(namespace)
+}
+
+func (k kubevirt) VirtualMachineRestoreGroup(namespace string) vmsnapshotv1alpha1.VirtualMachineRestoreGroupInterface {
+	return k.generatedKubeVirtClient.SnapshotV1alpha1().VirtualMachineRestoreGroups(namespace)
+}
+
+func (k kubevirt) VirtualMachineSnapshotMount(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotMountInterface {
+	return k.generatedKubeVirtClient.SnapshotV1alpha1().VirtualMachineSnapshotMounts(namespace)
+}
+
 func (k kubevirt) VirtualMachineExport(namespace string) vmexportv1alpha1.VirtualMachineExportInterface {
 	return k.generatedKubeVirtClient.ExportV1alpha1().VirtualMachineExports(namespace)
 }
@@ -246,11 +261,17 @@ type VirtualMachineInstanceInterface interface {
 	Freeze(ctx context.Context, name string, unfreezeTimeout time.Duration) error
 	Unfreeze(ctx context.Context, name string) error
 	SoftReboot(ctx context.Context, name string) error
+	FSTrim(ctx context.Context, name string) error
+	GuestExec(ctx context.Context, name string, options *v1.GuestExecOptions) (*v1.GuestExecResult, error)
 	GuestOsInfo(ctx context.Context, name string) (v1.VirtualMachineInstanceGuestAgentInfo, error)
 	UserList(ctx context.Context, name string) (v1.VirtualMachineInstanceGuestOSUserList, error)
 	FilesystemList(ctx context.Context, name string) (v1.VirtualMachineInstanceFileSystemList, error)
 	AddVolume(ctx context.Context, name string, addVolumeOptions *v1.AddVolumeOptions) error
 	RemoveVolume(ctx context.Context, name string, removeVolumeOptions *v1.RemoveVolumeOptions) error
+	InsertMedia(ctx context.Context, name string, insertMediaOptions *v1.InsertMediaOptions) error
+	EjectMedia(ctx context.Context, name string, ejectMediaOptions *v1.EjectMediaOptions) error
+	AddFilesystem(ctx context.Context, name string, addFilesystemOptions *v1.AddFilesystemOptions) error
+	RemoveFilesystem(ctx context.Context, name string, removeFilesystemOptions *v1.RemoveFilesystemOptions) error
 	VSOCK(name string, options *v1.VSOCKOptions) (StreamInterface, error)
 	SEVFetchCertChain(name string) (v1.SEVPlatformInfo, error)
 	SEVQueryLaunchMeasurement(name string) (v1.SEVMeasurementInfo, error)
@@ -300,6 +321,10 @@ type VirtualMachineInterface interface {
 	Migrate(ctx context.Context, name string, migrateOptions *v1.MigrateOptions) error
 	AddVolume(ctx context.Context, name string, addVolumeOptions *v1.AddVolumeOptions) error
 	RemoveVolume(ctx context.Context, name string, removeVolumeOptions *v1.RemoveVolumeOptions) error
+	InsertMedia(ctx context.Context, name string, insertMediaOptions *v1.InsertMediaOptions) error
+	EjectMedia(ctx context.Context, name string, ejectMediaOptions *v1.EjectMediaOptions) error
+	AddFilesystem(ctx context.Context, name string, addFilesystemOptions *v1.AddFilesystemOptions) error
+	RemoveFilesystem(ctx context.Context, name string, removeFilesystemOptions *v1.RemoveFilesystemOptions) error
 	PortForward(name string, port int, protocol string) (StreamInterface, error)
 	MemoryDump(ctx context.Context, name string, memoryDumpRequest *v1.VirtualMachineMemoryDumpRequest) error
 	RemoveMemoryDump(ctx context.Context, name string) error
@@ -282,6 +282,54 @@ func (v *vm) RemoveVolume(ctx context.Context, name string, removeVolumeOptions
 	return v.restClient.Put().AbsPath(uri).Body([]byte(JSON)).Do(ctx).Error()
 }
 
+func (v *vm) InsertMedia(ctx context.Context, name string, insertMediaOptions *v1.InsertMediaOptions) error {
+	uri := fmt.Sprintf(vmSubresourceURLFmt, v1.ApiStorageVersion, v.namespace, name, "insertmedia")
+
+	JSON, err := json.Marshal(insertMediaOptions)
+
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().AbsPath(uri).Body([]byte(JSON)).Do(ctx).Error()
+}
+
+func (v *vm) EjectMedia(ctx context.Context, name string, ejectMediaOptions *v1.EjectMediaOptions) error {
+	uri := fmt.Sprintf(vmSubresourceURLFmt, v1.ApiStorageVersion, v.namespace, name, "ejectmedia")
+
+	JSON, err := json.Marshal(ejectMediaOptions)
+
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().AbsPath(uri).Body([]byte(JSON)).Do(ctx).Error()
+}
+
+func (v *vm) AddFilesystem(ctx context.Context, name string, addFilesystemOptions *v1.AddFilesystemOptions) error {
+	uri := fmt.Sprintf(vmSubresourceURLFmt, v1.ApiStorageVersion, v.namespace, name, "addfilesystem")
+
+	JSON, err := json.Marshal(addFilesystemOptions)
+
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().AbsPath(uri).Body([]byte(JSON)).Do(ctx).Error()
+}
+
+func (v *vm) RemoveFilesystem(ctx context.Context, name string, removeFilesystemOptions *v1.RemoveFilesystemOptions) error {
+	uri := fmt.Sprintf(vmSubresourceURLFmt, v1.ApiStorageVersion, v.namespace, name, "removefilesystem")
+
+	JSON, err := json.Marshal(removeFilesystemOptions)
+
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().AbsPath(uri).Body([]byte(JSON)).Do(ctx).Error()
+}
+
 func (v *vm) PortForward(name string, port int, protocol string) (StreamInterface, error) {
 	return asyncSubresourceHelper(v.config, v.resource, v.namespace, name, buildPortForwardResourcePath(port, protocol), url.Values{})
 }
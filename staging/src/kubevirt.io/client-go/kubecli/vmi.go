@@ -270,6 +270,30 @@ func (v *vmis) SoftReboot(ctx context.Context, name string) error {
 	return v.restClient.Put().AbsPath(uri).Do(ctx).Error()
 }
 
+func (v *vmis) FSTrim(ctx context.Context, name string) error {
+	log.Log.Infof("FSTrim VMI %s", name)
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "fstrim")
+	return v.restClient.Put().AbsPath(uri).Do(ctx).Error()
+}
+
+func (v *vmis) GuestExec(ctx context.Context, name string, options *v1.GuestExecOptions) (*v1.GuestExecResult, error) {
+	body, err := json.Marshal(options)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot Marshal to json: %s", err)
+	}
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "guestexec")
+	res, err := v.restClient.Put().AbsPath(uri).Body(body).Do(ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &v1.GuestExecResult{}
+	if err := json.Unmarshal(res, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (v *vmis) Pause(ctx context.Context, name string, pauseOptions *v1.PauseOptions) error {
 	body, err := json.Marshal(pauseOptions)
 	if err != nil {
@@ -493,6 +517,54 @@ func (v *vmis) RemoveVolume(ctx context.Context, name string, removeVolumeOption
 	return v.restClient.Put().AbsPath(uri).Body([]byte(JSON)).Do(ctx).Error()
 }
 
+func (v *vmis) InsertMedia(ctx context.Context, name string, insertMediaOptions *v1.InsertMediaOptions) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "insertmedia")
+
+	JSON, err := json.Marshal(insertMediaOptions)
+
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().AbsPath(uri).Body([]byte(JSON)).Do(ctx).Error()
+}
+
+func (v *vmis) EjectMedia(ctx context.Context, name string, ejectMediaOptions *v1.EjectMediaOptions) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "ejectmedia")
+
+	JSON, err := json.Marshal(ejectMediaOptions)
+
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().AbsPath(uri).Body([]byte(JSON)).Do(ctx).Error()
+}
+
+func (v *vmis) AddFilesystem(ctx context.Context, name string, addFilesystemOptions *v1.AddFilesystemOptions) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "addfilesystem")
+
+	JSON, err := json.Marshal(addFilesystemOptions)
+
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().AbsPath(uri).Body([]byte(JSON)).Do(ctx).Error()
+}
+
+func (v *vmis) RemoveFilesystem(ctx context.Context, name string, removeFilesystemOptions *v1.RemoveFilesystemOptions) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "removefilesystem")
+
+	JSON, err := json.Marshal(removeFilesystemOptions)
+
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().AbsPath(uri).Body([]byte(JSON)).Do(ctx).Error()
+}
+
 func (v *vmis) VSOCK(name string, options *v1.VSOCKOptions) (StreamInterface, error) {
 	if options == nil || options.TargetPort == 0 {
 		return nil, fmt.Errorf("target port is required but not provided")
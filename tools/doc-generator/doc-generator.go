@@ -154,6 +154,16 @@ func getMetricsNotIncludeInEndpointByDefault() metricList {
 			description: "Indication for a VirtualMachine that its eviction strategy is set to Live Migration but is not migratable.",
 			mType:       "Gauge",
 		},
+		{
+			name:        "kubevirt_vmi_filesystem_trimmed_bytes_total",
+			description: "The total amount of unused space returned to the storage by trimming the guest filesystem, in bytes.",
+			mType:       "Counter",
+		},
+		{
+			name:        "kubevirt_vmi_filesystem_trims_total",
+			description: "The total number of trims of the guest filesystems of the VMI. `result` can be one of the following: [`succeeded`, `failed`].",
+			mType:       "Counter",
+		},
 	}
 
 	for _, rule := range components.GetRecordingRules("") {
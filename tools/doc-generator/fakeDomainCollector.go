@@ -70,6 +70,15 @@ func (fc fakeDomainCollector) Collect(ch chan<- prometheus.Metric) {
 		Status: k6tv1.VirtualMachineInstanceStatus{
 			Phase:    k6tv1.Running,
 			NodeName: "test",
+			VolumeStatus: []k6tv1.VolumeStatus{
+				{
+					Name: "disk1",
+					GuestUsage: &k6tv1.VolumeGuestUsage{
+						UsedBytes:  10,
+						TotalBytes: 1000,
+					},
+				},
+			},
 		},
 	}
 	ps.Report("test", &vmi, &domainstats.VirtualMachineInstanceStats{DomainStats: &out, FsStats: fs})